@@ -346,3 +346,52 @@ var Password action.GTSAction = func(ctx context.Context) error {
 		"encrypted_password",
 	)
 }
+
+// ResetTwoFactor disables two-factor authentication for target
+// account, and removes their security keys, eg., when they've lost
+// access to their authenticator app / keys and recovery codes. They
+// can then enroll again if desired.
+var ResetTwoFactor action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	username := config.GetAdminAccountUsername()
+	if err := validate.Username(username); err != nil {
+		return err
+	}
+
+	account, err := state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		return err
+	}
+
+	user, err := state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := state.DB.DeleteWebAuthnCredentialsByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+
+	user.TwoFactorSecret = ""
+	user.TwoFactorEnabledAt = time.Time{}
+	user.TwoFactorBackups = nil
+	user.TwoFactorLastCounter = 0
+	return state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_secret",
+		"two_factor_enabled_at",
+		"two_factor_backups",
+		"two_factor_last_counter",
+	)
+}
@@ -49,6 +49,7 @@ var All action.GTSAction = func(ctx context.Context) error {
 	days := config.GetMediaRemoteCacheDays()
 
 	// Perform the actual pruning with logging.
+	prune.cleaner.Card().All(ctx, days)
 	prune.cleaner.Media().All(ctx, days)
 	prune.cleaner.Emoji().All(ctx, days)
 
@@ -31,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/cards"
 	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/metrics"
@@ -59,6 +60,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 
 	// Inherit memory limit if set from cgroup
 	_ "github.com/KimMachineGun/automemlimit"
@@ -153,6 +155,18 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	transportController := transport.NewController(&state, federatingDB, &federation.Clock{}, client)
 	federator := federation.NewFederator(&state, federatingDB, transportController, typeConverter, mediaManager)
 
+	// Add a task to the scheduler to retry
+	// queued outgoing federation deliveries.
+	// Frequency = 1 * minute
+	_ = state.Workers.Scheduler.AddRecurring(
+		"@deliveryqueue", // id
+		time.Time{},      // start
+		time.Minute,      // freq
+		func(ctx context.Context, _ time.Time) {
+			transportController.ProcessDeliveryQueue(ctx)
+		},
+	)
+
 	// Decide whether to create a noop email
 	// sender (won't send emails) or a real one.
 	var emailSender email.Sender
@@ -203,6 +217,8 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		mediaManager,
 		&state,
 		emailSender,
+		webpush.NewSender(client, &state),
+		cards.NewFetcher(client, &state, mediaManager),
 	)
 
 	// Set state client / federator asynchronous worker enqueue functions
@@ -218,6 +234,36 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Schedule tasks for publishing all existing scheduled statuses.
+	if err := processor.Status().ScheduleAll(ctx); err != nil {
+		return fmt.Errorf("error scheduling scheduled statuses: %w", err)
+	}
+
+	// Schedule tasks for all existing mute expiries.
+	if err := processor.Account().ScheduleMuteExpiries(ctx); err != nil {
+		return fmt.Errorf("error scheduling mute expiries: %w", err)
+	}
+
+	// Schedule processing of domain permission subscriptions.
+	if err := processor.Admin().ScheduleDomainPermissionSubscriptions(); err != nil {
+		return fmt.Errorf("error scheduling domain permission subscriptions: %w", err)
+	}
+
+	// Schedule periodic recalculation of trends.
+	if err := processor.Trends().ScheduleRefresh(); err != nil {
+		return fmt.Errorf("error scheduling trends refresh: %w", err)
+	}
+
+	// Schedule periodic writing of token last used times.
+	if err := processor.User().ScheduleTokenUsageFlush(); err != nil {
+		return fmt.Errorf("error scheduling token usage flush: %w", err)
+	}
+
+	// Schedule periodic emailing of notification digests.
+	if err := processor.Workers().ScheduleEmailDigests(); err != nil {
+		return fmt.Errorf("error scheduling email digests: %w", err)
+	}
+
 	/*
 		HTTP router initialization
 	*/
@@ -358,6 +404,10 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	sig := <-sigs // block until signal received
 	log.Infof(ctx, "received signal %s, shutting down", sig)
 
+	// Write out any token usage not
+	// yet flushed, before db is closed.
+	processor.User().FlushTokenUsage(ctx)
+
 	// close down all running services in order
 	if err := server.Stop(ctx); err != nil {
 		return fmt.Errorf("error closing gotosocial service: %s", err)
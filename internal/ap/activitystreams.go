@@ -77,6 +77,14 @@ const (
 	// See https://www.w3.org/TR/activitystreams-vocabulary/#microsyntaxes
 	// and https://www.w3.org/TR/activitystreams-vocabulary/#dfn-tag
 	TagHashtag = "Hashtag"
+
+	// FeaturedTags is Mastodon's actor property pointing to the
+	// collection of hashtags featured on the actor's profile. It
+	// is not in the vocab, so it needs its own json-ld context.
+	//
+	// See https://docs.joinmastodon.org/spec/activitypub/#as
+	FeaturedTags        = "featuredTags"
+	FeaturedTagsContext = "http://joinmastodon.org/ns#featuredTags"
 )
 
 // isActivity returns whether AS type name is of an Activity (NOT IntransitiveActivity).
@@ -166,6 +166,7 @@ type Accountable interface {
 	WithEndpoints
 	WithTag
 	WithPublished
+	WithUnknownProperties
 }
 
 // Statusable represents the minimum activitypub interface for representing a 'status'.
@@ -178,6 +179,7 @@ type Statusable interface {
 	WithName
 	WithInReplyTo
 	WithPublished
+	WithUpdated
 	WithURL
 	WithAttributedTo
 	WithTo
@@ -443,6 +445,12 @@ type WithFeatured interface {
 	SetTootFeatured(vocab.TootFeaturedProperty)
 }
 
+// WithUnknownProperties represents an Object with properties
+// not covered by the vocab, eg., Mastodon's toot:featuredTags.
+type WithUnknownProperties interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
 // WithMovedTo represents an Object with ActivityStreamsMovedToProperty.
 type WithMovedTo interface {
 	GetActivityStreamsMovedTo() vocab.ActivityStreamsMovedToProperty
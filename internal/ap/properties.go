@@ -352,6 +352,38 @@ func SetFeatured(with WithFeatured, featured *url.URL) {
 	featuredProp.SetIRI(featured)
 }
 
+// GetFeaturedTags returns the IRI contained in the featuredTags property of 'with'.
+// As this property isn't covered by the vocab, it is read from unknown properties.
+func GetFeaturedTags(with WithUnknownProperties) *url.URL {
+	var raw string
+
+	switch v := with.GetUnknownProperties()[FeaturedTags].(type) {
+	case string:
+		raw = v
+	case map[string]interface{}:
+		// Embedded collection,
+		// just take its id.
+		raw, _ = v["id"].(string)
+	}
+
+	if raw == "" {
+		return nil
+	}
+
+	featuredTags, err := url.Parse(raw)
+	if err != nil {
+		return nil
+	}
+
+	return featuredTags
+}
+
+// SetFeaturedTags sets the given IRI on the featuredTags property of 'with'.
+// As this property isn't covered by the vocab, it is set on unknown properties.
+func SetFeaturedTags(with WithUnknownProperties, featuredTags *url.URL) {
+	with.GetUnknownProperties()[FeaturedTags] = featuredTags.String()
+}
+
 // GetMovedTo returns the IRI contained in the movedTo property of 'with'.
 func GetMovedTo(with WithMovedTo) *url.URL {
 	movedToProp := with.GetActivityStreamsMovedTo()
@@ -408,6 +440,25 @@ func SetPublished(with WithPublished, published time.Time) {
 	publishProp.Set(published)
 }
 
+// GetUpdated returns the time contained in the Updated property of 'with'.
+func GetUpdated(with WithUpdated) time.Time {
+	updateProp := with.GetActivityStreamsUpdated()
+	if updateProp == nil || !updateProp.IsXMLSchemaDateTime() {
+		return time.Time{}
+	}
+	return updateProp.Get()
+}
+
+// SetUpdated sets the given time on the Updated property of 'with'.
+func SetUpdated(with WithUpdated, updated time.Time) {
+	updateProp := with.GetActivityStreamsUpdated()
+	if updateProp == nil {
+		updateProp = streams.NewActivityStreamsUpdatedProperty()
+		with.SetActivityStreamsUpdated(updateProp)
+	}
+	updateProp.Set(updated)
+}
+
 // GetEndTime returns the time contained in the EndTime property of 'with'.
 func GetEndTime(with WithEndTime) time.Time {
 	endTimeProp := with.GetActivityStreamsEndTime()
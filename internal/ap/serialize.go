@@ -36,7 +36,7 @@ import (
 //
 //   - OrderedCollection:       'orderedItems' property will always be made into an array.
 //   - OrderedCollectionPage:   'orderedItems' property will always be made into an array.
-//   - Any Accountable type:    'attachment' property will always be made into an array.
+//   - Any Accountable type:    'attachment' property will always be made into an array; 'featuredTags' context will be added.
 //   - Any Statusable type:     'attachment' property will always be made into an array; 'content' and 'contentMap' will be normalized.
 //   - Any Activityable type:   any 'object's set on an activity will be custom serialized as above.
 func Serialize(t vocab.Type) (m map[string]interface{}, e error) {
@@ -127,6 +127,10 @@ func serializeAccountable(t vocab.Type, includeContext bool) (map[string]interfa
 	NormalizeOutgoingAttachmentProp(accountable, data)
 	NormalizeOutgoingAlsoKnownAsProp(accountable, data)
 
+	if includeContext {
+		appendFeaturedTagsContext(data, data)
+	}
+
 	return data, nil
 }
 
@@ -182,5 +186,43 @@ func serializeActivityable(t vocab.Type, includeContext bool) (map[string]interf
 		return nil, err
 	}
 
+	if includeContext {
+		appendFeaturedTagsContext(data, data["object"])
+	}
+
 	return data, nil
 }
+
+// appendFeaturedTagsContext appends a json-ld term definition
+// for the 'featuredTags' property to the '@context' of rawJSON,
+// if the given object (which may be rawJSON itself) has one set.
+// This is needed as featuredTags is carried as an unknown property,
+// so it's not covered by the context that go-fed generates for us.
+func appendFeaturedTagsContext(rawJSON map[string]interface{}, object interface{}) {
+	objectJSON, ok := object.(map[string]interface{})
+	if !ok {
+		// Not an object.
+		return
+	}
+
+	if _, ok := objectJSON[FeaturedTags]; !ok {
+		// Nothing to do.
+		return
+	}
+
+	term := map[string]interface{}{
+		FeaturedTags: map[string]interface{}{
+			"@id":   FeaturedTagsContext,
+			"@type": "@id",
+		},
+	}
+
+	switch context := rawJSON["@context"].(type) {
+	case nil:
+		// No context to append to.
+	case []interface{}:
+		rawJSON["@context"] = append(context, term)
+	default:
+		rawJSON["@context"] = []interface{}{context, term}
+	}
+}
@@ -78,3 +78,25 @@ type SwaggerFeaturedCollection struct {
 	// example: 2
 	TotalItems int
 }
+
+// SwaggerFeaturedTagsCollection represents an ActivityPub Collection of Hashtags.
+// swagger:model swaggerFeaturedTagsCollection
+type SwaggerFeaturedTagsCollection struct {
+	// ActivityStreams JSON-LD context.
+	// A string or an array of strings, or more
+	// complex nested items.
+	// example: https://www.w3.org/ns/activitystreams
+	Context interface{} `json:"@context"`
+	// ActivityStreams ID.
+	// example: https://example.org/users/some_user/collections/tags
+	ID string `json:"id"`
+	// ActivityStreams type.
+	// example: Collection
+	Type string `json:"type"`
+	// List of Hashtag objects, each with
+	// `type`, `href` and `name` properties.
+	Items []interface{} `json:"items"`
+	// Number of items in this collection.
+	// example: 2
+	TotalItems int
+}
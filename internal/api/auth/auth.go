@@ -43,6 +43,12 @@ const (
 	AuthAccountDisabledPath = "/account_disabled"
 	// AuthCallbackPath is the API path for receiving callback tokens from external OIDC providers
 	AuthCallbackPath = "/callback"
+	// AuthTwoFactorPath is the API path for users with two-factor authentication enabled to enter their code, after entering their password
+	AuthTwoFactorPath = "/2fa"
+	// AuthWebAuthnPath is the API path for users to sign in with a WebAuthn credential, either passwordless or as a second factor
+	AuthWebAuthnPath = "/webauthn"
+	// AuthWebAuthnOptionsPath is the API path for getting WebAuthn options to sign in with
+	AuthWebAuthnOptionsPath = AuthWebAuthnPath + "/options"
 
 	/*
 		paths prefixed with 'oauth'
@@ -50,6 +56,8 @@ const (
 
 	// OauthTokenPath is the API path to use for granting token requests to users with valid credentials
 	OauthTokenPath = "/token" // #nosec G101 else we get a hardcoded credentials warning
+	// OauthRevokePath is the API path for clients to revoke tokens issued to them
+	OauthRevokePath = "/revoke"
 	// OauthAuthorizePath is the API path for authorization requests (eg., authorize this app to act on my behalf as a user)
 	OauthAuthorizePath = "/authorize"
 	// OauthFinalizePath is the API path for completing user registration with additional user details
@@ -64,6 +72,9 @@ const (
 	callbackStateParam   = "state"
 	callbackCodeParam    = "code"
 	sessionUserID        = "userid"
+	sessionTwoFactorUser = "twofactor_userid"
+	sessionTwoFactorFail = "twofactor_fails"
+	sessionWebAuthn      = "webauthn_challenge"
 	sessionClientID      = "client_id"
 	sessionRedirectURI   = "redirect_uri"
 	sessionForceLogin    = "force_login"
@@ -73,6 +84,12 @@ const (
 	sessionClientState   = "client_state"
 	sessionClaims        = "claims"
 	sessionAppID         = "app_id"
+
+	/*
+		assets
+	*/
+
+	jsWebAuthn = "/assets/dist/webauthn.js"
 )
 
 type Module struct {
@@ -97,11 +114,16 @@ func (m *Module) RouteAuth(attachHandler func(method string, path string, f ...g
 	attachHandler(http.MethodGet, AuthSignInPath, m.SignInGETHandler)
 	attachHandler(http.MethodPost, AuthSignInPath, m.SignInPOSTHandler)
 	attachHandler(http.MethodGet, AuthCallbackPath, m.CallbackGETHandler)
+	attachHandler(http.MethodGet, AuthTwoFactorPath, m.TwoFactorGETHandler)
+	attachHandler(http.MethodPost, AuthTwoFactorPath, m.TwoFactorPOSTHandler)
+	attachHandler(http.MethodGet, AuthWebAuthnOptionsPath, m.WebAuthnOptionsGETHandler)
+	attachHandler(http.MethodPost, AuthWebAuthnPath, m.WebAuthnPOSTHandler)
 }
 
 // RouteOauth routes all paths that should have an 'oauth' prefix
 func (m *Module) RouteOauth(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodPost, OauthTokenPath, m.TokenPOSTHandler)
+	attachHandler(http.MethodPost, OauthRevokePath, m.RevokePOSTHandler)
 	attachHandler(http.MethodGet, OauthAuthorizePath, m.AuthorizeGETHandler)
 	attachHandler(http.MethodPost, OauthAuthorizePath, m.AuthorizePOSTHandler)
 	attachHandler(http.MethodPost, OauthFinalizePath, m.FinalizePOSTHandler)
@@ -64,8 +64,10 @@ type AuthStandardTestSuite struct {
 }
 
 const (
-	sessionUserID   = "userid"
-	sessionClientID = "client_id"
+	sessionUserID        = "userid"
+	sessionTwoFactorUser = "twofactor_userid"
+	sessionTwoFactorFail = "twofactor_fails"
+	sessionClientID      = "client_id"
 )
 
 func (suite *AuthStandardTestSuite) SetupSuite() {
@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RevokeTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *RevokeTestSuite) revoke(form map[string][]string) (int, string) {
+	requestBody, w, err := testrig.CreateMultipartFormData("", "", form)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/revoke", requestBody.Bytes(), w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.RevokePOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, string(b)
+}
+
+func (suite *RevokeTestSuite) TestRevokeEmptyForm() {
+	code, body := suite.revoke(map[string][]string{})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: token was not set in the revoke request form: client_id was not set in the revoke request form: client_secret was not set in the revoke request form"}`, body)
+}
+
+func (suite *RevokeTestSuite) TestRevokeBadClientSecret() {
+	var (
+		client = suite.testClients["local_account_1"]
+		token  = suite.testTokens["local_account_1"]
+	)
+
+	code, body := suite.revoke(map[string][]string{
+		"client_id":     {client.ID},
+		"client_secret": {"not the secret"},
+		"token":         {token.Access},
+	})
+	suite.Equal(http.StatusUnauthorized, code)
+	suite.Equal(`{"error":"invalid_client","error_description":"Unauthorized: client authentication failed"}`, body)
+
+	// Token should still exist.
+	_, err := suite.db.GetTokenByAccess(context.Background(), token.Access)
+	suite.NoError(err)
+}
+
+func (suite *RevokeTestSuite) TestRevokeOK() {
+	var (
+		client = suite.testClients["local_account_1"]
+		token  = suite.testTokens["local_account_1"]
+	)
+
+	code, body := suite.revoke(map[string][]string{
+		"client_id":       {client.ID},
+		"client_secret":   {client.Secret},
+		"token":           {token.Access},
+		"token_type_hint": {"access_token"},
+	})
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{}`, body)
+
+	_, err := suite.db.GetTokenByAccess(context.Background(), token.Access)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Revoking again should be fine too.
+	code, body = suite.revoke(map[string][]string{
+		"client_id":     {client.ID},
+		"client_secret": {client.Secret},
+		"token":         {token.Access},
+	})
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{}`, body)
+}
+
+func TestRevokeTestSuite(t *testing.T) {
+	suite.Run(t, new(RevokeTestSuite))
+}
@@ -56,8 +56,9 @@ func (m *Module) SignInGETHandler(c *gin.Context) {
 		}
 
 		page := apiutil.WebPage{
-			Template: "sign-in.tmpl",
-			Instance: instance,
+			Template:   "sign-in.tmpl",
+			Instance:   instance,
+			Javascript: []string{jsWebAuthn},
 		}
 
 		apiutil.TemplateWebPage(c, page)
@@ -81,7 +82,8 @@ func (m *Module) SignInGETHandler(c *gin.Context) {
 
 // SignInPOSTHandler should be served at https://example.org/auth/sign_in.
 // The idea is to present a sign in page to the user, where they can enter their username and password.
-// The handler will then redirect to the auth handler served at /auth
+// The handler will then redirect to the auth handler served at /auth, or
+// to the two-factor page if the user has two-factor authentication enabled.
 func (m *Module) SignInPOSTHandler(c *gin.Context) {
 	s := sessions.Default(c)
 
@@ -100,6 +102,37 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	user, err := m.db.GetUserByID(c.Request.Context(), userid)
+	if err != nil {
+		err := fmt.Errorf("error getting user %s: %w", userid, err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	hasKeys, err := m.processor.User().WebAuthnRegistered(c.Request.Context(), user)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if user.TwoFactorEnabled() || hasKeys {
+		// Password is correct, but the user
+		// still needs to provide a second
+		// factor (code from their app, or
+		// security key) before they're
+		// properly signed in.
+		s.Delete(sessionUserID)
+		s.Set(sessionTwoFactorUser, userid)
+		if err := s.Save(); err != nil {
+			err := fmt.Errorf("error saving user id onto session: %s", err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+			return
+		}
+
+		c.Redirect(http.StatusFound, "/auth"+AuthTwoFactorPath)
+		return
+	}
+
 	s.Set(sessionUserID, userid)
 	if err := s.Save(); err != nil {
 		err := fmt.Errorf("error saving user id onto session: %s", err)
@@ -0,0 +1,161 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// twoFactorMaxFails is the number of incorrect codes
+// a user can enter before their session is cleared, and
+// they have to sign in again with their password.
+const twoFactorMaxFails = 5
+
+// twoFactor just wraps a form-submitted two-factor code,
+// which may also be one of the user's recovery codes.
+type twoFactor struct {
+	Code string `form:"code"`
+}
+
+// TwoFactorGETHandler should be served at https://example.org/auth/2fa.
+// Users with two-factor authentication enabled land here after entering
+// their password on the sign in page, to enter a code from their app,
+// or use one of their security keys (see WebAuthnPOSTHandler).
+// The form will then POST to the same page, handled by TwoFactorPOSTHandler.
+func (m *Module) TwoFactorGETHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.HTMLAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	s := sessions.Default(c)
+	userID, ok := s.Get(sessionTwoFactorUser).(string)
+	if !ok {
+		// Not signed in with password yet.
+		c.Redirect(http.StatusFound, "/auth"+AuthSignInPath)
+		return
+	}
+
+	user, err := m.db.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		m.clearSession(s)
+		err := fmt.Errorf("error getting user %s: %w", userID, err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	hasKeys, err := m.processor.User().WebAuthnRegistered(c.Request.Context(), user)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template:   "sign-in-2fa.tmpl",
+		Instance:   instance,
+		Javascript: []string{jsWebAuthn},
+		Extra: map[string]any{
+			"totp":     user.TwoFactorEnabled(),
+			"webauthn": hasKeys,
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+// TwoFactorPOSTHandler should be served at https://example.org/auth/2fa.
+// It checks the submitted two-factor code for the user who entered their
+// password on the sign in page. If the code is correct, the user is signed
+// in, and the handler redirects to the auth handler served at /auth.
+// After too many incorrect codes, the session is cleared, to stop a
+// code being brute forced by someone who only knows the password.
+func (m *Module) TwoFactorPOSTHandler(c *gin.Context) {
+	s := sessions.Default(c)
+
+	userID, ok := s.Get(sessionTwoFactorUser).(string)
+	if !ok {
+		m.clearSession(s)
+		err := fmt.Errorf("key %s was not found in session", sessionTwoFactorUser)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &twoFactor{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	user, err := m.db.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		m.clearSession(s)
+		err := fmt.Errorf("error getting user %s: %w", userID, err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := m.processor.User().TwoFactorCheck(c.Request.Context(), user, form.Code); errWithCode != nil {
+		fails, _ := s.Get(sessionTwoFactorFail).(int)
+		fails++
+
+		if fails >= twoFactorMaxFails {
+			m.clearSession(s)
+			err := fmt.Errorf("too many incorrect two-factor codes for user %s", userID)
+			apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, "too many incorrect two-factor codes, please sign in again"), m.processor.InstanceGetV1)
+			return
+		}
+
+		// don't clear session here, so the user can just press back and try again
+		// if they mistyped the code or it expired while they were typing it
+		s.Set(sessionTwoFactorFail, fails)
+		if err := s.Save(); err != nil {
+			err := fmt.Errorf("error saving two-factor fails onto session: %s", err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+			return
+		}
+
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// User is now
+	// properly signed in.
+	s.Delete(sessionTwoFactorUser)
+	s.Delete(sessionTwoFactorFail)
+	s.Set(sessionUserID, userID)
+	if err := s.Save(); err != nil {
+		err := fmt.Errorf("error saving user id onto session: %s", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+}
@@ -0,0 +1,139 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TwoFactorTestSuite struct {
+	AuthStandardTestSuite
+}
+
+// enableTwoFactor enables two-factor
+// authentication for the given user.
+func (suite *TwoFactorTestSuite) enableTwoFactor(user *gtsmodel.User) {
+	secret, err := totp.NewSecret()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user.TwoFactorSecret = secret
+	user.TwoFactorEnabledAt = time.Now()
+	if err := suite.db.UpdateUser(
+		context.Background(), user,
+		"two_factor_secret",
+		"two_factor_enabled_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+// postCode posts the given code to the two-factor page, with
+// the given values set on the session beforehand, returning
+// the response code and the session afterwards.
+func (suite *TwoFactorTestSuite) postCode(code string, values map[string]any) (int, sessions.Session) {
+	requestBody, w, err := testrig.CreateMultipartFormData("", "", map[string][]string{
+		"code": {code},
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx, recorder := suite.newContext(http.MethodPost, "auth/2fa", requestBody.Bytes(), w.FormDataContentType())
+
+	s := sessions.Default(ctx)
+	for k, v := range values {
+		s.Set(k, v)
+	}
+
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+
+	return recorder.Code, s
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorOK() {
+	user := suite.testUsers["local_account_1"]
+	suite.enableTwoFactor(user)
+
+	code, err := totp.Code(user.TwoFactorSecret, time.Now())
+	suite.NoError(err)
+
+	status, s := suite.postCode(code, map[string]any{
+		sessionTwoFactorUser: user.ID,
+		sessionTwoFactorFail: 2,
+	})
+	suite.Equal(http.StatusFound, status)
+
+	// User is signed in, with
+	// two-factor state cleared.
+	suite.Equal(user.ID, s.Get(sessionUserID))
+	suite.Nil(s.Get(sessionTwoFactorUser))
+	suite.Nil(s.Get(sessionTwoFactorFail))
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorLockout() {
+	user := suite.testUsers["local_account_1"]
+	suite.enableTwoFactor(user)
+
+	// Carry the session values over
+	// from one attempt to the next,
+	// as the session cookie would.
+	values := map[string]any{
+		sessionTwoFactorUser: user.ID,
+	}
+
+	for i := 1; i < 5; i++ {
+		status, s := suite.postCode("000000", values)
+		suite.Equal(http.StatusUnauthorized, status)
+
+		// Still allowed to try again.
+		suite.Equal(user.ID, s.Get(sessionTwoFactorUser))
+		suite.Equal(i, s.Get(sessionTwoFactorFail))
+		values[sessionTwoFactorFail] = i
+	}
+
+	// Fifth incorrect code clears the session.
+	status, s := suite.postCode("000000", values)
+	suite.Equal(http.StatusUnauthorized, status)
+	suite.Nil(s.Get(sessionTwoFactorUser))
+	suite.Nil(s.Get(sessionTwoFactorFail))
+	suite.Nil(s.Get(sessionUserID))
+
+	// So even the correct code is now refused,
+	// until the user signs in with password again.
+	code, err := totp.Code(user.TwoFactorSecret, time.Now())
+	suite.NoError(err)
+
+	status, s = suite.postCode(code, nil)
+	suite.Equal(http.StatusBadRequest, status)
+	suite.Nil(s.Get(sessionUserID))
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorTestSuite))
+}
@@ -0,0 +1,196 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+)
+
+// webAuthnSignIn wraps the form-submitted, base64url
+// encoded response of navigator.credentials.get().
+type webAuthnSignIn struct {
+	CredentialID      string `form:"credential_id"`
+	ClientDataJSON    string `form:"client_data_json"`
+	AuthenticatorData string `form:"authenticator_data"`
+	Signature         string `form:"signature"`
+}
+
+// WebAuthnOptionsGETHandler should be served at https://example.org/auth/webauthn/options.
+// It returns options for the browser to pass to navigator.credentials.get(), storing
+// the challenge in the session. If the user already entered their password on the sign
+// in page, their credentials are requested as a second factor, else any passkey can
+// be used to sign in without a password.
+func (m *Module) WebAuthnOptionsGETHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if config.GetOIDCEnabled() {
+		// Sign in is handled by the idp.
+		err := errors.New("webauthn sign in not available when oidc is enabled")
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotFound(err), m.processor.InstanceGetV1)
+		return
+	}
+
+	s := sessions.Default(c)
+
+	user, errWithCode := m.twoFactorUser(c, s)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	options, errWithCode := m.processor.User().WebAuthnSignInOptions(c.Request.Context(), user)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	s.Set(sessionWebAuthn, base64.RawURLEncoding.EncodeToString(options.Challenge))
+	if err := s.Save(); err != nil {
+		err := fmt.Errorf("error saving webauthn challenge onto session: %s", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, options)
+}
+
+// WebAuthnPOSTHandler should be served at https://example.org/auth/webauthn.
+// It checks the submitted WebAuthn assertion against the challenge from
+// WebAuthnOptionsGETHandler. If valid, the user is signed in, and the handler
+// redirects to the auth handler served at /auth.
+func (m *Module) WebAuthnPOSTHandler(c *gin.Context) {
+	if config.GetOIDCEnabled() {
+		// Sign in is handled by the idp.
+		err := errors.New("webauthn sign in not available when oidc is enabled")
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotFound(err), m.processor.InstanceGetV1)
+		return
+	}
+
+	s := sessions.Default(c)
+
+	// Challenges are single use, so remove it from the
+	// session now, regardless of how the attempt goes.
+	encodedChallenge, ok := s.Get(sessionWebAuthn).(string)
+	if !ok {
+		err := fmt.Errorf("key %s was not found in session", sessionWebAuthn)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+	s.Delete(sessionWebAuthn)
+
+	challenge, err := webauthn.DecodeBase64URL(encodedChallenge)
+	if err != nil {
+		m.clearSession(s)
+		err := fmt.Errorf("error decoding webauthn challenge from session: %w", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &webAuthnSignIn{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	var decoded [4][]byte
+	for i, value := range []string{
+		form.CredentialID,
+		form.ClientDataJSON,
+		form.AuthenticatorData,
+		form.Signature,
+	} {
+		decoded[i], err = webauthn.DecodeBase64URL(value)
+		if err != nil || len(decoded[i]) == 0 {
+			err := fmt.Errorf("invalid or missing webauthn form value: %w", err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+			return
+		}
+	}
+
+	user, errWithCode := m.twoFactorUser(c, s)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	user, errWithCode = m.processor.User().WebAuthnSignIn(
+		c.Request.Context(),
+		user,
+		challenge,
+		decoded[0],
+		decoded[1],
+		decoded[2],
+		decoded[3],
+	)
+	if errWithCode != nil {
+		// Save session with the used challenge
+		// removed, but otherwise leave it as
+		// is, so the user can try again.
+		if err := s.Save(); err != nil {
+			log.Errorf(c.Request.Context(), "error saving session: %v", err)
+		}
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// User is now
+	// properly signed in.
+	s.Delete(sessionTwoFactorUser)
+	s.Set(sessionUserID, user.ID)
+	if err := s.Save(); err != nil {
+		err := fmt.Errorf("error saving user id onto session: %s", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+}
+
+// twoFactorUser returns the user who has entered their password
+// but not yet their second factor in this session, if any.
+func (m *Module) twoFactorUser(c *gin.Context, s sessions.Session) (*gtsmodel.User, gtserror.WithCode) {
+	userID, ok := s.Get(sessionTwoFactorUser).(string)
+	if !ok {
+		return nil, nil
+	}
+
+	user, err := m.db.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		m.clearSession(s)
+		err := fmt.Errorf("error getting user %s: %w", userID, err)
+		return nil, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice)
+	}
+
+	return user, nil
+}
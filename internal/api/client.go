@@ -26,23 +26,35 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/apps"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/conversations"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/customemojis"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/directory"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
-	filter "github.com/superseriousbusiness/gotosocial/internal/api/client/filters"
+	filtersV1 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v1"
+	filtersV2 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v2"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/followedtags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequests"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/invites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/lists"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/markers"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/mutes"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notifications"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/polls"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/scheduledstatuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/suggestions"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tokens"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/trends"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
@@ -54,29 +66,41 @@ type Client struct {
 	processor *processing.Processor
 	db        db.DB
 
-	accounts       *accounts.Module       // api/v1/accounts
-	admin          *admin.Module          // api/v1/admin
-	apps           *apps.Module           // api/v1/apps
-	blocks         *blocks.Module         // api/v1/blocks
-	bookmarks      *bookmarks.Module      // api/v1/bookmarks
-	customEmojis   *customemojis.Module   // api/v1/custom_emojis
-	favourites     *favourites.Module     // api/v1/favourites
-	featuredTags   *featuredtags.Module   // api/v1/featured_tags
-	filters        *filter.Module         // api/v1/filters
-	followRequests *followrequests.Module // api/v1/follow_requests
-	instance       *instance.Module       // api/v1/instance
-	lists          *lists.Module          // api/v1/lists
-	markers        *markers.Module        // api/v1/markers
-	media          *media.Module          // api/v1/media, api/v2/media
-	notifications  *notifications.Module  // api/v1/notifications
-	polls          *polls.Module          // api/v1/polls
-	preferences    *preferences.Module    // api/v1/preferences
-	reports        *reports.Module        // api/v1/reports
-	search         *search.Module         // api/v1/search, api/v2/search
-	statuses       *statuses.Module       // api/v1/statuses
-	streaming      *streaming.Module      // api/v1/streaming
-	timelines      *timelines.Module      // api/v1/timelines
-	user           *user.Module           // api/v1/user
+	accounts          *accounts.Module          // api/v1/accounts
+	admin             *admin.Module             // api/v1/admin
+	apps              *apps.Module              // api/v1/apps
+	blocks            *blocks.Module            // api/v1/blocks
+	bookmarks         *bookmarks.Module         // api/v1/bookmarks
+	conversations     *conversations.Module     // api/v1/conversations
+	customEmojis      *customemojis.Module      // api/v1/custom_emojis
+	directory         *directory.Module         // api/v1/directory
+	favourites        *favourites.Module        // api/v1/favourites
+	featuredTags      *featuredtags.Module      // api/v1/featured_tags
+	filtersV1         *filtersV1.Module         // api/v1/filters
+	filtersV2         *filtersV2.Module         // api/v2/filters
+	followedTags      *followedtags.Module      // api/v1/followed_tags
+	followRequests    *followrequests.Module    // api/v1/follow_requests
+	instance          *instance.Module          // api/v1/instance
+	invites           *invites.Module           // api/v1/invites
+	lists             *lists.Module             // api/v1/lists
+	markers           *markers.Module           // api/v1/markers
+	media             *media.Module             // api/v1/media, api/v2/media
+	mutes             *mutes.Module             // api/v1/mutes
+	notifications     *notifications.Module     // api/v1/notifications
+	polls             *polls.Module             // api/v1/polls
+	preferences       *preferences.Module       // api/v1/preferences
+	push              *push.Module              // api/v1/push
+	reports           *reports.Module           // api/v1/reports
+	scheduledStatuses *scheduledstatuses.Module // api/v1/scheduled_statuses
+	search            *search.Module            // api/v1/search, api/v2/search
+	statuses          *statuses.Module          // api/v1/statuses
+	streaming         *streaming.Module         // api/v1/streaming
+	suggestions       *suggestions.Module       // api/v1/suggestions, api/v2/suggestions
+	tags              *tags.Module              // api/v1/tags
+	timelines         *timelines.Module         // api/v1/timelines
+	tokens            *tokens.Module            // api/v1/tokens
+	trends            *trends.Module            // api/v1/trends
+	user              *user.Module              // api/v1/user
 }
 
 func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
@@ -86,7 +110,7 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	// attach non-global middlewares appropriate to the client api
 	apiGroup.Use(m...)
 	apiGroup.Use(
-		middleware.TokenCheck(c.db, c.processor.OAuthValidateBearerToken),
+		middleware.TokenCheck(c.db, c.processor.OAuthValidateBearerToken, c.processor.User().TokenUsed),
 		middleware.CacheControl(middleware.CacheControlConfig{
 			// Never cache client api responses.
 			Directives: []string{"no-store"},
@@ -101,23 +125,35 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.apps.Route(h)
 	c.blocks.Route(h)
 	c.bookmarks.Route(h)
+	c.conversations.Route(h)
 	c.customEmojis.Route(h)
+	c.directory.Route(h)
 	c.favourites.Route(h)
 	c.featuredTags.Route(h)
-	c.filters.Route(h)
+	c.filtersV1.Route(h)
+	c.filtersV2.Route(h)
+	c.followedTags.Route(h)
 	c.followRequests.Route(h)
 	c.instance.Route(h)
+	c.invites.Route(h)
 	c.lists.Route(h)
 	c.markers.Route(h)
 	c.media.Route(h)
+	c.mutes.Route(h)
 	c.notifications.Route(h)
 	c.polls.Route(h)
 	c.preferences.Route(h)
+	c.push.Route(h)
 	c.reports.Route(h)
+	c.scheduledStatuses.Route(h)
 	c.search.Route(h)
 	c.statuses.Route(h)
 	c.streaming.Route(h)
+	c.suggestions.Route(h)
+	c.tags.Route(h)
 	c.timelines.Route(h)
+	c.tokens.Route(h)
+	c.trends.Route(h)
 	c.user.Route(h)
 }
 
@@ -126,28 +162,40 @@ func NewClient(db db.DB, p *processing.Processor) *Client {
 		processor: p,
 		db:        db,
 
-		accounts:       accounts.New(p),
-		admin:          admin.New(p),
-		apps:           apps.New(p),
-		blocks:         blocks.New(p),
-		bookmarks:      bookmarks.New(p),
-		customEmojis:   customemojis.New(p),
-		favourites:     favourites.New(p),
-		featuredTags:   featuredtags.New(p),
-		filters:        filter.New(p),
-		followRequests: followrequests.New(p),
-		instance:       instance.New(p),
-		lists:          lists.New(p),
-		markers:        markers.New(p),
-		media:          media.New(p),
-		notifications:  notifications.New(p),
-		polls:          polls.New(p),
-		preferences:    preferences.New(p),
-		reports:        reports.New(p),
-		search:         search.New(p),
-		statuses:       statuses.New(p),
-		streaming:      streaming.New(p, time.Second*30, 4096),
-		timelines:      timelines.New(p),
-		user:           user.New(p),
+		accounts:          accounts.New(p),
+		admin:             admin.New(p),
+		apps:              apps.New(p),
+		blocks:            blocks.New(p),
+		bookmarks:         bookmarks.New(p),
+		conversations:     conversations.New(p),
+		customEmojis:      customemojis.New(p),
+		directory:         directory.New(p),
+		favourites:        favourites.New(p),
+		featuredTags:      featuredtags.New(p),
+		filtersV1:         filtersV1.New(p),
+		filtersV2:         filtersV2.New(p),
+		followedTags:      followedtags.New(p),
+		followRequests:    followrequests.New(p),
+		instance:          instance.New(p),
+		invites:           invites.New(p),
+		lists:             lists.New(p),
+		markers:           markers.New(p),
+		media:             media.New(p),
+		mutes:             mutes.New(p),
+		notifications:     notifications.New(p),
+		polls:             polls.New(p),
+		preferences:       preferences.New(p),
+		push:              push.New(p),
+		reports:           reports.New(p),
+		scheduledStatuses: scheduledstatuses.New(p),
+		search:            search.New(p),
+		statuses:          statuses.New(p),
+		streaming:         streaming.New(p, time.Second*30, 4096),
+		suggestions:       suggestions.New(p),
+		tags:              tags.New(p),
+		timelines:         timelines.New(p),
+		tokens:            tokens.New(p),
+		trends:            trends.New(p),
+		user:              user.New(p),
 	}
 }
@@ -118,7 +118,9 @@ func validateNormalizeCreateAccount(form *apimodel.AccountCreateRequest) error {
 		return errors.New("form was nil")
 	}
 
-	if !config.GetAccountsRegistrationOpen() {
+	if !config.GetAccountsRegistrationOpen() && form.InviteCode == "" {
+		// Registration is closed, but people
+		// may still sign up using an invite.
 		return errors.New("registration is not open for this server")
 	}
 
@@ -144,5 +146,9 @@ func validateNormalizeCreateAccount(form *apimodel.AccountCreateRequest) error {
 	}
 	form.Locale = locale
 
-	return validate.SignUpReason(form.Reason, config.GetAccountsReasonRequired())
+	// Sign ups using an invite don't need a reason,
+	// since they will not be reviewed by moderators.
+	reasonRequired := config.GetAccountsReasonRequired() && form.InviteCode == ""
+
+	return validate.SignUpReason(form.Reason, reasonRequired)
 }
@@ -53,13 +53,27 @@ import (
 //	-
 //		name: type
 //		in: formData
-//		description: Type of action to be taken, currently only supports `suspend`.
+//		description: >-
+//			Type of action to be taken. One of `disable`, `silence`, `sensitive`, or `suspend`,
+//			or one of their reversals `enable`, `unsilence`, `unsensitive`, or `unsuspend`.
+//			Suspensions can only be reversed within 30 days of the account being suspended.
 //		type: string
 //		required: true
 //	-
 //		name: text
 //		in: formData
-//		description: Optional text describing why this action was taken.
+//		description: >-
+//			Optional text describing why this action was taken.
+//			If the target account is local, this will be included
+//			in the email sent to the user about the action.
+//		type: string
+//	-
+//		name: report_id
+//		in: formData
+//		description: >-
+//			ID of a report targeting the account. If given, the
+//			report will be resolved, with the text of the action
+//			attached to the report as the action taken comment.
 //		type: string
 //
 //	security:
@@ -84,6 +98,10 @@ import (
 //				Conflict: There is already an admin action running that conflicts with this action.
 //				Check the error message in the response body for more information. This is a temporary
 //				error; it should be possible to process this action if you try again in a bit.
+//		'422':
+//			description: >-
+//				Unprocessable: the action can't be performed on this account, eg., because
+//				the account is already silenced, or because the account is not local.
 //		'500':
 //			description: internal server error
 func (m *Module) AccountActionPOSTHandler(c *gin.Context) {
@@ -111,6 +129,16 @@ func (m *Module) AccountActionPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	m.accountAction(c, authed, form)
+}
+
+// accountAction performs the given admin action
+// request on the account identified in the path.
+func (m *Module) accountAction(
+	c *gin.Context,
+	authed *oauth.Auth,
+	form *apimodel.AdminActionRequest,
+) {
 	targetAcctID := c.Param(IDKey)
 	if targetAcctID == "" {
 		err := errors.New("no account id specified")
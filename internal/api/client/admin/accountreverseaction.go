@@ -0,0 +1,237 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package admin
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountEnablePOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/enable adminAccountEnable
+//
+// Re-enable a local account that was previously disabled, allowing the user to log in again.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		required: true
+//		in: path
+//		description: ID of the account.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: OK
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: >-
+//				Conflict: There is already an admin action running that conflicts with this action.
+//		'422':
+//			description: unprocessable entity; the account is not local, or is not disabled
+//		'500':
+//			description: internal server error
+func (m *Module) AccountEnablePOSTHandler(c *gin.Context) {
+	m.accountReverseAction(c, gtsmodel.AdminActionReenable.String())
+}
+
+// AccountUnsilencePOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/unsilence adminAccountUnsilence
+//
+// Unsilence an account that was previously silenced, showing its posts as usual again.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		required: true
+//		in: path
+//		description: ID of the account.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: OK
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: >-
+//				Conflict: There is already an admin action running that conflicts with this action.
+//		'422':
+//			description: unprocessable entity; the account is not silenced
+//		'500':
+//			description: internal server error
+func (m *Module) AccountUnsilencePOSTHandler(c *gin.Context) {
+	m.accountReverseAction(c, gtsmodel.AdminActionUnsilence.String())
+}
+
+// AccountUnsensitivePOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/unsensitive adminAccountUnsensitive
+//
+// Unsensitize an account that was previously sensitized, no longer forcing its media to be marked as sensitive.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		required: true
+//		in: path
+//		description: ID of the account.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: OK
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: >-
+//				Conflict: There is already an admin action running that conflicts with this action.
+//		'422':
+//			description: unprocessable entity; the account is not sensitized
+//		'500':
+//			description: internal server error
+func (m *Module) AccountUnsensitivePOSTHandler(c *gin.Context) {
+	m.accountReverseAction(c, gtsmodel.AdminActionUnsensitize.String())
+}
+
+// AccountUnsuspendPOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/unsuspend adminAccountUnsuspend
+//
+// Unsuspend an account that was suspended within the last 30 days. Removed content is not restored, and local users will need to reset their password to log in again.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		required: true
+//		in: path
+//		description: ID of the account.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: OK
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: >-
+//				Conflict: There is already an admin action running that conflicts with this action.
+//		'422':
+//			description: unprocessable entity; the account is not suspended, was suspended too long ago, or was suspended by a domain block
+//		'500':
+//			description: internal server error
+func (m *Module) AccountUnsuspendPOSTHandler(c *gin.Context) {
+	m.accountReverseAction(c, gtsmodel.AdminActionUnsuspend.String())
+}
+
+// accountReverseAction reverses an earlier admin
+// action on the account identified in the path, by
+// performing the given reversing action type.
+func (m *Module) accountReverseAction(c *gin.Context, actionType string) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	m.accountAction(c, authed, &apimodel.AdminActionRequest{
+		Type: actionType,
+	})
+}
@@ -0,0 +1,444 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// AccountsGETHandler swagger:operation GET /api/v1/admin/accounts adminAccountsGetV1
+//
+// View + page through known accounts according to given filters.
+//
+// Filters are given as boolean flags, following the Mastodon v1 admin accounts API.
+// Only one origin flag (`local` or `remote`) and one status flag (`active`, `pending`,
+// `disabled`, `silenced` or `suspended`) may be set at a time.
+//
+// The next and previous queries can be parsed from the returned Link header.
+// Example:
+//
+// ```
+// <https://example.org/api/v1/admin/accounts?limit=80&max_id=01FC0SKA48HNSVR6YKZCQGS2V8&local=true>; rel="next", <https://example.org/api/v1/admin/accounts?limit=80&min_id=01FC0SKW5JK2Q4EVAV2B462YY0&local=true>; rel="prev"
+// ```
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: local
+//		type: boolean
+//		description: Filter for local accounts.
+//		default: false
+//		in: query
+//	-
+//		name: remote
+//		type: boolean
+//		description: Filter for remote accounts.
+//		default: false
+//		in: query
+//	-
+//		name: active
+//		type: boolean
+//		description: Filter for currently active accounts.
+//		default: false
+//		in: query
+//	-
+//		name: pending
+//		type: boolean
+//		description: Filter for currently pending accounts.
+//		default: false
+//		in: query
+//	-
+//		name: disabled
+//		type: boolean
+//		description: Filter for currently disabled accounts.
+//		default: false
+//		in: query
+//	-
+//		name: silenced
+//		type: boolean
+//		description: Filter for currently silenced accounts.
+//		default: false
+//		in: query
+//	-
+//		name: suspended
+//		type: boolean
+//		description: Filter for currently suspended accounts.
+//		default: false
+//		in: query
+//	-
+//		name: staff
+//		type: boolean
+//		description: Filter for accounts with admin or moderator permissions.
+//		default: false
+//		in: query
+//	-
+//		name: username
+//		type: string
+//		description: Search for usernames starting with the given string.
+//		in: query
+//	-
+//		name: display_name
+//		type: string
+//		description: Search for display names containing the given string.
+//		in: query
+//	-
+//		name: by_domain
+//		type: string
+//		description: Filter for accounts on the given domain.
+//		in: query
+//	-
+//		name: email
+//		type: string
+//		description: Search for local accounts with an email address containing the given string.
+//		in: query
+//	-
+//		name: ip
+//		type: string
+//		description: Search for local accounts that have signed up or signed in from the given IP address.
+//		in: query
+//	-
+//		name: max_id
+//		type: string
+//		description: >-
+//			Return only accounts *OLDER* than the given max ID.
+//			The account with the specified ID will not be included in the response.
+//		in: query
+//		required: false
+//	-
+//		name: since_id
+//		type: string
+//		description: >-
+//			Return only accounts *NEWER* than the given since ID.
+//			The account with the specified ID will not be included in the response.
+//		in: query
+//	-
+//		name: min_id
+//		type: string
+//		description: >-
+//			Return only accounts *IMMEDIATELY NEWER* than the given min ID.
+//			The account with the specified ID will not be included in the response.
+//		in: query
+//		required: false
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of accounts to return.
+//		default: 100
+//		minimum: 1
+//		maximum: 200
+//		in: query
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			headers:
+//				Link:
+//					type: string
+//					description: Links to the next and previous queries.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminAccountInfo"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AccountsGETHandler(c *gin.Context) {
+	m.accountsGET(c, 1, parseAccountsGETRequestV1)
+}
+
+// AccountsGETV2Handler swagger:operation GET /api/v2/admin/accounts adminAccountsGetV2
+//
+// View + page through known accounts according to given filters.
+//
+// The next and previous queries can be parsed from the returned Link header.
+// Example:
+//
+// ```
+// <https://example.org/api/v2/admin/accounts?limit=80&max_id=01FC0SKA48HNSVR6YKZCQGS2V8&origin=local>; rel="next", <https://example.org/api/v2/admin/accounts?limit=80&min_id=01FC0SKW5JK2Q4EVAV2B462YY0&origin=local>; rel="prev"
+// ```
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: origin
+//		type: string
+//		description: Filter for `local` or `remote` accounts.
+//		in: query
+//	-
+//		name: status
+//		type: string
+//		description: Filter for `active`, `pending`, `disabled`, `silenced`, or `suspended` accounts.
+//		in: query
+//	-
+//		name: permissions
+//		type: string
+//		description: Filter for accounts with staff permissions (users that can manage reports).
+//		enum:
+//			- staff
+//		in: query
+//	-
+//		name: invited_by
+//		type: string
+//		description: Filter for local accounts invited by the given account ID.
+//		in: query
+//	-
+//		name: username
+//		type: string
+//		description: Search for usernames starting with the given string.
+//		in: query
+//	-
+//		name: display_name
+//		type: string
+//		description: Search for display names containing the given string.
+//		in: query
+//	-
+//		name: by_domain
+//		type: string
+//		description: Filter for accounts on the given domain.
+//		in: query
+//	-
+//		name: email
+//		type: string
+//		description: Search for local accounts with an email address containing the given string.
+//		in: query
+//	-
+//		name: ip
+//		type: string
+//		description: Search for local accounts that have signed up or signed in from the given IP address.
+//		in: query
+//	-
+//		name: max_id
+//		type: string
+//		description: >-
+//			Return only accounts *OLDER* than the given max ID.
+//			The account with the specified ID will not be included in the response.
+//		in: query
+//		required: false
+//	-
+//		name: since_id
+//		type: string
+//		description: >-
+//			Return only accounts *NEWER* than the given since ID.
+//			The account with the specified ID will not be included in the response.
+//		in: query
+//	-
+//		name: min_id
+//		type: string
+//		description: >-
+//			Return only accounts *IMMEDIATELY NEWER* than the given min ID.
+//			The account with the specified ID will not be included in the response.
+//		in: query
+//		required: false
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of accounts to return.
+//		default: 100
+//		minimum: 1
+//		maximum: 200
+//		in: query
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			headers:
+//				Link:
+//					type: string
+//					description: Links to the next and previous queries.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminAccountInfo"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AccountsGETV2Handler(c *gin.Context) {
+	m.accountsGET(c, 2, parseAccountsGETRequestV2)
+}
+
+func (m *Module) accountsGET(
+	c *gin.Context,
+	apiVersion int,
+	parseRequest func(*gin.Context) (*apimodel.AdminGetAccountsRequest, gtserror.WithCode),
+) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	request, errWithCode := parseRequest(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+	request.APIVersion = apiVersion
+
+	page, errWithCode := paging.ParseIDPage(c,
+		1,   // min limit
+		200, // max limit
+		100, // default limit
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Admin().AccountsGet(
+		c.Request.Context(),
+		request,
+		page,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if resp.LinkHeader != "" {
+		c.Header("Link", resp.LinkHeader)
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp.Items)
+}
+
+// parseAccountsGETRequestV1 converts the boolean
+// flags of the v1 admin accounts API into a request.
+func parseAccountsGETRequestV1(c *gin.Context) (*apimodel.AdminGetAccountsRequest, gtserror.WithCode) {
+	request := &apimodel.AdminGetAccountsRequest{
+		Username:    c.Query(apiutil.AdminAccountsUsernameKey),
+		DisplayName: c.Query(apiutil.AdminAccountsDisplayNameKey),
+		ByDomain:    c.Query(apiutil.AdminAccountsByDomainKey),
+		Email:       c.Query(apiutil.AdminAccountsEmailKey),
+		IP:          c.Query(apiutil.AdminAccountsIPKey),
+
+		// Previous versions of this endpoint
+		// took a status string rather than
+		// flags, so still allow it here.
+		Status: c.Query(apiutil.AdminAccountsStatusKey),
+	}
+
+	// Each flag key maps to the request
+	// field it sets, and the value it sets.
+	flags := []struct {
+		key   string
+		field *string
+		value string
+	}{
+		{apiutil.LocalKey, &request.Origin, "local"},
+		{apiutil.AdminAccountsRemoteKey, &request.Origin, "remote"},
+		{apiutil.AdminAccountsActiveKey, &request.Status, "active"},
+		{apiutil.AdminAccountsPendingKey, &request.Status, "pending"},
+		{apiutil.AdminAccountsDisabledKey, &request.Status, "disabled"},
+		{apiutil.AdminAccountsSilencedKey, &request.Status, "silenced"},
+		{apiutil.AdminAccountsSuspendedKey, &request.Status, "suspended"},
+		{apiutil.AdminAccountsStaffKey, &request.Permissions, "staff"},
+	}
+
+	for _, flag := range flags {
+		set, errWithCode := apiutil.ParseAdminAccountsFlag(c.Query(flag.key), flag.key)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		if !set {
+			continue
+		}
+
+		if *flag.field != "" && *flag.field != flag.value {
+			err := fmt.Errorf("%s cannot be combined with %s", flag.key, *flag.field)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		*flag.field = flag.value
+	}
+
+	return request, nil
+}
+
+// parseAccountsGETRequestV2 reads the
+// v2 admin accounts API query into a request.
+func parseAccountsGETRequestV2(c *gin.Context) (*apimodel.AdminGetAccountsRequest, gtserror.WithCode) {
+	return &apimodel.AdminGetAccountsRequest{
+		Origin:      c.Query(apiutil.AdminAccountsOriginKey),
+		Status:      c.Query(apiutil.AdminAccountsStatusKey),
+		Permissions: c.Query(apiutil.AdminAccountsPermissionsKey),
+		InvitedBy:   c.Query(apiutil.AdminAccountsInvitedByKey),
+		Username:    c.Query(apiutil.AdminAccountsUsernameKey),
+		DisplayName: c.Query(apiutil.AdminAccountsDisplayNameKey),
+		ByDomain:    c.Query(apiutil.AdminAccountsByDomainKey),
+		Email:       c.Query(apiutil.AdminAccountsEmailKey),
+		IP:          c.Query(apiutil.AdminAccountsIPKey),
+	}, nil
+}
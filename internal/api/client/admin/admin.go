@@ -22,39 +22,65 @@ import (
 
 	"codeberg.org/gruf/go-debug"
 	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
 const (
-	BasePath                = "/v1/admin"
-	EmojiPath               = BasePath + "/custom_emojis"
-	EmojiPathWithID         = EmojiPath + "/:" + IDKey
-	EmojiCategoriesPath     = EmojiPath + "/categories"
-	DomainBlocksPath        = BasePath + "/domain_blocks"
-	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + IDKey
-	DomainAllowsPath        = BasePath + "/domain_allows"
-	DomainAllowsPathWithID  = DomainAllowsPath + "/:" + IDKey
-	DomainKeysExpirePath    = BasePath + "/domain_keys_expire"
-	HeaderAllowsPath        = BasePath + "/header_allows"
-	HeaderAllowsPathWithID  = HeaderAllowsPath + "/:" + IDKey
-	HeaderBlocksPath        = BasePath + "/header_blocks"
-	HeaderBlocksPathWithID  = HeaderBlocksPath + "/:" + IDKey
-	AccountsPath            = BasePath + "/accounts"
-	AccountsPathWithID      = AccountsPath + "/:" + IDKey
-	AccountsActionPath      = AccountsPathWithID + "/action"
-	MediaCleanupPath        = BasePath + "/media_cleanup"
-	MediaRefetchPath        = BasePath + "/media_refetch"
-	ReportsPath             = BasePath + "/reports"
-	ReportsPathWithID       = ReportsPath + "/:" + IDKey
-	ReportsResolvePath      = ReportsPathWithID + "/resolve"
-	EmailPath               = BasePath + "/email"
-	EmailTestPath           = EmailPath + "/test"
-	InstanceRulesPath       = BasePath + "/instance/rules"
-	InstanceRulesPathWithID = InstanceRulesPath + "/:" + IDKey
-	DebugPath               = BasePath + "/debug"
-	DebugAPUrlPath          = DebugPath + "/apurl"
+	BasePath                                = "/v1/admin"
+	EmojiPath                               = BasePath + "/custom_emojis"
+	EmojiPathWithID                         = EmojiPath + "/:" + IDKey
+	EmojiCategoriesPath                     = EmojiPath + "/categories"
+	ContentWarningPoliciesPath              = BasePath + "/content_warning_policies"
+	ContentWarningPoliciesPathWithID        = ContentWarningPoliciesPath + "/:" + IDKey
+	DomainBlocksPath                        = BasePath + "/domain_blocks"
+	EmailDomainBlocksPath                   = BasePath + "/email_domain_blocks"
+	EmailDomainBlocksPathWithID             = EmailDomainBlocksPath + "/:" + IDKey
+	DomainBlocksPathWithID                  = DomainBlocksPath + "/:" + IDKey
+	DomainAllowsPath                        = BasePath + "/domain_allows"
+	DomainAllowsPathWithID                  = DomainAllowsPath + "/:" + IDKey
+	DomainKeysExpirePath                    = BasePath + "/domain_keys_expire"
+	DomainPermissionSubscriptionsPath       = BasePath + "/domain_permission_subscriptions"
+	DomainPermissionSubscriptionsPathWithID = DomainPermissionSubscriptionsPath + "/:" + IDKey
+	HeaderAllowsPath                        = BasePath + "/header_allows"
+	HeaderAllowsPathWithID                  = HeaderAllowsPath + "/:" + IDKey
+	HeaderBlocksPath                        = BasePath + "/header_blocks"
+	HeaderBlocksPathWithID                  = HeaderBlocksPath + "/:" + IDKey
+	AccountsPath                            = BasePath + "/accounts"
+	AccountsV2Path                          = "/v2/admin/accounts"
+	AccountsPathWithID                      = AccountsPath + "/:" + IDKey
+	AccountsActionPath                      = AccountsPathWithID + "/action"
+	AccountsApprovePath                     = AccountsPathWithID + "/approve"
+	AccountsRejectPath                      = AccountsPathWithID + "/reject"
+	AccountsEnablePath                      = AccountsPathWithID + "/enable"
+	AccountsUnsilencePath                   = AccountsPathWithID + "/unsilence"
+	AccountsUnsensitivePath                 = AccountsPathWithID + "/unsensitive"
+	AccountsUnsuspendPath                   = AccountsPathWithID + "/unsuspend"
+	InvitesPath                             = BasePath + "/invites"
+	InvitesPathWithID                       = InvitesPath + "/:" + IDKey
+	MediaCleanupPath                        = BasePath + "/media_cleanup"
+	MediaRefetchPath                        = BasePath + "/media_refetch"
+	ReportsPath                             = BasePath + "/reports"
+	ReportsPathWithID                       = ReportsPath + "/:" + IDKey
+	ReportsResolvePath                      = ReportsPathWithID + "/resolve"
+	EmailPath                               = BasePath + "/email"
+	EmailTestPath                           = EmailPath + "/test"
+	InstanceRulesPath                       = BasePath + "/instance/rules"
+	InstanceRulesPathWithID                 = InstanceRulesPath + "/:" + IDKey
+	DeliveryQueuePath                       = BasePath + "/delivery_queue"
+	DeliveryQueuePathWithDomain             = DeliveryQueuePath + "/:" + DomainKey
+	DeliveryQueueFlushPath                  = DeliveryQueuePathWithDomain + "/flush"
+	PinnedSuggestionsPath                   = BasePath + "/suggestions"
+	PinnedSuggestionsPathWithID             = PinnedSuggestionsPath + "/:" + IDKey
+	TrendsPath                              = BasePath + "/trends"
+	TrendsPathWithType                      = TrendsPath + "/:" + apiutil.TrendTypeKey
+	TrendsApprovePath                       = TrendsPathWithType + "/:" + IDKey + "/approve"
+	TrendsRejectPath                        = TrendsPathWithType + "/:" + IDKey + "/reject"
+	DebugPath                               = BasePath + "/debug"
+	DebugAPUrlPath                          = DebugPath + "/apurl"
 
 	IDKey                 = "id"
+	DomainKey             = "domain"
 	FilterQueryKey        = "filter"
 	MaxShortcodeDomainKey = "max_shortcode_domain"
 	MinShortcodeDomainKey = "min_shortcode_domain"
@@ -66,6 +92,8 @@ const (
 	MaxIDKey              = "max_id"
 	SinceIDKey            = "since_id"
 	MinIDKey              = "min_id"
+	PermissionTypeKey     = "permission_type"
+	StatusKey             = "status"
 )
 
 type Module struct {
@@ -99,6 +127,13 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, DomainAllowsPathWithID, m.DomainAllowGETHandler)
 	attachHandler(http.MethodDelete, DomainAllowsPathWithID, m.DomainAllowDELETEHandler)
 
+	// domain permission subscription stuff
+	attachHandler(http.MethodPost, DomainPermissionSubscriptionsPath, m.DomainPermissionSubscriptionPOSTHandler)
+	attachHandler(http.MethodGet, DomainPermissionSubscriptionsPath, m.DomainPermissionSubscriptionsGETHandler)
+	attachHandler(http.MethodGet, DomainPermissionSubscriptionsPathWithID, m.DomainPermissionSubscriptionGETHandler)
+	attachHandler(http.MethodPatch, DomainPermissionSubscriptionsPathWithID, m.DomainPermissionSubscriptionPATCHHandler)
+	attachHandler(http.MethodDelete, DomainPermissionSubscriptionsPathWithID, m.DomainPermissionSubscriptionDELETEHandler)
+
 	// header filtering administration routes
 	attachHandler(http.MethodGet, HeaderAllowsPathWithID, m.HeaderFilterAllowGET)
 	attachHandler(http.MethodGet, HeaderBlocksPathWithID, m.HeaderFilterBlockGET)
@@ -113,7 +148,20 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, DomainKeysExpirePath, m.DomainKeysExpirePOSTHandler)
 
 	// accounts stuff
+	attachHandler(http.MethodGet, AccountsPath, m.AccountsGETHandler)
+	attachHandler(http.MethodGet, AccountsV2Path, m.AccountsGETV2Handler)
+	attachHandler(http.MethodGet, AccountsPathWithID, m.AccountGETHandler)
 	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
+	attachHandler(http.MethodPost, AccountsApprovePath, m.AccountApprovePOSTHandler)
+	attachHandler(http.MethodPost, AccountsRejectPath, m.AccountRejectPOSTHandler)
+	attachHandler(http.MethodPost, AccountsEnablePath, m.AccountEnablePOSTHandler)
+	attachHandler(http.MethodPost, AccountsUnsilencePath, m.AccountUnsilencePOSTHandler)
+	attachHandler(http.MethodPost, AccountsUnsensitivePath, m.AccountUnsensitivePOSTHandler)
+	attachHandler(http.MethodPost, AccountsUnsuspendPath, m.AccountUnsuspendPOSTHandler)
+
+	// invites stuff
+	attachHandler(http.MethodGet, InvitesPath, m.InvitesGETHandler)
+	attachHandler(http.MethodGet, InvitesPathWithID, m.InviteGETHandler)
 
 	// media stuff
 	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
@@ -134,6 +182,34 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPatch, InstanceRulesPathWithID, m.RulePATCHHandler)
 	attachHandler(http.MethodDelete, InstanceRulesPathWithID, m.RuleDELETEHandler)
 
+	// delivery queue stuff
+	attachHandler(http.MethodGet, DeliveryQueuePath, m.DeliveryQueueGETHandler)
+	attachHandler(http.MethodGet, DeliveryQueuePathWithDomain, m.DeliveryQueueDomainGETHandler)
+	attachHandler(http.MethodDelete, DeliveryQueuePathWithDomain, m.DeliveryQueueDomainDELETEHandler)
+	attachHandler(http.MethodPost, DeliveryQueueFlushPath, m.DeliveryQueueDomainFlushPOSTHandler)
+
+	// email domain blocks stuff
+	attachHandler(http.MethodGet, EmailDomainBlocksPath, m.EmailDomainBlocksGETHandler)
+	attachHandler(http.MethodPost, EmailDomainBlocksPath, m.EmailDomainBlockPOSTHandler)
+	attachHandler(http.MethodGet, EmailDomainBlocksPathWithID, m.EmailDomainBlockGETHandler)
+	attachHandler(http.MethodDelete, EmailDomainBlocksPathWithID, m.EmailDomainBlockDELETEHandler)
+
+	// content warning policies stuff
+	attachHandler(http.MethodGet, ContentWarningPoliciesPath, m.ContentWarningPoliciesGETHandler)
+	attachHandler(http.MethodPost, ContentWarningPoliciesPath, m.ContentWarningPolicyPOSTHandler)
+	attachHandler(http.MethodGet, ContentWarningPoliciesPathWithID, m.ContentWarningPolicyGETHandler)
+	attachHandler(http.MethodDelete, ContentWarningPoliciesPathWithID, m.ContentWarningPolicyDELETEHandler)
+
+	// pinned suggestions stuff
+	attachHandler(http.MethodGet, PinnedSuggestionsPath, m.PinnedSuggestionsGETHandler)
+	attachHandler(http.MethodPost, PinnedSuggestionsPathWithID, m.PinnedSuggestionPOSTHandler)
+	attachHandler(http.MethodDelete, PinnedSuggestionsPathWithID, m.PinnedSuggestionDELETEHandler)
+
+	// trends stuff
+	attachHandler(http.MethodGet, TrendsPathWithType, m.TrendsGETHandler)
+	attachHandler(http.MethodPost, TrendsApprovePath, m.TrendApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsRejectPath, m.TrendRejectPOSTHandler)
+
 	// debug stuff
 	if debug.DEBUG {
 		attachHandler(http.MethodGet, DebugAPUrlPath, m.DebugAPUrlHandler)
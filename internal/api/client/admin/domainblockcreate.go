@@ -90,6 +90,37 @@ import (
 //			is a useful way of internally keeping track of why a certain domain ended up blocked.
 //			Used only if `import` is not `true`.
 //		type: string
+//	-
+//		name: severity
+//		in: formData
+//		description: >-
+//			Severity of the domain block. `suspend` defederates from the domain entirely.
+//			`silence` (or `limit`) hides the domain's accounts from public timelines and from
+//			accounts who don't follow them. `noop` only applies `reject_media` and `reject_reports`.
+//			Used only if `import` is not `true`.
+//		type: string
+//		enum:
+//			- suspend
+//			- silence
+//			- limit
+//			- noop
+//		default: suspend
+//	-
+//		name: reject_media
+//		in: formData
+//		description: >-
+//			Don't fetch or store media (attachments, avatars, headers) from the domain.
+//			Used only if `import` is not `true`.
+//		type: boolean
+//		default: false
+//	-
+//		name: reject_reports
+//		in: formData
+//		description: >-
+//			Ignore reports sent from the domain.
+//			Used only if `import` is not `true`.
+//		type: boolean
+//		default: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -40,6 +40,9 @@ type singleDomainPermCreate func(
 	bool, // obfuscate
 	string, // publicComment
 	string, // privateComment
+	gtsmodel.DomainBlockSeverity, // severity
+	bool, // rejectMedia
+	bool, // rejectReports
 	string, // subscriptionID
 ) (*apimodel.DomainPermission, string, gtserror.WithCode)
 
@@ -114,6 +117,9 @@ func (m *Module) createDomainPermissions(
 			form.Obfuscate,
 			form.PublicComment,
 			form.PrivateComment,
+			gtsmodel.NewDomainBlockSeverity(form.Severity),
+			form.RejectMedia,
+			form.RejectReports,
 			"", // No sub ID for single perm creation.
 		)
 
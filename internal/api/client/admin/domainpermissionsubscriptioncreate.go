@@ -0,0 +1,270 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DomainPermissionSubscriptionPOSTHandler swagger:operation POST /api/v1/admin/domain_permission_subscriptions domainPermissionSubscriptionCreate
+//
+// Create a domain permission subscription with the given parameters.
+//
+// The subscribed list will be fetched and processed on the next scheduled
+// subscriptions run, creating or removing domain permissions as appropriate.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- multipart/form-data
+//	- application/json
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: priority
+//		in: formData
+//		description: >-
+//			Priority of this subscription compared to others of the same permission type.
+//			0-255 (higher = higher priority). Higher priority subscriptions will overwrite
+//			permissions generated by lower priority subscriptions. When two subscriptions
+//			have the same priority, the oldest one takes precedence.
+//		type: number
+//		minimum: 0
+//		maximum: 255
+//		default: 0
+//	-
+//		name: title
+//		in: formData
+//		description: Optional title for this subscription.
+//		type: string
+//	-
+//		name: permission_type
+//		required: true
+//		in: formData
+//		description: >-
+//			Type of permissions to create by parsing the targeted file/list.
+//			One of "allow" or "block".
+//		type: string
+//	-
+//		name: adopt_orphans
+//		in: formData
+//		description: >-
+//			If true, this subscription will "adopt" domain permissions
+//			which already exist on the instance, and which meet the
+//			following conditions:
+//			1) they have no subscription ID (ie., they're "orphaned") and
+//			2) they are present in the subscribed list.
+//			Such orphaned domain permissions will be given this
+//			subscription's subscription ID value and be managed
+//			by this subscription.
+//		type: boolean
+//		default: false
+//	-
+//		name: uri
+//		required: true
+//		in: formData
+//		description: URI to call in order to fetch the permissions list.
+//		type: string
+//	-
+//		name: content_type
+//		required: true
+//		in: formData
+//		description: >-
+//			MIME content type to use when parsing the permissions list.
+//			One of "text/plain", "text/csv", and "application/json".
+//		type: string
+//	-
+//		name: fetch_username
+//		in: formData
+//		description: >-
+//			Optional basic auth username to provide when fetching given uri.
+//			If set, will be transmitted along with `fetch_password` when doing the fetch.
+//		type: string
+//	-
+//		name: fetch_password
+//		in: formData
+//		description: >-
+//			Optional basic auth password to provide when fetching given uri.
+//			If set, will be transmitted along with `fetch_username` when doing the fetch.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: The newly created domain permission subscription.
+//			schema:
+//				"$ref": "#/definitions/domainPermissionSubscription"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict
+//		'500':
+//			description: internal server error
+func (m *Module) DomainPermissionSubscriptionPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Parse + validate form.
+	form := new(apimodel.DomainPermissionSubscriptionRequest)
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Permission type is only settable on creation.
+	if form.PermissionType == nil || *form.PermissionType == "" {
+		err := errors.New("permission_type must be set")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	permType := gtsmodel.NewDomainPermissionType(*form.PermissionType)
+	if permType == gtsmodel.DomainPermissionUnknown {
+		err := fmt.Errorf("permission_type %s not recognized, valid values are block or allow", *form.PermissionType)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// URI and content type are required on creation.
+	if form.URI == nil || *form.URI == "" {
+		err := errors.New("uri must be set")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.ContentType == nil || *form.ContentType == "" {
+		err := errors.New("content_type must be set")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	priority, uri, contentType, err := validateDomainPermSubRequest(form)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	permSub, errWithCode := m.processor.Admin().DomainPermissionSubscriptionCreate(
+		c.Request.Context(),
+		authed.Account,
+		util.PtrValueOr(priority, 0),
+		util.PtrValueOr(form.Title, ""),
+		*uri,
+		*contentType,
+		permType,
+		util.PtrValueOr(form.AdoptOrphans, false),
+		util.PtrValueOr(form.FetchUsername, ""),
+		util.PtrValueOr(form.FetchPassword, ""),
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, permSub)
+}
+
+// validateDomainPermSubRequest validates the common fields
+// of a domain permission subscription create/update request,
+// returning parsed values for any of the set fields.
+func validateDomainPermSubRequest(form *apimodel.DomainPermissionSubscriptionRequest) (
+	*uint8, // priority
+	*string, // uri
+	*gtsmodel.DomainPermSubContentType, // content type
+	error,
+) {
+	var (
+		priority    *uint8
+		uri         *string
+		contentType *gtsmodel.DomainPermSubContentType
+	)
+
+	if form.Priority != nil {
+		if *form.Priority < 0 || *form.Priority > 255 {
+			return nil, nil, nil, errors.New("priority must be a number in the range 0 to 255")
+		}
+		priority = util.Ptr(uint8(*form.Priority)) // #nosec G115 -- Just validated.
+	}
+
+	if form.Title != nil && len([]rune(*form.Title)) > 200 {
+		return nil, nil, nil, errors.New("title must be 200 characters or less")
+	}
+
+	if form.URI != nil {
+		u, err := url.Parse(*form.URI)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, nil, nil, fmt.Errorf("uri %s is not a valid http or https URL", *form.URI)
+		}
+		uri = util.Ptr(u.String())
+	}
+
+	if form.ContentType != nil {
+		ct := gtsmodel.NewDomainPermSubContentType(*form.ContentType)
+		if ct == gtsmodel.DomainPermSubContentTypeUnknown {
+			return nil, nil, nil, fmt.Errorf(
+				"content_type %s not recognized, valid values are %s, %s, or %s",
+				*form.ContentType,
+				gtsmodel.DomainPermSubContentTypePlain,
+				gtsmodel.DomainPermSubContentTypeCSV,
+				gtsmodel.DomainPermSubContentTypeJSON,
+			)
+		}
+		contentType = &ct
+	}
+
+	return priority, uri, contentType, nil
+}
@@ -162,6 +162,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "approved": false,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -193,7 +194,20 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "created_at": "2022-06-04T13:12:00.000Z",
       "email": "tortle.dude@example.org",
       "ip": "118.44.18.196",
-      "ips": [],
+      "ips": [
+        {
+          "ip": "118.44.18.196",
+          "used_at": "2022-06-05T13:12:00.000Z"
+        },
+        {
+          "ip": "198.98.21.15",
+          "used_at": "2022-06-06T13:12:00.000Z"
+        },
+        {
+          "ip": "59.99.19.172",
+          "used_at": "2022-05-23T13:12:00.000Z"
+        }
+      ],
       "locale": "en",
       "invite_request": null,
       "role": {
@@ -203,6 +217,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "approved": true,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -249,7 +264,16 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "created_at": "2022-05-17T13:10:59.000Z",
       "email": "admin@example.org",
       "ip": "89.122.255.1",
-      "ips": [],
+      "ips": [
+        {
+          "ip": "89.122.255.1",
+          "used_at": "2022-06-04T13:12:00.000Z"
+        },
+        {
+          "ip": "89.22.189.19",
+          "used_at": "2022-06-01T13:12:00.000Z"
+        }
+      ],
       "locale": "en",
       "invite_request": null,
       "role": {
@@ -259,6 +283,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "approved": true,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -295,7 +320,16 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "created_at": "2022-05-17T13:10:59.000Z",
       "email": "admin@example.org",
       "ip": "89.122.255.1",
-      "ips": [],
+      "ips": [
+        {
+          "ip": "89.122.255.1",
+          "used_at": "2022-06-04T13:12:00.000Z"
+        },
+        {
+          "ip": "89.22.189.19",
+          "used_at": "2022-06-01T13:12:00.000Z"
+        }
+      ],
       "locale": "en",
       "invite_request": null,
       "role": {
@@ -305,6 +339,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "approved": true,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -354,7 +389,20 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "created_at": "2022-06-04T13:12:00.000Z",
       "email": "tortle.dude@example.org",
       "ip": "118.44.18.196",
-      "ips": [],
+      "ips": [
+        {
+          "ip": "118.44.18.196",
+          "used_at": "2022-06-05T13:12:00.000Z"
+        },
+        {
+          "ip": "198.98.21.15",
+          "used_at": "2022-06-06T13:12:00.000Z"
+        },
+        {
+          "ip": "59.99.19.172",
+          "used_at": "2022-05-23T13:12:00.000Z"
+        }
+      ],
       "locale": "en",
       "invite_request": null,
       "role": {
@@ -364,6 +412,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "approved": true,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -420,6 +469,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "approved": false,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -575,7 +625,20 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       "created_at": "2022-06-04T13:12:00.000Z",
       "email": "tortle.dude@example.org",
       "ip": "118.44.18.196",
-      "ips": [],
+      "ips": [
+        {
+          "ip": "118.44.18.196",
+          "used_at": "2022-06-05T13:12:00.000Z"
+        },
+        {
+          "ip": "198.98.21.15",
+          "used_at": "2022-06-06T13:12:00.000Z"
+        },
+        {
+          "ip": "59.99.19.172",
+          "used_at": "2022-05-23T13:12:00.000Z"
+        }
+      ],
       "locale": "en",
       "invite_request": null,
       "role": {
@@ -585,6 +648,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       "approved": true,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -641,6 +705,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       "approved": false,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -796,7 +861,20 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       "created_at": "2022-06-04T13:12:00.000Z",
       "email": "tortle.dude@example.org",
       "ip": "118.44.18.196",
-      "ips": [],
+      "ips": [
+        {
+          "ip": "118.44.18.196",
+          "used_at": "2022-06-05T13:12:00.000Z"
+        },
+        {
+          "ip": "198.98.21.15",
+          "used_at": "2022-06-06T13:12:00.000Z"
+        },
+        {
+          "ip": "59.99.19.172",
+          "used_at": "2022-05-23T13:12:00.000Z"
+        }
+      ],
       "locale": "en",
       "invite_request": null,
       "role": {
@@ -806,6 +884,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       "approved": true,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -862,6 +941,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       "approved": false,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
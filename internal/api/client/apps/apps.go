@@ -24,8 +24,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
-// BasePath is the base path for this api module, excluding the api prefix
-const BasePath = "/v1/apps"
+const (
+	IDKey = "id"
+	// BasePath is the base path for this api module, excluding the api prefix
+	BasePath = "/v1/apps"
+	// AuthorizedPath is for viewing + revoking applications authorized by the requester
+	AuthorizedPath       = BasePath + "/authorized"
+	AuthorizedPathWithID = AuthorizedPath + "/:" + IDKey
+)
 
 type Module struct {
 	processor *processing.Processor
@@ -39,4 +45,6 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodPost, BasePath, m.AppsPOSTHandler)
+	attachHandler(http.MethodGet, AuthorizedPath, m.AuthorizedAppsGETHandler)
+	attachHandler(http.MethodDelete, AuthorizedPathWithID, m.AuthorizedAppDELETEHandler)
 }
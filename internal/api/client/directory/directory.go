@@ -15,7 +15,7 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
-package filter
+package directory
 
 import (
 	"net/http"
@@ -25,8 +25,8 @@ import (
 )
 
 const (
-	// BasePath is the base path for serving the filters API, minus the 'api' prefix
-	BasePath = "/v1/filters"
+	// BasePath is the base path for serving the directory API, minus the 'api' prefix
+	BasePath = "/v1/directory"
 )
 
 type Module struct {
@@ -40,5 +40,5 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, BasePath, m.FiltersGETHandler)
+	attachHandler(http.MethodGet, BasePath, m.DirectoryGETHandler)
 }
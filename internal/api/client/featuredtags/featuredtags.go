@@ -21,11 +21,15 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
 const (
-	BasePath = "/v1/featured_tags"
+	// BasePath is the base path for serving the featured tags API, minus the 'api' prefix
+	BasePath        = "/v1/featured_tags"
+	BasePathWithID  = BasePath + "/:" + apiutil.IDKey
+	SuggestionsPath = BasePath + "/suggestions"
 )
 
 type Module struct {
@@ -40,4 +44,7 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.FeaturedTagsGETHandler)
+	attachHandler(http.MethodPost, BasePath, m.FeaturedTagPOSTHandler)
+	attachHandler(http.MethodDelete, BasePathWithID, m.FeaturedTagDELETEHandler)
+	attachHandler(http.MethodGet, SuggestionsPath, m.FeaturedTagSuggestionsGETHandler)
 }
@@ -30,8 +30,6 @@ import (
 //
 // Get an array of all hashtags that you currently have featured on your profile.
 //
-// THIS ENDPOINT IS CURRENTLY NOT FULLY IMPLEMENTED: it will always return an empty array.
-//
 //	---
 //	tags:
 //	- featured_tags
@@ -45,10 +43,11 @@ import (
 //
 //	responses:
 //		'200':
+//			description: Array of featured tags, most used first.
 //			schema:
 //				type: array
 //				items:
-//					type: object
+//					"$ref": "#/definitions/featuredTag"
 //		'400':
 //			description: bad request
 //		'401':
@@ -60,7 +59,7 @@ import (
 //		'500':
 //			description: internal server error
 func (m *Module) FeaturedTagsGETHandler(c *gin.Context) {
-	_, err := oauth.Authed(c, true, true, true, true)
+	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -71,5 +70,11 @@ func (m *Module) FeaturedTagsGETHandler(c *gin.Context) {
 		return
 	}
 
-	apiutil.Data(c, http.StatusOK, apiutil.AppJSON, apiutil.EmptyJSONArray)
+	featuredTags, errWithCode := m.processor.Tags().Featured(c.Request.Context(), authed.Account)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, featuredTags)
 }
@@ -0,0 +1,322 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// FilterPUTHandler swagger:operation PUT /api/v2/filters/{id} filterV2Put
+//
+// Update a single filter with the given ID.
+// Note that this is actually closer to a PATCH operation:
+// only provided fields will be updated, and omitted fields will remain set to previous values.
+//
+//	---
+//	tags:
+//	- filters
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the filter.
+//		in: path
+//		required: true
+//	-
+//		name: title
+//		in: formData
+//		description: |-
+//			The name of the filter.
+//
+//			Sample: illuminati nonsense
+//		type: string
+//		minLength: 1
+//		maxLength: 200
+//	-
+//		name: context[]
+//		in: formData
+//		description: |-
+//			The contexts in which the filter should be applied.
+//
+//			Sample: home, public
+//		type: array
+//		items:
+//			type: string
+//			enum:
+//				- home
+//				- notifications
+//				- public
+//				- thread
+//				- account
+//		collectionFormat: multi
+//		minItems: 1
+//		uniqueItems: true
+//	-
+//		name: expires_in
+//		in: formData
+//		description: |-
+//			Number of seconds from now that the filter should expire. If empty, filter never expires.
+//
+//			Sample: 86400
+//		type: number
+//	-
+//		name: filter_action
+//		in: formData
+//		description: |-
+//			The action to be taken when a status matches this filter.
+//
+//			Sample: warn
+//		type: string
+//		enum:
+//			- warn
+//			- hide
+//	-
+//		name: keywords_attributes[][id]
+//		in: formData
+//		type: array
+//		items:
+//			type: string
+//		description: Keyword IDs to be updated or deleted. Omit to add new keywords instead.
+//		collectionFormat: multi
+//	-
+//		name: keywords_attributes[][keyword]
+//		in: formData
+//		type: array
+//		items:
+//			type: string
+//		description: Keywords to be added (if not using id param) or updated (if using id param).
+//		collectionFormat: multi
+//	-
+//		name: keywords_attributes[][whole_word]
+//		in: formData
+//		type: array
+//		items:
+//			type: boolean
+//		description: Should each keyword consider word boundaries?
+//		collectionFormat: multi
+//	-
+//		name: keywords_attributes[][_destroy]
+//		in: formData
+//		type: array
+//		items:
+//			type: boolean
+//		description: Set to true to delete the keyword with the corresponding id.
+//		collectionFormat: multi
+//	-
+//		name: statuses_attributes[][id]
+//		in: formData
+//		type: array
+//		items:
+//			type: string
+//		description: Filter status IDs to be deleted. Omit to add new statuses instead.
+//		collectionFormat: multi
+//	-
+//		name: statuses_attributes[][status_id]
+//		in: formData
+//		type: array
+//		items:
+//			type: string
+//		description: Statuses to be added to the filter.
+//		collectionFormat: multi
+//	-
+//		name: statuses_attributes[][_destroy]
+//		in: formData
+//		type: array
+//		items:
+//			type: boolean
+//		description: Set to true to delete the filter status with the corresponding id.
+//		collectionFormat: multi
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:filters
+//
+//	responses:
+//		'200':
+//			name: filter
+//			description: Updated filter.
+//			schema:
+//				"$ref": "#/definitions/filterV2"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict (duplicate title, keyword, or status)
+//		'422':
+//			description: unprocessable content
+//		'500':
+//			description: internal server error
+func (m *Module) FilterPUTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	id, errWithCode := apiutil.ParseID(c.Param(apiutil.IDKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.FilterUpdateRequestV2{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := validateNormalizeUpdateFilter(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnprocessableEntity(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	apiFilter, errWithCode := m.processor.FiltersV2().Update(c.Request.Context(), authed.Account, id, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, apiFilter)
+}
+
+func validateNormalizeUpdateFilter(form *apimodel.FilterUpdateRequestV2) error {
+	if form.Title != nil {
+		if err := validate.FilterTitle(*form.Title); err != nil {
+			return err
+		}
+	}
+	if form.Context != nil {
+		if err := validate.FilterContexts(*form.Context); err != nil {
+			return err
+		}
+	}
+	if form.FilterAction != nil {
+		if err := validate.FilterAction(*form.FilterAction); err != nil {
+			return err
+		}
+	}
+
+	// Normalize filter expiry if necessary.
+	expiresIn, err := parseExpiresIn(form.ExpiresInI)
+	if err != nil {
+		return err
+	}
+	if expiresIn != nil {
+		form.ExpiresIn = expiresIn
+	}
+	if form.ExpiresIn != nil && *form.ExpiresIn < 0 {
+		return fmt.Errorf("filter expires_in must not be negative")
+	}
+
+	// If we parsed form data, the nested keyword
+	// and status attributes will be in flat arrays.
+	if len(form.Keywords) == 0 {
+		count := max(
+			len(form.KeywordsAttributesID),
+			len(form.KeywordsAttributesKeyword),
+			len(form.KeywordsAttributesWholeWord),
+			len(form.KeywordsAttributesDestroy),
+		)
+		for i := 0; i < count; i++ {
+			formKeyword := apimodel.FilterKeywordCreateUpdateDeleteRequest{}
+			if i < len(form.KeywordsAttributesID) && form.KeywordsAttributesID[i] != "" {
+				formKeyword.ID = &form.KeywordsAttributesID[i]
+			}
+			if i < len(form.KeywordsAttributesKeyword) {
+				formKeyword.Keyword = &form.KeywordsAttributesKeyword[i]
+			}
+			if i < len(form.KeywordsAttributesWholeWord) {
+				formKeyword.WholeWord = &form.KeywordsAttributesWholeWord[i]
+			}
+			if i < len(form.KeywordsAttributesDestroy) {
+				formKeyword.Destroy = &form.KeywordsAttributesDestroy[i]
+			}
+			form.Keywords = append(form.Keywords, formKeyword)
+		}
+	}
+	if len(form.Statuses) == 0 {
+		count := max(
+			len(form.StatusesAttributesID),
+			len(form.StatusesAttributesStatusID),
+			len(form.StatusesAttributesDestroy),
+		)
+		for i := 0; i < count; i++ {
+			formStatus := apimodel.FilterStatusCreateDeleteRequest{}
+			if i < len(form.StatusesAttributesID) && form.StatusesAttributesID[i] != "" {
+				formStatus.ID = &form.StatusesAttributesID[i]
+			}
+			if i < len(form.StatusesAttributesStatusID) {
+				formStatus.StatusID = &form.StatusesAttributesStatusID[i]
+			}
+			if i < len(form.StatusesAttributesDestroy) {
+				formStatus.Destroy = &form.StatusesAttributesDestroy[i]
+			}
+			form.Statuses = append(form.Statuses, formStatus)
+		}
+	}
+
+	// Validate nested attributes.
+	for _, formKeyword := range form.Keywords {
+		if formKeyword.Destroy != nil && *formKeyword.Destroy && formKeyword.ID == nil {
+			return errors.New("filter keywords can only be destroyed by id")
+		}
+		if formKeyword.Keyword != nil {
+			if err := validate.FilterKeyword(*formKeyword.Keyword); err != nil {
+				return err
+			}
+		}
+	}
+	for _, formStatus := range form.Statuses {
+		if formStatus.Destroy != nil && *formStatus.Destroy && formStatus.ID == nil {
+			return errors.New("filter statuses can only be destroyed by id")
+		}
+		if formStatus.ID == nil && (formStatus.StatusID == nil || *formStatus.StatusID == "") {
+			return errors.New("filter status_id must be provided")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,232 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	filtersV2 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v2"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func (suite *FiltersTestSuite) putFilter(
+	filterID string,
+	form url.Values,
+	requestJson *string,
+	expectedHTTPStatus int,
+	expectedBody string,
+) (*apimodel.FilterV2, error) {
+	// instantiate recorder + test context
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	// create the request
+	ctx.Request = httptest.NewRequest(http.MethodPut, config.GetProtocol()+"://"+config.GetHost()+"/api/"+filtersV2.BasePath+"/"+filterID, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	if requestJson != nil {
+		ctx.Request.Header.Set("content-type", "application/json")
+		ctx.Request.Body = io.NopCloser(strings.NewReader(*requestJson))
+	} else {
+		ctx.Request.Form = form
+	}
+
+	ctx.AddParam("id", filterID)
+
+	// trigger the handler
+	suite.filtersModule.FilterPUTHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := gtserror.NewMultiError(2)
+
+	// check code + body
+	if resultCode := recorder.Code; expectedHTTPStatus != resultCode {
+		errs.Appendf("expected %d got %d", expectedHTTPStatus, resultCode)
+		if expectedBody == "" {
+			return nil, errs.Combine()
+		}
+	}
+
+	// if we got an expected body, return early
+	if expectedBody != "" {
+		if string(b) != expectedBody {
+			errs.Appendf("expected %s got %s", expectedBody, string(b))
+		}
+		return nil, errs.Combine()
+	}
+
+	resp := &apimodel.FilterV2{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (suite *FiltersTestSuite) TestPutFilterFull() {
+	filter := suite.testFilters["local_account_1_filter_1"]
+	filterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	filterStatus := suite.testFilterStatuses["local_account_1_filter_1_status_1"]
+
+	form := url.Values{
+		"title":                             {"GNU/Linux"},
+		"context[]":                         {"home", "public", "thread"},
+		"filter_action":                     {"hide"},
+		"expires_in":                        {"86400"},
+		"keywords_attributes[][id]":         {filterKeyword.ID, ""},
+		"keywords_attributes[][keyword]":    {"fnords", "GNU"},
+		"keywords_attributes[][whole_word]": {"false", "true"},
+		"statuses_attributes[][id]":         {filterStatus.ID},
+		"statuses_attributes[][_destroy]":   {"true"},
+	}
+	apiFilter, err := suite.putFilter(filter.ID, form, nil, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(filter.ID, apiFilter.ID)
+	suite.Equal("GNU/Linux", apiFilter.Title)
+	suite.ElementsMatch(
+		[]apimodel.FilterContext{
+			apimodel.FilterContextHome,
+			apimodel.FilterContextPublic,
+			apimodel.FilterContextThread,
+		},
+		apiFilter.Context,
+	)
+	suite.Equal(apimodel.FilterActionHide, apiFilter.FilterAction)
+	if suite.NotNil(apiFilter.ExpiresAt) {
+		suite.NotEmpty(*apiFilter.ExpiresAt)
+	}
+
+	// The existing keyword was updated and a new keyword was added.
+	if suite.Len(apiFilter.Keywords, 2) {
+		keywords := map[string]bool{}
+		for _, apiFilterKeyword := range apiFilter.Keywords {
+			keywords[apiFilterKeyword.Keyword] = apiFilterKeyword.WholeWord
+			if apiFilterKeyword.Keyword == "fnords" {
+				suite.Equal(filterKeyword.ID, apiFilterKeyword.ID)
+			}
+		}
+		suite.Equal(map[string]bool{"fnords": false, "GNU": true}, keywords)
+	}
+
+	// The existing status was removed.
+	suite.Empty(apiFilter.Statuses)
+}
+
+func (suite *FiltersTestSuite) TestPutFilterFullJSON() {
+	filter := suite.testFilters["local_account_1_filter_1"]
+	filterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+
+	requestJson := `{
+		"title": "GNU/Linux",
+		"keywords_attributes": [
+			{
+				"id": "` + filterKeyword.ID + `",
+				"_destroy": true
+			},
+			{
+				"keyword": "Linux"
+			}
+		]
+	}`
+	apiFilter, err := suite.putFilter(filter.ID, nil, &requestJson, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("GNU/Linux", apiFilter.Title)
+
+	// Omitted fields should be unchanged.
+	suite.Equal(string(filter.Action), string(apiFilter.FilterAction))
+	suite.ElementsMatch(
+		[]apimodel.FilterContext{
+			apimodel.FilterContextHome,
+			apimodel.FilterContextPublic,
+		},
+		apiFilter.Context,
+	)
+	suite.Nil(apiFilter.ExpiresAt)
+	suite.Len(apiFilter.Statuses, 1)
+
+	if suite.Len(apiFilter.Keywords, 1) {
+		suite.NotEqual(filterKeyword.ID, apiFilter.Keywords[0].ID)
+		suite.Equal("Linux", apiFilter.Keywords[0].Keyword)
+		suite.False(apiFilter.Keywords[0].WholeWord)
+	}
+}
+
+func (suite *FiltersTestSuite) TestPutFilterEmptyTitle() {
+	filter := suite.testFilters["local_account_1_filter_1"]
+	form := url.Values{"title": {""}}
+	_, err := suite.putFilter(filter.ID, form, nil, http.StatusUnprocessableEntity, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *FiltersTestSuite) TestPutFilterInvalidContext() {
+	filter := suite.testFilters["local_account_1_filter_1"]
+	form := url.Values{"context[]": {"sausages"}}
+	_, err := suite.putFilter(filter.ID, form, nil, http.StatusUnprocessableEntity, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *FiltersTestSuite) TestPutFilterUnknownKeyword() {
+	filter := suite.testFilters["local_account_1_filter_1"]
+	form := url.Values{
+		"keywords_attributes[][id]":      {"01HN2B1F6DYZ1ZEC4XWB2PYE7Q"},
+		"keywords_attributes[][keyword]": {"GNU"},
+	}
+	_, err := suite.putFilter(filter.ID, form, nil, http.StatusNotFound, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *FiltersTestSuite) TestPutNonexistentFilter() {
+	id := "not_even_a_real_ULID"
+	form := url.Values{"title": {"GNU/Linux"}}
+	_, err := suite.putFilter(id, form, nil, http.StatusNotFound, `{"error":"Not Found"}`)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
@@ -0,0 +1,240 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// PushSubscriptionPOSTHandler swagger:operation POST /api/v1/push/subscription pushSubscriptionCreate
+//
+// Create a new Web Push subscription for the current access token, replacing the existing one if any.
+//
+// Notifications will be encrypted using the given keys, and delivered to the given endpoint.
+//
+//	---
+//	tags:
+//	- push
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: subscription[endpoint]
+//		type: string
+//		description: The endpoint URL for the Web Push API.
+//		in: formData
+//		required: true
+//	-
+//		name: subscription[keys][p256dh]
+//		type: string
+//		description: User agent public key. Base64 encoded string of a public key from a ECDH keypair using the prime256v1 curve.
+//		in: formData
+//		required: true
+//	-
+//		name: subscription[keys][auth]
+//		type: string
+//		description: Auth secret. Base64 encoded string of 16 bytes of random data.
+//		in: formData
+//		required: true
+//	-
+//		name: data[alerts][follow]
+//		type: boolean
+//		description: Receive a push notification when someone has followed you?
+//		in: formData
+//	-
+//		name: data[alerts][follow_request]
+//		type: boolean
+//		description: Receive a push notification when someone has requested to follow you?
+//		in: formData
+//	-
+//		name: data[alerts][favourite]
+//		type: boolean
+//		description: Receive a push notification when a status you created has been favourited by someone else?
+//		in: formData
+//	-
+//		name: data[alerts][mention]
+//		type: boolean
+//		description: Receive a push notification when someone else has mentioned you in a status?
+//		in: formData
+//	-
+//		name: data[alerts][reblog]
+//		type: boolean
+//		description: Receive a push notification when a status you created has been boosted by someone else?
+//		in: formData
+//	-
+//		name: data[alerts][poll]
+//		type: boolean
+//		description: Receive a push notification when a poll you voted in or created has ended?
+//		in: formData
+//	-
+//		name: data[alerts][status]
+//		type: boolean
+//		description: Receive a push notification when a subscribed account posts a status?
+//		in: formData
+//	-
+//		name: data[alerts][admin.sign_up]
+//		type: boolean
+//		description: Receive a push notification when a new user has signed up?
+//		in: formData
+//	-
+//		name: data[policy]
+//		type: string
+//		description: Which accounts to receive push notifications from.
+//		enum:
+//			- all
+//			- followed
+//			- follower
+//			- none
+//		default: all
+//		in: formData
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- push
+//
+//	responses:
+//		'200':
+//			description: The newly created push subscription.
+//			schema:
+//				"$ref": "#/definitions/webPushSubscription"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable content
+//		'500':
+//			description: internal server error
+func (m *Module) PushSubscriptionPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.PushSubscriptionCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := validateNormalizeCreateSubscription(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnprocessableEntity(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	subscription, errWithCode := m.processor.Push().Create(
+		c.Request.Context(),
+		authed.Account,
+		authed.Token.GetAccess(),
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, subscription)
+}
+
+func validateNormalizeCreateSubscription(form *apimodel.PushSubscriptionCreateRequest) error {
+	// If we parsed form data, the nested
+	// subscription details will be flat.
+	if form.Subscription == nil {
+		form.Subscription = &apimodel.PushSubscriptionRequestSubscription{
+			Endpoint: form.SubscriptionEndpoint,
+			Keys: apimodel.PushSubscriptionRequestKeys{
+				P256dh: form.SubscriptionKeysP256dh,
+				Auth:   form.SubscriptionKeysAuth,
+			},
+		}
+	}
+
+	if form.Subscription.Endpoint == "" {
+		return errors.New("subscription endpoint must be provided")
+	}
+
+	endpoint, err := url.Parse(form.Subscription.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return fmt.Errorf("subscription endpoint %s is not a valid https url", form.Subscription.Endpoint)
+	}
+
+	if form.Subscription.Keys.P256dh == "" || form.Subscription.Keys.Auth == "" {
+		return errors.New("subscription keys p256dh and auth must be provided")
+	}
+
+	return validateNormalizeData(&form.PushSubscriptionUpdateRequest)
+}
+
+func validateNormalizeData(form *apimodel.PushSubscriptionUpdateRequest) error {
+	// If we parsed form data, the nested
+	// alerts and policy will be flat.
+	if form.Data == nil {
+		form.Data = &apimodel.PushSubscriptionRequestData{
+			Alerts: &apimodel.PushSubscriptionRequestAlerts{
+				Follow:        form.DataAlertsFollow,
+				FollowRequest: form.DataAlertsFollowRequest,
+				Favourite:     form.DataAlertsFavourite,
+				Mention:       form.DataAlertsMention,
+				Reblog:        form.DataAlertsReblog,
+				Poll:          form.DataAlertsPoll,
+				Status:        form.DataAlertsStatus,
+				AdminSignup:   form.DataAlertsAdminSignup,
+			},
+			Policy: form.DataPolicy,
+		}
+	}
+
+	if policy := form.Data.Policy; policy != nil {
+		switch gtsmodel.WebPushSubscriptionPolicy(*policy) {
+		case gtsmodel.WebPushSubscriptionPolicyAll,
+			gtsmodel.WebPushSubscriptionPolicyFollowed,
+			gtsmodel.WebPushSubscriptionPolicyFollower,
+			gtsmodel.WebPushSubscriptionPolicyNone:
+			// Valid.
+		default:
+			return fmt.Errorf("policy %s not recognized, must be one of all, followed, follower, none", *policy)
+		}
+	}
+
+	return nil
+}
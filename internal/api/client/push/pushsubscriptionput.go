@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// PushSubscriptionPUTHandler swagger:operation PUT /api/v1/push/subscription pushSubscriptionUpdate
+//
+// Update the alerts and policy of the Web Push subscription of the current access token.
+//
+// Alerts which are not provided are left unchanged.
+//
+//	---
+//	tags:
+//	- push
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: data[alerts][follow]
+//		type: boolean
+//		description: Receive a push notification when someone has followed you?
+//		in: formData
+//	-
+//		name: data[alerts][follow_request]
+//		type: boolean
+//		description: Receive a push notification when someone has requested to follow you?
+//		in: formData
+//	-
+//		name: data[alerts][favourite]
+//		type: boolean
+//		description: Receive a push notification when a status you created has been favourited by someone else?
+//		in: formData
+//	-
+//		name: data[alerts][mention]
+//		type: boolean
+//		description: Receive a push notification when someone else has mentioned you in a status?
+//		in: formData
+//	-
+//		name: data[alerts][reblog]
+//		type: boolean
+//		description: Receive a push notification when a status you created has been boosted by someone else?
+//		in: formData
+//	-
+//		name: data[alerts][poll]
+//		type: boolean
+//		description: Receive a push notification when a poll you voted in or created has ended?
+//		in: formData
+//	-
+//		name: data[alerts][status]
+//		type: boolean
+//		description: Receive a push notification when a subscribed account posts a status?
+//		in: formData
+//	-
+//		name: data[alerts][admin.sign_up]
+//		type: boolean
+//		description: Receive a push notification when a new user has signed up?
+//		in: formData
+//	-
+//		name: data[policy]
+//		type: string
+//		description: Which accounts to receive push notifications from.
+//		enum:
+//			- all
+//			- followed
+//			- follower
+//			- none
+//		in: formData
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- push
+//
+//	responses:
+//		'200':
+//			description: The updated push subscription.
+//			schema:
+//				"$ref": "#/definitions/webPushSubscription"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable content
+//		'500':
+//			description: internal server error
+func (m *Module) PushSubscriptionPUTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.PushSubscriptionUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := validateNormalizeData(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnprocessableEntity(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	subscription, errWithCode := m.processor.Push().Update(
+		c.Request.Context(),
+		authed.Token.GetAccess(),
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, subscription)
+}
@@ -57,7 +57,7 @@ import (
 //
 //	responses:
 //		'200':
-//			description: "The newly created status."
+//			description: "The newly created status, or the newly scheduled status if scheduled_at was set."
 //			schema:
 //				"$ref": "#/definitions/status"
 //		'400':
@@ -104,6 +104,24 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if form.ScheduledAt != "" {
+		// Status should be published later on,
+		// schedule it instead of creating it now.
+		apiScheduled, errWithCode := m.processor.Status().ScheduledStatusCreate(
+			c.Request.Context(),
+			authed.Account,
+			authed.Application,
+			form,
+		)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		c.JSON(http.StatusOK, apiScheduled)
+		return
+	}
+
 	apiStatus, errWithCode := m.processor.Status().Create(
 		c.Request.Context(),
 		authed.Account,
@@ -0,0 +1,217 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// StatusEditPUTHandler swagger:operation PUT /api/v1/statuses/{id} statusEdit
+//
+// Edit an existing status.
+//
+// The previous revision of the status is kept in its edit history.
+//
+// Visibility, reply target, and interaction settings cannot be changed by an edit.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+//	---
+//	tags:
+//	- statuses
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: Target status ID.
+//		in: path
+//		required: true
+//	-
+//		name: status
+//		type: string
+//		description: |-
+//			Text content of the status.
+//			If media_ids is provided, this becomes optional.
+//			Attaching a poll is optional while status is provided.
+//		in: formData
+//	-
+//		name: media_ids[]
+//		type: array
+//		items:
+//			type: string
+//		description: |-
+//			Array of Attachment ids to be attached as media.
+//			Attachments not included here will be removed from the status.
+//			If provided, status becomes optional, and poll cannot be used.
+//		in: formData
+//	-
+//		name: poll[options][]
+//		type: array
+//		items:
+//			type: string
+//		description: |-
+//			Array of possible poll answers.
+//			If the options are changed, existing votes on the poll will be reset.
+//		in: formData
+//	-
+//		name: poll[expires_in]
+//		type: integer
+//		description: Duration the poll should be open, in seconds.
+//		in: formData
+//	-
+//		name: poll[multiple]
+//		type: boolean
+//		description: Allow multiple choices on this poll.
+//		in: formData
+//	-
+//		name: poll[hide_totals]
+//		type: boolean
+//		description: Hide vote counts until the poll ends.
+//		in: formData
+//	-
+//		name: sensitive
+//		type: boolean
+//		description: Status and attached media should be marked as sensitive.
+//		in: formData
+//	-
+//		name: spoiler_text
+//		type: string
+//		description: |-
+//			Text to be shown as a warning or subject before the actual content.
+//			Statuses are generally collapsed behind this field.
+//		in: formData
+//	-
+//		name: language
+//		type: string
+//		description: ISO 639 language code for this status.
+//		in: formData
+//	-
+//		name: content_type
+//		type: string
+//		description: Content type to use when parsing this status.
+//		in: formData
+//		enum:
+//			- text/plain
+//			- text/markdown
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:statuses
+//
+//	responses:
+//		'200':
+//			description: "The updated status."
+//			schema:
+//				"$ref": "#/definitions/status"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable content
+//		'500':
+//			description: internal server error
+func (m *Module) StatusEditPUTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	targetStatusID := c.Param(IDKey)
+	if targetStatusID == "" {
+		err := errors.New("no status id specified")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.StatusEditRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := validateNormalizeEditStatus(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	apiStatus, errWithCode := m.processor.Status().Edit(
+		c.Request.Context(),
+		authed.Account,
+		targetStatusID,
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, apiStatus)
+}
+
+// validateNormalizeEditStatus checks the form
+// using the same rules as status creation.
+//
+// Side effect: normalizes the post's language tag.
+func validateNormalizeEditStatus(form *apimodel.StatusEditRequest) error {
+	createForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      form.Status,
+			MediaIDs:    form.MediaIDs,
+			Poll:        form.Poll,
+			SpoilerText: form.SpoilerText,
+			Language:    form.Language,
+		},
+	}
+
+	if err := validateNormalizeCreateStatus(createForm); err != nil {
+		return err
+	}
+
+	form.Language = createForm.Language
+	return nil
+}
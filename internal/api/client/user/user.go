@@ -21,6 +21,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -29,6 +30,24 @@ const (
 	BasePath = "/v1/user"
 	// PasswordChangePath is the path for POSTing a password change request.
 	PasswordChangePath = BasePath + "/password_change"
+	// EmailChangePath is the path for POSTing an email address change request.
+	EmailChangePath = BasePath + "/email_change"
+	// EmailNotificationsPath is the path for getting and updating email notification settings.
+	EmailNotificationsPath = BasePath + "/email_notifications"
+	// TwoFactorPath is the path for getting two-factor authentication status.
+	TwoFactorPath = BasePath + "/2fa"
+	// TwoFactorEnrollPath is the path for POSTing a two-factor authentication enrollment request.
+	TwoFactorEnrollPath = TwoFactorPath + "/enroll"
+	// TwoFactorEnablePath is the path for POSTing a two-factor authentication enable request.
+	TwoFactorEnablePath = TwoFactorPath + "/enable"
+	// TwoFactorDisablePath is the path for POSTing a two-factor authentication disable request.
+	TwoFactorDisablePath = TwoFactorPath + "/disable"
+	// WebAuthnPath is the path for listing and registering WebAuthn credentials.
+	WebAuthnPath = BasePath + "/webauthn"
+	// WebAuthnOptionsPath is the path for POSTing a request for WebAuthn credential registration options.
+	WebAuthnOptionsPath = WebAuthnPath + "/options"
+	// WebAuthnPathWithID is the path for renaming and removing one WebAuthn credential.
+	WebAuthnPathWithID = WebAuthnPath + "/:" + apiutil.IDKey
 )
 
 type Module struct {
@@ -43,4 +62,16 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodPost, PasswordChangePath, m.PasswordChangePOSTHandler)
+	attachHandler(http.MethodPost, EmailChangePath, m.EmailChangePOSTHandler)
+	attachHandler(http.MethodGet, EmailNotificationsPath, m.EmailNotificationsGETHandler)
+	attachHandler(http.MethodPatch, EmailNotificationsPath, m.EmailNotificationsPATCHHandler)
+	attachHandler(http.MethodGet, TwoFactorPath, m.TwoFactorGETHandler)
+	attachHandler(http.MethodPost, TwoFactorEnrollPath, m.TwoFactorEnrollPOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorEnablePath, m.TwoFactorEnablePOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorDisablePath, m.TwoFactorDisablePOSTHandler)
+	attachHandler(http.MethodGet, WebAuthnPath, m.WebAuthnCredentialsGETHandler)
+	attachHandler(http.MethodPost, WebAuthnPath, m.WebAuthnCredentialPOSTHandler)
+	attachHandler(http.MethodPost, WebAuthnOptionsPath, m.WebAuthnOptionsPOSTHandler)
+	attachHandler(http.MethodPatch, WebAuthnPathWithID, m.WebAuthnCredentialPATCHHandler)
+	attachHandler(http.MethodDelete, WebAuthnPathWithID, m.WebAuthnCredentialDELETEHandler)
 }
@@ -136,6 +136,11 @@ type AccountCreateRequest struct {
 	// example: en
 	// Required: true
 	Locale string `form:"locale" json:"locale" xml:"locale" binding:"required"`
+	// Invite code to register with. If valid, registration is permitted
+	// even when closed, and the new account will not require approval.
+	// swagger:parameters
+	// example: 9D7S4AB2YKQPXH3M
+	InviteCode string `form:"invite_code" json:"invite_code" xml:"invite_code"`
 	// The IP of the sign up request, will not be parsed from the form.
 	// swagger:parameters
 	// swagger:ignore
@@ -267,3 +272,16 @@ type AccountNoteRequest struct {
 	// Comment to use for the note text.
 	Comment string `form:"comment" json:"comment" xml:"comment"`
 }
+
+// UserMuteCreateUpdateRequest models a request to mute an account,
+// or update the parameters of an existing mute of an account.
+//
+// swagger:ignore
+type UserMuteCreateUpdateRequest struct {
+	// Mute notifications from the account as well as statuses.
+	// Defaults to true.
+	Notifications *bool `form:"notifications" json:"notifications" xml:"notifications"`
+	// Number of seconds from now that the mute should expire.
+	// If 0 or not set, the mute will not expire.
+	Duration *int `form:"duration" json:"duration" xml:"duration"`
+}
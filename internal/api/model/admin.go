@@ -44,9 +44,8 @@ type AdminAccountInfo struct {
 	// example: 192.0.2.1
 	IP *string `json:"ip"`
 	// All known IP addresses associated with this account.
-	// NOT IMPLEMENTED (will always be empty array).
-	// example: []
-	IPs []interface{} `json:"ips"`
+	// Empty for remote accounts.
+	IPs []AdminIP `json:"ips"`
 	// The locale of the account. (ISO 639 Part 1 two-letter language code)
 	// example: en
 	Locale string `json:"locale"`
@@ -64,6 +63,8 @@ type AdminAccountInfo struct {
 	Disabled bool `json:"disabled"`
 	// Whether the account is currently silenced
 	Silenced bool `json:"silenced"`
+	// Whether the account is currently sensitized.
+	Sensitized bool `json:"sensitized"`
 	// Whether the account is currently suspended.
 	Suspended bool `json:"suspended"`
 	// User-level information about the account.
@@ -74,6 +75,48 @@ type AdminAccountInfo struct {
 	InvitedByAccountID string `json:"invited_by_account_id,omitempty"`
 }
 
+// AdminIP models an IP address used by a local account.
+//
+// swagger:model adminIP
+type AdminIP struct {
+	// The IP address.
+	// example: 192.0.2.1
+	IP string `json:"ip"`
+	// When the IP address was last used by the account. (ISO 8601 Datetime)
+	// Empty if not known.
+	// example: 2021-07-30T09:20:25+00:00
+	UsedAt string `json:"used_at"`
+}
+
+// AdminGetAccountsRequest models a request
+// to get a filtered list of accounts.
+//
+// swagger:ignore
+type AdminGetAccountsRequest struct {
+	// Filter for `local` or `remote` accounts.
+	Origin string
+	// Filter for `active`, `pending`, `disabled`,
+	// `silenced`, or `suspended` accounts.
+	Status string
+	// Filter for accounts with staff permissions
+	// (users that can manage reports).
+	Permissions string
+	// Lookup users invited by the account with this ID.
+	InvitedBy string
+	// Search for the given username.
+	Username string
+	// Search for the given display name.
+	DisplayName string
+	// Filter by the given domain.
+	ByDomain string
+	// Lookup a user with this email.
+	Email string
+	// Lookup users with this IP address.
+	IP string
+	// API version to use for this request (1 or 2).
+	APIVersion int
+}
+
 // AdminReport models the admin view of a report.
 //
 // swagger:model adminReport
@@ -170,10 +213,14 @@ type AdminEmoji struct {
 type AdminActionRequest struct {
 	// Category of the target entity.
 	Category string `form:"-" json:"-" xml:"-"`
-	// Type of admin action to take. One of disable, silence, suspend.
+	// Type of admin action to take. One of disable, reenable,
+	// silence, unsilence, sensitize, unsensitize, suspend, unsuspend.
 	Type string `form:"type" json:"type" xml:"type"`
 	// Text describing why an action was taken.
 	Text string `form:"text" json:"text" xml:"text"`
+	// ID of a report targeting the account, which will
+	// be resolved with Text as the action taken comment.
+	ReportID string `form:"report_id" json:"report_id" xml:"report_id"`
 	// ID of the target entity.
 	TargetID string `form:"-" json:"-" xml:"-"`
 }
@@ -229,3 +276,67 @@ type DebugAPUrlResponse struct {
 	// may be an error, may be both!
 	ResponseBody string `json:"response_body"`
 }
+
+// AdminTrend models the admin view of a
+// trending hashtag or status, for review.
+//
+// swagger:model adminTrend
+type AdminTrend struct {
+	// ID of the trend.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Type of the trending item.
+	// example: tag
+	Type string `json:"type"`
+	// Time-decayed trend score, higher = more trendy.
+	// Will be 0 if the item is no longer trending.
+	// example: 4.5
+	Score float64 `json:"score"`
+	// Admin review state of the trend, one of pending, approved, or rejected.
+	// Only approved trends are shown to users.
+	// example: pending
+	Review string `json:"review"`
+	// Time at which the trend was last reviewed (ISO 8601 Datetime).
+	// Will be null if not yet reviewed.
+	// example: 2021-07-30T09:20:25+00:00
+	ReviewedAt *string `json:"reviewed_at"`
+	// The trending hashtag, if type is tag.
+	Tag *Tag `json:"tag,omitempty"`
+	// The trending status, if type is status.
+	Status *Status `json:"status,omitempty"`
+	// The trending link, if type is link.
+	Link *TrendsLink `json:"link,omitempty"`
+}
+
+// AdminEmailDomainBlock models a block of sign-ups
+// (and email changes) using email addresses of a domain.
+//
+// swagger:model adminEmailDomainBlock
+type AdminEmailDomainBlock struct {
+	// The ID of the email domain block.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// The blocked email domain. Subdomains of this domain,
+	// and domains whose mail servers are on this domain,
+	// are blocked too.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Time at which the email domain block was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+	// The ID of the admin account that created this email domain block.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+}
+
+// AdminEmailDomainBlockCreateRequest is the form submitted
+// as a POST to create a new email domain block.
+//
+// swagger:ignore
+type AdminEmailDomainBlockCreateRequest struct {
+	// The email domain to block.
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+}
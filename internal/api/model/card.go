@@ -64,3 +64,13 @@ type Card struct {
 	// A hash computed by the BlurHash algorithm, for generating colorful preview thumbnails when media has not been downloaded yet.
 	Blurhash string `json:"blurhash"`
 }
+
+// TrendsLink represents a link that is currently trending,
+// consisting of its preview card along with usage history.
+//
+// swagger:model trendsLink
+type TrendsLink struct {
+	Card
+	// Daily usage history of this link, most recent day first.
+	History []History `json:"history"`
+}
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// ContentWarningPolicy represents a moderation policy which forces a content
+// warning and/or the sensitive flag onto all statuses authored by one account,
+// or by any account on one domain.
+//
+// swagger:model contentWarningPolicy
+type ContentWarningPolicy struct {
+	// The ID of the content warning policy.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+
+	// The domain targeted by this policy, if it targets a domain.
+	// example: example.org
+	Domain string `json:"domain,omitempty"`
+
+	// The ID of the account targeted by this policy, if it targets an account.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	AccountID string `json:"account_id,omitempty"`
+
+	// The account targeted by this policy, if it targets an account.
+	Account *Account `json:"account,omitempty"`
+
+	// Content warning prepended to the content warning of targeted statuses.
+	// example: posted from a known spam instance
+	ContentWarning string `json:"content_warning"`
+
+	// Targeted statuses are always marked as sensitive.
+	Sensitive bool `json:"sensitive"`
+
+	// The ID of the admin account that created this policy.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+
+	// Time at which the policy was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+}
+
+// ContentWarningPolicyRequest is the form submitted as a POST to create a new content warning policy.
+//
+// swagger:ignore
+type ContentWarningPolicyRequest struct {
+	// Domain to target. Mutually exclusive with account_id.
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+
+	// ID of the account to target. Mutually exclusive with domain.
+	AccountID string `form:"account_id" json:"account_id" xml:"account_id"`
+
+	// Content warning to prepend to targeted statuses.
+	ContentWarning string `form:"content_warning" json:"content_warning" xml:"content_warning"`
+
+	// Always mark targeted statuses as sensitive.
+	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
+}
@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// DeliveryQueueDomain summarises the outgoing
+// federation deliveries queued for one domain.
+//
+// swagger:model deliveryQueueDomain
+type DeliveryQueueDomain struct {
+	// Domain (host) that queued deliveries are addressed to.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Number of deliveries queued for this domain.
+	// example: 12
+	Count int `json:"count"`
+}
+
+// Delivery represents an outgoing federation
+// delivery queued for another attempt.
+//
+// swagger:model delivery
+type Delivery struct {
+	// The ID of the queued delivery.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+	// Time of creation of this delivery (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// URI of the inbox this delivery is addressed to.
+	// example: https://example.org/users/someone/inbox
+	TargetURI string `json:"target_uri"`
+	// Number of failed attempts at this delivery so far.
+	// example: 3
+	Attempts int `json:"attempts"`
+	// Time of the next attempt at this delivery (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	NextAttemptAt string `json:"next_attempt_at"`
+	// Time of the last attempt at this delivery (ISO 8601 Datetime), if any.
+	// example: 2021-07-30T09:20:25+00:00
+	LastAttemptAt string `json:"last_attempt_at,omitempty"`
+	// Error returned by the last attempt at this delivery, if any.
+	// example: POST request to https://example.org/users/someone/inbox failed: status="503 Service Unavailable"
+	LastError string `json:"last_error,omitempty"`
+}
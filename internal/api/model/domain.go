@@ -55,6 +55,15 @@ type DomainPermission struct {
 	// If applicable, the ID of the subscription that caused this domain permission entry to be created.
 	// example: 01FBW25TF5J67JW3HFHZCSD23K
 	SubscriptionID string `json:"subscription_id,omitempty"`
+	// Severity of this domain block: suspend, silence, or noop. Only set for domain blocks.
+	// example: suspend
+	Severity string `json:"severity,omitempty"`
+	// Don't fetch or store media from the blocked domain. Only set for domain blocks.
+	// example: false
+	RejectMedia bool `json:"reject_media,omitempty"`
+	// Ignore reports sent from the blocked domain. Only set for domain blocks.
+	// example: false
+	RejectReports bool `json:"reject_reports,omitempty"`
 	// ID of the account that created this domain permission entry.
 	// example: 01FBW2758ZB6PBR200YPDDJK4C
 	CreatedBy string `json:"created_by,omitempty"`
@@ -85,6 +94,16 @@ type DomainPermissionRequest struct {
 	// Will be visible to requesters at /api/v1/instance/peers if this endpoint is exposed.
 	// example: foss dorks 😫
 	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
+	// Severity of the domain block, one of: suspend, silence (or limit), noop.
+	// Defaults to suspend. Only used for domain blocks.
+	// example: silence
+	Severity string `form:"severity" json:"severity" xml:"severity"`
+	// Don't fetch or store media from the blocked domain. Only used for domain blocks.
+	// example: false
+	RejectMedia bool `form:"reject_media" json:"reject_media" xml:"reject_media"`
+	// Ignore reports sent from the blocked domain. Only used for domain blocks.
+	// example: false
+	RejectReports bool `form:"reject_reports" json:"reject_reports" xml:"reject_reports"`
 }
 
 // DomainBlockCreateRequest is the form submitted as a POST to /api/v1/admin/domain_keys_expire to expire a domain's public keys.
@@ -94,3 +113,82 @@ type DomainKeysExpireRequest struct {
 	// hostname/domain to expire keys for.
 	Domain string `form:"domain" json:"domain" xml:"domain"`
 }
+
+// DomainPermissionSubscription represents an auto-refreshing subscription to a list of domain permissions (allows, blocks).
+//
+// swagger:model domainPermissionSubscription
+type DomainPermissionSubscription struct {
+	// The ID of the domain permission subscription.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Priority of this subscription compared to others of the same permission type. 0-255 (higher = higher priority).
+	// example: 100
+	Priority uint8 `json:"priority"`
+	// Title of this subscription, as set by admin who created or updated it.
+	// example: really cool list of neato pals
+	Title string `json:"title"`
+	// The type of domain permission subscription (allow, block).
+	// example: block
+	PermissionType string `json:"permission_type"`
+	// If true, domain permissions arising from this subscription will be
+	// "adopted" if they're not already owned by another subscription.
+	// example: false
+	AdoptOrphans bool `json:"adopt_orphans"`
+	// ID of the account that created this subscription.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+	// Time at which the subscription was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+	// URI to call in order to fetch the permissions list.
+	// example: https://www.example.org/blocklists/list1.csv
+	URI string `json:"uri"`
+	// MIME content type to use when parsing the permissions list.
+	// example: text/csv
+	ContentType string `json:"content_type"`
+	// (Optional) username to set for basic auth when doing a fetch of URI.
+	// example: admin123
+	FetchUsername string `json:"fetch_username,omitempty"`
+	// (Optional) password to set for basic auth when doing a fetch of URI.
+	// example: admin123
+	FetchPassword string `json:"fetch_password,omitempty"`
+	// Time of the most recent fetch attempt (successful or otherwise) (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	FetchedAt string `json:"fetched_at,omitempty"`
+	// Time of the most recent successful fetch (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	SuccessfullyFetchedAt string `json:"successfully_fetched_at,omitempty"`
+	// If most recent fetch attempt failed, this field will contain an error message related to the fetch attempt.
+	// example: Oopsie doopsie, we made a fucky wucky.
+	// readonly: true
+	Error string `json:"error,omitempty"`
+}
+
+// DomainPermissionSubscriptionRequest is the form submitted as a POST or PATCH
+// to create or update a domain permission subscription.
+//
+// swagger:ignore
+type DomainPermissionSubscriptionRequest struct {
+	// Priority of this subscription compared to others of the same permission type. 0-255 (higher = higher priority).
+	Priority *int `form:"priority" json:"priority"`
+	// Title of this subscription, as set by admin who created or updated it.
+	Title *string `form:"title" json:"title"`
+	// The type of domain permission subscription (allow, block). Only used on creation.
+	PermissionType *string `form:"permission_type" json:"permission_type"`
+	// URI to call in order to fetch the permissions list.
+	URI *string `form:"uri" json:"uri"`
+	// MIME content type to use when parsing the permissions list.
+	ContentType *string `form:"content_type" json:"content_type"`
+	// If true, domain permissions arising from this subscription will be
+	// "adopted" if they're not already owned by another subscription.
+	AdoptOrphans *bool `form:"adopt_orphans" json:"adopt_orphans"`
+	// (Optional) username to set for basic auth when doing a fetch of URI.
+	FetchUsername *string `form:"fetch_username" json:"fetch_username"`
+	// (Optional) password to set for basic auth when doing a fetch of URI.
+	FetchPassword *string `form:"fetch_password" json:"fetch_password"`
+}
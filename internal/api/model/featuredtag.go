@@ -18,15 +18,28 @@
 package model
 
 // FeaturedTag represents a hashtag that is featured on a profile.
+//
+// swagger:model featuredTag
 type FeaturedTag struct {
 	// The internal ID of the featured tag in the database.
+	// example: 01HTZ2V3BKQ8G4M5X8AXMYJ7TN
 	ID string `json:"id"`
 	// The name of the hashtag being featured.
+	// example: gotosocial
 	Name string `json:"name"`
 	// A link to all statuses by a user that contain this hashtag.
 	URL string `json:"url"`
 	// The number of authored statuses containing this hashtag.
 	StatusesCount int `json:"statuses_count"`
 	// The timestamp of the last authored status containing this hashtag. (ISO 8601 Datetime)
-	LastStatusAt string `json:"last_status_at"`
+	// Null if the hashtag has not been used yet.
+	LastStatusAt *string `json:"last_status_at"`
+}
+
+// FeaturedTagCreateRequest models a request to feature a hashtag on a profile.
+//
+// swagger:ignore
+type FeaturedTagCreateRequest struct {
+	// The name of the hashtag to feature, with or without leading `#`.
+	Name string `form:"name" json:"name"`
 }
@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Invite represents an invite code which can be
+// used to register a new account on this instance.
+//
+// swagger:model invite
+type Invite struct {
+	// The ID of the invite.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+	// Secret code to be given when registering a new account.
+	// example: 9D7S4AB2YKQPXH3M
+	Code string `json:"code"`
+	// Time of creation of this invite (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time at which this invite expires (ISO 8601 Datetime).
+	// Null if the invite does not expire.
+	// example: 2021-08-06T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+	// Maximum number of times this invite can be used.
+	// Null if the invite can be used an unlimited number of times.
+	// example: 5
+	MaxUses *int `json:"max_uses"`
+	// Number of times this invite has been used so far.
+	// example: 2
+	Uses int `json:"uses"`
+	// Whether this invite can still be used to register a new account,
+	// ie., it has neither expired, nor reached its maximum number of uses.
+	// example: true
+	Usable bool `json:"usable"`
+}
+
+// AdminInvite models the admin view of an invite,
+// including who created it, and who used it.
+//
+// swagger:model adminInvite
+type AdminInvite struct {
+	Invite
+	// The account that created this invite.
+	Account *AdminAccountInfo `json:"account"`
+	// Accounts that were registered using this invite.
+	InvitedAccounts []*AdminAccountInfo `json:"invited_accounts"`
+}
+
+// InviteCreateRequest models a request to create an invite.
+//
+// swagger:ignore
+type InviteCreateRequest struct {
+	// Maximum number of times the invite can be used. 0 or unset means unlimited.
+	MaxUses *int `form:"max_uses" json:"max_uses" xml:"max_uses"`
+	// Number of seconds from now that the invite should expire. 0 or unset means never.
+	ExpiresIn *int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+}
@@ -18,6 +18,8 @@
 package model
 
 // PushSubscription represents a subscription to the push streaming server.
+//
+// swagger:model webPushSubscription
 type PushSubscription struct {
 	// The id of the push subscription in the database.
 	ID string `json:"id"`
@@ -27,12 +29,20 @@ type PushSubscription struct {
 	ServerKey string `json:"server_key"`
 	// Which alerts should be delivered to the endpoint.
 	Alerts *PushSubscriptionAlerts `json:"alerts"`
+	// Which accounts to receive push notifications from.
+	// all = all accounts, followed = accounts you follow,
+	// follower = accounts following you, none = no accounts.
+	Policy string `json:"policy"`
 }
 
 // PushSubscriptionAlerts represents the specific alerts that this push subscription will give.
+//
+// swagger:model webPushSubscriptionAlerts
 type PushSubscriptionAlerts struct {
 	// Receive a push notification when someone has followed you?
 	Follow bool `json:"follow"`
+	// Receive a push notification when someone has requested to follow you?
+	FollowRequest bool `json:"follow_request"`
 	// Receive a push notification when a status you created has been favourited by someone else?
 	Favourite bool `json:"favourite"`
 	// Receive a push notification when someone else has mentioned you in a status?
@@ -41,4 +51,89 @@ type PushSubscriptionAlerts struct {
 	Reblog bool `json:"reblog"`
 	// Receive a push notification when a poll you voted in or created has ended?
 	Poll bool `json:"poll"`
+	// Receive a push notification when a subscribed account posts a status?
+	Status bool `json:"status"`
+	// Receive a push notification when a new user has signed up (admins only)?
+	AdminSignup bool `json:"admin.sign_up"`
+}
+
+// PushSubscriptionCreateRequest models a request to create a push subscription.
+//
+// swagger:ignore
+type PushSubscriptionCreateRequest struct {
+	// Subscription details, when parsed from JSON.
+	Subscription *PushSubscriptionRequestSubscription `form:"-" json:"subscription" xml:"subscription"`
+	// Subscription details, when parsed from form data.
+	SubscriptionEndpoint   string `form:"subscription[endpoint]" json:"-" xml:"-"`
+	SubscriptionKeysP256dh string `form:"subscription[keys][p256dh]" json:"-" xml:"-"`
+	SubscriptionKeysAuth   string `form:"subscription[keys][auth]" json:"-" xml:"-"`
+
+	PushSubscriptionUpdateRequest
+}
+
+// PushSubscriptionRequestSubscription models
+// the subscription details of a create request.
+//
+// swagger:ignore
+type PushSubscriptionRequestSubscription struct {
+	// Where push alerts will be sent to.
+	Endpoint string `json:"endpoint" xml:"endpoint"`
+	// Keys of the user agent used to encrypt push alerts.
+	Keys PushSubscriptionRequestKeys `json:"keys" xml:"keys"`
+}
+
+// PushSubscriptionRequestKeys models the
+// user agent keys of a create request.
+//
+// swagger:ignore
+type PushSubscriptionRequestKeys struct {
+	// Base64 encoded P-256 ECDH public key of the user agent.
+	P256dh string `json:"p256dh" xml:"p256dh"`
+	// Base64 encoded authentication secret of the user agent.
+	Auth string `json:"auth" xml:"auth"`
+}
+
+// PushSubscriptionUpdateRequest models a request to
+// update the alerts and policy of a push subscription.
+//
+// swagger:ignore
+type PushSubscriptionUpdateRequest struct {
+	// Alerts and policy, when parsed from JSON.
+	Data *PushSubscriptionRequestData `form:"-" json:"data" xml:"data"`
+	// Alerts and policy, when parsed from form data.
+	DataAlertsFollow        *bool   `form:"data[alerts][follow]" json:"-" xml:"-"`
+	DataAlertsFollowRequest *bool   `form:"data[alerts][follow_request]" json:"-" xml:"-"`
+	DataAlertsFavourite     *bool   `form:"data[alerts][favourite]" json:"-" xml:"-"`
+	DataAlertsMention       *bool   `form:"data[alerts][mention]" json:"-" xml:"-"`
+	DataAlertsReblog        *bool   `form:"data[alerts][reblog]" json:"-" xml:"-"`
+	DataAlertsPoll          *bool   `form:"data[alerts][poll]" json:"-" xml:"-"`
+	DataAlertsStatus        *bool   `form:"data[alerts][status]" json:"-" xml:"-"`
+	DataAlertsAdminSignup   *bool   `form:"data[alerts][admin.sign_up]" json:"-" xml:"-"`
+	DataPolicy              *string `form:"data[policy]" json:"-" xml:"-"`
+}
+
+// PushSubscriptionRequestData models the
+// alerts and policy of a create or update request.
+//
+// swagger:ignore
+type PushSubscriptionRequestData struct {
+	// Which alerts should be delivered to the endpoint.
+	Alerts *PushSubscriptionRequestAlerts `json:"alerts" xml:"alerts"`
+	// Which accounts to receive push notifications from.
+	Policy *string `json:"policy" xml:"policy"`
+}
+
+// PushSubscriptionRequestAlerts models the alerts of a
+// create or update request. Unset alerts are left as-is.
+//
+// swagger:ignore
+type PushSubscriptionRequestAlerts struct {
+	Follow        *bool `json:"follow" xml:"follow"`
+	FollowRequest *bool `json:"follow_request" xml:"follow_request"`
+	Favourite     *bool `json:"favourite" xml:"favourite"`
+	Mention       *bool `json:"mention" xml:"mention"`
+	Reblog        *bool `json:"reblog" xml:"reblog"`
+	Poll          *bool `json:"poll" xml:"poll"`
+	Status        *bool `json:"status" xml:"status"`
+	AdminSignup   *bool `json:"admin.sign_up" xml:"admin.sign_up"`
 }
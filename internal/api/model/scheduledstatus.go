@@ -18,21 +18,68 @@
 package model
 
 // ScheduledStatus represents a status that will be published at a future scheduled date.
+//
+// swagger:model scheduledStatus
 type ScheduledStatus struct {
-	ID               string        `json:"id"`
-	ScheduledAt      string        `json:"scheduled_at"`
-	Params           *StatusParams `json:"params"`
-	MediaAttachments []Attachment  `json:"media_attachments"`
+	// ID of the scheduled status.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Time at which the status will be published (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	ScheduledAt string `json:"scheduled_at"`
+	// Parameters that will be used to publish the status.
+	Params *StatusParams `json:"params"`
+	// Media that will be attached to the status.
+	MediaAttachments []Attachment `json:"media_attachments"`
 }
 
 // StatusParams represents parameters for a scheduled status.
+//
+// swagger:model statusParams
 type StatusParams struct {
-	Text          string   `json:"text"`
-	InReplyToID   string   `json:"in_reply_to_id,omitempty"`
-	MediaIDs      []string `json:"media_ids,omitempty"`
-	Sensitive     bool     `json:"sensitive,omitempty"`
-	SpoilerText   string   `json:"spoiler_text,omitempty"`
-	Visibility    string   `json:"visibility"`
-	ScheduledAt   string   `json:"scheduled_at,omitempty"`
-	ApplicationID string   `json:"application_id"`
+	// Text content of the status.
+	Text string `json:"text"`
+	// Poll that will be attached to the status.
+	// nullable: true
+	Poll *StatusParamsPoll `json:"poll"`
+	// ID of the status being replied to, if any.
+	InReplyToID string `json:"in_reply_to_id,omitempty"`
+	// IDs of media attachments that will be attached to the status.
+	MediaIDs []string `json:"media_ids,omitempty"`
+	// Status and attached media will be marked as sensitive.
+	Sensitive bool `json:"sensitive,omitempty"`
+	// Text to be shown as a warning or subject before the actual content.
+	SpoilerText string `json:"spoiler_text,omitempty"`
+	// Visibility of the status.
+	Visibility string `json:"visibility"`
+	// ISO 639 language code for the status.
+	// nullable: true
+	Language *string `json:"language"`
+	// Time at which the status will be published (ISO 8601 Datetime).
+	ScheduledAt string `json:"scheduled_at,omitempty"`
+	// ID of the application used to schedule the status.
+	ApplicationID string `json:"application_id"`
+}
+
+// StatusParamsPoll represents the poll parameters of a scheduled status.
+//
+// swagger:model statusParamsPoll
+type StatusParamsPoll struct {
+	// Options of the poll.
+	Options []string `json:"options"`
+	// Duration the poll will be open, in seconds.
+	ExpiresIn int `json:"expires_in"`
+	// Poll allows multiple choices.
+	Multiple bool `json:"multiple"`
+	// Poll hides vote counts until it ends.
+	HideTotals bool `json:"hide_totals"`
+}
+
+// ScheduledStatusUpdateRequest models a request to update a scheduled status.
+//
+// swagger:ignore
+type ScheduledStatusUpdateRequest struct {
+	// ISO 8601 Datetime at which the status should be published.
+	// Must be at least 5 minutes in the future.
+	ScheduledAt string `form:"scheduled_at" json:"scheduled_at" xml:"scheduled_at"`
 }
@@ -29,6 +29,10 @@ type Status struct {
 	// The date when this status was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at"`
+	// The date when this status was last edited (ISO 8601 Datetime).
+	// Omitted if the status has never been edited.
+	// example: 2021-07-30T09:20:25+00:00
+	EditedAt *string `json:"edited_at,omitempty"`
 	// ID of the status being replied to.
 	// example: 01FBVD42CQ3ZEEVMW180SBX03B
 	// nullable: true
@@ -100,6 +104,8 @@ type Status struct {
 	// so the user may redraft from the source text without the client having to reverse-engineer
 	// the original text from the HTML content.
 	Text string `json:"text,omitempty"`
+	// A list of filters that matched this status and why they matched, if there are any such filters.
+	Filtered []FilterResult `json:"filtered,omitempty"`
 
 	// Additional fields not exposed via JSON
 	// (used only internally for templating etc).
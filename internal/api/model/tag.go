@@ -27,8 +27,11 @@ type Tag struct {
 	// Web link to the hashtag.
 	// example: https://example.org/tags/helloworld
 	URL string `json:"url"`
-	// History of this hashtag's usage.
-	// Currently just a stub, if provided will always be an empty array.
-	// example: []
-	History *[]any `json:"history,omitempty"`
+	// History of this hashtag's usage, most recent day first.
+	// Only populated for trending hashtags, otherwise an empty array if provided.
+	History *[]History `json:"history,omitempty"`
+	// Whether the requesting account follows this hashtag.
+	// Only set if the request was made with an authorized account.
+	// example: true
+	Following *bool `json:"following,omitempty"`
 }
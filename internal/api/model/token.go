@@ -33,3 +33,61 @@ type Token struct {
 	// example: 1627644520
 	CreatedAt int64 `json:"created_at"`
 }
+
+// TokenInfo represents an OAuth access token issued to an
+// application on behalf of the user, without the token itself.
+//
+// swagger:model tokenInfo
+type TokenInfo struct {
+	// Database ID of this token.
+	// example: 01F8MGTQW4DKTDF8SW5CT9HYGA
+	ID string `json:"id"`
+	// When the token was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Approximate time (accurate to within a few minutes)
+	// when the token was last used (ISO 8601 Datetime),
+	// or null if the token hasn't been used since tracking
+	// token usage was introduced.
+	// example: 2021-07-30T09:20:25+00:00
+	LastUsed *string `json:"last_used"`
+	// OAuth scopes granted by this token, space-separated.
+	// example: read write push
+	Scope string `json:"scope"`
+	// Application that this token was issued to.
+	Application *Application `json:"application"`
+	// This token is the one being used to make this request.
+	Current bool `json:"current"`
+}
+
+// AuthorizedApplication represents an application which the
+// user has granted access to their account, through one or
+// more OAuth access tokens.
+//
+// swagger:model authorizedApplication
+type AuthorizedApplication struct {
+	// The ID of the application.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// The name of the application.
+	// example: Tusky
+	Name string `json:"name"`
+	// The website associated with the application (url)
+	// example: https://tusky.app
+	Website string `json:"website,omitempty"`
+	// OAuth scopes granted to the application
+	// across all of its tokens.
+	// example: ["read","write","push"]
+	Scopes []string `json:"scopes"`
+	// When the application was first authorized, ie., when
+	// its oldest current token was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Approximate time when one of the application's tokens was last
+	// used (ISO 8601 Datetime), or null if this isn't known.
+	// example: 2021-07-30T09:20:25+00:00
+	LastUsed *string `json:"last_used"`
+	// Number of access tokens issued to this application.
+	// example: 1
+	Tokens int `json:"tokens"`
+}
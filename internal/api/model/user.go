@@ -17,6 +17,20 @@
 
 package model
 
+// User represents the private information of a
+// local user, which isn't part of their account.
+//
+// swagger:model user
+type User struct {
+	// The user's current, confirmed email address.
+	// example: someone@example.org
+	Email string `json:"email"`
+	// The email address the user is changing to,
+	// which they have yet to confirm, if any.
+	// example: someone.else@example.org
+	UnconfirmedEmail string `json:"unconfirmed_email,omitempty"`
+}
+
 // PasswordChangeRequest models user password change parameters.
 //
 // swagger:parameters userPasswordChange
@@ -34,3 +48,164 @@ type PasswordChangeRequest struct {
 	// required: true
 	NewPassword string `form:"new_password" json:"new_password" xml:"new_password" validation:"required"`
 }
+
+// EmailChangeRequest models user email change parameters.
+//
+// swagger:parameters userEmailChange
+type EmailChangeRequest struct {
+	// User's current password, for verification.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+	// Desired new email address.
+	// A confirmation email will be sent to this address,
+	// and the change only takes effect once it's confirmed.
+	//
+	// in: formData
+	// required: true
+	NewEmail string `form:"new_email" json:"new_email" xml:"new_email" validation:"required"`
+}
+
+// EmailNotifications represents the email
+// notification settings of a local user.
+//
+// swagger:model emailNotifications
+type EmailNotifications struct {
+	// Email the user when someone follows them.
+	Follow bool `json:"follow"`
+	// Email the user when someone requests to follow them.
+	FollowRequest bool `json:"follow_request"`
+	// Email the user when someone mentions them in a non-direct status.
+	Mention bool `json:"mention"`
+	// Email the user when someone mentions them in a direct message.
+	Direct bool `json:"direct"`
+	// Email the user when a report they submitted is closed.
+	Report bool `json:"report"`
+	// How often to email the user a digest of their unread notifications.
+	// enum:
+	//	- never
+	//	- daily
+	//	- weekly
+	// example: weekly
+	Digest string `json:"digest"`
+}
+
+// EmailNotificationsUpdateRequest models user email notification
+// settings update parameters. Settings not provided are unchanged.
+//
+// swagger:parameters userEmailNotificationsUpdate
+type EmailNotificationsUpdateRequest struct {
+	// Email the user when someone follows them.
+	//
+	// in: formData
+	Follow *bool `form:"follow" json:"follow" xml:"follow"`
+	// Email the user when someone requests to follow them.
+	//
+	// in: formData
+	FollowRequest *bool `form:"follow_request" json:"follow_request" xml:"follow_request"`
+	// Email the user when someone mentions them in a non-direct status.
+	//
+	// in: formData
+	Mention *bool `form:"mention" json:"mention" xml:"mention"`
+	// Email the user when someone mentions them in a direct message.
+	//
+	// in: formData
+	Direct *bool `form:"direct" json:"direct" xml:"direct"`
+	// Email the user when a report they submitted is closed.
+	//
+	// in: formData
+	Report *bool `form:"report" json:"report" xml:"report"`
+	// How often to email the user a digest of their unread notifications.
+	//
+	// in: formData
+	// enum:
+	//	- never
+	//	- daily
+	//	- weekly
+	Digest *string `form:"digest" json:"digest" xml:"digest"`
+}
+
+// TwoFactor represents the two-factor
+// authentication status of a local user.
+//
+// swagger:model twoFactor
+type TwoFactor struct {
+	// Two-factor authentication is enabled, and
+	// required when signing in with a password.
+	Enabled bool `json:"enabled"`
+	// When two-factor authentication was enabled (ISO 8601 Datetime).
+	// Omitted if not enabled.
+	// example: 2021-07-30T09:20:25+00:00
+	EnabledAt string `json:"enabled_at,omitempty"`
+	// Number of unused recovery codes remaining.
+	RecoveryCodesRemaining int `json:"recovery_codes_remaining"`
+}
+
+// TwoFactorEnrollment contains a newly generated two-factor
+// authentication secret, to be added to an authenticator app.
+//
+// swagger:model twoFactorEnrollment
+type TwoFactorEnrollment struct {
+	// Base32 encoded TOTP secret, for entering
+	// into an authenticator app manually.
+	// example: JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+	Secret string `json:"secret"`
+	// otpauth:// provisioning URI containing the secret,
+	// to be displayed as a QR code for scanning into an
+	// authenticator app.
+	// example: otpauth://totp/example.org:someone@example.org?algorithm=SHA1&digits=6&issuer=example.org&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+	URI string `json:"uri"`
+}
+
+// TwoFactorRecoveryCodes contains single-use recovery codes, which
+// can be used to sign in in place of a code from an authenticator
+// app. These are only shown once, so should be stored somewhere safe.
+//
+// swagger:model twoFactorRecoveryCodes
+type TwoFactorRecoveryCodes struct {
+	// Single-use recovery codes.
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorEnrollRequest models a request to
+// enroll in two-factor authentication.
+//
+// swagger:parameters userTwoFactorEnroll
+type TwoFactorEnrollRequest struct {
+	// User's current password, for verification.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+}
+
+// TwoFactorEnableRequest models a request
+// to enable two-factor authentication.
+//
+// swagger:parameters userTwoFactorEnable
+type TwoFactorEnableRequest struct {
+	// User's current password, for verification.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+	// Code from an authenticator app, to confirm that
+	// the secret from enrollment was added correctly.
+	//
+	// in: formData
+	// required: true
+	Code string `form:"code" json:"code" xml:"code" validation:"required"`
+}
+
+// TwoFactorDisableRequest models a request
+// to disable two-factor authentication.
+//
+// swagger:parameters userTwoFactorDisable
+type TwoFactorDisableRequest struct {
+	// User's current password, for verification.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+}
@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// WebAuthnCredential represents a WebAuthn credential
+// (passkey or security key) registered by a local user.
+//
+// swagger:model webAuthnCredential
+type WebAuthnCredential struct {
+	// The ID of the credential.
+	// example: 01HTQ3K2ZS8V5Y4W9B1XGJ6N0C
+	ID string `json:"id"`
+	// Name given to the credential by the user.
+	// example: yubikey
+	Name string `json:"name"`
+	// When the credential was registered (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// When the credential was last used to sign in (ISO 8601 Datetime).
+	// Null if never used.
+	// example: 2021-07-30T09:20:25+00:00
+	LastUsedAt *string `json:"last_used_at"`
+}
+
+// WebAuthnOptionsRequest models a request for
+// options to register a new WebAuthn credential.
+//
+// swagger:parameters userWebAuthnOptions
+type WebAuthnOptionsRequest struct {
+	// User's current password, for verification.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+}
+
+// WebAuthnCredentialCreateRequest models a request to register a new
+// WebAuthn credential, containing the response of the browser's
+// navigator.credentials.create() to the registration options.
+//
+// swagger:parameters userWebAuthnCredentialCreate
+type WebAuthnCredentialCreateRequest struct {
+	// Name to give the credential, to tell it apart from others.
+	//
+	// in: formData
+	Name string `form:"name" json:"name" xml:"name"`
+	// Base64url encoded clientDataJSON of the credential's attestation response.
+	//
+	// in: formData
+	// required: true
+	ClientDataJSON string `form:"client_data_json" json:"client_data_json" xml:"client_data_json" validation:"required"`
+	// Base64url encoded attestationObject of the credential's attestation response.
+	//
+	// in: formData
+	// required: true
+	AttestationObject string `form:"attestation_object" json:"attestation_object" xml:"attestation_object" validation:"required"`
+}
+
+// WebAuthnCredentialUpdateRequest models
+// a request to rename a WebAuthn credential.
+//
+// swagger:parameters userWebAuthnCredentialUpdate
+type WebAuthnCredentialUpdateRequest struct {
+	// New name for the credential.
+	//
+	// in: formData
+	// required: true
+	Name string `form:"name" json:"name" xml:"name" validation:"required"`
+}
+
+// WebAuthnCredentialDeleteRequest models
+// a request to remove a WebAuthn credential.
+//
+// swagger:parameters userWebAuthnCredentialDelete
+type WebAuthnCredentialDeleteRequest struct {
+	// User's current password, for verification.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+}
@@ -45,6 +45,30 @@ const (
 
 	OnlyOtherAccountsKey = "only_other_accounts"
 
+	/* Admin accounts keys */
+
+	AdminAccountsActiveKey      = "active"
+	AdminAccountsByDomainKey    = "by_domain"
+	AdminAccountsDisabledKey    = "disabled"
+	AdminAccountsDisplayNameKey = "display_name"
+	AdminAccountsEmailKey       = "email"
+	AdminAccountsInvitedByKey   = "invited_by"
+	AdminAccountsIPKey          = "ip"
+	AdminAccountsOriginKey      = "origin"
+	AdminAccountsPendingKey     = "pending"
+	AdminAccountsPermissionsKey = "permissions"
+	AdminAccountsRemoteKey      = "remote"
+	AdminAccountsSilencedKey    = "silenced"
+	AdminAccountsStaffKey       = "staff"
+	AdminAccountsStatusKey      = "status"
+	AdminAccountsSuspendedKey   = "suspended"
+	AdminAccountsUsernameKey    = "username"
+
+	/* Directory keys */
+
+	DirectoryOffsetKey = "offset"
+	DirectoryOrderKey  = "order"
+
 	/* Search keys */
 
 	SearchExcludeUnreviewedKey = "exclude_unreviewed"
@@ -59,6 +83,11 @@ const (
 
 	TagNameKey = "tag_name"
 
+	/* Trends keys */
+
+	TrendsOffsetKey = "offset"
+	TrendTypeKey    = "trend_type"
+
 	/* Web endpoint keys */
 
 	WebUsernameKey = "username"
@@ -66,8 +95,9 @@ const (
 
 	/* Domain permission keys */
 
-	DomainPermissionExportKey = "export"
-	DomainPermissionImportKey = "import"
+	DomainPermissionExportKey         = "export"
+	DomainPermissionImportKey         = "import"
+	DomainPermissionRemoveChildrenKey = "remove_children"
 )
 
 /*
@@ -95,6 +125,17 @@ func ParseMaxID(value string, defaultValue string) string {
 	return value
 }
 
+// ParseAdminAccountsFlag parses one of the boolean
+// filter flags of the v1 admin accounts endpoint
+// (local, remote, active, pending, etc) at key.
+func ParseAdminAccountsFlag(value string, key string) (bool, gtserror.WithCode) {
+	return parseBool(value, false, key)
+}
+
+func ParseDirectoryOffset(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, DirectoryOffsetKey)
+}
+
 func ParseSearchExcludeUnreviewed(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, SearchExcludeUnreviewedKey)
 }
@@ -107,6 +148,10 @@ func ParseSearchOffset(value string, defaultValue int, max, min int) (int, gtser
 	return parseInt(value, defaultValue, max, min, SearchOffsetKey)
 }
 
+func ParseTrendsOffset(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, TrendsOffsetKey)
+}
+
 func ParseSearchResolve(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, SearchResolveKey)
 }
@@ -119,6 +164,10 @@ func ParseDomainPermissionImport(value string, defaultValue bool) (bool, gtserro
 	return parseBool(value, defaultValue, DomainPermissionImportKey)
 }
 
+func ParseDomainPermissionRemoveChildren(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, DomainPermissionRemoveChildrenKey)
+}
+
 func ParseOnlyOtherAccounts(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, OnlyOtherAccountsKey)
 }
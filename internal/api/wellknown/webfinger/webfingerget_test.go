@@ -85,7 +85,7 @@ func (suite *WebfingerGetTestSuite) funkifyAccountDomain(host string, accountDom
 	config.SetAccountDomain(accountDomain)
 	testrig.StopWorkers(&suite.state)
 	testrig.StartNoopWorkers(&suite.state)
-	suite.processor = processing.NewProcessor(cleaner.New(&suite.state), suite.tc, suite.federator, testrig.NewTestOauthServer(suite.db), testrig.NewTestMediaManager(&suite.state), &suite.state, suite.emailSender)
+	suite.processor = processing.NewProcessor(cleaner.New(&suite.state), suite.tc, suite.federator, testrig.NewTestOauthServer(suite.db), testrig.NewTestMediaManager(&suite.state), &suite.state, suite.emailSender, testrig.NewWebPushSender(nil), testrig.NewCardFetcher(nil))
 	suite.webfingerModule = webfinger.New(suite.processor)
 	testrig.StartNoopWorkers(&suite.state)
 
@@ -57,10 +57,15 @@ func (c *Caches) Init() {
 	c.initBlock()
 	c.initBlockIDs()
 	c.initBoostOfIDs()
+	c.initCard()
+	c.initContentWarningPolicy()
 	c.initDomainAllow()
 	c.initDomainBlock()
 	c.initEmoji()
 	c.initEmojiCategory()
+	c.initFilter()
+	c.initFilterKeyword()
+	c.initFilterStatus()
 	c.initFollow()
 	c.initFollowIDs()
 	c.initFollowRequest()
@@ -84,6 +89,7 @@ func (c *Caches) Init() {
 	c.initStatusFaveIDs()
 	c.initTombstone()
 	c.initUser()
+	c.initUserMute()
 	c.initWebfinger()
 	c.initVisibility()
 }
@@ -117,8 +123,13 @@ func (c *Caches) Sweep(threshold float64) {
 	c.GTS.AccountNote.Trim(threshold)
 	c.GTS.Block.Trim(threshold)
 	c.GTS.BlockIDs.Trim(threshold)
+	c.GTS.Card.Trim(threshold)
+	c.GTS.ContentWarningPolicy.Trim(threshold)
 	c.GTS.Emoji.Trim(threshold)
 	c.GTS.EmojiCategory.Trim(threshold)
+	c.GTS.Filter.Trim(threshold)
+	c.GTS.FilterKeyword.Trim(threshold)
+	c.GTS.FilterStatus.Trim(threshold)
 	c.GTS.Follow.Trim(threshold)
 	c.GTS.FollowIDs.Trim(threshold)
 	c.GTS.FollowRequest.Trim(threshold)
@@ -138,5 +149,6 @@ func (c *Caches) Sweep(threshold float64) {
 	c.GTS.ThreadMute.Trim(threshold)
 	c.GTS.Tombstone.Trim(threshold)
 	c.GTS.User.Trim(threshold)
+	c.GTS.UserMute.Trim(threshold)
 	c.Visibility.Trim(threshold)
 }
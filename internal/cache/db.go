@@ -55,18 +55,45 @@ type GTSCaches struct {
 	// BoostOfIDs provides access to the boost of IDs list database cache.
 	BoostOfIDs *SliceCache[string]
 
+	// Card provides access to the gtsmodel Card database cache.
+	Card structr.Cache[*gtsmodel.Card]
+
+	// ContentWarningPolicy provides access to the gtsmodel ContentWarningPolicy database cache.
+	ContentWarningPolicy structr.Cache[*gtsmodel.ContentWarningPolicy]
+
 	// DomainAllow provides access to the domain allow database cache.
 	DomainAllow *domain.Cache
 
 	// DomainBlock provides access to the domain block database cache.
 	DomainBlock *domain.Cache
 
+	// DomainSilence provides access to the cache of
+	// domains with a "silence" severity domain block.
+	DomainSilence *domain.Cache
+
+	// DomainRejectMedia provides access to the cache of
+	// domains with a domain block rejecting their media.
+	DomainRejectMedia *domain.Cache
+
+	// DomainRejectReports provides access to the cache of
+	// domains with a domain block rejecting their reports.
+	DomainRejectReports *domain.Cache
+
 	// Emoji provides access to the gtsmodel Emoji database cache.
 	Emoji structr.Cache[*gtsmodel.Emoji]
 
 	// EmojiCategory provides access to the gtsmodel EmojiCategory database cache.
 	EmojiCategory structr.Cache[*gtsmodel.EmojiCategory]
 
+	// Filter provides access to the gtsmodel Filter database cache.
+	Filter structr.Cache[*gtsmodel.Filter]
+
+	// FilterKeyword provides access to the gtsmodel FilterKeyword database cache.
+	FilterKeyword structr.Cache[*gtsmodel.FilterKeyword]
+
+	// FilterStatus provides access to the gtsmodel FilterStatus database cache.
+	FilterStatus structr.Cache[*gtsmodel.FilterStatus]
+
 	// Follow provides access to the gtsmodel Follow database cache.
 	Follow structr.Cache[*gtsmodel.Follow]
 
@@ -144,6 +171,9 @@ type GTSCaches struct {
 	// User provides access to the gtsmodel User database cache.
 	User structr.Cache[*gtsmodel.User]
 
+	// UserMute provides access to the gtsmodel UserMute database cache.
+	UserMute structr.Cache[*gtsmodel.UserMute]
+
 	// Webfinger provides access to the webfinger URL cache.
 	// TODO: move out of GTS caches since unrelated to DB.
 	Webfinger *ttl.Cache[string, string] // TTL=24hr, sweep=5min
@@ -339,12 +369,81 @@ func (c *Caches) initBoostOfIDs() {
 	)}
 }
 
+func (c *Caches) initCard() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofCard(), // model in-mem size.
+		config.GetCacheCardMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(c1 *gtsmodel.Card) *gtsmodel.Card {
+		c2 := new(gtsmodel.Card)
+		*c2 = *c1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/card.go.
+		c2.Image = nil
+
+		return c2
+	}
+
+	c.GTS.Card.Init(structr.Config[*gtsmodel.Card]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "URL"},
+			{Fields: "ImageID"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		CopyValue: copyF,
+	})
+}
+
+func (c *Caches) initContentWarningPolicy() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofContentWarningPolicy(), // model in-mem size.
+		config.GetCacheContentWarningPolicyMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(p1 *gtsmodel.ContentWarningPolicy) *gtsmodel.ContentWarningPolicy {
+		p2 := new(gtsmodel.ContentWarningPolicy)
+		*p2 = *p1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/contentwarningpolicy.go.
+		p2.Account = nil
+
+		return p2
+	}
+
+	c.GTS.ContentWarningPolicy.Init(structr.Config[*gtsmodel.ContentWarningPolicy]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "AccountID"},
+			{Fields: "Domain"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		CopyValue: copyF,
+	})
+}
+
 func (c *Caches) initDomainAllow() {
 	c.GTS.DomainAllow = new(domain.Cache)
 }
 
 func (c *Caches) initDomainBlock() {
 	c.GTS.DomainBlock = new(domain.Cache)
+	c.GTS.DomainSilence = new(domain.Cache)
+	c.GTS.DomainRejectMedia = new(domain.Cache)
+	c.GTS.DomainRejectReports = new(domain.Cache)
 }
 
 func (c *Caches) initEmoji() {
@@ -409,6 +508,105 @@ func (c *Caches) initEmojiCategory() {
 	})
 }
 
+func (c *Caches) initFilter() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofFilter(), // model in-mem size.
+		config.GetCacheFilterMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(filter1 *gtsmodel.Filter) *gtsmodel.Filter {
+		filter2 := new(gtsmodel.Filter)
+		*filter2 = *filter1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/filter.go.
+		filter2.Keywords = nil
+		filter2.Statuses = nil
+
+		return filter2
+	}
+
+	c.GTS.Filter.Init(structr.Config[*gtsmodel.Filter]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "AccountID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		CopyValue: copyF,
+	})
+}
+
+func (c *Caches) initFilterKeyword() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofFilterKeyword(), // model in-mem size.
+		config.GetCacheFilterKeywordMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(filterKeyword1 *gtsmodel.FilterKeyword) *gtsmodel.FilterKeyword {
+		filterKeyword2 := new(gtsmodel.FilterKeyword)
+		*filterKeyword2 = *filterKeyword1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/filter.go.
+		filterKeyword2.Filter = nil
+
+		return filterKeyword2
+	}
+
+	c.GTS.FilterKeyword.Init(structr.Config[*gtsmodel.FilterKeyword]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "AccountID", Multiple: true},
+			{Fields: "FilterID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		CopyValue: copyF,
+	})
+}
+
+func (c *Caches) initFilterStatus() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofFilterStatus(), // model in-mem size.
+		config.GetCacheFilterStatusMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(filterStatus1 *gtsmodel.FilterStatus) *gtsmodel.FilterStatus {
+		filterStatus2 := new(gtsmodel.FilterStatus)
+		*filterStatus2 = *filterStatus1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/filter.go.
+		filterStatus2.Filter = nil
+
+		return filterStatus2
+	}
+
+	c.GTS.FilterStatus.Init(structr.Config[*gtsmodel.FilterStatus]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "AccountID", Multiple: true},
+			{Fields: "FilterID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		CopyValue: copyF,
+	})
+}
+
 func (c *Caches) initFollow() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -886,6 +1084,7 @@ func (c *Caches) initStatus() {
 		s2.BoostOf = nil
 		s2.BoostOfAccount = nil
 		s2.Poll = nil
+		s2.Card = nil
 		s2.Attachments = nil
 		s2.Tags = nil
 		s2.Mentions = nil
@@ -1068,6 +1267,7 @@ func (c *Caches) initUser() {
 			{Fields: "Email"},
 			{Fields: "ConfirmationToken"},
 			{Fields: "ExternalID"},
+			{Fields: "EmailUnsubscribeToken"},
 		},
 		MaxSize:    cap,
 		IgnoreErr:  ignoreErrors,
@@ -1076,6 +1276,42 @@ func (c *Caches) initUser() {
 	})
 }
 
+func (c *Caches) initUserMute() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofUserMute(), // model in-mem size.
+		config.GetCacheUserMuteMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(u1 *gtsmodel.UserMute) *gtsmodel.UserMute {
+		u2 := new(gtsmodel.UserMute)
+		*u2 = *u1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/relationship_mute.go.
+		u2.Account = nil
+		u2.TargetAccount = nil
+
+		return u2
+	}
+
+	c.GTS.UserMute.Init(structr.Config[*gtsmodel.UserMute]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "AccountID,TargetAccountID"},
+			{Fields: "AccountID", Multiple: true},
+			{Fields: "TargetAccountID", Multiple: true},
+		},
+		MaxSize:    cap,
+		IgnoreErr:  ignoreErrors,
+		CopyValue:  copyF,
+		Invalidate: c.OnInvalidateUserMute,
+	})
+}
+
 func (c *Caches) initWebfinger() {
 	// Calculate maximum cache size.
 	cap := calculateCacheMax(
@@ -196,3 +196,9 @@ func (c *Caches) OnInvalidateUser(user *gtsmodel.User) {
 	c.Visibility.Invalidate("ItemID", user.AccountID)
 	c.Visibility.Invalidate("RequesterID", user.AccountID)
 }
+
+func (c *Caches) OnInvalidateUserMute(mute *gtsmodel.UserMute) {
+	// Invalidate mute origin account ID cached visibility,
+	// mutes only affect what the origin account sees.
+	c.Visibility.Invalidate("RequesterID", mute.AccountID)
+}
@@ -175,8 +175,13 @@ func totalOfRatios() float64 {
 		config.GetCacheBlockMemRatio() +
 		config.GetCacheBlockIDsMemRatio() +
 		config.GetCacheBoostOfIDsMemRatio() +
+		config.GetCacheCardMemRatio() +
+		config.GetCacheContentWarningPolicyMemRatio() +
 		config.GetCacheEmojiMemRatio() +
 		config.GetCacheEmojiCategoryMemRatio() +
+		config.GetCacheFilterMemRatio() +
+		config.GetCacheFilterKeywordMemRatio() +
+		config.GetCacheFilterStatusMemRatio() +
 		config.GetCacheFollowMemRatio() +
 		config.GetCacheFollowIDsMemRatio() +
 		config.GetCacheFollowRequestMemRatio() +
@@ -199,6 +204,7 @@ func totalOfRatios() float64 {
 		config.GetCacheThreadMuteMemRatio() +
 		config.GetCacheTombstoneMemRatio() +
 		config.GetCacheUserMemRatio() +
+		config.GetCacheUserMuteMemRatio() +
 		config.GetCacheWebfingerMemRatio() +
 		config.GetCacheVisibilityMemRatio()
 }
@@ -229,6 +235,7 @@ func sizeofAccount() uintptr {
 		FollowersURI:            exampleURI,
 		FollowingURI:            exampleURI,
 		FeaturedCollectionURI:   exampleURI,
+		FeaturedTagsURI:         exampleURI,
 		ActorType:               ap.ActorPerson,
 		PrivateKey:              &rsa.PrivateKey{},
 		PublicKey:               &rsa.PublicKey{},
@@ -278,6 +285,35 @@ func sizeofBlock() uintptr {
 	}))
 }
 
+func sizeofCard() uintptr {
+	return uintptr(size.Of(&gtsmodel.Card{
+		ID:           exampleID,
+		CreatedAt:    exampleTime,
+		UpdatedAt:    exampleTime,
+		FetchedAt:    exampleTime,
+		URL:          exampleURI,
+		Title:        exampleTextSmall,
+		Description:  exampleText,
+		Type:         gtsmodel.CardTypeLink,
+		ProviderName: exampleUsername,
+		ProviderURL:  exampleURI,
+		ImageID:      exampleID,
+	}))
+}
+
+func sizeofContentWarningPolicy() uintptr {
+	return uintptr(size.Of(&gtsmodel.ContentWarningPolicy{
+		ID:                 exampleID,
+		CreatedAt:          exampleTime,
+		UpdatedAt:          exampleTime,
+		Domain:             "example.org",
+		AccountID:          exampleID,
+		CreatedByAccountID: exampleID,
+		ContentWarning:     exampleTextSmall,
+		Sensitive:          func() *bool { ok := true; return &ok }(),
+	}))
+}
+
 func sizeofEmoji() uintptr {
 	return uintptr(size.Of(&gtsmodel.Emoji{
 		ID:                     exampleID,
@@ -311,6 +347,46 @@ func sizeofEmojiCategory() uintptr {
 	}))
 }
 
+func sizeofFilter() uintptr {
+	return uintptr(size.Of(&gtsmodel.Filter{
+		ID:                   exampleID,
+		CreatedAt:            exampleTime,
+		UpdatedAt:            exampleTime,
+		ExpiresAt:            exampleTime,
+		AccountID:            exampleID,
+		Title:                exampleTextSmall,
+		Action:               gtsmodel.FilterActionHide,
+		ContextHome:          func() *bool { ok := true; return &ok }(),
+		ContextNotifications: func() *bool { ok := true; return &ok }(),
+		ContextPublic:        func() *bool { ok := true; return &ok }(),
+		ContextThread:        func() *bool { ok := true; return &ok }(),
+		ContextAccount:       func() *bool { ok := true; return &ok }(),
+	}))
+}
+
+func sizeofFilterKeyword() uintptr {
+	return uintptr(size.Of(&gtsmodel.FilterKeyword{
+		ID:        exampleID,
+		CreatedAt: exampleTime,
+		UpdatedAt: exampleTime,
+		AccountID: exampleID,
+		FilterID:  exampleID,
+		Keyword:   exampleTextSmall,
+		WholeWord: func() *bool { ok := true; return &ok }(),
+	}))
+}
+
+func sizeofFilterStatus() uintptr {
+	return uintptr(size.Of(&gtsmodel.FilterStatus{
+		ID:        exampleID,
+		CreatedAt: exampleTime,
+		UpdatedAt: exampleTime,
+		AccountID: exampleID,
+		FilterID:  exampleID,
+		StatusID:  exampleID,
+	}))
+}
+
 func sizeofFollow() uintptr {
 	return uintptr(size.Of(&gtsmodel.Follow{
 		ID:              exampleID,
@@ -566,6 +642,18 @@ func sizeofVisibility() uintptr {
 	}))
 }
 
+func sizeofUserMute() uintptr {
+	return uintptr(size.Of(&gtsmodel.UserMute{
+		ID:              exampleID,
+		CreatedAt:       exampleTime,
+		UpdatedAt:       exampleTime,
+		ExpiresAt:       exampleTime,
+		AccountID:       exampleID,
+		TargetAccountID: exampleID,
+		Notifications:   func() *bool { ok := true; return &ok }(),
+	}))
+}
+
 func sizeofUser() uintptr {
 	return uintptr(size.Of(&gtsmodel.User{
 		ID:                     exampleID,
@@ -0,0 +1,459 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+const (
+	// refetchAfter is how long a card is reused
+	// for before the linked page is fetched again.
+	refetchAfter = 7 * 24 * time.Hour
+
+	// maxPageSize is the maximum number of bytes of a linked
+	// page that will be read when looking for its metadata.
+	maxPageSize = 1024 * 1024
+
+	// maxOEmbedSize is the maximum number of
+	// bytes of an oEmbed response that will be read.
+	maxOEmbedSize = 64 * 1024
+)
+
+// Fetcher contains functions for generating
+// preview cards for links included in statuses.
+type Fetcher interface {
+	// FetchStatusCard generates a preview card for the first link in
+	// the given status, reusing any existing card for the same URL if
+	// it's still fresh, and stores the card ID on the status. If the
+	// status no longer has a suitable link, the card is removed from
+	// it instead. Returns whether the card of the status changed.
+	FetchStatusCard(ctx context.Context, status *gtsmodel.Status) (bool, error)
+}
+
+// HTTPClient is the subset of http client
+// functionality used to fetch linked pages.
+type HTTPClient interface {
+	Do(r *http.Request) (*http.Response, error)
+}
+
+// NewFetcher returns a new preview card Fetcher, which
+// fetches linked pages and images using the given client,
+// and stores card images using the given media manager.
+func NewFetcher(client HTTPClient, state *state.State, mediaManager *media.Manager) Fetcher {
+	return &fetcher{
+		client:       client,
+		state:        state,
+		mediaManager: mediaManager,
+	}
+}
+
+type fetcher struct {
+	client       HTTPClient
+	state        *state.State
+	mediaManager *media.Manager
+}
+
+func (f *fetcher) FetchStatusCard(ctx context.Context, status *gtsmodel.Status) (bool, error) {
+	var (
+		card *gtsmodel.Card
+		err  error
+	)
+
+	if link := f.statusLink(ctx, status); link != "" {
+		// Status has a suitable link, get
+		// (or generate) the card for it.
+		card, err = f.getCard(ctx, link)
+		if err != nil {
+			// Don't return yet, the status might
+			// have an outdated card which should
+			// be removed now link has changed.
+			err = gtserror.Newf("error getting card for %s: %w", link, err)
+		}
+	}
+
+	var cardID string
+	if card != nil {
+		cardID = card.ID
+	}
+
+	if cardID == status.CardID {
+		// Nothing changed.
+		return false, err
+	}
+
+	// Update the card of the status.
+	status.CardID = cardID
+	status.Card = card
+	if err := f.state.DB.UpdateStatus(ctx, status, "card_id"); err != nil {
+		return false, gtserror.Newf("db error updating status: %w", err)
+	}
+
+	return true, err
+}
+
+// statusLink returns the URL of the first link in the given
+// status for which a card should be generated, if any.
+func (f *fetcher) statusLink(ctx context.Context, status *gtsmodel.Status) string {
+	if !config.GetCardsEnabled() {
+		return ""
+	}
+
+	if status.BoostOfID != "" ||
+		status.ContentWarning != "" ||
+		status.Visibility == gtsmodel.VisibilityDirect ||
+		len(status.AttachmentIDs) != 0 {
+		// Boosts get the card of the boosted status
+		// instead, and clients show attachments in
+		// place of a card anyway. Don't leak what's
+		// behind a content warning, or the links of
+		// direct messages to third parties.
+		return ""
+	}
+
+	for _, link := range statusLinks(status.Content) {
+		u, err := url.Parse(link)
+		if err != nil {
+			continue
+		}
+
+		if denied, err := f.denied(ctx, u.Hostname()); err != nil {
+			log.Errorf(ctx, "error checking card domain: %v", err)
+			return ""
+		} else if denied {
+			// Try the next link.
+			continue
+		}
+
+		return link
+	}
+
+	return ""
+}
+
+// denied returns whether cards may not
+// be fetched for links on the given host.
+func (f *fetcher) denied(ctx context.Context, host string) (bool, error) {
+	host = strings.ToLower(host)
+	if host == "" ||
+		host == hostname(config.GetHost()) ||
+		host == hostname(config.GetAccountDomain()) {
+		// Links to ourselves
+		// don't get cards.
+		return true, nil
+	}
+
+	for _, domain := range config.GetCardsDenyDomains() {
+		domain = strings.ToLower(strings.Trim(domain, "."))
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true, nil
+		}
+	}
+
+	// Don't contact hosts we've blocked.
+	return f.state.DB.IsDomainBlocked(ctx, host)
+}
+
+// getCard returns the card for the given link, either
+// the existing one if still fresh, or by (re)fetching.
+func (f *fetcher) getCard(ctx context.Context, link string) (*gtsmodel.Card, error) {
+	existing, err := f.state.DB.GetCardByURL(ctx, link)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting card: %w", err)
+	}
+
+	if existing != nil && !stale(existing) {
+		// Existing card
+		// is good to go.
+		return existing, nil
+	}
+
+	// Fetch metadata of the linked page.
+	latest, err := f.fetchCard(ctx, link, existing)
+	if err != nil {
+		if existing != nil {
+			// Outdated card is better than none.
+			log.Warnf(ctx, "error refreshing card %s: %v", existing.ID, err)
+			return existing, nil
+		}
+		return nil, err
+	}
+
+	if existing == nil {
+		// New card, store it.
+		latest.ID = id.NewULID()
+		err := f.state.DB.PutCard(ctx, latest)
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Card was generated for another
+			// status in the meantime, use that.
+			return f.state.DB.GetCardByURL(ctx, link)
+		} else if err != nil {
+			return nil, gtserror.Newf("db error putting card: %w", err)
+		}
+
+		return latest, nil
+	}
+
+	// Update existing card.
+	latest.ID = existing.ID
+	latest.CreatedAt = existing.CreatedAt
+	if err := f.state.DB.UpdateCard(ctx, latest); err != nil {
+		return nil, gtserror.Newf("db error updating card: %w", err)
+	}
+
+	return latest, nil
+}
+
+// stale returns whether the given card
+// should be refreshed from the linked page.
+func stale(card *gtsmodel.Card) bool {
+	if time.Since(card.FetchedAt) > refetchAfter {
+		return true
+	}
+
+	// Refresh if the image was
+	// uncached by the cleaner.
+	return card.Image != nil &&
+		!*card.Image.Cached
+}
+
+// fetchCard fetches the linked page and generates an (unstored)
+// card from its metadata, reusing the image of existing if set.
+func (f *fetcher) fetchCard(ctx context.Context, link string, existing *gtsmodel.Card) (*gtsmodel.Card, error) {
+	rsp, err := f.get(ctx, link, "text/html")
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if ct := rsp.Header.Get("Content-Type"); !isHTML(ct) {
+		return nil, fmt.Errorf("unsupported content type %q", ct)
+	}
+
+	// Parse page metadata, relative to the final
+	// location of the page (after redirects).
+	meta := parsePage(io.LimitReader(rsp.Body, maxPageSize), rsp.Request.URL)
+
+	now := time.Now()
+	card := &gtsmodel.Card{
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		FetchedAt:    now,
+		URL:          link,
+		Type:         gtsmodel.CardTypeLink,
+		Title:        meta.title,
+		Description:  meta.description,
+		AuthorName:   meta.authorName,
+		ProviderName: meta.siteName,
+	}
+
+	imageURL := meta.image
+
+	if meta.oEmbed != "" {
+		// Page advertises oEmbed, this gives more
+		// accurate info plus embeds for rich media.
+		oEmbed, err := f.fetchOEmbed(ctx, meta.oEmbed)
+		if err != nil {
+			log.Debugf(ctx, "error fetching oembed %s: %v", meta.oEmbed, err)
+		} else {
+			oEmbed.apply(card)
+			if imageURL == "" {
+				imageURL = oEmbed.ThumbnailURL
+			}
+		}
+	}
+
+	if card.Title == "" && card.HTML == "" {
+		// Nothing to show.
+		return nil, errors.New("page has no usable metadata")
+	}
+
+	if imageURL != "" {
+		var existingImage *gtsmodel.MediaAttachment
+		if existing != nil {
+			existingImage = existing.Image
+		}
+
+		// Cache the preview image.
+		card.Image, err = f.fetchImage(ctx, imageURL, existingImage)
+		if err != nil {
+			log.Debugf(ctx, "error fetching card image %s: %v", imageURL, err)
+		} else {
+			card.ImageID = card.Image.ID
+		}
+	}
+
+	return card, nil
+}
+
+// fetchOEmbed fetches the oEmbed
+// endpoint at the given URL.
+func (f *fetcher) fetchOEmbed(ctx context.Context, endpoint string) (*oEmbed, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if denied, err := f.denied(ctx, u.Hostname()); err != nil {
+		return nil, err
+	} else if denied {
+		return nil, fmt.Errorf("domain %s denied", u.Hostname())
+	}
+
+	rsp, err := f.get(ctx, endpoint, "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	return parseOEmbed(io.LimitReader(rsp.Body, maxOEmbedSize))
+}
+
+// fetchImage fetches and stores the card image at the given URL as
+// a media attachment owned by the instance account. The existing
+// attachment is reused if it's for the same URL, recaching if needed.
+func (f *fetcher) fetchImage(
+	ctx context.Context,
+	imageURL string,
+	existing *gtsmodel.MediaAttachment,
+) (*gtsmodel.MediaAttachment, error) {
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if denied, err := f.denied(ctx, u.Hostname()); err != nil {
+		return nil, err
+	} else if denied {
+		return nil, fmt.Errorf("domain %s denied", u.Hostname())
+	}
+
+	data := func(ctx context.Context) (io.ReadCloser, int64, error) {
+		rsp, err := f.get(ctx, imageURL, "image/*")
+		if err != nil {
+			return nil, 0, err
+		}
+		return rsp.Body, rsp.ContentLength, nil
+	}
+
+	var processing *media.ProcessingMedia
+
+	if existing != nil && existing.RemoteURL == imageURL {
+		if *existing.Cached {
+			// Image unchanged
+			// and still cached.
+			return existing, nil
+		}
+
+		// Image was uncached by the cleaner, recache it.
+		processing, err = f.mediaManager.PreProcessMediaRecache(ctx, data, existing.ID)
+		if err != nil {
+			return nil, gtserror.Newf("error recaching media: %w", err)
+		}
+	} else {
+		// New image, owned by the instance account. Any
+		// previous image is left for the cleaner to prune.
+		instanceAcc, err := f.state.DB.GetInstanceAccount(ctx, "")
+		if err != nil {
+			return nil, gtserror.Newf("db error getting instance account: %w", err)
+		}
+
+		processing = f.mediaManager.PreProcessMedia(data, instanceAcc.ID, &media.AdditionalMediaInfo{
+			RemoteURL: &imageURL,
+		})
+	}
+
+	// Load attachment *right now*. Unlike status
+	// attachments, partially loaded placeholders
+	// are no use for cards, so these are left
+	// for the cleaner to prune.
+	attachment, err := processing.LoadAttachment(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// get performs a GET request for the given URL,
+// returning the response on a 200 OK status.
+func (f *fetcher) get(ctx context.Context, link string, accept string) (*http.Response, error) {
+	// Don't retry slow or broken sites,
+	// cards can't hold up the queue.
+	ctx = gtscontext.SetFastFail(ctx)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", fmt.Sprintf("%s (+%s://%s) gotosocial/%s",
+		config.GetApplicationName(),
+		config.GetProtocol(),
+		config.GetHost(),
+		config.GetSoftwareVersion(),
+	))
+
+	rsp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		err := gtserror.NewFromResponse(rsp)
+		_ = rsp.Body.Close()
+		return nil, err
+	}
+
+	return rsp, nil
+}
+
+// hostname strips any port from the given host.
+func hostname(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return host
+}
+
+// isHTML returns whether the given
+// content type is an HTML document.
+func isHTML(contentType string) bool {
+	ct, _, _ := strings.Cut(contentType, ";")
+	ct = strings.TrimSpace(strings.ToLower(ct))
+	return ct == "text/html" || ct == "application/xhtml+xml"
+}
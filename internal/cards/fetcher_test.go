@@ -0,0 +1,257 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cards_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/cards"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+const testPage = `<!DOCTYPE html>
+<html>
+<head>
+	<title>Plain title</title>
+	<meta property="og:title" content="A very good article">
+	<meta property="og:description" content="You won't believe what happens next.">
+	<meta property="og:site_name" content="Example News">
+	<meta property="og:image" content="/preview.jpg">
+</head>
+<body></body>
+</html>`
+
+type FetcherTestSuite struct {
+	suite.Suite
+	state   state.State
+	fetcher cards.Fetcher
+
+	// requested URLs,
+	// in order of request.
+	requested []string
+
+	testStatuses map[string]*gtsmodel.Status
+}
+
+func (suite *FetcherTestSuite) SetupSuite() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+}
+
+func (suite *FetcherTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+
+	testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+
+	suite.state.Storage = testrig.NewInMemoryStorage()
+	testrig.StandardStorageSetup(suite.state.Storage, "../../testrig/media")
+
+	suite.requested = nil
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.fetcher = cards.NewFetcher(
+		suite,
+		&suite.state,
+		testrig.NewTestMediaManager(&suite.state),
+	)
+}
+
+func (suite *FetcherTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.state.DB)
+	testrig.StandardStorageTeardown(suite.state.Storage)
+	testrig.StopWorkers(&suite.state)
+}
+
+// Do implements cards.HTTPClient, serving
+// the test page, and a preview image.
+func (suite *FetcherTestSuite) Do(r *http.Request) (*http.Response, error) {
+	suite.requested = append(suite.requested, r.URL.String())
+
+	var (
+		contentType string
+		body        []byte
+	)
+
+	switch r.URL.String() {
+	case "https://news.example.org/articles/1":
+		contentType = "text/html; charset=utf-8"
+		body = []byte(testPage)
+	case "https://news.example.org/preview.jpg":
+		b, err := os.ReadFile("../../testrig/media/thoughtsofdog-original.jpg")
+		if err != nil {
+			return nil, err
+		}
+		contentType = "image/jpeg"
+		body = b
+	default:
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Status:     http.StatusText(http.StatusNotFound),
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+			Request:    r,
+		}, nil
+	}
+
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Status:        http.StatusText(http.StatusOK),
+		Header:        http.Header{"Content-Type": {contentType}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       r,
+	}, nil
+}
+
+func (suite *FetcherTestSuite) linkStatus(key string, link string) *gtsmodel.Status {
+	status := suite.testStatuses[key]
+	status.ContentWarning = ""
+	status.Visibility = gtsmodel.VisibilityPublic
+	status.Content = `<p>check this out: <a href="` + link + `" rel="nofollow noreferrer noopener" target="_blank">` + link + `</a></p>`
+	return status
+}
+
+func (suite *FetcherTestSuite) TestFetchStatusCard() {
+	ctx := context.Background()
+	status := suite.linkStatus("local_account_1_status_1", "https://news.example.org/articles/1")
+
+	changed, err := suite.fetcher.FetchStatusCard(ctx, status)
+	suite.NoError(err)
+	suite.True(changed)
+	suite.Equal([]string{
+		"https://news.example.org/articles/1",
+		"https://news.example.org/preview.jpg",
+	}, suite.requested)
+
+	card := status.Card
+	suite.NotNil(card)
+	suite.Equal(card.ID, status.CardID)
+	suite.Equal("https://news.example.org/articles/1", card.URL)
+	suite.Equal(gtsmodel.CardTypeLink, card.Type)
+	suite.Equal("A very good article", card.Title)
+	suite.Equal("You won't believe what happens next.", card.Description)
+	suite.Equal("Example News", card.ProviderName)
+	suite.NotNil(card.Image)
+	suite.True(*card.Image.Cached)
+	suite.Equal("https://news.example.org/preview.jpg", card.Image.RemoteURL)
+
+	// Card should be stored on the status.
+	dbStatus, err := suite.state.DB.GetStatusByID(ctx, status.ID)
+	suite.NoError(err)
+	suite.Equal(card.ID, dbStatus.CardID)
+	suite.NotNil(dbStatus.Card)
+
+	// Fetching for another status with the
+	// same link should reuse the fresh card.
+	other := suite.linkStatus("local_account_1_status_2", "https://news.example.org/articles/1")
+
+	suite.requested = nil
+	changed, err = suite.fetcher.FetchStatusCard(ctx, other)
+	suite.NoError(err)
+	suite.True(changed)
+	suite.Empty(suite.requested)
+	suite.Equal(card.ID, other.CardID)
+
+	// Fetching again should change nothing.
+	changed, err = suite.fetcher.FetchStatusCard(ctx, status)
+	suite.NoError(err)
+	suite.False(changed)
+}
+
+func (suite *FetcherTestSuite) TestFetchStatusCardLinkRemoved() {
+	ctx := context.Background()
+	status := suite.linkStatus("local_account_1_status_1", "https://news.example.org/articles/1")
+
+	changed, err := suite.fetcher.FetchStatusCard(ctx, status)
+	suite.NoError(err)
+	suite.True(changed)
+	suite.NotEmpty(status.CardID)
+
+	// Edit the link out again.
+	status.Content = "<p>never mind</p>"
+	changed, err = suite.fetcher.FetchStatusCard(ctx, status)
+	suite.NoError(err)
+	suite.True(changed)
+	suite.Empty(status.CardID)
+	suite.Nil(status.Card)
+}
+
+func (suite *FetcherTestSuite) TestFetchStatusCardNotFound() {
+	status := suite.linkStatus("local_account_1_status_1", "https://news.example.org/articles/2")
+
+	changed, err := suite.fetcher.FetchStatusCard(context.Background(), status)
+	suite.ErrorContains(err, "Not Found")
+	suite.False(changed)
+	suite.Empty(status.CardID)
+}
+
+func (suite *FetcherTestSuite) TestFetchStatusCardDenied() {
+	config.SetCardsDenyDomains([]string{"example.net"})
+	defer config.SetCardsDenyDomains([]string{})
+
+	for _, link := range []string{
+		// Our own host.
+		"http://localhost:8080/@the_mighty_zork",
+		// Blocked domain.
+		"https://replyguys.com/some/page",
+		// Subdomain of denied domain.
+		"https://www.example.net/page",
+	} {
+		status := suite.linkStatus("local_account_1_status_1", link)
+
+		changed, err := suite.fetcher.FetchStatusCard(context.Background(), status)
+		suite.NoError(err)
+		suite.False(changed)
+		suite.Empty(suite.requested)
+	}
+}
+
+func (suite *FetcherTestSuite) TestFetchStatusCardDisabled() {
+	config.SetCardsEnabled(false)
+	defer config.SetCardsEnabled(true)
+
+	status := suite.linkStatus("local_account_1_status_1", "https://news.example.org/articles/1")
+
+	changed, err := suite.fetcher.FetchStatusCard(context.Background(), status)
+	suite.NoError(err)
+	suite.False(changed)
+	suite.Empty(suite.requested)
+}
+
+func (suite *FetcherTestSuite) TestFetchStatusCardContentWarning() {
+	status := suite.linkStatus("local_account_1_status_1", "https://news.example.org/articles/1")
+	status.ContentWarning = "spoilers"
+
+	changed, err := suite.fetcher.FetchStatusCard(context.Background(), status)
+	suite.NoError(err)
+	suite.False(changed)
+	suite.Empty(suite.requested)
+}
+
+func TestFetcherTestSuite(t *testing.T) {
+	suite.Run(t, &FetcherTestSuite{})
+}
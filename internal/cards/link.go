@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cards
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// statusLinks returns the http(s) links in the given
+// status HTML content in order of appearance, skipping
+// mentions and hashtags, which never get cards.
+func statusLinks(content string) []string {
+	var (
+		links []string
+		z     = html.NewTokenizer(strings.NewReader(content))
+	)
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			// Done (or broken).
+			return links
+
+		case html.StartTagToken:
+			name, hasAttr := z.TagName()
+			if atom.Lookup(name) != atom.A || !hasAttr {
+				continue
+			}
+
+			var href, class, rel string
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				switch string(key) {
+				case "href":
+					href = string(val)
+				case "class":
+					class = string(val)
+				case "rel":
+					rel = string(val)
+				}
+			}
+
+			// Mentions and hashtags are marked with a "mention"
+			// class, (the latter with "hashtag" + rel="tag" too).
+			classes := strings.Fields(class)
+			if slices.Contains(classes, "mention") ||
+				slices.Contains(classes, "hashtag") ||
+				slices.Contains(strings.Fields(rel), "tag") {
+				continue
+			}
+
+			u, err := url.Parse(href)
+			if err != nil ||
+				(u.Scheme != "http" && u.Scheme != "https") ||
+				u.Host == "" {
+				continue
+			}
+
+			// Drop fragment, it doesn't
+			// change the linked page.
+			u.Fragment = ""
+			u.RawFragment = ""
+
+			link := u.String()
+			if !slices.Contains(links, link) {
+				links = append(links, link)
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cards
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusLinks(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		content string
+		expect  []string
+	}{
+		{
+			name:    "no links",
+			content: "<p>hello world</p>",
+			expect:  nil,
+		},
+		{
+			name: "mentions and hashtags skipped",
+			content: `<p><span class="h-card"><a href="https://example.org/@someone" class="u-url mention">@<span>someone</span></a></span> ` +
+				`<a href="https://example.org/tags/cats" class="mention hashtag" rel="tag">#<span>cats</span></a> ` +
+				`<a href="https://example.org/articles/1" rel="nofollow noreferrer noopener" target="_blank">https://example.org/articles/1</a></p>`,
+			expect: []string{"https://example.org/articles/1"},
+		},
+		{
+			name: "fragments dropped and deduped",
+			content: `<p><a href="https://example.org/page#one">one</a> <a href="https://example.org/page#two">two</a> ` +
+				`<a href="http://example.net/">three</a></p>`,
+			expect: []string{"https://example.org/page", "http://example.net/"},
+		},
+		{
+			name:    "non-http links skipped",
+			content: `<p><a href="mailto:someone@example.org">mail</a> <a href="/relative">relative</a> <a href="gopher://example.org">gopher</a></p>`,
+			expect:  nil,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, statusLinks(test.content))
+		})
+	}
+}
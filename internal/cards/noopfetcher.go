@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cards
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// NewNoopFetcher returns a no-op preview card fetcher that will just execute
+// the given fetchCallback every time it would otherwise fetch a status card.
+//
+// Passing a nil function is also acceptable, in which case FetchStatusCard will just return false.
+func NewNoopFetcher(fetchCallback func(status *gtsmodel.Status)) Fetcher {
+	return &noopFetcher{
+		fetchCallback: fetchCallback,
+	}
+}
+
+type noopFetcher struct {
+	fetchCallback func(status *gtsmodel.Status)
+}
+
+func (f *noopFetcher) FetchStatusCard(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) (bool, error) {
+	if f.fetchCallback != nil {
+		f.fetchCallback(status)
+	}
+	return false, nil
+}
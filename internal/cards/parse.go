@@ -0,0 +1,336 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cards
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// maxTitleLength is the maximum number
+	// of characters of a card title.
+	maxTitleLength = 256
+
+	// maxDescriptionLength is the maximum number
+	// of characters of a card description.
+	maxDescriptionLength = 512
+
+	// maxNameLength is the maximum number of
+	// characters of card author / provider names.
+	maxNameLength = 128
+)
+
+// embedPolicy only allows https iframes through,
+// as used by oEmbed video and rich embeds.
+var embedPolicy = func() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements("iframe")
+	p.AllowAttrs("src").OnElements("iframe")
+	p.AllowAttrs("width", "height").Matching(bluemonday.Integer).OnElements("iframe")
+	p.AllowAttrs("allowfullscreen", "frameborder", "title").OnElements("iframe")
+	p.AllowURLSchemes("https")
+	p.RequireParseableURLs(true)
+	return p
+}()
+
+// pageMeta contains the metadata
+// of a linked page used for its card.
+type pageMeta struct {
+	title       string
+	description string
+	siteName    string
+	authorName  string
+	image       string // absolute url
+	oEmbed      string // absolute url
+}
+
+// parsePage parses card metadata from the head of the HTML page
+// in r, preferring OpenGraph over Twitter over plain HTML tags.
+// Any URLs are resolved relative to the given page URL.
+func parsePage(r io.Reader, base *url.URL) pageMeta {
+	var (
+		// values of meta tags, by
+		// (lowercased) property / name.
+		metas = make(map[string]string)
+
+		title   strings.Builder
+		inTitle bool
+		oEmbed  string
+
+		z = html.NewTokenizer(r)
+	)
+
+loop:
+	for {
+		switch tt := z.Next(); tt {
+		case html.ErrorToken:
+			// Done (or truncated).
+			break loop
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			attrs := make(map[string]string)
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				attrs[string(key)] = string(val)
+			}
+
+			switch atom.Lookup(name) {
+			case atom.Body:
+				// Metadata is in the head.
+				break loop
+
+			case atom.Title:
+				inTitle = (tt == html.StartTagToken)
+
+			case atom.Meta:
+				key := attrs["property"]
+				if key == "" {
+					key = attrs["name"]
+				}
+				key = strings.ToLower(key)
+				if _, ok := metas[key]; !ok && key != "" {
+					// First value wins.
+					metas[key] = attrs["content"]
+				}
+
+			case atom.Link:
+				rels := strings.Fields(strings.ToLower(attrs["rel"]))
+				if oEmbed == "" &&
+					len(rels) == 1 && rels[0] == "alternate" &&
+					strings.EqualFold(attrs["type"], "application/json+oembed") {
+					oEmbed = attrs["href"]
+				}
+			}
+
+		case html.TextToken:
+			if inTitle {
+				title.Write(z.Text())
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Title:
+				inTitle = false
+			case atom.Head:
+				break loop
+			}
+		}
+	}
+
+	authorName := metas["author"]
+	if isURL(authorName) {
+		// Some sites put a profile link here.
+		authorName = ""
+	}
+
+	return pageMeta{
+		title: clean(first(
+			metas["og:title"],
+			metas["twitter:title"],
+			title.String(),
+		), maxTitleLength),
+		description: clean(first(
+			metas["og:description"],
+			metas["twitter:description"],
+			metas["description"],
+		), maxDescriptionLength),
+		siteName:   clean(metas["og:site_name"], maxNameLength),
+		authorName: clean(authorName, maxNameLength),
+		image: resolve(base, first(
+			metas["og:image:secure_url"],
+			metas["og:image"],
+			metas["og:image:url"],
+			metas["twitter:image"],
+			metas["twitter:image:src"],
+		)),
+		oEmbed: resolve(base, oEmbed),
+	}
+}
+
+// oEmbed is an oEmbed response,
+// see https://oembed.com/#section2.3.
+type oEmbed struct {
+	Type         string    `json:"type"`
+	Title        string    `json:"title"`
+	AuthorName   string    `json:"author_name"`
+	AuthorURL    string    `json:"author_url"`
+	ProviderName string    `json:"provider_name"`
+	ProviderURL  string    `json:"provider_url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	URL          string    `json:"url"`
+	HTML         string    `json:"html"`
+	Width        dimension `json:"width"`
+	Height       dimension `json:"height"`
+}
+
+// parseOEmbed parses the oEmbed JSON response in r.
+func parseOEmbed(r io.Reader) (*oEmbed, error) {
+	var o oEmbed
+	if err := json.NewDecoder(r).Decode(&o); err != nil {
+		return nil, err
+	}
+
+	if !isURL(o.ThumbnailURL) {
+		o.ThumbnailURL = ""
+	}
+
+	return &o, nil
+}
+
+// apply overrides card fields with
+// those provided by the oEmbed response.
+func (o *oEmbed) apply(card *gtsmodel.Card) {
+	if title := clean(o.Title, maxTitleLength); title != "" {
+		card.Title = title
+	}
+
+	if name := clean(o.AuthorName, maxNameLength); name != "" {
+		card.AuthorName = name
+	}
+
+	if isURL(o.AuthorURL) {
+		card.AuthorURL = o.AuthorURL
+	}
+
+	if name := clean(o.ProviderName, maxNameLength); name != "" {
+		card.ProviderName = name
+	}
+
+	if isURL(o.ProviderURL) {
+		card.ProviderURL = o.ProviderURL
+	}
+
+	switch o.Type {
+	case "photo":
+		if !isURL(o.URL) {
+			return
+		}
+		card.Type = gtsmodel.CardTypePhoto
+		card.EmbedURL = o.URL
+
+	case "video", "rich":
+		embed := sanitizeEmbed(o.HTML)
+		if embed == "" {
+			return
+		}
+		card.Type = gtsmodel.CardType(o.Type)
+		card.HTML = embed
+
+	default:
+		return
+	}
+
+	card.Width = int(o.Width)
+	card.Height = int(o.Height)
+}
+
+// sanitizeEmbed sanitizes the given oEmbed html, returning
+// an empty string if it doesn't contain an https iframe.
+func sanitizeEmbed(in string) string {
+	out := strings.TrimSpace(embedPolicy.Sanitize(in))
+	if !strings.HasPrefix(out, "<iframe ") ||
+		!strings.Contains(out, ` src="https://`) {
+		return ""
+	}
+	return out
+}
+
+// dimension is an oEmbed width or height, which
+// some providers wrongly encode as a string.
+type dimension int
+
+func (d *dimension) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		*d = 0
+		return nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		// Ignore invalid
+		// dimensions.
+		*d = 0
+		return nil
+	}
+
+	*d = dimension(n)
+	return nil
+}
+
+// first returns the first non-blank value.
+func first(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// clean collapses whitespace in the given text,
+// and truncates it to max characters.
+func clean(text string, max int) string {
+	text = strings.ToValidUTF8(text, "")
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > max {
+		text = string(runes[:max-1]) + "…"
+	}
+	return text
+}
+
+// resolve resolves the given reference relative to
+// base, returning an empty string if it's not http(s).
+func resolve(base *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+
+	u, err := base.Parse(ref)
+	if err != nil {
+		return ""
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+
+	return u.String()
+}
+
+// isURL returns whether the
+// given string is an http(s) URL.
+func isURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil &&
+		(u.Scheme == "http" || u.Scheme == "https") &&
+		u.Host != ""
+}
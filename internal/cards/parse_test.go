@@ -0,0 +1,139 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cards
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func TestParsePage(t *testing.T) {
+	const page = `<!DOCTYPE html>
+<html>
+<head>
+	<title>Plain   title</title>
+	<meta name="description" content="Plain description">
+	<meta name="author" content="Some Author">
+	<meta name="twitter:title" content="Twitter title">
+	<meta property="og:title" content="OpenGraph
+		title">
+	<meta property="og:title" content="Second OpenGraph title">
+	<meta property="og:site_name" content="Example Site">
+	<meta name="twitter:image" content="https://cdn.example.org/twitter.png">
+	<meta property="og:image" content="/images/preview.jpg">
+	<link rel="alternate" type="application/json+oembed" href="/oembed?url=article">
+</head>
+<body>
+	<meta property="og:description" content="Not in the head">
+</body>
+</html>`
+
+	base, _ := url.Parse("https://example.org/articles/1")
+	meta := parsePage(strings.NewReader(page), base)
+
+	assert.Equal(t, pageMeta{
+		title:       "OpenGraph title",
+		description: "Plain description",
+		siteName:    "Example Site",
+		authorName:  "Some Author",
+		image:       "https://example.org/images/preview.jpg",
+		oEmbed:      "https://example.org/oembed?url=article",
+	}, meta)
+}
+
+func TestParsePageNoMeta(t *testing.T) {
+	base, _ := url.Parse("https://example.org/")
+	meta := parsePage(strings.NewReader(`<p>just some text</p>`), base)
+	assert.Equal(t, pageMeta{}, meta)
+}
+
+func TestParseOEmbed(t *testing.T) {
+	const rsp = `{
+		"version": "1.0",
+		"type": "video",
+		"title": "A video",
+		"author_name": "Someone",
+		"author_url": "https://video.example.org/@someone",
+		"provider_name": "Example Video",
+		"provider_url": "https://video.example.org",
+		"thumbnail_url": "javascript:alert(1)",
+		"html": "<iframe src=\"https://video.example.org/embed/1\" width=\"560\" height=\"315\" onload=\"alert(1)\" allowfullscreen></iframe><script>alert(1)</script>",
+		"width": "560",
+		"height": 315
+	}`
+
+	o, err := parseOEmbed(strings.NewReader(rsp))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Empty(t, o.ThumbnailURL)
+
+	card := &gtsmodel.Card{
+		Type:  gtsmodel.CardTypeLink,
+		Title: "Page title",
+	}
+	o.apply(card)
+
+	assert.Equal(t, &gtsmodel.Card{
+		Type:         gtsmodel.CardTypeVideo,
+		Title:        "A video",
+		AuthorName:   "Someone",
+		AuthorURL:    "https://video.example.org/@someone",
+		ProviderName: "Example Video",
+		ProviderURL:  "https://video.example.org",
+		HTML:         `<iframe src="https://video.example.org/embed/1" width="560" height="315" allowfullscreen=""></iframe>`,
+		Width:        560,
+		Height:       315,
+	}, card)
+}
+
+func TestSanitizeEmbed(t *testing.T) {
+	for _, test := range []struct {
+		in     string
+		expect string
+	}{
+		{
+			in:     `<iframe src="https://example.org/embed" width="100%"></iframe>`,
+			expect: `<iframe src="https://example.org/embed"></iframe>`,
+		},
+		{
+			in:     `<iframe src="http://example.org/embed"></iframe>`,
+			expect: "",
+		},
+		{
+			in:     `<blockquote>Not an embed</blockquote>`,
+			expect: "",
+		},
+		{
+			in:     `<script src="https://example.org/embed.js"></script>`,
+			expect: "",
+		},
+	} {
+		assert.Equal(t, test.expect, sanitizeEmbed(test.in))
+	}
+}
+
+func TestClean(t *testing.T) {
+	assert.Equal(t, "some text", clean("  some\n\ttext  ", 10))
+	assert.Equal(t, "some text…", clean("some text that is too long", 10))
+	assert.Equal(t, "", clean("   ", 10))
+}
@@ -0,0 +1,222 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Card encompasses a set of
+// preview card cleanup / admin utils.
+type Card struct {
+	*Cleaner
+}
+
+// All will execute all cleaner.Card utilities synchronously, including output logging.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (c *Card) All(ctx context.Context, maxRemoteDays int) {
+	t := time.Now().Add(-24 * time.Hour * time.Duration(maxRemoteDays))
+	c.LogPruneUnused(ctx)
+	c.LogUncacheRemote(ctx, t)
+	_ = c.state.Storage.Storage.Clean(ctx)
+}
+
+// LogPruneUnused performs Card.PruneUnused(...), logging the start and outcome.
+func (c *Card) LogPruneUnused(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := c.PruneUnused(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "pruned: %d", n)
+	}
+}
+
+// LogUncacheRemote performs Card.UncacheRemote(...), logging the start and outcome.
+func (c *Card) LogUncacheRemote(ctx context.Context, olderThan time.Time) {
+	log.Infof(ctx, "start older than: %s", olderThan.Format(time.Stamp))
+	if n, err := c.UncacheRemote(ctx, olderThan); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "uncached: %d", n)
+	}
+}
+
+// PruneUnused will delete all preview cards no longer used by any status, along with
+// their images. Cards generated within the last hour are skipped, as these may still
+// be waiting to be set on their status. Context will be checked for `gtscontext.DryRun()`
+// in order to actually perform the action.
+func (c *Card) PruneUnused(ctx context.Context) (int, error) {
+	var total int
+
+	// Start from cards generated an hour ago.
+	maxID, err := id.NewULIDFromTime(time.Now().Add(-time.Hour))
+	if err != nil {
+		return total, gtserror.Newf("error generating max id: %w", err)
+	}
+
+	for {
+		// Fetch the next batch of unused cards to next maxID.
+		cards, err := c.state.DB.GetUnusedCards(
+			gtscontext.SetBarebones(ctx),
+			maxID,
+			selectLimit,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting unused cards: %w", err)
+		}
+
+		// If no cards are returned, we reached the end.
+		if len(cards) == 0 {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = cards[len(cards)-1].ID
+
+		for _, card := range cards {
+			// Delete each unused card.
+			if err := c.delete(ctx, card); err != nil {
+				return total, err
+			}
+
+			// Update
+			// count.
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// UncacheRemote will uncache the images of all preview cards last fetched before given input
+// time. The images get recached when the card is next refreshed, or when they're requested.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (c *Card) UncacheRemote(ctx context.Context, olderThan time.Time) (int, error) {
+	var total int
+
+	// Drop time by a minute to improve search,
+	// (i.e. make it olderThan inclusive search).
+	olderThan = olderThan.Add(-time.Minute)
+
+	for {
+		// Fetch the next batch of cards fetched before last-set time.
+		cards, err := c.state.DB.GetCardsFetchedBefore(
+			gtscontext.SetBarebones(ctx),
+			olderThan,
+			selectLimit,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting cards: %w", err)
+		}
+
+		// If no cards / same group is returned, we reached the end.
+		if len(cards) == 0 ||
+			olderThan.Equal(cards[len(cards)-1].FetchedAt) {
+			break
+		}
+
+		// Use last fetched-at as the next 'olderThan' value.
+		olderThan = cards[len(cards)-1].FetchedAt
+
+		for _, card := range cards {
+			// Check / uncache each card image.
+			uncached, err := c.uncacheImage(ctx, card)
+			if err != nil {
+				return total, err
+			}
+
+			if uncached {
+				// Update
+				// count.
+				total++
+			}
+		}
+	}
+
+	return total, nil
+}
+
+func (c *Card) uncacheImage(ctx context.Context, card *gtsmodel.Card) (bool, error) {
+	image, err := c.getImage(ctx, card)
+	if err != nil {
+		return false, err
+	}
+
+	if image == nil || !*image.Cached {
+		// Missing or
+		// already uncached.
+		return false, nil
+	}
+
+	log.WithContext(ctx).
+		WithField("card", card.ID).
+		Debug("uncaching old card image")
+	return true, c.media.uncache(ctx, image)
+}
+
+// getImage returns the image of the given card, or nil if it has none.
+func (c *Card) getImage(ctx context.Context, card *gtsmodel.Card) (*gtsmodel.MediaAttachment, error) {
+	if card.ImageID == "" {
+		// No image.
+		return nil, nil
+	}
+
+	image, err := c.state.DB.GetAttachmentByID(ctx, card.ImageID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error fetching card image %s: %w", card.ImageID, err)
+	}
+
+	return image, nil
+}
+
+func (c *Card) delete(ctx context.Context, card *gtsmodel.Card) error {
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return nil
+	}
+
+	image, err := c.getImage(ctx, card)
+	if err != nil {
+		return err
+	}
+
+	if image != nil {
+		// Delete card image first, else it
+		// becomes unused media left over.
+		if err := c.media.delete(ctx, image); err != nil {
+			return err
+		}
+	}
+
+	// Delete card entirely from the database.
+	log.Debugf(ctx, "deleting card: %s", card.ID)
+	if err := c.state.DB.DeleteCardByID(ctx, card.ID); err != nil {
+		return gtserror.Newf("error deleting card: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+// putTestCard stores a new card generated at the given
+// time for the given link, with a (cached) image.
+func (suite *CleanerTestSuite) putTestCard(link string, generatedAt time.Time) *gtsmodel.Card {
+	ctx := context.Background()
+
+	image := new(gtsmodel.MediaAttachment)
+	*image = *testrig.NewTestAttachments()["remote_account_1_status_1_attachment_1"]
+	image.ID = id.NewULID()
+	image.StatusID = ""
+	image.RemoteURL = link + "/preview.jpg"
+	image.Cached = util.Ptr(true)
+	if err := suite.state.DB.PutAttachment(ctx, image); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	cardID, err := id.NewULIDFromTime(generatedAt)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	card := &gtsmodel.Card{
+		ID:        cardID,
+		CreatedAt: generatedAt,
+		UpdatedAt: generatedAt,
+		FetchedAt: generatedAt,
+		URL:       link,
+		Title:     "Some page",
+		Type:      gtsmodel.CardTypeLink,
+		ImageID:   image.ID,
+	}
+	if err := suite.state.DB.PutCard(ctx, card); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return card
+}
+
+func (suite *CleanerTestSuite) TestCardPruneUnused() {
+	suite.testCardPruneUnused(context.Background())
+}
+
+func (suite *CleanerTestSuite) TestCardPruneUnusedDryRun() {
+	suite.testCardPruneUnused(gtscontext.SetDryRun(context.Background()))
+}
+
+func (suite *CleanerTestSuite) testCardPruneUnused(ctx context.Context) {
+	var (
+		weekAgo = time.Now().Add(-7 * 24 * time.Hour)
+		used    = suite.putTestCard("https://example.org/used", weekAgo)
+		unused  = suite.putTestCard("https://example.org/unused", weekAgo)
+		recent  = suite.putTestCard("https://example.org/recent", time.Now())
+	)
+
+	// Set the used card on a status.
+	status := testrig.NewTestStatuses()["local_account_1_status_1"]
+	status.CardID = used.ID
+	if err := suite.state.DB.UpdateStatus(ctx, status, "card_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pruned, err := suite.cleaner.Card().PruneUnused(ctx)
+	suite.NoError(err)
+	suite.Equal(1, pruned)
+
+	// Used and recent cards
+	// should never be pruned.
+	for _, card := range []*gtsmodel.Card{used, recent} {
+		_, err := suite.state.DB.GetCardByID(ctx, card.ID)
+		suite.NoError(err)
+		_, err = suite.state.DB.GetAttachmentByID(ctx, card.ImageID)
+		suite.NoError(err)
+	}
+
+	_, cardErr := suite.state.DB.GetCardByID(ctx, unused.ID)
+	_, imageErr := suite.state.DB.GetAttachmentByID(ctx, unused.ImageID)
+	if gtscontext.DryRun(ctx) {
+		// Nothing should be
+		// gone on a dry run.
+		suite.NoError(cardErr)
+		suite.NoError(imageErr)
+	} else {
+		// Unused card should be
+		// gone, with its image.
+		suite.ErrorIs(cardErr, db.ErrNoEntries)
+		suite.ErrorIs(imageErr, db.ErrNoEntries)
+	}
+}
+
+func (suite *CleanerTestSuite) TestCardUncacheRemote() {
+	suite.testCardUncacheRemote(context.Background())
+}
+
+func (suite *CleanerTestSuite) TestCardUncacheRemoteDryRun() {
+	suite.testCardUncacheRemote(gtscontext.SetDryRun(context.Background()))
+}
+
+func (suite *CleanerTestSuite) testCardUncacheRemote(ctx context.Context) {
+	var (
+		old    = suite.putTestCard("https://example.org/old", time.Now().Add(-14*24*time.Hour))
+		recent = suite.putTestCard("https://example.org/recent", time.Now())
+	)
+
+	uncached, err := suite.cleaner.Card().UncacheRemote(ctx, time.Now().Add(-7*24*time.Hour))
+	suite.NoError(err)
+	suite.Equal(1, uncached)
+
+	oldImage, err := suite.state.DB.GetAttachmentByID(ctx, old.ImageID)
+	suite.NoError(err)
+	suite.Equal(gtscontext.DryRun(ctx), *oldImage.Cached)
+
+	recentImage, err := suite.state.DB.GetAttachmentByID(ctx, recent.ImageID)
+	suite.NoError(err)
+	suite.True(*recentImage.Cached)
+}
+
+func (suite *CleanerTestSuite) TestMediaCleanupSkipsCardImages() {
+	ctx := context.Background()
+	card := suite.putTestCard("https://example.org/page", time.Now().Add(-14*24*time.Hour))
+
+	// Card images aren't attached to
+	// any status, but mustn't be pruned.
+	_, err := suite.cleaner.Media().PruneUnused(ctx)
+	suite.NoError(err)
+
+	// Card images are uncached based on when
+	// the card was last fetched, not here.
+	_, err = suite.cleaner.Media().UncacheRemote(ctx, time.Now())
+	suite.NoError(err)
+
+	image, err := suite.state.DB.GetAttachmentByID(ctx, card.ImageID)
+	if errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow("card image was pruned")
+	}
+	suite.NoError(err)
+	suite.True(*image.Cached)
+}
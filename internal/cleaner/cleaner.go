@@ -36,6 +36,7 @@ const (
 
 type Cleaner struct {
 	state *state.State
+	card  Card
 	emoji Emoji
 	media Media
 }
@@ -43,11 +44,17 @@ type Cleaner struct {
 func New(state *state.State) *Cleaner {
 	c := new(Cleaner)
 	c.state = state
+	c.card.Cleaner = c
 	c.emoji.Cleaner = c
 	c.media.Cleaner = c
 	return c
 }
 
+// Card returns the preview card set of cleaner utilities.
+func (c *Cleaner) Card() *Card {
+	return &c.card
+}
+
 // Emoji returns the emoji set of cleaner utilities.
 func (c *Cleaner) Emoji() *Emoji {
 	return &c.emoji
@@ -150,6 +157,7 @@ func (c *Cleaner) ScheduleJobs() error {
 
 	fn := func(ctx context.Context, start time.Time) {
 		log.Info(ctx, "starting media clean")
+		c.Card().All(ctx, config.GetMediaRemoteCacheDays())
 		c.Media().All(ctx, config.GetMediaRemoteCacheDays())
 		c.Emoji().All(ctx, config.GetMediaRemoteCacheDays())
 		log.Infof(ctx, "finished media clean after %s", time.Since(start))
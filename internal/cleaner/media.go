@@ -20,6 +20,7 @@ package cleaner
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -357,6 +358,24 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 		}
 	}
 
+	// Check whether media is waiting to be posted in a scheduled status.
+	scheduled, err := m.isScheduled(ctx, media)
+	if err != nil {
+		return false, err
+	} else if scheduled {
+		l.Debug("skipping as attached to scheduled status")
+		return false, nil
+	}
+
+	// Check whether media is the image of a preview card.
+	cardImage, err := m.isCardImage(ctx, media)
+	if err != nil {
+		return false, err
+	} else if cardImage {
+		l.Debug("skipping as used by preview card")
+		return false, nil
+	}
+
 	// Check whether we have the required status for media.
 	status, missing, err := m.getRelatedStatus(ctx, media)
 	if err != nil {
@@ -374,6 +393,15 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 				return false, nil
 			}
 		}
+
+		// Check whether attached to a previous revision of status.
+		inHistory, err := m.isInStatusEditHistory(ctx, status, media)
+		if err != nil {
+			return false, err
+		} else if inHistory {
+			l.Debug("skippping as attached to status edit history")
+			return false, nil
+		}
 	}
 
 	// Media totally unused, delete it.
@@ -443,11 +471,14 @@ func (m *Media) uncacheRemote(ctx context.Context, after time.Time, media *gtsmo
 	l := log.WithContext(ctx).
 		WithField("media", media.ID)
 
-	// There are two possibilities here:
+	// There are three possibilities here:
 	//
 	//   1. Media is an avatar or header; we should uncache
 	//      it if we haven't seen the account recently.
-	//   2. Media is attached to a status; we should uncache
+	//   2. Media is the image of a preview card; this is
+	//      left to Card.UncacheRemote, which checks when
+	//      the card was last fetched.
+	//   3. Media is attached to a status; we should uncache
 	//      it if we haven't seen the status recently.
 	if *media.Avatar || *media.Header {
 		// Check whether we have the account that owns the media.
@@ -464,6 +495,11 @@ func (m *Media) uncacheRemote(ctx context.Context, after time.Time, media *gtsmo
 			l.Debug("skipping due to recently fetched account")
 			return false, nil
 		}
+	} else if cardImage, err := m.isCardImage(ctx, media); err != nil {
+		return false, err
+	} else if cardImage {
+		l.Debug("skipping as used by preview card")
+		return false, nil
 	} else {
 		// Check whether we have the status that media is attached to.
 		status, missing, err := m.getRelatedStatus(ctx, media)
@@ -532,6 +568,70 @@ func (m *Media) getRelatedStatus(ctx context.Context, media *gtsmodel.MediaAttac
 	return status, false, nil
 }
 
+func (m *Media) isScheduled(ctx context.Context, media *gtsmodel.MediaAttachment) (bool, error) {
+	if media.ScheduledStatusID == "" {
+		// no related scheduled status.
+		return false, nil
+	}
+
+	// Load the scheduled status related to this media.
+	scheduled, err := m.state.DB.GetScheduledStatusByID(
+		gtscontext.SetBarebones(ctx),
+		media.ScheduledStatusID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("error fetching scheduled status by id %s: %w", media.ScheduledStatusID, err)
+	}
+
+	return (scheduled != nil), nil
+}
+
+func (m *Media) isCardImage(ctx context.Context, media *gtsmodel.MediaAttachment) (bool, error) {
+	if media.StatusID != "" || media.RemoteURL == "" {
+		// Card images are remote
+		// and never attached.
+		return false, nil
+	}
+
+	// Look for a card using this media as image.
+	_, err := m.state.DB.GetCardByImageID(
+		gtscontext.SetBarebones(ctx),
+		media.ID,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return false, nil
+		}
+		return false, gtserror.Newf("error fetching card by image id %s: %w", media.ID, err)
+	}
+
+	return true, nil
+}
+
+func (m *Media) isInStatusEditHistory(ctx context.Context, status *gtsmodel.Status, media *gtsmodel.MediaAttachment) (bool, error) {
+	if len(status.EditIDs) == 0 {
+		// status never edited.
+		return false, nil
+	}
+
+	// Load the previous revisions of this status.
+	edits, err := m.state.DB.GetStatusEditsByIDs(
+		gtscontext.SetBarebones(ctx),
+		status.EditIDs,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("error fetching status edits for status %s: %w", status.ID, err)
+	}
+
+	for _, edit := range edits {
+		if slices.Contains(edit.AttachmentIDs, media.ID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) error {
 	if gtscontext.DryRun(ctx) {
 		// Dry run, do nothing.
@@ -77,20 +77,24 @@ type Configuration struct {
 	WebTemplateBaseDir string `name:"web-template-base-dir" usage:"Basedir for html templating files for rendering pages and composing emails."`
 	WebAssetBaseDir    string `name:"web-asset-base-dir" usage:"Directory to serve static assets from, accessible at example.org/assets/"`
 
-	InstanceFederationMode         string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
-	InstanceExposePeers            bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
-	InstanceExposeSuspended        bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
-	InstanceExposeSuspendedWeb     bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
-	InstanceExposePublicTimeline   bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
-	InstanceDeliverToSharedInboxes bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
-	InstanceInjectMastodonVersion  bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
-	InstanceLanguages              language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
-
-	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
-	AccountsApprovalRequired bool `name:"accounts-approval-required" usage:"Do account signups require approval by an admin or moderator before user can log in? If false, new registrations will be automatically approved."`
-	AccountsReasonRequired   bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
-	AccountsAllowCustomCSS   bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
-	AccountsCustomCSSLength  int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
+	InstanceFederationMode            string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
+	InstanceExposePeers               bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
+	InstanceExposeSuspended           bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
+	InstanceExposeSuspendedWeb        bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
+	InstanceExposePublicTimeline      bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
+	InstanceDeliverToSharedInboxes    bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
+	InstanceInjectMastodonVersion     bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
+	InstanceLanguages                 language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+	InstanceSubscriptionsProcessEvery time.Duration      `name:"instance-subscriptions-process-every" usage:"Period to elapse between instance subscriptions processing jobs, starting from server startup."`
+	InstanceTrendsRefreshEvery        time.Duration      `name:"instance-trends-refresh-every" usage:"Period to elapse between recalculations of trending hashtags and statuses. Set to 0 to disable trends."`
+
+	AccountsRegistrationOpen      bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
+	AccountsApprovalRequired      bool `name:"accounts-approval-required" usage:"Do account signups require approval by an admin or moderator before user can log in? If false, new registrations will be automatically approved."`
+	AccountsReasonRequired        bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
+	AccountsInvitesEnabled        bool `name:"accounts-invites-enabled" usage:"Allow invite codes to be created, which can be used to register a new account even when registration is closed. Accounts registered with an invite code do not require approval."`
+	AccountsInvitesModeratorsOnly bool `name:"accounts-invites-moderators-only" usage:"Only allow admins and moderators to create invite codes. If false, any user can create invite codes."`
+	AccountsAllowCustomCSS        bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
+	AccountsCustomCSSLength       int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
 
 	MediaImageMaxSize        bytesize.Size `name:"media-image-max-size" usage:"Max size of accepted images in bytes"`
 	MediaVideoMaxSize        bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
@@ -117,6 +121,9 @@ type Configuration struct {
 	StatusesPollOptionMaxChars int `name:"statuses-poll-option-max-chars" usage:"Max amount of characters for a poll option"`
 	StatusesMediaMaxFiles      int `name:"statuses-media-max-files" usage:"Maximum number of media files/attachments per status"`
 
+	CardsEnabled     bool     `name:"cards-enabled" usage:"Fetch link metadata to generate preview cards for the first link in statuses"`
+	CardsDenyDomains []string `name:"cards-deny-domains" usage:"Domains (and their subdomains) for which link preview cards will never be fetched"`
+
 	LetsEncryptEnabled      bool   `name:"letsencrypt-enabled" usage:"Enable letsencrypt TLS certs for this server. If set to true, then cert dir also needs to be set (or take the default)."`
 	LetsEncryptPort         int    `name:"letsencrypt-port" usage:"Port to listen on for letsencrypt certificate challenges. Must not be the same as the GtS webserver/API port."`
 	LetsEncryptCertDir      string `name:"letsencrypt-cert-dir" usage:"Directory to store acquired letsencrypt certificates."`
@@ -191,40 +198,46 @@ type HTTPClientConfiguration struct {
 }
 
 type CacheConfiguration struct {
-	MemoryTarget             bytesize.Size `name:"memory-target"`
-	AccountMemRatio          float64       `name:"account-mem-ratio"`
-	AccountNoteMemRatio      float64       `name:"account-note-mem-ratio"`
-	ApplicationMemRatio      float64       `name:"application-mem-ratio"`
-	BlockMemRatio            float64       `name:"block-mem-ratio"`
-	BlockIDsMemRatio         float64       `name:"block-mem-ratio"`
-	BoostOfIDsMemRatio       float64       `name:"boost-of-ids-mem-ratio"`
-	EmojiMemRatio            float64       `name:"emoji-mem-ratio"`
-	EmojiCategoryMemRatio    float64       `name:"emoji-category-mem-ratio"`
-	FollowMemRatio           float64       `name:"follow-mem-ratio"`
-	FollowIDsMemRatio        float64       `name:"follow-ids-mem-ratio"`
-	FollowRequestMemRatio    float64       `name:"follow-request-mem-ratio"`
-	FollowRequestIDsMemRatio float64       `name:"follow-request-ids-mem-ratio"`
-	InReplyToIDsMemRatio     float64       `name:"in-reply-to-ids-mem-ratio"`
-	InstanceMemRatio         float64       `name:"instance-mem-ratio"`
-	ListMemRatio             float64       `name:"list-mem-ratio"`
-	ListEntryMemRatio        float64       `name:"list-entry-mem-ratio"`
-	MarkerMemRatio           float64       `name:"marker-mem-ratio"`
-	MediaMemRatio            float64       `name:"media-mem-ratio"`
-	MentionMemRatio          float64       `name:"mention-mem-ratio"`
-	NotificationMemRatio     float64       `name:"notification-mem-ratio"`
-	PollMemRatio             float64       `name:"poll-mem-ratio"`
-	PollVoteMemRatio         float64       `name:"poll-vote-mem-ratio"`
-	PollVoteIDsMemRatio      float64       `name:"poll-vote-ids-mem-ratio"`
-	ReportMemRatio           float64       `name:"report-mem-ratio"`
-	StatusMemRatio           float64       `name:"status-mem-ratio"`
-	StatusFaveMemRatio       float64       `name:"status-fave-mem-ratio"`
-	StatusFaveIDsMemRatio    float64       `name:"status-fave-ids-mem-ratio"`
-	TagMemRatio              float64       `name:"tag-mem-ratio"`
-	ThreadMuteMemRatio       float64       `name:"thread-mute-mem-ratio"`
-	TombstoneMemRatio        float64       `name:"tombstone-mem-ratio"`
-	UserMemRatio             float64       `name:"user-mem-ratio"`
-	WebfingerMemRatio        float64       `name:"webfinger-mem-ratio"`
-	VisibilityMemRatio       float64       `name:"visibility-mem-ratio"`
+	MemoryTarget                 bytesize.Size `name:"memory-target"`
+	AccountMemRatio              float64       `name:"account-mem-ratio"`
+	AccountNoteMemRatio          float64       `name:"account-note-mem-ratio"`
+	ApplicationMemRatio          float64       `name:"application-mem-ratio"`
+	BlockMemRatio                float64       `name:"block-mem-ratio"`
+	BlockIDsMemRatio             float64       `name:"block-mem-ratio"`
+	BoostOfIDsMemRatio           float64       `name:"boost-of-ids-mem-ratio"`
+	CardMemRatio                 float64       `name:"card-mem-ratio"`
+	ContentWarningPolicyMemRatio float64       `name:"content-warning-policy-mem-ratio"`
+	EmojiMemRatio                float64       `name:"emoji-mem-ratio"`
+	EmojiCategoryMemRatio        float64       `name:"emoji-category-mem-ratio"`
+	FilterMemRatio               float64       `name:"filter-mem-ratio"`
+	FilterKeywordMemRatio        float64       `name:"filter-keyword-mem-ratio"`
+	FilterStatusMemRatio         float64       `name:"filter-status-mem-ratio"`
+	FollowMemRatio               float64       `name:"follow-mem-ratio"`
+	FollowIDsMemRatio            float64       `name:"follow-ids-mem-ratio"`
+	FollowRequestMemRatio        float64       `name:"follow-request-mem-ratio"`
+	FollowRequestIDsMemRatio     float64       `name:"follow-request-ids-mem-ratio"`
+	InReplyToIDsMemRatio         float64       `name:"in-reply-to-ids-mem-ratio"`
+	InstanceMemRatio             float64       `name:"instance-mem-ratio"`
+	ListMemRatio                 float64       `name:"list-mem-ratio"`
+	ListEntryMemRatio            float64       `name:"list-entry-mem-ratio"`
+	MarkerMemRatio               float64       `name:"marker-mem-ratio"`
+	MediaMemRatio                float64       `name:"media-mem-ratio"`
+	MentionMemRatio              float64       `name:"mention-mem-ratio"`
+	NotificationMemRatio         float64       `name:"notification-mem-ratio"`
+	PollMemRatio                 float64       `name:"poll-mem-ratio"`
+	PollVoteMemRatio             float64       `name:"poll-vote-mem-ratio"`
+	PollVoteIDsMemRatio          float64       `name:"poll-vote-ids-mem-ratio"`
+	ReportMemRatio               float64       `name:"report-mem-ratio"`
+	StatusMemRatio               float64       `name:"status-mem-ratio"`
+	StatusFaveMemRatio           float64       `name:"status-fave-mem-ratio"`
+	StatusFaveIDsMemRatio        float64       `name:"status-fave-ids-mem-ratio"`
+	TagMemRatio                  float64       `name:"tag-mem-ratio"`
+	ThreadMuteMemRatio           float64       `name:"thread-mute-mem-ratio"`
+	TombstoneMemRatio            float64       `name:"tombstone-mem-ratio"`
+	UserMemRatio                 float64       `name:"user-mem-ratio"`
+	UserMuteMemRatio             float64       `name:"user-mute-mem-ratio"`
+	WebfingerMemRatio            float64       `name:"webfinger-mem-ratio"`
+	VisibilityMemRatio           float64       `name:"visibility-mem-ratio"`
 }
 
 // MarshalMap will marshal current Configuration into a map structure (useful for JSON/TOML/YAML).
@@ -58,18 +58,22 @@ var Defaults = Configuration{
 	WebTemplateBaseDir: "./web/template/",
 	WebAssetBaseDir:    "./web/assets/",
 
-	InstanceFederationMode:         InstanceFederationModeDefault,
-	InstanceExposePeers:            false,
-	InstanceExposeSuspended:        false,
-	InstanceExposeSuspendedWeb:     false,
-	InstanceDeliverToSharedInboxes: true,
-	InstanceLanguages:              make(language.Languages, 0),
-
-	AccountsRegistrationOpen: true,
-	AccountsApprovalRequired: true,
-	AccountsReasonRequired:   true,
-	AccountsAllowCustomCSS:   false,
-	AccountsCustomCSSLength:  10000,
+	InstanceFederationMode:            InstanceFederationModeDefault,
+	InstanceExposePeers:               false,
+	InstanceExposeSuspended:           false,
+	InstanceExposeSuspendedWeb:        false,
+	InstanceDeliverToSharedInboxes:    true,
+	InstanceLanguages:                 make(language.Languages, 0),
+	InstanceSubscriptionsProcessEvery: 24 * time.Hour,
+	InstanceTrendsRefreshEvery:        15 * time.Minute,
+
+	AccountsRegistrationOpen:      true,
+	AccountsApprovalRequired:      true,
+	AccountsReasonRequired:        true,
+	AccountsInvitesEnabled:        false,
+	AccountsInvitesModeratorsOnly: true,
+	AccountsAllowCustomCSS:        false,
+	AccountsCustomCSSLength:       10000,
 
 	MediaImageMaxSize:        10 * bytesize.MiB,
 	MediaVideoMaxSize:        40 * bytesize.MiB,
@@ -92,6 +96,9 @@ var Defaults = Configuration{
 	StatusesPollOptionMaxChars: 50,
 	StatusesMediaMaxFiles:      6,
 
+	CardsEnabled:     true,
+	CardsDenyDomains: []string{},
+
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         80,
 	LetsEncryptCertDir:      "/gotosocial/storage/certs",
@@ -157,39 +164,45 @@ var Defaults = Configuration{
 		// when TODO items in the size.go source
 		// file have been addressed, these should
 		// be able to make some more sense :D
-		AccountMemRatio:          5,
-		AccountNoteMemRatio:      1,
-		ApplicationMemRatio:      0.1,
-		BlockMemRatio:            2,
-		BlockIDsMemRatio:         3,
-		BoostOfIDsMemRatio:       3,
-		EmojiMemRatio:            3,
-		EmojiCategoryMemRatio:    0.1,
-		FollowMemRatio:           2,
-		FollowIDsMemRatio:        4,
-		FollowRequestMemRatio:    2,
-		FollowRequestIDsMemRatio: 2,
-		InReplyToIDsMemRatio:     3,
-		InstanceMemRatio:         1,
-		ListMemRatio:             1,
-		ListEntryMemRatio:        2,
-		MarkerMemRatio:           0.5,
-		MediaMemRatio:            4,
-		MentionMemRatio:          2,
-		NotificationMemRatio:     2,
-		PollMemRatio:             1,
-		PollVoteMemRatio:         2,
-		PollVoteIDsMemRatio:      2,
-		ReportMemRatio:           1,
-		StatusMemRatio:           5,
-		StatusFaveMemRatio:       2,
-		StatusFaveIDsMemRatio:    3,
-		TagMemRatio:              2,
-		ThreadMuteMemRatio:       0.2,
-		TombstoneMemRatio:        0.5,
-		UserMemRatio:             0.25,
-		WebfingerMemRatio:        0.1,
-		VisibilityMemRatio:       2,
+		AccountMemRatio:              5,
+		AccountNoteMemRatio:          1,
+		ApplicationMemRatio:          0.1,
+		BlockMemRatio:                2,
+		BlockIDsMemRatio:             3,
+		BoostOfIDsMemRatio:           3,
+		CardMemRatio:                 1,
+		ContentWarningPolicyMemRatio: 0.1,
+		EmojiMemRatio:                3,
+		EmojiCategoryMemRatio:        0.1,
+		FilterMemRatio:               0.5,
+		FilterKeywordMemRatio:        0.5,
+		FilterStatusMemRatio:         0.5,
+		FollowMemRatio:               2,
+		FollowIDsMemRatio:            4,
+		FollowRequestMemRatio:        2,
+		FollowRequestIDsMemRatio:     2,
+		InReplyToIDsMemRatio:         3,
+		InstanceMemRatio:             1,
+		ListMemRatio:                 1,
+		ListEntryMemRatio:            2,
+		MarkerMemRatio:               0.5,
+		MediaMemRatio:                4,
+		MentionMemRatio:              2,
+		NotificationMemRatio:         2,
+		PollMemRatio:                 1,
+		PollVoteMemRatio:             2,
+		PollVoteIDsMemRatio:          2,
+		ReportMemRatio:               1,
+		StatusMemRatio:               5,
+		StatusFaveMemRatio:           2,
+		StatusFaveIDsMemRatio:        3,
+		TagMemRatio:                  2,
+		ThreadMuteMemRatio:           0.2,
+		TombstoneMemRatio:            0.5,
+		UserMemRatio:                 0.25,
+		UserMuteMemRatio:             2,
+		WebfingerMemRatio:            0.1,
+		VisibilityMemRatio:           2,
 	},
 
 	HTTPClient: HTTPClientConfiguration{
@@ -950,6 +950,60 @@ func GetInstanceLanguages() language.Languages { return global.GetInstanceLangua
 // SetInstanceLanguages safely sets the value for global configuration 'InstanceLanguages' field
 func SetInstanceLanguages(v language.Languages) { global.SetInstanceLanguages(v) }
 
+// GetInstanceSubscriptionsProcessEvery safely fetches the Configuration value for state's 'InstanceSubscriptionsProcessEvery' field
+func (st *ConfigState) GetInstanceSubscriptionsProcessEvery() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.InstanceSubscriptionsProcessEvery
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceSubscriptionsProcessEvery safely sets the Configuration value for state's 'InstanceSubscriptionsProcessEvery' field
+func (st *ConfigState) SetInstanceSubscriptionsProcessEvery(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceSubscriptionsProcessEvery = v
+	st.reloadToViper()
+}
+
+// InstanceSubscriptionsProcessEveryFlag returns the flag name for the 'InstanceSubscriptionsProcessEvery' field
+func InstanceSubscriptionsProcessEveryFlag() string { return "instance-subscriptions-process-every" }
+
+// GetInstanceSubscriptionsProcessEvery safely fetches the value for global configuration 'InstanceSubscriptionsProcessEvery' field
+func GetInstanceSubscriptionsProcessEvery() time.Duration {
+	return global.GetInstanceSubscriptionsProcessEvery()
+}
+
+// SetInstanceSubscriptionsProcessEvery safely sets the value for global configuration 'InstanceSubscriptionsProcessEvery' field
+func SetInstanceSubscriptionsProcessEvery(v time.Duration) {
+	global.SetInstanceSubscriptionsProcessEvery(v)
+}
+
+// GetInstanceTrendsRefreshEvery safely fetches the Configuration value for state's 'InstanceTrendsRefreshEvery' field
+func (st *ConfigState) GetInstanceTrendsRefreshEvery() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.InstanceTrendsRefreshEvery
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceTrendsRefreshEvery safely sets the Configuration value for state's 'InstanceTrendsRefreshEvery' field
+func (st *ConfigState) SetInstanceTrendsRefreshEvery(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceTrendsRefreshEvery = v
+	st.reloadToViper()
+}
+
+// InstanceTrendsRefreshEveryFlag returns the flag name for the 'InstanceTrendsRefreshEvery' field
+func InstanceTrendsRefreshEveryFlag() string { return "instance-trends-refresh-every" }
+
+// GetInstanceTrendsRefreshEvery safely fetches the value for global configuration 'InstanceTrendsRefreshEvery' field
+func GetInstanceTrendsRefreshEvery() time.Duration { return global.GetInstanceTrendsRefreshEvery() }
+
+// SetInstanceTrendsRefreshEvery safely sets the value for global configuration 'InstanceTrendsRefreshEvery' field
+func SetInstanceTrendsRefreshEvery(v time.Duration) { global.SetInstanceTrendsRefreshEvery(v) }
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.RLock()
@@ -1025,6 +1079,56 @@ func GetAccountsReasonRequired() bool { return global.GetAccountsReasonRequired(
 // SetAccountsReasonRequired safely sets the value for global configuration 'AccountsReasonRequired' field
 func SetAccountsReasonRequired(v bool) { global.SetAccountsReasonRequired(v) }
 
+// GetAccountsInvitesEnabled safely fetches the Configuration value for state's 'AccountsInvitesEnabled' field
+func (st *ConfigState) GetAccountsInvitesEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsInvitesEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsInvitesEnabled safely sets the Configuration value for state's 'AccountsInvitesEnabled' field
+func (st *ConfigState) SetAccountsInvitesEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsInvitesEnabled = v
+	st.reloadToViper()
+}
+
+// AccountsInvitesEnabledFlag returns the flag name for the 'AccountsInvitesEnabled' field
+func AccountsInvitesEnabledFlag() string { return "accounts-invites-enabled" }
+
+// GetAccountsInvitesEnabled safely fetches the value for global configuration 'AccountsInvitesEnabled' field
+func GetAccountsInvitesEnabled() bool { return global.GetAccountsInvitesEnabled() }
+
+// SetAccountsInvitesEnabled safely sets the value for global configuration 'AccountsInvitesEnabled' field
+func SetAccountsInvitesEnabled(v bool) { global.SetAccountsInvitesEnabled(v) }
+
+// GetAccountsInvitesModeratorsOnly safely fetches the Configuration value for state's 'AccountsInvitesModeratorsOnly' field
+func (st *ConfigState) GetAccountsInvitesModeratorsOnly() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsInvitesModeratorsOnly
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsInvitesModeratorsOnly safely sets the Configuration value for state's 'AccountsInvitesModeratorsOnly' field
+func (st *ConfigState) SetAccountsInvitesModeratorsOnly(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsInvitesModeratorsOnly = v
+	st.reloadToViper()
+}
+
+// AccountsInvitesModeratorsOnlyFlag returns the flag name for the 'AccountsInvitesModeratorsOnly' field
+func AccountsInvitesModeratorsOnlyFlag() string { return "accounts-invites-moderators-only" }
+
+// GetAccountsInvitesModeratorsOnly safely fetches the value for global configuration 'AccountsInvitesModeratorsOnly' field
+func GetAccountsInvitesModeratorsOnly() bool { return global.GetAccountsInvitesModeratorsOnly() }
+
+// SetAccountsInvitesModeratorsOnly safely sets the value for global configuration 'AccountsInvitesModeratorsOnly' field
+func SetAccountsInvitesModeratorsOnly(v bool) { global.SetAccountsInvitesModeratorsOnly(v) }
+
 // GetAccountsAllowCustomCSS safely fetches the Configuration value for state's 'AccountsAllowCustomCSS' field
 func (st *ConfigState) GetAccountsAllowCustomCSS() (v bool) {
 	st.mutex.RLock()
@@ -1625,6 +1729,56 @@ func GetStatusesMediaMaxFiles() int { return global.GetStatusesMediaMaxFiles() }
 // SetStatusesMediaMaxFiles safely sets the value for global configuration 'StatusesMediaMaxFiles' field
 func SetStatusesMediaMaxFiles(v int) { global.SetStatusesMediaMaxFiles(v) }
 
+// GetCardsEnabled safely fetches the Configuration value for state's 'CardsEnabled' field
+func (st *ConfigState) GetCardsEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.CardsEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCardsEnabled safely sets the Configuration value for state's 'CardsEnabled' field
+func (st *ConfigState) SetCardsEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.CardsEnabled = v
+	st.reloadToViper()
+}
+
+// CardsEnabledFlag returns the flag name for the 'CardsEnabled' field
+func CardsEnabledFlag() string { return "cards-enabled" }
+
+// GetCardsEnabled safely fetches the value for global configuration 'CardsEnabled' field
+func GetCardsEnabled() bool { return global.GetCardsEnabled() }
+
+// SetCardsEnabled safely sets the value for global configuration 'CardsEnabled' field
+func SetCardsEnabled(v bool) { global.SetCardsEnabled(v) }
+
+// GetCardsDenyDomains safely fetches the Configuration value for state's 'CardsDenyDomains' field
+func (st *ConfigState) GetCardsDenyDomains() (v []string) {
+	st.mutex.RLock()
+	v = st.config.CardsDenyDomains
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCardsDenyDomains safely sets the Configuration value for state's 'CardsDenyDomains' field
+func (st *ConfigState) SetCardsDenyDomains(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.CardsDenyDomains = v
+	st.reloadToViper()
+}
+
+// CardsDenyDomainsFlag returns the flag name for the 'CardsDenyDomains' field
+func CardsDenyDomainsFlag() string { return "cards-deny-domains" }
+
+// GetCardsDenyDomains safely fetches the value for global configuration 'CardsDenyDomains' field
+func GetCardsDenyDomains() []string { return global.GetCardsDenyDomains() }
+
+// SetCardsDenyDomains safely sets the value for global configuration 'CardsDenyDomains' field
+func SetCardsDenyDomains(v []string) { global.SetCardsDenyDomains(v) }
+
 // GetLetsEncryptEnabled safely fetches the Configuration value for state's 'LetsEncryptEnabled' field
 func (st *ConfigState) GetLetsEncryptEnabled() (v bool) {
 	st.mutex.RLock()
@@ -2900,6 +3054,58 @@ func GetCacheBoostOfIDsMemRatio() float64 { return global.GetCacheBoostOfIDsMemR
 // SetCacheBoostOfIDsMemRatio safely sets the value for global configuration 'Cache.BoostOfIDsMemRatio' field
 func SetCacheBoostOfIDsMemRatio(v float64) { global.SetCacheBoostOfIDsMemRatio(v) }
 
+// GetCacheCardMemRatio safely fetches the Configuration value for state's 'Cache.CardMemRatio' field
+func (st *ConfigState) GetCacheCardMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.CardMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheCardMemRatio safely sets the Configuration value for state's 'Cache.CardMemRatio' field
+func (st *ConfigState) SetCacheCardMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.CardMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheCardMemRatioFlag returns the flag name for the 'Cache.CardMemRatio' field
+func CacheCardMemRatioFlag() string { return "cache-card-mem-ratio" }
+
+// GetCacheCardMemRatio safely fetches the value for global configuration 'Cache.CardMemRatio' field
+func GetCacheCardMemRatio() float64 { return global.GetCacheCardMemRatio() }
+
+// SetCacheCardMemRatio safely sets the value for global configuration 'Cache.CardMemRatio' field
+func SetCacheCardMemRatio(v float64) { global.SetCacheCardMemRatio(v) }
+
+// GetCacheContentWarningPolicyMemRatio safely fetches the Configuration value for state's 'Cache.ContentWarningPolicyMemRatio' field
+func (st *ConfigState) GetCacheContentWarningPolicyMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.ContentWarningPolicyMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheContentWarningPolicyMemRatio safely sets the Configuration value for state's 'Cache.ContentWarningPolicyMemRatio' field
+func (st *ConfigState) SetCacheContentWarningPolicyMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.ContentWarningPolicyMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheContentWarningPolicyMemRatioFlag returns the flag name for the 'Cache.ContentWarningPolicyMemRatio' field
+func CacheContentWarningPolicyMemRatioFlag() string { return "cache-content-warning-policy-mem-ratio" }
+
+// GetCacheContentWarningPolicyMemRatio safely fetches the value for global configuration 'Cache.ContentWarningPolicyMemRatio' field
+func GetCacheContentWarningPolicyMemRatio() float64 {
+	return global.GetCacheContentWarningPolicyMemRatio()
+}
+
+// SetCacheContentWarningPolicyMemRatio safely sets the value for global configuration 'Cache.ContentWarningPolicyMemRatio' field
+func SetCacheContentWarningPolicyMemRatio(v float64) { global.SetCacheContentWarningPolicyMemRatio(v) }
+
 // GetCacheEmojiMemRatio safely fetches the Configuration value for state's 'Cache.EmojiMemRatio' field
 func (st *ConfigState) GetCacheEmojiMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -2950,6 +3156,81 @@ func GetCacheEmojiCategoryMemRatio() float64 { return global.GetCacheEmojiCatego
 // SetCacheEmojiCategoryMemRatio safely sets the value for global configuration 'Cache.EmojiCategoryMemRatio' field
 func SetCacheEmojiCategoryMemRatio(v float64) { global.SetCacheEmojiCategoryMemRatio(v) }
 
+// GetCacheFilterMemRatio safely fetches the Configuration value for state's 'Cache.FilterMemRatio' field
+func (st *ConfigState) GetCacheFilterMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.FilterMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheFilterMemRatio safely sets the Configuration value for state's 'Cache.FilterMemRatio' field
+func (st *ConfigState) SetCacheFilterMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.FilterMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheFilterMemRatioFlag returns the flag name for the 'Cache.FilterMemRatio' field
+func CacheFilterMemRatioFlag() string { return "cache-filter-mem-ratio" }
+
+// GetCacheFilterMemRatio safely fetches the value for global configuration 'Cache.FilterMemRatio' field
+func GetCacheFilterMemRatio() float64 { return global.GetCacheFilterMemRatio() }
+
+// SetCacheFilterMemRatio safely sets the value for global configuration 'Cache.FilterMemRatio' field
+func SetCacheFilterMemRatio(v float64) { global.SetCacheFilterMemRatio(v) }
+
+// GetCacheFilterKeywordMemRatio safely fetches the Configuration value for state's 'Cache.FilterKeywordMemRatio' field
+func (st *ConfigState) GetCacheFilterKeywordMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.FilterKeywordMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheFilterKeywordMemRatio safely sets the Configuration value for state's 'Cache.FilterKeywordMemRatio' field
+func (st *ConfigState) SetCacheFilterKeywordMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.FilterKeywordMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheFilterKeywordMemRatioFlag returns the flag name for the 'Cache.FilterKeywordMemRatio' field
+func CacheFilterKeywordMemRatioFlag() string { return "cache-filter-keyword-mem-ratio" }
+
+// GetCacheFilterKeywordMemRatio safely fetches the value for global configuration 'Cache.FilterKeywordMemRatio' field
+func GetCacheFilterKeywordMemRatio() float64 { return global.GetCacheFilterKeywordMemRatio() }
+
+// SetCacheFilterKeywordMemRatio safely sets the value for global configuration 'Cache.FilterKeywordMemRatio' field
+func SetCacheFilterKeywordMemRatio(v float64) { global.SetCacheFilterKeywordMemRatio(v) }
+
+// GetCacheFilterStatusMemRatio safely fetches the Configuration value for state's 'Cache.FilterStatusMemRatio' field
+func (st *ConfigState) GetCacheFilterStatusMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.FilterStatusMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheFilterStatusMemRatio safely sets the Configuration value for state's 'Cache.FilterStatusMemRatio' field
+func (st *ConfigState) SetCacheFilterStatusMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.FilterStatusMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheFilterStatusMemRatioFlag returns the flag name for the 'Cache.FilterStatusMemRatio' field
+func CacheFilterStatusMemRatioFlag() string { return "cache-filter-status-mem-ratio" }
+
+// GetCacheFilterStatusMemRatio safely fetches the value for global configuration 'Cache.FilterStatusMemRatio' field
+func GetCacheFilterStatusMemRatio() float64 { return global.GetCacheFilterStatusMemRatio() }
+
+// SetCacheFilterStatusMemRatio safely sets the value for global configuration 'Cache.FilterStatusMemRatio' field
+func SetCacheFilterStatusMemRatio(v float64) { global.SetCacheFilterStatusMemRatio(v) }
+
 // GetCacheFollowMemRatio safely fetches the Configuration value for state's 'Cache.FollowMemRatio' field
 func (st *ConfigState) GetCacheFollowMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3525,6 +3806,31 @@ func GetCacheUserMemRatio() float64 { return global.GetCacheUserMemRatio() }
 // SetCacheUserMemRatio safely sets the value for global configuration 'Cache.UserMemRatio' field
 func SetCacheUserMemRatio(v float64) { global.SetCacheUserMemRatio(v) }
 
+// GetCacheUserMuteMemRatio safely fetches the Configuration value for state's 'Cache.UserMuteMemRatio' field
+func (st *ConfigState) GetCacheUserMuteMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.UserMuteMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheUserMuteMemRatio safely sets the Configuration value for state's 'Cache.UserMuteMemRatio' field
+func (st *ConfigState) SetCacheUserMuteMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.UserMuteMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheUserMuteMemRatioFlag returns the flag name for the 'Cache.UserMuteMemRatio' field
+func CacheUserMuteMemRatioFlag() string { return "cache-user-mute-mem-ratio" }
+
+// GetCacheUserMuteMemRatio safely fetches the value for global configuration 'Cache.UserMuteMemRatio' field
+func GetCacheUserMuteMemRatio() float64 { return global.GetCacheUserMuteMemRatio() }
+
+// SetCacheUserMuteMemRatio safely sets the value for global configuration 'Cache.UserMuteMemRatio' field
+func SetCacheUserMuteMemRatio(v float64) { global.SetCacheUserMuteMemRatio(v) }
+
 // GetCacheWebfingerMemRatio safely fetches the Configuration value for state's 'Cache.WebfingerMemRatio' field
 func (st *ConfigState) GetCacheWebfingerMemRatio() (v float64) {
 	st.mutex.RLock()
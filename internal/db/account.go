@@ -19,9 +19,11 @@ package db
 
 import (
 	"context"
+	"net"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Account contains functions related to account getting/setting/creation.
@@ -29,6 +31,9 @@ type Account interface {
 	// GetAccountByID returns one account with the given ID, or an error if something goes wrong.
 	GetAccountByID(ctx context.Context, id string) (*gtsmodel.Account, error)
 
+	// GetAccountsByIDs returns accounts corresponding to given IDs.
+	GetAccountsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Account, error)
+
 	// GetAccountByURI returns one account with the given URI, or an error if something goes wrong.
 	GetAccountByURI(ctx context.Context, uri string) (*gtsmodel.Account, error)
 
@@ -53,6 +58,31 @@ type Account interface {
 	// GetAccountByFollowersURI returns one account with the given followers_uri, or an error if something goes wrong.
 	GetAccountByFollowersURI(ctx context.Context, uri string) (*gtsmodel.Account, error)
 
+	// GetAccounts returns a page of accounts filtered by the given parameters, all of which are optional:
+	//
+	//   - origin: "local" or "remote" accounts only.
+	//   - status: "active", "pending", "disabled", "silenced", or "suspended" accounts only.
+	//   - mods: accounts of admins + moderators only.
+	//   - invitedBy: accounts of users who signed up using an invite created by this account ID.
+	//   - username: accounts with usernames starting with this string, case-insensitive.
+	//   - displayName: accounts with display names containing this string, case-insensitive.
+	//   - domain: accounts on this domain.
+	//   - email: accounts of users with an email address containing this string, case-insensitive.
+	//   - ip: accounts of users who have signed up or signed in from this IP.
+	GetAccounts(
+		ctx context.Context,
+		origin string,
+		status string,
+		mods bool,
+		invitedBy string,
+		username string,
+		displayName string,
+		domain string,
+		email string,
+		ip net.IP,
+		page *paging.Page,
+	) ([]*gtsmodel.Account, error)
+
 	// PopulateAccount ensures that all sub-models of an account are populated (e.g. avatar, header etc).
 	PopulateAccount(ctx context.Context, account *gtsmodel.Account) error
 
@@ -76,6 +106,11 @@ type Account interface {
 	// GetAccountsUsingEmoji fetches all account models using emoji with given ID stored in their 'emojis' column.
 	GetAccountsUsingEmoji(ctx context.Context, emojiID string) ([]*gtsmodel.Account, error)
 
+	// GetDirectoryAccounts fetches a page of discoverable, unsuspended accounts to show in the
+	// profile directory, optionally local accounts only. Accounts are ordered by most recently
+	// posted if orderByActive is true, else by most recently created / first seen.
+	GetDirectoryAccounts(ctx context.Context, localOnly bool, orderByActive bool, limit int, offset int) ([]*gtsmodel.Account, error)
+
 	// GetAccountStatusesCount is a shortcut for the common action of counting statuses produced by accountID.
 	CountAccountStatuses(ctx context.Context, accountID string) (int, error)
 
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -35,4 +36,23 @@ type Application interface {
 
 	// DeleteApplicationByClientID deletes the application with corresponding client_id value from the database.
 	DeleteApplicationByClientID(ctx context.Context, clientID string) error
+
+	// GetTokenByID fetches the oauth token with the given ID.
+	GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error)
+
+	// GetTokenByAccess fetches the oauth token with the given access token.
+	GetTokenByAccess(ctx context.Context, access string) (*gtsmodel.Token, error)
+
+	// GetTokenByRefresh fetches the oauth token with the given refresh token.
+	GetTokenByRefresh(ctx context.Context, refresh string) (*gtsmodel.Token, error)
+
+	// GetAccessTokensByUserID fetches all oauth tokens owned by the given user
+	// that have an access token set (ie., not pending authorization codes), newest first.
+	GetAccessTokensByUserID(ctx context.Context, userID string) ([]*gtsmodel.Token, error)
+
+	// UpdateTokenLastUsed sets the last used time of the token with the given access token.
+	UpdateTokenLastUsed(ctx context.Context, access string, lastUsed time.Time) error
+
+	// DeleteTokenByID deletes the oauth token with the given ID.
+	DeleteTokenByID(ctx context.Context, id string) error
 }
@@ -20,6 +20,8 @@ package bundb
 import (
 	"context"
 	"errors"
+	"net"
+	"slices"
 	"strings"
 	"time"
 
@@ -30,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
@@ -251,6 +254,155 @@ func (a *accountDB) getAccount(ctx context.Context, lookup string, dbQuery func(
 	return account, nil
 }
 
+func (a *accountDB) GetAccounts(
+	ctx context.Context,
+	origin string,
+	status string,
+	mods bool,
+	invitedBy string,
+	username string,
+	displayName string,
+	domain string,
+	email string,
+	ip net.IP,
+	page *paging.Page,
+) ([]*gtsmodel.Account, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		accountIDs = make([]string, 0, limit)
+	)
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		// Local accounts have a user, join on
+		// this in case we need to filter by it.
+		Join(
+			"LEFT JOIN ? AS ? ON ? = ?",
+			bun.Ident("users"), bun.Ident("user"),
+			bun.Ident("user.account_id"), bun.Ident("account.id"),
+		)
+
+	if maxID != "" {
+		// Return only accounts LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("account.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only accounts HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("account.id"), minID)
+	}
+
+	switch origin {
+	case "local":
+		q = q.Where("? IS NULL", bun.Ident("account.domain"))
+	case "remote":
+		q = q.Where("? IS NOT NULL", bun.Ident("account.domain"))
+	}
+
+	switch status {
+	case "active":
+		q = q.Where("? IS NULL", bun.Ident("account.suspended_at"))
+	case "pending":
+		q = q.Where("? = ?", bun.Ident("user.approved"), false)
+	case "disabled":
+		q = q.Where("? = ?", bun.Ident("user.disabled"), true)
+	case "silenced":
+		q = q.Where("? IS NOT NULL", bun.Ident("account.silenced_at"))
+	case "suspended":
+		q = q.Where("? IS NOT NULL", bun.Ident("account.suspended_at"))
+	}
+
+	if mods {
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("user.admin"), true).
+				WhereOr("? = ?", bun.Ident("user.moderator"), true)
+		})
+	}
+
+	if invitedBy != "" {
+		// Select IDs of invites created by invitedBy.
+		invitesQ := a.db.
+			NewSelect().
+			Table("invites").
+			Column("id").
+			Where("? = ?", bun.Ident("account_id"), invitedBy)
+
+		q = q.Where("? IN (?)", bun.Ident("user.invite_id"), invitesQ)
+	}
+
+	if username != "" {
+		q = whereStartsLike(q, bun.Ident("account.username"), username)
+	}
+
+	if displayName != "" {
+		q = whereLike(q, bun.Ident("account.display_name"), displayName)
+	}
+
+	if domain != "" {
+		q = q.Where("? = ?", bun.Ident("account.domain"), domain)
+	}
+
+	if email != "" {
+		// Search both confirmed and unconfirmed email,
+		// as a user may not have confirmed theirs yet.
+		like := likeOperator(q)
+		search := `%` + likeEscaper.Replace(email) + `%`
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? ? ? ESCAPE ?", bun.Ident("user.email"), bun.Safe(like), search, `\`).
+				WhereOr("? ? ? ESCAPE ?", bun.Ident("user.unconfirmed_email"), bun.Safe(like), search, `\`)
+		})
+	}
+
+	if ip != nil {
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("user.sign_up_ip"), ip).
+				WhereOr("? = ?", bun.Ident("user.current_sign_in_ip"), ip).
+				WhereOr("? = ?", bun.Ident("user.last_sign_in_ip"), ip)
+		})
+	}
+
+	if limit > 0 {
+		// Limit amount of accounts returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("account.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("account.id"))
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	if len(accountIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want accounts
+	// to be sorted by ID desc, so reverse ids slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(accountIDs)
+	}
+
+	// Convert account IDs into account objects.
+	return a.GetAccountsByIDs(ctx, accountIDs)
+}
+
 func (a *accountDB) PopulateAccount(ctx context.Context, account *gtsmodel.Account) error {
 	var (
 		err  error
@@ -387,11 +539,20 @@ func (a *accountDB) UpdateAccount(ctx context.Context, account *gtsmodel.Account
 			}
 
 			// update the account
-			_, err := tx.NewUpdate().
+			q := tx.NewUpdate().
 				Model(account).
 				Where("? = ?", bun.Ident("account.id"), account.ID).
-				Column(columns...).
-				Exec(ctx)
+				Column(columns...)
+
+			if len(columns) == 0 {
+				// Last status time is maintained by
+				// PutStatus directly in the db, so the
+				// model's copy may be stale; don't let
+				// a full update write it back.
+				q = q.ExcludeColumn("last_status_at")
+			}
+
+			_, err := q.Exec(ctx)
 			return err
 		})
 	})
@@ -517,6 +678,62 @@ func (a *accountDB) GetAccountsUsingEmoji(ctx context.Context, emojiID string) (
 	return a.GetAccountsByIDs(ctx, accountIDs)
 }
 
+func (a *accountDB) GetDirectoryAccounts(
+	ctx context.Context,
+	localOnly bool,
+	orderByActive bool,
+	limit int,
+	offset int,
+) ([]*gtsmodel.Account, error) {
+	var accountIDs []string
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? = ?", bun.Ident("account.discoverable"), true).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		Where("? IS NULL", bun.Ident("account.moved_to_uri"))
+
+	if localOnly {
+		// Only local accounts
+		// have no domain set.
+		q = q.Where("? IS NULL", bun.Ident("account.domain"))
+	}
+
+	if orderByActive {
+		// Most recently posting accounts first, with accounts that
+		// have never posted sorted last on both sqlite + postgres
+		// (which otherwise disagree on where NULLs go).
+		q = q.
+			OrderExpr("? IS NULL", bun.Ident("account.last_status_at")).
+			OrderExpr("? DESC", bun.Ident("account.last_status_at"))
+	}
+
+	// Newest accounts first, either as the
+	// primary ordering or as a tie-breaker.
+	q = q.OrderExpr("? DESC", bun.Ident("account.id"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if offset > 0 {
+		q = q.Offset(offset)
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	if len(accountIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// Convert account IDs into account objects.
+	return a.GetAccountsByIDs(ctx, accountIDs)
+}
+
 func (a *accountDB) GetAccountFaves(ctx context.Context, accountID string) ([]*gtsmodel.StatusFave, error) {
 	faves := new([]*gtsmodel.StatusFave)
 
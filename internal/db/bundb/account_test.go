@@ -32,6 +32,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 )
 
@@ -376,6 +379,156 @@ func (suite *AccountTestSuite) TestCountAccountPinnedNothingPinned() {
 	suite.Equal(pinned, 0) // This account has nothing pinned.
 }
 
+func (suite *AccountTestSuite) TestGetDirectoryAccounts() {
+	ctx := context.Background()
+
+	// Local discoverable accounts only.
+	accounts, err := suite.db.GetDirectoryAccounts(ctx, true, true, 0, 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(accounts, 3)
+	for _, account := range accounts {
+		suite.True(account.IsLocal())
+		suite.True(*account.Discoverable)
+		suite.True(account.SuspendedAt.IsZero())
+	}
+
+	// Local + remote discoverable accounts, newest first.
+	accounts, err = suite.db.GetDirectoryAccounts(ctx, false, false, 0, 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(accounts, 6)
+	for i := 1; i < len(accounts); i++ {
+		suite.Greater(accounts[i-1].ID, accounts[i].ID)
+	}
+
+	// Page through with limit + offset.
+	accounts, err = suite.db.GetDirectoryAccounts(ctx, false, false, 2, 5)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(accounts, 1)
+
+	// Offset past the end.
+	_, err = suite.db.GetDirectoryAccounts(ctx, false, false, 2, 6)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *AccountTestSuite) TestGetDirectoryAccountsByActive() {
+	ctx := context.Background()
+
+	accounts, err := suite.db.GetDirectoryAccounts(ctx, false, false, 0, 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Post a new status as the oldest
+	// discoverable account in the directory.
+	target := accounts[len(accounts)-1]
+	statusID := id.NewULID()
+	if err := suite.db.PutStatus(ctx, &gtsmodel.Status{
+		ID:                  statusID,
+		CreatedAt:           time.Now(),
+		URI:                 target.URI + "/statuses/" + statusID,
+		Local:               util.Ptr(target.IsLocal()),
+		AccountID:           target.ID,
+		AccountURI:          target.URI,
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           util.Ptr(true),
+		Boostable:           util.Ptr(true),
+		Replyable:           util.Ptr(true),
+		Likeable:            util.Ptr(true),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// It should now be first when
+	// ordering by most recently active.
+	accounts, err = suite.db.GetDirectoryAccounts(ctx, false, true, 0, 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(accounts, 6)
+	suite.Equal(target.ID, accounts[0].ID)
+}
+
+func (suite *AccountTestSuite) TestGetAccounts() {
+	ctx := context.Background()
+
+	// All accounts, newest first.
+	accounts, err := suite.db.GetAccounts(ctx, "", "", false, "", "", "", "", "", nil, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(accounts)
+	for i := 1; i < len(accounts); i++ {
+		suite.Greater(accounts[i-1].ID, accounts[i].ID)
+	}
+
+	// Remote accounts on a given domain.
+	accounts, err = suite.db.GetAccounts(ctx, "remote", "", false, "", "", "", "example.org", "", nil, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(accounts)
+	for _, account := range accounts {
+		suite.Equal("example.org", account.Domain)
+	}
+
+	// Local accounts by username prefix.
+	accounts, err = suite.db.GetAccounts(ctx, "local", "", false, "", "the_mighty", "", "", "", nil, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(accounts, 1)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, accounts[0].ID)
+
+	// Local accounts by sign-up / sign-in IP.
+	testUser := suite.testUsers["local_account_1"]
+	accounts, err = suite.db.GetAccounts(ctx, "local", "", false, "", "", "", "", "", testUser.SignUpIP, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(accounts)
+
+	// Local accounts by email.
+	accounts, err = suite.db.GetAccounts(ctx, "", "", false, "", "", "", "", testUser.Email, nil, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(accounts, 1)
+	suite.Equal(testUser.AccountID, accounts[0].ID)
+
+	// Suspended accounts.
+	suspended := new(gtsmodel.Account)
+	*suspended = *suite.testAccounts["remote_account_1"]
+	suspended.SuspendedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, suspended, "suspended_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accounts, err = suite.db.GetAccounts(ctx, "", "suspended", false, "", "", "", "", "", nil, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(accounts, 1)
+	suite.Equal(suspended.ID, accounts[0].ID)
+
+	// Paging by limit.
+	accounts, err = suite.db.GetAccounts(ctx, "", "", false, "", "", "", "", "", nil, &paging.Page{Limit: 2})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(accounts, 2)
+
+	// No matches.
+	_, err = suite.db.GetAccounts(ctx, "", "", false, "", "nobody_has_this_name", "", "", "", nil, nil)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
@@ -132,6 +132,7 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 			FollowingURI:          uris.FollowingURI,
 			FollowersURI:          uris.FollowersURI,
 			FeaturedCollectionURI: uris.FeaturedCollectionURI,
+			FeaturedTagsURI:       uris.FeaturedTagsURI,
 			ActorType:             ap.ActorPerson,
 			PrivateKey:            privKey,
 			PublicKey:             &privKey.PublicKey,
@@ -191,6 +192,7 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 		UnconfirmedEmail:       newSignup.Email,
 		CreatedByApplicationID: newSignup.AppID,
 		ExternalID:             newSignup.ExternalID,
+		InviteID:               newSignup.InviteID,
 	}
 
 	if newSignup.EmailVerified {
@@ -265,6 +267,7 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) error {
 		FollowersURI:          newAccountURIs.FollowersURI,
 		FollowingURI:          newAccountURIs.FollowingURI,
 		FeaturedCollectionURI: newAccountURIs.FeaturedCollectionURI,
+		FeaturedTagsURI:       newAccountURIs.FeaturedTagsURI,
 	}
 
 	// insert the new account!
@@ -19,7 +19,9 @@ package bundb
 
 import (
 	"context"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/uptrace/bun"
@@ -95,3 +97,68 @@ func (a *applicationDB) DeleteApplicationByClientID(ctx context.Context, clientI
 
 	return nil
 }
+
+func (a *applicationDB) GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error) {
+	return a.getToken(ctx, "id", id)
+}
+
+func (a *applicationDB) GetTokenByAccess(ctx context.Context, access string) (*gtsmodel.Token, error) {
+	return a.getToken(ctx, "access", access)
+}
+
+func (a *applicationDB) GetTokenByRefresh(ctx context.Context, refresh string) (*gtsmodel.Token, error) {
+	return a.getToken(ctx, "refresh", refresh)
+}
+
+func (a *applicationDB) getToken(ctx context.Context, column string, value string) (*gtsmodel.Token, error) {
+	if value == "" {
+		// Empty access + refresh tokens are
+		// stored as '', don't match those.
+		return nil, db.ErrNoEntries
+	}
+
+	token := new(gtsmodel.Token)
+	if err := a.db.
+		NewSelect().
+		Model(token).
+		Where("? = ?", bun.Ident(column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (a *applicationDB) GetAccessTokensByUserID(ctx context.Context, userID string) ([]*gtsmodel.Token, error) {
+	tokens := []*gtsmodel.Token{}
+	if err := a.db.
+		NewSelect().
+		Model(&tokens).
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Where("? != ''", bun.Ident("access")).
+		OrderExpr("? DESC", bun.Ident("id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (a *applicationDB) UpdateTokenLastUsed(ctx context.Context, access string, lastUsed time.Time) error {
+	_, err := a.db.
+		NewUpdate().
+		Table("tokens").
+		Set("? = ?", bun.Ident("last_used"), lastUsed).
+		Where("? = ?", bun.Ident("access"), access).
+		Exec(ctx)
+	return err
+}
+
+func (a *applicationDB) DeleteTokenByID(ctx context.Context, id string) error {
+	_, err := a.db.
+		NewDelete().
+		Table("tokens").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
@@ -58,10 +58,17 @@ type DBService struct {
 	db.Admin
 	db.Application
 	db.Basic
+	db.Card
+	db.ContentWarningPolicy
+	db.Conversation
+	db.Delivery
 	db.Domain
+	db.EmailDomainBlock
 	db.Emoji
+	db.Filter
 	db.HeaderFilter
 	db.Instance
+	db.Invite
 	db.List
 	db.Marker
 	db.Media
@@ -71,16 +78,22 @@ type DBService struct {
 	db.Relationship
 	db.Report
 	db.Rule
+	db.ScheduledStatus
 	db.Search
 	db.Session
 	db.Status
 	db.StatusBookmark
+	db.StatusEdit
 	db.StatusFave
+	db.Suggestion
 	db.Tag
 	db.Thread
 	db.Timeline
+	db.Trend
 	db.User
 	db.Tombstone
+	db.WebAuthn
+	db.WebPush
 	db *bun.DB
 }
 
@@ -184,14 +197,38 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 		Basic: &basicDB{
 			db: db,
 		},
+		Card: &cardDB{
+			db:    db,
+			state: state,
+		},
+		ContentWarningPolicy: &contentWarningPolicyDB{
+			db:    db,
+			state: state,
+		},
+		Conversation: &conversationDB{
+			db:    db,
+			state: state,
+		},
+		Delivery: &deliveryDB{
+			db:    db,
+			state: state,
+		},
 		Domain: &domainDB{
 			db:    db,
 			state: state,
 		},
+		EmailDomainBlock: &emailDomainBlockDB{
+			db:    db,
+			state: state,
+		},
 		Emoji: &emojiDB{
 			db:    db,
 			state: state,
 		},
+		Filter: &filterDB{
+			db:    db,
+			state: state,
+		},
 		HeaderFilter: &headerFilterDB{
 			db:    db,
 			state: state,
@@ -200,6 +237,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Invite: &inviteDB{
+			db:    db,
+			state: state,
+		},
 		List: &listDB{
 			db:    db,
 			state: state,
@@ -236,6 +277,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		ScheduledStatus: &scheduledStatusDB{
+			db:    db,
+			state: state,
+		},
 		Search: &searchDB{
 			db:    db,
 			state: state,
@@ -251,10 +296,18 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		StatusEdit: &statusEditDB{
+			db:    db,
+			state: state,
+		},
 		StatusFave: &statusFaveDB{
 			db:    db,
 			state: state,
 		},
+		Suggestion: &suggestionDB{
+			db:    db,
+			state: state,
+		},
 		Tag: &tagDB{
 			db:    db,
 			state: state,
@@ -267,6 +320,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Trend: &trendDB{
+			db:    db,
+			state: state,
+		},
 		User: &userDB{
 			db:    db,
 			state: state,
@@ -275,6 +332,14 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		WebAuthn: &webAuthnDB{
+			db:    db,
+			state: state,
+		},
+		WebPush: &webPushDB{
+			db:    db,
+			state: state,
+		},
 		db: db,
 	}
 
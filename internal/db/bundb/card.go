@@ -0,0 +1,234 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type cardDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (c *cardDB) GetCardByID(ctx context.Context, id string) (*gtsmodel.Card, error) {
+	return c.getCard(
+		ctx,
+		"ID",
+		func(card *gtsmodel.Card) error {
+			return c.db.NewSelect().
+				Model(card).
+				Where("? = ?", bun.Ident("card.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (c *cardDB) GetCardByURL(ctx context.Context, url string) (*gtsmodel.Card, error) {
+	return c.getCard(
+		ctx,
+		"URL",
+		func(card *gtsmodel.Card) error {
+			return c.db.NewSelect().
+				Model(card).
+				Where("? = ?", bun.Ident("card.url"), url).
+				Scan(ctx)
+		},
+		url,
+	)
+}
+
+func (c *cardDB) GetCardByImageID(ctx context.Context, imageID string) (*gtsmodel.Card, error) {
+	return c.getCard(
+		ctx,
+		"ImageID",
+		func(card *gtsmodel.Card) error {
+			return c.db.NewSelect().
+				Model(card).
+				Where("? = ?", bun.Ident("card.image_id"), imageID).
+				Scan(ctx)
+		},
+		imageID,
+	)
+}
+
+func (c *cardDB) getCard(ctx context.Context, lookup string, dbQuery func(*gtsmodel.Card) error, keyParts ...any) (*gtsmodel.Card, error) {
+	// Fetch card from database cache with loader callback
+	card, err := c.state.Caches.GTS.Card.LoadOne(lookup, func() (*gtsmodel.Card, error) {
+		var card gtsmodel.Card
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&card); err != nil {
+			return nil, err
+		}
+
+		return &card, nil
+	}, keyParts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return card, nil
+	}
+
+	// Further populate the card fields where applicable.
+	if err := c.PopulateCard(ctx, card); err != nil {
+		return nil, err
+	}
+
+	return card, nil
+}
+
+func (c *cardDB) GetUnusedCards(ctx context.Context, maxID string, limit int) ([]*gtsmodel.Card, error) {
+	var cardIDs []string
+
+	// Select IDs of cards not
+	// referenced by any status.
+	q := c.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("cards"), bun.Ident("card")).
+		Column("card.id").
+		Where("NOT EXISTS (?)", c.db.NewSelect().
+			TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+			Column("status.id").
+			Where("? = ?", bun.Ident("status.card_id"), bun.Ident("card.id")),
+		).
+		Where("? < ?", bun.Ident("card.id"), maxID).
+		OrderExpr("? DESC", bun.Ident("card.id"))
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &cardIDs); err != nil {
+		return nil, err
+	}
+
+	return c.getCardsByIDs(ctx, cardIDs), nil
+}
+
+func (c *cardDB) GetCardsFetchedBefore(ctx context.Context, before time.Time, limit int) ([]*gtsmodel.Card, error) {
+	var cardIDs []string
+
+	q := c.db.NewSelect().
+		Table("cards").
+		Column("id").
+		Where("? IS NOT NULL", bun.Ident("image_id")).
+		Where("? < ?", bun.Ident("fetched_at"), before).
+		Order("fetched_at DESC")
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &cardIDs); err != nil {
+		return nil, err
+	}
+
+	return c.getCardsByIDs(ctx, cardIDs), nil
+}
+
+// getCardsByIDs fetches the cards with given IDs,
+// skipping (and logging) any that can't be loaded.
+func (c *cardDB) getCardsByIDs(ctx context.Context, ids []string) []*gtsmodel.Card {
+	cards := make([]*gtsmodel.Card, 0, len(ids))
+
+	for _, id := range ids {
+		// Attempt to fetch card from DB.
+		card, err := c.GetCardByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting card %s: %v", id, err)
+			continue
+		}
+
+		// Append card to return slice.
+		cards = append(cards, card)
+	}
+
+	return cards
+}
+
+func (c *cardDB) PopulateCard(ctx context.Context, card *gtsmodel.Card) error {
+	var (
+		err  error
+		errs gtserror.MultiError
+	)
+
+	if card.ImageID != "" && card.Image == nil {
+		// Card image is not set, fetch from database.
+		card.Image, err = c.state.DB.GetAttachmentByID(
+			ctx, // these are already barebones
+			card.ImageID,
+		)
+		if err != nil {
+			errs.Appendf("error populating card image: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (c *cardDB) PutCard(ctx context.Context, card *gtsmodel.Card) error {
+	return c.state.Caches.GTS.Card.Store(card, func() error {
+		_, err := c.db.NewInsert().Model(card).Exec(ctx)
+		return err
+	})
+}
+
+func (c *cardDB) UpdateCard(ctx context.Context, card *gtsmodel.Card, cols ...string) error {
+	card.UpdatedAt = time.Now()
+	if len(cols) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		cols = append(cols, "updated_at")
+	}
+
+	return c.state.Caches.GTS.Card.Store(card, func() error {
+		_, err := c.db.NewUpdate().
+			Model(card).
+			Column(cols...).
+			Where("? = ?", bun.Ident("card.id"), card.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (c *cardDB) DeleteCardByID(ctx context.Context, id string) error {
+	// Delete card by ID from database.
+	if _, err := c.db.NewDelete().
+		Table("cards").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Invalidate card by ID from cache.
+	c.state.Caches.GTS.Card.Invalidate("ID", id)
+
+	return nil
+}
@@ -0,0 +1,216 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type contentWarningPolicyDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (c *contentWarningPolicyDB) GetContentWarningPolicyByID(ctx context.Context, id string) (*gtsmodel.ContentWarningPolicy, error) {
+	return c.getContentWarningPolicy(
+		ctx,
+		"ID",
+		func(policy *gtsmodel.ContentWarningPolicy) error {
+			return c.db.NewSelect().Model(policy).
+				Where("? = ?", bun.Ident("content_warning_policy.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (c *contentWarningPolicyDB) GetContentWarningPolicyByAccountID(ctx context.Context, accountID string) (*gtsmodel.ContentWarningPolicy, error) {
+	return c.getContentWarningPolicy(
+		ctx,
+		"AccountID",
+		func(policy *gtsmodel.ContentWarningPolicy) error {
+			return c.db.NewSelect().Model(policy).
+				Where("? = ?", bun.Ident("content_warning_policy.account_id"), accountID).
+				Scan(ctx)
+		},
+		accountID,
+	)
+}
+
+func (c *contentWarningPolicyDB) GetContentWarningPolicyByDomain(ctx context.Context, domain string) (*gtsmodel.ContentWarningPolicy, error) {
+	return c.getContentWarningPolicy(
+		ctx,
+		"Domain",
+		func(policy *gtsmodel.ContentWarningPolicy) error {
+			return c.db.NewSelect().Model(policy).
+				Where("? = ?", bun.Ident("content_warning_policy.domain"), domain).
+				Scan(ctx)
+		},
+		domain,
+	)
+}
+
+func (c *contentWarningPolicyDB) getContentWarningPolicy(
+	ctx context.Context,
+	lookup string,
+	dbQuery func(*gtsmodel.ContentWarningPolicy) error,
+	keyParts ...any,
+) (*gtsmodel.ContentWarningPolicy, error) {
+	// Fetch policy from cache with loader callback
+	policy, err := c.state.Caches.GTS.ContentWarningPolicy.LoadOne(lookup, func() (*gtsmodel.ContentWarningPolicy, error) {
+		var policy gtsmodel.ContentWarningPolicy
+
+		// Not cached! Perform database query
+		if err := dbQuery(&policy); err != nil {
+			return nil, err
+		}
+
+		return &policy, nil
+	}, keyParts...)
+	if err != nil {
+		// already processed
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return policy, nil
+	}
+
+	if err := c.populateContentWarningPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (c *contentWarningPolicyDB) populateContentWarningPolicy(ctx context.Context, policy *gtsmodel.ContentWarningPolicy) error {
+	var err error
+
+	if policy.AccountID != "" && policy.Account == nil {
+		// Targeted account is not set, fetch from database.
+		policy.Account, err = c.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			policy.AccountID,
+		)
+		if err != nil {
+			return gtserror.Newf("error populating content warning policy account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *contentWarningPolicyDB) GetContentWarningPolicies(ctx context.Context) ([]*gtsmodel.ContentWarningPolicy, error) {
+	var policyIDs []string
+
+	if err := c.db.
+		NewSelect().
+		Table("content_warning_policies").
+		Column("id").
+		Order("id DESC").
+		Scan(ctx, &policyIDs); err != nil {
+		return nil, err
+	}
+
+	policies := make([]*gtsmodel.ContentWarningPolicy, 0, len(policyIDs))
+	for _, id := range policyIDs {
+		policy, err := c.GetContentWarningPolicyByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting content warning policy %s: %v", id, err)
+			continue
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+func (c *contentWarningPolicyDB) GetContentWarningPolicyForAccount(ctx context.Context, account *gtsmodel.Account) (*gtsmodel.ContentWarningPolicy, error) {
+	// Barebones is all we need to apply a policy.
+	ctx = gtscontext.SetBarebones(ctx)
+
+	// A policy targeting the account
+	// itself takes precedence.
+	policy, err := c.GetContentWarningPolicyByAccountID(ctx, account.ID)
+	if err == nil || !errors.Is(err, db.ErrNoEntries) {
+		return policy, err
+	}
+
+	if account.IsLocal() {
+		// Local accounts can
+		// only be targeted directly.
+		return nil, db.ErrNoEntries
+	}
+
+	return c.GetContentWarningPolicyByDomain(ctx, account.Domain)
+}
+
+func (c *contentWarningPolicyDB) PutContentWarningPolicy(ctx context.Context, policy *gtsmodel.ContentWarningPolicy) error {
+	return c.state.Caches.GTS.ContentWarningPolicy.Store(policy, func() error {
+		_, err := c.db.NewInsert().Model(policy).Exec(ctx)
+		return err
+	})
+}
+
+func (c *contentWarningPolicyDB) DeleteContentWarningPolicyByID(ctx context.Context, id string) error {
+	policy, err := c.GetContentWarningPolicyByID(gtscontext.SetBarebones(ctx), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// not an issue.
+			err = nil
+		}
+		return err
+	}
+
+	return c.deleteContentWarningPolicy(ctx, policy)
+}
+
+func (c *contentWarningPolicyDB) DeleteContentWarningPolicyByAccountID(ctx context.Context, accountID string) error {
+	policy, err := c.GetContentWarningPolicyByAccountID(gtscontext.SetBarebones(ctx), accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// not an issue.
+			err = nil
+		}
+		return err
+	}
+
+	return c.deleteContentWarningPolicy(ctx, policy)
+}
+
+func (c *contentWarningPolicyDB) deleteContentWarningPolicy(ctx context.Context, policy *gtsmodel.ContentWarningPolicy) error {
+	// Drop this now-cached policy on return after delete.
+	defer c.state.Caches.GTS.ContentWarningPolicy.Invalidate("ID", policy.ID)
+
+	// Finally delete policy from DB.
+	_, err := c.db.NewDelete().
+		Table("content_warning_policies").
+		Where("? = ?", bun.Ident("id"), policy.ID).
+		Exec(ctx)
+	return err
+}
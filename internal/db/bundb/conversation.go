@@ -0,0 +1,332 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type conversationDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (c *conversationDB) GetConversationByID(ctx context.Context, id string) (*gtsmodel.Conversation, error) {
+	return c.getConversation(
+		ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("? = ?", bun.Ident("conversation.id"), id)
+		},
+	)
+}
+
+func (c *conversationDB) GetConversationByThreadAndAccountIDs(
+	ctx context.Context,
+	accountID string,
+	threadID string,
+	otherAccountIDs []string,
+) (*gtsmodel.Conversation, error) {
+	otherAccountsKey := gtsmodel.ConversationOtherAccountsKey(otherAccountIDs)
+	return c.getConversation(
+		ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("conversation.account_id"), accountID).
+				Where("? = ?", bun.Ident("conversation.thread_id"), threadID).
+				Where("? = ?", bun.Ident("conversation.other_accounts_key"), otherAccountsKey)
+		},
+	)
+}
+
+func (c *conversationDB) getConversation(ctx context.Context, query func(*bun.SelectQuery) *bun.SelectQuery) (*gtsmodel.Conversation, error) {
+	var conversation gtsmodel.Conversation
+
+	q := c.db.
+		NewSelect().
+		Model(&conversation)
+
+	if err := query(q).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return &conversation, nil
+	}
+
+	if err := c.PopulateConversation(ctx, &conversation); err != nil {
+		return nil, err
+	}
+
+	return &conversation, nil
+}
+
+func (c *conversationDB) GetConversationsByOwnerAccountID(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Conversation, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		conversations = make([]*gtsmodel.Conversation, 0, limit)
+	)
+
+	q := c.db.
+		NewSelect().
+		Model(&conversations).
+		Where("? = ?", bun.Ident("conversation.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only conversations with last status LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("conversation.last_status_id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only conversations with last status HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("conversation.last_status_id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of conversations returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("conversation.last_status_id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("conversation.last_status_id"))
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want conversations
+	// to be sorted by last status ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(conversations)
+	}
+
+	return c.populateConversations(ctx, conversations)
+}
+
+func (c *conversationDB) GetConversationsByStatusID(ctx context.Context, statusID string) ([]*gtsmodel.Conversation, error) {
+	var conversations []*gtsmodel.Conversation
+
+	if err := c.db.
+		NewSelect().
+		Model(&conversations).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("conversation_to_statuses"), bun.Ident("conversation_to_status"),
+			bun.Ident("conversation_to_status.conversation_id"), bun.Ident("conversation.id"),
+		).
+		Where("? = ?", bun.Ident("conversation_to_status.status_id"), statusID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.populateConversations(ctx, conversations)
+}
+
+func (c *conversationDB) populateConversations(ctx context.Context, conversations []*gtsmodel.Conversation) ([]*gtsmodel.Conversation, error) {
+	if gtscontext.Barebones(ctx) {
+		// Only barebones models were requested.
+		return conversations, nil
+	}
+
+	for _, conversation := range conversations {
+		if err := c.PopulateConversation(ctx, conversation); err != nil {
+			return nil, err
+		}
+	}
+
+	return conversations, nil
+}
+
+func (c *conversationDB) GetConversationLatestStatusID(ctx context.Context, conversationID string) (string, error) {
+	var statusID string
+
+	if err := c.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("conversation_to_statuses"), bun.Ident("conversation_to_status")).
+		Column("conversation_to_status.status_id").
+		Where("? = ?", bun.Ident("conversation_to_status.conversation_id"), conversationID).
+		OrderExpr("? DESC", bun.Ident("conversation_to_status.status_id")).
+		Limit(1).
+		Scan(ctx, &statusID); err != nil {
+		return "", err
+	}
+
+	return statusID, nil
+}
+
+func (c *conversationDB) PopulateConversation(ctx context.Context, conversation *gtsmodel.Conversation) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(3)
+	)
+
+	if conversation.Account == nil {
+		// Conversation owner is not set, fetch from the database.
+		conversation.Account, err = c.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			conversation.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating conversation account: %w", err)
+		}
+	}
+
+	if len(conversation.OtherAccounts) != len(conversation.OtherAccountIDs) {
+		// Conversation participants are out-of-date with IDs, repopulate.
+		conversation.OtherAccounts, err = c.state.DB.GetAccountsByIDs(
+			gtscontext.SetBarebones(ctx),
+			conversation.OtherAccountIDs,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating conversation other accounts: %w", err)
+		}
+	}
+
+	if conversation.LastStatus == nil {
+		// Conversation last status is not set, fetch from the database.
+		conversation.LastStatus, err = c.state.DB.GetStatusByID(
+			ctx,
+			conversation.LastStatusID,
+		)
+		if err != nil {
+			errs.Appendf("error populating conversation last status: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (c *conversationDB) PutConversation(ctx context.Context, conversation *gtsmodel.Conversation) error {
+	// Ensure key is in sync with participants.
+	conversation.OtherAccountsKey = gtsmodel.ConversationOtherAccountsKey(conversation.OtherAccountIDs)
+
+	_, err := c.db.
+		NewInsert().
+		Model(conversation).
+		Exec(ctx)
+	return err
+}
+
+func (c *conversationDB) UpdateConversation(ctx context.Context, conversation *gtsmodel.Conversation, columns ...string) error {
+	conversation.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := c.db.
+		NewUpdate().
+		Model(conversation).
+		Column(columns...).
+		Where("? = ?", bun.Ident("conversation.id"), conversation.ID).
+		Exec(ctx)
+	return err
+}
+
+func (c *conversationDB) AddStatusToConversation(ctx context.Context, conversationID string, statusID string) error {
+	_, err := c.db.
+		NewInsert().
+		Model(&gtsmodel.ConversationToStatus{
+			ConversationID: conversationID,
+			StatusID:       statusID,
+		}).
+		On("CONFLICT (?, ?) DO NOTHING", bun.Ident("conversation_id"), bun.Ident("status_id")).
+		Exec(ctx)
+	return err
+}
+
+func (c *conversationDB) DeleteStatusFromConversations(ctx context.Context, statusID string) error {
+	_, err := c.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("conversation_to_statuses"), bun.Ident("conversation_to_status")).
+		Where("? = ?", bun.Ident("conversation_to_status.status_id"), statusID).
+		Exec(ctx)
+	return err
+}
+
+func (c *conversationDB) DeleteConversationByID(ctx context.Context, id string) error {
+	return c.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete links between this conversation and its statuses.
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("conversation_to_statuses"), bun.Ident("conversation_to_status")).
+			Where("? = ?", bun.Ident("conversation_to_status.conversation_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Delete the conversation itself.
+		_, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("conversations"), bun.Ident("conversation")).
+			Where("? = ?", bun.Ident("conversation.id"), id).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (c *conversationDB) DeleteConversationsByOwnerAccountID(ctx context.Context, accountID string) error {
+	return c.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete links between this account's
+		// conversations and their statuses.
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("conversation_to_statuses"), bun.Ident("conversation_to_status")).
+			Where("? IN (?)",
+				bun.Ident("conversation_to_status.conversation_id"),
+				tx.NewSelect().
+					TableExpr("? AS ?", bun.Ident("conversations"), bun.Ident("conversation")).
+					Column("conversation.id").
+					Where("? = ?", bun.Ident("conversation.account_id"), accountID),
+			).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Delete the conversations themselves.
+		_, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("conversations"), bun.Ident("conversation")).
+			Where("? = ?", bun.Ident("conversation.account_id"), accountID).
+			Exec(ctx)
+		return err
+	})
+}
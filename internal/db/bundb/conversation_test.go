@@ -0,0 +1,175 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ConversationTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ConversationTestSuite) newConversation() *gtsmodel.Conversation {
+	var (
+		owner  = suite.testAccounts["local_account_1"]
+		other  = suite.testAccounts["local_account_2"]
+		status = suite.testStatuses["local_account_2_status_6"]
+	)
+
+	return &gtsmodel.Conversation{
+		ID:              "01HQSF5Q7JW6Q9M6E3N8TPZ0ME",
+		AccountID:       owner.ID,
+		OtherAccountIDs: []string{other.ID},
+		ThreadID:        status.ThreadID,
+		LastStatusID:    status.ID,
+		Read:            util.Ptr(false),
+	}
+}
+
+func (suite *ConversationTestSuite) TestPutGetConversation() {
+	ctx := context.Background()
+
+	conversation := suite.newConversation()
+	if err := suite.db.PutConversation(ctx, conversation); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbConversation, err := suite.db.GetConversationByThreadAndAccountIDs(
+		ctx,
+		conversation.AccountID,
+		conversation.ThreadID,
+		conversation.OtherAccountIDs,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(conversation.ID, dbConversation.ID)
+	suite.Equal(conversation.OtherAccountIDs, dbConversation.OtherAccountIDs)
+	suite.False(*dbConversation.Read)
+	suite.NotNil(dbConversation.Account)
+	suite.Len(dbConversation.OtherAccounts, 1)
+	suite.NotNil(dbConversation.LastStatus)
+
+	// Different set of participants should not match.
+	_, err = suite.db.GetConversationByThreadAndAccountIDs(
+		ctx,
+		conversation.AccountID,
+		conversation.ThreadID,
+		nil,
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *ConversationTestSuite) TestGetConversationsByOwnerAccountID() {
+	ctx := context.Background()
+
+	conversation := suite.newConversation()
+	if err := suite.db.PutConversation(ctx, conversation); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	conversations, err := suite.db.GetConversationsByOwnerAccountID(ctx, conversation.AccountID, &paging.Page{Limit: 20})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(conversations, 1)
+
+	// Paging is done by last status ID.
+	conversations, err = suite.db.GetConversationsByOwnerAccountID(ctx, conversation.AccountID, &paging.Page{
+		Max:   paging.MaxID(conversation.LastStatusID),
+		Limit: 20,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(conversations)
+}
+
+func (suite *ConversationTestSuite) TestConversationStatuses() {
+	ctx := context.Background()
+
+	conversation := suite.newConversation()
+	if err := suite.db.PutConversation(ctx, conversation); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Adding the same status twice should be a no-op.
+	for i := 0; i < 2; i++ {
+		if err := suite.db.AddStatusToConversation(ctx, conversation.ID, conversation.LastStatusID); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	conversations, err := suite.db.GetConversationsByStatusID(ctx, conversation.LastStatusID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(conversations, 1)
+
+	latestStatusID, err := suite.db.GetConversationLatestStatusID(ctx, conversation.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(conversation.LastStatusID, latestStatusID)
+
+	if err := suite.db.DeleteStatusFromConversations(ctx, conversation.LastStatusID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetConversationLatestStatusID(ctx, conversation.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *ConversationTestSuite) TestDeleteConversationsByOwnerAccountID() {
+	ctx := context.Background()
+
+	conversation := suite.newConversation()
+	if err := suite.db.PutConversation(ctx, conversation); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.AddStatusToConversation(ctx, conversation.ID, conversation.LastStatusID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.DeleteConversationsByOwnerAccountID(ctx, conversation.AccountID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetConversationByID(ctx, conversation.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	conversations, err := suite.db.GetConversationsByStatusID(ctx, conversation.LastStatusID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(conversations)
+}
+
+func TestConversationTestSuite(t *testing.T) {
+	suite.Run(t, new(ConversationTestSuite))
+}
@@ -0,0 +1,198 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type deliveryDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (d *deliveryDB) GetDeliveryByID(ctx context.Context, id string) (*gtsmodel.Delivery, error) {
+	var delivery gtsmodel.Delivery
+
+	if err := d.db.
+		NewSelect().
+		Model(&delivery).
+		Where("? = ?", bun.Ident("delivery.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (d *deliveryDB) GetDueDeliveries(
+	ctx context.Context,
+	now time.Time,
+	maxID string,
+	limit int,
+) ([]*gtsmodel.Delivery, error) {
+	deliveries := make([]*gtsmodel.Delivery, 0, limit)
+
+	q := d.db.
+		NewSelect().
+		Model(&deliveries).
+		Where("? <= ?", bun.Ident("delivery.next_attempt_at"), now)
+
+	if maxID != "" {
+		// Page on from the last seen ID.
+		q = q.Where("? > ?", bun.Ident("delivery.id"), maxID)
+	}
+
+	if err := q.
+		Order("delivery.id ASC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (d *deliveryDB) GetDeliveriesForDomain(
+	ctx context.Context,
+	domain string,
+	limit int,
+) ([]*gtsmodel.Delivery, error) {
+	deliveries := make([]*gtsmodel.Delivery, 0, limit)
+
+	if err := d.db.
+		NewSelect().
+		Model(&deliveries).
+		// Payloads can be large and aren't
+		// needed for inspecting the queue.
+		ExcludeColumn("data").
+		Where("? = ?", bun.Ident("delivery.target_domain"), domain).
+		Order("delivery.next_attempt_at ASC").
+		Order("delivery.id ASC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (d *deliveryDB) CountDeliveriesPerDomain(ctx context.Context) (map[string]int, error) {
+	var counts []struct {
+		TargetDomain string
+		Count        int
+	}
+
+	if err := d.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("deliveries"), bun.Ident("delivery")).
+		Column("delivery.target_domain").
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Group("delivery.target_domain").
+		Scan(ctx, &counts); err != nil {
+		return nil, err
+	}
+
+	perDomain := make(map[string]int, len(counts))
+	for _, c := range counts {
+		perDomain[c.TargetDomain] = c.Count
+	}
+
+	return perDomain, nil
+}
+
+func (d *deliveryDB) PutDeliveries(ctx context.Context, deliveries ...*gtsmodel.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	_, err := d.db.
+		NewInsert().
+		Model(&deliveries).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) UpdateDelivery(
+	ctx context.Context,
+	delivery *gtsmodel.Delivery,
+	columns ...string,
+) error {
+	delivery.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := d.db.
+		NewUpdate().
+		Model(delivery).
+		Column(columns...).
+		Where("? = ?", bun.Ident("delivery.id"), delivery.ID).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) SetDeliveriesDueForDomain(
+	ctx context.Context,
+	domain string,
+	at time.Time,
+) (int, error) {
+	res, err := d.db.
+		NewUpdate().
+		Table("deliveries").
+		Set("? = ?", bun.Ident("next_attempt_at"), at).
+		Set("? = ?", bun.Ident("updated_at"), time.Now()).
+		Where("? = ?", bun.Ident("target_domain"), domain).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+	return int(rows), err
+}
+
+func (d *deliveryDB) DeleteDeliveryByID(ctx context.Context, id string) error {
+	_, err := d.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("deliveries"), bun.Ident("delivery")).
+		Where("? = ?", bun.Ident("delivery.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) DeleteDeliveriesForDomain(ctx context.Context, domain string) (int, error) {
+	res, err := d.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("deliveries"), bun.Ident("delivery")).
+		Where("? = ?", bun.Ident("delivery.target_domain"), domain).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+	return int(rows), err
+}
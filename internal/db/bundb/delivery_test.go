@@ -0,0 +1,122 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type DeliveryTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *DeliveryTestSuite) TestDeliveryQueue() {
+	var (
+		ctx    = context.Background()
+		now    = time.Now()
+		pubKey = suite.testAccounts["local_account_1"].PublicKeyURI
+	)
+
+	newDelivery := func(id string, domain string, nextAttemptAt time.Time) *gtsmodel.Delivery {
+		return &gtsmodel.Delivery{
+			ID:            id,
+			PubKeyID:      pubKey,
+			TargetURI:     "https://" + domain + "/inbox",
+			TargetDomain:  domain,
+			Data:          []byte(`{"hello":"world"}`),
+			NextAttemptAt: nextAttemptAt,
+		}
+	}
+
+	// Use fixed IDs, since ULIDs generated
+	// within the same ms aren't ordered.
+	due1 := newDelivery("01HPBZ0N4W4Q9KTNZ4P0J3Q5AA", "example.org", now.Add(-time.Minute))
+	due2 := newDelivery("01HPBZ0N4W4Q9KTNZ4P0J3Q5AB", "example.com", now.Add(-time.Minute))
+	notDue := newDelivery("01HPBZ0N4W4Q9KTNZ4P0J3Q5AC", "example.org", now.Add(time.Hour))
+
+	if err := suite.db.PutDeliveries(ctx, due1, due2, notDue); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only due deliveries should be returned, paged by ID.
+	deliveries, err := suite.db.GetDueDeliveries(ctx, now, "", 1)
+	suite.NoError(err)
+	if suite.Len(deliveries, 1) {
+		suite.Equal(due1.ID, deliveries[0].ID)
+		suite.Equal(due1.Data, deliveries[0].Data)
+	}
+
+	deliveries, err = suite.db.GetDueDeliveries(ctx, now, due1.ID, 10)
+	suite.NoError(err)
+	if suite.Len(deliveries, 1) {
+		suite.Equal(due2.ID, deliveries[0].ID)
+	}
+
+	// Deliveries should be counted per domain.
+	counts, err := suite.db.CountDeliveriesPerDomain(ctx)
+	suite.NoError(err)
+	suite.Equal(map[string]int{"example.org": 2, "example.com": 1}, counts)
+
+	// Update a delivery after a failed attempt.
+	due1.Attempts = 1
+	due1.LastError = "oh no"
+	due1.NextAttemptAt = now.Add(time.Hour)
+	suite.NoError(suite.db.UpdateDelivery(ctx, due1, "attempts", "last_error", "next_attempt_at"))
+
+	dbDelivery, err := suite.db.GetDeliveryByID(ctx, due1.ID)
+	suite.NoError(err)
+	suite.Equal(1, dbDelivery.Attempts)
+	suite.Equal("oh no", dbDelivery.LastError)
+
+	// Listing per domain shouldn't include payloads.
+	deliveries, err = suite.db.GetDeliveriesForDomain(ctx, "example.org", 10)
+	suite.NoError(err)
+	suite.Len(deliveries, 2)
+	for _, d := range deliveries {
+		suite.Empty(d.Data)
+	}
+
+	// Flush all deliveries for a domain.
+	n, err := suite.db.SetDeliveriesDueForDomain(ctx, "example.org", now)
+	suite.NoError(err)
+	suite.Equal(2, n)
+
+	deliveries, err = suite.db.GetDueDeliveries(ctx, now, "", 10)
+	suite.NoError(err)
+	suite.Len(deliveries, 3)
+
+	// Delete deliveries.
+	suite.NoError(suite.db.DeleteDeliveryByID(ctx, due2.ID))
+
+	n, err = suite.db.DeleteDeliveriesForDomain(ctx, "example.org")
+	suite.NoError(err)
+	suite.Equal(2, n)
+
+	counts, err = suite.db.CountDeliveriesPerDomain(ctx)
+	suite.NoError(err)
+	suite.Empty(counts)
+}
+
+func TestDeliveryTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliveryTestSuite))
+}
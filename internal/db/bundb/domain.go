@@ -20,6 +20,7 @@ package bundb
 import (
 	"context"
 	"net/url"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -110,6 +111,28 @@ func (d *domainDB) GetDomainAllowByID(ctx context.Context, id string) (*gtsmodel
 	return &allow, nil
 }
 
+func (d *domainDB) UpdateDomainAllow(ctx context.Context, allow *gtsmodel.DomainAllow, columns ...string) error {
+	allow.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	// Attempt to update domain allow
+	if _, err := d.db.NewUpdate().
+		Model(allow).
+		Column(columns...).
+		Where("? = ?", bun.Ident("domain_allow.id"), allow.ID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the domain allow cache (for later reload)
+	d.state.Caches.GTS.DomainAllow.Clear()
+
+	return nil
+}
+
 func (d *domainDB) DeleteDomainAllow(ctx context.Context, domain string) error {
 	// Normalize the domain as punycode
 	domain, err := util.Punify(domain)
@@ -146,8 +169,8 @@ func (d *domainDB) CreateDomainBlock(ctx context.Context, block *gtsmodel.Domain
 		return err
 	}
 
-	// Clear the domain block cache (for later reload)
-	d.state.Caches.GTS.DomainBlock.Clear()
+	// Clear the domain block caches (for later reload)
+	d.clearDomainBlockCaches()
 
 	return nil
 }
@@ -206,6 +229,28 @@ func (d *domainDB) GetDomainBlockByID(ctx context.Context, id string) (*gtsmodel
 	return &block, nil
 }
 
+func (d *domainDB) UpdateDomainBlock(ctx context.Context, block *gtsmodel.DomainBlock, columns ...string) error {
+	block.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	// Attempt to update domain block
+	if _, err := d.db.NewUpdate().
+		Model(block).
+		Column(columns...).
+		Where("? = ?", bun.Ident("domain_block.id"), block.ID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the domain block caches (for later reload)
+	d.clearDomainBlockCaches()
+
+	return nil
+}
+
 func (d *domainDB) DeleteDomainBlock(ctx context.Context, domain string) error {
 	// Normalize the domain as punycode
 	domain, err := util.Punify(domain)
@@ -221,8 +266,8 @@ func (d *domainDB) DeleteDomainBlock(ctx context.Context, domain string) error {
 		return err
 	}
 
-	// Clear the domain block cache (for later reload)
-	d.state.Caches.GTS.DomainBlock.Clear()
+	// Clear the domain block caches (for later reload)
+	d.clearDomainBlockCaches()
 
 	return nil
 }
@@ -240,20 +285,8 @@ func (d *domainDB) IsDomainBlocked(ctx context.Context, domain string) (bool, er
 		return false, nil
 	}
 
-	// Check the cache for an explicit domain allow (hydrating the cache with callback if necessary).
-	explicitAllow, err := d.state.Caches.GTS.DomainAllow.Matches(domain, func() ([]string, error) {
-		var domains []string
-
-		// Scan list of all explicitly allowed domains from DB
-		q := d.db.NewSelect().
-			Table("domain_allows").
-			Column("domain")
-		if err := q.Scan(ctx, &domains); err != nil {
-			return nil, err
-		}
-
-		return domains, nil
-	})
+	// Check the cache for an explicit domain allow.
+	explicitAllow, err := d.isDomainExplicitlyAllowed(ctx, domain)
 	if err != nil {
 		return false, err
 	}
@@ -262,10 +295,13 @@ func (d *domainDB) IsDomainBlocked(ctx context.Context, domain string) (bool, er
 	explicitBlock, err := d.state.Caches.GTS.DomainBlock.Matches(domain, func() ([]string, error) {
 		var domains []string
 
-		// Scan list of all blocked domains from DB
+		// Scan list of all suspended domains from DB. Blocks
+		// of lesser severity only limit the domain's accounts,
+		// see IsDomainSilenced, IsDomainMediaRejected etc.
 		q := d.db.NewSelect().
 			Table("domain_blocks").
-			Column("domain")
+			Column("domain").
+			Where("? = ?", bun.Ident("severity"), gtsmodel.DomainBlockSeveritySuspend)
 		if err := q.Scan(ctx, &domains); err != nil {
 			return nil, err
 		}
@@ -328,3 +364,110 @@ func (d *domainDB) AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, e
 	}
 	return false, nil
 }
+
+func (d *domainDB) IsDomainSilenced(ctx context.Context, domain string) (bool, error) {
+	return d.isDomainLimited(ctx, domain,
+		d.state.Caches.GTS.DomainSilence.Matches,
+		"severity", gtsmodel.DomainBlockSeveritySilence,
+	)
+}
+
+func (d *domainDB) IsDomainMediaRejected(ctx context.Context, domain string) (bool, error) {
+	return d.isDomainLimited(ctx, domain,
+		d.state.Caches.GTS.DomainRejectMedia.Matches,
+		"reject_media", true,
+	)
+}
+
+func (d *domainDB) IsDomainReportsRejected(ctx context.Context, domain string) (bool, error) {
+	return d.isDomainLimited(ctx, domain,
+		d.state.Caches.GTS.DomainRejectReports.Matches,
+		"reject_reports", true,
+	)
+}
+
+// isDomainLimited checks whether domain matches any domain block
+// with column set to value, using the given cache matching function
+// (hydrating the cache with callback if necessary). In blocklist
+// mode, an explicit allow for the domain takes precedence.
+func (d *domainDB) isDomainLimited(
+	ctx context.Context,
+	domain string,
+	matches func(string, func() ([]string, error)) (bool, error),
+	column string,
+	value any,
+) (bool, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return false, err
+	}
+
+	// Domain referencing *us* cannot be limited.
+	if domain == "" || domain == config.GetAccountDomain() ||
+		domain == config.GetHost() {
+		return false, nil
+	}
+
+	limited, err := matches(domain, func() ([]string, error) {
+		var domains []string
+
+		// Scan list of all matching blocked domains from DB
+		q := d.db.NewSelect().
+			Table("domain_blocks").
+			Column("domain").
+			Where("? = ?", bun.Ident(column), value)
+		if err := q.Scan(ctx, &domains); err != nil {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+	if err != nil || !limited {
+		return false, err
+	}
+
+	if config.GetInstanceFederationMode() == config.InstanceFederationModeAllowlist {
+		// Allowlist mode: limits apply
+		// on top of any explicit allow.
+		return true, nil
+	}
+
+	// Blocklist mode: explicit allow takes
+	// precedence over the explicit block.
+	explicitAllow, err := d.isDomainExplicitlyAllowed(ctx, domain)
+	if err != nil {
+		return false, err
+	}
+
+	return !explicitAllow, nil
+}
+
+// isDomainExplicitlyAllowed checks the cache for an explicit
+// domain allow (hydrating the cache with callback if necessary).
+func (d *domainDB) isDomainExplicitlyAllowed(ctx context.Context, domain string) (bool, error) {
+	return d.state.Caches.GTS.DomainAllow.Matches(domain, func() ([]string, error) {
+		var domains []string
+
+		// Scan list of all explicitly allowed domains from DB
+		q := d.db.NewSelect().
+			Table("domain_allows").
+			Column("domain")
+		if err := q.Scan(ctx, &domains); err != nil {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+}
+
+// clearDomainBlockCaches clears all of the caches hydrated from
+// the domain blocks table, as well as the visibility cache, since
+// status visibility depends on limits set by domain blocks.
+func (d *domainDB) clearDomainBlockCaches() {
+	d.state.Caches.GTS.DomainBlock.Clear()
+	d.state.Caches.GTS.DomainSilence.Clear()
+	d.state.Caches.GTS.DomainRejectMedia.Clear()
+	d.state.Caches.GTS.DomainRejectReports.Clear()
+	d.state.Caches.Visibility.Clear()
+}
@@ -24,7 +24,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type DomainTestSuite struct {
@@ -109,6 +111,69 @@ func (suite *DomainTestSuite) TestIsDomainBlockedWithAllow() {
 	suite.False(blocked)
 }
 
+func (suite *DomainTestSuite) TestDomainBlockSeverity() {
+	ctx := context.Background()
+
+	domainBlock := &gtsmodel.DomainBlock{
+		ID:                 "01G204214Y9TNJEBX39C7G88SW",
+		Domain:             "noisy.apples",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		Severity:           gtsmodel.DomainBlockSeveritySilence,
+		RejectMedia:        util.Ptr(true),
+		RejectReports:      util.Ptr(false),
+	}
+
+	if err := suite.db.CreateDomainBlock(ctx, domainBlock); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Silenced domain (and subdomains)
+	// should be limited but not blocked.
+	for _, domain := range []string{
+		"noisy.apples",
+		"media.noisy.apples",
+	} {
+		blocked, err := suite.db.IsDomainBlocked(ctx, domain)
+		suite.NoError(err)
+		suite.False(blocked)
+
+		silenced, err := suite.db.IsDomainSilenced(ctx, domain)
+		suite.NoError(err)
+		suite.True(silenced)
+
+		mediaRejected, err := suite.db.IsDomainMediaRejected(ctx, domain)
+		suite.NoError(err)
+		suite.True(mediaRejected)
+
+		reportsRejected, err := suite.db.IsDomainReportsRejected(ctx, domain)
+		suite.NoError(err)
+		suite.False(reportsRejected)
+	}
+
+	// Testrig domain blocks are suspensions.
+	silenced, err := suite.db.IsDomainSilenced(ctx, "replyguys.com")
+	suite.NoError(err)
+	suite.False(silenced)
+
+	// Explicitly allow the domain; in blocklist
+	// mode this should lift the limits.
+	if err := suite.db.CreateDomainAllow(ctx, &gtsmodel.DomainAllow{
+		ID:                 "01H8KY9MJQFWE712EG3VN02Y3J",
+		Domain:             "noisy.apples",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	silenced, err = suite.db.IsDomainSilenced(ctx, "noisy.apples")
+	suite.NoError(err)
+	suite.False(silenced)
+
+	mediaRejected, err := suite.db.IsDomainMediaRejected(ctx, "noisy.apples")
+	suite.NoError(err)
+	suite.False(mediaRejected)
+}
+
 func (suite *DomainTestSuite) TestIsDomainBlockedWildcard() {
 	ctx := context.Background()
 
@@ -274,6 +339,66 @@ func (suite *DomainTestSuite) TestIsOtherDomainBlockedWildcardAndExplicit() {
 	}
 }
 
+func (suite *DomainTestSuite) TestDomainPermissionSubscriptions() {
+	ctx := context.Background()
+
+	permSub := &gtsmodel.DomainPermissionSubscription{
+		ID:                 "01JGE69SY9ZYNEE6MVB0DRTGRE",
+		Priority:           100,
+		Title:              "some allows",
+		PermissionType:     gtsmodel.DomainPermissionAllow,
+		AdoptOrphans:       util.Ptr(true),
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		URI:                "https://lists.example.org/goodies.txt",
+		ContentType:        gtsmodel.DomainPermSubContentTypePlain,
+	}
+
+	if err := suite.db.PutDomainPermissionSubscription(ctx, permSub); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Should be able to get it by ID.
+	dbPermSub, err := suite.db.GetDomainPermissionSubscriptionByID(ctx, permSub.ID)
+	suite.NoError(err)
+	suite.Equal(permSub.URI, dbPermSub.URI)
+	suite.Equal(gtsmodel.DomainPermissionAllow, dbPermSub.PermissionType)
+	suite.True(*dbPermSub.AdoptOrphans)
+
+	// Only the allow sub should be returned for allows.
+	permSubs, err := suite.db.GetDomainPermissionSubscriptions(ctx, gtsmodel.DomainPermissionAllow)
+	suite.NoError(err)
+	suite.Len(permSubs, 1)
+
+	// Both subs should be returned for all types,
+	// with higher priority (the block sub) first.
+	permSubs, err = suite.db.GetDomainPermissionSubscriptions(ctx, gtsmodel.DomainPermissionUnknown)
+	suite.NoError(err)
+	if suite.Len(permSubs, 2) {
+		suite.Equal(gtsmodel.DomainPermissionBlock, permSubs[0].PermissionType)
+		suite.Equal(permSub.ID, permSubs[1].ID)
+	}
+
+	// Update some columns.
+	permSub.Error = "oh no"
+	permSub.FetchedAt = time.Now()
+	if err := suite.db.UpdateDomainPermissionSubscription(ctx, permSub, "error", "fetched_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbPermSub, err = suite.db.GetDomainPermissionSubscriptionByID(ctx, permSub.ID)
+	suite.NoError(err)
+	suite.Equal("oh no", dbPermSub.Error)
+	suite.WithinDuration(permSub.FetchedAt, dbPermSub.FetchedAt, time.Second)
+
+	// Delete it.
+	if err := suite.db.DeleteDomainPermissionSubscription(ctx, permSub.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetDomainPermissionSubscriptionByID(ctx, permSub.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestDomainTestSuite(t *testing.T) {
 	suite.Run(t, new(DomainTestSuite))
 }
@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func (d *domainDB) GetDomainPermissionSubscriptionByID(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionSubscription, error) {
+	var permSub gtsmodel.DomainPermissionSubscription
+
+	if err := d.db.
+		NewSelect().
+		Model(&permSub).
+		Where("? = ?", bun.Ident("domain_permission_subscription.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &permSub, nil
+}
+
+func (d *domainDB) GetDomainPermissionSubscriptions(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+) ([]*gtsmodel.DomainPermissionSubscription, error) {
+	permSubs := []*gtsmodel.DomainPermissionSubscription{}
+
+	q := d.db.
+		NewSelect().
+		Model(&permSubs)
+
+	if permType != gtsmodel.DomainPermissionUnknown {
+		// Only select subscriptions of given type.
+		q = q.Where("? = ?", bun.Ident("domain_permission_subscription.permission_type"), permType)
+	}
+
+	// Highest priority first, then oldest first.
+	if err := q.
+		Order("domain_permission_subscription.priority DESC").
+		Order("domain_permission_subscription.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return permSubs, nil
+}
+
+func (d *domainDB) PutDomainPermissionSubscription(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+) error {
+	_, err := d.db.
+		NewInsert().
+		Model(permSub).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) UpdateDomainPermissionSubscription(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	columns ...string,
+) error {
+	permSub.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := d.db.
+		NewUpdate().
+		Model(permSub).
+		Column(columns...).
+		Where("? = ?", bun.Ident("domain_permission_subscription.id"), permSub.ID).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) DeleteDomainPermissionSubscription(
+	ctx context.Context,
+	id string,
+) error {
+	_, err := d.db.
+		NewDelete().
+		Model((*gtsmodel.DomainPermissionSubscription)(nil)).
+		Where("? = ?", bun.Ident("domain_permission_subscription.id"), id).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type emailDomainBlockDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (e *emailDomainBlockDB) GetEmailDomainBlockByID(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, error) {
+	return e.getEmailDomainBlock(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("? = ?", bun.Ident("email_domain_block.id"), id)
+	})
+}
+
+func (e *emailDomainBlockDB) GetEmailDomainBlock(ctx context.Context, domain string) (*gtsmodel.EmailDomainBlock, error) {
+	return e.getEmailDomainBlock(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("? = ?", bun.Ident("email_domain_block.domain"), domain)
+	})
+}
+
+func (e *emailDomainBlockDB) getEmailDomainBlock(
+	ctx context.Context,
+	where func(*bun.SelectQuery) *bun.SelectQuery,
+) (*gtsmodel.EmailDomainBlock, error) {
+	var block gtsmodel.EmailDomainBlock
+
+	q := e.db.
+		NewSelect().
+		Model(&block)
+
+	if err := where(q).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return &block, nil
+	}
+
+	if err := e.populateEmailDomainBlock(ctx, &block); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+func (e *emailDomainBlockDB) GetEmailDomainBlocks(ctx context.Context) ([]*gtsmodel.EmailDomainBlock, error) {
+	var blocks []*gtsmodel.EmailDomainBlock
+
+	if err := e.db.
+		NewSelect().
+		Model(&blocks).
+		OrderExpr("? DESC", bun.Ident("email_domain_block.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only barebones models were requested.
+		return blocks, nil
+	}
+
+	// Populate all loaded blocks, removing those we
+	// fail to populate (removes need for db calls later).
+	blocks = slices.DeleteFunc(blocks, func(block *gtsmodel.EmailDomainBlock) bool {
+		if err := e.populateEmailDomainBlock(ctx, block); err != nil {
+			log.Errorf(ctx, "error populating email domain block %s: %v", block.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return blocks, nil
+}
+
+func (e *emailDomainBlockDB) populateEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) error {
+	var err error
+
+	if block.CreatedByAccount == nil {
+		// Block creator is not set, fetch from database.
+		block.CreatedByAccount, err = e.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			block.CreatedByAccountID,
+		)
+		if err != nil {
+			return gtserror.Newf("error populating email domain block creator account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (e *emailDomainBlockDB) PutEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) error {
+	_, err := e.db.
+		NewInsert().
+		Model(block).
+		Exec(ctx)
+	return err
+}
+
+func (e *emailDomainBlockDB) DeleteEmailDomainBlockByID(ctx context.Context, id string) error {
+	_, err := e.db.
+		NewDelete().
+		Table("email_domain_blocks").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
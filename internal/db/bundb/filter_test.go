@@ -0,0 +1,189 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type FilterTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+// TestFilterCRUD tests CRUD and read-all operations on filters.
+func (suite *FilterTestSuite) TestFilterCRUD() {
+	t := suite.T()
+
+	// Create new example filter with attached keyword.
+	filter := &gtsmodel.Filter{
+		ID:                   "01HNEJNVZZVXJTRB3FX3K2B1YF",
+		AccountID:            "01HNEJXCPRTJVJY9MV0VVHGD47",
+		Title:                "foss jail",
+		Action:               gtsmodel.FilterActionWarn,
+		ContextHome:          util.Ptr(true),
+		ContextNotifications: util.Ptr(false),
+		ContextPublic:        util.Ptr(true),
+		ContextThread:        util.Ptr(false),
+		ContextAccount:       util.Ptr(false),
+	}
+	filterKeyword := &gtsmodel.FilterKeyword{
+		ID:        "01HNEK4RW5QEAMG9Y4ET6ST0J4",
+		AccountID: filter.AccountID,
+		FilterID:  filter.ID,
+		Keyword:   "GNU/Linux",
+		WholeWord: util.Ptr(false),
+	}
+	filter.Keywords = []*gtsmodel.FilterKeyword{filterKeyword}
+
+	// Create new cancellable test context.
+	ctx := context.Background()
+	ctx, cncl := context.WithCancel(ctx)
+	defer cncl()
+
+	// Insert the example filter into db.
+	if err := suite.db.PutFilter(ctx, filter); err != nil {
+		t.Fatalf("error inserting filter: %v", err)
+	}
+
+	// Now fetch newly created filter.
+	check, err := suite.db.GetFilterByID(ctx, filter.ID)
+	if err != nil {
+		t.Fatalf("error fetching filter: %v", err)
+	}
+
+	// Check all expected fields match.
+	suite.Equal(filter.ID, check.ID)
+	suite.Equal(filter.AccountID, check.AccountID)
+	suite.Equal(filter.Title, check.Title)
+	suite.Equal(filter.Action, check.Action)
+	suite.Equal(filter.ContextHome, check.ContextHome)
+	suite.Equal(filter.ContextNotifications, check.ContextNotifications)
+	suite.Equal(filter.ContextPublic, check.ContextPublic)
+	suite.Equal(filter.ContextThread, check.ContextThread)
+	suite.Equal(filter.ContextAccount, check.ContextAccount)
+	if suite.Len(check.Keywords, 1) {
+		suite.Equal(filterKeyword.Keyword, check.Keywords[0].Keyword)
+		suite.NotNil(check.Keywords[0].Regexp)
+	}
+	suite.Empty(check.Statuses)
+
+	// Fetch all filters.
+	all, err := suite.db.GetFiltersForAccountID(ctx, filter.AccountID)
+	if err != nil {
+		t.Fatalf("error fetching filters: %v", err)
+	}
+
+	// Ensure the result contains our example filter.
+	suite.Len(all, 1)
+	suite.Equal(filter.ID, all[0].ID)
+
+	// Modify the example filter: update the
+	// existing keyword, add a new keyword,
+	// and add a new status.
+	check.Title = "foss purgatory"
+	check.Action = gtsmodel.FilterActionHide
+	check.ExpiresAt = time.Now().Add(time.Hour)
+	check.Keywords[0].Keyword = "GNU+Linux"
+	check.Keywords[0].WholeWord = util.Ptr(true)
+	check.Keywords = append(check.Keywords, &gtsmodel.FilterKeyword{
+		ID:        "01HNEMY810E5XKWDDMN5ZRE749",
+		AccountID: filter.AccountID,
+		FilterID:  filter.ID,
+		Keyword:   "tux",
+		WholeWord: util.Ptr(true),
+	})
+	check.Statuses = []*gtsmodel.FilterStatus{
+		{
+			ID:        "01HNEMYD5XE7C8HH8TNCZ76FN2",
+			AccountID: filter.AccountID,
+			FilterID:  filter.ID,
+			StatusID:  "01HNEKZW34SQZ8PSDQ0Z10NZES",
+		},
+	}
+
+	// Update the filter in the database.
+	if err := suite.db.UpdateFilter(ctx, check, []string{"title", "action", "expires_at"}, nil, nil); err != nil {
+		t.Fatalf("error updating filter: %v", err)
+	}
+
+	// Now fetch newly updated filter.
+	check, err = suite.db.GetFilterByID(ctx, filter.ID)
+	if err != nil {
+		t.Fatalf("error fetching updated filter: %v", err)
+	}
+
+	// Ensure expected fields were modified on check filter.
+	suite.Equal("foss purgatory", check.Title)
+	suite.Equal(gtsmodel.FilterActionHide, check.Action)
+	suite.False(check.ExpiresAt.IsZero())
+	if suite.Len(check.Keywords, 2) {
+		for _, checkKeyword := range check.Keywords {
+			if checkKeyword.ID == filterKeyword.ID {
+				suite.Equal("GNU+Linux", checkKeyword.Keyword)
+				suite.True(*checkKeyword.WholeWord)
+			}
+		}
+	}
+	suite.Len(check.Statuses, 1)
+
+	// Delete one keyword and the status.
+	if err := suite.db.UpdateFilter(
+		ctx,
+		check,
+		nil,
+		[]string{"01HNEMY810E5XKWDDMN5ZRE749"},
+		[]string{"01HNEMYD5XE7C8HH8TNCZ76FN2"},
+	); err != nil {
+		t.Fatalf("error updating filter: %v", err)
+	}
+
+	// Fetch the filter again.
+	check, err = suite.db.GetFilterByID(ctx, filter.ID)
+	if err != nil {
+		t.Fatalf("error fetching updated filter: %v", err)
+	}
+	suite.Len(check.Keywords, 1)
+	suite.Empty(check.Statuses)
+
+	// Delete the filter from the DB.
+	if err := suite.db.DeleteFilterByID(ctx, filter.ID); err != nil {
+		t.Fatalf("error deleting filter: %v", err)
+	}
+
+	// Ensure the filter and its keywords are gone.
+	_, err = suite.db.GetFilterByID(ctx, filter.ID)
+	if !errors.Is(err, db.ErrNoEntries) {
+		t.Fatalf("expected no entries error fetching deleted filter, got: %v", err)
+	}
+	_, err = suite.db.GetFilterKeywordByID(ctx, filterKeyword.ID)
+	if !errors.Is(err, db.ErrNoEntries) {
+		t.Fatalf("expected no entries error fetching deleted filter keyword, got: %v", err)
+	}
+}
+
+func TestFilterTestSuite(t *testing.T) {
+	suite.Run(t, new(FilterTestSuite))
+}
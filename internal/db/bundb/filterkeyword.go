@@ -0,0 +1,227 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func (f *filterDB) GetFilterKeywordByID(ctx context.Context, id string) (*gtsmodel.FilterKeyword, error) {
+	filterKeyword, err := f.state.Caches.GTS.FilterKeyword.LoadOne("ID", func() (*gtsmodel.FilterKeyword, error) {
+		var filterKeyword gtsmodel.FilterKeyword
+
+		// Not cached! Perform database query.
+		if err := f.db.
+			NewSelect().
+			Model(&filterKeyword).
+			Where("? = ?", bun.Ident("id"), id).
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		// Pre-compile filter keyword regular expression.
+		if err := filterKeyword.Compile(); err != nil {
+			return nil, gtserror.Newf("error compiling filter keyword regex: %w", err)
+		}
+
+		return &filterKeyword, nil
+	}, id)
+	if err != nil {
+		// already processed
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return filterKeyword, nil
+	}
+
+	if filterKeyword.Filter == nil {
+		// Filter is not set, fetch from the database.
+		filterKeyword.Filter, err = f.GetFilterByID(
+			gtscontext.SetBarebones(ctx),
+			filterKeyword.FilterID,
+		)
+		if err != nil {
+			return nil, gtserror.Newf("error populating filter keyword filter: %w", err)
+		}
+	}
+
+	return filterKeyword, nil
+}
+
+func (f *filterDB) GetFilterKeywordsForFilterID(ctx context.Context, filterID string) ([]*gtsmodel.FilterKeyword, error) {
+	return f.getFilterKeywords(ctx, "filter_id", filterID)
+}
+
+func (f *filterDB) GetFilterKeywordsForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.FilterKeyword, error) {
+	return f.getFilterKeywords(ctx, "account_id", accountID)
+}
+
+func (f *filterDB) getFilterKeywords(ctx context.Context, idColumn string, id string) ([]*gtsmodel.FilterKeyword, error) {
+	var filterKeywordIDs []string
+	if err := f.db.
+		NewSelect().
+		Model((*gtsmodel.FilterKeyword)(nil)).
+		Column("id").
+		Where("? = ?", bun.Ident(idColumn), id).
+		Scan(ctx, &filterKeywordIDs); err != nil {
+		return nil, err
+	}
+
+	if len(filterKeywordIDs) == 0 {
+		return nil, nil
+	}
+
+	// Preallocate at-worst possible length.
+	uncached := make([]string, 0, len(filterKeywordIDs))
+
+	// Load all filter keyword IDs via cache loader callbacks.
+	filterKeywords, err := f.state.Caches.GTS.FilterKeyword.Load("ID",
+
+		// Load cached + check for uncached.
+		func(load func(keyParts ...any) bool) {
+			for _, id := range filterKeywordIDs {
+				if !load(id) {
+					uncached = append(uncached, id)
+				}
+			}
+		},
+
+		// Uncached filter keyword loader function.
+		func() ([]*gtsmodel.FilterKeyword, error) {
+			// Preallocate expected length of uncached filter keywords.
+			filterKeywords := make([]*gtsmodel.FilterKeyword, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) IDs.
+			if err := f.db.NewSelect().
+				Model(&filterKeywords).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			// Compile all the keyword regular expressions.
+			filterKeywords = slices.DeleteFunc(filterKeywords, func(filterKeyword *gtsmodel.FilterKeyword) bool {
+				if err := filterKeyword.Compile(); err != nil {
+					log.Errorf(ctx, "error compiling filter keyword regex: %v", err)
+					return true
+				}
+				return false
+			})
+
+			return filterKeywords, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the filter keywords by their
+	// IDs to ensure in correct order.
+	getID := func(filterKeyword *gtsmodel.FilterKeyword) string { return filterKeyword.ID }
+	util.OrderBy(filterKeywords, filterKeywordIDs, getID)
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return filterKeywords, nil
+	}
+
+	// Populate the filters. Remove any that we can't populate from the return slice.
+	filterKeywords = slices.DeleteFunc(filterKeywords, func(filterKeyword *gtsmodel.FilterKeyword) bool {
+		var err error
+		filterKeyword.Filter, err = f.GetFilterByID(
+			gtscontext.SetBarebones(ctx),
+			filterKeyword.FilterID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error populating filter keyword filter %s: %v", filterKeyword.FilterID, err)
+			return true
+		}
+		return false
+	})
+
+	return filterKeywords, nil
+}
+
+func (f *filterDB) PutFilterKeyword(ctx context.Context, filterKeyword *gtsmodel.FilterKeyword) error {
+	if filterKeyword.Regexp == nil {
+		// Ensure regexp is compiled
+		// before attempted caching.
+		err := filterKeyword.Compile()
+		if err != nil {
+			return gtserror.Newf("error compiling filter keyword regex: %w", err)
+		}
+	}
+
+	return f.state.Caches.GTS.FilterKeyword.Store(filterKeyword, func() error {
+		_, err := f.db.
+			NewInsert().
+			Model(filterKeyword).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (f *filterDB) UpdateFilterKeyword(ctx context.Context, filterKeyword *gtsmodel.FilterKeyword, columns ...string) error {
+	filterKeyword.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	// Ensure the regexp is recompiled
+	// in case keyword has been changed.
+	if err := filterKeyword.Compile(); err != nil {
+		return gtserror.Newf("error compiling filter keyword regex: %w", err)
+	}
+
+	return f.state.Caches.GTS.FilterKeyword.Store(filterKeyword, func() error {
+		_, err := f.db.
+			NewUpdate().
+			Model(filterKeyword).
+			Where("? = ?", bun.Ident("id"), filterKeyword.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (f *filterDB) DeleteFilterKeywordByID(ctx context.Context, id string) error {
+	if _, err := f.db.
+		NewDelete().
+		Model((*gtsmodel.FilterKeyword)(nil)).
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	f.state.Caches.GTS.FilterKeyword.Invalidate("ID", id)
+
+	return nil
+}
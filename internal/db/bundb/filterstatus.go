@@ -0,0 +1,179 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func (f *filterDB) GetFilterStatusByID(ctx context.Context, id string) (*gtsmodel.FilterStatus, error) {
+	filterStatus, err := f.state.Caches.GTS.FilterStatus.LoadOne("ID", func() (*gtsmodel.FilterStatus, error) {
+		var filterStatus gtsmodel.FilterStatus
+
+		// Not cached! Perform database query.
+		if err := f.db.
+			NewSelect().
+			Model(&filterStatus).
+			Where("? = ?", bun.Ident("id"), id).
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return &filterStatus, nil
+	}, id)
+	if err != nil {
+		// already processed
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return filterStatus, nil
+	}
+
+	if filterStatus.Filter == nil {
+		// Filter is not set, fetch from the database.
+		filterStatus.Filter, err = f.GetFilterByID(
+			gtscontext.SetBarebones(ctx),
+			filterStatus.FilterID,
+		)
+		if err != nil {
+			return nil, gtserror.Newf("error populating filter status filter: %w", err)
+		}
+	}
+
+	return filterStatus, nil
+}
+
+func (f *filterDB) GetFilterStatusesForFilterID(ctx context.Context, filterID string) ([]*gtsmodel.FilterStatus, error) {
+	return f.getFilterStatuses(ctx, "filter_id", filterID)
+}
+
+func (f *filterDB) GetFilterStatusesForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.FilterStatus, error) {
+	return f.getFilterStatuses(ctx, "account_id", accountID)
+}
+
+func (f *filterDB) getFilterStatuses(ctx context.Context, idColumn string, id string) ([]*gtsmodel.FilterStatus, error) {
+	var filterStatusIDs []string
+	if err := f.db.
+		NewSelect().
+		Model((*gtsmodel.FilterStatus)(nil)).
+		Column("id").
+		Where("? = ?", bun.Ident(idColumn), id).
+		Scan(ctx, &filterStatusIDs); err != nil {
+		return nil, err
+	}
+
+	if len(filterStatusIDs) == 0 {
+		return nil, nil
+	}
+
+	// Preallocate at-worst possible length.
+	uncached := make([]string, 0, len(filterStatusIDs))
+
+	// Load all filter status IDs via cache loader callbacks.
+	filterStatuses, err := f.state.Caches.GTS.FilterStatus.Load("ID",
+
+		// Load cached + check for uncached.
+		func(load func(keyParts ...any) bool) {
+			for _, id := range filterStatusIDs {
+				if !load(id) {
+					uncached = append(uncached, id)
+				}
+			}
+		},
+
+		// Uncached filter status loader function.
+		func() ([]*gtsmodel.FilterStatus, error) {
+			// Preallocate expected length of uncached filter statuses.
+			filterStatuses := make([]*gtsmodel.FilterStatus, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) IDs.
+			if err := f.db.NewSelect().
+				Model(&filterStatuses).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return filterStatuses, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the filter statuses by their
+	// IDs to ensure in correct order.
+	getID := func(filterStatus *gtsmodel.FilterStatus) string { return filterStatus.ID }
+	util.OrderBy(filterStatuses, filterStatusIDs, getID)
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return filterStatuses, nil
+	}
+
+	// Populate the filters. Remove any that we can't populate from the return slice.
+	filterStatuses = slices.DeleteFunc(filterStatuses, func(filterStatus *gtsmodel.FilterStatus) bool {
+		var err error
+		filterStatus.Filter, err = f.GetFilterByID(
+			gtscontext.SetBarebones(ctx),
+			filterStatus.FilterID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error populating filter status filter %s: %v", filterStatus.FilterID, err)
+			return true
+		}
+		return false
+	})
+
+	return filterStatuses, nil
+}
+
+func (f *filterDB) PutFilterStatus(ctx context.Context, filterStatus *gtsmodel.FilterStatus) error {
+	return f.state.Caches.GTS.FilterStatus.Store(filterStatus, func() error {
+		_, err := f.db.
+			NewInsert().
+			Model(filterStatus).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (f *filterDB) DeleteFilterStatusByID(ctx context.Context, id string) error {
+	if _, err := f.db.
+		NewDelete().
+		Model((*gtsmodel.FilterStatus)(nil)).
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	f.state.Caches.GTS.FilterStatus.Invalidate("ID", id)
+
+	return nil
+}
@@ -380,3 +380,40 @@ func (i *instanceDB) GetInstanceModeratorAddresses(ctx context.Context) ([]strin
 
 	return addresses, nil
 }
+
+func (i *instanceDB) GetInstanceModerators(ctx context.Context) ([]*gtsmodel.User, error) {
+	var userIDs []string
+
+	// Select IDs of approved and
+	// enabled moderators or admins.
+	if err := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Column("user.id").
+		Where("? = ?", bun.Ident("user.approved"), true).
+		Where("? = ?", bun.Ident("user.disabled"), false).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("user.moderator"), true).
+				WhereOr("? = ?", bun.Ident("user.admin"), true)
+		}).
+		OrderExpr("? ASC", bun.Ident("user.id")).
+		Scan(ctx, &userIDs); err != nil {
+		return nil, err
+	}
+
+	if len(userIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	users := make([]*gtsmodel.User, 0, len(userIDs))
+	for _, userID := range userIDs {
+		user, err := i.state.DB.GetUserByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,224 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type inviteDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *inviteDB) GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, "id", id)
+}
+
+func (i *inviteDB) GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, "code", code)
+}
+
+func (i *inviteDB) getInvite(ctx context.Context, column string, value any) (*gtsmodel.Invite, error) {
+	var invite gtsmodel.Invite
+
+	if err := i.db.
+		NewSelect().
+		Model(&invite).
+		Where("? = ?", bun.Ident("invite."+column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return &invite, nil
+	}
+
+	if err := i.PopulateInvite(ctx, &invite); err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (i *inviteDB) GetInvites(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Invite, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		invites = make([]*gtsmodel.Invite, 0, limit)
+	)
+
+	q := i.db.
+		NewSelect().
+		Model(&invites)
+
+	if accountID != "" {
+		// Return only invites created by this account.
+		q = q.Where("? = ?", bun.Ident("invite.account_id"), accountID)
+	}
+
+	if maxID != "" {
+		// Return only invites LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("invite.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only invites HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("invite.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of invites returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("invite.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("invite.id"))
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want invites
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(invites)
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only barebones models were requested.
+		return invites, nil
+	}
+
+	for _, invite := range invites {
+		if err := i.PopulateInvite(ctx, invite); err != nil {
+			return nil, err
+		}
+	}
+
+	return invites, nil
+}
+
+func (i *inviteDB) PopulateInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	var err error
+
+	if invite.Account == nil {
+		// Invite creator is not set, fetch from the database.
+		invite.Account, err = i.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			invite.AccountID,
+		)
+		if err != nil {
+			return gtserror.Newf("error populating invite account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (i *inviteDB) PutInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	_, err := i.db.
+		NewInsert().
+		Model(invite).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error {
+	invite.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.
+		NewUpdate().
+		Model(invite).
+		Column(columns...).
+		Where("? = ?", bun.Ident("invite.id"), invite.ID).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) IncrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error {
+	now := time.Now()
+
+	// Increment uses in the database, making sure we
+	// don't go over max uses if someone else has used
+	// the invite in the meantime.
+	res, err := i.db.
+		NewUpdate().
+		Table("invites").
+		Set("? = ? + 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), now).
+		Where("? = ?", bun.Ident("id"), invite.ID).
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				Where("? = 0", bun.Ident("max_uses")).
+				WhereOr("? < ?", bun.Ident("uses"), bun.Ident("max_uses"))
+		}).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		// Invite used up.
+		return db.ErrNoEntries
+	}
+
+	invite.Uses++
+	invite.UpdatedAt = now
+	return nil
+}
+
+func (i *inviteDB) DeleteInviteByID(ctx context.Context, id string) error {
+	_, err := i.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("invites"), bun.Ident("invite")).
+		Where("? = ?", bun.Ident("invite.id"), id).
+		Exec(ctx)
+	return err
+}
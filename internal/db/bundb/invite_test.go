@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type InviteTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *InviteTestSuite) TestGetInviteByCode() {
+	testInvite := suite.testInvites["admin_account_invite_1"]
+
+	invite, err := suite.db.GetInviteByCode(context.Background(), testInvite.Code)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(testInvite.ID, invite.ID)
+	suite.NotNil(invite.Account)
+	suite.Equal(testInvite.AccountID, invite.Account.ID)
+}
+
+func (suite *InviteTestSuite) TestGetInvites() {
+	ctx := context.Background()
+
+	// All invites.
+	invites, err := suite.db.GetInvites(ctx, "", &paging.Page{Limit: 10})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(invites, len(suite.testInvites))
+
+	// Newest first.
+	for i := 1; i < len(invites); i++ {
+		suite.Greater(invites[i-1].ID, invites[i].ID)
+	}
+
+	// Only invites of one account.
+	adminAccount := suite.testAccounts["admin_account"]
+	invites, err = suite.db.GetInvites(ctx, adminAccount.ID, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(invites, 2)
+	for _, invite := range invites {
+		suite.Equal(adminAccount.ID, invite.AccountID)
+	}
+}
+
+func (suite *InviteTestSuite) TestIncrementInviteUses() {
+	var (
+		ctx    = context.Background()
+		invite = suite.testInvites["local_account_1_invite_1"]
+	)
+
+	// Invite has max uses of 1,
+	// so first use should be fine.
+	if err := suite.db.IncrementInviteUses(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, invite.Uses)
+
+	// Second use should fail.
+	err := suite.db.IncrementInviteUses(ctx, invite)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, dbInvite.Uses)
+	suite.True(dbInvite.UsedUp())
+}
+
+func TestInviteTestSuite(t *testing.T) {
+	suite.Run(t, new(InviteTestSuite))
+}
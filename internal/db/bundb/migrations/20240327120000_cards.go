@@ -0,0 +1,90 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Create the preview cards table.
+			if _, err := tx.
+				NewCreateTable().
+				Model(&gtsmodel.Card{}).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Index cards by image, so that
+			// card images can be looked up
+			// when pruning unused media.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("cards").
+				Index("cards_image_id_idx").
+				Column("image_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Add card_id column to statuses.
+			if _, err := tx.
+				NewAddColumn().
+				Table("statuses").
+				ColumnExpr("? CHAR(26)", bun.Ident("card_id")).
+				Exec(ctx); err != nil &&
+				!(strings.Contains(err.Error(), "already exists") ||
+					strings.Contains(err.Error(), "duplicate column name") ||
+					strings.Contains(err.Error(), "SQLSTATE 42701")) {
+				return err
+			}
+
+			// Index statuses by card, so that
+			// unused cards can be found quickly.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("statuses").
+				Index("statuses_card_id_idx").
+				Column("card_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,86 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Add column for when each
+			// account last created a status.
+			if _, err := tx.
+				NewAddColumn().
+				Table("accounts").
+				ColumnExpr("? TIMESTAMPTZ", bun.Ident("last_status_at")).
+				Exec(ctx); err != nil &&
+				!(strings.Contains(err.Error(), "already exists") ||
+					strings.Contains(err.Error(), "duplicate column name") ||
+					strings.Contains(err.Error(), "SQLSTATE 42701")) {
+				return err
+			}
+
+			// Backfill the new column from
+			// each account's existing statuses.
+			lastStatusQ := tx.
+				NewSelect().
+				TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+				ColumnExpr("MAX(?)", bun.Ident("status.created_at")).
+				Where("? = ?", bun.Ident("status.account_id"), bun.Ident("accounts.id"))
+
+			if _, err := tx.
+				NewUpdate().
+				Table("accounts").
+				Set("? = (?)", bun.Ident("last_status_at"), lastStatusQ).
+				Where("? IS NULL", bun.Ident("accounts.last_status_at")).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Index the new column, as it's
+			// used to order the profile directory.
+			if _, err := tx.
+				NewCreateIndex().
+				Model(&gtsmodel.Account{}).
+				Index("accounts_last_status_at_idx").
+				Column("last_status_at").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
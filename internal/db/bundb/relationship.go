@@ -20,6 +20,7 @@ package bundb
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
@@ -86,6 +87,20 @@ func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount
 		return nil, gtserror.Newf("error checking blockedBy: %w", err)
 	}
 
+	// check if the requesting account is muting the target account
+	mute, err := r.GetMute(
+		gtscontext.SetBarebones(ctx),
+		requestingAccount,
+		targetAccount,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error checking muting: %w", err)
+	}
+	if mute != nil && !mute.Expired(time.Now()) {
+		rel.Muting = true
+		rel.MutingNotifications = *mute.Notifications
+	}
+
 	// retrieve a note by the requesting account on the target account, if there is one
 	note, err := r.GetNote(
 		gtscontext.SetBarebones(ctx),
@@ -0,0 +1,339 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func (r *relationshipDB) GetMuteByID(ctx context.Context, id string) (*gtsmodel.UserMute, error) {
+	return r.getMute(
+		ctx,
+		"ID",
+		func(mute *gtsmodel.UserMute) error {
+			return r.db.NewSelect().Model(mute).
+				Where("? = ?", bun.Ident("user_mute.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (r *relationshipDB) GetMute(ctx context.Context, sourceAccountID string, targetAccountID string) (*gtsmodel.UserMute, error) {
+	return r.getMute(
+		ctx,
+		"AccountID,TargetAccountID",
+		func(mute *gtsmodel.UserMute) error {
+			return r.db.NewSelect().Model(mute).
+				Where("? = ?", bun.Ident("user_mute.account_id"), sourceAccountID).
+				Where("? = ?", bun.Ident("user_mute.target_account_id"), targetAccountID).
+				Scan(ctx)
+		},
+		sourceAccountID,
+		targetAccountID,
+	)
+}
+
+func (r *relationshipDB) GetMutesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.UserMute, error) {
+	// Preallocate at-worst possible length.
+	uncached := make([]string, 0, len(ids))
+
+	// Load all mutes IDs via cache loader callbacks.
+	mutes, err := r.state.Caches.GTS.UserMute.Load("ID",
+
+		// Load cached + check for uncached.
+		func(load func(keyParts ...any) bool) {
+			for _, id := range ids {
+				if !load(id) {
+					uncached = append(uncached, id)
+				}
+			}
+		},
+
+		// Uncached mute loader function.
+		func() ([]*gtsmodel.UserMute, error) {
+			// Preallocate expected length of uncached mutes.
+			mutes := make([]*gtsmodel.UserMute, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) IDs.
+			if err := r.db.NewSelect().
+				Model(&mutes).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return mutes, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the mutes by their
+	// IDs to ensure in correct order.
+	getID := func(m *gtsmodel.UserMute) string { return m.ID }
+	util.OrderBy(mutes, ids, getID)
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return mutes, nil
+	}
+
+	// Populate all loaded mutes, removing those we fail to
+	// populate (removes needing so many nil checks everywhere).
+	mutes = slices.DeleteFunc(mutes, func(mute *gtsmodel.UserMute) bool {
+		if err := r.PopulateMute(ctx, mute); err != nil {
+			log.Errorf(ctx, "error populating mute %s: %v", mute.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return mutes, nil
+}
+
+func (r *relationshipDB) getMute(ctx context.Context, lookup string, dbQuery func(*gtsmodel.UserMute) error, keyParts ...any) (*gtsmodel.UserMute, error) {
+	// Fetch mute from cache with loader callback
+	mute, err := r.state.Caches.GTS.UserMute.LoadOne(lookup, func() (*gtsmodel.UserMute, error) {
+		var mute gtsmodel.UserMute
+
+		// Not cached! Perform database query
+		if err := dbQuery(&mute); err != nil {
+			return nil, err
+		}
+
+		return &mute, nil
+	}, keyParts...)
+	if err != nil {
+		// already processed
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return mute, nil
+	}
+
+	if err := r.state.DB.PopulateMute(ctx, mute); err != nil {
+		return nil, err
+	}
+
+	return mute, nil
+}
+
+func (r *relationshipDB) GetAccountMutes(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.UserMute, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		muteIDs = make([]string, 0, limit)
+	)
+
+	q := r.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("user_mutes"), bun.Ident("user_mute")).
+		Column("user_mute.id").
+		Where("? = ?", bun.Ident("user_mute.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only mutes LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("user_mute.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only mutes HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("user_mute.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of mutes returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("user_mute.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("user_mute.id"))
+	}
+
+	if err := q.Scan(ctx, &muteIDs); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want mutes
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(muteIDs)
+	}
+
+	return r.GetMutesByIDs(ctx, muteIDs)
+}
+
+func (r *relationshipDB) GetExpiringMutes(ctx context.Context) ([]*gtsmodel.UserMute, error) {
+	var muteIDs []string
+
+	if err := r.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("user_mutes"), bun.Ident("user_mute")).
+		Column("user_mute.id").
+		Where("? IS NOT NULL", bun.Ident("user_mute.expires_at")).
+		Scan(ctx, &muteIDs); err != nil {
+		return nil, err
+	}
+
+	return r.GetMutesByIDs(ctx, muteIDs)
+}
+
+func (r *relationshipDB) PopulateMute(ctx context.Context, mute *gtsmodel.UserMute) error {
+	var (
+		errs gtserror.MultiError
+		err  error
+	)
+
+	if mute.Account == nil {
+		// Mute origin account is not set, fetch from database.
+		mute.Account, err = r.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			mute.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating mute account: %w", err)
+		}
+	}
+
+	if mute.TargetAccount == nil {
+		// Mute target account is not set, fetch from database.
+		mute.TargetAccount, err = r.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			mute.TargetAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating mute target account: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (r *relationshipDB) PutMute(ctx context.Context, mute *gtsmodel.UserMute) error {
+	return r.state.Caches.GTS.UserMute.Store(mute, func() error {
+		_, err := r.db.NewInsert().Model(mute).Exec(ctx)
+		return err
+	})
+}
+
+func (r *relationshipDB) UpdateMute(ctx context.Context, mute *gtsmodel.UserMute, columns ...string) error {
+	mute.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return r.state.Caches.GTS.UserMute.Store(mute, func() error {
+		_, err := r.db.NewUpdate().
+			Model(mute).
+			Column(columns...).
+			Where("? = ?", bun.Ident("user_mute.id"), mute.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (r *relationshipDB) DeleteMuteByID(ctx context.Context, id string) error {
+	// Load mute into cache before attempting a delete,
+	// as we need it cached in order to trigger the invalidate
+	// callback. This in turn invalidates others.
+	_, err := r.GetMuteByID(gtscontext.SetBarebones(ctx), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// not an issue.
+			err = nil
+		}
+		return err
+	}
+
+	// Drop this now-cached mute on return after delete.
+	defer r.state.Caches.GTS.UserMute.Invalidate("ID", id)
+
+	// Finally delete mute from DB.
+	_, err = r.db.NewDelete().
+		Table("user_mutes").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (r *relationshipDB) DeleteAccountMutes(ctx context.Context, accountID string) error {
+	var muteIDs []string
+
+	// Get full list of IDs.
+	if err := r.db.NewSelect().
+		Column("id").
+		Table("user_mutes").
+		WhereOr("? = ? OR ? = ?",
+			bun.Ident("account_id"),
+			accountID,
+			bun.Ident("target_account_id"),
+			accountID,
+		).
+		Scan(ctx, &muteIDs); err != nil {
+		return err
+	}
+
+	defer func() {
+		// Invalidate all account's incoming / outoing mutes on return.
+		r.state.Caches.GTS.UserMute.Invalidate("AccountID", accountID)
+		r.state.Caches.GTS.UserMute.Invalidate("TargetAccountID", accountID)
+	}()
+
+	// Load all mutes into cache, this *really* isn't great
+	// but it is the only way we can ensure we invalidate all
+	// related caches correctly (e.g. visibility).
+	_, err := r.GetMutesByIDs(ctx, muteIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Finally delete all from DB.
+	_, err = r.db.NewDelete().
+		Table("user_mutes").
+		Where("? IN (?)", bun.Ident("id"), bun.In(muteIDs)).
+		Exec(ctx)
+	return err
+}
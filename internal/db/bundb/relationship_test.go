@@ -959,6 +959,88 @@ func (suite *RelationshipTestSuite) TestPutNote() {
 	suite.Equal("bar", note.Comment)
 }
 
+func (suite *RelationshipTestSuite) TestPutGetDeleteMute() {
+	ctx := context.Background()
+
+	// put a mute in first
+	account1 := suite.testAccounts["local_account_1"].ID
+	account2 := suite.testAccounts["local_account_2"].ID
+	if err := suite.db.PutMute(ctx, &gtsmodel.UserMute{
+		ID:              "01HQX0MGFP1Y4Z4GCMT4D0YBCT",
+		AccountID:       account1,
+		TargetAccountID: account2,
+		Notifications:   util.Ptr(true),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// make sure the mute is in the db
+	mute, err := suite.db.GetMute(ctx, account1, account2)
+	suite.NoError(err)
+	suite.NotNil(mute)
+	suite.Equal("01HQX0MGFP1Y4Z4GCMT4D0YBCT", mute.ID)
+	suite.NotNil(mute.Account)
+	suite.NotNil(mute.TargetAccount)
+
+	// make sure the relationship reflects the mute
+	relationship, err := suite.db.GetRelationship(ctx, account1, account2)
+	suite.NoError(err)
+	suite.True(relationship.Muting)
+	suite.True(relationship.MutingNotifications)
+
+	// make sure the mute is returned for the account
+	mutes, err := suite.db.GetAccountMutes(ctx, account1, nil)
+	suite.NoError(err)
+	suite.Len(mutes, 1)
+
+	// delete the mute by ID
+	err = suite.db.DeleteMuteByID(ctx, "01HQX0MGFP1Y4Z4GCMT4D0YBCT")
+	suite.NoError(err)
+
+	// mute should be gone
+	mute, err = suite.db.GetMute(ctx, account1, account2)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Nil(mute)
+}
+
+func (suite *RelationshipTestSuite) TestExpiredMute() {
+	ctx := context.Background()
+
+	// put an already-expired mute in
+	account1 := suite.testAccounts["local_account_1"].ID
+	account2 := suite.testAccounts["local_account_2"].ID
+	if err := suite.db.PutMute(ctx, &gtsmodel.UserMute{
+		ID:              "01HQX0MGFP1Y4Z4GCMT4D0YBCT",
+		ExpiresAt:       time.Now().Add(-time.Minute),
+		AccountID:       account1,
+		TargetAccountID: account2,
+		Notifications:   util.Ptr(false),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// mute should be returned as expiring
+	mutes, err := suite.db.GetExpiringMutes(ctx)
+	suite.NoError(err)
+	suite.Len(mutes, 1)
+	suite.True(mutes[0].Expired(time.Now()))
+
+	// relationship should not reflect the expired mute
+	relationship, err := suite.db.GetRelationship(ctx, account1, account2)
+	suite.NoError(err)
+	suite.False(relationship.Muting)
+	suite.False(relationship.MutingNotifications)
+
+	// delete mutes by originAccountID
+	err = suite.db.DeleteAccountMutes(ctx, account1)
+	suite.NoError(err)
+
+	// mute should be gone
+	mute, err := suite.db.GetMute(ctx, account1, account2)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Nil(mute)
+}
+
 func TestRelationshipTestSuite(t *testing.T) {
 	suite.Run(t, new(RelationshipTestSuite))
 }
@@ -0,0 +1,234 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type scheduledStatusDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error) {
+	var status gtsmodel.ScheduledStatus
+
+	if err := s.db.
+		NewSelect().
+		Model(&status).
+		Where("? = ?", bun.Ident("scheduled_status.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return &status, nil
+	}
+
+	if err := s.PopulateScheduledStatus(ctx, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusesForAccount(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.ScheduledStatus, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		statuses = make([]*gtsmodel.ScheduledStatus, 0, limit)
+	)
+
+	q := s.db.
+		NewSelect().
+		Model(&statuses).
+		Where("? = ?", bun.Ident("scheduled_status.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only statuses LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("scheduled_status.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only statuses HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("scheduled_status.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of statuses returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("scheduled_status.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("scheduled_status.id"))
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want statuses
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(statuses)
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only barebones models were requested.
+		return statuses, nil
+	}
+
+	for _, status := range statuses {
+		if err := s.PopulateScheduledStatus(ctx, status); err != nil {
+			return nil, err
+		}
+	}
+
+	return statuses, nil
+}
+
+func (s *scheduledStatusDB) GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error) {
+	var statuses []*gtsmodel.ScheduledStatus
+
+	if err := s.db.
+		NewSelect().
+		Model(&statuses).
+		OrderExpr("? ASC", bun.Ident("scheduled_status.scheduled_at")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only barebones models were requested.
+		return statuses, nil
+	}
+
+	for _, status := range statuses {
+		if err := s.PopulateScheduledStatus(ctx, status); err != nil {
+			return nil, err
+		}
+	}
+
+	return statuses, nil
+}
+
+func (s *scheduledStatusDB) CountScheduledStatusesForAccount(ctx context.Context, accountID string) (int, error) {
+	return s.db.
+		NewSelect().
+		Table("scheduled_statuses").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Count(ctx)
+}
+
+func (s *scheduledStatusDB) PopulateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(3)
+	)
+
+	if status.Account == nil {
+		// Scheduled status author is not set, fetch from the database.
+		status.Account, err = s.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status account: %w", err)
+		}
+	}
+
+	if !status.MediaAttachmentsPopulated() {
+		// Scheduled status attachments are out-of-date with IDs, repopulate.
+		status.MediaAttachments, err = s.state.DB.GetAttachmentsByIDs(
+			ctx, // these are already barebones
+			status.MediaIDs,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating scheduled status attachments: %w", err)
+		}
+	}
+
+	if status.ApplicationID != "" && status.Application == nil {
+		// Scheduled status application is not set, fetch from the database.
+		status.Application, err = s.state.DB.GetApplicationByID(
+			ctx, // these are already barebones
+			status.ApplicationID,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status application: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (s *scheduledStatusDB) PutScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error {
+	_, err := s.db.
+		NewInsert().
+		Model(status).
+		Exec(ctx)
+	return err
+}
+
+func (s *scheduledStatusDB) UpdateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus, columns ...string) error {
+	status.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := s.db.
+		NewUpdate().
+		Model(status).
+		Column(columns...).
+		Where("? = ?", bun.Ident("scheduled_status.id"), status.ID).
+		Exec(ctx)
+	return err
+}
+
+func (s *scheduledStatusDB) DeleteScheduledStatusByID(ctx context.Context, id string) error {
+	_, err := s.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		Where("? = ?", bun.Ident("scheduled_status.id"), id).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,154 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ScheduledStatusTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) newScheduledStatus(id string, scheduledAt time.Time) *gtsmodel.ScheduledStatus {
+	var (
+		account    = suite.testAccounts["local_account_1"]
+		attachment = suite.testAttachments["local_account_1_unattached_1"]
+	)
+
+	return &gtsmodel.ScheduledStatus{
+		ID:            id,
+		AccountID:     account.ID,
+		ScheduledAt:   scheduledAt,
+		Text:          "this is a scheduled status",
+		Sensitive:     util.Ptr(false),
+		Visibility:    gtsmodel.VisibilityPublic,
+		Federated:     util.Ptr(true),
+		Boostable:     util.Ptr(true),
+		Replyable:     util.Ptr(true),
+		Likeable:      util.Ptr(true),
+		Language:      "en",
+		MediaIDs:      []string{attachment.ID},
+		ApplicationID: suite.testApplications["application_1"].ID,
+	}
+}
+
+func (suite *ScheduledStatusTestSuite) TestPutGetScheduledStatus() {
+	ctx := context.Background()
+
+	scheduled := suite.newScheduledStatus("01HQ3N7A1X1VJ0T2J2XNJ5DPPS", time.Now().Add(time.Hour))
+	if err := suite.db.PutScheduledStatus(ctx, scheduled); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbScheduled, err := suite.db.GetScheduledStatusByID(ctx, scheduled.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(scheduled.Text, dbScheduled.Text)
+	suite.Equal(scheduled.Visibility, dbScheduled.Visibility)
+	suite.Equal(scheduled.MediaIDs, dbScheduled.MediaIDs)
+	suite.NotNil(dbScheduled.Account)
+	suite.NotNil(dbScheduled.Application)
+	suite.Len(dbScheduled.MediaAttachments, 1)
+	suite.WithinDuration(scheduled.ScheduledAt, dbScheduled.ScheduledAt, time.Second)
+}
+
+func (suite *ScheduledStatusTestSuite) TestGetScheduledStatuses() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		now     = time.Now()
+	)
+
+	for _, scheduled := range []*gtsmodel.ScheduledStatus{
+		suite.newScheduledStatus("01HQ3N7A1X1VJ0T2J2XNJ5DPPS", now.Add(2*time.Hour)),
+		suite.newScheduledStatus("01HQ3N8F4S8XH0CQ2T1E6T5V0Q", now.Add(time.Hour)),
+		suite.newScheduledStatus("01HQ3N9C2M3VF6Y4ZB7K0P2Q1R", now.Add(3*time.Hour)),
+	} {
+		if err := suite.db.PutScheduledStatus(ctx, scheduled); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Account scheduled statuses, newest first.
+	scheduled, err := suite.db.GetScheduledStatusesForAccount(ctx, account.ID, &paging.Page{Limit: 2})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(scheduled, 2)
+	suite.Equal("01HQ3N9C2M3VF6Y4ZB7K0P2Q1R", scheduled[0].ID)
+	suite.Equal("01HQ3N8F4S8XH0CQ2T1E6T5V0Q", scheduled[1].ID)
+
+	count, err := suite.db.CountScheduledStatusesForAccount(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(3, count)
+
+	// All scheduled statuses, soonest first.
+	scheduled, err = suite.db.GetAllScheduledStatuses(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(scheduled, 3)
+	suite.Equal("01HQ3N8F4S8XH0CQ2T1E6T5V0Q", scheduled[0].ID)
+	suite.Equal("01HQ3N7A1X1VJ0T2J2XNJ5DPPS", scheduled[1].ID)
+	suite.Equal("01HQ3N9C2M3VF6Y4ZB7K0P2Q1R", scheduled[2].ID)
+}
+
+func (suite *ScheduledStatusTestSuite) TestUpdateDeleteScheduledStatus() {
+	ctx := context.Background()
+
+	scheduled := suite.newScheduledStatus("01HQ3N7A1X1VJ0T2J2XNJ5DPPS", time.Now().Add(time.Hour))
+	if err := suite.db.PutScheduledStatus(ctx, scheduled); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	scheduledAt := time.Now().Add(24 * time.Hour)
+	scheduled.ScheduledAt = scheduledAt
+	if err := suite.db.UpdateScheduledStatus(ctx, scheduled, "scheduled_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbScheduled, err := suite.db.GetScheduledStatusByID(ctx, scheduled.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.WithinDuration(scheduledAt, dbScheduled.ScheduledAt, time.Second)
+
+	if err := suite.db.DeleteScheduledStatusByID(ctx, scheduled.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetScheduledStatusByID(ctx, scheduled.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
@@ -194,7 +194,7 @@ func (s *statusDB) getStatus(ctx context.Context, lookup string, dbQuery func(*g
 func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status) error {
 	var (
 		err  error
-		errs = gtserror.NewMultiError(9)
+		errs = gtserror.NewMultiError(10)
 	)
 
 	if status.Account == nil {
@@ -267,6 +267,17 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.CardID != "" && status.Card == nil {
+		// Status preview card is not set, fetch from database.
+		status.Card, err = s.state.DB.GetCardByID(
+			ctx, // card image is also needed
+			status.CardID,
+		)
+		if err != nil {
+			errs.Appendf("error populating status card: %w", err)
+		}
+	}
+
 	if !status.AttachmentsPopulated() {
 		// Status attachments are out-of-date with IDs, repopulate.
 		status.Attachments, err = s.state.DB.GetAttachmentsByIDs(
@@ -396,8 +407,26 @@ func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) error
 				}
 			}
 
-			// Finally, insert the status
-			_, err := tx.NewInsert().Model(status).Exec(ctx)
+			// Insert the status.
+			if _, err := tx.NewInsert().Model(status).Exec(ctx); err != nil {
+				return err
+			}
+
+			// Finally, bump the author's last status time, used to
+			// order the profile directory. This is done in the db
+			// only, as hitting the account cache for every new
+			// status would be a lot of churn for one sort key.
+			_, err := tx.
+				NewUpdate().
+				Table("accounts").
+				Set("? = ?", bun.Ident("last_status_at"), status.CreatedAt).
+				Where("? = ?", bun.Ident("id"), status.AccountID).
+				WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+					return q.
+						Where("? IS NULL", bun.Ident("last_status_at")).
+						WhereOr("? < ?", bun.Ident("last_status_at"), status.CreatedAt)
+				}).
+				Exec(ctx)
 			return err
 		})
 	})
@@ -539,6 +568,16 @@ func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) error {
 			return err
 		}
 
+		// Delete any previous
+		// revisions of this status.
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("status_edits"), bun.Ident("status_edit")).
+			Where("? = ?", bun.Ident("status_edit.status_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
 		// delete the status itself
 		if _, err := tx.
 			NewDelete().
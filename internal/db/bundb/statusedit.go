@@ -0,0 +1,125 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type statusEditDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *statusEditDB) GetStatusEditByID(ctx context.Context, id string) (*gtsmodel.StatusEdit, error) {
+	var edit gtsmodel.StatusEdit
+
+	if err := s.db.
+		NewSelect().
+		Model(&edit).
+		Where("? = ?", bun.Ident("status_edit.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return &edit, nil
+	}
+
+	if err := s.PopulateStatusEdit(ctx, &edit); err != nil {
+		return nil, err
+	}
+
+	return &edit, nil
+}
+
+func (s *statusEditDB) GetStatusEditsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.StatusEdit, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	// Preallocate expected length of edits.
+	edits := make([]*gtsmodel.StatusEdit, 0, len(ids))
+
+	if err := s.db.
+		NewSelect().
+		Model(&edits).
+		Where("? IN (?)", bun.Ident("status_edit.id"), bun.In(ids)).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reorder the edits by their
+	// IDs to ensure in correct order.
+	getID := func(e *gtsmodel.StatusEdit) string { return e.ID }
+	util.OrderBy(edits, ids, getID)
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return edits, nil
+	}
+
+	// Populate all loaded edits, removing those we fail to
+	// populate (removes needing so many nil checks everywhere).
+	edits = slices.DeleteFunc(edits, func(edit *gtsmodel.StatusEdit) bool {
+		if err := s.PopulateStatusEdit(ctx, edit); err != nil {
+			log.Errorf(ctx, "error populating status edit %s: %v", edit.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return edits, nil
+}
+
+func (s *statusEditDB) PopulateStatusEdit(ctx context.Context, edit *gtsmodel.StatusEdit) error {
+	var err error
+
+	if !edit.AttachmentsPopulated() {
+		// Status edit attachments are out-of-date with IDs, repopulate.
+		edit.Attachments, err = s.state.DB.GetAttachmentsByIDs(
+			ctx, // these are already barebones
+			edit.AttachmentIDs,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error populating status edit attachments: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *statusEditDB) PutStatusEdit(ctx context.Context, edit *gtsmodel.StatusEdit) error {
+	_, err := s.db.
+		NewInsert().
+		Model(edit).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,115 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type StatusEditTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) TestPutGetStatusEdits() {
+	var (
+		ctx    = context.Background()
+		status = suite.testStatuses["admin_account_status_1"]
+		edits  = make([]*gtsmodel.StatusEdit, 3)
+	)
+
+	for i := range edits {
+		edits[i] = &gtsmodel.StatusEdit{
+			ID:                     id.NewULID(),
+			CreatedAt:              time.Now(),
+			StatusID:               status.ID,
+			Content:                status.Content,
+			ContentWarning:         status.ContentWarning,
+			Language:               status.Language,
+			Sensitive:              util.Ptr(i%2 == 0),
+			AttachmentIDs:          status.AttachmentIDs,
+			AttachmentDescriptions: []string{"some description"},
+			PollOptions:            []string{"yes", "no"},
+			PollVotes:              []int{i, 0},
+		}
+
+		if err := suite.db.PutStatusEdit(ctx, edits[i]); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Fetch edits in a different order to insertion,
+	// they should be returned in the order requested.
+	ids := []string{edits[2].ID, edits[0].ID, edits[1].ID}
+	dbEdits, err := suite.db.GetStatusEditsByIDs(ctx, ids)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(dbEdits, len(ids))
+	for i, edit := range dbEdits {
+		suite.Equal(ids[i], edit.ID)
+		suite.Equal(status.ID, edit.StatusID)
+		suite.Equal([]string{"yes", "no"}, edit.PollOptions)
+		suite.Equal([]string{"some description"}, edit.AttachmentDescriptions)
+		suite.Len(edit.Attachments, len(status.AttachmentIDs))
+	}
+
+	dbEdit, err := suite.db.GetStatusEditByID(ctx, edits[2].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbEdit.Sensitive)
+	suite.Equal([]int{2, 0}, dbEdit.PollVotes)
+}
+
+func (suite *StatusEditTestSuite) TestDeleteStatusDeletesEdits() {
+	var (
+		ctx    = context.Background()
+		status = suite.testStatuses["admin_account_status_1"]
+		edit   = &gtsmodel.StatusEdit{
+			ID:        id.NewULID(),
+			CreatedAt: time.Now(),
+			StatusID:  status.ID,
+			Content:   status.Content,
+			Sensitive: util.Ptr(false),
+		}
+	)
+
+	if err := suite.db.PutStatusEdit(ctx, edit); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.DeleteStatusByID(ctx, status.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetStatusEditByID(ctx, edit.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
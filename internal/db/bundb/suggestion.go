@@ -0,0 +1,206 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type suggestionDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *suggestionDB) GetPinnedSuggestions(ctx context.Context) ([]*gtsmodel.PinnedSuggestion, error) {
+	var pinnedSuggestions []*gtsmodel.PinnedSuggestion
+
+	if err := s.db.
+		NewSelect().
+		Model(&pinnedSuggestions).
+		OrderExpr("? DESC", bun.Ident("pinned_suggestion.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// Populate all loaded suggestions, removing those we
+	// fail to populate (removes need for db calls later).
+	pinnedSuggestions = slices.DeleteFunc(pinnedSuggestions, func(pinnedSuggestion *gtsmodel.PinnedSuggestion) bool {
+		if err := s.populatePinnedSuggestion(ctx, pinnedSuggestion); err != nil {
+			log.Errorf(ctx, "error populating pinned suggestion %s: %v", pinnedSuggestion.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return pinnedSuggestions, nil
+}
+
+func (s *suggestionDB) GetPinnedSuggestionByTargetAccountID(ctx context.Context, targetAccountID string) (*gtsmodel.PinnedSuggestion, error) {
+	var pinnedSuggestion gtsmodel.PinnedSuggestion
+
+	if err := s.db.
+		NewSelect().
+		Model(&pinnedSuggestion).
+		Where("? = ?", bun.Ident("pinned_suggestion.target_account_id"), targetAccountID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.populatePinnedSuggestion(ctx, &pinnedSuggestion); err != nil {
+		return nil, err
+	}
+
+	return &pinnedSuggestion, nil
+}
+
+func (s *suggestionDB) populatePinnedSuggestion(ctx context.Context, pinnedSuggestion *gtsmodel.PinnedSuggestion) error {
+	var err error
+
+	if pinnedSuggestion.TargetAccount == nil {
+		// Suggested account is not set, fetch from database.
+		pinnedSuggestion.TargetAccount, err = s.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			pinnedSuggestion.TargetAccountID,
+		)
+		if err != nil {
+			return gtserror.Newf("error populating pinned suggestion target account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *suggestionDB) PutPinnedSuggestion(ctx context.Context, pinnedSuggestion *gtsmodel.PinnedSuggestion) error {
+	_, err := s.db.
+		NewInsert().
+		Model(pinnedSuggestion).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) DeletePinnedSuggestionByTargetAccountID(ctx context.Context, targetAccountID string) error {
+	_, err := s.db.
+		NewDelete().
+		Table("pinned_suggestions").
+		Where("? = ?", bun.Ident("target_account_id"), targetAccountID).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) GetDismissedSuggestionTargetAccountIDs(ctx context.Context, accountID string) ([]string, error) {
+	var targetAccountIDs []string
+
+	if _, err := s.db.
+		NewSelect().
+		Table("dismissed_suggestions").
+		Column("target_account_id").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx, &targetAccountIDs); err != nil {
+		return nil, err
+	}
+
+	return targetAccountIDs, nil
+}
+
+func (s *suggestionDB) PutDismissedSuggestion(ctx context.Context, dismissedSuggestion *gtsmodel.DismissedSuggestion) error {
+	_, err := s.db.
+		NewInsert().
+		Model(dismissedSuggestion).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) GetFriendsOfFriendsAccountIDs(ctx context.Context, accountID string, limit int) ([]string, error) {
+	var accountIDs []string
+
+	// Accounts followed by accountID.
+	followingQ := s.db.
+		NewSelect().
+		Table("follows").
+		Column("target_account_id").
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	// Accounts follow-requested by accountID.
+	requestedQ := s.db.
+		NewSelect().
+		Table("follow_requests").
+		Column("target_account_id").
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	// Accounts dismissed by accountID.
+	dismissedQ := s.db.
+		NewSelect().
+		Table("dismissed_suggestions").
+		Column("target_account_id").
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		Column("follow.target_account_id").
+		Where("? IN (?)", bun.Ident("follow.account_id"), followingQ).
+		Where("? != ?", bun.Ident("follow.target_account_id"), accountID).
+		Where("? NOT IN (?)", bun.Ident("follow.target_account_id"), followingQ).
+		Where("? NOT IN (?)", bun.Ident("follow.target_account_id"), requestedQ).
+		Where("? NOT IN (?)", bun.Ident("follow.target_account_id"), dismissedQ).
+		Group("follow.target_account_id").
+		// Most commonly followed first.
+		OrderExpr("COUNT(*) DESC").
+		OrderExpr("? DESC", bun.Ident("follow.target_account_id"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
+
+func (s *suggestionDB) DeleteSuggestionsByAccountID(ctx context.Context, accountID string) error {
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete pins of, or by, this account.
+		if _, err := tx.
+			NewDelete().
+			Table("pinned_suggestions").
+			WhereOr("? = ?", bun.Ident("account_id"), accountID).
+			WhereOr("? = ?", bun.Ident("target_account_id"), accountID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Delete dismissals of, or by, this account.
+		_, err := tx.
+			NewDelete().
+			Table("dismissed_suggestions").
+			WhereOr("? = ?", bun.Ident("account_id"), accountID).
+			WhereOr("? = ?", bun.Ident("target_account_id"), accountID).
+			Exec(ctx)
+		return err
+	})
+}
@@ -0,0 +1,137 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type SuggestionTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *SuggestionTestSuite) TestPinnedSuggestions() {
+	var (
+		ctx          = context.Background()
+		adminAccount = suite.testAccounts["admin_account"]
+		target       = suite.testAccounts["remote_account_1"]
+	)
+
+	if err := suite.db.PutPinnedSuggestion(ctx, &gtsmodel.PinnedSuggestion{
+		ID:              id.NewULID(),
+		AccountID:       adminAccount.ID,
+		TargetAccountID: target.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Pinning the same account twice should fail.
+	err := suite.db.PutPinnedSuggestion(ctx, &gtsmodel.PinnedSuggestion{
+		ID:              id.NewULID(),
+		AccountID:       adminAccount.ID,
+		TargetAccountID: target.ID,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	pinnedSuggestions, err := suite.db.GetPinnedSuggestions(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(pinnedSuggestions, 1)
+	suite.Equal(target.ID, pinnedSuggestions[0].TargetAccount.ID)
+
+	pinnedSuggestion, err := suite.db.GetPinnedSuggestionByTargetAccountID(ctx, target.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(adminAccount.ID, pinnedSuggestion.AccountID)
+
+	if err := suite.db.DeletePinnedSuggestionByTargetAccountID(ctx, target.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetPinnedSuggestionByTargetAccountID(ctx, target.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *SuggestionTestSuite) TestGetFriendsOfFriendsAccountIDs() {
+	var (
+		ctx          = context.Background()
+		adminAccount = suite.testAccounts["admin_account"]
+		account1     = suite.testAccounts["local_account_1"]
+		account2     = suite.testAccounts["local_account_2"]
+	)
+
+	// Admin follows local_account_1, who follows
+	// admin (self, excluded) and local_account_2.
+	accountIDs, err := suite.db.GetFriendsOfFriendsAccountIDs(ctx, adminAccount.ID, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{account2.ID}, accountIDs)
+
+	// local_account_1 already follows
+	// everyone followed by its follows.
+	accountIDs, err = suite.db.GetFriendsOfFriendsAccountIDs(ctx, account1.ID, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(accountIDs)
+
+	// Dismissed suggestions should be excluded.
+	if err := suite.db.PutDismissedSuggestion(ctx, &gtsmodel.DismissedSuggestion{
+		ID:              id.NewULID(),
+		AccountID:       adminAccount.ID,
+		TargetAccountID: account2.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dismissedIDs, err := suite.db.GetDismissedSuggestionTargetAccountIDs(ctx, adminAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{account2.ID}, dismissedIDs)
+
+	accountIDs, err = suite.db.GetFriendsOfFriendsAccountIDs(ctx, adminAccount.ID, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(accountIDs)
+
+	// Dismissals are removed along with the account.
+	if err := suite.db.DeleteSuggestionsByAccountID(ctx, adminAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dismissedIDs, err = suite.db.GetDismissedSuggestionTargetAccountIDs(ctx, adminAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dismissedIDs)
+}
+
+func TestSuggestionTestSuite(t *testing.T) {
+	suite.Run(t, new(SuggestionTestSuite))
+}
@@ -19,9 +19,13 @@ package bundb
 
 import (
 	"context"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
@@ -144,3 +148,341 @@ func (t *tagDB) PutTag(ctx context.Context, tag *gtsmodel.Tag) error {
 
 	return nil
 }
+
+func (t *tagDB) GetFollowedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FollowedTag, error) {
+	var followedTag gtsmodel.FollowedTag
+
+	if err := t.db.
+		NewSelect().
+		Model(&followedTag).
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+		Where("? = ?", bun.Ident("followed_tag.tag_id"), tagID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &followedTag, nil
+}
+
+func (t *tagDB) GetFollowedTags(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.FollowedTag, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		followedTags = make([]*gtsmodel.FollowedTag, 0, limit)
+	)
+
+	q := t.db.
+		NewSelect().
+		Model(&followedTags).
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only followed tags LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("followed_tag.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only followed tags HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("followed_tag.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of followed tags returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("followed_tag.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("followed_tag.id"))
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want followed
+	// tags to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(followedTags)
+	}
+
+	// Populate the tag of each followed tag.
+	for _, followedTag := range followedTags {
+		tag, err := t.GetTag(ctx, followedTag.TagID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting tag %s: %w", followedTag.TagID, err)
+		}
+		followedTag.Tag = tag
+	}
+
+	return followedTags, nil
+}
+
+func (t *tagDB) GetFollowedTagIDs(ctx context.Context, accountID string) ([]string, error) {
+	var tagIDs []string
+
+	if err := t.db.
+		NewSelect().
+		Table("followed_tags").
+		Column("tag_id").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Scan(ctx, &tagIDs); err != nil {
+		return nil, err
+	}
+
+	return tagIDs, nil
+}
+
+func (t *tagDB) GetAccountIDsFollowingTagIDs(ctx context.Context, tagIDs []string) ([]string, error) {
+	if len(tagIDs) == 0 {
+		// Nothing to select.
+		return nil, nil
+	}
+
+	var accountIDs []string
+
+	if err := t.db.
+		NewSelect().
+		Table("followed_tags").
+		Column("account_id").
+		Distinct().
+		Where("? IN (?)", bun.Ident("tag_id"), bun.In(tagIDs)).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
+
+func (t *tagDB) PutFollowedTag(ctx context.Context, followedTag *gtsmodel.FollowedTag) error {
+	if _, err := t.db.
+		NewInsert().
+		Model(followedTag).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Home timeline visibility of
+	// tagged statuses may have changed.
+	t.state.Caches.Visibility.Invalidate("RequesterID", followedTag.AccountID)
+	return nil
+}
+
+func (t *tagDB) DeleteFollowedTag(ctx context.Context, accountID string, tagID string) error {
+	if _, err := t.db.
+		NewDelete().
+		Table("followed_tags").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Where("? = ?", bun.Ident("tag_id"), tagID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Home timeline visibility of
+	// tagged statuses may have changed.
+	t.state.Caches.Visibility.Invalidate("RequesterID", accountID)
+	return nil
+}
+
+func (t *tagDB) DeleteFollowedTagsByAccountID(ctx context.Context, accountID string) error {
+	if _, err := t.db.
+		NewDelete().
+		Table("followed_tags").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Home timeline visibility of
+	// tagged statuses may have changed.
+	t.state.Caches.Visibility.Invalidate("RequesterID", accountID)
+	return nil
+}
+
+func (t *tagDB) GetFeaturedTagByID(ctx context.Context, id string) (*gtsmodel.FeaturedTag, error) {
+	var featuredTag gtsmodel.FeaturedTag
+
+	if err := t.db.
+		NewSelect().
+		Model(&featuredTag).
+		Where("? = ?", bun.Ident("featured_tag.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := t.populateFeaturedTag(ctx, &featuredTag); err != nil {
+		return nil, err
+	}
+
+	return &featuredTag, nil
+}
+
+func (t *tagDB) GetFeaturedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FeaturedTag, error) {
+	var featuredTag gtsmodel.FeaturedTag
+
+	if err := t.db.
+		NewSelect().
+		Model(&featuredTag).
+		Where("? = ?", bun.Ident("featured_tag.account_id"), accountID).
+		Where("? = ?", bun.Ident("featured_tag.tag_id"), tagID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := t.populateFeaturedTag(ctx, &featuredTag); err != nil {
+		return nil, err
+	}
+
+	return &featuredTag, nil
+}
+
+func (t *tagDB) GetFeaturedTagsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, error) {
+	var featuredTags []*gtsmodel.FeaturedTag
+
+	if err := t.db.
+		NewSelect().
+		Model(&featuredTags).
+		Where("? = ?", bun.Ident("featured_tag.account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("featured_tag.statuses_count")).
+		OrderExpr("? DESC", bun.Ident("featured_tag.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, featuredTag := range featuredTags {
+		if err := t.populateFeaturedTag(ctx, featuredTag); err != nil {
+			return nil, err
+		}
+	}
+
+	return featuredTags, nil
+}
+
+func (t *tagDB) populateFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error {
+	if featuredTag.Tag != nil {
+		// Already populated.
+		return nil
+	}
+
+	tag, err := t.GetTag(ctx, featuredTag.TagID)
+	if err != nil {
+		return gtserror.Newf("error getting tag %s: %w", featuredTag.TagID, err)
+	}
+	featuredTag.Tag = tag
+
+	return nil
+}
+
+func (t *tagDB) PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error {
+	_, err := t.db.
+		NewInsert().
+		Model(featuredTag).
+		Exec(ctx)
+	return err
+}
+
+func (t *tagDB) UpdateFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag, columns ...string) error {
+	featuredTag.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := t.db.
+		NewUpdate().
+		Model(featuredTag).
+		Column(columns...).
+		Where("? = ?", bun.Ident("featured_tag.id"), featuredTag.ID).
+		Exec(ctx)
+	return err
+}
+
+func (t *tagDB) DeleteFeaturedTagByID(ctx context.Context, id string) error {
+	_, err := t.db.
+		NewDelete().
+		Table("featured_tags").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (t *tagDB) DeleteFeaturedTagsByAccountID(ctx context.Context, accountID string) error {
+	_, err := t.db.
+		NewDelete().
+		Table("featured_tags").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+// newAccountTagUsesQ returns a new select query over the
+// tag uses of public + unlisted statuses by accountID.
+func (t *tagDB) newAccountTagUsesQ(accountID string) *bun.SelectQuery {
+	return t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Join(
+			"INNER JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		Where("? IN (?)", bun.Ident("status.visibility"), bun.In([]gtsmodel.Visibility{
+			gtsmodel.VisibilityPublic,
+			gtsmodel.VisibilityUnlocked,
+		})).
+		Where("? IS NULL", bun.Ident("status.boost_of_id"))
+}
+
+func (t *tagDB) GetAccountTagUsage(ctx context.Context, accountID string, tagID string) (int, time.Time, error) {
+	count, err := t.newAccountTagUsesQ(accountID).
+		Where("? = ?", bun.Ident("status_to_tag.tag_id"), tagID).
+		Count(ctx)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	if count == 0 {
+		// Never used.
+		return 0, time.Time{}, nil
+	}
+
+	var lastAt time.Time
+
+	// Status IDs are ULIDs generated from creation
+	// time, so the highest ID is the latest status.
+	if err := t.newAccountTagUsesQ(accountID).
+		Column("status.created_at").
+		Where("? = ?", bun.Ident("status_to_tag.tag_id"), tagID).
+		OrderExpr("? DESC", bun.Ident("status.id")).
+		Limit(1).
+		Scan(ctx, &lastAt); err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return count, lastAt, nil
+}
+
+func (t *tagDB) GetAccountMostUsedTagIDs(ctx context.Context, accountID string, limit int) ([]string, error) {
+	var tagIDs []string
+
+	if err := t.newAccountTagUsesQ(accountID).
+		Column("status_to_tag.tag_id").
+		Group("status_to_tag.tag_id").
+		OrderExpr("COUNT(*) DESC").
+		Limit(limit).
+		Scan(ctx, &tagIDs); err != nil {
+		return nil, err
+	}
+
+	return tagIDs, nil
+}
@@ -86,6 +86,119 @@ func (suite *TagTestSuite) TestPutTag() {
 	}
 }
 
+func (suite *TagTestSuite) TestFollowedTags() {
+	var (
+		ctx         = context.Background()
+		testTag     = suite.testTags["welcome"]
+		testAccount = suite.testAccounts["local_account_2"]
+	)
+
+	// Follow the tag.
+	if err := suite.db.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+		ID:        "01HRF5BSN2BFVPGQ8CV9CBDAWA",
+		AccountID: testAccount.ID,
+		TagID:     testTag.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Following again should fail.
+	err := suite.db.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+		ID:        "01HRF5CAN9R05ZB45Z3XKN9ECD",
+		AccountID: testAccount.ID,
+		TagID:     testTag.ID,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	followedTag, err := suite.db.GetFollowedTag(ctx, testAccount.ID, testTag.ID)
+	suite.NoError(err)
+	suite.Equal("01HRF5BSN2BFVPGQ8CV9CBDAWA", followedTag.ID)
+
+	followedTags, err := suite.db.GetFollowedTags(ctx, testAccount.ID, nil)
+	suite.NoError(err)
+	suite.Len(followedTags, 1)
+	suite.Equal(testTag.Name, followedTags[0].Tag.Name)
+
+	tagIDs, err := suite.db.GetFollowedTagIDs(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Equal([]string{testTag.ID}, tagIDs)
+
+	accountIDs, err := suite.db.GetAccountIDsFollowingTagIDs(ctx, []string{
+		testTag.ID,
+		suite.testTags["Hashtag"].ID,
+	})
+	suite.NoError(err)
+	suite.Equal([]string{testAccount.ID}, accountIDs)
+
+	// Unfollow the tag.
+	err = suite.db.DeleteFollowedTag(ctx, testAccount.ID, testTag.ID)
+	suite.NoError(err)
+
+	followedTag, err = suite.db.GetFollowedTag(ctx, testAccount.ID, testTag.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Nil(followedTag)
+}
+
+func (suite *TagTestSuite) TestFeaturedTags() {
+	var (
+		ctx         = context.Background()
+		testTag     = suite.testTags["welcome"]
+		testAccount = suite.testAccounts["admin_account"]
+	)
+
+	// Feature the tag.
+	if err := suite.db.PutFeaturedTag(ctx, &gtsmodel.FeaturedTag{
+		ID:        "01HTZ2V3BKQ8G4M5X8AXMYJ7TN",
+		AccountID: testAccount.ID,
+		TagID:     testTag.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Featuring again should fail.
+	err := suite.db.PutFeaturedTag(ctx, &gtsmodel.FeaturedTag{
+		ID:        "01HTZ2W7S3J0WZP1E2QK6F9V4B",
+		AccountID: testAccount.ID,
+		TagID:     testTag.ID,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	// Admin has used the tag once, in a public status.
+	count, lastAt, err := suite.db.GetAccountTagUsage(ctx, testAccount.ID, testTag.ID)
+	suite.NoError(err)
+	suite.Equal(1, count)
+	suite.Equal(suite.testStatuses["admin_account_status_1"].CreatedAt.UTC(), lastAt.UTC())
+
+	featuredTag, err := suite.db.GetFeaturedTag(ctx, testAccount.ID, testTag.ID)
+	suite.NoError(err)
+	suite.Equal("01HTZ2V3BKQ8G4M5X8AXMYJ7TN", featuredTag.ID)
+	suite.Equal(0, featuredTag.StatusesCount)
+
+	// Store the usage stats.
+	featuredTag.StatusesCount = count
+	featuredTag.LastStatusAt = lastAt
+	err = suite.db.UpdateFeaturedTag(ctx, featuredTag, "statuses_count", "last_status_at")
+	suite.NoError(err)
+
+	featuredTags, err := suite.db.GetFeaturedTagsByAccountID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Len(featuredTags, 1)
+	suite.Equal(testTag.Name, featuredTags[0].Tag.Name)
+	suite.Equal(1, featuredTags[0].StatusesCount)
+
+	tagIDs, err := suite.db.GetAccountMostUsedTagIDs(ctx, testAccount.ID, 10)
+	suite.NoError(err)
+	suite.Equal([]string{testTag.ID}, tagIDs)
+
+	// Unfeature the tag.
+	err = suite.db.DeleteFeaturedTagByID(ctx, featuredTag.ID)
+	suite.NoError(err)
+
+	featuredTag, err = suite.db.GetFeaturedTagByID(ctx, featuredTag.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Nil(featuredTag)
+}
+
 func TestTagTestSuite(t *testing.T) {
 	suite.Run(t, new(TagTestSuite))
 }
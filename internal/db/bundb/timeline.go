@@ -129,13 +129,41 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 	// accountID can see its own posts in the timeline.
 	targetAccountIDs[len(targetAccountIDs)-1] = accountID
 
-	// Select only statuses authored by
-	// accounts with IDs in the slice.
-	q = q.Where(
-		"? IN (?)",
-		bun.Ident("status.account_id"),
-		bun.In(targetAccountIDs),
-	)
+	// Additionally, public statuses using any of the
+	// hashtags followed by accountID should be included.
+	tagIDs, err := t.state.DB.GetFollowedTagIDs(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting followed tags for account %s: %w", accountID, err)
+	}
+
+	q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		// Select only statuses authored by
+		// accounts with IDs in the slice.
+		q = q.Where(
+			"? IN (?)",
+			bun.Ident("status.account_id"),
+			bun.In(targetAccountIDs),
+		)
+
+		if len(tagIDs) != 0 {
+			// Or, public non-boost statuses
+			// using any of the followed tags.
+			q = q.WhereOr(
+				"? = ? AND ? IS NULL AND ? IN (?)",
+				bun.Ident("status.visibility"),
+				gtsmodel.VisibilityPublic,
+				bun.Ident("status.boost_of_id"),
+				bun.Ident("status.id"),
+				t.db.
+					NewSelect().
+					TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+					Column("status_to_tag.status_id").
+					Where("? IN (?)", bun.Ident("status_to_tag.tag_id"), bun.In(tagIDs)),
+			)
+		}
+
+		return q
+	})
 
 	if err := q.Scan(ctx, &statusIDs); err != nil {
 		return nil, err
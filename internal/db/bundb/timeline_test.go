@@ -19,6 +19,7 @@ package bundb_test
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
@@ -192,6 +193,52 @@ func (suite *TimelineTestSuite) TestGetHomeTimelineNoFollowing() {
 	suite.checkStatuses(s, id.Highest, id.Lowest, 7)
 }
 
+func (suite *TimelineTestSuite) TestGetHomeTimelineFollowedTag() {
+	var (
+		ctx            = context.Background()
+		viewingAccount = suite.testAccounts["local_account_1"]
+		testStatus     = suite.testStatuses["admin_account_status_1"]
+	)
+
+	// Remove all of viewingAccount's follows.
+	follows, err := suite.state.DB.GetAccountFollows(
+		gtscontext.SetBarebones(ctx),
+		viewingAccount.ID,
+		nil, // select all
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, f := range follows {
+		if err := suite.state.DB.DeleteFollowByID(ctx, f.ID); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Follow a hashtag used by a
+	// status of unfollowed account.
+	if err := suite.state.DB.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+		ID:        "01HRF5BSN2BFVPGQ8CV9CBDAWA",
+		AccountID: viewingAccount.ID,
+		TagID:     suite.testTags["welcome"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Tagged status should now be included
+	// alongside viewingAccount's own statuses.
+	s, err := suite.db.GetHomeTimeline(ctx, viewingAccount.ID, "", "", "", 20, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.checkStatuses(s, id.Highest, id.Lowest, 8)
+	suite.True(slices.ContainsFunc(s, func(status *gtsmodel.Status) bool {
+		return status.ID == testStatus.ID
+	}))
+}
+
 func (suite *TimelineTestSuite) TestGetHomeTimelineWithFutureStatus() {
 	var (
 		ctx            = context.Background()
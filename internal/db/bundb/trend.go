@@ -0,0 +1,283 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type trendDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (t *trendDB) GetTrendByID(ctx context.Context, id string) (*gtsmodel.Trend, error) {
+	var trend gtsmodel.Trend
+
+	if err := t.db.
+		NewSelect().
+		Model(&trend).
+		Where("? = ?", bun.Ident("trend.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &trend, nil
+	}
+
+	// Further populate the trend fields where applicable.
+	if err := t.PopulateTrend(ctx, &trend); err != nil {
+		return nil, err
+	}
+
+	return &trend, nil
+}
+
+func (t *trendDB) GetTrendsByType(ctx context.Context, trendType gtsmodel.TrendType) ([]*gtsmodel.Trend, error) {
+	var trends []*gtsmodel.Trend
+
+	if err := t.db.
+		NewSelect().
+		Model(&trends).
+		Where("? = ?", bun.Ident("trend.type"), trendType).
+		OrderExpr("? DESC", bun.Ident("trend.score")).
+		OrderExpr("? DESC", bun.Ident("trend.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return trends, nil
+	}
+
+	// Populate all loaded trends, removing those we
+	// fail to populate (removes need for db calls later).
+	trends = slices.DeleteFunc(trends, func(trend *gtsmodel.Trend) bool {
+		if err := t.PopulateTrend(ctx, trend); err != nil {
+			log.Errorf(ctx, "error populating trend %s: %v", trend.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return trends, nil
+}
+
+func (t *trendDB) PopulateTrend(ctx context.Context, trend *gtsmodel.Trend) error {
+	var err error
+
+	switch trend.Type {
+	case gtsmodel.TrendTypeTag:
+		if trend.Tag == nil {
+			// Trend target tag is not set, fetch from database.
+			trend.Tag, err = t.state.DB.GetTag(
+				gtscontext.SetBarebones(ctx),
+				trend.TargetID,
+			)
+			if err != nil {
+				return gtserror.Newf("error populating trend tag: %w", err)
+			}
+		}
+
+	case gtsmodel.TrendTypeStatus:
+		if trend.Status == nil {
+			// Trend target status is not set, fetch from database.
+			trend.Status, err = t.state.DB.GetStatusByID(
+				gtscontext.SetBarebones(ctx),
+				trend.TargetID,
+			)
+			if err != nil {
+				return gtserror.Newf("error populating trend status: %w", err)
+			}
+		}
+
+	case gtsmodel.TrendTypeLink:
+		if trend.Card == nil {
+			// Trend target card is not set, fetch from database.
+			trend.Card, err = t.state.DB.GetCardByID(
+				gtscontext.SetBarebones(ctx),
+				trend.TargetID,
+			)
+			if err != nil {
+				return gtserror.Newf("error populating trend card: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *trendDB) PutTrend(ctx context.Context, trend *gtsmodel.Trend) error {
+	_, err := t.db.
+		NewInsert().
+		Model(trend).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) UpdateTrend(ctx context.Context, trend *gtsmodel.Trend, columns ...string) error {
+	trend.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := t.db.
+		NewUpdate().
+		Model(trend).
+		Column(columns...).
+		Where("? = ?", bun.Ident("trend.id"), trend.ID).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) DeleteTrendByID(ctx context.Context, id string) error {
+	_, err := t.db.
+		NewDelete().
+		Table("trends").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) GetTagUsesSince(ctx context.Context, since time.Time) ([]*gtsmodel.TrendUse, error) {
+	// Status IDs are ULIDs generated from creation
+	// time, so use them to select only recent statuses.
+	sinceID, err := id.NewULIDFromTime(since)
+	if err != nil {
+		return nil, err
+	}
+
+	var uses []*gtsmodel.TrendUse
+
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Join(
+			"INNER JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		ColumnExpr("? AS ?", bun.Ident("status_to_tag.tag_id"), bun.Ident("target_id")).
+		ColumnExpr("? AS ?", bun.Ident("status.account_id"), bun.Ident("account_id")).
+		ColumnExpr("? AS ?", bun.Ident("status.created_at"), bun.Ident("created_at")).
+		Where("? > ?", bun.Ident("status.id"), sinceID).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Scan(ctx, &uses); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	return uses, nil
+}
+
+func (t *trendDB) GetLinkUsesSince(ctx context.Context, since time.Time) ([]*gtsmodel.TrendUse, error) {
+	// Status IDs are ULIDs generated from creation
+	// time, so use them to select only recent statuses.
+	sinceID, err := id.NewULIDFromTime(since)
+	if err != nil {
+		return nil, err
+	}
+
+	var uses []*gtsmodel.TrendUse
+
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("status.card_id"), bun.Ident("target_id")).
+		ColumnExpr("? AS ?", bun.Ident("status.account_id"), bun.Ident("account_id")).
+		ColumnExpr("? AS ?", bun.Ident("status.created_at"), bun.Ident("created_at")).
+		Where("? > ?", bun.Ident("status.id"), sinceID).
+		Where("? IS NOT NULL", bun.Ident("status.card_id")).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Scan(ctx, &uses); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	return uses, nil
+}
+
+func (t *trendDB) GetStatusInteractionCountsSince(ctx context.Context, since time.Time) (map[string]int, error) {
+	// Boost + fave IDs are ULIDs generated from creation
+	// time, so use them to select only recent interactions.
+	sinceID, err := id.NewULIDFromTime(since)
+	if err != nil {
+		return nil, err
+	}
+
+	type count struct {
+		StatusID string
+		Count    int
+	}
+
+	var boosts []count
+
+	// Count boosts of each status.
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("status.boost_of_id"), bun.Ident("status_id")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("? > ?", bun.Ident("status.id"), sinceID).
+		Where("? IS NOT NULL", bun.Ident("status.boost_of_id")).
+		Group("status.boost_of_id").
+		Scan(ctx, &boosts); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	var faves []count
+
+	// Count faves of each status.
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		ColumnExpr("? AS ?", bun.Ident("status_fave.status_id"), bun.Ident("status_id")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("? > ?", bun.Ident("status_fave.id"), sinceID).
+		Group("status_fave.status_id").
+		Scan(ctx, &faves); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(boosts)+len(faves))
+	for _, c := range boosts {
+		counts[c.StatusID] += c.Count
+	}
+	for _, c := range faves {
+		counts[c.StatusID] += c.Count
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,177 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type TrendTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *TrendTestSuite) TestPutGetUpdateDeleteTrend() {
+	ctx := context.Background()
+	testTag := suite.testTags["welcome"]
+
+	trend := &gtsmodel.Trend{
+		ID:       id.NewULID(),
+		Type:     gtsmodel.TrendTypeTag,
+		TargetID: testTag.ID,
+		Score:    4.5,
+		History: []gtsmodel.TrendHistory{
+			{Day: time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), Uses: 5, Accounts: 4},
+			{Day: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), Uses: 1, Accounts: 1},
+		},
+		Review: gtsmodel.TrendReviewPending,
+	}
+
+	if err := suite.db.PutTrend(ctx, trend); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Storing the same target twice should fail.
+	err := suite.db.PutTrend(ctx, &gtsmodel.Trend{
+		ID:       id.NewULID(),
+		Type:     gtsmodel.TrendTypeTag,
+		TargetID: testTag.ID,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	dbTrend, err := suite.db.GetTrendByID(ctx, trend.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testTag.ID, dbTrend.TargetID)
+	suite.Equal(testTag.ID, dbTrend.Tag.ID)
+	suite.Nil(dbTrend.Status)
+	suite.Equal(4.5, dbTrend.Score)
+	suite.Len(dbTrend.History, 2)
+	suite.Equal(4, dbTrend.History[0].Accounts)
+	suite.False(dbTrend.Trending())
+
+	// Approve the trend.
+	dbTrend.Review = gtsmodel.TrendReviewApproved
+	dbTrend.ReviewedAt = time.Now()
+	if err := suite.db.UpdateTrend(ctx, dbTrend, "review", "reviewed_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	trends, err := suite.db.GetTrendsByType(ctx, gtsmodel.TrendTypeTag)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(trends, 1)
+	suite.True(trends[0].Trending())
+
+	// No status trends stored yet.
+	trends, err = suite.db.GetTrendsByType(ctx, gtsmodel.TrendTypeStatus)
+	suite.NoError(err)
+	suite.Empty(trends)
+
+	if err := suite.db.DeleteTrendByID(ctx, trend.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetTrendByID(ctx, trend.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *TrendTestSuite) TestGetTagUsesSince() {
+	uses, err := suite.db.GetTagUsesSince(context.Background(), time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(uses)
+
+	for _, use := range uses {
+		suite.NotEmpty(use.TargetID)
+		suite.NotEmpty(use.AccountID)
+		suite.False(use.CreatedAt.IsZero())
+	}
+
+	// Nothing in the future.
+	uses, err = suite.db.GetTagUsesSince(context.Background(), time.Now().Add(time.Hour))
+	suite.NoError(err)
+	suite.Empty(uses)
+}
+
+func (suite *TrendTestSuite) TestGetLinkUsesSince() {
+	ctx := context.Background()
+
+	// Give a public status a preview card.
+	card := &gtsmodel.Card{
+		ID:   id.NewULID(),
+		URL:  "https://example.org/some/article",
+		Type: gtsmodel.CardTypeLink,
+	}
+	if err := suite.db.PutCard(ctx, card); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	status := new(gtsmodel.Status)
+	*status = *suite.testStatuses["local_account_1_status_1"]
+	status.CardID = card.ID
+	if err := suite.db.UpdateStatus(ctx, status, "card_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	uses, err := suite.db.GetLinkUsesSince(ctx, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(uses, 1) {
+		suite.Equal(card.ID, uses[0].TargetID)
+		suite.Equal(status.AccountID, uses[0].AccountID)
+		suite.False(uses[0].CreatedAt.IsZero())
+	}
+
+	// Nothing in the future.
+	uses, err = suite.db.GetLinkUsesSince(ctx, time.Now().Add(time.Hour))
+	suite.NoError(err)
+	suite.Empty(uses)
+}
+
+func (suite *TrendTestSuite) TestGetStatusInteractionCountsSince() {
+	counts, err := suite.db.GetStatusInteractionCountsSince(context.Background(), time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(counts)
+
+	// Every fave of a status should be counted.
+	for _, fave := range suite.testFaves {
+		suite.Positive(counts[fave.StatusID])
+	}
+
+	// Nothing in the future.
+	counts, err = suite.db.GetStatusInteractionCountsSince(context.Background(), time.Now().Add(time.Hour))
+	suite.NoError(err)
+	suite.Empty(counts)
+}
+
+func TestTrendTestSuite(t *testing.T) {
+	suite.Run(t, new(TrendTestSuite))
+}
@@ -20,12 +20,14 @@ package bundb
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/uptrace/bun"
 )
@@ -114,6 +116,17 @@ func (u *userDB) GetUserByConfirmationToken(ctx context.Context, token string) (
 	)
 }
 
+func (u *userDB) GetUserByEmailUnsubscribeToken(ctx context.Context, token string) (*gtsmodel.User, error) {
+	return u.getUser(
+		ctx,
+		"EmailUnsubscribeToken",
+		func(user *gtsmodel.User) error {
+			return u.db.NewSelect().Model(user).Where("? = ?", bun.Ident("email_unsubscribe_token"), token).Scan(ctx)
+		},
+		token,
+	)
+}
+
 func (u *userDB) getUser(ctx context.Context, lookup string, dbQuery func(*gtsmodel.User) error, keyParts ...any) (*gtsmodel.User, error) {
 	// Fetch user from database cache with loader callback.
 	user, err := u.state.Caches.GTS.User.LoadOne(lookup, func() (*gtsmodel.User, error) {
@@ -178,6 +191,109 @@ func (u *userDB) GetAllUsers(ctx context.Context) ([]*gtsmodel.User, error) {
 	return u.GetUsersByIDs(ctx, userIDs)
 }
 
+func (u *userDB) GetEmailDigestUsers(ctx context.Context) ([]*gtsmodel.User, error) {
+	var userIDs []string
+
+	// Scan IDs of users
+	// with a digest set.
+	if err := u.db.NewSelect().
+		Table("users").
+		Column("id").
+		Where("? IS NOT NULL", bun.Ident("email_digest")).
+		Order("id ASC").
+		Scan(ctx, &userIDs); err != nil {
+		return nil, err
+	}
+
+	// Transform user IDs into user slice.
+	return u.GetUsersByIDs(ctx, userIDs)
+}
+
+func (u *userDB) GetUsersByInviteID(ctx context.Context, inviteID string) ([]*gtsmodel.User, error) {
+	var userIDs []string
+
+	// Scan IDs of users who
+	// used invite into slice.
+	if err := u.db.NewSelect().
+		Table("users").
+		Column("id").
+		Where("? = ?", bun.Ident("invite_id"), inviteID).
+		Order("id ASC").
+		Scan(ctx, &userIDs); err != nil {
+		return nil, err
+	}
+
+	// Transform user IDs into user slice.
+	return u.GetUsersByIDs(ctx, userIDs)
+}
+
+func (u *userDB) GetPendingUsers(ctx context.Context, page *paging.Page) ([]*gtsmodel.User, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		accountIDs = make([]string, 0, limit)
+	)
+
+	q := u.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Column("user.account_id").
+		Where("? = ?", bun.Ident("user.approved"), false)
+
+	if maxID != "" {
+		// Return only users with account ID LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("user.account_id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only users with account ID HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("user.account_id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of users returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("user.account_id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("user.account_id"))
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	if len(accountIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want users
+	// to be sorted by account ID desc, so reverse.
+	if order == paging.OrderAscending {
+		slices.Reverse(accountIDs)
+	}
+
+	users := make([]*gtsmodel.User, 0, len(accountIDs))
+	for _, accountID := range accountIDs {
+		user, err := u.GetUserByAccountID(ctx, accountID)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
 func (u *userDB) PutUser(ctx context.Context, user *gtsmodel.User) error {
 	return u.state.Caches.GTS.User.Store(user, func() error {
 		_, err := u.db.
@@ -208,6 +324,47 @@ func (u *userDB) UpdateUser(ctx context.Context, user *gtsmodel.User, columns ..
 	})
 }
 
+func (u *userDB) UpdateUserTwoFactorCounter(ctx context.Context, user *gtsmodel.User, counter int64) error {
+	now := time.Now()
+
+	// Only update the counter if it's
+	// greater than the stored one; this
+	// check and set happens atomically.
+	res, err := u.db.
+		NewUpdate().
+		Table("users").
+		Set("? = ?", bun.Ident("two_factor_last_counter"), counter).
+		Set("? = ?", bun.Ident("updated_at"), now).
+		Where("? = ?", bun.Ident("id"), user.ID).
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("two_factor_last_counter")).
+				WhereOr("? < ?", bun.Ident("two_factor_last_counter"), counter)
+		}).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		// Counter already
+		// at or past this.
+		return db.ErrNoEntries
+	}
+
+	// Drop the now stale cached user.
+	u.state.Caches.GTS.User.Invalidate("ID", user.ID)
+
+	user.TwoFactorLastCounter = counter
+	user.UpdatedAt = now
+	return nil
+}
+
 func (u *userDB) DeleteUserByID(ctx context.Context, userID string) error {
 	defer u.state.Caches.GTS.User.Invalidate("ID", userID)
 
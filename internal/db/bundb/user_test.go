@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
@@ -35,6 +36,14 @@ func (suite *UserTestSuite) TestGetAllUsers() {
 	suite.Len(users, len(suite.testUsers))
 }
 
+func (suite *UserTestSuite) TestGetPendingUsers() {
+	users, err := suite.db.GetPendingUsers(context.Background(), nil)
+	suite.NoError(err)
+	suite.Len(users, 1)
+	suite.Equal(suite.testUsers["unconfirmed_account"].ID, users[0].ID)
+	suite.NotNil(users[0].Account)
+}
+
 func (suite *UserTestSuite) TestGetUser() {
 	user, err := suite.db.GetUserByID(context.Background(), suite.testUsers["local_account_1"].ID)
 	suite.NoError(err)
@@ -53,6 +62,34 @@ func (suite *UserTestSuite) TestGetUserByAccountID() {
 	suite.NotNil(user)
 }
 
+func (suite *UserTestSuite) TestUpdateUserTwoFactorCounter() {
+	ctx := context.Background()
+
+	// Load two copies of the same user,
+	// as two concurrent sign ins would.
+	user1, err := suite.db.GetUserByID(ctx, suite.testUsers["local_account_1"].ID)
+	suite.NoError(err)
+	user2 := new(gtsmodel.User)
+	*user2 = *user1
+
+	// First one to set the counter wins.
+	suite.NoError(suite.db.UpdateUserTwoFactorCounter(ctx, user1, 1000))
+	suite.EqualValues(1000, user1.TwoFactorLastCounter)
+
+	// Second one with the same (or
+	// a lower) counter is rejected.
+	suite.ErrorIs(suite.db.UpdateUserTwoFactorCounter(ctx, user2, 1000), db.ErrNoEntries)
+	suite.ErrorIs(suite.db.UpdateUserTwoFactorCounter(ctx, user2, 999), db.ErrNoEntries)
+	suite.Zero(user2.TwoFactorLastCounter)
+
+	// A greater counter is fine.
+	suite.NoError(suite.db.UpdateUserTwoFactorCounter(ctx, user2, 1001))
+
+	dbUser, err := suite.db.GetUserByID(ctx, user1.ID)
+	suite.NoError(err)
+	suite.EqualValues(1001, dbUser.TwoFactorLastCounter)
+}
+
 func (suite *UserTestSuite) TestUpdateUserSelectedColumns() {
 	testUser := suite.testUsers["local_account_1"]
 
@@ -0,0 +1,101 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type WebAuthnTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *WebAuthnTestSuite) TestWebAuthnCredentials() {
+	var (
+		ctx  = context.Background()
+		user = suite.testUsers["local_account_1"]
+	)
+
+	// No credentials to begin with.
+	credentials, err := suite.db.GetWebAuthnCredentialsByUserID(ctx, user.ID)
+	suite.NoError(err)
+	suite.Empty(credentials)
+
+	credential := &gtsmodel.WebAuthnCredential{
+		ID:           "01HTQ3K2ZS8V5Y4W9B1XGJ6N0C",
+		UserID:       user.ID,
+		Name:         "yubikey",
+		CredentialID: "Y3JlZGVudGlhbA",
+		PublicKey:    []byte{0xa5, 0x01, 0x02},
+		SignCount:    3,
+	}
+	if err := suite.db.PutWebAuthnCredential(ctx, credential); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Credential IDs are unique.
+	err = suite.db.PutWebAuthnCredential(ctx, &gtsmodel.WebAuthnCredential{
+		ID:           "01HTQ3K2ZS8V5Y4W9B1XGJ6N0D",
+		UserID:       user.ID,
+		Name:         "another yubikey",
+		CredentialID: credential.CredentialID,
+		PublicKey:    []byte{0xa5, 0x01, 0x02},
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	dbCredential, err := suite.db.GetWebAuthnCredentialByCredentialID(ctx, credential.CredentialID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(credential.ID, dbCredential.ID)
+	suite.Equal(credential.PublicKey, dbCredential.PublicKey)
+	suite.EqualValues(3, dbCredential.SignCount)
+
+	dbCredential.Name = "work yubikey"
+	dbCredential.SignCount = 4
+	if err := suite.db.UpdateWebAuthnCredential(ctx, dbCredential, "name", "sign_count"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbCredential, err = suite.db.GetWebAuthnCredentialByID(ctx, credential.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("work yubikey", dbCredential.Name)
+	suite.EqualValues(4, dbCredential.SignCount)
+
+	credentials, err = suite.db.GetWebAuthnCredentialsByUserID(ctx, user.ID)
+	suite.NoError(err)
+	suite.Len(credentials, 1)
+
+	if err := suite.db.DeleteWebAuthnCredentialsByUserID(ctx, user.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetWebAuthnCredentialByID(ctx, credential.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestWebAuthnTestSuite(t *testing.T) {
+	suite.Run(t, new(WebAuthnTestSuite))
+}
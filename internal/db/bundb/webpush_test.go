@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type WebPushTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *WebPushTestSuite) TestVAPIDKeyPair() {
+	ctx := context.Background()
+
+	// No key pair to begin with.
+	_, err := suite.db.GetVAPIDKeyPair(ctx)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	keyPair := &gtsmodel.VAPIDKeyPair{
+		ID:      "01HQ5Y6VBZ2K6J8FSXK3Q4A0E1",
+		Public:  "public",
+		Private: "private",
+	}
+	if err := suite.db.PutVAPIDKeyPair(ctx, keyPair); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbKeyPair, err := suite.db.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(keyPair.ID, dbKeyPair.ID)
+	suite.Equal(keyPair.Public, dbKeyPair.Public)
+	suite.Equal(keyPair.Private, dbKeyPair.Private)
+}
+
+func (suite *WebPushTestSuite) TestWebPushSubscriptions() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		token   = suite.testTokens["local_account_1"]
+	)
+
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:          "01HQ5Y6VBZ2K6J8FSXK3Q4A0E2",
+		AccountID:   account.ID,
+		TokenID:     token.ID,
+		Endpoint:    "https://push.example.org/send/abcdef",
+		Auth:        "auth",
+		P256dh:      "p256dh",
+		AlertFollow: util.Ptr(true),
+		Policy:      gtsmodel.WebPushSubscriptionPolicyAll,
+	}
+	if err := suite.db.PutWebPushSubscription(ctx, subscription); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbSubscription, err := suite.db.GetWebPushSubscriptionByTokenID(ctx, token.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(subscription.ID, dbSubscription.ID)
+	suite.True(dbSubscription.AlertEnabled(gtsmodel.NotificationFollow))
+	suite.False(dbSubscription.AlertEnabled(gtsmodel.NotificationMention))
+
+	// Update alerts and policy.
+	dbSubscription.AlertMention = util.Ptr(true)
+	dbSubscription.Policy = gtsmodel.WebPushSubscriptionPolicyFollowed
+	if err := suite.db.UpdateWebPushSubscription(ctx, dbSubscription, "alert_mention", "policy"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	subscriptions, err := suite.db.GetWebPushSubscriptionsByAccountID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(subscriptions, 1)
+	suite.True(subscriptions[0].AlertEnabled(gtsmodel.NotificationMention))
+	suite.Equal(gtsmodel.WebPushSubscriptionPolicyFollowed, subscriptions[0].Policy)
+
+	// Delete by token.
+	if err := suite.db.DeleteWebPushSubscriptionByTokenID(ctx, token.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetWebPushSubscriptionByTokenID(ctx, token.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestWebPushTestSuite(t *testing.T) {
+	suite.Run(t, new(WebPushTestSuite))
+}
@@ -0,0 +1,53 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// ContentWarningPolicy contains functions for getting/creating/deleting content warning policies in the database.
+type ContentWarningPolicy interface {
+	// GetContentWarningPolicyByID gets one content warning policy with the given id.
+	GetContentWarningPolicyByID(ctx context.Context, id string) (*gtsmodel.ContentWarningPolicy, error)
+
+	// GetContentWarningPolicyByAccountID gets the content warning policy targeting the given accountID, if it exists.
+	GetContentWarningPolicyByAccountID(ctx context.Context, accountID string) (*gtsmodel.ContentWarningPolicy, error)
+
+	// GetContentWarningPolicyByDomain gets the content warning policy targeting the given domain, if it exists.
+	GetContentWarningPolicyByDomain(ctx context.Context, domain string) (*gtsmodel.ContentWarningPolicy, error)
+
+	// GetContentWarningPolicies gets all content warning policies, newest first.
+	GetContentWarningPolicies(ctx context.Context) ([]*gtsmodel.ContentWarningPolicy, error)
+
+	// GetContentWarningPolicyForAccount gets the content warning policy that applies to statuses authored by the
+	// given account. A policy targeting the account itself takes precedence over one targeting its domain.
+	// Returns ErrNoEntries if no policy applies to the account.
+	GetContentWarningPolicyForAccount(ctx context.Context, account *gtsmodel.Account) (*gtsmodel.ContentWarningPolicy, error)
+
+	// PutContentWarningPolicy inserts the given content warning policy in the database.
+	PutContentWarningPolicy(ctx context.Context, policy *gtsmodel.ContentWarningPolicy) error
+
+	// DeleteContentWarningPolicyByID deletes one content warning policy with the given id.
+	DeleteContentWarningPolicyByID(ctx context.Context, id string) error
+
+	// DeleteContentWarningPolicyByAccountID deletes the content warning policy targeting the given accountID, if it exists.
+	DeleteContentWarningPolicyByAccountID(ctx context.Context, accountID string) error
+}
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// Conversation contains functions for getting, creating,
+// updating, and deleting direct message conversations.
+type Conversation interface {
+	// GetConversationByID gets one conversation with the given id.
+	GetConversationByID(ctx context.Context, id string) (*gtsmodel.Conversation, error)
+
+	// GetConversationByThreadAndAccountIDs gets the conversation owned by the
+	// given account, in the given thread, with the given other participants.
+	GetConversationByThreadAndAccountIDs(ctx context.Context, accountID string, threadID string, otherAccountIDs []string) (*gtsmodel.Conversation, error)
+
+	// GetConversationsByOwnerAccountID returns a page of conversations owned
+	// by the given account, newest last status first. Paging is done by
+	// last status ID, rather than by the ID of the conversation itself.
+	GetConversationsByOwnerAccountID(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Conversation, error)
+
+	// GetConversationsByStatusID returns all
+	// conversations which contain the given status.
+	GetConversationsByStatusID(ctx context.Context, statusID string) ([]*gtsmodel.Conversation, error)
+
+	// GetConversationLatestStatusID returns the ID of the newest status
+	// in the given conversation, or db.ErrNoEntries if it has none.
+	GetConversationLatestStatusID(ctx context.Context, conversationID string) (string, error)
+
+	// PopulateConversation ensures that the Account, OtherAccounts,
+	// and LastStatus fields are set on the given conversation.
+	PopulateConversation(ctx context.Context, conversation *gtsmodel.Conversation) error
+
+	// PutConversation inserts the given conversation into the database.
+	PutConversation(ctx context.Context, conversation *gtsmodel.Conversation) error
+
+	// UpdateConversation updates the given conversation.
+	// Columns is optional, if not specified all will be updated.
+	UpdateConversation(ctx context.Context, conversation *gtsmodel.Conversation, columns ...string) error
+
+	// AddStatusToConversation marks the given status as belonging
+	// to the given conversation. It is a no-op if already added.
+	AddStatusToConversation(ctx context.Context, conversationID string, statusID string) error
+
+	// DeleteStatusFromConversations removes the
+	// given status from all conversations.
+	DeleteStatusFromConversations(ctx context.Context, statusID string) error
+
+	// DeleteConversationByID deletes one conversation with the given id.
+	DeleteConversationByID(ctx context.Context, id string) error
+
+	// DeleteConversationsByOwnerAccountID deletes
+	// all conversations owned by the given account.
+	DeleteConversationsByOwnerAccountID(ctx context.Context, accountID string) error
+}
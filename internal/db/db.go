@@ -28,10 +28,17 @@ type DB interface {
 	Admin
 	Application
 	Basic
+	Card
+	ContentWarningPolicy
+	Conversation
+	Delivery
 	Domain
+	EmailDomainBlock
 	Emoji
+	Filter
 	HeaderFilter
 	Instance
+	Invite
 	List
 	Marker
 	Media
@@ -41,14 +48,20 @@ type DB interface {
 	Relationship
 	Report
 	Rule
+	ScheduledStatus
 	Search
 	Session
 	Status
 	StatusBookmark
+	StatusEdit
 	StatusFave
+	Suggestion
 	Tag
 	Thread
 	Timeline
+	Trend
 	User
 	Tombstone
+	WebAuthn
+	WebPush
 }
@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Delivery contains functions for getting, storing
+// and removing queued outgoing federation deliveries.
+type Delivery interface {
+	// GetDeliveryByID gets one queued delivery with the given id.
+	GetDeliveryByID(ctx context.Context, id string) (*gtsmodel.Delivery, error)
+
+	// GetDueDeliveries returns up to limit queued deliveries due to be
+	// attempted at or before the given time, with ID greater than maxID,
+	// ordered by ID ascending. This allows paging through all due deliveries.
+	GetDueDeliveries(ctx context.Context, now time.Time, maxID string, limit int) ([]*gtsmodel.Delivery, error)
+
+	// GetDeliveriesForDomain returns up to limit queued deliveries
+	// targeting the given domain, ordered by next attempt time.
+	GetDeliveriesForDomain(ctx context.Context, domain string, limit int) ([]*gtsmodel.Delivery, error)
+
+	// CountDeliveriesPerDomain returns the number of
+	// queued deliveries for each targeted domain.
+	CountDeliveriesPerDomain(ctx context.Context) (map[string]int, error)
+
+	// PutDeliveries inserts the given queued deliveries into the database.
+	PutDeliveries(ctx context.Context, deliveries ...*gtsmodel.Delivery) error
+
+	// UpdateDelivery updates the given queued delivery.
+	// Columns is optional, if not specified all will be updated.
+	UpdateDelivery(ctx context.Context, delivery *gtsmodel.Delivery, columns ...string) error
+
+	// SetDeliveriesDueForDomain sets the next attempt time of all
+	// queued deliveries targeting the given domain to the given time,
+	// returning the number of deliveries affected.
+	SetDeliveriesDueForDomain(ctx context.Context, domain string, at time.Time) (int, error)
+
+	// DeleteDeliveryByID deletes one queued delivery with the given id.
+	DeleteDeliveryByID(ctx context.Context, id string) error
+
+	// DeleteDeliveriesForDomain deletes all queued deliveries targeting
+	// the given domain, returning the number of deliveries deleted.
+	DeleteDeliveriesForDomain(ctx context.Context, domain string) (int, error)
+}
@@ -42,6 +42,9 @@ type Domain interface {
 	// GetDomainAllows returns all instance-level domain allows currently enforced by this instance.
 	GetDomainAllows(ctx context.Context) ([]*gtsmodel.DomainAllow, error)
 
+	// UpdateDomainAllow updates the given domain allow, setting the provided columns (empty for all).
+	UpdateDomainAllow(ctx context.Context, allow *gtsmodel.DomainAllow, columns ...string) error
+
 	// DeleteDomainAllow deletes an instance-level domain allow with the given domain, if it exists.
 	DeleteDomainAllow(ctx context.Context, domain string) error
 
@@ -57,9 +60,33 @@ type Domain interface {
 	// GetDomainBlocks returns all instance-level domain blocks currently enforced by this instance.
 	GetDomainBlocks(ctx context.Context) ([]*gtsmodel.DomainBlock, error)
 
+	// UpdateDomainBlock updates the given domain block, setting the provided columns (empty for all).
+	UpdateDomainBlock(ctx context.Context, block *gtsmodel.DomainBlock, columns ...string) error
+
 	// DeleteDomainBlock deletes an instance-level domain block with the given domain, if it exists.
 	DeleteDomainBlock(ctx context.Context, domain string) error
 
+	/*
+		Domain permission subscription functions.
+	*/
+
+	// GetDomainPermissionSubscriptionByID gets one DomainPermissionSubscription with the given ID.
+	GetDomainPermissionSubscriptionByID(ctx context.Context, id string) (*gtsmodel.DomainPermissionSubscription, error)
+
+	// GetDomainPermissionSubscriptions returns all DomainPermissionSubscriptions of the
+	// given permission type (or of all types, if unknown), ordered by descending priority.
+	GetDomainPermissionSubscriptions(ctx context.Context, permType gtsmodel.DomainPermissionType) ([]*gtsmodel.DomainPermissionSubscription, error)
+
+	// PutDomainPermissionSubscription stores one DomainPermissionSubscription.
+	PutDomainPermissionSubscription(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription) error
+
+	// UpdateDomainPermissionSubscription updates the provided
+	// columns of one DomainPermissionSubscription (empty for all).
+	UpdateDomainPermissionSubscription(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription, columns ...string) error
+
+	// DeleteDomainPermissionSubscription deletes one DomainPermissionSubscription with the given id.
+	DeleteDomainPermissionSubscription(ctx context.Context, id string) error
+
 	/*
 		Block/allow checking functions.
 	*/
@@ -78,4 +105,16 @@ type Domain interface {
 	// AreURIsBlocked calls IsURIBlocked for each URI.
 	// Will return true if even one of the given URIs is blocked.
 	AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, error)
+
+	// IsDomainSilenced checks if domain is limited by a domain block with "silence"
+	// severity. In blocklist mode, an explicit allow for the domain takes precedence.
+	IsDomainSilenced(ctx context.Context, domain string) (bool, error)
+
+	// IsDomainMediaRejected checks if domain has a domain block with reject_media set.
+	// In blocklist mode, an explicit allow for the domain takes precedence.
+	IsDomainMediaRejected(ctx context.Context, domain string) (bool, error)
+
+	// IsDomainReportsRejected checks if domain has a domain block with reject_reports set.
+	// In blocklist mode, an explicit allow for the domain takes precedence.
+	IsDomainReportsRejected(ctx context.Context, domain string) (bool, error)
 }
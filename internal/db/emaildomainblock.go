@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// EmailDomainBlock contains functions for getting/creating/deleting email domain blocks in the database.
+type EmailDomainBlock interface {
+	// GetEmailDomainBlockByID gets one email domain block with the given id.
+	GetEmailDomainBlockByID(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, error)
+
+	// GetEmailDomainBlock gets the email domain block of exactly the given domain, if it exists.
+	GetEmailDomainBlock(ctx context.Context, domain string) (*gtsmodel.EmailDomainBlock, error)
+
+	// GetEmailDomainBlocks gets all email domain blocks, newest first.
+	GetEmailDomainBlocks(ctx context.Context) ([]*gtsmodel.EmailDomainBlock, error)
+
+	// PutEmailDomainBlock inserts the given email domain block in the database.
+	PutEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) error
+
+	// DeleteEmailDomainBlockByID deletes one email domain block with the given id.
+	DeleteEmailDomainBlockByID(ctx context.Context, id string) error
+}
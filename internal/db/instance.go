@@ -58,4 +58,8 @@ type Instance interface {
 	// GetInstanceModeratorAddresses returns a slice of email addresses belonging to active
 	// (as in, not suspended) moderators + admins on this instance.
 	GetInstanceModeratorAddresses(ctx context.Context) ([]string, error)
+
+	// GetInstanceModerators returns a slice of users belonging to active
+	// (as in, approved and not disabled) moderators + admins on this instance.
+	GetInstanceModerators(ctx context.Context) ([]*gtsmodel.User, error)
 }
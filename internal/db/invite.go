@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// Invite contains functions for getting, creating,
+// and using invite codes for registering new accounts.
+type Invite interface {
+	// GetInviteByID gets one invite with the given id.
+	GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error)
+
+	// GetInviteByCode gets one invite with the given code.
+	GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error)
+
+	// GetInvites returns a page of invites, newest first. If accountID
+	// is set, only invites created by that account will be returned.
+	GetInvites(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Invite, error)
+
+	// PopulateInvite ensures that the Account field is set on the given invite.
+	PopulateInvite(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// PutInvite inserts the given invite into the database.
+	PutInvite(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// UpdateInvite updates the given invite.
+	// Columns is optional, if not specified all will be updated.
+	UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error
+
+	// IncrementInviteUses atomically increments the uses count of
+	// the given invite, so long as it has not already reached its
+	// maximum number of uses. Returns ErrNoEntries if it has.
+	IncrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// DeleteInviteByID deletes one invite with the given id.
+	DeleteInviteByID(ctx context.Context, id string) error
+}
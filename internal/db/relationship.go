@@ -56,6 +56,37 @@ type Relationship interface {
 	// DeleteAccountBlocks will delete all database blocks to / from the given account ID.
 	DeleteAccountBlocks(ctx context.Context, accountID string) error
 
+	// GetMuteByID fetches mute with given ID from the database.
+	GetMuteByID(ctx context.Context, id string) (*gtsmodel.UserMute, error)
+
+	// GetMute returns the mute from account1 targeting account2, if it exists, or an error if it doesn't.
+	// Note that the returned mute may have expired, check this with UserMute.Expired().
+	GetMute(ctx context.Context, account1 string, account2 string) (*gtsmodel.UserMute, error)
+
+	// GetMutesByIDs fetches mutes with given IDs from the database.
+	GetMutesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.UserMute, error)
+
+	// GetAccountMutes returns all mutes originating from the given account, with given optional paging parameters.
+	GetAccountMutes(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.UserMute, error)
+
+	// GetExpiringMutes returns all mutes in the database which have an expiry time set.
+	GetExpiringMutes(ctx context.Context) ([]*gtsmodel.UserMute, error)
+
+	// PopulateMute populates the struct pointers on the given mute.
+	PopulateMute(ctx context.Context, mute *gtsmodel.UserMute) error
+
+	// PutMute attempts to place the given account mute in the database.
+	PutMute(ctx context.Context, mute *gtsmodel.UserMute) error
+
+	// UpdateMute updates one mute by ID.
+	UpdateMute(ctx context.Context, mute *gtsmodel.UserMute, columns ...string) error
+
+	// DeleteMuteByID removes mute with given ID from the database.
+	DeleteMuteByID(ctx context.Context, id string) error
+
+	// DeleteAccountMutes will delete all database mutes to / from the given account ID.
+	DeleteAccountMutes(ctx context.Context, accountID string) error
+
 	// GetRelationship retrieves the relationship of the targetAccount to the requestingAccount.
 	GetRelationship(ctx context.Context, requestingAccount string, targetAccount string) (*gtsmodel.Relationship, error)
 
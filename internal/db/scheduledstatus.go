@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// ScheduledStatus contains functions for getting, creating,
+// and deleting statuses which are scheduled to be published.
+type ScheduledStatus interface {
+	// GetScheduledStatusByID gets one scheduled status with the given id.
+	GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error)
+
+	// GetScheduledStatusesForAccount returns a page of scheduled
+	// statuses created by the given account, newest first.
+	GetScheduledStatusesForAccount(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.ScheduledStatus, error)
+
+	// GetAllScheduledStatuses returns all scheduled statuses in the
+	// database, for (re)adding them to the scheduler on startup.
+	GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error)
+
+	// CountScheduledStatusesForAccount counts the
+	// scheduled statuses created by the given account.
+	CountScheduledStatusesForAccount(ctx context.Context, accountID string) (int, error)
+
+	// PopulateScheduledStatus ensures that the Account, MediaAttachments,
+	// and Application fields are set on the given scheduled status.
+	PopulateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// PutScheduledStatus inserts the given scheduled status into the database.
+	PutScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// UpdateScheduledStatus updates the given scheduled status.
+	// Columns is optional, if not specified all will be updated.
+	UpdateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus, columns ...string) error
+
+	// DeleteScheduledStatusByID deletes one scheduled status with the given id.
+	DeleteScheduledStatusByID(ctx context.Context, id string) error
+}
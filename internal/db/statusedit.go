@@ -0,0 +1,38 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusEdit interface {
+	// GetStatusEditByID gets one status edit with the given ID.
+	GetStatusEditByID(ctx context.Context, id string) (*gtsmodel.StatusEdit, error)
+
+	// GetStatusEditsByIDs gets status edits with the given IDs, in the order of the given IDs.
+	GetStatusEditsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.StatusEdit, error)
+
+	// PopulateStatusEdit ensures that the Attachments field is set on the given status edit.
+	PopulateStatusEdit(ctx context.Context, edit *gtsmodel.StatusEdit) error
+
+	// PutStatusEdit inserts the given status edit into the database.
+	PutStatusEdit(ctx context.Context, edit *gtsmodel.StatusEdit) error
+}
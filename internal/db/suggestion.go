@@ -0,0 +1,53 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Suggestion contains functions for getting/creating follow suggestions in the database.
+type Suggestion interface {
+	// GetPinnedSuggestions gets all admin-pinned suggestions, with target accounts populated, newest first.
+	GetPinnedSuggestions(ctx context.Context) ([]*gtsmodel.PinnedSuggestion, error)
+
+	// GetPinnedSuggestionByTargetAccountID gets the admin-pinned suggestion of the given target account, if it exists.
+	GetPinnedSuggestionByTargetAccountID(ctx context.Context, targetAccountID string) (*gtsmodel.PinnedSuggestion, error)
+
+	// PutPinnedSuggestion inserts the given pinned suggestion in the database.
+	PutPinnedSuggestion(ctx context.Context, pinnedSuggestion *gtsmodel.PinnedSuggestion) error
+
+	// DeletePinnedSuggestionByTargetAccountID deletes the admin-pinned suggestion of the given target account, if it exists.
+	DeletePinnedSuggestionByTargetAccountID(ctx context.Context, targetAccountID string) error
+
+	// GetDismissedSuggestionTargetAccountIDs gets the IDs of all accounts whose suggestions were dismissed by the given accountID.
+	GetDismissedSuggestionTargetAccountIDs(ctx context.Context, accountID string) ([]string, error)
+
+	// PutDismissedSuggestion inserts the given dismissed suggestion in the database.
+	PutDismissedSuggestion(ctx context.Context, dismissedSuggestion *gtsmodel.DismissedSuggestion) error
+
+	// GetFriendsOfFriendsAccountIDs gets up to limit IDs of accounts followed by accounts that the given
+	// accountID follows, most commonly followed first. Accounts already followed or follow-requested by
+	// accountID, and accounts whose suggestion accountID has dismissed, are excluded.
+	GetFriendsOfFriendsAccountIDs(ctx context.Context, accountID string, limit int) ([]string, error)
+
+	// DeleteSuggestionsByAccountID deletes all pinned and dismissed suggestions to / from the given accountID.
+	DeleteSuggestionsByAccountID(ctx context.Context, accountID string) error
+}
@@ -19,8 +19,10 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Tag contains functions for getting/creating tags in the database.
@@ -36,4 +38,57 @@ type Tag interface {
 
 	// GetTags gets multiple tags.
 	GetTags(ctx context.Context, ids []string) ([]*gtsmodel.Tag, error)
+
+	// GetFollowedTag gets the followed tag from accountID to tagID, if it exists.
+	GetFollowedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FollowedTag, error)
+
+	// GetFollowedTags gets a page of followed tags owned by the given accountID, with tags populated.
+	GetFollowedTags(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.FollowedTag, error)
+
+	// GetFollowedTagIDs gets the IDs of all tags followed by the given accountID.
+	GetFollowedTagIDs(ctx context.Context, accountID string) ([]string, error)
+
+	// GetAccountIDsFollowingTagIDs gets the IDs of all accounts following any of the given tagIDs.
+	GetAccountIDsFollowingTagIDs(ctx context.Context, tagIDs []string) ([]string, error)
+
+	// PutFollowedTag inserts the given followed tag in the database.
+	PutFollowedTag(ctx context.Context, followedTag *gtsmodel.FollowedTag) error
+
+	// DeleteFollowedTag deletes the followed tag from accountID to tagID, if it exists.
+	DeleteFollowedTag(ctx context.Context, accountID string, tagID string) error
+
+	// DeleteFollowedTagsByAccountID deletes all followed tags owned by the given accountID.
+	DeleteFollowedTagsByAccountID(ctx context.Context, accountID string) error
+
+	// GetFeaturedTagByID gets the featured tag with the given ID, with tag populated.
+	GetFeaturedTagByID(ctx context.Context, id string) (*gtsmodel.FeaturedTag, error)
+
+	// GetFeaturedTag gets the featured tag from accountID to tagID, if it exists.
+	GetFeaturedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FeaturedTag, error)
+
+	// GetFeaturedTagsByAccountID gets all tags featured by the given
+	// accountID, with tags populated, most used tags first.
+	GetFeaturedTagsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, error)
+
+	// PutFeaturedTag inserts the given featured tag in the database.
+	PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error
+
+	// UpdateFeaturedTag updates the given featured tag in the database,
+	// only updating the given columns (or all columns if none given).
+	UpdateFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag, columns ...string) error
+
+	// DeleteFeaturedTagByID deletes the featured tag with the given ID.
+	DeleteFeaturedTagByID(ctx context.Context, id string) error
+
+	// DeleteFeaturedTagsByAccountID deletes all featured tags owned by the given accountID.
+	DeleteFeaturedTagsByAccountID(ctx context.Context, accountID string) error
+
+	// GetAccountTagUsage returns the number of public or unlisted statuses
+	// authored by accountID which use tagID, and when the latest of them
+	// was created (zero time if none). Boosts are not counted.
+	GetAccountTagUsage(ctx context.Context, accountID string, tagID string) (int, time.Time, error)
+
+	// GetAccountMostUsedTagIDs gets the IDs of up to limit tags most
+	// used in public or unlisted statuses authored by accountID.
+	GetAccountMostUsedTagIDs(ctx context.Context, accountID string, limit int) ([]string, error)
 }
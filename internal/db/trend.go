@@ -0,0 +1,60 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Trend contains functions for getting, creating, and
+// calculating trends of hashtags, statuses and links.
+type Trend interface {
+	// GetTrendByID gets one trend with the given ID.
+	GetTrendByID(ctx context.Context, id string) (*gtsmodel.Trend, error)
+
+	// GetTrendsByType gets all trends of the given type, ordered by score descending.
+	GetTrendsByType(ctx context.Context, trendType gtsmodel.TrendType) ([]*gtsmodel.Trend, error)
+
+	// PopulateTrend populates the struct pointers on the given trend.
+	PopulateTrend(ctx context.Context, trend *gtsmodel.Trend) error
+
+	// PutTrend puts the given trend in the database.
+	PutTrend(ctx context.Context, trend *gtsmodel.Trend) error
+
+	// UpdateTrend updates the given trend in the database,
+	// optionally limited to the given columns.
+	UpdateTrend(ctx context.Context, trend *gtsmodel.Trend, columns ...string) error
+
+	// DeleteTrendByID deletes one trend with the given ID.
+	DeleteTrendByID(ctx context.Context, id string) error
+
+	// GetTagUsesSince returns one entry for each use of a hashtag
+	// in a public, non-boost status created after the given time.
+	GetTagUsesSince(ctx context.Context, since time.Time) ([]*gtsmodel.TrendUse, error)
+
+	// GetLinkUsesSince returns one entry for each share of a link, by its
+	// preview card, in a public, non-boost status created after the given time.
+	GetLinkUsesSince(ctx context.Context, since time.Time) ([]*gtsmodel.TrendUse, error)
+
+	// GetStatusInteractionCountsSince returns the number of boosts
+	// + faves created after the given time, keyed by status ID.
+	GetStatusInteractionCountsSince(ctx context.Context, since time.Time) (map[string]int, error)
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // User contains functions related to user getting/setting/creation.
@@ -28,6 +29,14 @@ type User interface {
 	// GetAllUsers returns all local user accounts, or an error if something goes wrong.
 	GetAllUsers(ctx context.Context) ([]*gtsmodel.User, error)
 
+	// GetUsersByInviteID returns all users who
+	// registered using the invite with the given ID.
+	GetUsersByInviteID(ctx context.Context, inviteID string) ([]*gtsmodel.User, error)
+
+	// GetPendingUsers returns a page of local users whose sign-up has not yet been
+	// approved by a moderator. Users are paged by their account ID, newest first.
+	GetPendingUsers(ctx context.Context, page *paging.Page) ([]*gtsmodel.User, error)
+
 	// GetUserByID returns one user with the given ID, or an error if something goes wrong.
 	GetUserByID(ctx context.Context, id string) (*gtsmodel.User, error)
 
@@ -43,6 +52,13 @@ type User interface {
 	// GetUserByConfirmationToken returns one user by its confirmation token, or an error if something goes wrong.
 	GetUserByConfirmationToken(ctx context.Context, confirmationToken string) (*gtsmodel.User, error)
 
+	// GetUserByEmailUnsubscribeToken returns one user by the unsubscribe token included in their notification emails, or an error if something goes wrong.
+	GetUserByEmailUnsubscribeToken(ctx context.Context, token string) (*gtsmodel.User, error)
+
+	// GetEmailDigestUsers returns all local users who are
+	// emailed a digest of their unread notifications.
+	GetEmailDigestUsers(ctx context.Context) ([]*gtsmodel.User, error)
+
 	// PopulateUser populates the struct pointers on the given user.
 	PopulateUser(ctx context.Context, user *gtsmodel.User) error
 
@@ -52,6 +68,12 @@ type User interface {
 	// UpdateUser updates one user by its primary key, updating either only the specified columns, or all of them.
 	UpdateUser(ctx context.Context, user *gtsmodel.User, columns ...string) error
 
+	// UpdateUserTwoFactorCounter sets the TOTP time step of the last two-factor
+	// code accepted for the given user to counter, but only if it's greater than
+	// the one currently stored, so that a code can't be accepted twice, even by
+	// concurrent sign ins. If it isn't greater, db.ErrNoEntries is returned.
+	UpdateUserTwoFactorCounter(ctx context.Context, user *gtsmodel.User, counter int64) error
+
 	// DeleteUserByID deletes one user by its ID.
 	DeleteUserByID(ctx context.Context, userID string) error
 }
@@ -0,0 +1,53 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// WebAuthn contains functions for getting, creating,
+// updating, and deleting users' WebAuthn credentials.
+type WebAuthn interface {
+	// GetWebAuthnCredentialByID gets one credential with the given id.
+	GetWebAuthnCredentialByID(ctx context.Context, id string) (*gtsmodel.WebAuthnCredential, error)
+
+	// GetWebAuthnCredentialByCredentialID gets one credential
+	// with the given base64url encoded WebAuthn credential ID.
+	GetWebAuthnCredentialByCredentialID(ctx context.Context, credentialID string) (*gtsmodel.WebAuthnCredential, error)
+
+	// GetWebAuthnCredentialsByUserID gets all
+	// credentials registered by the given user.
+	GetWebAuthnCredentialsByUserID(ctx context.Context, userID string) ([]*gtsmodel.WebAuthnCredential, error)
+
+	// PutWebAuthnCredential inserts the given credential into the database.
+	PutWebAuthnCredential(ctx context.Context, credential *gtsmodel.WebAuthnCredential) error
+
+	// UpdateWebAuthnCredential updates the given credential.
+	// Columns is optional, if not specified all will be updated.
+	UpdateWebAuthnCredential(ctx context.Context, credential *gtsmodel.WebAuthnCredential, columns ...string) error
+
+	// DeleteWebAuthnCredentialByID deletes one credential with the given id.
+	DeleteWebAuthnCredentialByID(ctx context.Context, id string) error
+
+	// DeleteWebAuthnCredentialsByUserID deletes all
+	// credentials registered by the given user.
+	DeleteWebAuthnCredentialsByUserID(ctx context.Context, userID string) error
+}
@@ -0,0 +1,60 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// WebPush contains functions for getting, creating, and deleting
+// Web Push subscriptions, and the instance's VAPID key pair.
+type WebPush interface {
+	// GetVAPIDKeyPair gets the instance's VAPID key pair, if it has been created.
+	GetVAPIDKeyPair(ctx context.Context) (*gtsmodel.VAPIDKeyPair, error)
+
+	// PutVAPIDKeyPair inserts the instance's VAPID key pair into the database.
+	PutVAPIDKeyPair(ctx context.Context, keyPair *gtsmodel.VAPIDKeyPair) error
+
+	// GetWebPushSubscriptionByTokenID gets the one
+	// subscription created with the given OAuth token.
+	GetWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) (*gtsmodel.WebPushSubscription, error)
+
+	// GetWebPushSubscriptionsByAccountID gets all
+	// subscriptions created by the given account.
+	GetWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.WebPushSubscription, error)
+
+	// PutWebPushSubscription inserts the given subscription into the database.
+	PutWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) error
+
+	// UpdateWebPushSubscription updates the given subscription.
+	// Columns is optional, if not specified all will be updated.
+	UpdateWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription, columns ...string) error
+
+	// DeleteWebPushSubscriptionByID deletes one subscription with the given id.
+	DeleteWebPushSubscriptionByID(ctx context.Context, id string) error
+
+	// DeleteWebPushSubscriptionByTokenID deletes the one
+	// subscription created with the given OAuth token.
+	DeleteWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) error
+
+	// DeleteWebPushSubscriptionsByAccountID deletes all
+	// subscriptions created by the given account.
+	DeleteWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) error
+}
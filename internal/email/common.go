@@ -32,12 +32,18 @@ import (
 )
 
 func (s *sender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
+	return s.sendTemplateWithHeaders(template, subject, data, nil, toAddresses...)
+}
+
+// sendTemplateWithHeaders is like sendTemplate, but includes the
+// given extra headers (eg., "List-Unsubscribe: <...>") in the email.
+func (s *sender) sendTemplateWithHeaders(template string, subject string, data any, headers []string, toAddresses ...string) error {
 	buf := &bytes.Buffer{}
 	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
 		return err
 	}
 
-	msg, err := assembleMessage(subject, buf.String(), s.from, toAddresses...)
+	msg, err := assembleMessage(subject, buf.String(), s.from, headers, toAddresses...)
 	if err != nil {
 		return err
 	}
@@ -65,11 +71,17 @@ func loadTemplates(templateBaseDir string) (*template.Template, error) {
 // assembleMessage assembles a valid email message following:
 //   - https://datatracker.ietf.org/doc/html/rfc2822
 //   - https://pkg.go.dev/net/smtp#SendMail
-func assembleMessage(mailSubject string, mailBody string, mailFrom string, mailTo ...string) ([]byte, error) {
+func assembleMessage(mailSubject string, mailBody string, mailFrom string, mailHeaders []string, mailTo ...string) ([]byte, error) {
 	if strings.ContainsAny(mailSubject, "\r\n") {
 		return nil, errors.New("email subject must not contain newline characters")
 	}
 
+	for _, header := range mailHeaders {
+		if strings.ContainsAny(header, "\r\n") {
+			return nil, errors.New("email headers must not contain newline characters")
+		}
+	}
+
 	if strings.ContainsAny(mailFrom, "\r\n") {
 		return nil, errors.New("email from address must not contain newline characters")
 	}
@@ -105,6 +117,9 @@ func assembleMessage(mailSubject string, mailBody string, mailFrom string, mailT
 	}
 	msg.WriteString("From: " + mailFrom + CRLF)
 	msg.WriteString("Subject: " + mailSubject + CRLF)
+	for _, header := range mailHeaders {
+		msg.WriteString(header + CRLF)
+	}
 	msg.WriteString(CRLF)
 	msg.WriteString(mailBody)
 	msg.WriteString(CRLF)
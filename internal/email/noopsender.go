@@ -68,13 +68,37 @@ func (s *noopSender) SendReportClosedEmail(toAddress string, data ReportClosedDa
 	return s.sendTemplate(reportClosedTemplate, reportClosedSubject, data, toAddress)
 }
 
+func (s *noopSender) SendSignupApprovedEmail(toAddress string, data SignupApprovedData) error {
+	return s.sendTemplate(signupApprovedTemplate, signupApprovedSubject, data, toAddress)
+}
+
+func (s *noopSender) SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error {
+	return s.sendTemplate(signupRejectedTemplate, signupRejectedSubject, data, toAddress)
+}
+
+func (s *noopSender) SendAccountActionEmail(toAddress string, data AccountActionData) error {
+	return s.sendTemplate(accountActionTemplate, accountActionSubject, data, toAddress)
+}
+
+func (s *noopSender) SendNotificationsEmail(toAddress string, data NotificationsData) error {
+	return s.sendTemplateWithHeaders(notificationsTemplate, notificationsSubject, data, unsubscribeHeaders(data.UnsubscribeURL), toAddress)
+}
+
+func (s *noopSender) SendDigestEmail(toAddress string, data DigestData) error {
+	return s.sendTemplateWithHeaders(digestTemplate, digestSubject, data, unsubscribeHeaders(data.UnsubscribeURL), toAddress)
+}
+
 func (s *noopSender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
+	return s.sendTemplateWithHeaders(template, subject, data, nil, toAddresses...)
+}
+
+func (s *noopSender) sendTemplateWithHeaders(template string, subject string, data any, headers []string, toAddresses ...string) error {
 	buf := &bytes.Buffer{}
 	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
 		return err
 	}
 
-	msg, err := assembleMessage(subject, buf.String(), "test@example.org", toAddresses...)
+	msg, err := assembleMessage(subject, buf.String(), "test@example.org", headers, toAddresses...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+const (
+	notificationsTemplate = "email_notifications.tmpl"
+	notificationsSubject  = "GoToSocial Notifications"
+	digestTemplate        = "email_digest.tmpl"
+	digestSubject         = "GoToSocial Notification Digest"
+)
+
+type Notification struct {
+	// Type of the notification, one of "follow",
+	// "follow_request", "mention", "direct", or any
+	// other notification type (digests only).
+	Type string
+	// Name of the account that triggered the
+	// notification, eg., "@someone@example.org".
+	AccountName string
+	// URL of the profile of the account
+	// that triggered the notification.
+	AccountURL string
+	// URL of the status that the notification
+	// is about. Can be empty string.
+	StatusURL string
+	// Plaintext (and possibly shortened) content
+	// of the status that the notification is about.
+	// Can be empty string.
+	StatusText string
+}
+
+type NotificationsData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Notifications to list in the email.
+	Notifications []Notification
+	// Number of additional notifications
+	// that are not listed in the email.
+	More int
+	// URL to change email notification settings.
+	SettingsURL string
+	// URL to unsubscribe from notification
+	// emails in one click. Also included in
+	// a List-Unsubscribe header (RFC 8058).
+	UnsubscribeURL string
+}
+
+func (s *sender) SendNotificationsEmail(toAddress string, data NotificationsData) error {
+	return s.sendTemplateWithHeaders(notificationsTemplate, notificationsSubject, data, unsubscribeHeaders(data.UnsubscribeURL), toAddress)
+}
+
+type DigestData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// How often the digest is sent,
+	// ie., "daily" or "weekly".
+	Frequency string
+	// Unread notifications to list in the email.
+	Notifications []Notification
+	// Number of additional unread notifications
+	// that are not listed in the email.
+	More int
+	// URL to change email notification settings.
+	SettingsURL string
+	// URL to unsubscribe from notification
+	// emails in one click. Also included in
+	// a List-Unsubscribe header (RFC 8058).
+	UnsubscribeURL string
+}
+
+func (s *sender) SendDigestEmail(toAddress string, data DigestData) error {
+	return s.sendTemplateWithHeaders(digestTemplate, digestSubject, data, unsubscribeHeaders(data.UnsubscribeURL), toAddress)
+}
+
+// unsubscribeHeaders returns email headers allowing mail
+// clients to offer one-click unsubscription (RFC 8058)
+// using the given URL, which must accept POST requests.
+func unsubscribeHeaders(unsubscribeURL string) []string {
+	if unsubscribeURL == "" {
+		return nil
+	}
+
+	return []string{
+		"List-Unsubscribe: <" + unsubscribeURL + ">",
+		"List-Unsubscribe-Post: List-Unsubscribe=One-Click",
+	}
+}
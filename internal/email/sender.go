@@ -46,6 +46,26 @@ type Sender interface {
 	// SendReportClosedEmail sends an email notification to the given address, letting them
 	// know that a report that they created has been closed / resolved by an admin.
 	SendReportClosedEmail(toAddress string, data ReportClosedData) error
+
+	// SendSignupApprovedEmail sends an email notification to the given address, letting
+	// them know that their sign-up request has been approved by an admin.
+	SendSignupApprovedEmail(toAddress string, data SignupApprovedData) error
+
+	// SendSignupRejectedEmail sends an email notification to the given address, letting
+	// them know that their sign-up request has been rejected by an admin.
+	SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error
+
+	// SendAccountActionEmail sends an email notification to the given address, letting
+	// them know that an admin has taken a moderation action on their account.
+	SendAccountActionEmail(toAddress string, data AccountActionData) error
+
+	// SendNotificationsEmail sends an email to the given address, letting them know
+	// about a batch of new notifications they've opted in to receiving emails about.
+	SendNotificationsEmail(toAddress string, data NotificationsData) error
+
+	// SendDigestEmail sends a daily or weekly digest of unread notifications
+	// to the given address, for users who've opted in to receiving one.
+	SendDigestEmail(toAddress string, data DigestData) error
 }
 
 // NewSender returns a new email Sender interface with the given configuration, or an error if something goes wrong.
@@ -23,6 +23,7 @@ import (
 	"errors"
 	"io"
 	"net/url"
+	"slices"
 	"time"
 
 	"github.com/superseriousbusiness/activity/streams"
@@ -36,6 +37,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -105,11 +107,15 @@ func (d *Dereferencer) GetAccountByURI(ctx context.Context, requestUser string,
 	}
 
 	if accountable != nil {
-		// This account was updated, enqueue re-dereference featured posts.
+		// This account was updated, enqueue re-dereference featured posts + tags.
 		d.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
 			if err := d.dereferenceAccountFeatured(ctx, requestUser, account); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
+
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, account); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
 		})
 	}
 
@@ -202,11 +208,15 @@ func (d *Dereferencer) GetAccountByUsernameDomain(ctx context.Context, requestUs
 	}
 
 	if accountable != nil {
-		// This account was updated, enqueue re-dereference featured posts.
+		// This account was updated, enqueue re-dereference featured posts + tags.
 		d.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
 			if err := d.dereferenceAccountFeatured(ctx, requestUser, account); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
+
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, account); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
 		})
 	}
 
@@ -323,11 +333,15 @@ func (d *Dereferencer) RefreshAccount(
 	}
 
 	if accountable != nil {
-		// This account was updated, enqueue re-dereference featured posts.
+		// This account was updated, enqueue re-dereference featured posts + tags.
 		d.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
 			if err := d.dereferenceAccountFeatured(ctx, requestUser, latest); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
+
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, latest); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
 		})
 	}
 
@@ -372,10 +386,14 @@ func (d *Dereferencer) RefreshAccountAsync(
 		}
 
 		if accountable != nil {
-			// This account was updated, enqueue re-dereference featured posts.
+			// This account was updated, enqueue re-dereference featured posts + tags.
 			if err := d.dereferenceAccountFeatured(ctx, requestUser, latest); err != nil {
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
+
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, latest); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
 		}
 	})
 }
@@ -752,6 +770,19 @@ func (d *Dereferencer) fetchRemoteAccountAvatar(ctx context.Context, tsport tran
 	// If we reach here, we know we need to fetch the most
 	// up-to-date version of the attachment from remote.
 
+	// Media may be hosted away from the account's domain, and
+	// new accounts aren't stored yet for the media manager to
+	// check, so check the account's domain for media rejection.
+	rejected, err := d.state.DB.IsDomainMediaRejected(ctx, latestAcc.Domain)
+	if err != nil {
+		return gtserror.Newf("error checking media rejection for %s: %w", latestAcc.Domain, err)
+	}
+
+	if rejected {
+		// Don't fetch.
+		return nil
+	}
+
 	// Parse and validate the newly provided media URL.
 	avatarURI, err := url.Parse(latestAcc.AvatarRemoteURL)
 	if err != nil {
@@ -838,6 +869,19 @@ func (d *Dereferencer) fetchRemoteAccountHeader(ctx context.Context, tsport tran
 	// If we reach here, we know we need to fetch the most
 	// up-to-date version of the attachment from remote.
 
+	// Media may be hosted away from the account's domain, and
+	// new accounts aren't stored yet for the media manager to
+	// check, so check the account's domain for media rejection.
+	rejected, err := d.state.DB.IsDomainMediaRejected(ctx, latestAcc.Domain)
+	if err != nil {
+		return gtserror.Newf("error checking media rejection for %s: %w", latestAcc.Domain, err)
+	}
+
+	if rejected {
+		// Don't fetch.
+		return nil
+	}
+
 	// Parse and validate the newly provided media URL.
 	headerURI, err := url.Parse(latestAcc.HeaderRemoteURL)
 	if err != nil {
@@ -1152,3 +1196,162 @@ outerLoop:
 
 	return nil
 }
+
+// dereferenceAccountFeaturedTags dereferences an account's featuredTagsURI (if not empty). For each discovered hashtag, the tag
+// will be created (if necessary) and marked as featured by the account (if necessary). Then, old featured tags will be removed if
+// they're not included in the new featured tags.
+func (d *Dereferencer) dereferenceAccountFeaturedTags(ctx context.Context, requestUser string, account *gtsmodel.Account) error {
+	if account.FeaturedTagsURI == "" {
+		// Nothing to
+		// dereference.
+		return nil
+	}
+
+	uri, err := url.Parse(account.FeaturedTagsURI)
+	if err != nil {
+		return err
+	}
+
+	// Pre-fetch a transport for requesting username, used by later deref procedures.
+	tsport, err := d.transportController.NewTransportForUsername(ctx, requestUser)
+	if err != nil {
+		return gtserror.Newf("couldn't create transport: %w", err)
+	}
+
+	b, err := tsport.Dereference(ctx, uri)
+	if err != nil {
+		return err
+	}
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return gtserror.Newf("error unmarshalling bytes into json: %w", err)
+	}
+
+	t, err := streams.ToType(ctx, m)
+	if err != nil {
+		return gtserror.Newf("error resolving json into ap vocab type: %w", err)
+	}
+
+	// Mastodon serves featured tags as an
+	// unordered Collection, but accept both.
+	var hashtags []vocab.TootHashtag
+	switch t.GetTypeName() {
+	case ap.ObjectCollection:
+		collection, ok := t.(vocab.ActivityStreamsCollection)
+		if !ok {
+			return gtserror.New("couldn't coerce Collection")
+		}
+
+		if items := collection.GetActivityStreamsItems(); items != nil {
+			for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+				if iter.IsTootHashtag() {
+					hashtags = append(hashtags, iter.GetTootHashtag())
+				}
+			}
+		}
+
+	case ap.ObjectOrderedCollection:
+		collection, ok := t.(vocab.ActivityStreamsOrderedCollection)
+		if !ok {
+			return gtserror.New("couldn't coerce OrderedCollection")
+		}
+
+		if items := collection.GetActivityStreamsOrderedItems(); items != nil {
+			for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+				if iter.IsTootHashtag() {
+					hashtags = append(hashtags, iter.GetTootHashtag())
+				}
+			}
+		}
+
+	default:
+		return gtserror.Newf("%s was not a Collection or OrderedCollection", uri)
+	}
+
+	// Get previous featured tags (we'll need these later).
+	wasFeatured, err := d.state.DB.GetFeaturedTagsByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting account featured tags: %w", err)
+	}
+
+	tagIDs := make(map[string]struct{}, len(hashtags))
+	for _, hashtag := range hashtags {
+		if len(tagIDs) == gtsmodel.MaxFeaturedTags {
+			// Don't store more featured
+			// tags than we allow locally.
+			break
+		}
+
+		// Normalize name of the tag the same way
+		// as we would when it's used in a status.
+		name, ok := text.NormalizeHashtag(ap.ExtractName(hashtag))
+		if !ok {
+			continue
+		}
+
+		// Look for existing tag with name in the database.
+		tag, err := d.state.DB.GetTagByName(ctx, name)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting tag %s: %v", name, err)
+			continue
+		}
+
+		if tag == nil {
+			// Insert this tag with new name into the database.
+			tag = &gtsmodel.Tag{ID: id.NewULID(), Name: name}
+			if err := d.state.DB.PutTag(ctx, tag); err != nil {
+				log.Errorf(ctx, "db error putting tag %s: %v", name, err)
+				continue
+			}
+		}
+
+		// Already mark this tag as featured. We do this here
+		// so that even if we can't store the featured tag in
+		// the next part for some reason, we still know it was
+		// *meant* to be featured.
+		tagIDs[tag.ID] = struct{}{}
+
+		// If the tag was already featured, we don't need to do anything.
+		if slices.ContainsFunc(wasFeatured, func(ft *gtsmodel.FeaturedTag) bool {
+			return ft.TagID == tag.ID
+		}) {
+			continue
+		}
+
+		// Stats are based on this account's
+		// statuses that we know of locally.
+		count, lastAt, err := d.state.DB.GetAccountTagUsage(ctx, account.ID, tag.ID)
+		if err != nil {
+			log.Errorf(ctx, "db error getting tag %s usage: %v", name, err)
+		}
+
+		if err := d.state.DB.PutFeaturedTag(ctx, &gtsmodel.FeaturedTag{
+			ID:            id.NewULID(),
+			AccountID:     account.ID,
+			TagID:         tag.ID,
+			StatusesCount: count,
+			LastStatusAt:  lastAt,
+		}); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+			log.Errorf(ctx, "db error putting featured tag %s: %v", name, err)
+			continue
+		}
+	}
+
+	// Now that we know which tags are featured, we should
+	// *unfeature* previous featured tags that aren't included.
+	for _, featuredTag := range wasFeatured {
+		if _, ok := tagIDs[featuredTag.TagID]; ok {
+			// This tag is included in
+			// most recent featured tags.
+			continue
+		}
+
+		if err := d.state.DB.DeleteFeaturedTagByID(ctx, featuredTag.ID); err != nil {
+			log.Errorf(ctx, "error unfeaturing tag %s: %v", featuredTag.TagID, err)
+			continue
+		}
+	}
+
+	return nil
+}
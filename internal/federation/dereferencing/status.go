@@ -442,6 +442,15 @@ func (d *Dereferencer) enrichStatus(
 
 		// Reuse existing status ID.
 		latestStatus.ID = status.ID
+
+		// Carry-over existing edit history.
+		latestStatus.EditIDs = status.EditIDs
+		latestStatus.Edits = status.Edits
+
+		// Carry-over existing preview card, this
+		// gets refreshed separately after update.
+		latestStatus.CardID = status.CardID
+		latestStatus.Card = status.Card
 	}
 
 	// Carry-over values and set fetch time.
@@ -480,6 +489,14 @@ func (d *Dereferencer) enrichStatus(
 		return nil, nil, gtserror.Newf("error populating emojis for status %s: %w", uri, err)
 	}
 
+	if !isNew {
+		// Check whether the latest status differs from the
+		// existing, if so store the existing as a revision.
+		if err := d.handleStatusEdit(ctx, status, latestStatus); err != nil {
+			return nil, nil, gtserror.Newf("error handling edit for status %s: %w", uri, err)
+		}
+	}
+
 	if isNew {
 		// This is new, put the status in the database.
 		err := d.state.DB.PutStatus(ctx, latestStatus)
@@ -600,12 +617,10 @@ func (d *Dereferencer) fetchStatusMentions(ctx context.Context, requestUser stri
 		}
 
 		// This mention didn't exist yet.
-		// Generate new ID according to status creation.
-		// TODO: update this to use "edited_at" when we add
-		//       support for edited status revision history.
-		mention.ID, err = id.NewULIDFromTime(status.CreatedAt)
+		// Generate new ID according to status creation / edit.
+		mention.ID, err = id.NewULIDFromTime(statusRevisedAt(status))
 		if err != nil {
-			log.Errorf(ctx, "invalid created at date (falling back to 'now'): %v", err)
+			log.Errorf(ctx, "invalid created / edited at date (falling back to 'now'): %v", err)
 			mention.ID = id.NewULID() // just use "now"
 		}
 
@@ -753,18 +768,69 @@ func (d *Dereferencer) fetchStatusTags(ctx context.Context, existing, status *gt
 	return nil
 }
 
+// handleStatusEdit compares the latest version of a
+// status with the existing version, and if they differ
+// stores the existing version in the status edit history.
+func (d *Dereferencer) handleStatusEdit(ctx context.Context, existing, status *gtsmodel.Status) error {
+	if !statusEdited(existing, status) {
+		if status.EditedAt.IsZero() {
+			// Carry-over any existing
+			// edit time if not provided.
+			status.EditedAt = existing.EditedAt
+		}
+		return nil
+	}
+
+	// Store the existing status
+	// as its previous revision.
+	edit := &gtsmodel.StatusEdit{
+		ID:             id.NewULID(),
+		CreatedAt:      statusRevisedAt(existing),
+		StatusID:       existing.ID,
+		Content:        existing.Content,
+		ContentWarning: existing.ContentWarning,
+		Language:       existing.Language,
+		Sensitive:      existing.Sensitive,
+		AttachmentIDs:  existing.AttachmentIDs,
+		Attachments:    existing.Attachments,
+	}
+
+	for _, attachment := range existing.Attachments {
+		edit.AttachmentDescriptions = append(edit.AttachmentDescriptions, attachment.Description)
+	}
+
+	if existing.Poll != nil {
+		edit.PollOptions = existing.Poll.Options
+		edit.PollVotes = existing.Poll.Votes
+	}
+
+	if err := d.state.DB.PutStatusEdit(ctx, edit); err != nil {
+		return gtserror.Newf("error putting status edit in database: %w", err)
+	}
+
+	// Add the revision to the status edit history.
+	status.EditIDs = append(status.EditIDs, edit.ID)
+	status.Edits = append(status.Edits, edit)
+
+	if !status.EditedAt.After(existing.EditedAt) {
+		// Remote didn't provide a (newer)
+		// edit time, so just use "now".
+		status.EditedAt = time.Now()
+	}
+
+	return nil
+}
+
 func (d *Dereferencer) fetchStatusPoll(ctx context.Context, existing, status *gtsmodel.Status) error {
 	var (
 		// insertStatusPoll generates ID and inserts the poll attached to status into the database.
 		insertStatusPoll = func(ctx context.Context, status *gtsmodel.Status) error {
 			var err error
 
-			// Generate new ID for poll from the status CreatedAt.
-			// TODO: update this to use "edited_at" when we add
-			//       support for edited status revision history.
-			status.Poll.ID, err = id.NewULIDFromTime(status.CreatedAt)
+			// Generate new ID for poll from the status creation / edit.
+			status.Poll.ID, err = id.NewULIDFromTime(statusRevisedAt(status))
 			if err != nil {
-				log.Errorf(ctx, "invalid created at date (falling back to 'now'): %v", err)
+				log.Errorf(ctx, "invalid created / edited at date (falling back to 'now'): %v", err)
 				status.Poll.ID = id.NewULID() // just use "now"
 			}
 
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -218,6 +219,45 @@ func (suite *StatusTestSuite) TestDereferenceStatusWithImageAndNoContent() {
 	suite.NoError(err)
 }
 
+func (suite *StatusTestSuite) TestDereferenceStatusEdit() {
+	ctx := context.Background()
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	statusURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839")
+	status, statusable, err := suite.dereferencer.GetStatusByURI(ctx, fetchingAccount.Username, statusURL)
+	suite.NoError(err)
+	suite.NotNil(statusable)
+	suite.Empty(status.EditIDs)
+
+	// Edit the status content, as though we
+	// had received an Update from the remote.
+	editedAt := testrig.TimeMustParse("2024-02-18T12:00:00Z")
+	contentProp := streams.NewActivityStreamsContentProperty()
+	contentProp.AppendXMLSchemaString("Hello edited world!")
+	statusable.SetActivityStreamsContent(contentProp)
+	ap.SetUpdated(statusable, editedAt)
+
+	latest, _, err := suite.dereferencer.RefreshStatus(ctx, fetchingAccount.Username, status, statusable, nil)
+	suite.NoError(err)
+	suite.Equal(status.ID, latest.ID)
+	suite.Equal("Hello edited world!", latest.Content)
+	suite.True(latest.EditedAt.Equal(editedAt))
+	suite.Len(latest.EditIDs, 1)
+
+	// The previous revision should be stored.
+	edit, err := suite.db.GetStatusEditByID(ctx, latest.EditIDs[0])
+	suite.NoError(err)
+	suite.Equal(status.ID, edit.StatusID)
+	suite.Equal("Hello world!", edit.Content)
+	suite.True(edit.CreatedAt.Equal(status.CreatedAt))
+
+	// Refreshing again without changes
+	// should not record another revision.
+	latest, _, err = suite.dereferencer.RefreshStatus(ctx, fetchingAccount.Username, latest, statusable, nil)
+	suite.NoError(err)
+	suite.Len(latest.EditIDs, 1)
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
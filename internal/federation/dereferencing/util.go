@@ -19,6 +19,7 @@ package dereferencing
 
 import (
 	"slices"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -43,3 +44,32 @@ func pollUpdated(existing, latest *gtsmodel.Poll) bool {
 func pollJustClosed(existing, latest *gtsmodel.Poll) bool {
 	return existing.ClosedAt.IsZero() && latest.Closed()
 }
+
+// statusEdited returns whether the latest version of a status
+// differs from the existing in a way that should be recorded
+// as an edit, i.e. if its content, content warning, sensitivity,
+// remote media attachments or poll have changed.
+func statusEdited(existing, latest *gtsmodel.Status) bool {
+	return existing.Content != latest.Content ||
+		existing.ContentWarning != latest.ContentWarning ||
+		*existing.Sensitive != *latest.Sensitive ||
+		!slices.EqualFunc(existing.Attachments, latest.Attachments, attachmentsEqual) ||
+		existing.PollID != latest.PollID
+}
+
+// attachmentsEqual returns whether the given attachments
+// point to the same remote media. This is used instead of
+// comparing IDs, as recaching media may generate new IDs.
+func attachmentsEqual(a, b *gtsmodel.MediaAttachment) bool {
+	return a.RemoteURL == b.RemoteURL
+}
+
+// statusRevisedAt returns the time at which the current
+// revision of a status was created, i.e. its last edit
+// time if it has been edited, else its creation time.
+func statusRevisedAt(status *gtsmodel.Status) time.Time {
+	if !status.EditedAt.IsZero() {
+		return status.EditedAt
+	}
+	return status.CreatedAt
+}
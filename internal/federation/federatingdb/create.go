@@ -520,6 +520,18 @@ func (f *federatingDB) activityFlag(ctx context.Context, asType vocab.Type, rece
 		return errors.New("activityFlag: could not convert type to flag")
 	}
 
+	// Drop reports from domains that
+	// we've been told to reject them from.
+	rejected, err := f.state.DB.IsDomainReportsRejected(ctx, requestingAccount.Domain)
+	if err != nil {
+		return fmt.Errorf("activityFlag: db error checking domain %s: %w", requestingAccount.Domain, err)
+	}
+
+	if rejected {
+		log.Debugf(ctx, "activityFlag: ignoring Flag from %s, reports from domain are rejected", requestingAccount.URI)
+		return nil
+	}
+
 	report, err := f.converter.ASFlagToReport(ctx, flag)
 	if err != nil {
 		return fmt.Errorf("activityFlag: could not convert Flag to report: %w", err)
@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -90,11 +91,13 @@ func (suite *CreateTestSuite) TestCreateNoteForward() {
 	suite.Equal("http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1", msg.APIri.String())
 }
 
-func (suite *CreateTestSuite) TestCreateFlag1() {
-	reportedAccount := suite.testAccounts["local_account_1"]
-	reportingAccount := suite.testAccounts["remote_account_1"]
-	reportedStatus := suite.testStatuses["local_account_1_status_1"]
-
+// newFlag returns a Flag of reportedAccount by reportingAccount,
+// with the given status mentioned in the content of the Flag.
+func (suite *CreateTestSuite) newFlag(
+	reportingAccount *gtsmodel.Account,
+	reportedAccount *gtsmodel.Account,
+	reportedStatus *gtsmodel.Status,
+) vocab.Type {
 	raw := `{
   "@context": "https://www.w3.org/ns/activitystreams",
   "actor": "` + reportingAccount.URI + `",
@@ -114,6 +117,16 @@ func (suite *CreateTestSuite) TestCreateFlag1() {
 		suite.FailNow(err.Error())
 	}
 
+	return t
+}
+
+func (suite *CreateTestSuite) TestCreateFlag1() {
+	reportedAccount := suite.testAccounts["local_account_1"]
+	reportingAccount := suite.testAccounts["remote_account_1"]
+	reportedStatus := suite.testStatuses["local_account_1_status_1"]
+
+	t := suite.newFlag(reportingAccount, reportedAccount, reportedStatus)
+
 	ctx := createTestContext(reportedAccount, reportingAccount)
 	if err := suite.federatingDB.Create(ctx, t); err != nil {
 		suite.FailNow(err.Error())
@@ -134,6 +147,34 @@ func (suite *CreateTestSuite) TestCreateFlag1() {
 	}
 }
 
+func (suite *CreateTestSuite) TestCreateFlagReportsRejected() {
+	reportedAccount := suite.testAccounts["local_account_1"]
+	reportingAccount := suite.testAccounts["remote_account_1"]
+	reportedStatus := suite.testStatuses["local_account_1_status_1"]
+
+	// Reject reports from the reporting
+	// account's domain, without blocking it.
+	if err := suite.db.CreateDomainBlock(context.Background(), &gtsmodel.DomainBlock{
+		ID:                 id.NewULID(),
+		Domain:             reportingAccount.Domain,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		Severity:           gtsmodel.DomainBlockSeverityNoop,
+		RejectReports:      util.Ptr(true),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t := suite.newFlag(reportingAccount, reportedAccount, reportedStatus)
+
+	ctx := createTestContext(reportedAccount, reportingAccount)
+	if err := suite.federatingDB.Create(ctx, t); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Flag should have been dropped.
+	suite.Empty(suite.fromFederator)
+}
+
 func TestCreateTestSuite(t *testing.T) {
 	suite.Run(t, &CreateTestSuite{})
 }
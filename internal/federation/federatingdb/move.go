@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+
+	"codeberg.org/gruf/go-logger/v2/level"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+func (f *federatingDB) Move(ctx context.Context, move vocab.ActivityStreamsMove) error {
+	if log.Level() >= level.DEBUG {
+		i, err := marshalItem(move)
+		if err != nil {
+			return err
+		}
+		l := log.WithContext(ctx).
+			WithField("move", i)
+		l.Debug("entering Move")
+	}
+
+	receivingAccount, requestingAccount, internal := extractFromCtx(ctx)
+	if internal {
+		return nil // Already processed.
+	}
+
+	// Ensure requestingAccount is the
+	// only Actor doing the Move.
+	//
+	// We don't support Move forwards.
+	actorIRIs := ap.GetActorIRIs(move)
+	if len(actorIRIs) != 1 || actorIRIs[0].String() != requestingAccount.URI {
+		return gtserror.Newf(
+			"requestingAccount %s was not the only Move Actor",
+			requestingAccount.URI,
+		)
+	}
+
+	// Accounts can only Move themselves,
+	// so the Object of the Move must be
+	// the requesting account.
+	objectIRIs := ap.GetObjectIRIs(move)
+	if len(objectIRIs) != 1 || objectIRIs[0].String() != requestingAccount.URI {
+		return gtserror.Newf(
+			"requestingAccount %s was not the only Move Object",
+			requestingAccount.URI,
+		)
+	}
+
+	// There must be exactly one
+	// Target account of the Move.
+	targetIRIs := ap.GetTargetIRIs(move)
+	if len(targetIRIs) != 1 {
+		return gtserror.Newf(
+			"Move from %s must have exactly one Target, had %d",
+			requestingAccount.URI, len(targetIRIs),
+		)
+	}
+
+	targetIRI := targetIRIs[0]
+	if targetIRI.String() == requestingAccount.URI {
+		return gtserror.Newf(
+			"requestingAccount %s cannot Move to itself",
+			requestingAccount.URI,
+		)
+	}
+
+	// Verifying the target account and
+	// updating follows both involve a fair
+	// bit of work, so do it asynchronously.
+	f.state.Workers.EnqueueFediAPI(ctx, messages.FromFediAPI{
+		APObjectType:     ap.ObjectProfile,
+		APActivityType:   ap.ActivityMove,
+		APIri:            targetIRI,
+		GTSModel:         requestingAccount,
+		ReceivingAccount: receivingAccount,
+	})
+
+	return nil
+}
@@ -73,6 +73,7 @@ type Account struct {
 	FollowingURI            string           `bun:",nullzero,unique"`               // URI for getting the following list of this account
 	FollowersURI            string           `bun:",nullzero,unique"`               // URI for getting the followers list of this account
 	FeaturedCollectionURI   string           `bun:",nullzero,unique"`               // URL for getting the featured collection list of this account
+	FeaturedTagsURI         string           `bun:",nullzero"`                      // URL for getting the featured tags collection of this account
 	ActorType               string           `bun:",nullzero,notnull"`              // What type of activitypub actor is this account?
 	PrivateKey              *rsa.PrivateKey  `bun:""`                               // Privatekey for signing activitypub requests, will only be defined for local accounts
 	PublicKey               *rsa.PublicKey   `bun:",notnull"`                       // Publickey for authorizing signed activitypub requests, will be defined for both local and remote accounts
@@ -86,6 +87,7 @@ type Account struct {
 	EnableRSS               *bool            `bun:",default:false"`                 // enable RSS feed subscription for this account's public posts at [URL]/feed
 	ShowAllReplies          *bool            `bun:",default:false"`                 // enable showing all replies in the home timeline (i.e. a reply from a followed account to an unfollowed account ( Issue #2254 )
 	NoisyMode               *bool            `bun:",default:false"`                 // enable NoisyMode - PSWFork
+	LastStatusAt            time.Time        `bun:"type:timestamptz,nullzero"`      // When did this account most recently create a status? Maintained by the database on status insert, so may be stale on cached models.
 }
 
 // IsLocal returns whether account is a local user account.
@@ -119,6 +121,18 @@ func (a *Account) IsInstance() bool {
 		a.Username == "instance.actor" // <- misskey
 }
 
+// IsSilenced returns whether account
+// has been silenced by an admin.
+func (a *Account) IsSilenced() bool {
+	return !a.SilencedAt.IsZero()
+}
+
+// IsSensitized returns whether account
+// has been sensitized by an admin.
+func (a *Account) IsSensitized() bool {
+	return !a.SensitizedAt.IsZero()
+}
+
 // EmojisPopulated returns whether emojis are
 // populated according to current EmojiIDs.
 func (a *Account) EmojisPopulated() bool {
@@ -73,6 +73,9 @@ const (
 	AdminActionSuspend
 	AdminActionUnsuspend
 	AdminActionExpireKeys
+	AdminActionSensitize
+	AdminActionUnsensitize
+	AdminActionContentWarning
 )
 
 func (t AdminActionType) String() string {
@@ -91,6 +94,12 @@ func (t AdminActionType) String() string {
 		return "unsuspend"
 	case AdminActionExpireKeys:
 		return "expire-keys"
+	case AdminActionSensitize:
+		return "sensitize"
+	case AdminActionUnsensitize:
+		return "unsensitize"
+	case AdminActionContentWarning:
+		return "content-warning"
 	default:
 		return "unknown"
 	}
@@ -100,7 +109,7 @@ func NewAdminActionType(in string) AdminActionType {
 	switch in {
 	case "disable":
 		return AdminActionDisable
-	case "reenable":
+	case "reenable", "enable":
 		return AdminActionReenable
 	case "silence":
 		return AdminActionSilence
@@ -112,6 +121,12 @@ func NewAdminActionType(in string) AdminActionType {
 		return AdminActionUnsuspend
 	case "expire-keys":
 		return AdminActionExpireKeys
+	case "sensitize", "sensitive":
+		return AdminActionSensitize
+	case "unsensitize", "unsensitive":
+		return AdminActionUnsensitize
+	case "content-warning":
+		return AdminActionContentWarning
 	default:
 		return AdminActionUnknown
 	}
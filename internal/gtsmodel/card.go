@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Card represents a preview card generated from
+// the metadata of a link included in a status.
+// Cards are shared between all statuses that
+// link to the same URL.
+type Card struct {
+	ID           string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt    time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt    time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	FetchedAt    time.Time        `bun:"type:timestamptz,nullzero"`                                   // when was the linked resource last fetched
+	URL          string           `bun:",nullzero,notnull,unique"`                                    // location of linked resource
+	Title        string           `bun:""`                                                            // title of linked resource
+	Description  string           `bun:""`                                                            // description of linked resource
+	Type         CardType         `bun:",nullzero,notnull"`                                           // type of preview card
+	AuthorName   string           `bun:",nullzero"`                                                   // author of linked resource
+	AuthorURL    string           `bun:",nullzero"`                                                   // link to author of linked resource
+	ProviderName string           `bun:",nullzero"`                                                   // provider of linked resource
+	ProviderURL  string           `bun:",nullzero"`                                                   // link to provider of linked resource
+	HTML         string           `bun:",nullzero"`                                                   // sanitized embed html (video / rich cards)
+	Width        int              `bun:",nullzero"`                                                   // width of preview or embed, in pixels
+	Height       int              `bun:",nullzero"`                                                   // height of preview or embed, in pixels
+	EmbedURL     string           `bun:",nullzero"`                                                   // url of photo to embed (photo cards)
+	ImageID      string           `bun:"type:CHAR(26),nullzero"`                                      // id of the cached preview image media attachment
+	Image        *MediaAttachment `bun:"-"`                                                           // preview image corresponding to imageID
+}
+
+// CardType is the type of preview card.
+type CardType string
+
+// Card types, as used by the Mastodon API.
+const (
+	CardTypeLink  CardType = "link"
+	CardTypePhoto CardType = "photo"
+	CardTypeVideo CardType = "video"
+	CardTypeRich  CardType = "rich"
+)
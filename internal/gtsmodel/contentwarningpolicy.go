@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"strings"
+	"time"
+)
+
+// ContentWarningPolicy is a moderation policy which forces a content
+// warning and/or the sensitive flag onto all statuses authored by
+// either one account, or any account on one domain.
+type ContentWarningPolicy struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Domain             string    `bun:",nullzero,unique"`                                            // Domain targeted by this policy, if it targets a domain.
+	AccountID          string    `bun:"type:CHAR(26),nullzero,unique"`                               // ID of the account targeted by this policy, if it targets an account.
+	Account            *Account  `bun:"-"`                                                           // Account corresponding to AccountID.
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the admin who created this policy.
+	ContentWarning     string    `bun:""`                                                            // Content warning to prepend to targeted statuses, if any.
+	Sensitive          *bool     `bun:",nullzero,notnull,default:false"`                             // Force targeted statuses to be marked as sensitive.
+}
+
+// Apply returns the given content warning and sensitive
+// flag of a status, with this policy applied to them.
+// Applying a policy to an already-applied result is
+// a no-op, so it's safe to apply more than once.
+func (p *ContentWarningPolicy) Apply(contentWarning string, sensitive bool) (string, bool) {
+	if p.Sensitive != nil && *p.Sensitive {
+		sensitive = true
+	}
+
+	if p.ContentWarning != "" {
+		switch {
+		case contentWarning == "":
+			contentWarning = p.ContentWarning
+		case !strings.HasPrefix(contentWarning, p.ContentWarning):
+			contentWarning = p.ContentWarning + "; " + contentWarning
+		}
+
+		// Content warned
+		// statuses are sensitive.
+		sensitive = true
+	}
+
+	return contentWarning, sensitive
+}
@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Conversation represents one local account's view of
+// a thread of direct-visibility statuses exchanged with
+// a particular set of other accounts.
+type Conversation struct {
+	ID               string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                       // id of this item in the database
+	CreatedAt        time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                    // when was item created
+	UpdatedAt        time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                    // when was item last updated
+	AccountID        string     `bun:"type:CHAR(26),nullzero,notnull,unique:conversations_account_thread_others_uniq"` // ID of the local account that owns this conversation.
+	Account          *Account   `bun:"-"`                                                                              // Account corresponding to AccountID.
+	OtherAccountIDs  []string   `bun:"other_account_ids,array"`                                                        // IDs of the other participants in this conversation.
+	OtherAccounts    []*Account `bun:"-"`                                                                              // Accounts corresponding to OtherAccountIDs.
+	OtherAccountsKey string     `bun:",notnull,unique:conversations_account_thread_others_uniq"`                       // Sorted, comma-separated OtherAccountIDs, for uniqueness + lookups.
+	ThreadID         string     `bun:"type:CHAR(26),nullzero,notnull,unique:conversations_account_thread_others_uniq"` // ID of the thread that statuses in this conversation belong to.
+	LastStatusID     string     `bun:"type:CHAR(26),nullzero,notnull"`                                                 // ID of the most recent status in this conversation.
+	LastStatus       *Status    `bun:"-"`                                                                              // Status corresponding to LastStatusID.
+	Read             *bool      `bun:",nullzero,notnull,default:false"`                                                // Has the owner read the most recent status in this conversation?
+}
+
+// ConversationOtherAccountsKey returns the key used to
+// identify a conversation with the given set of other
+// participants, independent of the order of the IDs.
+func ConversationOtherAccountsKey(otherAccountIDs []string) string {
+	otherAccountIDs = slices.Clone(otherAccountIDs)
+	slices.Sort(otherAccountIDs)
+	return strings.Join(otherAccountIDs, ",")
+}
+
+// ConversationToStatus is an intermediate struct to facilitate the
+// many2many relationship between a conversation and its statuses.
+type ConversationToStatus struct {
+	ConversationID string `bun:"type:CHAR(26),unique:conversationstatus,nullzero,notnull"`
+	StatusID       string `bun:"type:CHAR(26),unique:conversationstatus,nullzero,notnull"`
+}
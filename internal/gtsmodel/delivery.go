@@ -0,0 +1,38 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Delivery represents a queued delivery of an outgoing
+// ActivityPub message to a single remote inbox. Deliveries
+// are stored in the database until they either succeed, or
+// are given up on, so that they survive a restart.
+type Delivery struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	PubKeyID      string    `bun:",nullzero,notnull"`                                           // ID of the public key of the local actor which signs this delivery.
+	TargetURI     string    `bun:",nullzero,notnull"`                                           // URI of the inbox this delivery is addressed to.
+	TargetDomain  string    `bun:",nullzero,notnull"`                                           // Host of TargetURI, used to group deliveries by domain.
+	Data          []byte    `bun:"type:bytea,nullzero,notnull"`                                 // Serialized ActivityPub message to deliver.
+	Attempts      int       `bun:",notnull,default:0"`                                          // Number of delivery attempts made so far.
+	NextAttemptAt time.Time `bun:"type:timestamptz,nullzero,notnull"`                           // Time at which to next attempt delivery.
+	LastAttemptAt time.Time `bun:"type:timestamptz,nullzero"`                                   // Time at which delivery was last attempted.
+	LastError     string    `bun:",nullzero"`                                                   // Error from the last failed delivery attempt, if any.
+}
@@ -21,16 +21,47 @@ import "time"
 
 // DomainBlock represents a federation block against a particular domain
 type DomainBlock struct {
-	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
-	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
-	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
-	Domain             string    `bun:",nullzero,notnull"`                                           // domain to block. Eg. 'whatever.com'
-	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this block
-	CreatedByAccount   *Account  `bun:"rel:belongs-to"`                                              // Account corresponding to createdByAccountID
-	PrivateComment     string    `bun:""`                                                            // Private comment on this block, viewable to admins
-	PublicComment      string    `bun:""`                                                            // Public comment on this block, viewable (optionally) by everyone
-	Obfuscate          *bool     `bun:",nullzero,notnull,default:false"`                             // whether the domain name should appear obfuscated when displaying it publicly
-	SubscriptionID     string    `bun:"type:CHAR(26),nullzero"`                                      // if this block was created through a subscription, what's the subscription ID?
+	ID                 string              `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Domain             string              `bun:",nullzero,notnull"`                                           // domain to block. Eg. 'whatever.com'
+	CreatedByAccountID string              `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this block
+	CreatedByAccount   *Account            `bun:"rel:belongs-to"`                                              // Account corresponding to createdByAccountID
+	PrivateComment     string              `bun:""`                                                            // Private comment on this block, viewable to admins
+	PublicComment      string              `bun:""`                                                            // Public comment on this block, viewable (optionally) by everyone
+	Obfuscate          *bool               `bun:",nullzero,notnull,default:false"`                             // whether the domain name should appear obfuscated when displaying it publicly
+	SubscriptionID     string              `bun:"type:CHAR(26),nullzero"`                                      // if this block was created through a subscription, what's the subscription ID?
+	Severity           DomainBlockSeverity `bun:",nullzero,notnull,default:'suspend'"`                         // severity of this block: suspend, silence or noop
+	RejectMedia        *bool               `bun:",nullzero,notnull,default:false"`                             // don't fetch or store remote media from this domain
+	RejectReports      *bool               `bun:",nullzero,notnull,default:false"`                             // ignore reports (flags) sent from this domain
+}
+
+// DomainBlockSeverity describes how
+// severely a domain block is enforced.
+type DomainBlockSeverity string
+
+const (
+	DomainBlockSeverityUnknown DomainBlockSeverity = ""
+	DomainBlockSeveritySuspend DomainBlockSeverity = "suspend" // Defederate from domain entirely.
+	DomainBlockSeveritySilence DomainBlockSeverity = "silence" // Limit visibility of domain's accounts.
+	DomainBlockSeverityNoop    DomainBlockSeverity = "noop"    // Only apply media / report rejection.
+)
+
+// NewDomainBlockSeverity parses the given string as a
+// DomainBlockSeverity. An empty string is taken to mean
+// suspend (the default), and "limit" is accepted as an
+// alias for silence. Unrecognized values return unknown.
+func NewDomainBlockSeverity(in string) DomainBlockSeverity {
+	switch in {
+	case "", "suspend":
+		return DomainBlockSeveritySuspend
+	case "silence", "limit":
+		return DomainBlockSeveritySilence
+	case "noop":
+		return DomainBlockSeverityNoop
+	default:
+		return DomainBlockSeverityUnknown
+	}
 }
 
 func (d *DomainBlock) GetID() string {
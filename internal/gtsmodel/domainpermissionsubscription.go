@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DomainPermissionSubscription represents a subscription
+// to a remote list of domain permissions (blocks or allows).
+// Permissions created through a subscription are kept in
+// sync with the list, and are owned by the subscription.
+type DomainPermissionSubscription struct {
+	ID                    string                   `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt             time.Time                `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt             time.Time                `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Priority              uint8                    `bun:""`                                                            // Priority of this subscription compared to others of the same permission type. 0-255 (higher = higher priority).
+	Title                 string                   `bun:",nullzero,unique"`                                            // Moderator-set title for this list.
+	PermissionType        DomainPermissionType     `bun:",notnull"`                                                    // Permission type of the subscription.
+	AdoptOrphans          *bool                    `bun:",nullzero,notnull,default:false"`                             // Take ownership of matching permissions that aren't owned by any subscription.
+	CreatedByAccountID    string                   `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this subscription.
+	CreatedByAccount      *Account                 `bun:"-"`                                                           // Account corresponding to createdByAccountID.
+	URI                   string                   `bun:",nullzero,notnull,unique"`                                    // URI of the domain permission list.
+	ContentType           DomainPermSubContentType `bun:",nullzero,notnull"`                                           // Content type to expect from the URI.
+	FetchUsername         string                   `bun:",nullzero"`                                                   // Username to send when doing a GET of URI using basic auth.
+	FetchPassword         string                   `bun:",nullzero"`                                                   // Password to send when doing a GET of URI using basic auth.
+	FetchedAt             time.Time                `bun:"type:timestamptz,nullzero"`                                   // Time when fetch of URI was last attempted.
+	SuccessfullyFetchedAt time.Time                `bun:"type:timestamptz,nullzero"`                                   // Time when the domain permission list was last successfully fetched, to be transmitted as If-Modified-Since header.
+	ETag                  string                   `bun:"etag,nullzero"`                                               // Etag last received from the server (if any) on successful fetch.
+	Error                 string                   `bun:",nullzero"`                                                   // If latest fetch attempt errored, this field stores the error message. Cleared on latest successful fetch.
+}
+
+// DomainPermSubContentType is the content
+// type of a domain permission subscription list.
+type DomainPermSubContentType string
+
+const (
+	// DomainPermSubContentTypeUnknown is for
+	// unknown or unrecognized content types.
+	DomainPermSubContentTypeUnknown DomainPermSubContentType = ""
+	// DomainPermSubContentTypeCSV is for Mastodon-style
+	// CSV exports, with a header row naming the columns.
+	DomainPermSubContentTypeCSV DomainPermSubContentType = "text/csv"
+	// DomainPermSubContentTypeJSON is for
+	// GoToSocial-style JSON exports.
+	DomainPermSubContentTypeJSON DomainPermSubContentType = "application/json"
+	// DomainPermSubContentTypePlain is for plaintext
+	// lists with one domain per line.
+	DomainPermSubContentTypePlain DomainPermSubContentType = "text/plain"
+)
+
+// NewDomainPermSubContentType parses the given string as a
+// DomainPermSubContentType, returning unknown if not recognized.
+func NewDomainPermSubContentType(in string) DomainPermSubContentType {
+	switch ct := DomainPermSubContentType(in); ct {
+	case DomainPermSubContentTypeCSV,
+		DomainPermSubContentTypeJSON,
+		DomainPermSubContentTypePlain:
+		return ct
+	default:
+		return DomainPermSubContentTypeUnknown
+	}
+}
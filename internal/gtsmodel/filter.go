@@ -0,0 +1,174 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"regexp"
+	"time"
+)
+
+// FilterContext represents the context in which a Filter applies.
+type FilterContext string
+
+const (
+	// FilterContextNone means no filters should be applied.
+	// There is no corresponding field on the Filter model.
+	FilterContextNone FilterContext = ""
+	// FilterContextHome means this filter should be applied to the home timeline and lists.
+	FilterContextHome FilterContext = "home"
+	// FilterContextNotifications means this filter should be applied to the notifications timeline.
+	FilterContextNotifications FilterContext = "notifications"
+	// FilterContextPublic means this filter should be applied to public timelines.
+	FilterContextPublic FilterContext = "public"
+	// FilterContextThread means this filter should be applied to the expanded thread of a detailed status.
+	FilterContextThread FilterContext = "thread"
+	// FilterContextAccount means this filter should be applied when viewing a profile.
+	FilterContextAccount FilterContext = "account"
+)
+
+// FilterAction represents the action taken when a Filter matches.
+type FilterAction string
+
+const (
+	// FilterActionNone filters should not exist, except
+	// internally, for partially constructed or invalid filters.
+	FilterActionNone FilterAction = ""
+	// FilterActionWarn means that the status should
+	// be shown behind a warning, with the filter title.
+	FilterActionWarn FilterAction = "warn"
+	// FilterActionHide means that the status should
+	// be removed from timeline results entirely.
+	FilterActionHide FilterAction = "hide"
+)
+
+// Filter stores a filter created by a local account.
+type Filter struct {
+	ID                   string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                            // id of this item in the database
+	CreatedAt            time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`         // when was item created
+	UpdatedAt            time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`         // when was item last updated
+	ExpiresAt            time.Time        `bun:"type:timestamptz,nullzero"`                                           // Time filter should expire. If null, should not expire.
+	AccountID            string           `bun:"type:CHAR(26),notnull,nullzero,unique:filters_account_id_title_uniq"` // ID of the local account that created the filter.
+	Title                string           `bun:",nullzero,notnull,unique:filters_account_id_title_uniq"`              // The name of the filter.
+	Action               FilterAction     `bun:",nullzero,notnull"`                                                   // The action to take.
+	Keywords             []*FilterKeyword `bun:"-"`                                                                   // Keywords for this filter.
+	Statuses             []*FilterStatus  `bun:"-"`                                                                   // Statuses for this filter.
+	ContextHome          *bool            `bun:",nullzero,notnull,default:false"`                                     // Apply filter to home timeline and lists.
+	ContextNotifications *bool            `bun:",nullzero,notnull,default:false"`                                     // Apply filter to notifications.
+	ContextPublic        *bool            `bun:",nullzero,notnull,default:false"`                                     // Apply filter to public timelines.
+	ContextThread        *bool            `bun:",nullzero,notnull,default:false"`                                     // Apply filter when viewing a status's associated thread.
+	ContextAccount       *bool            `bun:",nullzero,notnull,default:false"`                                     // Apply filter when viewing an account profile.
+}
+
+// Expired returns whether the filter has expired at a given time.
+// Filters without an expiration timestamp never expire.
+func (f *Filter) Expired(now time.Time) bool {
+	return !f.ExpiresAt.IsZero() && !f.ExpiresAt.After(now)
+}
+
+// Contexts returns the contexts in which this filter applies.
+func (f *Filter) Contexts() []FilterContext {
+	contexts := make([]FilterContext, 0, 5)
+	if *f.ContextHome {
+		contexts = append(contexts, FilterContextHome)
+	}
+	if *f.ContextNotifications {
+		contexts = append(contexts, FilterContextNotifications)
+	}
+	if *f.ContextPublic {
+		contexts = append(contexts, FilterContextPublic)
+	}
+	if *f.ContextThread {
+		contexts = append(contexts, FilterContextThread)
+	}
+	if *f.ContextAccount {
+		contexts = append(contexts, FilterContextAccount)
+	}
+	return contexts
+}
+
+// AppliesInContext returns whether this filter
+// should be applied in the given filter context.
+func (f *Filter) AppliesInContext(filterContext FilterContext) bool {
+	switch filterContext {
+	case FilterContextHome:
+		return *f.ContextHome
+	case FilterContextNotifications:
+		return *f.ContextNotifications
+	case FilterContextPublic:
+		return *f.ContextPublic
+	case FilterContextThread:
+		return *f.ContextThread
+	case FilterContextAccount:
+		return *f.ContextAccount
+	default:
+		return false
+	}
+}
+
+// FilterKeyword stores a single keyword to filter statuses against.
+type FilterKeyword struct {
+	ID        string         `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                     // id of this item in the database
+	CreatedAt time.Time      `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                  // when was item created
+	UpdatedAt time.Time      `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                  // when was item last updated
+	AccountID string         `bun:"type:CHAR(26),notnull,nullzero"`                                               // ID of the local account that created the filter keyword.
+	FilterID  string         `bun:"type:CHAR(26),notnull,nullzero,unique:filter_keywords_filter_id_keyword_uniq"` // ID of the filter that this keyword belongs to.
+	Filter    *Filter        `bun:"-"`                                                                            // Filter corresponding to FilterID
+	Keyword   string         `bun:",nullzero,notnull,unique:filter_keywords_filter_id_keyword_uniq"`              // The keyword or phrase to filter against.
+	WholeWord *bool          `bun:",nullzero,notnull,default:false"`                                              // Should the filter consider word boundaries?
+	Regexp    *regexp.Regexp `bun:"-"`                                                                            // pre-prepared regular expression
+}
+
+// Compile will compile this FilterKeyword as a prepared regular expression.
+//
+// Matching is always case-insensitive. If WholeWord is set, the keyword
+// will only match on word boundaries, but only at ends of the keyword that
+// are themselves word characters (just like Mastodon does it).
+func (k *FilterKeyword) Compile() (err error) {
+	var (
+		quoted = regexp.QuoteMeta(k.Keyword)
+		prefix string
+		suffix string
+	)
+
+	if k.WholeWord != nil && *k.WholeWord && k.Keyword != "" {
+		if wordChar.MatchString(k.Keyword[:1]) {
+			prefix = `\b`
+		}
+		if wordChar.MatchString(k.Keyword[len(k.Keyword)-1:]) {
+			suffix = `\b`
+		}
+	}
+
+	// Compile keyword filter regexp.
+	k.Regexp, err = regexp.Compile(`(?i)` + prefix + quoted + suffix)
+	return // caller is expected to wrap this error
+}
+
+// wordChar matches a single regexp word character.
+var wordChar = regexp.MustCompile(`\w`)
+
+// FilterStatus stores a single status to filter.
+type FilterStatus struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                       // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                    // when was item created
+	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                    // when was item last updated
+	AccountID string    `bun:"type:CHAR(26),notnull,nullzero"`                                                 // ID of the local account that created the filter status.
+	FilterID  string    `bun:"type:CHAR(26),notnull,nullzero,unique:filter_statuses_filter_id_status_id_uniq"` // ID of the filter that this status belongs to.
+	Filter    *Filter   `bun:"-"`                                                                              // Filter corresponding to FilterID
+	StatusID  string    `bun:"type:CHAR(26),notnull,nullzero,unique:filter_statuses_filter_id_status_id_uniq"` // ID of the status to filter.
+}
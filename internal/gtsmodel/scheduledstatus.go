@@ -0,0 +1,65 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ScheduledStatus represents a status which has been
+// drafted by a local account, to be published at a
+// given time in the future.
+type ScheduledStatus struct {
+	ID               string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt        time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt        time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID        string             `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local account that scheduled this status.
+	Account          *Account           `bun:"-"`                                                           // Account corresponding to AccountID.
+	ScheduledAt      time.Time          `bun:"type:timestamptz,nullzero,notnull"`                           // Time at which the status should be published.
+	Text             string             `bun:""`                                                            // Text of the status, as submitted.
+	ContentType      string             `bun:",nullzero"`                                                   // Content type with which to parse Text.
+	SpoilerText      string             `bun:",nullzero"`                                                   // Content warning of the status, as submitted.
+	Sensitive        *bool              `bun:",nullzero,notnull,default:false"`                             // Mark the status as sensitive?
+	Visibility       Visibility         `bun:",nullzero,notnull"`                                           // Visibility of the status.
+	Federated        *bool              `bun:",notnull"`                                                    // This status will be federated beyond the local timeline(s)
+	Boostable        *bool              `bun:",notnull"`                                                    // This status can be boosted/reblogged
+	Replyable        *bool              `bun:",notnull"`                                                    // This status can be replied to
+	Likeable         *bool              `bun:",notnull"`                                                    // This status can be liked/faved
+	Language         string             `bun:",nullzero"`                                                   // Language of the status. If empty, account default is used.
+	InReplyToID      string             `bun:"type:CHAR(26),nullzero"`                                      // ID of the status this status will reply to, if any.
+	MediaIDs         []string           `bun:"attachments,array"`                                           // IDs of media attachments to attach to the status.
+	MediaAttachments []*MediaAttachment `bun:"-"`                                                           // Attachments corresponding to MediaIDs.
+	PollOptions      []string           `bun:",nullzero"`                                                   // Options of the status poll, if any.
+	PollExpiresIn    int                `bun:",nullzero"`                                                   // Seconds after publishing that the status poll should expire.
+	PollMultiple     *bool              `bun:",nullzero"`                                                   // Allow multiple choices in the status poll.
+	PollHideTotals   *bool              `bun:",nullzero"`                                                   // Hide vote totals of the status poll until it has expired.
+	ApplicationID    string             `bun:"type:CHAR(26),nullzero"`                                      // ID of the application used to schedule this status.
+	Application      *Application       `bun:"-"`                                                           // Application corresponding to ApplicationID.
+}
+
+// MediaAttachmentsPopulated returns whether media attachments are populated according to current MediaIDs.
+func (s *ScheduledStatus) MediaAttachmentsPopulated() bool {
+	if len(s.MediaIDs) != len(s.MediaAttachments) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range s.MediaIDs {
+		if s.MediaAttachments[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
@@ -29,6 +29,7 @@ type Status struct {
 	UpdatedAt                time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
 	FetchedAt                time.Time          `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
 	PinnedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status was pinned by owning account at this time.
+	EditedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // when was status last edited by its author; zero if never edited
 	URI                      string             `bun:",unique,nullzero,notnull"`                                    // activitypub URI of this status
 	URL                      string             `bun:",nullzero"`                                                   // web url for viewing this status
 	Content                  string             `bun:""`                                                            // content of this status; likely html-formatted but not guaranteed
@@ -57,6 +58,10 @@ type Status struct {
 	ThreadID                 string             `bun:"type:CHAR(26),nullzero"`                                      // id of the thread to which this status belongs; only set for remote statuses if a local account is involved at some point in the thread, otherwise null
 	PollID                   string             `bun:"type:CHAR(26),nullzero"`                                      //
 	Poll                     *Poll              `bun:"-"`                                                           //
+	CardID                   string             `bun:"type:CHAR(26),nullzero"`                                      // id of the preview card for the first link in this status
+	Card                     *Card              `bun:"-"`                                                           // preview card corresponding to cardID
+	EditIDs                  []string           `bun:"edits,array"`                                                 // Database IDs of previous revisions of this status, oldest first
+	Edits                    []*StatusEdit      `bun:"-"`                                                           // Previous revisions corresponding to editIDs
 	ContentWarning           string             `bun:",nullzero"`                                                   // cw string for this status
 	Visibility               Visibility         `bun:",nullzero,notnull"`                                           // visibility entry for this status
 	Sensitive                *bool              `bun:",nullzero,notnull,default:false"`                             // mark the status as sensitive?
@@ -211,6 +216,22 @@ func (s *Status) IsLocal() bool {
 	return s.Local != nil && *s.Local
 }
 
+// IsSensitive returns whether this status should be
+// presented as sensitive, either because it was marked
+// so by its author, or because it has media attached
+// and its author's account has been sensitized by an
+// admin. The status account must be populated for the
+// latter check to be done.
+func (s *Status) IsSensitive() bool {
+	if s.Sensitive != nil && *s.Sensitive {
+		return true
+	}
+
+	return len(s.AttachmentIDs) != 0 &&
+		s.Account != nil &&
+		s.Account.IsSensitized()
+}
+
 // StatusToTag is an intermediate struct to facilitate the many2many relationship between a status and one or more tags.
 type StatusToTag struct {
 	StatusID string  `bun:"type:CHAR(26),unique:statustag,nullzero,notnull"`
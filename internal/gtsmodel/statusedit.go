@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// StatusEdit represents a previous revision of a Status, stored
+// when the status is edited (either locally, or by a remote Update).
+//
+// The stored fields are those of the status *before* the edit was
+// applied, and CreatedAt is the time at which that revision was first
+// created (ie., the status creation time, or the time of the previous edit).
+type StatusEdit struct {
+	ID                     string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt              time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was this revision of the status created
+	StatusID               string             `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the status this is a revision of
+	Content                string             `bun:""`                                                            // content of this revision; likely html-formatted but not guaranteed
+	ContentWarning         string             `bun:",nullzero"`                                                   // cw string for this revision
+	Text                   string             `bun:""`                                                            // original text of this revision without formatting (local statuses only)
+	Language               string             `bun:",nullzero"`                                                   // language of this revision
+	Sensitive              *bool              `bun:",nullzero,notnull,default:false"`                             // was this revision marked as sensitive?
+	AttachmentIDs          []string           `bun:"attachments,array"`                                           // database IDs of media attachments of this revision
+	Attachments            []*MediaAttachment `bun:"-"`                                                           // attachments corresponding to attachmentIDs
+	AttachmentDescriptions []string           `bun:",nullzero"`                                                   // descriptions of attachments at the time of this revision, by index
+	PollOptions            []string           `bun:",nullzero"`                                                   // poll options of this revision, if it had a poll
+	PollVotes              []int              `bun:",nullzero"`                                                   // poll vote counts of this revision, if it had a poll, by index
+}
+
+// AttachmentsPopulated returns whether media attachments are populated according to current AttachmentIDs.
+func (e *StatusEdit) AttachmentsPopulated() bool {
+	if len(e.AttachmentIDs) != len(e.Attachments) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range e.AttachmentIDs {
+		if e.Attachments[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// PinnedSuggestion represents an account that an admin has
+// pinned to always be suggested to local users as an account
+// to follow, for example a moderator or an instance news account.
+type PinnedSuggestion struct {
+	ID              string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	AccountID       string    `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the admin account that pinned the suggestion
+	Account         *Account  `bun:"-"`                                                           // admin account that pinned the suggestion
+	TargetAccountID string    `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // id of the suggested account
+	TargetAccount   *Account  `bun:"-"`                                                           // suggested account
+}
+
+// DismissedSuggestion represents an account having dismissed
+// the suggestion to follow another account, such that it will
+// no longer be suggested to them.
+type DismissedSuggestion struct {
+	ID              string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                      // id of this item in the database
+	CreatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                   // when was item created
+	AccountID       string    `bun:"type:CHAR(26),unique:dismissed_suggestions_account_id_target_account_id_uniq,nullzero,notnull"` // id of the account that dismissed the suggestion
+	TargetAccountID string    `bun:"type:CHAR(26),unique:dismissed_suggestions_account_id_target_account_id_uniq,nullzero,notnull"` // id of the dismissed suggested account
+}
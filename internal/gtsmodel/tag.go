@@ -28,3 +28,33 @@ type Tag struct {
 	Useable   *bool     `bun:",nullzero,notnull,default:true"`                              // Tag is useable on this instance.
 	Listable  *bool     `bun:",nullzero,notnull,default:true"`                              // Tagged statuses can be listed on this instance.
 }
+
+// FollowedTag represents an account following a hashtag, such that
+// public statuses using that hashtag appear in the account's home timeline.
+type FollowedTag struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                   // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                // when was item created
+	AccountID string    `bun:"type:CHAR(26),unique:followed_tags_account_id_tag_id_uniq,nullzero,notnull"` // id of the account following the tag
+	Account   *Account  `bun:"-"`                                                                          // account following the tag
+	TagID     string    `bun:"type:CHAR(26),unique:followed_tags_account_id_tag_id_uniq,nullzero,notnull"` // id of the followed tag
+	Tag       *Tag      `bun:"-"`                                                                          // the followed tag
+}
+
+// MaxFeaturedTags is the maximum number of
+// hashtags an account can feature on their profile.
+const MaxFeaturedTags = 10
+
+// FeaturedTag represents an account featuring a hashtag on
+// their profile, along with usage stats of the hashtag in
+// statuses authored by that account.
+type FeaturedTag struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                   // id of this item in the database
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                // when was item created
+	UpdatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                // when was item last updated
+	AccountID     string    `bun:"type:CHAR(26),unique:featured_tags_account_id_tag_id_uniq,nullzero,notnull"` // id of the account featuring the tag
+	Account       *Account  `bun:"-"`                                                                          // account featuring the tag
+	TagID         string    `bun:"type:CHAR(26),unique:featured_tags_account_id_tag_id_uniq,nullzero,notnull"` // id of the featured tag
+	Tag           *Tag      `bun:"-"`                                                                          // the featured tag
+	StatusesCount int       `bun:",notnull,default:0"`                                                         // number of statuses by the account using the tag
+	LastStatusAt  time.Time `bun:"type:timestamptz,nullzero"`                                                  // when the account last used the tag in a status
+}
@@ -0,0 +1,122 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Trend represents a hashtag, status or link that is
+// trending among statuses seen by this instance, along
+// with its latest score and admin review state.
+type Trend struct {
+	ID         string         `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                         // id of this item in the database
+	CreatedAt  time.Time      `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`      // when was item created
+	UpdatedAt  time.Time      `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`      // when was item last updated (ie., score last refreshed)
+	Type       TrendType      `bun:",nullzero,notnull,unique:trends_type_target_id_uniq"`              // type of the trending item
+	TargetID   string         `bun:"type:CHAR(26),nullzero,notnull,unique:trends_type_target_id_uniq"` // id of the trending item (ie., tag, status or card ID)
+	Tag        *Tag           `bun:"-"`                                                                // trending tag, if Type is TrendTypeTag
+	Status     *Status        `bun:"-"`                                                                // trending status, if Type is TrendTypeStatus
+	Card       *Card          `bun:"-"`                                                                // preview card of trending link, if Type is TrendTypeLink
+	Score      float64        `bun:",notnull,default:0"`                                               // time-decayed score of the trend as of UpdatedAt, higher = more trendy
+	History    []TrendHistory // per-day usage history of the trend, most recent day first
+	Review     TrendReview    `bun:",nullzero,notnull,default:1"` // admin review state of the trend
+	ReviewedAt time.Time      `bun:"type:timestamptz,nullzero"`   // when was the trend last reviewed by an admin
+}
+
+// Trending returns true if trend
+// is approved and has a positive score.
+func (t *Trend) Trending() bool {
+	return t.Review == TrendReviewApproved && t.Score > 0
+}
+
+// TrendHistory represents usage
+// of a trend on one calendar day.
+type TrendHistory struct {
+	Day      time.Time `json:"day"`      // midnight (UTC) of the day
+	Uses     int       `json:"uses"`     // number of uses that day
+	Accounts int       `json:"accounts"` // number of distinct accounts using it that day
+}
+
+// TrendType denotes the
+// type of a trending item.
+type TrendType uint8
+
+const (
+	TrendTypeUnknown TrendType = iota
+	TrendTypeTag               // Trending hashtag.
+	TrendTypeStatus            // Trending status.
+	TrendTypeLink              // Trending link.
+)
+
+func (t TrendType) String() string {
+	switch t {
+	case TrendTypeTag:
+		return "tag"
+	case TrendTypeStatus:
+		return "status"
+	case TrendTypeLink:
+		return "link"
+	default:
+		return "unknown"
+	}
+}
+
+func NewTrendType(in string) TrendType {
+	switch in {
+	case "tag", "tags":
+		return TrendTypeTag
+	case "status", "statuses":
+		return TrendTypeStatus
+	case "link", "links":
+		return TrendTypeLink
+	default:
+		return TrendTypeUnknown
+	}
+}
+
+// TrendReview denotes the admin
+// review state of a trending item.
+type TrendReview uint8
+
+const (
+	TrendReviewUnknown  TrendReview = iota
+	TrendReviewPending              // Not yet reviewed, will not be shown.
+	TrendReviewApproved             // Approved, will be shown while trending.
+	TrendReviewRejected             // Rejected, will never be shown.
+)
+
+func (r TrendReview) String() string {
+	switch r {
+	case TrendReviewPending:
+		return "pending"
+	case TrendReviewApproved:
+		return "approved"
+	case TrendReviewRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// TrendUse represents one use of a hashtag or link in
+// a status. It is not stored in the database, but used
+// to calculate hashtag and link trends.
+type TrendUse struct {
+	TargetID  string
+	AccountID string
+	CreatedAt time.Time
+}
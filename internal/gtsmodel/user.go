@@ -25,37 +25,65 @@ import (
 // User represents an actual human user of gotosocial. Note, this is a LOCAL gotosocial user, not a remote account.
 // To cross reference this local user with their account (which can be local or remote), use the AccountID field.
 type User struct {
-	ID                     string       `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
-	CreatedAt              time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
-	UpdatedAt              time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
-	Email                  string       `bun:",nullzero,unique"`                                            // confirmed email address for this user, this should be unique -- only one email address registered per instance, multiple users per email are not supported
-	AccountID              string       `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // The id of the local gtsmodel.Account entry for this user.
-	Account                *Account     `bun:"rel:belongs-to"`                                              // Pointer to the account of this user that corresponds to AccountID.
-	EncryptedPassword      string       `bun:",nullzero,notnull"`                                           // The encrypted password of this user, generated using https://pkg.go.dev/golang.org/x/crypto/bcrypt#GenerateFromPassword. A salt is included so we're safe against 🌈 tables.
-	SignUpIP               net.IP       `bun:",nullzero"`                                                   // From what IP was this user created?
-	CurrentSignInAt        time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did the user sign in with their current session.
-	CurrentSignInIP        net.IP       `bun:",nullzero"`                                                   // What's the most recent IP of this user
-	LastSignInAt           time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did this user last sign in?
-	LastSignInIP           net.IP       `bun:",nullzero"`                                                   // What's the previous IP of this user?
-	SignInCount            int          `bun:",notnull,default:0"`                                          // How many times has this user signed in?
-	InviteID               string       `bun:"type:CHAR(26),nullzero"`                                      // id of the user who invited this user (who let this joker in?)
-	ChosenLanguages        []string     `bun:",nullzero"`                                                   // What languages does this user want to see?
-	FilteredLanguages      []string     `bun:",nullzero"`                                                   // What languages does this user not want to see?
-	Locale                 string       `bun:",nullzero"`                                                   // In what timezone/locale is this user located?
-	CreatedByApplicationID string       `bun:"type:CHAR(26),nullzero"`                                      // Which application id created this user? See gtsmodel.Application
-	CreatedByApplication   *Application `bun:"rel:belongs-to"`                                              // Pointer to the application corresponding to createdbyapplicationID.
-	LastEmailedAt          time.Time    `bun:"type:timestamptz,nullzero"`                                   // When was this user last contacted by email.
-	ConfirmationToken      string       `bun:",nullzero"`                                                   // What confirmation token did we send this user/what are we expecting back?
-	ConfirmationSentAt     time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we send email confirmation to this user?
-	ConfirmedAt            time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did the user confirm their email address
-	UnconfirmedEmail       string       `bun:",nullzero"`                                                   // Email address that hasn't yet been confirmed
-	Moderator              *bool        `bun:",nullzero,notnull,default:false"`                             // Is this user a moderator?
-	Admin                  *bool        `bun:",nullzero,notnull,default:false"`                             // Is this user an admin?
-	Disabled               *bool        `bun:",nullzero,notnull,default:false"`                             // Is this user disabled from posting?
-	Approved               *bool        `bun:",nullzero,notnull,default:false"`                             // Has this user been approved by a moderator?
-	ResetPasswordToken     string       `bun:",nullzero"`                                                   // The generated token that the user can use to reset their password
-	ResetPasswordSentAt    time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we email the user their reset-password email?
-	ExternalID             string       `bun:",nullzero,unique"`                                            // If the login for the user is managed externally (e.g OIDC), we need to keep a stable reference to the external object (e.g OIDC sub claim)
+	ID                       string       `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt                time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt                time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Email                    string       `bun:",nullzero,unique"`                                            // confirmed email address for this user, this should be unique -- only one email address registered per instance, multiple users per email are not supported
+	AccountID                string       `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // The id of the local gtsmodel.Account entry for this user.
+	Account                  *Account     `bun:"rel:belongs-to"`                                              // Pointer to the account of this user that corresponds to AccountID.
+	EncryptedPassword        string       `bun:",nullzero,notnull"`                                           // The encrypted password of this user, generated using https://pkg.go.dev/golang.org/x/crypto/bcrypt#GenerateFromPassword. A salt is included so we're safe against 🌈 tables.
+	SignUpIP                 net.IP       `bun:",nullzero"`                                                   // From what IP was this user created?
+	CurrentSignInAt          time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did the user sign in with their current session.
+	CurrentSignInIP          net.IP       `bun:",nullzero"`                                                   // What's the most recent IP of this user
+	LastSignInAt             time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did this user last sign in?
+	LastSignInIP             net.IP       `bun:",nullzero"`                                                   // What's the previous IP of this user?
+	SignInCount              int          `bun:",notnull,default:0"`                                          // How many times has this user signed in?
+	InviteID                 string       `bun:"type:CHAR(26),nullzero"`                                      // id of the invite used to register this user, if any (who let this joker in?)
+	ChosenLanguages          []string     `bun:",nullzero"`                                                   // What languages does this user want to see?
+	FilteredLanguages        []string     `bun:",nullzero"`                                                   // What languages does this user not want to see?
+	Locale                   string       `bun:",nullzero"`                                                   // In what timezone/locale is this user located?
+	CreatedByApplicationID   string       `bun:"type:CHAR(26),nullzero"`                                      // Which application id created this user? See gtsmodel.Application
+	CreatedByApplication     *Application `bun:"rel:belongs-to"`                                              // Pointer to the application corresponding to createdbyapplicationID.
+	LastEmailedAt            time.Time    `bun:"type:timestamptz,nullzero"`                                   // When was this user last contacted by email.
+	ConfirmationToken        string       `bun:",nullzero"`                                                   // What confirmation token did we send this user/what are we expecting back?
+	ConfirmationSentAt       time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we send email confirmation to this user?
+	ConfirmedAt              time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did the user confirm their email address
+	UnconfirmedEmail         string       `bun:",nullzero"`                                                   // Email address that hasn't yet been confirmed
+	Moderator                *bool        `bun:",nullzero,notnull,default:false"`                             // Is this user a moderator?
+	Admin                    *bool        `bun:",nullzero,notnull,default:false"`                             // Is this user an admin?
+	Disabled                 *bool        `bun:",nullzero,notnull,default:false"`                             // Is this user disabled from posting?
+	Approved                 *bool        `bun:",nullzero,notnull,default:false"`                             // Has this user been approved by a moderator?
+	ResetPasswordToken       string       `bun:",nullzero"`                                                   // The generated token that the user can use to reset their password
+	ResetPasswordSentAt      time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we email the user their reset-password email?
+	ExternalID               string       `bun:",nullzero,unique"`                                            // If the login for the user is managed externally (e.g OIDC), we need to keep a stable reference to the external object (e.g OIDC sub claim)
+	EmailNotifyFollow        *bool        `bun:",nullzero,notnull,default:false"`                             // Email the user when someone follows them?
+	EmailNotifyFollowRequest *bool        `bun:",nullzero,notnull,default:false"`                             // Email the user when someone requests to follow them?
+	EmailNotifyMention       *bool        `bun:",nullzero,notnull,default:false"`                             // Email the user when someone mentions them in a non-direct status?
+	EmailNotifyDirect        *bool        `bun:",nullzero,notnull,default:false"`                             // Email the user when someone mentions them in a direct message?
+	EmailNotifyReport        *bool        `bun:",nullzero,notnull,default:true"`                              // Email the user when a report they submitted is closed?
+	EmailDigest              EmailDigest  `bun:",nullzero"`                                                   // How often to email the user a digest of their unread notifications, if at all.
+	EmailDigestSentAt        time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we last email the user a digest of unread notifications?
+	EmailUnsubscribeToken    string       `bun:",nullzero,unique"`                                            // Token included in notification emails, allowing the user to unsubscribe from them in one click.
+	TwoFactorSecret          string       `bun:",nullzero"`                                                   // Base32 encoded TOTP secret of this user, set on enrollment in two-factor authentication.
+	TwoFactorEnabledAt       time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did the user enable two-factor authentication? Zero if not enabled.
+	TwoFactorBackups         []string     `bun:"two_factor_backups,array"`                                    // SHA256 hashes of the user's remaining single-use two-factor recovery codes.
+	TwoFactorLastCounter     int64        `bun:",nullzero"`                                                   // TOTP time step of the last code accepted for this user, so that codes can't be replayed.
+}
+
+// EmailDigest is how often a user is
+// emailed a digest of their unread notifications.
+type EmailDigest string
+
+const (
+	EmailDigestNever  EmailDigest = ""       // no digest
+	EmailDigestDaily  EmailDigest = "daily"  // digest once a day
+	EmailDigestWeekly EmailDigest = "weekly" // digest once a week
+)
+
+// TwoFactorEnabled returns whether the user
+// has enabled two-factor authentication.
+func (u *User) TwoFactorEnabled() bool {
+	return !u.TwoFactorEnabledAt.IsZero()
 }
 
 // NewSignup models parameters for the creation
@@ -76,4 +104,5 @@ type NewSignup struct {
 	EmailVerified bool   // Mark submitted email address as already verified (optional).
 	ExternalID    string // ID of this user in external OIDC system (optional).
 	Admin         bool   // Mark new user as an admin user (optional).
+	InviteID      string // ID of the invite used to register the new user (optional).
 }
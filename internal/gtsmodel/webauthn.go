@@ -0,0 +1,35 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// WebAuthnCredential is a WebAuthn public key credential (ie., a passkey
+// or security key) registered by a local user. Credentials can be used
+// to sign in passwordless, or as a second factor after a password.
+type WebAuthnCredential struct {
+	ID           string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt    time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt    time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	UserID       string    `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local user that registered this credential.
+	Name         string    `bun:",nullzero,notnull"`                                           // Name given to this credential by the user, eg., "yubikey".
+	CredentialID string    `bun:",nullzero,notnull,unique"`                                    // Base64url encoded credential ID chosen by the authenticator.
+	PublicKey    []byte    `bun:",nullzero,notnull"`                                           // CBOR encoded COSE_Key public key of this credential.
+	SignCount    int64     `bun:",notnull,default:0"`                                          // Last signature counter value reported by the authenticator.
+	LastUsedAt   time.Time `bun:"type:timestamptz,nullzero"`                                   // When was this credential last used to sign in?
+}
@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// WebPushSubscription represents a subscription, created
+// by a local account through a client application, to have
+// its notifications pushed to a Web Push (RFC 8030) endpoint.
+//
+// Each OAuth token can have at most one subscription, as
+// per the Mastodon API.
+type WebPushSubscription struct {
+	ID                 string                    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time                 `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time                 `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID          string                    `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local account that created this subscription.
+	TokenID            string                    `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // ID of the OAuth token through which this subscription was created.
+	Endpoint           string                    `bun:",nullzero,notnull"`                                           // URL of the push service endpoint to deliver to.
+	Auth               string                    `bun:",nullzero,notnull"`                                           // Base64 encoded authentication secret of the user agent.
+	P256dh             string                    `bun:",nullzero,notnull"`                                           // Base64 encoded P-256 ECDH public key of the user agent.
+	AlertFollow        *bool                     `bun:",nullzero,notnull,default:false"`                             // Push notifications of type follow?
+	AlertFollowRequest *bool                     `bun:",nullzero,notnull,default:false"`                             // Push notifications of type follow_request?
+	AlertFavourite     *bool                     `bun:",nullzero,notnull,default:false"`                             // Push notifications of type favourite?
+	AlertMention       *bool                     `bun:",nullzero,notnull,default:false"`                             // Push notifications of type mention?
+	AlertReblog        *bool                     `bun:",nullzero,notnull,default:false"`                             // Push notifications of type reblog?
+	AlertPoll          *bool                     `bun:",nullzero,notnull,default:false"`                             // Push notifications of type poll?
+	AlertStatus        *bool                     `bun:",nullzero,notnull,default:false"`                             // Push notifications of type status?
+	AlertSignup        *bool                     `bun:",nullzero,notnull,default:false"`                             // Push notifications of type admin.sign_up?
+	Policy             WebPushSubscriptionPolicy `bun:",nullzero,notnull,default:'all'"`                             // From which accounts to push notifications.
+}
+
+// AlertEnabled returns whether this subscription
+// wants notifications of the given type pushed.
+func (s *WebPushSubscription) AlertEnabled(notificationType NotificationType) bool {
+	var alert *bool
+
+	switch notificationType {
+	case NotificationFollow:
+		alert = s.AlertFollow
+	case NotificationFollowRequest:
+		alert = s.AlertFollowRequest
+	case NotificationFave:
+		alert = s.AlertFavourite
+	case NotificationMention:
+		alert = s.AlertMention
+	case NotificationReblog:
+		alert = s.AlertReblog
+	case NotificationPoll:
+		alert = s.AlertPoll
+	case NotificationStatus:
+		alert = s.AlertStatus
+	case NotificationSignup:
+		alert = s.AlertSignup
+	}
+
+	return alert != nil && *alert
+}
+
+// WebPushSubscriptionPolicy determines from which
+// accounts notifications should be pushed.
+type WebPushSubscriptionPolicy string
+
+const (
+	WebPushSubscriptionPolicyAll      WebPushSubscriptionPolicy = "all"      // Push notifications from all accounts.
+	WebPushSubscriptionPolicyFollowed WebPushSubscriptionPolicy = "followed" // Push notifications from accounts the subscriber follows.
+	WebPushSubscriptionPolicyFollower WebPushSubscriptionPolicy = "follower" // Push notifications from accounts following the subscriber.
+	WebPushSubscriptionPolicyNone     WebPushSubscriptionPolicy = "none"     // Push no notifications at all.
+)
+
+// VAPIDKeyPair represents the instance's Voluntary Application
+// Server Identification (RFC 8292) key pair, used to sign
+// requests to Web Push endpoints. There is only ever one.
+type VAPIDKeyPair struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	Public    string    `bun:",nullzero,notnull"`                                           // Base64 (raw url) encoded, uncompressed P-256 public key.
+	Private   string    `bun:",nullzero,notnull"`                                           // Base64 (raw url) encoded, SEC 1 DER form P-256 private key.
+}
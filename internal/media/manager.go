@@ -21,10 +21,13 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net/url"
 	"time"
 
 	"codeberg.org/gruf/go-iotools"
 	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -169,6 +172,45 @@ func (m *Manager) PreProcessMedia(
 	return processingMedia
 }
 
+// isMediaRejected returns whether the given remote media should
+// not be fetched, because either the domain of the account that
+// owns it, or the domain hosting it, is blocked with reject_media.
+// Local media (no remote URL) is never rejected.
+func (m *Manager) isMediaRejected(ctx context.Context, attachment *gtsmodel.MediaAttachment) (bool, error) {
+	if attachment.RemoteURL == "" {
+		return false, nil
+	}
+
+	if attachment.AccountID != "" {
+		// The owning account may not be stored yet
+		// (eg., avatar of a new account), in which
+		// case we can only go by the hosting domain.
+		account, err := m.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			attachment.AccountID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return false, gtserror.Newf("error getting account %s: %w", attachment.AccountID, err)
+		}
+
+		if account != nil && account.Domain != "" {
+			// Media may be hosted elsewhere (eg., on a CDN),
+			// so check the domain of the owning account first.
+			rejected, err := m.state.DB.IsDomainMediaRejected(ctx, account.Domain)
+			if err != nil || rejected {
+				return rejected, err
+			}
+		}
+	}
+
+	uri, err := url.Parse(attachment.RemoteURL)
+	if err != nil {
+		return false, gtserror.Newf("error parsing remote url %s: %w", attachment.RemoteURL, err)
+	}
+
+	return m.state.DB.IsDomainMediaRejected(ctx, uri.Hostname())
+}
+
 // PreProcessMediaRecache refetches, reprocesses,
 // and recaches an existing attachment that has
 // been uncached via cleaner pruning.
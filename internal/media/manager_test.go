@@ -33,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type ManagerTestSuite struct {
@@ -501,6 +502,81 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessPartial() {
 	suite.False(stored)
 }
 
+func (suite *ManagerTestSuite) TestRemoteMediaRejected() {
+	ctx := context.Background()
+
+	// Reject media from the remote domain.
+	if err := suite.db.CreateDomainBlock(ctx, &gtsmodel.DomainBlock{
+		ID:                 "01HRWG0GBWZ3RJMJ5PW1TTNX2K",
+		Domain:             "fossbros-anonymous.io",
+		CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		Severity:           gtsmodel.DomainBlockSeverityNoop,
+		RejectMedia:        util.Ptr(true),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		suite.FailNow("data function should not be called for rejected media")
+		return nil, 0, nil
+	}
+
+	accountID := "01F8MH5ZK5VRH73AKHQM6Y9VNX"
+	remoteURL := "http://fossbros-anonymous.io/attachments/original/13bbc3f8-2b5e-46ea-9531-40b4974d9912.jpg"
+
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
+		RemoteURL: &remoteURL,
+	})
+
+	// Loading should fail, but leave a placeholder.
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.ErrorContains(err, "is rejected")
+	suite.NotNil(attachment)
+
+	// Placeholder should be stored in the database, uncached.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(remoteURL, dbAttachment.RemoteURL)
+	suite.Equal(gtsmodel.FileTypeUnknown, dbAttachment.Type)
+	suite.False(*dbAttachment.Cached)
+}
+
+func (suite *ManagerTestSuite) TestRemoteMediaRejectedOnCDN() {
+	ctx := context.Background()
+
+	// Reject media from the remote domain.
+	if err := suite.db.CreateDomainBlock(ctx, &gtsmodel.DomainBlock{
+		ID:                 "01HRWG0GBWZ3RJMJ5PW1TTNX2K",
+		Domain:             "fossbros-anonymous.io",
+		CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+		Severity:           gtsmodel.DomainBlockSeverityNoop,
+		RejectMedia:        util.Ptr(true),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		suite.FailNow("data function should not be called for rejected media")
+		return nil, 0, nil
+	}
+
+	// Media is owned by an account on the rejected
+	// domain, but served from a different host.
+	accountID := "01F8MH5ZK5VRH73AKHQM6Y9VNX"
+	remoteURL := "https://cdn.example.org/attachments/original/13bbc3f8-2b5e-46ea-9531-40b4974d9912.jpg"
+
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
+		RemoteURL: &remoteURL,
+	})
+
+	// Loading should still fail.
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.ErrorContains(err, "is rejected")
+	suite.NotNil(attachment)
+}
+
 func (suite *ManagerTestSuite) TestPDFProcess() {
 	ctx := context.Background()
 
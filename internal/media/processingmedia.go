@@ -129,6 +129,35 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 			p.err = err
 		}()
 
+		// Check whether we're allowed to fetch
+		// this media before calling data func.
+		var rejected bool
+		rejected, err = p.mgr.isMediaRejected(ctx, p.media)
+		if err != nil {
+			return err
+		}
+
+		if rejected {
+			if !p.recache {
+				// Store the uncached attachment as a
+				// placeholder, so that users can still
+				// click through to the remote server.
+				// (Finishing uncached media only sets
+				// thumbnail details, it doesn't fetch.)
+				if err = p.finish(ctx); err != nil {
+					return err
+				}
+
+				err = p.mgr.state.DB.PutAttachment(ctx, p.media)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = gtserror.Newf("media from %s is rejected", p.media.RemoteURL)
+			return err
+		}
+
 		// Gather errors as we proceed.
 		var errs = gtserror.NewMultiError(4)
 
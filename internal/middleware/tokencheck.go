@@ -33,7 +33,8 @@ import (
 //
 // If no token was set in the Authorization header, or the token was invalid, the handler will return.
 //
-// If a valid oauth Bearer token was provided, it will be set on the gin context for further use.
+// If a valid oauth Bearer token was provided, it will be set on the gin context for further use,
+// and its access token passed to tokenUsed, so that the time the token was last used can be tracked.
 //
 // Then, it will check which *gtsmodel.User the token belongs to. If the user is not confirmed, not approved,
 // or has been disabled, then the middleware will return early. Otherwise, the User will be set on the
@@ -48,7 +49,11 @@ import (
 // If an invalid token is presented, or a user/account/application can't be found, then this middleware
 // won't abort the request, since the server might want to still allow public requests that don't have a
 // Bearer token set (eg., for public instance information and so on).
-func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.TokenInfo, error)) func(*gin.Context) {
+func TokenCheck(
+	dbConn db.DB,
+	validateBearerToken func(r *http.Request) (oauth2.TokenInfo, error),
+	tokenUsed func(access string),
+) func(*gin.Context) {
 	return func(c *gin.Context) {
 		// Acquire context from gin request.
 		ctx := c.Request.Context()
@@ -65,6 +70,11 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 		}
 		c.Set(oauth.SessionAuthorizedToken, ti)
 
+		// Mark token as used; this is cheap, as
+		// last used times are only written to
+		// the database periodically in batches.
+		tokenUsed(ti.GetAccess())
+
 		// check for user-level token
 		if userID := ti.GetUserID(); userID != "" {
 			log.Tracef(ctx, "authenticated user %s with bearer token, scope is %s", userID, ti.GetScope())
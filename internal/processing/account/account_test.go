@@ -63,8 +63,10 @@ type AccountStandardTestSuite struct {
 	testFollows      map[string]*gtsmodel.Follow
 	testAttachments  map[string]*gtsmodel.MediaAttachment
 	testStatuses     map[string]*gtsmodel.Status
+	testInvites      map[string]*gtsmodel.Invite
 
 	// module being tested
+	common           common.Processor
 	accountProcessor account.Processor
 }
 
@@ -77,6 +79,7 @@ func (suite *AccountStandardTestSuite) SetupSuite() {
 	suite.testFollows = testrig.NewTestFollows()
 	suite.testAttachments = testrig.NewTestAttachments()
 	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testInvites = testrig.NewTestInvites()
 }
 
 func (suite *AccountStandardTestSuite) SetupTest() {
@@ -114,8 +117,8 @@ func (suite *AccountStandardTestSuite) SetupTest() {
 	suite.emailSender = testrig.NewEmailSender("../../../web/template/", suite.sentEmails)
 
 	filter := visibility.NewFilter(&suite.state)
-	common := common.New(&suite.state, suite.tc, suite.federator, filter)
-	suite.accountProcessor = account.New(&common, &suite.state, suite.tc, suite.mediaManager, suite.oauthServer, suite.federator, filter, processing.GetParseMentionFunc(suite.db, suite.federator))
+	suite.common = common.New(&suite.state, suite.tc, suite.federator, filter)
+	suite.accountProcessor = account.New(&suite.common, &suite.state, suite.tc, suite.mediaManager, suite.oauthServer, suite.federator, filter, processing.GetParseMentionFunc(suite.db, suite.federator))
 	testrig.StandardDBSetup(suite.db, nil)
 	testrig.StandardStorageSetup(suite.storage, "../../../testrig/media")
 }
@@ -19,13 +19,18 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/oauth2/v4"
@@ -41,6 +46,12 @@ func (p *Processor) Create(
 	app *gtsmodel.Application,
 	form *apimodel.AccountCreateRequest,
 ) (*apimodel.Token, gtserror.WithCode) {
+	// Ensure sign-ups from this
+	// email domain are allowed.
+	if errWithCode := p.c.CheckEmailDomain(ctx, form.Email); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	emailAvailable, err := p.state.DB.IsEmailAvailable(ctx, form.Email)
 	if err != nil {
 		err := fmt.Errorf("db error checking email availability: %w", err)
@@ -61,9 +72,27 @@ func (p *Processor) Create(
 		return nil, gtserror.NewErrorConflict(err, err.Error())
 	}
 
-	// Only store reason if one is required.
-	var reason string
-	if config.GetAccountsReasonRequired() {
+	var (
+		reason      string
+		invite      *gtsmodel.Invite
+		inviteID    string
+		preApproved = !config.GetAccountsApprovalRequired() // Mark as approved if no approval required.
+	)
+
+	if form.InviteCode != "" {
+		// Sign up using invite; ensure the invite
+		// is valid. Invited users don't need to
+		// be approved by a moderator.
+		var errWithCode gtserror.WithCode
+		invite, errWithCode = p.getUsableInvite(ctx, form.InviteCode)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		inviteID = invite.ID
+		preApproved = true
+	} else if config.GetAccountsReasonRequired() {
+		// Only store reason if one is required.
 		reason = form.Reason
 	}
 
@@ -72,16 +101,26 @@ func (p *Processor) Create(
 		Email:       form.Email,
 		Password:    form.Password,
 		Reason:      text.SanitizeToPlaintext(reason),
-		PreApproved: !config.GetAccountsApprovalRequired(), // Mark as approved if no approval required.
+		PreApproved: preApproved,
 		SignUpIP:    form.IP,
 		Locale:      form.Locale,
 		AppID:       app.ID,
+		InviteID:    inviteID,
 	})
 	if err != nil {
 		err := fmt.Errorf("db error creating new signup: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if invite != nil {
+		// Only use up the invite now the sign-up
+		// has succeeded, so failed sign-ups don't
+		// count towards the invite's max uses.
+		if errWithCode := p.useInvite(ctx, invite, user); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
 	// Generate access token *before* doing side effects; we
 	// don't want to process side effects if something borks.
 	accessToken, err := p.oauthServer.GenerateUserAccessToken(ctx, appToken, app.ClientSecret, user.ID)
@@ -106,3 +145,54 @@ func (p *Processor) Create(
 		CreatedAt:   accessToken.GetAccessCreateAt().Unix(),
 	}, nil
 }
+
+// getUsableInvite checks that the invite with
+// the given code exists and is usable, returning it.
+func (p *Processor) getUsableInvite(ctx context.Context, code string) (*gtsmodel.Invite, gtserror.WithCode) {
+	if !config.GetAccountsInvitesEnabled() {
+		const help = "invites are not enabled on this server"
+		return nil, gtserror.NewErrorForbidden(errors.New(help), help)
+	}
+
+	invite, err := p.state.DB.GetInviteByCode(gtscontext.SetBarebones(ctx), code)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil || !invite.Usable(time.Now()) {
+		const help = "invite code is invalid, expired, or has been used up"
+		return nil, gtserror.NewErrorForbidden(errors.New(help), help)
+	}
+
+	return invite, nil
+}
+
+// useInvite marks one use of the given invite by the
+// newly signed up user. If the invite was used up by
+// someone else in the meantime, or marking the use
+// fails, the sign-up is undone so it can be retried.
+func (p *Processor) useInvite(ctx context.Context, invite *gtsmodel.Invite, user *gtsmodel.User) gtserror.WithCode {
+	err := p.state.DB.IncrementInviteUses(ctx, invite)
+	if err == nil {
+		return nil
+	}
+
+	// Remove the user and account again, so that
+	// the username and email address are free.
+	if err := p.state.DB.DeleteUserByID(ctx, user.ID); err != nil {
+		log.Errorf(ctx, "db error deleting user %s: %v", user.ID, err)
+	}
+
+	if err := p.state.DB.DeleteAccount(ctx, user.AccountID); err != nil {
+		log.Errorf(ctx, "db error deleting account %s: %v", user.AccountID, err)
+	}
+
+	if errors.Is(err, db.ErrNoEntries) {
+		const help = "invite code is invalid, expired, or has been used up"
+		return gtserror.NewErrorForbidden(errors.New(help), help)
+	}
+
+	err = gtserror.Newf("db error using invite: %w", err)
+	return gtserror.NewErrorInternalError(err)
+}
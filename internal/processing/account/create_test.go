@@ -0,0 +1,195 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type CreateTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *CreateTestSuite) SetupTest() {
+	suite.AccountStandardTestSuite.SetupTest()
+
+	// Invites are off by default.
+	config.SetAccountsInvitesEnabled(true)
+}
+
+func (suite *CreateTestSuite) create(username string, inviteCode string) (*apimodel.Token, gtserror.WithCode) {
+	var (
+		ctx      = context.Background()
+		app      = suite.testApplications["application_1"]
+		appToken = oauth.DBTokenToToken(suite.testTokens["local_account_1_client_application_token"])
+	)
+
+	return suite.accountProcessor.Create(ctx, appToken, app, &apimodel.AccountCreateRequest{
+		Username:   username,
+		Email:      username + "@example.org",
+		Password:   "a very good password indeed, 12345",
+		Agreement:  true,
+		Locale:     "en",
+		IP:         net.ParseIP("192.0.2.1"),
+		InviteCode: inviteCode,
+	})
+}
+
+func (suite *CreateTestSuite) TestCreateWithInvite() {
+	var (
+		ctx    = context.Background()
+		invite = suite.testInvites["admin_account_invite_1"]
+	)
+
+	if _, err := suite.create("invited_person", invite.Code); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	account, err := suite.db.GetAccountByUsernameDomain(ctx, "invited_person", "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user, err := suite.db.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Invited user should be pre-approved,
+	// and linked to the invite they used.
+	suite.True(*user.Approved)
+	suite.Equal(invite.ID, user.InviteID)
+
+	// Invite should now be used once.
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, dbInvite.Uses)
+}
+
+func (suite *CreateTestSuite) TestCreateWithInviteUsedUp() {
+	invite := suite.testInvites["local_account_1_invite_1"]
+
+	// Invite has max uses of 1,
+	// so first use should be fine.
+	if _, err := suite.create("invited_person", invite.Code); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Second use should fail.
+	_, errWithCode := suite.create("another_invited_person", invite.Code)
+	suite.EqualError(errWithCode, "invite code is invalid, expired, or has been used up")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *CreateTestSuite) TestCreateWithInviteExpired() {
+	invite := suite.testInvites["admin_account_invite_expired"]
+
+	_, err := suite.create("invited_person", invite.Code)
+	suite.EqualError(err, "invite code is invalid, expired, or has been used up")
+}
+
+func (suite *CreateTestSuite) TestCreateWithInviteNonexistent() {
+	_, err := suite.create("invited_person", "NOTAREALCODEXXXX")
+	suite.EqualError(err, "invite code is invalid, expired, or has been used up")
+}
+
+func (suite *CreateTestSuite) TestCreateWithInviteDisabled() {
+	config.SetAccountsInvitesEnabled(false)
+
+	invite := suite.testInvites["admin_account_invite_1"]
+
+	_, err := suite.create("invited_person", invite.Code)
+	suite.EqualError(err, "invites are not enabled on this server")
+}
+
+func (suite *CreateTestSuite) blockEmailDomain(domain string) {
+	if err := suite.db.PutEmailDomainBlock(context.Background(), &gtsmodel.EmailDomainBlock{
+		ID:                 "01HT0Q9PV4DJFJ8Q6AW3Y0N3Q5",
+		Domain:             domain,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *CreateTestSuite) TestCreateEmailDomainBlocked() {
+	suite.blockEmailDomain("example.org")
+
+	_, errWithCode := suite.create("blocked_person", "")
+	suite.EqualError(errWithCode, "CheckEmailDomain: email domain example.org is blocked")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *CreateTestSuite) TestCreateEmailParentDomainBlocked() {
+	suite.blockEmailDomain("org")
+
+	_, errWithCode := suite.create("blocked_person", "")
+	suite.EqualError(errWithCode, "CheckEmailDomain: email domain example.org is blocked")
+}
+
+func (suite *CreateTestSuite) TestCreateEmailMXBlocked() {
+	suite.blockEmailDomain("bad-mail-host.example")
+
+	// Pretend example.org has
+	// its mail handled by the
+	// blocked mail provider.
+	suite.common.SetMXResolver(mxResolverFunc(func(name string) ([]*net.MX, error) {
+		if name != "example.org" {
+			return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+		}
+		return []*net.MX{{Host: "mx1.bad-mail-host.example.", Pref: 10}}, nil
+	}))
+
+	_, errWithCode := suite.create("blocked_person", "")
+	suite.EqualError(errWithCode, "CheckEmailDomain: email domain example.org is blocked")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *CreateTestSuite) TestCreateEmailMXNotBlocked() {
+	suite.blockEmailDomain("bad-mail-host.example")
+
+	suite.common.SetMXResolver(mxResolverFunc(func(name string) ([]*net.MX, error) {
+		return []*net.MX{{Host: "mx.good-mail-host.example.", Pref: 10}}, nil
+	}))
+
+	if _, err := suite.create("allowed_person", ""); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+type mxResolverFunc func(name string) ([]*net.MX, error)
+
+func (f mxResolverFunc) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	return f(name)
+}
+
+func TestCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateTestSuite))
+}
@@ -127,6 +127,18 @@ func (p *Processor) deleteUserAndTokensForAccount(ctx context.Context, account *
 		return gtserror.Newf("db error getting user: %w", err)
 	}
 
+	// Delete any web push subscriptions
+	// created with this account's tokens.
+	if err := p.state.DB.DeleteWebPushSubscriptionsByAccountID(ctx, account.ID); err != nil {
+		return gtserror.Newf("db error deleting web push subscriptions: %w", err)
+	}
+
+	// Delete any webauthn
+	// credentials of the user.
+	if err := p.state.DB.DeleteWebAuthnCredentialsByUserID(ctx, user.ID); err != nil {
+		return gtserror.Newf("db error deleting webauthn credentials: %w", err)
+	}
+
 	tokens := []*gtsmodel.Token{}
 	if err := p.state.DB.GetWhere(ctx, []db.Where{{Key: "user_id", Value: user.ID}}, &tokens); err != nil {
 		return gtserror.Newf("db error getting tokens: %w", err)
@@ -477,6 +489,12 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting faves targeting account: %w", err)
 	}
 
+	// Delete all mutes owned by or targeting given account.
+	if err := p.state.DB.DeleteAccountMutes(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting mutes of account: %w", err)
+	}
+
 	// TODO: add status mutes here when they're implemented.
 
 	// Delete all poll votes owned by given account.
@@ -485,6 +503,36 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting poll votes by account: %w", err)
 	}
 
+	// Delete all tags followed by given account.
+	if err := p.state.DB.DeleteFollowedTagsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting followed tags by account: %w", err)
+	}
+
+	// Delete all tags featured by given account.
+	if err := p.state.DB.DeleteFeaturedTagsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting featured tags by account: %w", err)
+	}
+
+	// Delete all suggestions to / from given account.
+	if err := p.state.DB.DeleteSuggestionsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting suggestions by account: %w", err)
+	}
+
+	// Delete any content warning policy targeting given account.
+	if err := p.state.DB.DeleteContentWarningPolicyByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting content warning policy targeting account: %w", err)
+	}
+
+	// Delete all conversations owned by given account.
+	if err := p.state.DB.DeleteConversationsByOwnerAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting conversations by account: %w", err)
+	}
+
 	return nil
 }
 
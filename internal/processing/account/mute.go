@@ -0,0 +1,303 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// MuteCreate handles the creation or updating of a mute from requestingAccount to targetAccountID.
+// The form params should have already been validated.
+func (p *Processor) MuteCreate(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	targetAccountID string,
+	form *apimodel.UserMuteCreateUpdateRequest,
+) (*apimodel.Relationship, gtserror.WithCode) {
+	_, existingMute, errWithCode := p.getMuteTarget(ctx, requestingAccount, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var (
+		// Mute notifications by default.
+		notifications = util.PtrValueOr(form.Notifications, true)
+
+		// No expiry by default.
+		expiresAt time.Time
+	)
+
+	if duration := util.PtrValueOr(form.Duration, 0); duration > 0 {
+		expiresAt = time.Now().Add(time.Duration(duration) * time.Second)
+	}
+
+	if existingMute != nil {
+		// Mute already exists, update its parameters.
+		existingMute.Notifications = &notifications
+		existingMute.ExpiresAt = expiresAt
+
+		if err := p.state.DB.UpdateMute(ctx, existingMute, "notifications", "expires_at"); err != nil {
+			err := gtserror.Newf("error updating mute in db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// Replace any existing expiry task.
+		_ = p.state.Workers.Scheduler.Cancel(existingMute.ID)
+		if err := p.ScheduleMuteExpiry(ctx, existingMute); err != nil {
+			log.Errorf(ctx, "error scheduling mute expiry: %v", err)
+		}
+
+		return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+	}
+
+	// Create and store a new mute.
+	mute := &gtsmodel.UserMute{
+		ID:              id.NewULID(),
+		ExpiresAt:       expiresAt,
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: targetAccountID,
+		Notifications:   &notifications,
+	}
+
+	if err := p.state.DB.PutMute(ctx, mute); err != nil {
+		err := gtserror.Newf("error creating mute in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ScheduleMuteExpiry(ctx, mute); err != nil {
+		log.Errorf(ctx, "error scheduling mute expiry: %v", err)
+	}
+
+	// Remove mutee's statuses from muter's timeline.
+	if err := p.state.Timelines.Home.WipeItemsFromAccountID(
+		ctx,
+		requestingAccount.ID,
+		targetAccountID,
+	); err != nil {
+		err := gtserror.Newf("error wiping timeline items for mute: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+}
+
+// MuteRemove handles the removal of a mute from requestingAccount to targetAccountID.
+func (p *Processor) MuteRemove(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	targetAccountID string,
+) (*apimodel.Relationship, gtserror.WithCode) {
+	_, existingMute, errWithCode := p.getMuteTarget(ctx, requestingAccount, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if existingMute == nil {
+		// Already not muted, nothing to do.
+		return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+	}
+
+	// We got a mute, remove it from the db.
+	if err := p.state.DB.DeleteMuteByID(ctx, existingMute.ID); err != nil {
+		err := gtserror.Newf("error removing mute from db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Cancel any scheduled expiry.
+	_ = p.state.Workers.Scheduler.Cancel(existingMute.ID)
+
+	return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
+}
+
+// MutesGet returns a page of accounts muted by requestingAccount.
+func (p *Processor) MutesGet(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	mutes, err := p.state.DB.GetAccountMutes(ctx,
+		requestingAccount.ID,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(mutes)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := mutes[count-1].ID
+	hi := mutes[0].ID
+
+	var (
+		items = make([]interface{}, 0, count)
+		now   = time.Now()
+	)
+
+	for _, mute := range mutes {
+		if mute.Expired(now) {
+			// Expired but not yet
+			// cleaned up, skip it.
+			continue
+		}
+
+		// Convert target account to frontend API model. (target will never be nil)
+		account, err := p.converter.AccountToAPIAccountPublic(ctx, mute.TargetAccount)
+		if err != nil {
+			log.Errorf(ctx, "error converting account to public api account: %v", err)
+			continue
+		}
+
+		if !mute.ExpiresAt.IsZero() {
+			account.MuteExpiresAt = util.FormatISO8601(mute.ExpiresAt)
+		}
+
+		// Append target to return items.
+		items = append(items, account)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/mutes",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// ScheduleMuteExpiries adds all expiring mutes in the database to the scheduler.
+// This should be called on startup, as scheduled tasks do not survive restarts.
+func (p *Processor) ScheduleMuteExpiries(ctx context.Context) error {
+	// Fetch all expiring mutes from the database (barebones models are enough).
+	mutes, err := p.state.DB.GetExpiringMutes(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting expiring mutes from db: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, mute := range mutes {
+		// Schedule each of the mutes and catch any errors.
+		if err := p.ScheduleMuteExpiry(ctx, mute); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// ScheduleMuteExpiry adds the given mute to the scheduler, to be
+// removed at its ExpiresAt time. Mutes without expiry are ignored.
+func (p *Processor) ScheduleMuteExpiry(ctx context.Context, mute *gtsmodel.UserMute) error {
+	if mute.ExpiresAt.IsZero() {
+		// Nothing to do.
+		return nil
+	}
+
+	// Add the given mute to the scheduler.
+	ok := p.state.Workers.Scheduler.AddOnce(
+		mute.ID,
+		mute.ExpiresAt,
+		p.onMuteExpiry(mute.ID),
+	)
+
+	if !ok {
+		// Failed to add the mute to the scheduler, either it was
+		// starting / stopping or there already exists a task for it.
+		return gtserror.Newf("failed adding mute %s to scheduler", mute.ID)
+	}
+
+	atStr := mute.ExpiresAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled mute expiry for %s at '%s'", mute.ID, atStr)
+	return nil
+}
+
+// onMuteExpiry returns a callback function to be
+// used by the scheduler when the given mute expires.
+func (p *Processor) onMuteExpiry(muteID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Get the latest version of mute from database.
+		mute, err := p.state.DB.GetMuteByID(gtscontext.SetBarebones(ctx), muteID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "error getting mute %s from db: %v", muteID, err)
+			}
+			return
+		}
+
+		if !mute.Expired(now) {
+			// Mute was updated in
+			// the meantime, leave it.
+			return
+		}
+
+		if err := p.state.DB.DeleteMuteByID(ctx, muteID); err != nil {
+			log.Errorf(ctx, "error deleting expired mute %s from db: %v", muteID, err)
+		}
+	}
+}
+
+func (p *Processor) getMuteTarget(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	targetAccountID string,
+) (*gtsmodel.Account, *gtsmodel.UserMute, gtserror.WithCode) {
+	// Account should not mute or unmute itself.
+	if requestingAccount.ID == targetAccountID {
+		err := gtserror.Newf("account %s cannot mute or unmute itself", requestingAccount.ID)
+		return nil, nil, gtserror.NewErrorNotAcceptable(err, err.Error())
+	}
+
+	// Ensure target account retrievable.
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			// Real db error.
+			err = gtserror.Newf("db error looking for target account %s: %w", targetAccountID, err)
+			return nil, nil, gtserror.NewErrorInternalError(err)
+		}
+		// Account not found.
+		err = gtserror.Newf("target account %s not found in the db", targetAccountID)
+		return nil, nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	// Check if currently muted.
+	mute, err := p.state.DB.GetMute(ctx, requestingAccount.ID, targetAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error checking existing mute: %w", err)
+		return nil, nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return targetAccount, mute, nil
+}
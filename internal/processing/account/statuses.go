@@ -24,6 +24,7 @@ import (
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -96,10 +97,22 @@ func (p *Processor) StatusesGet(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	var filters []*gtsmodel.Filter
+	if requestingAccount != nil {
+		filters, err = p.state.DB.GetFiltersForAccountID(ctx, requestingAccount.ID)
+		if err != nil {
+			err = gtserror.Newf("couldn't retrieve filters for account %s: %w", requestingAccount.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
 	for _, s := range filtered {
 		// Convert filtered statuses to API statuses.
-		item, err := p.converter.StatusToAPIStatus(ctx, s, requestingAccount)
+		item, err := p.converter.StatusToAPIStatus(ctx, s, requestingAccount, gtsmodel.FilterContextAccount, filters)
 		if err != nil {
+			if errors.Is(err, statusfilter.ErrHideStatus) {
+				continue
+			}
 			log.Errorf(ctx, "error convering to api status: %v", err)
 			continue
 		}
@@ -19,36 +19,419 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+// AccountsGet returns a page of accounts filtered by
+// the given request, converted to the admin view of an
+// account. Paging links use the parameter names of the
+// API version that the request was made to.
+func (p *Processor) AccountsGet(
+	ctx context.Context,
+	request *apimodel.AdminGetAccountsRequest,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	switch request.Origin {
+	case "", "local", "remote":
+	default:
+		err := fmt.Errorf("origin %s not recognized, valid values are: local, remote", request.Origin)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	switch request.Status {
+	case "", "active", "pending", "disabled", "silenced", "suspended":
+	default:
+		err := fmt.Errorf("status %s not recognized, valid values are: active, pending, disabled, silenced, suspended", request.Status)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	switch request.Permissions {
+	case "", "staff":
+	default:
+		err := fmt.Errorf("permissions %s not recognized, valid values are: staff", request.Permissions)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	var ip net.IP
+	if request.IP != "" {
+		ip = net.ParseIP(request.IP)
+		if ip == nil {
+			err := fmt.Errorf("ip %s could not be parsed as an IP address", request.IP)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	var domain string
+	if request.ByDomain != "" {
+		var err error
+		domain, err = util.Punify(request.ByDomain)
+		if err != nil {
+			err := fmt.Errorf("by_domain %s could not be punified: %w", request.ByDomain, err)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	accounts, err := p.state.DB.GetAccounts(
+		ctx,
+		request.Origin,
+		request.Status,
+		request.Permissions == "staff",
+		request.InvitedBy,
+		request.Username,
+		request.DisplayName,
+		domain,
+		request.Email,
+		ip,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting accounts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(accounts)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := accounts[count-1].ID
+	hi := accounts[0].ID
+
+	items := make([]interface{}, 0, count)
+
+	for _, account := range accounts {
+		apiAccount, err := p.converter.AccountToAdminAPIAccount(ctx, account)
+		if err != nil {
+			log.Errorf(ctx, "error converting account to admin api account: %v", err)
+			continue
+		}
+
+		items = append(items, apiAccount)
+	}
+
+	path, query := accountsGetLinkParams(request)
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  path,
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+		Query: query,
+	}), nil
+}
+
+// accountsGetLinkParams returns the path and query
+// parameters to use in paging links for the given
+// request, according to the request's API version.
+func accountsGetLinkParams(request *apimodel.AdminGetAccountsRequest) (string, url.Values) {
+	query := make(url.Values)
+
+	// Params that are the
+	// same in v1 and v2.
+	for k, v := range map[string]string{
+		"username":     request.Username,
+		"display_name": request.DisplayName,
+		"by_domain":    request.ByDomain,
+		"email":        request.Email,
+		"ip":           request.IP,
+	} {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+
+	if request.APIVersion == 2 {
+		for k, v := range map[string]string{
+			"origin":      request.Origin,
+			"status":      request.Status,
+			"permissions": request.Permissions,
+			"invited_by":  request.InvitedBy,
+		} {
+			if v != "" {
+				query.Set(k, v)
+			}
+		}
+
+		return "/api/v2/admin/accounts", query
+	}
+
+	// v1 uses a boolean
+	// param for each value.
+	for _, v := range []string{
+		request.Origin,
+		request.Status,
+	} {
+		if v != "" {
+			query.Set(v, "true")
+		}
+	}
+
+	if request.Permissions == "staff" {
+		query.Set("staff", "true")
+	}
+
+	return "/api/v1/admin/accounts", query
+}
+
+// AccountGet returns the admin view of
+// the account with the given ID.
+func (p *Processor) AccountGet(
+	ctx context.Context,
+	accountID string,
+) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account == nil {
+		err := gtserror.Newf("account %s not found", accountID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	apiAccount, err := p.converter.AccountToAdminAPIAccount(ctx, account)
+	if err != nil {
+		err := gtserror.Newf("error converting account to admin api account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAccount, nil
+}
+
+// AccountApprove approves the pending sign-up of the
+// local account with the given ID, allowing it to log
+// in, and lets the applicant know by email.
+func (p *Processor) AccountApprove(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	accountID string,
+) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	user, errWithCode := p.getPendingUser(ctx, accountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Mark the user as approved.
+	user.Approved = util.Ptr(true)
+	if err := p.state.DB.UpdateUser(ctx, user, "approved"); err != nil {
+		err := gtserror.Newf("db error updating user %s: %w", user.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccount, err := p.converter.AccountToAdminAPIAccount(ctx, user.Account)
+	if err != nil {
+		err := gtserror.Newf("error converting account to admin api account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process side effects (eg., email) asynchronously.
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityAccept,
+		GTSModel:       user,
+		OriginAccount:  adminAcct,
+		TargetAccount:  user.Account,
+	})
+
+	return apiAccount, nil
+}
+
+// AccountReject rejects the pending sign-up of the local
+// account with the given ID, removing the user and account
+// from the database, and lets the applicant know by email.
+func (p *Processor) AccountReject(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	accountID string,
+) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	user, errWithCode := p.getPendingUser(ctx, accountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert the account before deleting
+	// it, since we won't be able to after.
+	apiAccount, err := p.converter.AccountToAdminAPIAccount(ctx, user.Account)
+	if err != nil {
+		err := gtserror.Newf("error converting account to admin api account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Delete any tokens issued to the
+	// user when they signed up.
+	tokens := []*gtsmodel.Token{}
+	if err := p.state.DB.GetWhere(ctx, []db.Where{{Key: "user_id", Value: user.ID}}, &tokens); err != nil {
+		err := gtserror.Newf("db error getting tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, t := range tokens {
+		if err := p.state.DB.DeleteByID(ctx, t.ID, t); err != nil {
+			err := gtserror.Newf("db error deleting token: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Delete sign-up notifications sent
+	// to moderators about this account.
+	if err := p.state.DB.DeleteNotifications(ctx, nil, "", user.AccountID); err != nil {
+		err := gtserror.Newf("db error deleting notifications: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Delete the user and account themselves, which
+	// frees up the username + email for future use.
+	if err := p.state.DB.DeleteUserByID(ctx, user.ID); err != nil {
+		err := gtserror.Newf("db error deleting user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteAccount(ctx, user.AccountID); err != nil {
+		err := gtserror.Newf("db error deleting account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process side effects (eg., email) asynchronously.
+	// The user + account models are still populated in
+	// memory, so they can still be used for the email.
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityReject,
+		GTSModel:       user,
+		OriginAccount:  adminAcct,
+		TargetAccount:  user.Account,
+	})
+
+	return apiAccount, nil
+}
+
+// getPendingUser gets the user belonging to the local
+// account with the given ID, erroring if the account
+// doesn't exist or has already been approved.
+func (p *Processor) getPendingUser(ctx context.Context, accountID string) (*gtsmodel.User, gtserror.WithCode) {
+	user, err := p.state.DB.GetUserByAccountID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("local account %s not found", accountID)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		err := gtserror.Newf("db error getting user for account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if *user.Approved {
+		err := fmt.Errorf("account %s has already been approved", accountID)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return user, nil
+}
+
+// unsuspendGracePeriod is the length of time after
+// suspension of an account during which an admin can
+// still reverse the suspension. After this, the account
+// is considered gone for good.
+const unsuspendGracePeriod = 30 * 24 * time.Hour
+
+// AccountAction performs the admin action described by the given
+// request on the target account, returning the ID of the action.
+// If the request includes a report ID, the report is resolved with
+// the request text as the action taken. Local users are emailed
+// to let them know about the action taken on their account.
 func (p *Processor) AccountAction(
 	ctx context.Context,
 	adminAcct *gtsmodel.Account,
 	request *apimodel.AdminActionRequest,
 ) (string, gtserror.WithCode) {
 	targetAcct, err := p.state.DB.GetAccountByID(ctx, request.TargetID)
-	if err != nil {
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		err := gtserror.Newf("db error getting target account: %w", err)
 		return "", gtserror.NewErrorInternalError(err)
 	}
 
-	switch gtsmodel.NewAdminActionType(request.Type) {
+	if targetAcct == nil {
+		err := fmt.Errorf("account %s not found", request.TargetID)
+		return "", gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	var report *gtsmodel.Report
+	if request.ReportID != "" {
+		report, err = p.state.DB.GetReportByID(ctx, request.ReportID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting report: %w", err)
+			return "", gtserror.NewErrorInternalError(err)
+		}
+
+		if report == nil {
+			err := fmt.Errorf("report %s not found", request.ReportID)
+			return "", gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		if report.TargetAccountID != targetAcct.ID {
+			err := fmt.Errorf("report %s does not target account %s", report.ID, targetAcct.ID)
+			return "", gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	var (
+		actionType  = gtsmodel.NewAdminActionType(request.Type)
+		f           func(context.Context) gtserror.MultiError
+		errWithCode gtserror.WithCode
+	)
+
+	switch actionType {
 	case gtsmodel.AdminActionSuspend:
-		return p.accountActionSuspend(ctx, adminAcct, targetAcct, request.Text)
+		f = p.accountActionSuspend(adminAcct, targetAcct)
+
+	case gtsmodel.AdminActionUnsuspend:
+		f, errWithCode = p.accountActionUnsuspend(ctx, targetAcct)
+
+	case gtsmodel.AdminActionSilence,
+		gtsmodel.AdminActionUnsilence:
+		f, errWithCode = p.accountActionSilence(targetAcct, actionType == gtsmodel.AdminActionSilence)
+
+	case gtsmodel.AdminActionSensitize,
+		gtsmodel.AdminActionUnsensitize:
+		f, errWithCode = p.accountActionSensitize(targetAcct, actionType == gtsmodel.AdminActionSensitize)
+
+	case gtsmodel.AdminActionDisable,
+		gtsmodel.AdminActionReenable:
+		f, errWithCode = p.accountActionDisable(ctx, targetAcct, actionType == gtsmodel.AdminActionDisable)
 
 	default:
 		// TODO: add more types to this slice when adding
 		//       more types to the switch statement above.
 		supportedTypes := []string{
+			gtsmodel.AdminActionDisable.String(),
+			gtsmodel.AdminActionReenable.String(),
+			gtsmodel.AdminActionSilence.String(),
+			gtsmodel.AdminActionUnsilence.String(),
+			gtsmodel.AdminActionSensitize.String(),
+			gtsmodel.AdminActionUnsensitize.String(),
 			gtsmodel.AdminActionSuspend.String(),
+			gtsmodel.AdminActionUnsuspend.String(),
 		}
 
 		err := fmt.Errorf(
@@ -58,36 +441,36 @@ func (p *Processor) AccountAction(
 
 		return "", gtserror.NewErrorBadRequest(err, err.Error())
 	}
-}
 
-func (p *Processor) accountActionSuspend(
-	ctx context.Context,
-	adminAcct *gtsmodel.Account,
-	targetAcct *gtsmodel.Account,
-	text string,
-) (string, gtserror.WithCode) {
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+
 	actionID := id.NewULID()
 
-	errWithCode := p.actions.Run(
+	if errWithCode := p.actions.Run(
 		ctx,
 		&gtsmodel.AdminAction{
 			ID:             actionID,
 			TargetCategory: gtsmodel.AdminActionCategoryAccount,
 			TargetID:       targetAcct.ID,
 			Target:         targetAcct,
-			Type:           gtsmodel.AdminActionSuspend,
+			Type:           actionType,
 			AccountID:      adminAcct.ID,
-			Text:           text,
+			Text:           request.Text,
 		},
 		func(ctx context.Context) gtserror.MultiError {
-			if err := p.state.Workers.ProcessFromClientAPI(
+			if errs := f(ctx); errs != nil {
+				return errs
+			}
+
+			// Let the account owner know
+			// what's happened, if we can.
+			if err := p.emailAccountAction(
 				ctx,
-				messages.FromClientAPI{
-					APObjectType:   ap.ActorPerson,
-					APActivityType: ap.ActivityDelete,
-					OriginAccount:  adminAcct,
-					TargetAccount:  targetAcct,
-				},
+				targetAcct,
+				actionType,
+				request.Text,
 			); err != nil {
 				errs := gtserror.NewMultiError(1)
 				errs.Append(err)
@@ -96,7 +479,260 @@ func (p *Processor) accountActionSuspend(
 
 			return nil
 		},
-	)
+	); errWithCode != nil {
+		return "", errWithCode
+	}
+
+	if report != nil {
+		// Attach a note about the
+		// action taken to the report.
+		actionTaken := request.Text
+		if actionTaken == "" {
+			actionTaken = "Account action taken: " + actionType.String()
+		}
+
+		if _, errWithCode := p.ReportResolve(
+			ctx,
+			adminAcct,
+			report.ID,
+			&actionTaken,
+		); errWithCode != nil {
+			return "", errWithCode
+		}
+	}
+
+	return actionID, nil
+}
+
+// accountActionSuspend returns a function to suspend
+// the target account, deleting its statuses, media,
+// follows etc, and stubbifying the account.
+func (p *Processor) accountActionSuspend(
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) func(context.Context) gtserror.MultiError {
+	return func(ctx context.Context) gtserror.MultiError {
+		if err := p.state.Workers.ProcessFromClientAPI(
+			ctx,
+			messages.FromClientAPI{
+				APObjectType:   ap.ActorPerson,
+				APActivityType: ap.ActivityDelete,
+				OriginAccount:  adminAcct,
+				TargetAccount:  targetAcct,
+			},
+		); err != nil {
+			errs := gtserror.NewMultiError(1)
+			errs.Append(err)
+			return errs
+		}
+
+		return nil
+	}
+}
+
+// accountActionUnsuspend checks whether the suspension of
+// the target account can be reversed, and if so returns a
+// function to lift the suspension. Account content removed
+// by the suspension is not restored; remote accounts will be
+// dereferenced again next time they're encountered, and local
+// users will have to reset their password to log in again.
+func (p *Processor) accountActionUnsuspend(
+	ctx context.Context,
+	targetAcct *gtsmodel.Account,
+) (func(context.Context) gtserror.MultiError, gtserror.WithCode) {
+	if targetAcct.SuspendedAt.IsZero() {
+		err := fmt.Errorf("account %s is not suspended", targetAcct.ID)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if time.Since(targetAcct.SuspendedAt) > unsuspendGracePeriod {
+		err := fmt.Errorf(
+			"account %s was suspended more than %s ago and can no longer be unsuspended",
+			targetAcct.ID, unsuspendGracePeriod,
+		)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if targetAcct.SuspensionOrigin == targetAcct.ID {
+		err := fmt.Errorf("account %s was deleted by its owner and cannot be unsuspended", targetAcct.ID)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	domainBlock, err := p.state.DB.GetDomainBlockByID(ctx, targetAcct.SuspensionOrigin)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if domainBlock != nil {
+		err := fmt.Errorf(
+			"account %s was suspended as a side effect of domain block %s, remove the domain block instead",
+			targetAcct.ID, domainBlock.ID,
+		)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return func(ctx context.Context) gtserror.MultiError {
+		targetAcct.SuspendedAt = time.Time{}
+		targetAcct.SuspensionOrigin = ""
+
+		if err := p.state.DB.UpdateAccount(
+			ctx,
+			targetAcct,
+			"suspended_at",
+			"suspension_origin",
+		); err != nil {
+			errs := gtserror.NewMultiError(1)
+			errs.Append(gtserror.Newf("db error updating account: %w", err))
+			return errs
+		}
+
+		return nil
+	}, nil
+}
+
+// accountActionSilence returns a function to silence (or
+// unsilence) the target account. Statuses of a silenced
+// account are hidden from public timelines, and from
+// accounts that don't follow the silenced account.
+func (p *Processor) accountActionSilence(
+	targetAcct *gtsmodel.Account,
+	silence bool,
+) (func(context.Context) gtserror.MultiError, gtserror.WithCode) {
+	if silence == targetAcct.IsSilenced() {
+		err := fmt.Errorf("account %s silenced is already %t", targetAcct.ID, silence)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return func(ctx context.Context) gtserror.MultiError {
+		if silence {
+			targetAcct.SilencedAt = time.Now()
+		} else {
+			targetAcct.SilencedAt = time.Time{}
+		}
+
+		if err := p.state.DB.UpdateAccount(ctx, targetAcct, "silenced_at"); err != nil {
+			errs := gtserror.NewMultiError(1)
+			errs.Append(gtserror.Newf("db error updating account: %w", err))
+			return errs
+		}
+
+		// Visibility of the account's statuses is cached
+		// by status ID, not account ID, so clear the whole
+		// visibility cache to make the change take effect.
+		p.state.Caches.Visibility.Clear()
+
+		return nil
+	}, nil
+}
+
+// accountActionSensitize returns a function to sensitize
+// (or unsensitize) the target account. Statuses with media
+// from a sensitized account are always marked as sensitive,
+// both in the client API and when federated out.
+func (p *Processor) accountActionSensitize(
+	targetAcct *gtsmodel.Account,
+	sensitize bool,
+) (func(context.Context) gtserror.MultiError, gtserror.WithCode) {
+	if sensitize == targetAcct.IsSensitized() {
+		err := fmt.Errorf("account %s sensitized is already %t", targetAcct.ID, sensitize)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return func(ctx context.Context) gtserror.MultiError {
+		if sensitize {
+			targetAcct.SensitizedAt = time.Now()
+		} else {
+			targetAcct.SensitizedAt = time.Time{}
+		}
+
+		if err := p.state.DB.UpdateAccount(ctx, targetAcct, "sensitized_at"); err != nil {
+			errs := gtserror.NewMultiError(1)
+			errs.Append(gtserror.Newf("db error updating account: %w", err))
+			return errs
+		}
+
+		return nil
+	}, nil
+}
+
+// accountActionDisable returns a function to disable (or
+// re-enable) the user of the local target account. Disabled
+// users cannot log in, and their existing tokens stop working.
+func (p *Processor) accountActionDisable(
+	ctx context.Context,
+	targetAcct *gtsmodel.Account,
+	disable bool,
+) (func(context.Context) gtserror.MultiError, gtserror.WithCode) {
+	if !targetAcct.IsLocal() {
+		err := fmt.Errorf("account %s is not a local account", targetAcct.ID)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting user for account %s: %w", targetAcct.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if disable == *user.Disabled {
+		err := fmt.Errorf("account %s disabled is already %t", targetAcct.ID, disable)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return func(ctx context.Context) gtserror.MultiError {
+		user.Disabled = util.Ptr(disable)
+		if err := p.state.DB.UpdateUser(ctx, user, "disabled"); err != nil {
+			errs := gtserror.NewMultiError(1)
+			errs.Append(gtserror.Newf("db error updating user: %w", err))
+			return errs
+		}
+
+		return nil
+	}, nil
+}
+
+// emailAccountAction emails the user of the given local
+// account to let them know about the action taken on their
+// account. Remote accounts and users without a confirmed
+// email address are skipped.
+func (p *Processor) emailAccountAction(
+	ctx context.Context,
+	targetAcct *gtsmodel.Account,
+	actionType gtsmodel.AdminActionType,
+	text string,
+) error {
+	if !targetAcct.IsLocal() {
+		// Nobody to email.
+		return nil
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		return gtserror.Newf("db error getting user for account %s: %w", targetAcct.ID, err)
+	}
+
+	if user.Email == "" {
+		// Nowhere to send.
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	accountActionData := email.AccountActionData{
+		Username:     targetAcct.Username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+		Action:       actionType.String(),
+		Text:         text,
+	}
+
+	if err := p.emailSender.SendAccountActionEmail(user.Email, accountActionData); err != nil {
+		return gtserror.Newf("error sending account action email: %w", err)
+	}
 
-	return actionID, errWithCode
+	return nil
 }
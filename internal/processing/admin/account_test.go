@@ -19,11 +19,15 @@ package admin_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -94,10 +98,463 @@ func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 		adminAcct,
 		request,
 	)
-	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"suspend\"]")
+	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"disable\" \"reenable\" \"silence\" \"unsilence\" \"sensitize\" \"unsensitize\" \"suspend\" \"unsuspend\"]")
 	suite.Empty(actionID)
 }
 
+func (suite *AccountTestSuite) TestAccountActionSilenceWithReport() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		report    = suite.testReports["local_account_2_report_remote_account_1"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionSilence.String(),
+			Text:     "too loud",
+			TargetID: report.TargetAccountID,
+			ReportID: report.ID,
+		}
+	)
+
+	actionID := suite.runAccountAction(ctx, adminAcct, request)
+	suite.NotEmpty(actionID)
+
+	// Ensure target account silenced.
+	targetAcct, err := suite.db.GetAccountByID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(targetAcct.SilencedAt)
+
+	// Ensure report resolved with action text.
+	dbReport, err := suite.db.GetReportByID(ctx, report.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(dbReport.ActionTakenAt)
+	suite.Equal("too loud", dbReport.ActionTaken)
+
+	// Silencing again should fail.
+	_, errWithCode := suite.adminProcessor.AccountAction(ctx, adminAcct, request)
+	suite.EqualError(errWithCode, "account "+request.TargetID+" silenced is already true")
+
+	// Unsilence should work.
+	request.Type = gtsmodel.AdminActionUnsilence.String()
+	request.ReportID = ""
+	suite.runAccountAction(ctx, adminAcct, request)
+
+	targetAcct, err = suite.db.GetAccountByID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(targetAcct.SilencedAt)
+}
+
+func (suite *AccountTestSuite) TestAccountActionSilenceCachedVisibility() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		requester = suite.testAccounts["local_account_1"]
+		status    = suite.testStatuses["remote_account_1_status_1"]
+		filter    = visibility.NewFilter(&suite.state)
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionSilence.String(),
+			TargetID: status.AccountID,
+		}
+	)
+
+	visible := func() bool {
+		dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		visible, err := filter.StatusVisible(ctx, requester, dbStatus)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		return visible
+	}
+
+	// Visible to non-follower before silence,
+	// which also caches the visibility.
+	suite.True(visible())
+
+	// Not visible once silenced.
+	suite.runAccountAction(ctx, adminAcct, request)
+	suite.False(visible())
+
+	// Visible again once unsilenced.
+	request.Type = gtsmodel.AdminActionUnsilence.String()
+	suite.runAccountAction(ctx, adminAcct, request)
+	suite.True(visible())
+}
+
+func (suite *AccountTestSuite) TestAccountActionReportWrongTarget() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionSilence.String(),
+			TargetID: suite.testAccounts["local_account_1"].ID,
+			ReportID: suite.testReports["local_account_2_report_remote_account_1"].ID,
+		}
+	)
+
+	_, errWithCode := suite.adminProcessor.AccountAction(ctx, adminAcct, request)
+	suite.Error(errWithCode)
+	suite.Equal(400, errWithCode.Code())
+}
+
+func (suite *AccountTestSuite) TestAccountActionSensitizeLocal() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		testUser  = suite.testUsers["local_account_1"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     "sensitive",
+			Text:     "please use content warnings",
+			TargetID: testUser.AccountID,
+		}
+	)
+
+	suite.runAccountAction(ctx, adminAcct, request)
+
+	// Ensure target account sensitized.
+	targetAcct, err := suite.db.GetAccountByID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(targetAcct.SensitizedAt)
+
+	// Ensure the user was emailed.
+	if !testrig.WaitFor(func() bool {
+		return suite.sentEmails[testUser.Email] != ""
+	}) {
+		suite.FailNow("timed out waiting for email")
+	}
+
+	mail := suite.sentEmails[testUser.Email]
+	suite.Contains(mail, "Media attached to your posts will now always be marked as sensitive.")
+	suite.Contains(mail, "please use content warnings")
+
+	// Status with media from the account
+	// should now be presented as sensitive.
+	status, err := suite.db.GetStatusByID(ctx, suite.testStatuses["local_account_1_status_4"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(status.AttachmentIDs)
+	suite.True(status.IsSensitive())
+}
+
+func (suite *AccountTestSuite) TestAccountActionDisableRemote() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionDisable.String(),
+			TargetID: suite.testAccounts["remote_account_1"].ID,
+		}
+	)
+
+	_, errWithCode := suite.adminProcessor.AccountAction(ctx, adminAcct, request)
+	suite.Error(errWithCode)
+	suite.Equal(422, errWithCode.Code())
+}
+
+func (suite *AccountTestSuite) TestAccountActionDisableEnable() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		testUser  = suite.testUsers["local_account_1"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionDisable.String(),
+			TargetID: testUser.AccountID,
+		}
+	)
+
+	suite.runAccountAction(ctx, adminAcct, request)
+
+	user, err := suite.db.GetUserByID(ctx, testUser.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*user.Disabled)
+
+	request.Type = "enable"
+	suite.runAccountAction(ctx, adminAcct, request)
+
+	user, err = suite.db.GetUserByID(ctx, testUser.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*user.Disabled)
+}
+
+func (suite *AccountTestSuite) TestAccountActionUnsuspend() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionUnsuspend.String(),
+			TargetID: suite.testAccounts["remote_account_1"].ID,
+		}
+	)
+
+	// Can't unsuspend an account that isn't suspended.
+	_, errWithCode := suite.adminProcessor.AccountAction(ctx, adminAcct, request)
+	suite.EqualError(errWithCode, "account "+request.TargetID+" is not suspended")
+
+	request.Type = gtsmodel.AdminActionSuspend.String()
+	suite.runAccountAction(ctx, adminAcct, request)
+
+	request.Type = gtsmodel.AdminActionUnsuspend.String()
+	suite.runAccountAction(ctx, adminAcct, request)
+
+	targetAcct, err := suite.db.GetAccountByID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(targetAcct.SuspendedAt)
+	suite.Empty(targetAcct.SuspensionOrigin)
+}
+
+func (suite *AccountTestSuite) TestAccountActionUnsuspendGracePeriodExpired() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionUnsuspend.String(),
+			TargetID: suite.testAccounts["remote_account_1"].ID,
+		}
+	)
+
+	// Mark account as suspended a long time ago.
+	targetAcct := new(gtsmodel.Account)
+	*targetAcct = *suite.testAccounts["remote_account_1"]
+	targetAcct.SuspendedAt = time.Now().Add(-60 * 24 * time.Hour)
+	targetAcct.SuspensionOrigin = adminAcct.ID
+	if err := suite.db.UpdateAccount(ctx, targetAcct, "suspended_at", "suspension_origin"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, errWithCode := suite.adminProcessor.AccountAction(ctx, adminAcct, request)
+	suite.Error(errWithCode)
+	suite.Equal(422, errWithCode.Code())
+}
+
+// runAccountAction runs the given account action
+// request, and waits for the action to complete
+// without errors, returning the ID of the action.
+func (suite *AccountTestSuite) runAccountAction(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	request *apimodel.AdminActionRequest,
+) string {
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	adminAction, err := suite.db.GetAdminAction(ctx, actionID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(adminAction.CompletedAt)
+	suite.Empty(adminAction.Errors)
+
+	return actionID
+}
+
+func (suite *AccountTestSuite) TestAccountsGetPending() {
+	resp, errWithCode := suite.adminProcessor.AccountsGet(
+		context.Background(),
+		&apimodel.AdminGetAccountsRequest{
+			Status: "pending",
+		},
+		nil,
+	)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Items, 1)
+
+	account := resp.Items[0].(*apimodel.AdminAccountInfo)
+	suite.Equal(suite.testAccounts["unconfirmed_account"].ID, account.ID)
+	suite.False(account.Approved)
+}
+
+func (suite *AccountTestSuite) TestAccountsGetLocalStaff() {
+	resp, errWithCode := suite.adminProcessor.AccountsGet(
+		context.Background(),
+		&apimodel.AdminGetAccountsRequest{
+			Origin:      "local",
+			Permissions: "staff",
+		},
+		nil,
+	)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Items, 1)
+
+	account := resp.Items[0].(*apimodel.AdminAccountInfo)
+	suite.Equal(suite.testAccounts["admin_account"].ID, account.ID)
+	suite.Equal(apimodel.AccountRoleAdmin, account.Role.Name)
+}
+
+func (suite *AccountTestSuite) TestAccountsGetByDomain() {
+	resp, errWithCode := suite.adminProcessor.AccountsGet(
+		context.Background(),
+		&apimodel.AdminGetAccountsRequest{
+			ByDomain:   "example.org",
+			APIVersion: 2,
+		},
+		nil,
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(resp.Items)
+
+	for _, item := range resp.Items {
+		account := item.(*apimodel.AdminAccountInfo)
+		suite.Equal("example.org", *account.Domain)
+	}
+}
+
+func (suite *AccountTestSuite) TestAccountsGetInvalidStatus() {
+	_, errWithCode := suite.adminProcessor.AccountsGet(
+		context.Background(),
+		&apimodel.AdminGetAccountsRequest{
+			Status: "sleeping",
+		},
+		nil,
+	)
+	suite.EqualError(errWithCode, "status sleeping not recognized, valid values are: active, pending, disabled, silenced, suspended")
+}
+
+func (suite *AccountTestSuite) TestAccountsGetInvalidIP() {
+	_, errWithCode := suite.adminProcessor.AccountsGet(
+		context.Background(),
+		&apimodel.AdminGetAccountsRequest{
+			IP: "not an ip",
+		},
+		nil,
+	)
+	suite.EqualError(errWithCode, "ip not an ip could not be parsed as an IP address")
+}
+
+func (suite *AccountTestSuite) TestAccountGet() {
+	var (
+		ctx      = context.Background()
+		testAcct = suite.testAccounts["local_account_1"]
+		testUser = suite.testUsers["local_account_1"]
+	)
+
+	apiAcct, errWithCode := suite.adminProcessor.AccountGet(ctx, testAcct.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(testAcct.ID, apiAcct.ID)
+	suite.Equal(testUser.Email, apiAcct.Email)
+	suite.NotEmpty(apiAcct.IPs)
+	suite.Equal(testUser.CurrentSignInIP.String(), apiAcct.IPs[0].IP)
+}
+
+func (suite *AccountTestSuite) TestAccountGetNotFound() {
+	_, errWithCode := suite.adminProcessor.AccountGet(
+		context.Background(),
+		"01HZZZZZZZZZZZZZZZZZZZZZZZ",
+	)
+	suite.Error(errWithCode)
+	suite.Equal(404, errWithCode.Code())
+}
+
+func (suite *AccountTestSuite) TestAccountApprove() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		targetAcct = suite.testAccounts["unconfirmed_account"]
+		targetUser = suite.testUsers["unconfirmed_account"]
+	)
+
+	account, errWithCode := suite.adminProcessor.AccountApprove(ctx, adminAcct, targetAcct.ID)
+	suite.NoError(errWithCode)
+	suite.True(account.Approved)
+
+	// User should now be approved.
+	dbUser, err := suite.db.GetUserByID(ctx, targetUser.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbUser.Approved)
+
+	// Applicant should have been emailed.
+	if !testrig.WaitFor(func() bool {
+		return suite.sentEmails[targetUser.UnconfirmedEmail] != ""
+	}) {
+		suite.FailNow("timed out waiting for approval email")
+	}
+	suite.Contains(suite.sentEmails[targetUser.UnconfirmedEmail], "Subject: GoToSocial Sign-Up Approved")
+
+	// Approving again should fail.
+	_, errWithCode = suite.adminProcessor.AccountApprove(ctx, adminAcct, targetAcct.ID)
+	suite.EqualError(errWithCode, "account "+targetAcct.ID+" has already been approved")
+}
+
+func (suite *AccountTestSuite) TestAccountReject() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		targetAcct = suite.testAccounts["unconfirmed_account"]
+		targetUser = suite.testUsers["unconfirmed_account"]
+	)
+
+	account, errWithCode := suite.adminProcessor.AccountReject(ctx, adminAcct, targetAcct.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(targetAcct.ID, account.ID)
+
+	// User and account should now be gone.
+	_, err := suite.db.GetUserByID(ctx, targetUser.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	_, err = suite.db.GetAccountByID(ctx, targetAcct.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	// Applicant should have been emailed.
+	if !testrig.WaitFor(func() bool {
+		return suite.sentEmails[targetUser.UnconfirmedEmail] != ""
+	}) {
+		suite.FailNow("timed out waiting for rejection email")
+	}
+	suite.Contains(suite.sentEmails[targetUser.UnconfirmedEmail], "Subject: GoToSocial Sign-Up Rejected")
+}
+
+func (suite *AccountTestSuite) TestAccountApproveAlreadyApproved() {
+	_, errWithCode := suite.adminProcessor.AccountApprove(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		suite.testAccounts["local_account_1"].ID,
+	)
+	suite.EqualError(errWithCode, "account "+suite.testAccounts["local_account_1"].ID+" has already been approved")
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
@@ -63,6 +63,7 @@ type AdminStandardTestSuite struct {
 	testAttachments  map[string]*gtsmodel.MediaAttachment
 	testStatuses     map[string]*gtsmodel.Status
 	testEmojis       map[string]*gtsmodel.Emoji
+	testReports      map[string]*gtsmodel.Report
 
 	// module being tested
 	adminProcessor *admin.Processor
@@ -78,6 +79,7 @@ func (suite *AdminStandardTestSuite) SetupSuite() {
 	suite.testAttachments = testrig.NewTestAttachments()
 	suite.testStatuses = testrig.NewTestStatuses()
 	suite.testEmojis = testrig.NewTestEmojis()
+	suite.testReports = testrig.NewTestReports()
 }
 
 func (suite *AdminStandardTestSuite) SetupTest() {
@@ -114,6 +116,8 @@ func (suite *AdminStandardTestSuite) SetupTest() {
 		suite.mediaManager,
 		&suite.state,
 		suite.emailSender,
+		testrig.NewWebPushSender(nil),
+		testrig.NewCardFetcher(nil),
 	)
 
 	testrig.StartWorkers(&suite.state, suite.processor.Workers())
@@ -0,0 +1,322 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"codeberg.org/gruf/go-kv"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// ContentWarningPoliciesGet returns all content warning policies, newest first.
+func (p *Processor) ContentWarningPoliciesGet(
+	ctx context.Context,
+) ([]*apimodel.ContentWarningPolicy, gtserror.WithCode) {
+	policies, err := p.state.DB.GetContentWarningPolicies(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting content warning policies: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPolicies := make([]*apimodel.ContentWarningPolicy, 0, len(policies))
+	for _, policy := range policies {
+		apiPolicy, err := p.converter.ContentWarningPolicyToAPIContentWarningPolicy(ctx, policy)
+		if err != nil {
+			log.Errorf(ctx, "error converting content warning policy %s: %v", policy.ID, err)
+			continue
+		}
+		apiPolicies = append(apiPolicies, apiPolicy)
+	}
+
+	return apiPolicies, nil
+}
+
+// ContentWarningPolicyGet returns the content warning policy with the given ID.
+func (p *Processor) ContentWarningPolicyGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.ContentWarningPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getContentWarningPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiContentWarningPolicy(ctx, policy)
+}
+
+// ContentWarningPolicyCreate creates a content warning policy targeting either
+// one account, or all accounts of one domain, from the given form. Statuses
+// already stored for targeted accounts are updated asynchronously.
+func (p *Processor) ContentWarningPolicyCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.ContentWarningPolicyRequest,
+) (*apimodel.ContentWarningPolicy, gtserror.WithCode) {
+	var (
+		domain         = strings.TrimSpace(form.Domain)
+		accountID      = strings.TrimSpace(form.AccountID)
+		contentWarning = text.SanitizeToPlaintext(strings.TrimSpace(form.ContentWarning))
+	)
+
+	if (domain == "") == (accountID == "") {
+		const text = "exactly one of domain or account_id must be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if contentWarning == "" && !form.Sensitive {
+		const text = "content_warning must be provided or sensitive must be true"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	policy := &gtsmodel.ContentWarningPolicy{
+		ID:                 id.NewULID(),
+		CreatedByAccountID: adminAcct.ID,
+		ContentWarning:     contentWarning,
+		Sensitive:          &form.Sensitive,
+	}
+
+	actionCategory := gtsmodel.AdminActionCategoryDomain
+
+	if accountID != "" {
+		account, err := p.state.DB.GetAccountByID(ctx, accountID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting account %s: %w", accountID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if account == nil {
+			err := gtserror.Newf("account %s not found", accountID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+		policy.AccountID = account.ID
+		policy.Account = account
+		actionCategory = gtsmodel.AdminActionCategoryAccount
+	} else {
+		var err error
+
+		domain, err = util.Punify(domain)
+		if err != nil {
+			err = gtserror.Newf("error punifying domain %s: %w", domain, err)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if domain == config.GetHost() || domain == config.GetAccountDomain() {
+			const text = "domain must not be this instance's domain, target local accounts by account_id instead"
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		policy.Domain = domain
+	}
+
+	if err := p.state.DB.PutContentWarningPolicy(ctx, policy); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			const text = "a content warning policy already exists for this target"
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+
+		err = gtserror.Newf("db error putting content warning policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	targetID := policy.Domain
+	if targetID == "" {
+		targetID = policy.AccountID
+	}
+
+	actionID := id.NewULID()
+
+	// Apply policy to already
+	// stored statuses asynchronously.
+	if errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: actionCategory,
+			TargetID:       targetID,
+			Type:           gtsmodel.AdminActionContentWarning,
+			AccountID:      adminAcct.ID,
+			Text:           policy.ContentWarning,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			// Log start + finish.
+			l := log.WithFields(kv.Fields{
+				{"target", targetID},
+				{"actionID", actionID},
+			}...).WithContext(ctx)
+
+			l.Info("applying content warning policy to stored statuses")
+			defer func() { l.Info("finished applying content warning policy to stored statuses") }()
+
+			return p.contentWarningPolicySideEffects(ctx, policy)
+		},
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiContentWarningPolicy(ctx, policy)
+}
+
+// ContentWarningPolicyDelete deletes the content warning policy with
+// the given ID. Statuses that were already stored with the policy
+// applied to them are left as they are.
+func (p *Processor) ContentWarningPolicyDelete(
+	ctx context.Context,
+	id string,
+) (*apimodel.ContentWarningPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getContentWarningPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteContentWarningPolicyByID(ctx, policy.ID); err != nil {
+		err = gtserror.Newf("db error deleting content warning policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiContentWarningPolicy(ctx, policy)
+}
+
+// contentWarningPolicySideEffects applies the given policy
+// to all statuses already stored for the account(s) it targets.
+func (p *Processor) contentWarningPolicySideEffects(
+	ctx context.Context,
+	policy *gtsmodel.ContentWarningPolicy,
+) gtserror.MultiError {
+	var errs gtserror.MultiError
+
+	if policy.AccountID != "" {
+		if err := p.applyContentWarningPolicy(ctx, policy, policy.AccountID); err != nil {
+			errs.Append(err)
+		}
+		return errs
+	}
+
+	if err := p.rangeDomainAccounts(ctx, policy.Domain, func(account *gtsmodel.Account) {
+		// Accounts targeted by a policy of their
+		// own are governed by that policy instead.
+		_, err := p.state.DB.GetContentWarningPolicyByAccountID(gtscontext.SetBarebones(ctx), account.ID)
+		if err == nil {
+			return
+		} else if !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("db error getting content warning policy of account %s: %w", account.ID, err)
+			return
+		}
+
+		if err := p.applyContentWarningPolicy(ctx, policy, account.ID); err != nil {
+			errs.Append(err)
+		}
+	}); err != nil {
+		errs.Appendf("db error ranging through accounts: %w", err)
+	}
+
+	return errs
+}
+
+// applyContentWarningPolicy pages through all statuses
+// (excluding boosts) authored by the given account,
+// updating those that the given policy changes.
+func (p *Processor) applyContentWarningPolicy(
+	ctx context.Context,
+	policy *gtsmodel.ContentWarningPolicy,
+	accountID string,
+) error {
+	var (
+		limit = 50   // Limit selection to avoid spiking mem/cpu.
+		maxID string // Start with empty string to select from top.
+	)
+
+	// Barebones statuses are
+	// all we need to update.
+	ctx = gtscontext.SetBarebones(ctx)
+
+	for {
+		// Get (next) page of statuses.
+		statuses, err := p.state.DB.GetAccountStatuses(ctx, accountID, limit, false, true, maxID, "", false, false)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting statuses of account %s: %w", accountID, err)
+		}
+
+		if len(statuses) == 0 {
+			// No statuses left, we're done.
+			return nil
+		}
+
+		// Set next max ID for paging down.
+		maxID = statuses[len(statuses)-1].ID
+
+		for _, status := range statuses {
+			sensitive := util.PtrValueOr(status.Sensitive, false)
+			contentWarning, newSensitive := policy.Apply(status.ContentWarning, sensitive)
+			if contentWarning == status.ContentWarning && newSensitive == sensitive {
+				// Nothing changed.
+				continue
+			}
+
+			status.ContentWarning = contentWarning
+			status.Sensitive = &newSensitive
+
+			if err := p.state.DB.UpdateStatus(ctx, status, "content_warning", "sensitive"); err != nil {
+				return gtserror.Newf("db error updating status %s: %w", status.ID, err)
+			}
+		}
+	}
+}
+
+func (p *Processor) getContentWarningPolicy(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.ContentWarningPolicy, gtserror.WithCode) {
+	policy, err := p.state.DB.GetContentWarningPolicyByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting content warning policy %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if policy == nil {
+		err := gtserror.Newf("content warning policy %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return policy, nil
+}
+
+func (p *Processor) apiContentWarningPolicy(
+	ctx context.Context,
+	policy *gtsmodel.ContentWarningPolicy,
+) (*apimodel.ContentWarningPolicy, gtserror.WithCode) {
+	apiPolicy, err := p.converter.ContentWarningPolicyToAPIContentWarningPolicy(ctx, policy)
+	if err != nil {
+		err = gtserror.Newf("error converting content warning policy %s: %w", policy.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPolicy, nil
+}
@@ -0,0 +1,177 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ContentWarningPolicyTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *ContentWarningPolicyTestSuite) TestCreateContentWarningPolicyInvalid() {
+	ctx := context.Background()
+	adminAcct := suite.testAccounts["admin_account"]
+
+	for _, form := range []*apimodel.ContentWarningPolicyRequest{
+		// Neither domain nor account.
+		{ContentWarning: "spam"},
+		// Both domain and account.
+		{Domain: "fossbros-anonymous.io", AccountID: suite.testAccounts["remote_account_1"].ID, ContentWarning: "spam"},
+		// Policy that does nothing.
+		{Domain: "fossbros-anonymous.io"},
+		// Our own domain.
+		{Domain: config.GetHost(), Sensitive: true},
+	} {
+		_, errWithCode := suite.adminProcessor.ContentWarningPolicyCreate(ctx, adminAcct, form)
+		if suite.NotNil(errWithCode) {
+			suite.Equal(http.StatusBadRequest, errWithCode.Code())
+		}
+	}
+
+	// Account that doesn't exist.
+	_, errWithCode := suite.adminProcessor.ContentWarningPolicyCreate(ctx, adminAcct, &apimodel.ContentWarningPolicyRequest{
+		AccountID: "01HSVVNE2Y8G4MXEDV6NRPW3A4",
+		Sensitive: true,
+	})
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+}
+
+func (suite *ContentWarningPolicyTestSuite) TestCreateContentWarningPolicyDomain() {
+	var (
+		ctx           = context.Background()
+		adminAcct     = suite.testAccounts["admin_account"]
+		remoteAccount = suite.testAccounts["remote_account_1"]
+	)
+
+	form := &apimodel.ContentWarningPolicyRequest{
+		Domain:         "Fossbros-Anonymous.io",
+		ContentWarning: "fossbros",
+	}
+
+	apiPolicy, errWithCode := suite.adminProcessor.ContentWarningPolicyCreate(ctx, adminAcct, form)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("fossbros-anonymous.io", apiPolicy.Domain)
+	suite.Equal("fossbros", apiPolicy.ContentWarning)
+	suite.False(apiPolicy.Sensitive)
+
+	// A second policy for the same domain should conflict.
+	_, errWithCode = suite.adminProcessor.ContentWarningPolicyCreate(ctx, adminAcct, form)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusConflict, errWithCode.Code())
+	}
+
+	// Wait for the retroactive action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	// Already stored statuses of the
+	// domain should now be content warned.
+	status, err := suite.db.GetStatusByID(ctx, suite.testStatuses["remote_account_1_status_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("fossbros", status.ContentWarning)
+	suite.True(*status.Sensitive)
+
+	// The policy should apply to the account.
+	policy, err := suite.db.GetContentWarningPolicyForAccount(ctx, remoteAccount)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(apiPolicy.ID, policy.ID)
+
+	// Delete the policy again.
+	if _, errWithCode := suite.adminProcessor.ContentWarningPolicyDelete(ctx, apiPolicy.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.adminProcessor.ContentWarningPolicyGet(ctx, apiPolicy.ID)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+
+	apiPolicies, errWithCode := suite.adminProcessor.ContentWarningPoliciesGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiPolicies)
+}
+
+func (suite *ContentWarningPolicyTestSuite) TestCreateContentWarningPolicyAccount() {
+	var (
+		ctx         = context.Background()
+		adminAcct   = suite.testAccounts["admin_account"]
+		targetAcct  = suite.testAccounts["local_account_1"]
+		cwStatus    = suite.testStatuses["local_account_1_status_2"]
+		otherStatus = suite.testStatuses["local_account_2_status_5"]
+	)
+
+	apiPolicy, errWithCode := suite.adminProcessor.ContentWarningPolicyCreate(ctx, adminAcct, &apimodel.ContentWarningPolicyRequest{
+		AccountID: targetAcct.ID,
+		Sensitive: true,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(targetAcct.ID, apiPolicy.AccountID)
+	suite.NotNil(apiPolicy.Account)
+	suite.True(apiPolicy.Sensitive)
+
+	// Wait for the retroactive action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	// Target account's statuses should now be sensitive,
+	// keeping their content warning as it was.
+	status, err := suite.db.GetStatusByID(ctx, cwStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(cwStatus.ContentWarning, status.ContentWarning)
+	suite.True(*status.Sensitive)
+
+	// Other accounts' statuses should be untouched.
+	status, err = suite.db.GetStatusByID(ctx, otherStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(*otherStatus.Sensitive, *status.Sensitive)
+}
+
+func TestContentWarningPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentWarningPolicyTestSuite))
+}
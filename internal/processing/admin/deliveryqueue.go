@@ -0,0 +1,150 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DeliveryQueueGet returns a summary of queued outgoing
+// federation deliveries per domain, ordered by most queued.
+func (p *Processor) DeliveryQueueGet(ctx context.Context) ([]*apimodel.DeliveryQueueDomain, gtserror.WithCode) {
+	counts, err := p.state.DB.CountDeliveriesPerDomain(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error counting queued deliveries: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiDomains := make([]*apimodel.DeliveryQueueDomain, 0, len(counts))
+	for domain, count := range counts {
+		apiDomains = append(apiDomains, &apimodel.DeliveryQueueDomain{
+			Domain: domain,
+			Count:  count,
+		})
+	}
+
+	slices.SortFunc(apiDomains, func(a, b *apimodel.DeliveryQueueDomain) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Domain, b.Domain)
+	})
+
+	return apiDomains, nil
+}
+
+// DeliveryQueueDomainGet returns up to limit queued outgoing
+// federation deliveries to the given domain, next due first.
+func (p *Processor) DeliveryQueueDomainGet(
+	ctx context.Context,
+	domain string,
+	limit int,
+) ([]*apimodel.Delivery, gtserror.WithCode) {
+	domain, errWithCode := normalizeQueueDomain(domain)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	deliveries, err := p.state.DB.GetDeliveriesForDomain(ctx, domain, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting queued deliveries for %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiDeliveries := make([]*apimodel.Delivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		apiDeliveries = append(apiDeliveries, p.converter.DeliveryToAPIDelivery(ctx, d))
+	}
+
+	return apiDeliveries, nil
+}
+
+// DeliveryQueueDomainFlush marks all queued outgoing federation
+// deliveries to the given domain as due for an attempt now, and
+// kicks off processing of the delivery queue in the background.
+func (p *Processor) DeliveryQueueDomainFlush(
+	ctx context.Context,
+	domain string,
+) (*apimodel.DeliveryQueueDomain, gtserror.WithCode) {
+	domain, errWithCode := normalizeQueueDomain(domain)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	count, err := p.state.DB.SetDeliveriesDueForDomain(ctx, domain, time.Now())
+	if err != nil {
+		err := gtserror.Newf("db error flushing queued deliveries for %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if count != 0 {
+		// Process the queue in the background, the
+		// request shouldn't wait on remote servers.
+		go p.transportController.ProcessDeliveryQueue(context.Background())
+	}
+
+	return &apimodel.DeliveryQueueDomain{
+		Domain: domain,
+		Count:  count,
+	}, nil
+}
+
+// DeliveryQueueDomainDelete drops all queued outgoing
+// federation deliveries to the given domain, for good.
+func (p *Processor) DeliveryQueueDomainDelete(
+	ctx context.Context,
+	domain string,
+) (*apimodel.DeliveryQueueDomain, gtserror.WithCode) {
+	domain, errWithCode := normalizeQueueDomain(domain)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	count, err := p.state.DB.DeleteDeliveriesForDomain(ctx, domain)
+	if err != nil {
+		err := gtserror.Newf("db error deleting queued deliveries for %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.DeliveryQueueDomain{
+		Domain: domain,
+		Count:  count,
+	}, nil
+}
+
+// normalizeQueueDomain returns the given domain in the
+// form that queued deliveries are stored with, i.e.
+// lowercase and punycode-encoded.
+func normalizeQueueDomain(domain string) (string, gtserror.WithCode) {
+	domain, err := util.Punify(strings.TrimSpace(domain))
+	if err != nil || domain == "" {
+		const text = "invalid domain"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+	return strings.ToLower(domain), nil
+}
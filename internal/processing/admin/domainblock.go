@@ -43,8 +43,16 @@ func (p *Processor) createDomainBlock(
 	obfuscate bool,
 	publicComment string,
 	privateComment string,
+	severity gtsmodel.DomainBlockSeverity,
+	rejectMedia bool,
+	rejectReports bool,
 	subscriptionID string,
 ) (*apimodel.DomainPermission, string, gtserror.WithCode) {
+	if severity == gtsmodel.DomainBlockSeverityUnknown {
+		const text = "domain block severity not recognized, valid values are: suspend, silence, limit, noop"
+		return nil, "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
 	// Check if a block already exists for this domain.
 	domainBlock, err := p.state.DB.GetDomainBlock(ctx, domain)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
@@ -53,6 +61,10 @@ func (p *Processor) createDomainBlock(
 		return nil, "", gtserror.NewErrorInternalError(err)
 	}
 
+	// Severity of the block before this call,
+	// stays unknown if no block existed yet.
+	var prevSeverity gtsmodel.DomainBlockSeverity
+
 	if domainBlock == nil {
 		// No block exists yet, create it.
 		domainBlock = &gtsmodel.DomainBlock{
@@ -63,6 +75,9 @@ func (p *Processor) createDomainBlock(
 			PublicComment:      text.SanitizeToPlaintext(publicComment),
 			Obfuscate:          &obfuscate,
 			SubscriptionID:     subscriptionID,
+			Severity:           severity,
+			RejectMedia:        &rejectMedia,
+			RejectReports:      &rejectReports,
 		}
 
 		// Insert the new block into the database.
@@ -70,6 +85,50 @@ func (p *Processor) createDomainBlock(
 			err = gtserror.Newf("db error putting domain block %s: %w", domain, err)
 			return nil, "", gtserror.NewErrorInternalError(err)
 		}
+	} else {
+		prevSeverity = domainBlock.Severity
+
+		// Block already exists, update how it's
+		// enforced in place if that has changed.
+		var columns []string
+
+		if domainBlock.Severity != severity {
+			domainBlock.Severity = severity
+			columns = append(columns, "severity")
+		}
+
+		if *domainBlock.RejectMedia != rejectMedia {
+			domainBlock.RejectMedia = &rejectMedia
+			columns = append(columns, "reject_media")
+		}
+
+		if *domainBlock.RejectReports != rejectReports {
+			domainBlock.RejectReports = &rejectReports
+			columns = append(columns, "reject_reports")
+		}
+
+		if len(columns) > 0 {
+			if err := p.state.DB.UpdateDomainBlock(ctx, domainBlock, columns...); err != nil {
+				err = gtserror.Newf("db error updating domain block %s: %w", domain, err)
+				return nil, "", gtserror.NewErrorInternalError(err)
+			}
+		}
+	}
+
+	if prevSeverity == gtsmodel.DomainBlockSeveritySuspend &&
+		domainBlock.Severity != gtsmodel.DomainBlockSeveritySuspend {
+		// Block was lowered from a suspension,
+		// so undo the suspension side effects.
+		return p.lowerDomainBlock(ctx, adminAcct, domainBlock)
+	}
+
+	if domainBlock.Severity != gtsmodel.DomainBlockSeveritySuspend {
+		// Blocks of lesser severity only limit
+		// the domain, which is enforced as content
+		// comes and goes, so there are no side
+		// effects to process (and no admin action).
+		apiDomainBlock, errWithCode := p.apiDomainPerm(ctx, domainBlock, false)
+		return apiDomainBlock, "", errWithCode
 	}
 
 	actionID := id.NewULID()
@@ -119,6 +178,50 @@ func (p *Processor) createDomainBlock(
 	return apiDomainBlock, actionID, nil
 }
 
+// lowerDomainBlock undoes the side effects of the given domain
+// block having been a suspension, after its severity was lowered.
+func (p *Processor) lowerDomainBlock(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	domainBlock *gtsmodel.DomainBlock,
+) (*apimodel.DomainPermission, string, gtserror.WithCode) {
+	actionID := id.NewULID()
+
+	// Process domain unsuspend
+	// side effects asynchronously.
+	if errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryDomain,
+			TargetID:       domainBlock.Domain,
+			Type:           gtsmodel.AdminActionUnsuspend,
+			AccountID:      adminAcct.ID,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			// Log start + finish.
+			l := log.WithFields(kv.Fields{
+				{"domain", domainBlock.Domain},
+				{"actionID", actionID},
+			}...).WithContext(ctx)
+
+			l.Info("processing domain block lowering side effects")
+			defer func() { l.Info("finished processing domain block lowering side effects") }()
+
+			return p.domainUnblockSideEffects(ctx, domainBlock)
+		},
+	); errWithCode != nil {
+		return nil, actionID, errWithCode
+	}
+
+	apiDomainBlock, errWithCode := p.apiDomainPerm(ctx, domainBlock, false)
+	if errWithCode != nil {
+		return nil, actionID, errWithCode
+	}
+
+	return apiDomainBlock, actionID, nil
+}
+
 // skipBlockSideEffects checks if side effects of block creation
 // should be skipped for the given domain, taking account of
 // instance federation mode, and existence of any allows
@@ -249,6 +352,12 @@ func (p *Processor) deleteDomainBlock(
 		return nil, "", gtserror.NewErrorInternalError(err)
 	}
 
+	if domainBlock.Severity != gtsmodel.DomainBlockSeveritySuspend {
+		// Nothing was suspended by this
+		// block, so nothing to undo.
+		return apiDomainBlock, "", nil
+	}
+
 	actionID := id.NewULID()
 
 	// Process domain unblock side
@@ -54,7 +54,13 @@ func (p *Processor) apiDomainPerm(
 // effects of the permission creation.
 //
 // If the same permission type already exists for the domain,
-// side effects will be retried.
+// side effects will be retried. For an existing domain block,
+// severity, rejectMedia and rejectReports are updated in place,
+// and lowering a block from suspend undoes the suspension.
+//
+// Severity, rejectMedia and rejectReports only apply to domain
+// blocks. Blocks with a severity other than suspend don't have
+// side effects, and so won't result in an admin action.
 //
 // Return values for this function are the new or existing
 // domain permission, the ID of the admin action resulting
@@ -67,6 +73,9 @@ func (p *Processor) DomainPermissionCreate(
 	obfuscate bool,
 	publicComment string,
 	privateComment string,
+	severity gtsmodel.DomainBlockSeverity,
+	rejectMedia bool,
+	rejectReports bool,
 	subscriptionID string,
 ) (*apimodel.DomainPermission, string, gtserror.WithCode) {
 	switch permissionType {
@@ -80,6 +89,9 @@ func (p *Processor) DomainPermissionCreate(
 			obfuscate,
 			publicComment,
 			privateComment,
+			severity,
+			rejectMedia,
+			rejectReports,
 			subscriptionID,
 		)
 
@@ -195,6 +207,9 @@ func (p *Processor) DomainPermissionsImport(
 			obfuscate      = domainPerm.Obfuscate
 			publicComment  = domainPerm.PublicComment
 			privateComment = domainPerm.PrivateComment
+			severity       = gtsmodel.NewDomainBlockSeverity(domainPerm.Severity)
+			rejectMedia    = domainPerm.RejectMedia
+			rejectReports  = domainPerm.RejectReports
 			subscriptionID = "" // No sub ID for imports.
 			errWithCode    gtserror.WithCode
 		)
@@ -207,6 +222,9 @@ func (p *Processor) DomainPermissionsImport(
 			obfuscate,
 			publicComment,
 			privateComment,
+			severity,
+			rejectMedia,
+			rejectReports,
 			subscriptionID,
 		)
 
@@ -133,6 +133,9 @@ func (suite *DomainBlockTestSuite) createDomainPerm(
 		false,
 		"",
 		"",
+		gtsmodel.DomainBlockSeveritySuspend,
+		false,
+		false,
 		"",
 	)
 	suite.NoError(errWithCode)
@@ -526,6 +529,155 @@ func (suite *DomainBlockTestSuite) TestAllowAndBlockDomain() {
 	})
 }
 
+func (suite *DomainBlockTestSuite) TestSilenceDomain() {
+	const domain = "fossbros-anonymous.io"
+	ctx := context.Background()
+
+	// Unrecognized severity should be rejected.
+	_, _, errWithCode := suite.adminProcessor.DomainPermissionCreate(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		suite.testAccounts["admin_account"],
+		domain,
+		false,
+		"",
+		"",
+		gtsmodel.NewDomainBlockSeverity("obliterate"),
+		false,
+		false,
+		"",
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	apiPerm, actionID, errWithCode := suite.adminProcessor.DomainPermissionCreate(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		suite.testAccounts["admin_account"],
+		domain,
+		false,
+		"",
+		"",
+		gtsmodel.NewDomainBlockSeverity("limit"),
+		true,
+		false,
+		"",
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal("silence", apiPerm.Severity)
+	suite.True(apiPerm.RejectMedia)
+	suite.False(apiPerm.RejectReports)
+
+	// No side effects to process, so no action.
+	suite.Empty(actionID)
+
+	// Domain should be limited, not blocked.
+	blocked, err := suite.db.IsDomainBlocked(ctx, domain)
+	suite.NoError(err)
+	suite.False(blocked)
+
+	silenced, err := suite.db.IsDomainSilenced(ctx, domain)
+	suite.NoError(err)
+	suite.True(silenced)
+
+	// Accounts on the domain shouldn't be suspended.
+	account, err := suite.db.GetAccountByID(ctx, suite.testAccounts["remote_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(account.SuspendedAt)
+
+	// Lifting the limit has no side effects either.
+	_, actionID, errWithCode = suite.adminProcessor.DomainPermissionDelete(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		suite.testAccounts["admin_account"],
+		apiPerm.ID,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(actionID)
+
+	silenced, err = suite.db.IsDomainSilenced(ctx, domain)
+	suite.NoError(err)
+	suite.False(silenced)
+}
+
+func (suite *DomainBlockTestSuite) TestEscalateAndLowerDomainBlock() {
+	const domain = "fossbros-anonymous.io"
+	ctx := context.Background()
+	config.SetInstanceFederationMode(config.InstanceFederationModeBlocklist)
+
+	// createBlock creates (or updates)
+	// the block with given severity.
+	createBlock := func(severity gtsmodel.DomainBlockSeverity) (*apimodel.DomainPermission, string) {
+		apiPerm, actionID, errWithCode := suite.adminProcessor.DomainPermissionCreate(
+			ctx,
+			gtsmodel.DomainPermissionBlock,
+			suite.testAccounts["admin_account"],
+			domain,
+			false,
+			"",
+			"",
+			severity,
+			false,
+			false,
+			"",
+		)
+		if errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+		return apiPerm, actionID
+	}
+
+	// checkSuspended checks whether
+	// account on the domain is suspended.
+	checkSuspended := func(suspended bool) {
+		account, err := suite.db.GetAccountByID(ctx, suite.testAccounts["remote_account_1"].ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(suspended, !account.SuspendedAt.IsZero())
+	}
+
+	// Start out with a silence.
+	silence, actionID := createBlock(gtsmodel.DomainBlockSeveritySilence)
+	suite.Equal("silence", silence.Severity)
+	suite.Empty(actionID)
+	checkSuspended(false)
+
+	// Escalate it to a suspension.
+	suspend, actionID := createBlock(gtsmodel.DomainBlockSeveritySuspend)
+	suite.Equal(silence.ID, suspend.ID)
+	suite.Equal("suspend", suspend.Severity)
+	suite.NotEmpty(actionID)
+	suite.awaitAction(actionID)
+	checkSuspended(true)
+
+	blocked, err := suite.db.IsDomainBlocked(ctx, domain)
+	suite.NoError(err)
+	suite.True(blocked)
+
+	// Lower it back down to a silence.
+	silence, actionID = createBlock(gtsmodel.DomainBlockSeveritySilence)
+	suite.Equal(suspend.ID, silence.ID)
+	suite.Equal("silence", silence.Severity)
+	suite.NotEmpty(actionID)
+	suite.awaitAction(actionID)
+	checkSuspended(false)
+
+	blocked, err = suite.db.IsDomainBlocked(ctx, domain)
+	suite.NoError(err)
+	suite.False(blocked)
+
+	silenced, err := suite.db.IsDomainSilenced(ctx, domain)
+	suite.NoError(err)
+	suite.True(silenced)
+}
+
 func TestDomainBlockTestSuite(t *testing.T) {
 	suite.Run(t, new(DomainBlockTestSuite))
 }
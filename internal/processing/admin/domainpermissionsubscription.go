@@ -0,0 +1,343 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// apiDomainPermSub is a cheeky shortcut for returning the
+// API version of the given domain permission subscription,
+// or an appropriate error if something goes wrong.
+func (p *Processor) apiDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	apiPermSub, err := p.converter.DomainPermSubToAPIDomainPermSub(ctx, permSub)
+	if err != nil {
+		err := gtserror.NewfAt(3, "error converting domain permission subscription to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPermSub, nil
+}
+
+// getDomainPermSub returns the domain permission subscription
+// with the given id, or an appropriate error if not found.
+func (p *Processor) getDomainPermSub(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, err := p.state.DB.GetDomainPermissionSubscriptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("no domain permission subscription exists with id %s", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		err = gtserror.Newf("db error getting domain permission subscription %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return permSub, nil
+}
+
+// DomainPermissionSubscriptionsGet returns all domain permission
+// subscriptions of the given type (or all types if unknown),
+// ordered by descending priority.
+func (p *Processor) DomainPermissionSubscriptionsGet(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+) ([]*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSubs, err := p.state.DB.GetDomainPermissionSubscriptions(ctx, permType)
+	if err != nil {
+		err := gtserror.Newf("db error getting domain permission subscriptions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPermSubs := make([]*apimodel.DomainPermissionSubscription, len(permSubs))
+	for i, permSub := range permSubs {
+		apiPermSub, errWithCode := p.apiDomainPermSub(ctx, permSub)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiPermSubs[i] = apiPermSub
+	}
+
+	return apiPermSubs, nil
+}
+
+// DomainPermissionSubscriptionGet returns one
+// domain permission subscription with the given id.
+func (p *Processor) DomainPermissionSubscriptionGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiDomainPermSub(ctx, permSub)
+}
+
+// DomainPermissionSubscriptionCreate creates a new domain permission
+// subscription with the given parameters. The subscription list will
+// be fetched and processed on the next scheduled subscriptions run.
+func (p *Processor) DomainPermissionSubscriptionCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	priority uint8,
+	title string,
+	uri string,
+	contentType gtsmodel.DomainPermSubContentType,
+	permType gtsmodel.DomainPermissionType,
+	adoptOrphans bool,
+	fetchUsername string,
+	fetchPassword string,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub := &gtsmodel.DomainPermissionSubscription{
+		ID:                 id.NewULID(),
+		Priority:           priority,
+		Title:              title,
+		PermissionType:     permType,
+		AdoptOrphans:       &adoptOrphans,
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+		URI:                uri,
+		ContentType:        contentType,
+		FetchUsername:      fetchUsername,
+		FetchPassword:      fetchPassword,
+	}
+
+	if err := p.state.DB.PutDomainPermissionSubscription(ctx, permSub); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Unique constraint conflict.
+			const errText = "domain permission subscription with given URI or title already exists"
+			return nil, gtserror.NewErrorConflict(errors.New(errText), errText)
+		}
+
+		err = gtserror.Newf("db error putting domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPermSub(ctx, permSub)
+}
+
+// DomainPermissionSubscriptionUpdate updates the domain permission
+// subscription with the given id, setting any non-nil fields.
+func (p *Processor) DomainPermissionSubscriptionUpdate(
+	ctx context.Context,
+	id string,
+	priority *uint8,
+	title *string,
+	uri *string,
+	contentType *gtsmodel.DomainPermSubContentType,
+	adoptOrphans *bool,
+	fetchUsername *string,
+	fetchPassword *string,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	columns := make([]string, 0, 7)
+
+	if priority != nil {
+		permSub.Priority = *priority
+		columns = append(columns, "priority")
+	}
+
+	if title != nil {
+		permSub.Title = *title
+		columns = append(columns, "title")
+	}
+
+	if uri != nil && *uri != permSub.URI {
+		permSub.URI = *uri
+		columns = append(columns, "uri")
+
+		// New list, so forget
+		// any caching headers.
+		permSub.ETag = ""
+		permSub.SuccessfullyFetchedAt = time.Time{}
+		columns = append(columns, "etag", "successfully_fetched_at")
+	}
+
+	if contentType != nil {
+		permSub.ContentType = *contentType
+		columns = append(columns, "content_type")
+	}
+
+	if adoptOrphans != nil {
+		permSub.AdoptOrphans = adoptOrphans
+		columns = append(columns, "adopt_orphans")
+	}
+
+	if fetchUsername != nil {
+		permSub.FetchUsername = *fetchUsername
+		columns = append(columns, "fetch_username")
+	}
+
+	if fetchPassword != nil {
+		permSub.FetchPassword = *fetchPassword
+		columns = append(columns, "fetch_password")
+	}
+
+	if len(columns) == 0 {
+		const errText = "no updateable fields set on request"
+		return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, permSub, columns...); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Unique constraint conflict.
+			const errText = "domain permission subscription with given URI or title already exists"
+			return nil, gtserror.NewErrorConflict(errors.New(errText), errText)
+		}
+
+		err = gtserror.Newf("db error updating domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPermSub(ctx, permSub)
+}
+
+// DomainPermissionSubscriptionRemove removes the domain permission
+// subscription with the given id. If removeChildren is true, then
+// all domain permissions owned by the subscription are removed too
+// (with the usual side effects), else they're orphaned and kept.
+func (p *Processor) DomainPermissionSubscriptionRemove(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+	removeChildren bool,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Get all perms owned by this subscription.
+	domainPerms, err := p.subscriptionDomainPerms(ctx, permSub)
+	if err != nil {
+		err := gtserror.Newf("db error getting domain permissions of subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, domainPerm := range domainPerms {
+		if removeChildren {
+			// Remove the perm entirely.
+			_, _, errWithCode := p.DomainPermissionDelete(
+				ctx,
+				permSub.PermissionType,
+				adminAcct,
+				domainPerm.GetID(),
+			)
+			if errWithCode != nil {
+				return nil, errWithCode
+			}
+			continue
+		}
+
+		// Just orphan the perm.
+		if err := p.setDomainPermSubscriptionID(ctx, domainPerm, ""); err != nil {
+			err := gtserror.Newf("db error orphaning domain permission: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.state.DB.DeleteDomainPermissionSubscription(ctx, permSub.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPermSub(ctx, permSub)
+}
+
+// subscriptionDomainPerms returns all domain permissions
+// currently owned by the given domain permission subscription.
+func (p *Processor) subscriptionDomainPerms(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+) ([]gtsmodel.DomainPermission, error) {
+	var domainPerms []gtsmodel.DomainPermission
+
+	switch permSub.PermissionType {
+	case gtsmodel.DomainPermissionBlock:
+		blocks, err := p.state.DB.GetDomainBlocks(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, block := range blocks {
+			if block.SubscriptionID == permSub.ID {
+				domainPerms = append(domainPerms, block)
+			}
+		}
+
+	case gtsmodel.DomainPermissionAllow:
+		allows, err := p.state.DB.GetDomainAllows(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, allow := range allows {
+			if allow.SubscriptionID == permSub.ID {
+				domainPerms = append(domainPerms, allow)
+			}
+		}
+
+	default:
+		return nil, gtserror.Newf("unrecognized permission type %d", permSub.PermissionType)
+	}
+
+	return domainPerms, nil
+}
+
+// setDomainPermSubscriptionID updates the
+// subscription ID of the given domain permission.
+func (p *Processor) setDomainPermSubscriptionID(
+	ctx context.Context,
+	domainPerm gtsmodel.DomainPermission,
+	subscriptionID string,
+) error {
+	switch perm := domainPerm.(type) {
+	case *gtsmodel.DomainBlock:
+		perm.SubscriptionID = subscriptionID
+		return p.state.DB.UpdateDomainBlock(ctx, perm, "subscription_id")
+
+	case *gtsmodel.DomainAllow:
+		perm.SubscriptionID = subscriptionID
+		return p.state.DB.UpdateDomainAllow(ctx, perm, "subscription_id")
+
+	default:
+		return gtserror.Newf("unrecognized domain permission %T", domainPerm)
+	}
+}
@@ -0,0 +1,215 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainPermissionSubscriptionTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// subscribedDomains are the domains which should be
+// blocked by each of the subscribed testrig lists.
+var subscribedDomains = []string{
+	"bumfaces.net",
+	"peepee.poopoo",
+	"nothanks.com",
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) getPermSub(ctx context.Context) *gtsmodel.DomainPermissionSubscription {
+	permSub, err := suite.db.GetDomainPermissionSubscriptionByID(
+		ctx,
+		testrig.NewTestDomainPermissionSubscriptions()["admin_account_block_1"].ID,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return permSub
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) testProcess(uri string, contentType gtsmodel.DomainPermSubContentType) {
+	ctx := context.Background()
+
+	// Point the sub at the given list.
+	permSub := suite.getPermSub(ctx)
+	permSub.URI = uri
+	permSub.ContentType = contentType
+	if err := suite.db.UpdateDomainPermissionSubscription(ctx, permSub, "uri", "content_type"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.adminProcessor.DomainPermissionSubscriptionsProcess(ctx)
+
+	// Each listed domain should now
+	// be blocked, owned by the sub.
+	for _, domain := range subscribedDomains {
+		block, err := suite.db.GetDomainBlock(ctx, domain)
+		if err != nil {
+			suite.FailNow(err.Error(), "no block for %s", domain)
+		}
+		suite.Equal(permSub.ID, block.SubscriptionID)
+		suite.Equal(suite.testAccounts["admin_account"].ID, block.CreatedByAccountID)
+	}
+
+	// Fetch should be recorded on the sub.
+	permSub = suite.getPermSub(ctx)
+	suite.Empty(permSub.Error)
+	suite.False(permSub.FetchedAt.IsZero())
+	suite.Equal(permSub.FetchedAt, permSub.SuccessfullyFetchedAt)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessCSV() {
+	suite.testProcess("https://lists.example.org/baddies.csv", gtsmodel.DomainPermSubContentTypeCSV)
+
+	// Silenced domain should not be blocked...
+	blocked, err := suite.db.IsDomainBlocked(context.Background(), "silenced.example.org")
+	suite.NoError(err)
+	suite.False(blocked)
+
+	// ...but it should be limited.
+	silenced, err := suite.db.IsDomainSilenced(context.Background(), "silenced.example.org")
+	suite.NoError(err)
+	suite.True(silenced)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessJSON() {
+	suite.testProcess("https://lists.example.org/baddies.json", gtsmodel.DomainPermSubContentTypeJSON)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessPlain() {
+	suite.testProcess("https://lists.example.org/baddies.txt", gtsmodel.DomainPermSubContentTypePlain)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessOwnership() {
+	ctx := context.Background()
+	permSub := suite.getPermSub(ctx)
+
+	// Block owned by the sub, which
+	// is no longer in the list.
+	unlisted := &gtsmodel.DomainBlock{
+		ID:                 "01JGE6CB7Y4G6TCVDGWT3NVEJ0",
+		Domain:             "not-bad-anymore.example.org",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		SubscriptionID:     permSub.ID,
+	}
+
+	// Orphaned block which
+	// is present in the list.
+	orphan := &gtsmodel.DomainBlock{
+		ID:                 "01JGE6D0W0H1K3C5PT4RBTQ7PW",
+		Domain:             "bumfaces.net",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}
+
+	for _, block := range []*gtsmodel.DomainBlock{unlisted, orphan} {
+		if err := suite.db.CreateDomainBlock(ctx, block); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	suite.adminProcessor.DomainPermissionSubscriptionsProcess(ctx)
+
+	// Unlisted block should be gone.
+	blocked, err := suite.db.IsDomainBlocked(ctx, unlisted.Domain)
+	suite.NoError(err)
+	suite.False(blocked)
+
+	// Orphan should be left alone,
+	// since sub doesn't adopt orphans.
+	block, err := suite.db.GetDomainBlock(ctx, orphan.Domain)
+	suite.NoError(err)
+	suite.Empty(block.SubscriptionID)
+
+	// Now allow adopting orphans and go again.
+	permSub.AdoptOrphans = util.Ptr(true)
+	permSub.ETag = ""
+	permSub.SuccessfullyFetchedAt = permSub.SuccessfullyFetchedAt.AddDate(-1, 0, 0)
+	if err := suite.db.UpdateDomainPermissionSubscription(ctx, permSub, "adopt_orphans"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.adminProcessor.DomainPermissionSubscriptionsProcess(ctx)
+
+	block, err = suite.db.GetDomainBlock(ctx, orphan.Domain)
+	suite.NoError(err)
+	suite.Equal(permSub.ID, block.SubscriptionID)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessFetchError() {
+	ctx := context.Background()
+
+	permSub := suite.getPermSub(ctx)
+	permSub.URI = "https://lists.example.org/does-not-exist.csv"
+	if err := suite.db.UpdateDomainPermissionSubscription(ctx, permSub, "uri"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.adminProcessor.DomainPermissionSubscriptionsProcess(ctx)
+
+	// Error should be stored on sub.
+	permSub = suite.getPermSub(ctx)
+	suite.Contains(permSub.Error, "404")
+	suite.False(permSub.FetchedAt.IsZero())
+	suite.True(permSub.SuccessfullyFetchedAt.IsZero())
+
+	// Nothing should be blocked.
+	for _, domain := range subscribedDomains {
+		blocked, err := suite.db.IsDomainBlocked(ctx, domain)
+		suite.NoError(err)
+		suite.False(blocked)
+	}
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestRemoveOrphansChildren() {
+	ctx := context.Background()
+	permSub := suite.getPermSub(ctx)
+
+	suite.adminProcessor.DomainPermissionSubscriptionsProcess(ctx)
+
+	// Remove sub, keeping children.
+	_, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionRemove(
+		ctx,
+		suite.testAccounts["admin_account"],
+		permSub.ID,
+		false,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Blocks should remain, orphaned.
+	for _, domain := range subscribedDomains {
+		block, err := suite.db.GetDomainBlock(ctx, domain)
+		if err != nil {
+			suite.FailNow(err.Error(), "no block for %s", domain)
+		}
+		suite.Empty(block.SubscriptionID)
+	}
+}
+
+func TestDomainPermissionSubscriptionTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainPermissionSubscriptionTestSuite))
+}
@@ -0,0 +1,546 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// maxDomainPermListSize is the maximum number
+// of bytes we'll read from a subscribed list.
+const maxDomainPermListSize = 16 << 20 // 16MiB
+
+// domainPermEntry is one entry
+// parsed from a subscribed list.
+type domainPermEntry struct {
+	domain        string
+	publicComment string
+	obfuscate     bool
+	severity      gtsmodel.DomainBlockSeverity
+	rejectMedia   bool
+	rejectReports bool
+}
+
+// ScheduleDomainPermissionSubscriptions schedules processing
+// of all domain permission subscriptions to run periodically,
+// according to the configured instance subscriptions period.
+func (p *Processor) ScheduleDomainPermissionSubscriptions() error {
+	every := config.GetInstanceSubscriptionsProcessEvery()
+	if every <= 0 {
+		log.Info(nil, "domain permission subscriptions processing disabled")
+		return nil
+	}
+
+	// Give the instance a minute
+	// to settle before first run.
+	firstRunAt := time.Now().Add(time.Minute)
+
+	log.Infof(nil,
+		"scheduling domain permission subscriptions to be processed every %s, starting at %s",
+		every, firstRunAt,
+	)
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@subsprocess",
+		firstRunAt,
+		every,
+		func(ctx context.Context, _ time.Time) {
+			p.DomainPermissionSubscriptionsProcess(ctx)
+		},
+	) {
+		return gtserror.New("failed to schedule @subsprocess")
+	}
+
+	return nil
+}
+
+// DomainPermissionSubscriptionsProcess fetches the list of each
+// domain permission subscription in order of priority, and creates
+// or removes domain permissions owned by that subscription so that
+// they match the latest version of the list.
+func (p *Processor) DomainPermissionSubscriptionsProcess(ctx context.Context) {
+	l := log.WithContext(ctx)
+	l.Info("start processing domain permission subscriptions")
+	defer l.Info("finished processing domain permission subscriptions")
+
+	for _, permType := range []gtsmodel.DomainPermissionType{
+		gtsmodel.DomainPermissionBlock,
+		gtsmodel.DomainPermissionAllow,
+	} {
+		permSubs, err := p.state.DB.GetDomainPermissionSubscriptions(ctx, permType)
+		if err != nil {
+			l.Errorf("db error getting domain %s subscriptions: %v", permType.String(), err)
+			continue
+		}
+
+		// Rank each subscription by priority, so that
+		// higher priority subscriptions can take over
+		// permissions owned by lower priority ones.
+		ranks := make(map[string]int, len(permSubs))
+		for i, permSub := range permSubs {
+			ranks[permSub.ID] = i
+		}
+
+		for _, permSub := range permSubs {
+			p.processDomainPermSub(ctx, permSub, ranks)
+		}
+	}
+}
+
+// processDomainPermSub fetches and processes
+// one domain permission subscription, updating
+// the subscription with the result of the fetch.
+func (p *Processor) processDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	ranks map[string]int,
+) {
+	l := log.WithContext(ctx).WithField("subscription", permSub.URI)
+
+	// Fetch and parse latest version of the list.
+	entries, etag, err := p.fetchDomainPermSub(ctx, permSub)
+	permSub.FetchedAt = time.Now()
+
+	if err != nil {
+		// Store the error on the subscription
+		// so it can be seen by admins, leaving
+		// existing permissions untouched.
+		l.Warnf("error fetching subscription: %v", err)
+		permSub.Error = err.Error()
+		if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, permSub,
+			"fetched_at",
+			"error",
+		); err != nil {
+			l.Errorf("db error updating subscription: %v", err)
+		}
+		return
+	}
+
+	if entries != nil {
+		// List was (re)fetched, sync permissions with it.
+		if err := p.syncDomainPermSub(ctx, permSub, entries, ranks); err != nil {
+			l.Errorf("error syncing subscription: %v", err)
+		}
+	} else {
+		l.Debug("subscription unchanged since last fetch")
+	}
+
+	permSub.SuccessfullyFetchedAt = permSub.FetchedAt
+	permSub.ETag = etag
+	permSub.Error = ""
+	if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, permSub,
+		"fetched_at",
+		"successfully_fetched_at",
+		"etag",
+		"error",
+	); err != nil {
+		l.Errorf("db error updating subscription: %v", err)
+	}
+}
+
+// fetchDomainPermSub fetches the list of the given domain
+// permission subscription using the instance transport, and
+// parses it. Returned entries will be nil if the list did not
+// change since it was last fetched.
+func (p *Processor) fetchDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+) ([]domainPermEntry, string, error) {
+	tsport, err := p.transportController.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return nil, "", gtserror.Newf("error getting instance transport: %w", err)
+	}
+
+	body, etag, err := tsport.DereferenceDomainPermissions(ctx, permSub)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if body == nil {
+		// Not modified.
+		return nil, etag, nil
+	}
+	defer body.Close()
+
+	// Don't read more than we should.
+	r := io.LimitReader(body, maxDomainPermListSize)
+
+	var entries []domainPermEntry
+	switch permSub.ContentType {
+	case gtsmodel.DomainPermSubContentTypeCSV:
+		entries, err = parseDomainPermsCSV(r, permSub.PermissionType)
+	case gtsmodel.DomainPermSubContentTypeJSON:
+		entries, err = parseDomainPermsJSON(r)
+	case gtsmodel.DomainPermSubContentTypePlain:
+		entries, err = parseDomainPermsPlain(r)
+	default:
+		err = fmt.Errorf("unrecognized content type %s", permSub.ContentType)
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Make sure entries is non-nil,
+	// since an empty list is valid.
+	if entries == nil {
+		entries = []domainPermEntry{}
+	}
+
+	return entries, etag, nil
+}
+
+// syncDomainPermSub creates domain permissions for entries in
+// the given list that don't exist yet, takes ownership of any
+// that should be owned by this subscription, updates the severity
+// of owned blocks which changed in the list, and removes owned
+// permissions which are no longer present in the list.
+func (p *Processor) syncDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	entries []domainPermEntry,
+	ranks map[string]int,
+) error {
+	l := log.WithContext(ctx).WithField("subscription", permSub.URI)
+
+	// Permissions created by the subscription
+	// are attributed to the admin who created it.
+	adminAcct, err := p.state.DB.GetAccountByID(ctx, permSub.CreatedByAccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting subscription creator: %w", err)
+	}
+
+	privateComment := "created by domain permission subscription " + permSub.URI
+	if permSub.Title != "" {
+		privateComment = "created by domain permission subscription " + permSub.Title
+	}
+
+	listed := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		listed[entry.domain] = struct{}{}
+
+		existing, err := p.getDomainPerm(ctx, permSub.PermissionType, entry.domain)
+		if err != nil {
+			l.Errorf("db error getting existing permission for %s: %v", entry.domain, err)
+			continue
+		}
+
+		if existing == nil {
+			// No permission yet, create it.
+			if _, _, errWithCode := p.DomainPermissionCreate(
+				ctx,
+				permSub.PermissionType,
+				adminAcct,
+				entry.domain,
+				entry.obfuscate,
+				entry.publicComment,
+				privateComment,
+				entry.severity,
+				entry.rejectMedia,
+				entry.rejectReports,
+				permSub.ID,
+			); errWithCode != nil {
+				l.Errorf("error creating permission for %s: %v", entry.domain, errWithCode)
+			}
+			continue
+		}
+
+		ownerID := existing.GetSubscriptionID()
+		switch {
+		case ownerID == permSub.ID:
+			// Already ours.
+
+		case ownerID == "":
+			// Orphan; only adopt
+			// it if we're allowed.
+			if !util.PtrValueOr(permSub.AdoptOrphans, false) {
+				continue
+			}
+
+		default:
+			// Owned by another subscription; only take
+			// it over if that subscription is gone, or
+			// has a lower priority than this one.
+			if rank, ok := ranks[ownerID]; ok && rank < ranks[permSub.ID] {
+				continue
+			}
+		}
+
+		if ownerID != permSub.ID {
+			if err := p.setDomainPermSubscriptionID(ctx, existing, permSub.ID); err != nil {
+				l.Errorf("db error taking ownership of permission for %s: %v", entry.domain, err)
+				continue
+			}
+		}
+
+		block, ok := existing.(*gtsmodel.DomainBlock)
+		if !ok || (block.Severity == entry.severity &&
+			*block.RejectMedia == entry.rejectMedia &&
+			*block.RejectReports == entry.rejectReports) {
+			// Nothing to update.
+			continue
+		}
+
+		// Owned block's enforcement changed in the
+		// list, update it (and process side effects).
+		if _, _, errWithCode := p.DomainPermissionCreate(
+			ctx,
+			permSub.PermissionType,
+			adminAcct,
+			entry.domain,
+			entry.obfuscate,
+			entry.publicComment,
+			privateComment,
+			entry.severity,
+			entry.rejectMedia,
+			entry.rejectReports,
+			permSub.ID,
+		); errWithCode != nil {
+			l.Errorf("error updating permission for %s: %v", entry.domain, errWithCode)
+		}
+	}
+
+	// Remove permissions we own which
+	// are no longer present in the list.
+	owned, err := p.subscriptionDomainPerms(ctx, permSub)
+	if err != nil {
+		return gtserror.Newf("db error getting owned permissions: %w", err)
+	}
+
+	for _, domainPerm := range owned {
+		if _, ok := listed[domainPerm.GetDomain()]; ok {
+			continue
+		}
+
+		if _, _, errWithCode := p.DomainPermissionDelete(
+			ctx,
+			permSub.PermissionType,
+			adminAcct,
+			domainPerm.GetID(),
+		); errWithCode != nil {
+			l.Errorf("error removing permission for %s: %v", domainPerm.GetDomain(), errWithCode)
+		}
+	}
+
+	return nil
+}
+
+// getDomainPerm returns the domain permission of the given
+// type for the given domain, or nil if it doesn't exist.
+func (p *Processor) getDomainPerm(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	domain string,
+) (gtsmodel.DomainPermission, error) {
+	switch permType {
+	case gtsmodel.DomainPermissionBlock:
+		block, err := p.state.DB.GetDomainBlock(ctx, domain)
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, nil
+		}
+		return block, err
+
+	case gtsmodel.DomainPermissionAllow:
+		allow, err := p.state.DB.GetDomainAllow(ctx, domain)
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, nil
+		}
+		return allow, err
+
+	default:
+		return nil, gtserror.Newf("unrecognized permission type %d", permType)
+	}
+}
+
+// normalizeListDomain tidies up and punifies the given
+// domain from a subscribed list, returning an empty
+// string if the domain is not valid for a permission.
+func normalizeListDomain(domain string) string {
+	domain = strings.TrimSpace(domain)
+	domain = strings.TrimSuffix(domain, ".")
+	if domain == "" || strings.ContainsAny(domain, "/*:@ \t") {
+		return ""
+	}
+
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(domain)
+}
+
+// parseDomainPermsPlain parses a plaintext list
+// with one domain per line. Empty lines and
+// lines starting with '#' are ignored.
+func parseDomainPermsPlain(r io.Reader) ([]domainPermEntry, error) {
+	var (
+		entries []domainPermEntry
+		scanner = bufio.NewScanner(r)
+	)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		domain := normalizeListDomain(line)
+		if domain == "" {
+			log.Debugf(nil, "skipping invalid domain %q", line)
+			continue
+		}
+
+		entries = append(entries, domainPermEntry{
+			domain:   domain,
+			severity: gtsmodel.DomainBlockSeveritySuspend,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, gtserror.Newf("error reading plaintext list: %w", err)
+	}
+
+	return entries, nil
+}
+
+// parseDomainPermsCSV parses a Mastodon-style CSV list,
+// with a header row naming the columns ("#domain",
+// "#severity", "#public_comment", "#obfuscate", etc).
+// For block lists, rows with unrecognized severity
+// are skipped.
+func parseDomainPermsCSV(r io.Reader, permType gtsmodel.DomainPermissionType) ([]domainPermEntry, error) {
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1 // Allow variable.
+	csvReader.TrimLeadingSpace = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, gtserror.Newf("error reading csv list: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	// Map header names to column indexes.
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "#")
+		columns[name] = i
+	}
+
+	domainIdx, ok := columns["domain"]
+	if !ok {
+		return nil, gtserror.New("csv list header has no domain column")
+	}
+
+	// field returns the value of named column
+	// in the given record, or "" if not set.
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	entries := make([]domainPermEntry, 0, len(records)-1)
+	for _, record := range records[1:] {
+		if domainIdx >= len(record) {
+			continue
+		}
+
+		domain := normalizeListDomain(record[domainIdx])
+		if domain == "" {
+			log.Debugf(nil, "skipping invalid domain %q", record[domainIdx])
+			continue
+		}
+
+		severity := gtsmodel.NewDomainBlockSeverity(field(record, "severity"))
+		if permType == gtsmodel.DomainPermissionBlock &&
+			severity == gtsmodel.DomainBlockSeverityUnknown {
+			log.Debugf(nil, "skipping %s with unknown severity", domain)
+			continue
+		}
+
+		obfuscate, _ := strconv.ParseBool(field(record, "obfuscate"))
+		rejectMedia, _ := strconv.ParseBool(field(record, "reject_media"))
+		rejectReports, _ := strconv.ParseBool(field(record, "reject_reports"))
+		entries = append(entries, domainPermEntry{
+			domain:        domain,
+			publicComment: field(record, "public_comment"),
+			obfuscate:     obfuscate,
+			severity:      severity,
+			rejectMedia:   rejectMedia,
+			rejectReports: rejectReports,
+		})
+	}
+
+	return entries, nil
+}
+
+// parseDomainPermsJSON parses a GoToSocial-style
+// JSON list, as produced by domain permission exports.
+func parseDomainPermsJSON(r io.Reader) ([]domainPermEntry, error) {
+	var apiDomainPerms []*apimodel.DomainPermission
+	if err := json.NewDecoder(r).Decode(&apiDomainPerms); err != nil {
+		return nil, gtserror.Newf("error decoding json list: %w", err)
+	}
+
+	entries := make([]domainPermEntry, 0, len(apiDomainPerms))
+	for _, apiDomainPerm := range apiDomainPerms {
+		if apiDomainPerm == nil {
+			continue
+		}
+
+		domain := normalizeListDomain(apiDomainPerm.Domain.Domain)
+		if domain == "" {
+			log.Debugf(nil, "skipping invalid domain %q", apiDomainPerm.Domain.Domain)
+			continue
+		}
+
+		entries = append(entries, domainPermEntry{
+			domain:        domain,
+			publicComment: apiDomainPerm.PublicComment,
+			obfuscate:     apiDomainPerm.Obfuscate,
+			severity:      gtsmodel.NewDomainBlockSeverity(apiDomainPerm.Severity),
+			rejectMedia:   apiDomainPerm.RejectMedia,
+			rejectReports: apiDomainPerm.RejectReports,
+		})
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,150 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// EmailDomainBlocksGet returns all email domain blocks, newest first.
+func (p *Processor) EmailDomainBlocksGet(
+	ctx context.Context,
+) ([]*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	// Barebones is all we need for API conversion,
+	// and ensures we don't omit blocks created by
+	// an account that has since been deleted.
+	blocks, err := p.state.DB.GetEmailDomainBlocks(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting email domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlocks := make([]*apimodel.AdminEmailDomainBlock, 0, len(blocks))
+	for _, block := range blocks {
+		apiBlocks = append(apiBlocks, p.converter.EmailDomainBlockToAdminAPIEmailDomainBlock(ctx, block))
+	}
+
+	return apiBlocks, nil
+}
+
+// EmailDomainBlockGet returns the email domain block with the given ID.
+func (p *Processor) EmailDomainBlockGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	block, errWithCode := p.getEmailDomainBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.EmailDomainBlockToAdminAPIEmailDomainBlock(ctx, block), nil
+}
+
+// EmailDomainBlockCreate blocks sign-ups and email changes
+// using email addresses of the given domain, its subdomains,
+// or domains whose mail servers are on the given domain.
+func (p *Processor) EmailDomainBlockCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	domain string,
+) (*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	// Allow admins to paste in a whole
+	// email address, or just the domain.
+	domain = strings.TrimSpace(domain)
+	if i := strings.LastIndexByte(domain, '@'); i != -1 {
+		domain = domain[i+1:]
+	}
+
+	domain, err := util.Punify(domain)
+	if err != nil || domain == "" || strings.ContainsAny(domain, " /:") {
+		const text = "email domain not valid"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Check if a block already exists for this domain.
+	existing, err := p.state.DB.GetEmailDomainBlock(gtscontext.SetBarebones(ctx), domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting email domain block %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		const text = "an email domain block already exists for this domain"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	block := &gtsmodel.EmailDomainBlock{
+		ID:                 id.NewULID(),
+		Domain:             domain,
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+	}
+
+	if err := p.state.DB.PutEmailDomainBlock(ctx, block); err != nil {
+		err = gtserror.Newf("db error putting email domain block %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.EmailDomainBlockToAdminAPIEmailDomainBlock(ctx, block), nil
+}
+
+// EmailDomainBlockDelete deletes the email domain block with the given ID.
+func (p *Processor) EmailDomainBlockDelete(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	block, errWithCode := p.getEmailDomainBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteEmailDomainBlockByID(ctx, block.ID); err != nil {
+		err = gtserror.Newf("db error deleting email domain block %s: %w", block.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.EmailDomainBlockToAdminAPIEmailDomainBlock(ctx, block), nil
+}
+
+func (p *Processor) getEmailDomainBlock(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.EmailDomainBlock, gtserror.WithCode) {
+	block, err := p.state.DB.GetEmailDomainBlockByID(gtscontext.SetBarebones(ctx), id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting email domain block %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if block == nil {
+		err := gtserror.Newf("email domain block %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return block, nil
+}
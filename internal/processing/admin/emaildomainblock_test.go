@@ -0,0 +1,103 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EmailDomainBlockTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *EmailDomainBlockTestSuite) TestEmailDomainBlockCRUD() {
+	ctx := context.Background()
+	adminAcct := suite.testAccounts["admin_account"]
+
+	// Whole email address should be
+	// stripped down to its domain.
+	block, errWithCode := suite.adminProcessor.EmailDomainBlockCreate(ctx, adminAcct, " someone@Spam.Example ")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("spam.example", block.Domain)
+	suite.Equal(adminAcct.ID, block.CreatedBy)
+	suite.NotEmpty(block.ID)
+
+	// Blocking the same domain again should conflict.
+	_, errWithCode = suite.adminProcessor.EmailDomainBlockCreate(ctx, adminAcct, "spam.example")
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusConflict, errWithCode.Code())
+	}
+
+	got, errWithCode := suite.adminProcessor.EmailDomainBlockGet(ctx, block.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(block.ID, got.ID)
+	suite.Equal(block.Domain, got.Domain)
+
+	blocks, errWithCode := suite.adminProcessor.EmailDomainBlocksGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(blocks, 1)
+
+	deleted, errWithCode := suite.adminProcessor.EmailDomainBlockDelete(ctx, block.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(block.ID, deleted.ID)
+
+	_, errWithCode = suite.adminProcessor.EmailDomainBlockGet(ctx, block.ID)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+
+	blocks, errWithCode = suite.adminProcessor.EmailDomainBlocksGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(blocks)
+}
+
+func (suite *EmailDomainBlockTestSuite) TestEmailDomainBlockCreateInvalid() {
+	ctx := context.Background()
+	adminAcct := suite.testAccounts["admin_account"]
+
+	for _, domain := range []string{
+		"",
+		"   ",
+		"someone@",
+		"https://example.org",
+		"not a domain",
+	} {
+		_, errWithCode := suite.adminProcessor.EmailDomainBlockCreate(ctx, adminAcct, domain)
+		if suite.NotNil(errWithCode, domain) {
+			suite.Equal(http.StatusBadRequest, errWithCode.Code(), domain)
+		}
+	}
+}
+
+func TestEmailDomainBlockTestSuite(t *testing.T) {
+	suite.Run(t, new(EmailDomainBlockTestSuite))
+}
@@ -0,0 +1,101 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// InvitesGet returns a page of all invites created on this
+// instance, newest first, converted to the admin view of an
+// invite, ie., including who created each invite, and which
+// accounts were registered using it.
+func (p *Processor) InvitesGet(
+	ctx context.Context,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	invites, err := p.state.DB.GetInvites(ctx, "", page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invites: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(invites)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := invites[count-1].ID
+	hi := invites[0].ID
+
+	items := make([]interface{}, 0, count)
+
+	for _, invite := range invites {
+		apiInvite, err := p.converter.InviteToAdminAPIInvite(ctx, invite)
+		if err != nil {
+			log.Errorf(ctx, "error converting invite to admin api invite: %v", err)
+			continue
+		}
+
+		items = append(items, apiInvite)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/invites",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// InviteGet returns the admin view of the invite with the given ID.
+func (p *Processor) InviteGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminInvite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil {
+		err := fmt.Errorf("invite %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	apiInvite, err := p.converter.InviteToAdminAPIInvite(ctx, invite)
+	if err != nil {
+		err := gtserror.Newf("error converting invite to admin api invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInvite, nil
+}
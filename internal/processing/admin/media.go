@@ -57,6 +57,7 @@ func (p *Processor) MediaPrune(ctx context.Context, mediaRemoteCacheDays int) gt
 	// Start background task performing all media cleanup tasks.
 	go func() {
 		ctx := context.Background()
+		p.cleaner.Card().All(ctx, mediaRemoteCacheDays)
 		p.cleaner.Media().All(ctx, mediaRemoteCacheDays)
 		p.cleaner.Emoji().All(ctx, mediaRemoteCacheDays)
 	}()
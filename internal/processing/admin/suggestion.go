@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// PinnedSuggestionsGet returns all accounts pinned
+// to be suggested to local users, newest first.
+func (p *Processor) PinnedSuggestionsGet(
+	ctx context.Context,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	pinnedSuggestions, err := p.state.DB.GetPinnedSuggestions(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting pinned suggestions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccounts := make([]*apimodel.Account, 0, len(pinnedSuggestions))
+	for _, pinnedSuggestion := range pinnedSuggestions {
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, pinnedSuggestion.TargetAccount)
+		if err != nil {
+			log.Errorf(ctx, "error converting account %s: %v", pinnedSuggestion.TargetAccountID, err)
+			continue
+		}
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	return apiAccounts, nil
+}
+
+// PinnedSuggestionCreate pins the account with the given ID
+// to be suggested to local users. Pinning an account that's
+// already pinned is a no-op.
+func (p *Processor) PinnedSuggestionCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAccountID string,
+) (*apimodel.Account, gtserror.WithCode) {
+	target, errWithCode := p.getSuggestionTarget(ctx, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if target.IsInstance() || !target.SuspendedAt.IsZero() {
+		const text = "instance and suspended accounts cannot be suggested"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if err := p.state.DB.PutPinnedSuggestion(ctx, &gtsmodel.PinnedSuggestion{
+		ID:              id.NewULID(),
+		AccountID:       adminAcct.ID,
+		TargetAccountID: target.ID,
+	}); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+		err = gtserror.Newf("db error putting pinned suggestion: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiSuggestionTarget(ctx, target)
+}
+
+// PinnedSuggestionDelete unpins the account with the given
+// ID from being suggested to local users. Unpinning an account
+// that isn't pinned is a no-op.
+func (p *Processor) PinnedSuggestionDelete(
+	ctx context.Context,
+	targetAccountID string,
+) (*apimodel.Account, gtserror.WithCode) {
+	target, errWithCode := p.getSuggestionTarget(ctx, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeletePinnedSuggestionByTargetAccountID(ctx, target.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error deleting pinned suggestion: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiSuggestionTarget(ctx, target)
+}
+
+func (p *Processor) getSuggestionTarget(
+	ctx context.Context,
+	targetAccountID string,
+) (*gtsmodel.Account, gtserror.WithCode) {
+	target, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting account %s: %w", targetAccountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if target == nil {
+		err := gtserror.Newf("account %s not found", targetAccountID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return target, nil
+}
+
+func (p *Processor) apiSuggestionTarget(
+	ctx context.Context,
+	target *gtsmodel.Account,
+) (*apimodel.Account, gtserror.WithCode) {
+	apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, target)
+	if err != nil {
+		err = gtserror.Newf("error converting account %s: %w", target.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAccount, nil
+}
@@ -0,0 +1,104 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// TrendsGet returns all trends of the given type known to
+// this instance, including those pending review or rejected,
+// ordered by score descending.
+func (p *Processor) TrendsGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	trendType gtsmodel.TrendType,
+) ([]*apimodel.AdminTrend, gtserror.WithCode) {
+	trends, err := p.state.DB.GetTrendsByType(ctx, trendType)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting %s trends: %w", trendType.String(), err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTrends := make([]*apimodel.AdminTrend, 0, len(trends))
+	for _, trend := range trends {
+		apiTrend, err := p.converter.TrendToAdminAPITrend(ctx, trend, account)
+		if err != nil {
+			log.Errorf(ctx, "error converting trend %s: %v", trend.ID, err)
+			continue
+		}
+		apiTrends = append(apiTrends, apiTrend)
+	}
+
+	return apiTrends, nil
+}
+
+// TrendReview approves or rejects the trend with the given ID
+// and type, determining whether it will be shown to users.
+func (p *Processor) TrendReview(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	trendType gtsmodel.TrendType,
+	id string,
+	approve bool,
+) (*apimodel.AdminTrend, gtserror.WithCode) {
+	trend, err := p.state.DB.GetTrendByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting trend %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if trend == nil || trend.Type != trendType {
+		err := gtserror.Newf("%s trend %s not found", trendType.String(), id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if approve && trend.Type == gtsmodel.TrendTypeTag &&
+		(!*trend.Tag.Useable || !*trend.Tag.Listable) {
+		const text = "tag is not useable or not listable on this instance, so it cannot trend"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if approve {
+		trend.Review = gtsmodel.TrendReviewApproved
+	} else {
+		trend.Review = gtsmodel.TrendReviewRejected
+	}
+	trend.ReviewedAt = time.Now()
+
+	if err := p.state.DB.UpdateTrend(ctx, trend, "review", "reviewed_at"); err != nil {
+		err = gtserror.Newf("db error updating trend %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTrend, err := p.converter.TrendToAdminAPITrend(ctx, trend, account)
+	if err != nil {
+		err = gtserror.Newf("error converting trend %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiTrend, nil
+}
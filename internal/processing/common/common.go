@@ -18,6 +18,8 @@
 package common
 
 import (
+	"net"
+
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
@@ -32,6 +34,10 @@ type Processor struct {
 	converter *typeutils.Converter
 	federator *federation.Federator
 	filter    *visibility.Filter
+
+	// mxResolver is used to look up
+	// the mail servers of email domains.
+	mxResolver MXResolver
 }
 
 // New returns a new Processor instance.
@@ -46,5 +52,7 @@ func New(
 		converter: converter,
 		federator: federator,
 		filter:    filter,
+
+		mxResolver: net.DefaultResolver,
 	}
 }
@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// mxLookupTimeout is the maximum time
+// to spend looking up the MX records
+// of an email address domain.
+const mxLookupTimeout = 5 * time.Second
+
+// MXResolver looks up the MX records of a
+// domain. It is implemented by *net.Resolver.
+type MXResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// SetMXResolver sets the resolver used to look up the MX
+// records of email address domains, in place of the default
+// system resolver. This is mostly useful for testing.
+func (p *Processor) SetMXResolver(resolver MXResolver) {
+	p.mxResolver = resolver
+}
+
+// CheckEmailDomain checks the domain of the given email
+// address against email domain blocks, returning an
+// appropriate gtserror.WithCode if the address may
+// not be used on this instance.
+//
+// An email domain block matches the domain it blocks
+// and all of its subdomains, and also matches any email
+// domain whose MX records point to a blocked host.
+func (p *Processor) CheckEmailDomain(ctx context.Context, address string) gtserror.WithCode {
+	i := strings.LastIndexByte(address, '@')
+	if i == -1 {
+		const text = "email address not valid"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	domain, err := util.Punify(address[i+1:])
+	if err != nil {
+		const text = "email address domain not valid"
+		return gtserror.NewErrorBadRequest(err, text)
+	}
+
+	blocks, err := p.state.DB.GetEmailDomainBlocks(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting email domain blocks: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if len(blocks) == 0 {
+		// Nothing to check against, so
+		// don't bother resolving anything.
+		return nil
+	}
+
+	blocked := emailDomainBlocked(blocks, domain)
+	if !blocked {
+		// Domain itself is fine,
+		// check its mail servers.
+		for _, host := range p.lookupMXHosts(ctx, domain) {
+			if emailDomainBlocked(blocks, host) {
+				blocked = true
+				break
+			}
+		}
+	}
+
+	if blocked {
+		const text = "email addresses from this domain are not allowed on this instance"
+		err := gtserror.Newf("email domain %s is blocked", domain)
+		return gtserror.NewErrorUnprocessableEntity(err, text)
+	}
+
+	return nil
+}
+
+// lookupMXHosts returns the hosts of the MX records of the
+// given domain. Lookup errors are logged and otherwise
+// ignored, as failing to resolve a domain's mail servers
+// doesn't mean the domain is blocked.
+func (p *Processor) lookupMXHosts(ctx context.Context, domain string) []string {
+	ctx, cancel := context.WithTimeout(ctx, mxLookupTimeout)
+	defer cancel()
+
+	mxs, err := p.mxResolver.LookupMX(ctx, domain)
+	if err != nil {
+		log.Debugf(ctx, "error looking up mx records of %s: %v", domain, err)
+	}
+
+	hosts := make([]string, 0, len(mxs))
+	for _, mx := range mxs {
+		host := strings.ToLower(strings.TrimSuffix(mx.Host, "."))
+		if host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+
+	return hosts
+}
+
+// emailDomainBlocked returns whether one
+// of the given blocks matches host, or
+// one of host's parent domains.
+func emailDomainBlocked(blocks []*gtsmodel.EmailDomainBlock, host string) bool {
+	for _, block := range blocks {
+		if host == block.Domain ||
+			strings.HasSuffix(host, "."+block.Domain) {
+			return true
+		}
+	}
+	return false
+}
@@ -184,7 +184,7 @@ func (p *Processor) GetAPIStatus(
 	apiStatus *apimodel.Status,
 	errWithCode gtserror.WithCode,
 ) {
-	apiStatus, err := p.converter.StatusToAPIStatus(ctx, target, requester)
+	apiStatus, err := p.converter.StatusToAPIStatus(ctx, target, requester, gtsmodel.FilterContextNone, nil)
 	if err != nil {
 		err = gtserror.Newf("error converting status: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
@@ -260,7 +260,7 @@ func (p *Processor) getVisibleAPIStatuses(
 		}
 
 		// Convert the status to an API model representation.
-		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requester)
+		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requester, gtsmodel.FilterContextNone, nil)
 		if err != nil {
 			l.Errorf("error converting status: %v", err)
 			continue
@@ -308,3 +308,39 @@ func (p *Processor) InvalidateTimelinedStatus(ctx context.Context, accountID str
 
 	return nil
 }
+
+// InvalidateTimelinesForAccount removes the home timeline and all list
+// timelines of the given accountID, so that they will be re-indexed and
+// re-prepared the next time they're requested. It should be called when
+// something changes that affects how *every* status is prepared for the
+// account, such as creating, updating, or deleting filters.
+func (p *Processor) InvalidateTimelinesForAccount(ctx context.Context, accountID string) error {
+	// Get lists first + bail if this fails.
+	lists, err := p.state.DB.GetListsForAccountID(ctx, accountID)
+	if err != nil {
+		return gtserror.Newf("db error getting lists for account %s: %w", accountID, err)
+	}
+
+	// Start new log entry with
+	// the above calling func's name.
+	l := log.
+		WithContext(ctx).
+		WithField("caller", log.Caller(3)).
+		WithField("accountID", accountID)
+
+	// Remove home + list timelines, just log if
+	// something goes wrong since this is not a
+	// showstopper: the timelines will just be stale.
+
+	if err := p.state.Timelines.Home.RemoveTimeline(ctx, accountID); err != nil {
+		l.Errorf("error removing home timeline: %v", err)
+	}
+
+	for _, list := range lists {
+		if err := p.state.Timelines.List.RemoveTimeline(ctx, list.ID); err != nil {
+			l.Errorf("error removing list timeline %s: %v", list.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package conversations
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+}
+
+func New(state *state.State, converter *typeutils.Converter) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+	}
+}
+
+// getConversationOwnedBy gets the conversation with the given
+// ID, returning 404 if it doesn't exist or isn't owned by the
+// given requester.
+func (p *Processor) getConversationOwnedBy(
+	ctx context.Context,
+	id string,
+	requester *gtsmodel.Account,
+) (*gtsmodel.Conversation, gtserror.WithCode) {
+	conversation, err := p.state.DB.GetConversationByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting conversation %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if conversation == nil || conversation.AccountID != requester.ID {
+		err := gtserror.Newf("conversation %s not found for account %s", id, requester.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return conversation, nil
+}
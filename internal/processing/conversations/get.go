@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package conversations
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// GetAll returns a page of the direct message
+// conversations owned by the given account,
+// most recently active conversation first.
+func (p *Processor) GetAll(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	conversations, err := p.state.DB.GetConversationsByOwnerAccountID(ctx, requester.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting conversations: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(conversations)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest last status
+	// ID values, as those are used for paging.
+	lo := conversations[count-1].LastStatusID
+	hi := conversations[0].LastStatusID
+
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, requester.ID)
+	if err != nil {
+		err := gtserror.Newf("couldn't retrieve filters for account %s: %w", requester.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	items := make([]interface{}, 0, count)
+	for _, conversation := range conversations {
+		apiConversation, err := p.converter.ConversationToAPIConversation(ctx, conversation, requester, filters)
+		if err != nil {
+			if !errors.Is(err, statusfilter.ErrHideStatus) {
+				log.Errorf(ctx, "error converting conversation %s to api model: %v", conversation.ID, err)
+			}
+			continue
+		}
+		items = append(items, apiConversation)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/conversations",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package conversations
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Read marks the given conversation of the
+// requester as read, and returns it.
+func (p *Processor) Read(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*apimodel.Conversation, gtserror.WithCode) {
+	conversation, errWithCode := p.getConversationOwnedBy(ctx, id, requester)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !util.PtrValueOr(conversation.Read, false) {
+		conversation.Read = util.Ptr(true)
+		if err := p.state.DB.UpdateConversation(ctx, conversation, "read"); err != nil {
+			err = gtserror.Newf("db error updating conversation %s: %w", id, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, requester.ID)
+	if err != nil {
+		err := gtserror.Newf("couldn't retrieve filters for account %s: %w", requester.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiConversation, err := p.converter.ConversationToAPIConversation(ctx, conversation, requester, filters)
+	if err != nil {
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			// Treat conversations hidden
+			// by filters as not found.
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+		err = gtserror.Newf("error converting conversation %s to api model: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiConversation, nil
+}
@@ -336,3 +336,32 @@ func (p *Processor) FeaturedCollectionGet(ctx context.Context, requestedUser str
 
 	return data, nil
 }
+
+// FeaturedTagsGet returns the featured tags collection
+// (hashtags featured on profile) of the requested user.
+func (p *Processor) FeaturedTagsGet(ctx context.Context, requestedUser string) (interface{}, gtserror.WithCode) {
+	// Authenticate the incoming request, getting related user accounts.
+	_, receiver, errWithCode := p.authenticate(ctx, requestedUser)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	featuredTags, err := p.state.DB.GetFeaturedTagsByAccountID(ctx, receiver.ID)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	collection, err := p.converter.FeaturedTagsToASCollection(ctx, receiver.FeaturedTagsURI, featuredTags)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	data, err := ap.Serialize(collection)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return data, nil
+}
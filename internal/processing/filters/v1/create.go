@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Create a new filter and filter keyword for the given account, using the provided parameters.
+// These params should have already been validated by the time they reach this function.
+func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form *apimodel.FilterCreateUpdateRequestV1) (*apimodel.FilterV1, gtserror.WithCode) {
+	filter := &gtsmodel.Filter{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		Title:     form.Phrase,
+		Action:    gtsmodel.FilterActionWarn,
+	}
+	if util.PtrValueOr(form.Irreversible, false) {
+		filter.Action = gtsmodel.FilterActionHide
+	}
+	if form.ExpiresIn != nil && *form.ExpiresIn != 0 {
+		filter.ExpiresAt = time.Now().Add(time.Second * time.Duration(*form.ExpiresIn))
+	}
+	typeutils.APIFilterContextsToFilter(form.Context, filter)
+
+	filterKeyword := &gtsmodel.FilterKeyword{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		FilterID:  filter.ID,
+		Filter:    filter,
+		Keyword:   form.Phrase,
+		WholeWord: util.Ptr(util.PtrValueOr(form.WholeWord, false)),
+	}
+	filter.Keywords = []*gtsmodel.FilterKeyword{filterKeyword}
+	filter.Statuses = []*gtsmodel.FilterStatus{}
+
+	if err := p.state.DB.PutFilter(ctx, filter); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("you already have a filter with this title")
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFilter, errWithCode := p.apiFilter(ctx, filterKeyword)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Handle filter changes.
+	p.filtersChanged(ctx, account)
+
+	return apiFilter, nil
+}
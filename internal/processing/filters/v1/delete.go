@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Delete a v1 filter by the ID of its filter keyword.
+// If the filter has no other keywords, the whole filter is deleted.
+func (p *Processor) Delete(ctx context.Context, account *gtsmodel.Account, filterKeywordID string) gtserror.WithCode {
+	// Get the filter keyword.
+	filterKeyword, errWithCode := p.getFilterKeyword(ctx, account, filterKeywordID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Get the whole filter so we
+	// can check for other keywords.
+	filter, err := p.state.DB.GetFilterByID(ctx, filterKeyword.FilterID)
+	if err != nil {
+		err = gtserror.Newf("error getting filter %s: %w", filterKeyword.FilterID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if len(filter.Keywords) > 1 || len(filter.Statuses) > 0 {
+		// The filter has other keywords or statuses, just delete the one filter keyword.
+		if err := p.state.DB.DeleteFilterKeywordByID(ctx, filterKeyword.ID); err != nil {
+			err = gtserror.Newf("error deleting filter keyword %s: %w", filterKeyword.ID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	} else {
+		// Delete the entire filter.
+		if err := p.state.DB.DeleteFilterByID(ctx, filter.ID); err != nil {
+			err = gtserror.Newf("error deleting filter %s: %w", filter.ID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Handle filter changes.
+	p.filtersChanged(ctx, account)
+
+	return nil
+}
@@ -0,0 +1,52 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+// Processor processes v1 filter API requests.
+//
+// v1 filters are represented internally as
+// v2 filters with exactly one filter keyword,
+// and the ID of that keyword is used as the
+// ID of the v1 filter.
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+	c         *common.Processor
+	stream    *stream.Processor
+}
+
+func New(
+	state *state.State,
+	converter *typeutils.Converter,
+	common *common.Processor,
+	stream *stream.Processor,
+) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+		c:         common,
+		stream:    stream,
+	}
+}
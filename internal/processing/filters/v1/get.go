@@ -0,0 +1,67 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Get looks up a filter keyword by ID and returns it as a v1 filter.
+func (p *Processor) Get(ctx context.Context, account *gtsmodel.Account, filterKeywordID string) (*apimodel.FilterV1, gtserror.WithCode) {
+	filterKeyword, errWithCode := p.getFilterKeyword(ctx, account, filterKeywordID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiFilter(ctx, filterKeyword)
+}
+
+// GetAll looks up all filter keywords for the current account and returns them as v1 filters.
+func (p *Processor) GetAll(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.FilterV1, gtserror.WithCode) {
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("error getting filters for account %s: %w", account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFilters := make([]*apimodel.FilterV1, 0, len(filters))
+	for _, filter := range filters {
+		apiFiltersForFilter, err := p.converter.FilterToAPIFiltersV1(ctx, filter)
+		if err != nil {
+			err = gtserror.Newf("error converting filter %s to API v1 filters: %w", filter.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiFilters = append(apiFilters, apiFiltersForFilter...)
+	}
+
+	// Sort them by ID so that they're in a stable order.
+	// Clients may opt to sort them lexically in a locale-aware manner.
+	slices.SortFunc(apiFilters, func(lhs *apimodel.FilterV1, rhs *apimodel.FilterV1) int {
+		return strings.Compare(lhs.ID, rhs.ID)
+	})
+
+	return apiFilters, nil
+}
@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Update an existing filter and filter keyword for the given account, using the provided parameters.
+// These params should have already been validated by the time they reach this function.
+func (p *Processor) Update(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterKeywordID string,
+	form *apimodel.FilterCreateUpdateRequestV1,
+) (*apimodel.FilterV1, gtserror.WithCode) {
+	// Get the filter keyword, and its filter.
+	filterKeyword, errWithCode := p.getFilterKeyword(ctx, account, filterKeywordID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Get the filter with all of its keywords,
+	// so we can tell whether we should keep the
+	// filter title in sync with the keyword.
+	filter, err := p.state.DB.GetFilterByID(ctx, filterKeyword.FilterID)
+	if err != nil {
+		err = gtserror.Newf("error getting filter %s: %w", filterKeyword.FilterID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Update the filter. v1 filters
+	// replace all filter fields.
+	if len(filter.Keywords) <= 1 {
+		filter.Title = form.Phrase
+	}
+	filter.Action = gtsmodel.FilterActionWarn
+	if util.PtrValueOr(form.Irreversible, false) {
+		filter.Action = gtsmodel.FilterActionHide
+	}
+	filter.ExpiresAt = time.Time{}
+	if form.ExpiresIn != nil && *form.ExpiresIn != 0 {
+		filter.ExpiresAt = time.Now().Add(time.Second * time.Duration(*form.ExpiresIn))
+	}
+	typeutils.APIFilterContextsToFilter(form.Context, filter)
+
+	// Update the filter keyword,
+	// replacing it in the filter's
+	// keywords so that it's upserted.
+	filterKeyword.Keyword = form.Phrase
+	filterKeyword.WholeWord = util.Ptr(util.PtrValueOr(form.WholeWord, false))
+	filterKeyword.Filter = filter
+	filter.Keywords = []*gtsmodel.FilterKeyword{filterKeyword}
+	filter.Statuses = nil
+
+	filterColumns := []string{
+		"title",
+		"action",
+		"expires_at",
+		"context_home",
+		"context_notifications",
+		"context_public",
+		"context_thread",
+		"context_account",
+	}
+	if err := p.state.DB.UpdateFilter(ctx, filter, filterColumns, nil, nil); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("you already have a filter with this title")
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFilter, errWithCode := p.apiFilter(ctx, filterKeyword)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Handle filter changes.
+	p.filtersChanged(ctx, account)
+
+	return apiFilter, nil
+}
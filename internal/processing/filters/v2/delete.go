@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Delete a filter, and all of its
+// keywords and statuses, by ID.
+func (p *Processor) Delete(ctx context.Context, account *gtsmodel.Account, filterID string) gtserror.WithCode {
+	// Ensure filter exists + is owned by requesting account.
+	if _, errWithCode := p.getFilter(
+		// Use barebones ctx; no embedded
+		// structs necessary for this call.
+		gtscontext.SetBarebones(ctx),
+		account,
+		filterID,
+	); errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteFilterByID(ctx, filterID); err != nil {
+		err = gtserror.Newf("error deleting filter %s: %w", filterID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Handle filter changes.
+	p.filtersChanged(ctx, account)
+
+	return nil
+}
@@ -0,0 +1,147 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// KeywordCreate adds a filter keyword to an existing filter for the given account, using the provided parameters.
+// These params should have already been validated by the time they reach this function.
+func (p *Processor) KeywordCreate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterID string,
+	form *apimodel.FilterKeywordCreateUpdateRequest,
+) (*apimodel.FilterKeyword, gtserror.WithCode) {
+	// Ensure filter exists + is owned by requesting account.
+	if _, errWithCode := p.getFilter(gtscontext.SetBarebones(ctx), account, filterID); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filterKeyword := &gtsmodel.FilterKeyword{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		FilterID:  filterID,
+		Keyword:   form.Keyword,
+		WholeWord: util.Ptr(util.PtrValueOr(form.WholeWord, false)),
+	}
+
+	if err := p.state.DB.PutFilterKeyword(ctx, filterKeyword); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("duplicate keyword")
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Handle filter changes.
+	p.filtersChanged(ctx, account)
+
+	return p.converter.FilterKeywordToAPIFilterKeyword(ctx, filterKeyword), nil
+}
+
+// KeywordGet looks up a filter keyword by ID.
+func (p *Processor) KeywordGet(ctx context.Context, account *gtsmodel.Account, filterKeywordID string) (*apimodel.FilterKeyword, gtserror.WithCode) {
+	filterKeyword, errWithCode := p.getFilterKeyword(gtscontext.SetBarebones(ctx), account, filterKeywordID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.FilterKeywordToAPIFilterKeyword(ctx, filterKeyword), nil
+}
+
+// KeywordsGetForFilterID looks up all filter keywords for the given filter.
+func (p *Processor) KeywordsGetForFilterID(ctx context.Context, account *gtsmodel.Account, filterID string) ([]*apimodel.FilterKeyword, gtserror.WithCode) {
+	// Ensure filter exists + is owned by requesting account.
+	if _, errWithCode := p.getFilter(gtscontext.SetBarebones(ctx), account, filterID); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filterKeywords, err := p.state.DB.GetFilterKeywordsForFilterID(gtscontext.SetBarebones(ctx), filterID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("error getting filter keywords for filter %s: %w", filterID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFilterKeywords := make([]*apimodel.FilterKeyword, 0, len(filterKeywords))
+	for _, filterKeyword := range filterKeywords {
+		apiFilterKeywords = append(apiFilterKeywords, p.converter.FilterKeywordToAPIFilterKeyword(ctx, filterKeyword))
+	}
+
+	return apiFilterKeywords, nil
+}
+
+// KeywordUpdate updates an existing filter keyword for the given account, using the provided parameters.
+// These params should have already been validated by the time they reach this function.
+func (p *Processor) KeywordUpdate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterKeywordID string,
+	form *apimodel.FilterKeywordCreateUpdateRequest,
+) (*apimodel.FilterKeyword, gtserror.WithCode) {
+	// Get the filter keyword by ID.
+	filterKeyword, errWithCode := p.getFilterKeyword(gtscontext.SetBarebones(ctx), account, filterKeywordID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filterKeyword.Keyword = form.Keyword
+	filterKeyword.WholeWord = util.Ptr(util.PtrValueOr(form.WholeWord, false))
+
+	if err := p.state.DB.UpdateFilterKeyword(ctx, filterKeyword, "keyword", "whole_word"); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("duplicate keyword")
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Handle filter changes.
+	p.filtersChanged(ctx, account)
+
+	return p.converter.FilterKeywordToAPIFilterKeyword(ctx, filterKeyword), nil
+}
+
+// KeywordDelete deletes an existing filter keyword from a filter.
+func (p *Processor) KeywordDelete(ctx context.Context, account *gtsmodel.Account, filterKeywordID string) gtserror.WithCode {
+	// Get the filter keyword by ID.
+	filterKeyword, errWithCode := p.getFilterKeyword(gtscontext.SetBarebones(ctx), account, filterKeywordID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteFilterKeywordByID(ctx, filterKeyword.ID); err != nil {
+		err = gtserror.Newf("error deleting filter keyword %s: %w", filterKeyword.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Handle filter changes.
+	p.filtersChanged(ctx, account)
+
+	return nil
+}
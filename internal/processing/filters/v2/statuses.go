@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// StatusCreate adds a filter status to an existing filter for the given account, using the provided parameters.
+// These params should have already been validated by the time they reach this function.
+func (p *Processor) StatusCreate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterID string,
+	form *apimodel.FilterStatusCreateRequest,
+) (*apimodel.FilterStatus, gtserror.WithCode) {
+	// Ensure filter exists + is owned by requesting account.
+	if _, errWithCode := p.getFilter(gtscontext.SetBarebones(ctx), account, filterID); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filterStatus := &gtsmodel.FilterStatus{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		FilterID:  filterID,
+		StatusID:  form.StatusID,
+	}
+
+	if err := p.state.DB.PutFilterStatus(ctx, filterStatus); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("duplicate status")
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Handle filter changes.
+	p.filtersChanged(ctx, account)
+
+	return p.converter.FilterStatusToAPIFilterStatus(ctx, filterStatus), nil
+}
+
+// StatusGet looks up a filter status by ID.
+func (p *Processor) StatusGet(ctx context.Context, account *gtsmodel.Account, filterStatusID string) (*apimodel.FilterStatus, gtserror.WithCode) {
+	filterStatus, errWithCode := p.getFilterStatus(gtscontext.SetBarebones(ctx), account, filterStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.FilterStatusToAPIFilterStatus(ctx, filterStatus), nil
+}
+
+// StatusesGetForFilterID looks up all filter statuses for the given filter.
+func (p *Processor) StatusesGetForFilterID(ctx context.Context, account *gtsmodel.Account, filterID string) ([]*apimodel.FilterStatus, gtserror.WithCode) {
+	// Ensure filter exists + is owned by requesting account.
+	if _, errWithCode := p.getFilter(gtscontext.SetBarebones(ctx), account, filterID); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filterStatuses, err := p.state.DB.GetFilterStatusesForFilterID(gtscontext.SetBarebones(ctx), filterID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("error getting filter statuses for filter %s: %w", filterID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFilterStatuses := make([]*apimodel.FilterStatus, 0, len(filterStatuses))
+	for _, filterStatus := range filterStatuses {
+		apiFilterStatuses = append(apiFilterStatuses, p.converter.FilterStatusToAPIFilterStatus(ctx, filterStatus))
+	}
+
+	return apiFilterStatuses, nil
+}
+
+// StatusDelete deletes an existing filter status from a filter.
+func (p *Processor) StatusDelete(ctx context.Context, account *gtsmodel.Account, filterStatusID string) gtserror.WithCode {
+	// Get the filter status by ID.
+	filterStatus, errWithCode := p.getFilterStatus(gtscontext.SetBarebones(ctx), account, filterStatusID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteFilterStatusByID(ctx, filterStatus.ID); err != nil {
+		err = gtserror.Newf("error deleting filter status %s: %w", filterStatus.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Handle filter changes.
+	p.filtersChanged(ctx, account)
+
+	return nil
+}
@@ -0,0 +1,198 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Update an existing filter for the given account, using the provided parameters.
+// These params should have already been validated by the time they reach this function.
+func (p *Processor) Update(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	filterID string,
+	form *apimodel.FilterUpdateRequestV2,
+) (*apimodel.FilterV2, gtserror.WithCode) {
+	// Get the filter by ID, with existing keywords and statuses.
+	filter, errWithCode := p.getFilter(ctx, account, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Filter columns that we're going to update.
+	var filterColumns []string
+
+	// Apply filter changes.
+	if form.Title != nil {
+		filterColumns = append(filterColumns, "title")
+		filter.Title = *form.Title
+	}
+	if form.FilterAction != nil {
+		filterColumns = append(filterColumns, "action")
+		filter.Action = typeutils.APIFilterActionToFilterAction(*form.FilterAction)
+	}
+	if form.ExpiresIn != nil {
+		filterColumns = append(filterColumns, "expires_at")
+		filter.ExpiresAt = time.Time{}
+
+		// A zero value also means the filter should not expire.
+		if *form.ExpiresIn != 0 {
+			filter.ExpiresAt = time.Now().Add(time.Second * time.Duration(*form.ExpiresIn))
+		}
+	}
+	if form.Context != nil {
+		filterColumns = append(filterColumns,
+			"context_home",
+			"context_notifications",
+			"context_public",
+			"context_thread",
+			"context_account",
+		)
+		typeutils.APIFilterContextsToFilter(*form.Context, filter)
+	}
+
+	// Index existing keywords and statuses by ID.
+	existingKeywords := make(map[string]*gtsmodel.FilterKeyword, len(filter.Keywords))
+	for _, filterKeyword := range filter.Keywords {
+		existingKeywords[filterKeyword.ID] = filterKeyword
+	}
+	existingStatuses := make(map[string]*gtsmodel.FilterStatus, len(filter.Statuses))
+	for _, filterStatus := range filter.Statuses {
+		existingStatuses[filterStatus.ID] = filterStatus
+	}
+
+	// Only the keywords and statuses being
+	// created or updated are passed on to the
+	// database, any others are left untouched.
+	var (
+		filterKeywords         = make([]*gtsmodel.FilterKeyword, 0, len(form.Keywords))
+		deleteFilterKeywordIDs []string
+		filterStatuses         = make([]*gtsmodel.FilterStatus, 0, len(form.Statuses))
+		deleteFilterStatusIDs  []string
+	)
+
+	for _, formKeyword := range form.Keywords {
+		if formKeyword.ID == nil {
+			// No ID, so this is a new keyword.
+			if formKeyword.Keyword == nil {
+				err := errors.New("new filter keywords must have a keyword")
+				return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+			}
+			filterKeywords = append(filterKeywords, &gtsmodel.FilterKeyword{
+				ID:        id.NewULID(),
+				AccountID: account.ID,
+				FilterID:  filter.ID,
+				Filter:    filter,
+				Keyword:   *formKeyword.Keyword,
+				WholeWord: util.Ptr(util.PtrValueOr(formKeyword.WholeWord, false)),
+			})
+			continue
+		}
+
+		// Keyword with an ID must already exist on this filter.
+		filterKeyword, ok := existingKeywords[*formKeyword.ID]
+		if !ok {
+			err := fmt.Errorf("couldn't find filter keyword '%s' to update or delete", *formKeyword.ID)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		if util.PtrValueOr(formKeyword.Destroy, false) {
+			deleteFilterKeywordIDs = append(deleteFilterKeywordIDs, filterKeyword.ID)
+			continue
+		}
+
+		if formKeyword.Keyword != nil {
+			filterKeyword.Keyword = *formKeyword.Keyword
+		}
+		if formKeyword.WholeWord != nil {
+			filterKeyword.WholeWord = util.Ptr(*formKeyword.WholeWord)
+		}
+		filterKeywords = append(filterKeywords, filterKeyword)
+	}
+
+	for _, formStatus := range form.Statuses {
+		if formStatus.ID == nil {
+			// No ID, so this is a new status.
+			if formStatus.StatusID == nil {
+				err := errors.New("new filter statuses must have a status ID")
+				return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+			}
+			filterStatuses = append(filterStatuses, &gtsmodel.FilterStatus{
+				ID:        id.NewULID(),
+				AccountID: account.ID,
+				FilterID:  filter.ID,
+				Filter:    filter,
+				StatusID:  *formStatus.StatusID,
+			})
+			continue
+		}
+
+		// Status with an ID must already exist on this filter.
+		filterStatus, ok := existingStatuses[*formStatus.ID]
+		if !ok {
+			err := fmt.Errorf("couldn't find filter status '%s' to delete", *formStatus.ID)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		// Filter statuses can't be updated, only deleted.
+		if util.PtrValueOr(formStatus.Destroy, false) {
+			deleteFilterStatusIDs = append(deleteFilterStatusIDs, filterStatus.ID)
+		}
+	}
+
+	filter.Keywords = filterKeywords
+	filter.Statuses = filterStatuses
+
+	if err := p.state.DB.UpdateFilter(ctx, filter, filterColumns, deleteFilterKeywordIDs, deleteFilterStatusIDs); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			err = errors.New("duplicate title, keyword, or status")
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Refetch the filter so that the
+	// returned keywords and statuses
+	// are complete and up to date.
+	filter, errWithCode = p.getFilter(ctx, account, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiFilter, errWithCode := p.apiFilter(ctx, filter)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Handle filter changes.
+	p.filtersChanged(ctx, account)
+
+	return apiFilter, nil
+}
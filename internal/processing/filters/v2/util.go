@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// getFilter is a shortcut to get one filter with the
+// given ID, and ensure it's owned by the given account.
+func (p *Processor) getFilter(ctx context.Context, account *gtsmodel.Account, filterID string) (*gtsmodel.Filter, gtserror.WithCode) {
+	filter, err := p.state.DB.GetFilterByID(ctx, filterID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if filter.AccountID != account.ID {
+		err = fmt.Errorf("filter %s does not belong to account %s", filter.ID, account.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return filter, nil
+}
+
+// getFilterKeyword is a shortcut to get one filter keyword with
+// the given ID, and ensure it's owned by the given account.
+func (p *Processor) getFilterKeyword(ctx context.Context, account *gtsmodel.Account, filterKeywordID string) (*gtsmodel.FilterKeyword, gtserror.WithCode) {
+	filterKeyword, err := p.state.DB.GetFilterKeywordByID(ctx, filterKeywordID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if filterKeyword.AccountID != account.ID {
+		err = fmt.Errorf("filter keyword %s does not belong to account %s", filterKeyword.ID, account.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return filterKeyword, nil
+}
+
+// getFilterStatus is a shortcut to get one filter status with
+// the given ID, and ensure it's owned by the given account.
+func (p *Processor) getFilterStatus(ctx context.Context, account *gtsmodel.Account, filterStatusID string) (*gtsmodel.FilterStatus, gtserror.WithCode) {
+	filterStatus, err := p.state.DB.GetFilterStatusByID(ctx, filterStatusID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if filterStatus.AccountID != account.ID {
+		err = fmt.Errorf("filter status %s does not belong to account %s", filterStatus.ID, account.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return filterStatus, nil
+}
+
+// apiFilter is a shortcut to return the API v2 filter version of the given
+// filter, or return an appropriate error if conversion fails.
+func (p *Processor) apiFilter(ctx context.Context, filter *gtsmodel.Filter) (*apimodel.FilterV2, gtserror.WithCode) {
+	apiFilter, err := p.converter.FilterToAPIFilterV2(ctx, filter)
+	if err != nil {
+		err = gtserror.Newf("error converting filter to API v2 filter: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiFilter, nil
+}
+
+// filtersChanged invalidates the given account's prepared
+// timelines so that new filters are applied, and notifies
+// the account's open streams that its filters have changed.
+func (p *Processor) filtersChanged(ctx context.Context, account *gtsmodel.Account) {
+	if err := p.c.InvalidateTimelinesForAccount(ctx, account.ID); err != nil {
+		log.Errorf(ctx, "error invalidating timelines for account %s: %v", account.ID, err)
+	}
+
+	if err := p.stream.FiltersChanged(account); err != nil {
+		log.Errorf(ctx, "error streaming filters changed event for account %s: %v", account.ID, err)
+	}
+}
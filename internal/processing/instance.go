@@ -91,6 +91,12 @@ func (p *Processor) InstancePeersGet(ctx context.Context, includeSuspended bool,
 		}
 
 		for _, domainBlock := range domainBlocks {
+			if domainBlock.Severity == gtsmodel.DomainBlockSeverityNoop {
+				// Domain isn't limited, so
+				// it's not worth listing.
+				continue
+			}
+
 			// Domain may be in Punycode,
 			// de-punify it just in case.
 			d, err := util.DePunify(domainBlock.Domain)
@@ -104,11 +110,18 @@ func (p *Processor) InstancePeersGet(ctx context.Context, includeSuspended bool,
 				d = obfuscate(d)
 			}
 
-			domains = append(domains, &apimodel.Domain{
+			domain := &apimodel.Domain{
 				Domain:        d,
-				SuspendedAt:   util.FormatISO8601(domainBlock.CreatedAt),
 				PublicComment: domainBlock.PublicComment,
-			})
+			}
+
+			if domainBlock.Severity == gtsmodel.DomainBlockSeveritySilence {
+				domain.SilencedAt = util.FormatISO8601(domainBlock.CreatedAt)
+			} else {
+				domain.SuspendedAt = util.FormatISO8601(domainBlock.CreatedAt)
+			}
+
+			domains = append(domains, domain)
 		}
 	}
 
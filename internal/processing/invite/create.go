@@ -0,0 +1,90 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invite
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// Create creates a new invite owned by the given account, which can
+// then be given out to people to register accounts on this instance.
+func (p *Processor) Create(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	user *gtsmodel.User,
+	form *apimodel.InviteCreateRequest,
+) (*apimodel.Invite, gtserror.WithCode) {
+	if !config.GetAccountsInvitesEnabled() {
+		const help = "invites are not enabled on this server"
+		return nil, gtserror.NewErrorForbidden(errors.New(help), help)
+	}
+
+	if config.GetAccountsInvitesModeratorsOnly() &&
+		!*user.Admin && !*user.Moderator {
+		const help = "only admins and moderators can create invites on this server"
+		return nil, gtserror.NewErrorForbidden(errors.New(help), help)
+	}
+
+	code, err := newCode()
+	if err != nil {
+		err := gtserror.Newf("error generating invite code: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	invite := &gtsmodel.Invite{
+		ID:        id.NewULID(),
+		Code:      code,
+		AccountID: account.ID,
+		Account:   account,
+	}
+
+	if form.MaxUses != nil {
+		if *form.MaxUses < 0 {
+			const help = "max_uses must be 0 (unlimited) or greater"
+			return nil, gtserror.NewErrorBadRequest(errors.New(help), help)
+		}
+		invite.MaxUses = *form.MaxUses
+	}
+
+	if form.ExpiresIn != nil {
+		if *form.ExpiresIn < 0 {
+			const help = "expires_in must be 0 (never) or greater"
+			return nil, gtserror.NewErrorBadRequest(errors.New(help), help)
+		}
+
+		if *form.ExpiresIn > 0 {
+			expiresIn := time.Duration(*form.ExpiresIn) * time.Second
+			invite.ExpiresAt = time.Now().Add(expiresIn)
+		}
+	}
+
+	if err := p.state.DB.PutInvite(ctx, invite); err != nil {
+		err := gtserror.Newf("db error putting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.InviteToAPIInvite(ctx, invite), nil
+}
@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Expire expires the invite with the given ID, owned by the given
+// account, so that it can no longer be used to register new accounts.
+//
+// The invite itself is not deleted, so that admins can still
+// see which accounts were registered using the invite.
+func (p *Processor) Expire(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	id string,
+) (*apimodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByID(gtscontext.SetBarebones(ctx), id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil || invite.AccountID != account.ID {
+		// Don't leak the existence of other accounts' invites.
+		err := fmt.Errorf("invite %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	now := time.Now()
+	if !invite.Expired(now) {
+		// Expire the invite as of now.
+		invite.ExpiresAt = now
+		if err := p.state.DB.UpdateInvite(ctx, invite, "expires_at"); err != nil {
+			err := gtserror.Newf("db error updating invite: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.converter.InviteToAPIInvite(ctx, invite), nil
+}
@@ -18,6 +18,7 @@
 package processing
 
 import (
+	"github.com/superseriousbusiness/gotosocial/internal/cards"
 	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
@@ -27,22 +28,31 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/admin"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/conversations"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/fedi"
+	filtersv1 "github.com/superseriousbusiness/gotosocial/internal/processing/filters/v1"
+	filtersv2 "github.com/superseriousbusiness/gotosocial/internal/processing/filters/v2"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/invite"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/list"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/markers"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/polls"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/push"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/report"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/search"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/suggestions"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/tags"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/trends"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/workers"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Processor groups together processing functions and
@@ -68,20 +78,28 @@ type Processor struct {
 		SUB-PROCESSORS
 	*/
 
-	account  account.Processor
-	admin    admin.Processor
-	fedi     fedi.Processor
-	list     list.Processor
-	markers  markers.Processor
-	media    media.Processor
-	polls    polls.Processor
-	report   report.Processor
-	search   search.Processor
-	status   status.Processor
-	stream   stream.Processor
-	timeline timeline.Processor
-	user     user.Processor
-	workers  workers.Processor
+	account       account.Processor
+	admin         admin.Processor
+	conversations conversations.Processor
+	fedi          fedi.Processor
+	filtersv1     filtersv1.Processor
+	filtersv2     filtersv2.Processor
+	invite        invite.Processor
+	list          list.Processor
+	markers       markers.Processor
+	media         media.Processor
+	polls         polls.Processor
+	push          push.Processor
+	report        report.Processor
+	search        search.Processor
+	status        status.Processor
+	stream        stream.Processor
+	suggestions   suggestions.Processor
+	tags          tags.Processor
+	timeline      timeline.Processor
+	trends        trends.Processor
+	user          user.Processor
+	workers       workers.Processor
 }
 
 func (p *Processor) Account() *account.Processor {
@@ -92,10 +110,26 @@ func (p *Processor) Admin() *admin.Processor {
 	return &p.admin
 }
 
+func (p *Processor) Conversations() *conversations.Processor {
+	return &p.conversations
+}
+
 func (p *Processor) Fedi() *fedi.Processor {
 	return &p.fedi
 }
 
+func (p *Processor) FiltersV1() *filtersv1.Processor {
+	return &p.filtersv1
+}
+
+func (p *Processor) FiltersV2() *filtersv2.Processor {
+	return &p.filtersv2
+}
+
+func (p *Processor) Invite() *invite.Processor {
+	return &p.invite
+}
+
 func (p *Processor) List() *list.Processor {
 	return &p.list
 }
@@ -112,6 +146,10 @@ func (p *Processor) Polls() *polls.Processor {
 	return &p.polls
 }
 
+func (p *Processor) Push() *push.Processor {
+	return &p.push
+}
+
 func (p *Processor) Report() *report.Processor {
 	return &p.report
 }
@@ -128,10 +166,22 @@ func (p *Processor) Stream() *stream.Processor {
 	return &p.stream
 }
 
+func (p *Processor) Suggestions() *suggestions.Processor {
+	return &p.suggestions
+}
+
+func (p *Processor) Tags() *tags.Processor {
+	return &p.tags
+}
+
 func (p *Processor) Timeline() *timeline.Processor {
 	return &p.timeline
 }
 
+func (p *Processor) Trends() *trends.Processor {
+	return &p.trends
+}
+
 func (p *Processor) User() *user.Processor {
 	return &p.user
 }
@@ -149,6 +199,8 @@ func NewProcessor(
 	mediaManager *mm.Manager,
 	state *state.State,
 	emailSender email.Sender,
+	webPushSender webpush.Sender,
+	cardFetcher cards.Fetcher,
 ) *Processor {
 	var (
 		parseMentionFunc = GetParseMentionFunc(state.DB, federator)
@@ -176,15 +228,23 @@ func NewProcessor(
 	// processors + pin them to this struct.
 	processor.account = account.New(&common, state, converter, mediaManager, oauthServer, federator, filter, parseMentionFunc)
 	processor.admin = admin.New(state, cleaner, converter, mediaManager, federator.TransportController(), emailSender)
+	processor.conversations = conversations.New(state, converter)
 	processor.fedi = fedi.New(state, &common, converter, federator, filter)
+	processor.filtersv1 = filtersv1.New(state, converter, &common, &processor.stream)
+	processor.filtersv2 = filtersv2.New(state, converter, &common, &processor.stream)
+	processor.invite = invite.New(state, converter)
 	processor.list = list.New(state, converter)
 	processor.markers = markers.New(state, converter)
 	processor.polls = polls.New(&common, state, converter)
+	processor.push = push.New(state, converter)
 	processor.report = report.New(state, converter)
+	processor.suggestions = suggestions.New(state, converter, filter)
+	processor.tags = tags.New(state, converter)
 	processor.timeline = timeline.New(state, converter, filter)
+	processor.trends = trends.New(state, converter, filter)
 	processor.search = search.New(state, federator, converter, filter)
 	processor.status = status.New(state, &common, &processor.polls, federator, converter, filter, parseMentionFunc)
-	processor.user = user.New(state, emailSender)
+	processor.user = user.New(&common, state, converter, emailSender)
 
 	// Workers processor handles asynchronous
 	// worker jobs; instantiate it separately
@@ -195,7 +255,10 @@ func NewProcessor(
 		converter,
 		filter,
 		emailSender,
+		webPushSender,
+		cardFetcher,
 		&processor.account,
+		&processor.user,
 		&processor.media,
 		&processor.stream,
 	)
@@ -0,0 +1,222 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+)
+
+// Create creates a new web push subscription for the given account
+// and access token, replacing any existing subscription of the token.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) Create(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	accessToken string,
+	form *apimodel.PushSubscriptionCreateRequest,
+) (*apimodel.PushSubscription, gtserror.WithCode) {
+	tokenID, errWithCode := p.getTokenID(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := webpush.CheckKeys(
+		form.Subscription.Keys.P256dh,
+		form.Subscription.Keys.Auth,
+	); err != nil {
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// Each token can only have one subscription,
+	// so any existing one is replaced by this one.
+	if err := p.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, tokenID); err != nil {
+		err := gtserror.Newf("db error deleting existing web push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:                 id.NewULID(),
+		AccountID:          account.ID,
+		TokenID:            tokenID,
+		Endpoint:           form.Subscription.Endpoint,
+		P256dh:             form.Subscription.Keys.P256dh,
+		Auth:               form.Subscription.Keys.Auth,
+		AlertFollow:        util.Ptr(false),
+		AlertFollowRequest: util.Ptr(false),
+		AlertFavourite:     util.Ptr(false),
+		AlertMention:       util.Ptr(false),
+		AlertReblog:        util.Ptr(false),
+		AlertPoll:          util.Ptr(false),
+		AlertStatus:        util.Ptr(false),
+		AlertSignup:        util.Ptr(false),
+		Policy:             gtsmodel.WebPushSubscriptionPolicyAll,
+	}
+	applyData(subscription, form.Data)
+
+	if err := p.state.DB.PutWebPushSubscription(ctx, subscription); err != nil {
+		err := gtserror.Newf("db error putting web push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiSubscription(ctx, subscription)
+}
+
+// Get returns the web push subscription of the given access token.
+func (p *Processor) Get(
+	ctx context.Context,
+	accessToken string,
+) (*apimodel.PushSubscription, gtserror.WithCode) {
+	subscription, errWithCode := p.getSubscription(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiSubscription(ctx, subscription)
+}
+
+// Update updates the alerts and policy of the
+// web push subscription of the given access token.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) Update(
+	ctx context.Context,
+	accessToken string,
+	form *apimodel.PushSubscriptionUpdateRequest,
+) (*apimodel.PushSubscription, gtserror.WithCode) {
+	subscription, errWithCode := p.getSubscription(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	applyData(subscription, form.Data)
+
+	if err := p.state.DB.UpdateWebPushSubscription(ctx, subscription,
+		"alert_follow",
+		"alert_follow_request",
+		"alert_favourite",
+		"alert_mention",
+		"alert_reblog",
+		"alert_poll",
+		"alert_status",
+		"alert_signup",
+		"policy",
+	); err != nil {
+		err := gtserror.Newf("db error updating web push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiSubscription(ctx, subscription)
+}
+
+// Delete deletes the web push subscription of the given
+// access token. Deleting a non-existent subscription is OK.
+func (p *Processor) Delete(
+	ctx context.Context,
+	accessToken string,
+) gtserror.WithCode {
+	tokenID, errWithCode := p.getTokenID(ctx, accessToken)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, tokenID); err != nil {
+		err := gtserror.Newf("db error deleting web push subscription: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// getSubscription fetches the web
+// push subscription of the given token.
+func (p *Processor) getSubscription(
+	ctx context.Context,
+	accessToken string,
+) (*gtsmodel.WebPushSubscription, gtserror.WithCode) {
+	tokenID, errWithCode := p.getTokenID(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	subscription, err := p.state.DB.GetWebPushSubscriptionByTokenID(ctx, tokenID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting web push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if subscription == nil {
+		const text = "push subscription not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return subscription, nil
+}
+
+func (p *Processor) apiSubscription(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+) (*apimodel.PushSubscription, gtserror.WithCode) {
+	keyPair, err := webpush.GetVAPIDKeyPair(ctx, p.state)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.WebPushSubscriptionToAPIPushSubscription(ctx, subscription, keyPair.Public), nil
+}
+
+// applyData sets the alerts and policy given in
+// data on the subscription, leaving unset ones as-is.
+func applyData(subscription *gtsmodel.WebPushSubscription, data *apimodel.PushSubscriptionRequestData) {
+	if data == nil {
+		return
+	}
+
+	if alerts := data.Alerts; alerts != nil {
+		for _, alert := range []struct {
+			set   *bool
+			field **bool
+		}{
+			{alerts.Follow, &subscription.AlertFollow},
+			{alerts.FollowRequest, &subscription.AlertFollowRequest},
+			{alerts.Favourite, &subscription.AlertFavourite},
+			{alerts.Mention, &subscription.AlertMention},
+			{alerts.Reblog, &subscription.AlertReblog},
+			{alerts.Poll, &subscription.AlertPoll},
+			{alerts.Status, &subscription.AlertStatus},
+			{alerts.AdminSignup, &subscription.AlertSignup},
+		} {
+			if alert.set != nil {
+				*alert.field = util.Ptr(*alert.set)
+			}
+		}
+	}
+
+	if data.Policy != nil {
+		subscription.Policy = gtsmodel.WebPushSubscriptionPolicy(*data.Policy)
+	}
+}
@@ -113,7 +113,7 @@ func (p *Processor) packageStatuses(
 			continue
 		}
 
-		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requestingAccount)
+		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requestingAccount, gtsmodel.FilterContextNone, nil)
 		if err != nil {
 			log.Debugf(ctx, "skipping status %s because it couldn't be converted to its api representation: %s", status.ID, err)
 			continue
@@ -145,7 +145,7 @@ func (p *Processor) packageHashtags(
 	} else {
 		// If API not version 1, provide slice of full tags.
 		rangeF = func(tag *gtsmodel.Tag) {
-			apiTag, err := p.converter.TagToAPITag(ctx, tag, true)
+			apiTag, err := p.converter.TagToAPITag(ctx, tag, true, nil)
 			if err != nil {
 				log.Debugf(
 					ctx,
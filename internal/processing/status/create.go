@@ -247,7 +247,11 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.Advanced
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
 
-		if attachment.StatusID != "" || attachment.ScheduledStatusID != "" {
+		// Attachments may only be attached to one status, though
+		// we allow media already attached to this same status
+		// (as may be the case when a status is being edited).
+		if (attachment.StatusID != "" && attachment.StatusID != status.ID) ||
+			attachment.ScheduledStatusID != "" {
 			text := fmt.Sprintf("media %s already attached to status", mediaID)
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
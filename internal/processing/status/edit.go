@@ -0,0 +1,305 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// Edit processes the given form to edit an existing status owned by the requesting
+// account. The previous revision of the status is stored in its edit history, and
+// the api model representation of the updated status is returned if all is OK.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) Edit(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statusID string,
+	form *apimodel.StatusEditRequest,
+) (*apimodel.Status, gtserror.WithCode) {
+	status, err := p.state.DB.GetStatusByID(ctx, statusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error fetching status %s from db: %w", statusID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if status == nil {
+		const text = "status not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	if status.AccountID != requester.ID {
+		const text = "status doesn't belong to requesting account"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	if status.BoostOfID != "" {
+		const text = "boosts cannot be edited"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Get current time.
+	now := time.Now()
+
+	// Take a snapshot of the
+	// current status revision
+	// before making any changes.
+	edit := &gtsmodel.StatusEdit{
+		ID:             id.NewULID(),
+		CreatedAt:      status.CreatedAt,
+		StatusID:       status.ID,
+		Content:        status.Content,
+		ContentWarning: status.ContentWarning,
+		Text:           status.Text,
+		Language:       status.Language,
+		Sensitive:      status.Sensitive,
+		AttachmentIDs:  status.AttachmentIDs,
+		Attachments:    status.Attachments,
+	}
+
+	if !status.EditedAt.IsZero() {
+		// Revision was itself an edit.
+		edit.CreatedAt = status.EditedAt
+	}
+
+	for _, attachment := range status.Attachments {
+		edit.AttachmentDescriptions = append(edit.AttachmentDescriptions, attachment.Description)
+	}
+
+	// Take note of the existing poll
+	// and mentions, as the former may
+	// be kept, while the latter are
+	// regenerated from the new content.
+	existingPoll := status.Poll
+	existingMentions := status.Mentions
+
+	if existingPoll != nil {
+		edit.PollOptions = existingPoll.Options
+		edit.PollVotes = existingPoll.Votes
+	}
+
+	// Wrap the edit form in a create form
+	// so we can reuse status create logic.
+	createForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      form.Status,
+			MediaIDs:    form.MediaIDs,
+			Poll:        form.Poll,
+			Sensitive:   form.Sensitive,
+			SpoilerText: form.SpoilerText,
+			Language:    form.Language,
+			ContentType: form.ContentType,
+		},
+	}
+
+	// Reset fields that will
+	// be repopulated from form.
+	status.Text = form.Status
+	status.Sensitive = &form.Sensitive
+	status.ActivityStreamsType = ap.ObjectNote
+	status.Attachments = nil
+	status.AttachmentIDs = nil
+	status.Mentions = nil
+	status.MentionIDs = nil
+	status.Emojis = nil
+	status.EmojiIDs = nil
+	status.Tags = nil
+	status.TagIDs = nil
+	status.Poll = nil
+	status.PollID = ""
+
+	if form.Poll != nil {
+		// Update the status AS type to "Question".
+		status.ActivityStreamsType = ap.ActivityQuestion
+
+		// Create new poll for status from form,
+		// we'll swap this out for the existing
+		// one later on if it's actually unchanged.
+		secs := time.Duration(form.Poll.ExpiresIn)
+		status.Poll = &gtsmodel.Poll{
+			ID:         id.NewULID(),
+			Multiple:   &form.Poll.Multiple,
+			HideCounts: &form.Poll.HideTotals,
+			Options:    form.Poll.Options,
+			StatusID:   status.ID,
+			Status:     status,
+			ExpiresAt:  now.Add(secs * time.Second),
+		}
+
+		// Set poll ID on the status.
+		status.PollID = status.Poll.ID
+	}
+
+	if errWithCode := p.processMediaIDs(ctx, createForm, requester.ID, status); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := processLanguage(createForm, requester.Language, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.processContent(ctx, p.parseMention, createForm, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check whether the poll has changed. If so
+	// the existing poll and its votes are dropped.
+	var newPoll bool
+	switch {
+	case existingPoll != nil && pollUnchanged(existingPoll, status.Poll):
+		// Keep the existing poll.
+		status.Poll = existingPoll
+		status.PollID = existingPoll.ID
+
+	case existingPoll != nil:
+		// Poll changed or removed, delete the existing one.
+		if err := p.state.DB.DeletePollByID(ctx, existingPoll.ID); err != nil {
+			err := gtserror.Newf("error deleting existing poll from db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if err := p.state.DB.DeletePollVotes(ctx, existingPoll.ID); err != nil {
+			err := gtserror.Newf("error deleting existing poll votes from db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// Cancel any scheduled expiry task for poll.
+		_ = p.state.Workers.Scheduler.Cancel(existingPoll.ID)
+		newPoll = (status.Poll != nil)
+
+	default:
+		newPoll = (status.Poll != nil)
+	}
+
+	if newPoll {
+		// Try to insert the new status poll in the database.
+		if err := p.state.DB.PutPoll(ctx, status.Poll); err != nil {
+			err := gtserror.Newf("error inserting poll in db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Store the previous revision.
+	if err := p.state.DB.PutStatusEdit(ctx, edit); err != nil {
+		err := gtserror.Newf("error inserting status edit in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Add the revision to the status edit history.
+	status.EditIDs = append(status.EditIDs, edit.ID)
+	status.Edits = append(status.Edits, edit)
+	status.EditedAt = now
+
+	// Update the status in the database.
+	if err := p.state.DB.UpdateStatus(ctx, status); err != nil {
+		err := gtserror.Newf("error updating status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Mentions are regenerated from the new content,
+	// so drop the mentions of the previous revision.
+	for _, mention := range existingMentions {
+		if slices.Contains(status.MentionIDs, mention.ID) {
+			continue
+		}
+
+		if err := p.state.DB.DeleteMentionByID(ctx, mention.ID); err != nil {
+			log.Errorf(ctx, "error deleting mention %s: %v", mention.ID, err)
+		}
+	}
+
+	// Send it back to the client API worker for async side-effects.
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       status,
+		OriginAccount:  requester,
+	})
+
+	if newPoll {
+		// Now that the status is updated, and side effects queued,
+		// attempt to schedule an expiry handler for the status poll.
+		if err := p.polls.ScheduleExpiry(ctx, status.Poll); err != nil {
+			err := gtserror.Newf("error scheduling poll expiry: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.c.GetAPIStatus(ctx, requester, status)
+}
+
+// HistoryGet gets the edit history of the given status, taking account of privacy settings and blocks etc.
+func (p *Processor) HistoryGet(ctx context.Context, requester *gtsmodel.Account, statusID string) ([]*apimodel.StatusEdit, gtserror.WithCode) {
+	status, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		statusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiEdits, err := p.converter.StatusToAPIEdits(ctx, status)
+	if err != nil {
+		err := gtserror.Newf("error converting status edits: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiEdits, nil
+}
+
+// SourceGet gets the plain-text source of the given status, for use when editing.
+func (p *Processor) SourceGet(ctx context.Context, requester *gtsmodel.Account, statusID string) (*apimodel.StatusSource, gtserror.WithCode) {
+	status, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		statusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if status.AccountID != requester.ID {
+		const text = "status doesn't belong to requesting account"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	return p.converter.StatusToAPIStatusSource(ctx, status), nil
+}
+
+// pollUnchanged returns whether the given new poll
+// (if any) is equivalent to the existing poll, such
+// that the existing poll and its votes can be kept.
+func pollUnchanged(existing, poll *gtsmodel.Poll) bool {
+	return poll != nil &&
+		*existing.Multiple == *poll.Multiple &&
+		*existing.HideCounts == *poll.HideCounts &&
+		slices.Equal(existing.Options, poll.Options)
+}
@@ -0,0 +1,148 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type StatusEditTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) TestEditStatus() {
+	var (
+		ctx             = context.Background()
+		requester       = suite.testAccounts["local_account_1"]
+		status          = suite.testStatuses["local_account_1_status_1"]
+		originalContent = status.Content
+	)
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, requester, status.ID, &apimodel.StatusEditRequest{
+		Status:      "hello everyone! (edited)",
+		SpoilerText: "edited",
+		Language:    "en",
+		ContentType: apimodel.StatusContentTypePlain,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal("<p>hello everyone! (edited)</p>", apiStatus.Content)
+	suite.Equal("edited", apiStatus.SpoilerText)
+	suite.NotNil(apiStatus.EditedAt)
+
+	// Status should now have one previous revision.
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(dbStatus.EditIDs, 1)
+
+	// History should contain the original
+	// revision followed by the current one.
+	history, errWithCode := suite.status.HistoryGet(ctx, requester, status.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Len(history, 2)
+	suite.Equal(originalContent, history[0].Content)
+	suite.Equal(status.ContentWarning, history[0].SpoilerText)
+	suite.Equal(apiStatus.Content, history[1].Content)
+	suite.Equal(*apiStatus.EditedAt, history[1].CreatedAt)
+
+	// Source should be the latest plain text.
+	source, errWithCode := suite.status.SourceGet(ctx, requester, status.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("hello everyone! (edited)", source.Text)
+	suite.Equal("edited", source.SpoilerText)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusKeepMedia() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+		status    = suite.testStatuses["local_account_1_status_4"]
+	)
+
+	// Keep only the first attachment.
+	apiStatus, errWithCode := suite.status.Edit(ctx, requester, status.ID, &apimodel.StatusEditRequest{
+		Status:   "here's a little gif of trent",
+		MediaIDs: status.AttachmentIDs[:1],
+		Language: "en",
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Len(apiStatus.MediaAttachments, 1)
+	suite.Equal(status.AttachmentIDs[0], apiStatus.MediaAttachments[0].ID)
+
+	// Previous revision should still have both attachments.
+	history, errWithCode := suite.status.HistoryGet(ctx, requester, status.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Len(history, 2)
+	suite.Len(history[0].MediaAttachments, 2)
+	suite.Len(history[1].MediaAttachments, 1)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusNotOwned() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_2"]
+		status    = suite.testStatuses["local_account_1_status_1"]
+	)
+
+	_, errWithCode := suite.status.Edit(ctx, requester, status.ID, &apimodel.StatusEditRequest{
+		Status:   "this isn't mine",
+		Language: "en",
+	})
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *StatusEditTestSuite) TestHistoryNeverEdited() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+		status    = suite.testStatuses["local_account_1_status_2"]
+	)
+
+	history, errWithCode := suite.status.HistoryGet(ctx, requester, status.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Len(history, 1)
+	suite.Equal(status.Content, history[0].Content)
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, &StatusEditTestSuite{})
+}
@@ -211,7 +211,20 @@ func TopoSort(apiStatuses []*apimodel.Status, targetAccountID string) {
 
 // ContextGet returns the context (previous and following posts) from the given status ID.
 func (p *Processor) ContextGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Context, gtserror.WithCode) {
-	return p.contextGet(ctx, requestingAccount, targetStatusID, p.converter.StatusToAPIStatus)
+	var filters []*gtsmodel.Filter
+	if requestingAccount != nil {
+		var err error
+		filters, err = p.state.DB.GetFiltersForAccountID(ctx, requestingAccount.ID)
+		if err != nil {
+			err = gtserror.Newf("couldn't retrieve filters for account %s: %w", requestingAccount.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	convert := func(ctx context.Context, status *gtsmodel.Status, requestingAccount *gtsmodel.Account) (*apimodel.Status, error) {
+		return p.converter.StatusToAPIStatus(ctx, status, requestingAccount, gtsmodel.FilterContextThread, filters)
+	}
+	return p.contextGet(ctx, requestingAccount, targetStatusID, convert)
 }
 
 // WebContextGet is like ContextGet, but is explicitly
@@ -0,0 +1,483 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// minScheduleAhead is the minimum amount of time
+	// in the future that a status may be scheduled for.
+	minScheduleAhead = 5 * time.Minute
+
+	// maxScheduledStatuses is the maximum number of
+	// statuses an account may have scheduled at once.
+	maxScheduledStatuses = 300
+)
+
+// ScheduledStatusCreate processes the given form to schedule a new status for publishing
+// at the time given by form.ScheduledAt, returning the api model representation of the
+// scheduled status if it's OK. The status itself is created by the scheduler later on.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) ScheduledStatusCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.AdvancedStatusCreateForm,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledAt, errWithCode := parseScheduledAt(form.ScheduledAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	count, err := p.state.DB.CountScheduledStatusesForAccount(ctx, requester.ID)
+	if err != nil {
+		err := gtserror.Newf("db error counting scheduled statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if count >= maxScheduledStatuses {
+		text := fmt.Sprintf("cannot have more than %d scheduled statuses", maxScheduledStatuses)
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Run the form through the same checks as
+	// status create, using a placeholder status
+	// that is only used to gather the results.
+	status := &gtsmodel.Status{ID: id.NewULID()}
+
+	if errWithCode := p.processReplyToID(ctx, form, requester.ID, status); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.processMediaIDs(ctx, form, requester.ID, status); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := processVisibility(form, requester.Privacy, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := processLanguage(form, requester.Language, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	scheduled := &gtsmodel.ScheduledStatus{
+		ID:               id.NewULID(),
+		AccountID:        requester.ID,
+		Account:          requester,
+		ScheduledAt:      scheduledAt,
+		Text:             form.Status,
+		ContentType:      string(form.ContentType),
+		SpoilerText:      form.SpoilerText,
+		Sensitive:        &form.Sensitive,
+		Visibility:       status.Visibility,
+		Federated:        status.Federated,
+		Boostable:        status.Boostable,
+		Replyable:        status.Replyable,
+		Likeable:         status.Likeable,
+		Language:         status.Language,
+		InReplyToID:      form.InReplyToID,
+		MediaIDs:         status.AttachmentIDs,
+		MediaAttachments: status.Attachments,
+		ApplicationID:    application.ID,
+		Application:      application,
+	}
+
+	if form.Poll != nil {
+		scheduled.PollOptions = form.Poll.Options
+		scheduled.PollExpiresIn = form.Poll.ExpiresIn
+		scheduled.PollMultiple = &form.Poll.Multiple
+		scheduled.PollHideTotals = &form.Poll.HideTotals
+	}
+
+	if err := p.state.DB.PutScheduledStatus(ctx, scheduled); err != nil {
+		err := gtserror.Newf("error inserting scheduled status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Mark attachments as belonging to the scheduled
+	// status, so they can't be attached elsewhere and
+	// aren't cleaned up as unused in the meantime.
+	for _, attachment := range scheduled.MediaAttachments {
+		attachment.ScheduledStatusID = scheduled.ID
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+			err := gtserror.Newf("error updating attachment %s: %w", attachment.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.ScheduleScheduledStatus(ctx, scheduled); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiScheduledStatus(ctx, scheduled)
+}
+
+// ScheduledStatusesGet returns a page of statuses scheduled by the given account, newest first.
+func (p *Processor) ScheduledStatusesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	scheduled, err := p.state.DB.GetScheduledStatusesForAccount(ctx, requester.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting scheduled statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(scheduled)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := scheduled[count-1].ID
+	hi := scheduled[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, s := range scheduled {
+		apiScheduled, errWithCode := p.apiScheduledStatus(ctx, s)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		items = append(items, apiScheduled)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/scheduled_statuses",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// ScheduledStatusGet returns one status scheduled by the given account.
+func (p *Processor) ScheduledStatusGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledID string,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduled, errWithCode := p.getOwnScheduledStatus(ctx, requester, scheduledID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiScheduledStatus(ctx, scheduled)
+}
+
+// ScheduledStatusUpdate moves the publishing time
+// of one status scheduled by the given account.
+func (p *Processor) ScheduledStatusUpdate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledID string,
+	form *apimodel.ScheduledStatusUpdateRequest,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduled, errWithCode := p.getOwnScheduledStatus(ctx, requester, scheduledID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduledAt, errWithCode := parseScheduledAt(form.ScheduledAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduled.ScheduledAt = scheduledAt
+	if err := p.state.DB.UpdateScheduledStatus(ctx, scheduled, "scheduled_at"); err != nil {
+		err := gtserror.Newf("error updating scheduled status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Replace the existing scheduler
+	// task with one at the new time.
+	_ = p.state.Workers.Scheduler.Cancel(scheduled.ID)
+	if err := p.ScheduleScheduledStatus(ctx, scheduled); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiScheduledStatus(ctx, scheduled)
+}
+
+// ScheduledStatusDelete cancels one status scheduled by the given account.
+func (p *Processor) ScheduledStatusDelete(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledID string,
+) gtserror.WithCode {
+	scheduled, errWithCode := p.getOwnScheduledStatus(ctx, requester, scheduledID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Cancel the scheduler task, if any.
+	_ = p.state.Workers.Scheduler.Cancel(scheduled.ID)
+
+	// Release attachments, so they can be
+	// attached elsewhere or cleaned up.
+	p.releaseScheduledMedia(ctx, scheduled)
+
+	if err := p.state.DB.DeleteScheduledStatusByID(ctx, scheduled.ID); err != nil {
+		err := gtserror.Newf("error deleting scheduled status from db: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// ScheduleAll adds all scheduled statuses in the database to the scheduler.
+// This should be called on startup, as scheduled tasks do not survive restarts.
+func (p *Processor) ScheduleAll(ctx context.Context) error {
+	// Fetch all scheduled statuses from the database (barebones models are enough).
+	scheduled, err := p.state.DB.GetAllScheduledStatuses(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting scheduled statuses from db: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, s := range scheduled {
+		// Schedule each of the statuses and catch any errors.
+		if err := p.ScheduleScheduledStatus(ctx, s); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// ScheduleScheduledStatus adds the given scheduled status to the scheduler,
+// to be published at its ScheduledAt time. If that time has already passed
+// (eg., the instance was down at the time), it will be published right away.
+func (p *Processor) ScheduleScheduledStatus(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) error {
+	// Add the given scheduled status to the scheduler.
+	ok := p.state.Workers.Scheduler.AddOnce(
+		scheduled.ID,
+		scheduled.ScheduledAt,
+		p.onScheduledAt(scheduled.ID),
+	)
+
+	if !ok {
+		// Failed to add the status to the scheduler, either it was
+		// starting / stopping or there already exists a task for it.
+		return gtserror.Newf("failed adding scheduled status %s to scheduler", scheduled.ID)
+	}
+
+	atStr := scheduled.ScheduledAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled status %s for publishing at '%s'", scheduled.ID, atStr)
+	return nil
+}
+
+// onScheduledAt returns a callback function to be used by
+// the scheduler when the given scheduled status is due.
+func (p *Processor) onScheduledAt(scheduledID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		if err := p.publishScheduledStatus(ctx, scheduledID); err != nil {
+			log.Errorf(ctx, "error publishing scheduled status %s: %v", scheduledID, err)
+		}
+	}
+}
+
+// publishScheduledStatus creates a status from the scheduled status with
+// the given ID, and deletes the scheduled status once that's been done.
+func (p *Processor) publishScheduledStatus(ctx context.Context, scheduledID string) error {
+	// Get the latest version of scheduled status from database.
+	scheduled, err := p.state.DB.GetScheduledStatusByID(
+		gtscontext.SetBarebones(ctx),
+		scheduledID,
+	)
+	if err != nil {
+		return gtserror.Newf("error getting scheduled status: %w", err)
+	}
+
+	// Populate the scheduled status, though we can carry on without
+	// eg., the application if it has been deleted in the meantime.
+	if err := p.state.DB.PopulateScheduledStatus(ctx, scheduled); err != nil {
+		log.Errorf(ctx, "error(s) populating scheduled status %s, will continue: %v", scheduledID, err)
+	}
+
+	// Scheduled status is done with either way after this,
+	// so make sure it's gone when we return. Attachments are
+	// released first, to allow them to be attached to the status.
+	p.releaseScheduledMedia(ctx, scheduled)
+	defer func() {
+		if err := p.state.DB.DeleteScheduledStatusByID(ctx, scheduled.ID); err != nil {
+			log.Errorf(ctx, "error deleting scheduled status %s: %v", scheduled.ID, err)
+		}
+	}()
+
+	// Fetch the full author account model (needed for create).
+	account, err := p.state.DB.GetAccountByID(ctx, scheduled.AccountID)
+	if err != nil {
+		return gtserror.Newf("error getting account %s: %w", scheduled.AccountID, err)
+	}
+
+	if !account.SuspendedAt.IsZero() {
+		// Suspended accounts don't get to post.
+		return gtserror.Newf("account %s is suspended", account.ID)
+	}
+
+	application := scheduled.Application
+	if application == nil {
+		// Application may have been deleted in the
+		// meantime, the status still gets posted.
+		application = &gtsmodel.Application{}
+	}
+
+	form := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      scheduled.Text,
+			MediaIDs:    scheduled.MediaIDs,
+			InReplyToID: scheduled.InReplyToID,
+			Sensitive:   util.PtrValueOr(scheduled.Sensitive, false),
+			SpoilerText: scheduled.SpoilerText,
+			Visibility:  scheduledVisToAPIVis(scheduled.Visibility),
+			Language:    scheduled.Language,
+			ContentType: apimodel.StatusContentType(scheduled.ContentType),
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			Federated: scheduled.Federated,
+			Boostable: scheduled.Boostable,
+			Replyable: scheduled.Replyable,
+			Likeable:  scheduled.Likeable,
+		},
+	}
+
+	if len(scheduled.PollOptions) > 0 {
+		form.Poll = &apimodel.PollRequest{
+			Options:    scheduled.PollOptions,
+			ExpiresIn:  scheduled.PollExpiresIn,
+			Multiple:   util.PtrValueOr(scheduled.PollMultiple, false),
+			HideTotals: util.PtrValueOr(scheduled.PollHideTotals, false),
+		}
+	}
+
+	if _, errWithCode := p.Create(ctx, account, application, form); errWithCode != nil {
+		return gtserror.Newf("error creating status: %w", errWithCode)
+	}
+
+	return nil
+}
+
+// getOwnScheduledStatus fetches the scheduled status
+// with the given ID, checking it belongs to requester.
+func (p *Processor) getOwnScheduledStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledID string,
+) (*gtsmodel.ScheduledStatus, gtserror.WithCode) {
+	scheduled, err := p.state.DB.GetScheduledStatusByID(ctx, scheduledID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting scheduled status %s: %w", scheduledID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Scheduled statuses belonging to other
+	// accounts are treated as not existing.
+	if scheduled == nil || scheduled.AccountID != requester.ID {
+		const text = "scheduled status not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return scheduled, nil
+}
+
+// releaseScheduledMedia unsets the scheduled status
+// ID on the attachments of given scheduled status.
+func (p *Processor) releaseScheduledMedia(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) {
+	for _, attachment := range scheduled.MediaAttachments {
+		if attachment.ScheduledStatusID != scheduled.ID {
+			// Attachment has been
+			// reused elsewhere, skip.
+			continue
+		}
+
+		attachment.ScheduledStatusID = ""
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "scheduled_status_id"); err != nil {
+			log.Errorf(ctx, "error updating attachment %s: %v", attachment.ID, err)
+		}
+	}
+}
+
+func (p *Processor) apiScheduledStatus(
+	ctx context.Context,
+	scheduled *gtsmodel.ScheduledStatus,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	apiScheduled, err := p.converter.ScheduledStatusToAPIScheduledStatus(ctx, scheduled)
+	if err != nil {
+		err := gtserror.Newf("error converting scheduled status %s: %w", scheduled.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiScheduled, nil
+}
+
+// parseScheduledAt parses the given scheduled_at
+// string, checking it's far enough in the future.
+func parseScheduledAt(scheduledAtStr string) (time.Time, gtserror.WithCode) {
+	scheduledAt, err := time.Parse(time.RFC3339, scheduledAtStr)
+	if err != nil {
+		text := fmt.Sprintf("invalid scheduled_at %s: %v", scheduledAtStr, err)
+		return time.Time{}, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if time.Until(scheduledAt) < minScheduleAhead {
+		text := fmt.Sprintf("scheduled_at must be at least %s in the future", minScheduleAhead)
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return scheduledAt, nil
+}
+
+// scheduledVisToAPIVis converts the stored visibility of a
+// scheduled status back into a form value. Unlike the usual
+// conversion this one has to be lossless, so that mutuals
+// only statuses are published with the intended visibility.
+func scheduledVisToAPIVis(vis gtsmodel.Visibility) apimodel.Visibility {
+	switch vis {
+	case gtsmodel.VisibilityPublic:
+		return apimodel.VisibilityPublic
+	case gtsmodel.VisibilityUnlocked:
+		return apimodel.VisibilityUnlisted
+	case gtsmodel.VisibilityFollowersOnly:
+		return apimodel.VisibilityPrivate
+	case gtsmodel.VisibilityMutualsOnly:
+		return apimodel.VisibilityMutualsOnly
+	case gtsmodel.VisibilityDirect:
+		return apimodel.VisibilityDirect
+	}
+	return ""
+}
@@ -0,0 +1,203 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type ScheduledStatusTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) scheduledForm(scheduledAt time.Time, mediaIDs ...string) *apimodel.AdvancedStatusCreateForm {
+	return &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "this status was scheduled!",
+			MediaIDs:    mediaIDs,
+			Visibility:  apimodel.VisibilityMutualsOnly,
+			ScheduledAt: scheduledAt.Format(time.RFC3339),
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusCreate() {
+	var (
+		ctx         = context.Background()
+		requester   = suite.testAccounts["local_account_1"]
+		application = suite.testApplications["application_1"]
+		attachment  = suite.testAttachments["local_account_1_unattached_1"]
+		scheduledAt = time.Now().Add(time.Hour)
+	)
+
+	apiScheduled, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application,
+		suite.scheduledForm(scheduledAt, attachment.ID),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.NotEmpty(apiScheduled.ID)
+	suite.Equal("this status was scheduled!", apiScheduled.Params.Text)
+	suite.Equal(application.ID, apiScheduled.Params.ApplicationID)
+	suite.Len(apiScheduled.MediaAttachments, 1)
+
+	// Attachment should now be marked as scheduled.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(apiScheduled.ID, dbAttachment.ScheduledStatusID)
+
+	// So it can't be scheduled again.
+	_, errWithCode = suite.status.ScheduledStatusCreate(ctx, requester, application,
+		suite.scheduledForm(scheduledAt, attachment.ID),
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Scheduled status should be listed for requester.
+	resp, errWithCode := suite.status.ScheduledStatusesGet(ctx, requester, &paging.Page{Limit: 20})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(resp.Items, 1)
+
+	// But not visible to anyone else.
+	_, errWithCode = suite.status.ScheduledStatusGet(ctx, suite.testAccounts["local_account_2"], apiScheduled.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusCreateTooSoon() {
+	var (
+		ctx         = context.Background()
+		requester   = suite.testAccounts["local_account_1"]
+		application = suite.testApplications["application_1"]
+	)
+
+	_, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application,
+		suite.scheduledForm(time.Now().Add(time.Minute)),
+	)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusUpdateDelete() {
+	var (
+		ctx         = context.Background()
+		requester   = suite.testAccounts["local_account_1"]
+		application = suite.testApplications["application_1"]
+		attachment  = suite.testAttachments["local_account_1_unattached_1"]
+	)
+
+	apiScheduled, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application,
+		suite.scheduledForm(time.Now().Add(time.Hour), attachment.ID),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	scheduledAt := time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+	apiScheduled, errWithCode = suite.status.ScheduledStatusUpdate(ctx, requester, apiScheduled.ID,
+		&apimodel.ScheduledStatusUpdateRequest{ScheduledAt: scheduledAt},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	dbScheduled, err := suite.db.GetScheduledStatusByID(ctx, apiScheduled.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(scheduledAt, dbScheduled.ScheduledAt.Format(time.RFC3339))
+
+	if errWithCode := suite.status.ScheduledStatusDelete(ctx, requester, apiScheduled.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, err = suite.db.GetScheduledStatusByID(ctx, apiScheduled.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Attachment should be free to use again.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbAttachment.ScheduledStatusID)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusPublish() {
+	var (
+		ctx         = context.Background()
+		requester   = suite.testAccounts["local_account_1"]
+		application = suite.testApplications["application_1"]
+		attachment  = suite.testAttachments["local_account_1_unattached_1"]
+	)
+
+	apiScheduled, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application,
+		suite.scheduledForm(time.Now().Add(time.Hour), attachment.ID),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Bring the scheduled time forward
+	// so the status is published now.
+	dbScheduled, err := suite.db.GetScheduledStatusByID(ctx, apiScheduled.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbScheduled.ScheduledAt = time.Now()
+	_ = suite.state.Workers.Scheduler.Cancel(dbScheduled.ID)
+	if err := suite.status.ScheduleScheduledStatus(ctx, dbScheduled); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Wait for the scheduled status to be removed.
+	if !suite.Eventually(func() bool {
+		_, err := suite.db.GetScheduledStatusByID(ctx, dbScheduled.ID)
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond) {
+		suite.FailNow("timed out waiting for scheduled status to be published")
+	}
+
+	// Status should now be published with the scheduled params.
+	statuses, err := suite.db.GetAccountStatuses(ctx, requester.ID, 1, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(statuses, 1)
+
+	status := statuses[0]
+	suite.Equal("this status was scheduled!", status.Text)
+	suite.Equal(dbScheduled.Visibility, status.Visibility)
+	suite.Equal(application.ID, status.CreatedWithApplicationID)
+	suite.Equal([]string{attachment.ID}, status.AttachmentIDs)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
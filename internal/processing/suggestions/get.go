@@ -0,0 +1,218 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package suggestions
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// SuggestionsGet returns up to limit accounts suggested for the requester
+// to follow. Accounts pinned by an admin come first, followed by accounts
+// that are commonly followed by accounts that the requester follows.
+func (p *Processor) SuggestionsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+) ([]*apimodel.Suggestion, gtserror.WithCode) {
+	pinnedSuggestions, err := p.state.DB.GetPinnedSuggestions(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting pinned suggestions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	dismissedIDs, err := p.state.DB.GetDismissedSuggestionTargetAccountIDs(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting dismissed suggestions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Friends of friends query already excludes
+	// follows, follow requests + dismissed accounts.
+	fofIDs, err := p.state.DB.GetFriendsOfFriendsAccountIDs(ctx, requester.ID, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting friends of friends: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	suggestions := make([]*apimodel.Suggestion, 0, limit)
+
+	for _, pinnedSuggestion := range pinnedSuggestions {
+		if len(suggestions) == limit {
+			break
+		}
+
+		target := pinnedSuggestion.TargetAccount
+		if slices.Contains(dismissedIDs, target.ID) {
+			continue
+		}
+
+		// Pinned suggestions aren't excluded by
+		// the db, so check follow relationship here.
+		ok, err := p.notFollowing(ctx, requester, target)
+		if err != nil {
+			log.Errorf(ctx, "error checking relationship: %v", err)
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		suggestion := p.suggestion(ctx, requester, target, sourceStaff, sourcesFeatured)
+		if suggestion == nil {
+			continue
+		}
+
+		if slices.Contains(fofIDs, target.ID) {
+			// Also a friend of friend,
+			// so add that source too.
+			suggestion.Sources = append(suggestion.Sources, sourcesFriendsOfFriends)
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	if len(fofIDs) == 0 {
+		return suggestions, nil
+	}
+
+	fofs, err := p.state.DB.GetAccountsByIDs(ctx, fofIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting friends of friends: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, target := range fofs {
+		if len(suggestions) == limit {
+			break
+		}
+
+		if !util.PtrValueOr(target.Discoverable, false) {
+			// Only suggest accounts
+			// that have opted in.
+			continue
+		}
+
+		if slices.ContainsFunc(suggestions, func(s *apimodel.Suggestion) bool {
+			return s.Account.ID == target.ID
+		}) {
+			// Already pinned.
+			continue
+		}
+
+		suggestion := p.suggestion(ctx, requester, target, sourcePastInteractions, sourcesFriendsOfFriends)
+		if suggestion == nil {
+			continue
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, nil
+}
+
+// notFollowing returns true if requester is not
+// the target account, and does not already follow
+// or have a pending follow request to the target.
+func (p *Processor) notFollowing(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	target *gtsmodel.Account,
+) (bool, error) {
+	if requester.ID == target.ID {
+		return false, nil
+	}
+
+	following, err := p.state.DB.IsFollowing(ctx, requester.ID, target.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if following {
+		return false, nil
+	}
+
+	requested, err := p.state.DB.IsFollowRequested(ctx, requester.ID, target.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return !requested, nil
+}
+
+// suggestion converts the given target account into a
+// suggestion with the given source, or returns nil if
+// the target shouldn't be suggested to the requester.
+func (p *Processor) suggestion(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	target *gtsmodel.Account,
+	source string,
+	sources string,
+) *apimodel.Suggestion {
+	if target.IsInstance() {
+		// Instance actors
+		// aren't people.
+		return nil
+	}
+
+	// Ensure account is visible to requester,
+	// this accounts for blocks + suspensions.
+	visible, err := p.filter.AccountVisible(ctx, requester, target)
+	if err != nil {
+		log.Errorf(ctx, "error checking account visibility: %v", err)
+		return nil
+	}
+
+	if !visible {
+		return nil
+	}
+
+	// Don't suggest accounts the requester has muted.
+	mute, err := p.state.DB.GetMute(ctx, requester.ID, target.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "error checking mute: %v", err)
+		return nil
+	}
+
+	if mute != nil && !mute.Expired(time.Now()) {
+		return nil
+	}
+
+	apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, target)
+	if err != nil {
+		log.Errorf(ctx, "error converting account %s: %v", target.ID, err)
+		return nil
+	}
+
+	return &apimodel.Suggestion{
+		Source:  source,
+		Sources: []string{sources},
+		Account: apiAccount,
+	}
+}
@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package suggestions
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+)
+
+const (
+	// Deprecated suggestion sources,
+	// kept for older clients.
+	sourceStaff            = "staff"
+	sourcePastInteractions = "past_interactions"
+
+	// Suggestion sources.
+	sourcesFeatured         = "featured"
+	sourcesFriendsOfFriends = "friends_of_friends"
+)
+
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+	filter    *visibility.Filter
+}
+
+func New(state *state.State, converter *typeutils.Converter, filter *visibility.Filter) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+		filter:    filter,
+	}
+}
@@ -0,0 +1,125 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package suggestions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/suggestions"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SuggestionsTestSuite struct {
+	suite.Suite
+	state       state.State
+	suggestions suggestions.Processor
+
+	testAccounts map[string]*gtsmodel.Account
+}
+
+func (suite *SuggestionsTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+	testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+	converter := typeutils.NewConverter(&suite.state)
+	filter := visibility.NewFilter(&suite.state)
+	suite.suggestions = suggestions.New(&suite.state, converter, filter)
+
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *SuggestionsTestSuite) TearDownTest() {
+	testrig.StopWorkers(&suite.state)
+	testrig.StandardDBTeardown(suite.state.DB)
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionsGet() {
+	var (
+		ctx          = context.Background()
+		requester    = suite.testAccounts["local_account_2"]
+		adminAccount = suite.testAccounts["admin_account"]
+		pinned       = suite.testAccounts["remote_account_2"]
+		blocked      = suite.testAccounts["remote_account_1"]
+	)
+
+	// local_account_2 follows local_account_1,
+	// who follows (discoverable) admin_account.
+	apiSuggestions, errWithCode := suite.suggestions.SuggestionsGet(ctx, requester, 40)
+	suite.NoError(errWithCode)
+	suite.Len(apiSuggestions, 1)
+	suite.Equal(adminAccount.ID, apiSuggestions[0].Account.ID)
+	suite.Equal("past_interactions", apiSuggestions[0].Source)
+	suite.Equal([]string{"friends_of_friends"}, apiSuggestions[0].Sources)
+
+	// Pinned suggestions come first, unless
+	// blocked (local_account_2 blocks remote_account_1).
+	for _, target := range []*gtsmodel.Account{pinned, blocked} {
+		if err := suite.state.DB.PutPinnedSuggestion(ctx, &gtsmodel.PinnedSuggestion{
+			ID:              id.NewULID(),
+			AccountID:       adminAccount.ID,
+			TargetAccountID: target.ID,
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	apiSuggestions, errWithCode = suite.suggestions.SuggestionsGet(ctx, requester, 40)
+	suite.NoError(errWithCode)
+	suite.Len(apiSuggestions, 2)
+	suite.Equal(pinned.ID, apiSuggestions[0].Account.ID)
+	suite.Equal("staff", apiSuggestions[0].Source)
+	suite.Equal([]string{"featured"}, apiSuggestions[0].Sources)
+
+	// Limit is obeyed.
+	apiSuggestions, errWithCode = suite.suggestions.SuggestionsGet(ctx, requester, 1)
+	suite.NoError(errWithCode)
+	suite.Len(apiSuggestions, 1)
+
+	// Dismissed suggestions aren't returned again.
+	errWithCode = suite.suggestions.SuggestionDismiss(ctx, requester, adminAccount.ID)
+	suite.NoError(errWithCode)
+	errWithCode = suite.suggestions.SuggestionDismiss(ctx, requester, pinned.ID)
+	suite.NoError(errWithCode)
+
+	apiSuggestions, errWithCode = suite.suggestions.SuggestionsGet(ctx, requester, 40)
+	suite.NoError(errWithCode)
+	suite.Empty(apiSuggestions)
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionDismissNotFound() {
+	errWithCode := suite.suggestions.SuggestionDismiss(
+		context.Background(),
+		suite.testAccounts["local_account_2"],
+		"01HQZ5Z2T3X0Y7K8V9W1M2N3P4",
+	)
+	suite.Equal(404, errWithCode.Code())
+}
+
+func TestSuggestionsTestSuite(t *testing.T) {
+	suite.Run(t, new(SuggestionsTestSuite))
+}
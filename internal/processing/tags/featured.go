@@ -0,0 +1,284 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// Featured gets all tags featured by requester.
+func (p *Processor) Featured(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	return p.featured(ctx, requester.ID)
+}
+
+// AccountFeatured gets all tags featured by the target account. Requester
+// may be nil, for example when viewing the target's profile while logged out.
+func (p *Processor) AccountFeatured(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetAccountID string,
+) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("account %s not found", targetAccountID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err = gtserror.Newf("db error getting account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if requester != nil {
+		blocked, err := p.state.DB.IsEitherBlocked(ctx, requester.ID, targetAccount.ID)
+		if err != nil {
+			err = gtserror.Newf("db error checking block: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if blocked {
+			// Block exists between accounts.
+			// Just return empty featured tags.
+			return []*apimodel.FeaturedTag{}, nil
+		}
+	}
+
+	return p.featured(ctx, targetAccount.ID)
+}
+
+func (p *Processor) featured(
+	ctx context.Context,
+	accountID string,
+) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	featuredTags, err := p.state.DB.GetFeaturedTagsByAccountID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting featured tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFeaturedTags := make([]*apimodel.FeaturedTag, 0, len(featuredTags))
+	for _, featuredTag := range featuredTags {
+		apiFeaturedTag, err := p.converter.FeaturedTagToAPIFeaturedTag(ctx, featuredTag)
+		if err != nil {
+			log.Errorf(ctx, "error converting featured tag %s to frontend representation: %v", featuredTag.ID, err)
+			continue
+		}
+
+		apiFeaturedTags = append(apiFeaturedTags, apiFeaturedTag)
+	}
+
+	return apiFeaturedTags, nil
+}
+
+// Feature features the tag with the given name on
+// requester's profile, creating the tag if it doesn't
+// exist, and federates the change out to followers.
+func (p *Processor) Feature(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	name string,
+) (*apimodel.FeaturedTag, gtserror.WithCode) {
+	tag, normalized, errWithCode := p.getTag(ctx, name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag == nil {
+		// We didn't have a tag with
+		// this name, create one.
+		tag = &gtsmodel.Tag{
+			ID:   id.NewULID(),
+			Name: normalized,
+		}
+
+		if err := p.state.DB.PutTag(ctx, tag); err != nil {
+			err = gtserror.Newf("db error putting new tag %s: %w", normalized, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	featuredTags, err := p.state.DB.GetFeaturedTagsByAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting featured tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, featuredTag := range featuredTags {
+		if featuredTag.TagID == tag.ID {
+			const text = "tag is already featured"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	if len(featuredTags) >= gtsmodel.MaxFeaturedTags {
+		text := fmt.Sprintf("you can feature at most %d tags", gtsmodel.MaxFeaturedTags)
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Populate stats from
+	// requester's statuses.
+	count, lastAt, err := p.state.DB.GetAccountTagUsage(ctx, requester.ID, tag.ID)
+	if err != nil {
+		err = gtserror.Newf("db error getting tag usage: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	featuredTag := &gtsmodel.FeaturedTag{
+		ID:            id.NewULID(),
+		AccountID:     requester.ID,
+		Account:       requester,
+		TagID:         tag.ID,
+		Tag:           tag,
+		StatusesCount: count,
+		LastStatusAt:  lastAt,
+	}
+
+	if err := p.state.DB.PutFeaturedTag(ctx, featuredTag); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Featured in the meantime.
+			const text = "tag is already featured"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+		err = gtserror.Newf("db error putting featured tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.federateFeaturedTags(ctx, requester)
+
+	apiFeaturedTag, err := p.converter.FeaturedTagToAPIFeaturedTag(ctx, featuredTag)
+	if err != nil {
+		err = gtserror.Newf("error converting featured tag to frontend representation: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiFeaturedTag, nil
+}
+
+// Unfeature removes the featured tag with the given ID from
+// requester's profile, and federates the change out to followers.
+func (p *Processor) Unfeature(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	featuredTagID string,
+) gtserror.WithCode {
+	featuredTag, err := p.state.DB.GetFeaturedTagByID(ctx, featuredTagID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting featured tag: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if featuredTag == nil || featuredTag.AccountID != requester.ID {
+		err := gtserror.Newf("featured tag %s not found", featuredTagID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	if err := p.state.DB.DeleteFeaturedTagByID(ctx, featuredTag.ID); err != nil {
+		err = gtserror.Newf("db error deleting featured tag: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.federateFeaturedTags(ctx, requester)
+
+	return nil
+}
+
+// FeatureSuggestions gets up to 10 of the tags most used
+// by requester in their statuses, which they haven't yet
+// featured on their profile.
+func (p *Processor) FeatureSuggestions(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.Tag, gtserror.WithCode) {
+	featuredTags, err := p.state.DB.GetFeaturedTagsByAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting featured tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	featured := make(map[string]struct{}, len(featuredTags))
+	for _, featuredTag := range featuredTags {
+		featured[featuredTag.TagID] = struct{}{}
+	}
+
+	// Fetch enough most used tags to still
+	// fill the suggestions when the already
+	// featured tags are filtered out of them.
+	const limit = 10
+	tagIDs, err := p.state.DB.GetAccountMostUsedTagIDs(ctx, requester.ID, limit+len(featured))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting most used tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	tags, err := p.state.DB.GetTags(ctx, tagIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTags := make([]*apimodel.Tag, 0, limit)
+	for _, tag := range tags {
+		if len(apiTags) == limit {
+			break
+		}
+
+		if _, ok := featured[tag.ID]; ok {
+			// Already featured.
+			continue
+		}
+
+		if !*tag.Useable {
+			// Can't be featured.
+			continue
+		}
+
+		apiTag, errWithCode := p.apiTag(ctx, requester, tag)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiTags = append(apiTags, apiTag)
+	}
+
+	return apiTags, nil
+}
+
+// federateFeaturedTags sends out an update of requester's profile
+// after their featured tags changed, as that's what prompts remote
+// instances to re-dereference the featured tags collection.
+func (p *Processor) federateFeaturedTags(ctx context.Context, requester *gtsmodel.Account) {
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       requester,
+		OriginAccount:  requester,
+	})
+}
@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Follow follows the tag with the given name as
+// requester, creating the tag if it doesn't exist.
+// If the tag is already followed, this is a no-op.
+func (p *Processor) Follow(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	name string,
+) (*apimodel.Tag, gtserror.WithCode) {
+	tag, normalized, errWithCode := p.getTag(ctx, name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag == nil {
+		// We didn't have a tag with
+		// this name, create one.
+		tag = &gtsmodel.Tag{
+			ID:   id.NewULID(),
+			Name: normalized,
+		}
+
+		if err := p.state.DB.PutTag(ctx, tag); err != nil {
+			err = gtserror.Newf("db error putting new tag %s: %w", normalized, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	following, err := p.isFollowing(ctx, requester, tag)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !following {
+		// Not yet following, store new followed tag.
+		if err := p.state.DB.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+			ID:        id.NewULID(),
+			AccountID: requester.ID,
+			TagID:     tag.ID,
+		}); err != nil {
+			err = gtserror.Newf("db error putting followed tag: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiTag(ctx, requester, tag)
+}
+
+// Unfollow unfollows the tag with the given name as
+// requester. If the tag is not followed, this is a no-op.
+func (p *Processor) Unfollow(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	name string,
+) (*apimodel.Tag, gtserror.WithCode) {
+	tag, _, errWithCode := p.getTag(ctx, name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag == nil {
+		err := gtserror.Newf("tag %s not found", name)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if err := p.state.DB.DeleteFollowedTag(ctx, requester.ID, tag.ID); err != nil {
+		err = gtserror.Newf("db error deleting followed tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Remove requester's home timeline so that it's rebuilt
+	// without statuses that were only there via this tag. Just
+	// log if this goes wrong, the timeline will just be stale.
+	if err := p.state.Timelines.Home.RemoveTimeline(ctx, requester.ID); err != nil {
+		log.Errorf(ctx, "error removing home timeline: %v", err)
+	}
+
+	return p.apiTag(ctx, requester, tag)
+}
@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Get gets the tag with the given name, with
+// 'following' set relative to requester.
+func (p *Processor) Get(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	name string,
+) (*apimodel.Tag, gtserror.WithCode) {
+	tag, _, errWithCode := p.getTag(ctx, name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag == nil {
+		err := gtserror.Newf("tag %s not found", name)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return p.apiTag(ctx, requester, tag)
+}
+
+// Followed gets a page of tags followed by requester.
+func (p *Processor) Followed(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	followedTags, err := p.state.DB.GetFollowedTags(ctx,
+		requester.ID,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting followed tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(followedTags)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := followedTags[count-1].ID
+	hi := followedTags[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, followedTag := range followedTags {
+		apiTag, err := p.converter.TagToAPITag(ctx, followedTag.Tag, true, util.Ptr(true))
+		if err != nil {
+			log.Errorf(ctx, "error converting tag %s to frontend representation: %v", followedTag.Tag.Name, err)
+			continue
+		}
+
+		items = append(items, apiTag)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/followed_tags",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
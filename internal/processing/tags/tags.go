@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+}
+
+func New(state *state.State, converter *typeutils.Converter) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+	}
+}
+
+// getTag normalizes the given tag name and fetches the
+// tag with that name from the database, returning nil
+// tag if it doesn't exist (yet).
+func (p *Processor) getTag(ctx context.Context, name string) (*gtsmodel.Tag, string, gtserror.WithCode) {
+	// Normalize + validate tag name.
+	normalized, ok := text.NormalizeHashtag(name)
+	if !ok {
+		err := gtserror.Newf("string '%s' could not be normalized to a valid hashtag", name)
+		return nil, "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Check if we have a tag with this name already.
+	tag, err := p.state.DB.GetTagByName(ctx, normalized)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting tag by name: %w", err)
+		return nil, "", gtserror.NewErrorInternalError(err)
+	}
+
+	if tag != nil && !*tag.Useable {
+		// Obey mastodon API by returning 404 for this.
+		err := gtserror.Newf("tag %s not useable on this instance", normalized)
+		return nil, "", gtserror.NewErrorNotFound(err)
+	}
+
+	return tag, normalized, nil
+}
+
+// apiTag converts the given tag to its API model
+// representation, with 'following' set according
+// to whether requester follows the tag.
+func (p *Processor) apiTag(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	tag *gtsmodel.Tag,
+) (*apimodel.Tag, gtserror.WithCode) {
+	following, err := p.isFollowing(ctx, requester, tag)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTag, err := p.converter.TagToAPITag(ctx, tag, true, util.Ptr(following))
+	if err != nil {
+		err = gtserror.Newf("error converting tag %s to frontend representation: %w", tag.Name, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apiTag, nil
+}
+
+// isFollowing returns whether requester follows the given tag.
+func (p *Processor) isFollowing(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	tag *gtsmodel.Tag,
+) (bool, error) {
+	followedTag, err := p.state.DB.GetFollowedTag(ctx, requester.ID, tag.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error checking followed tag: %w", err)
+	}
+
+	return followedTag != nil, nil
+}
@@ -110,7 +110,13 @@ func ListTimelineStatusPrepare(state *state.State, converter *typeutils.Converte
 			return nil, err
 		}
 
-		return converter.StatusToAPIStatus(ctx, status, requestingAccount)
+		filters, err := state.DB.GetFiltersForAccountID(ctx, requestingAccount.ID)
+		if err != nil {
+			err = gtserror.Newf("couldn't retrieve filters for account %s: %w", requestingAccount.ID, err)
+			return nil, err
+		}
+
+		return converter.StatusToAPIStatus(ctx, status, requestingAccount, gtsmodel.FilterContextHome, filters)
 	}
 }
 
@@ -24,6 +24,7 @@ import (
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -43,6 +44,12 @@ func (p *Processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, ma
 		return util.EmptyPageableResponse(), nil
 	}
 
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, authed.Account.ID)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve filters for account %s: %w", authed.Account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	var (
 		items          = make([]interface{}, 0, count)
 		nextMaxIDValue string
@@ -72,6 +79,17 @@ func (p *Processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, ma
 			if !visible {
 				continue
 			}
+
+			// Ensure notifications from origin account aren't muted.
+			muted, err := p.filter.AccountNotificationsMuted(ctx, authed.Account, n.OriginAccount)
+			if err != nil {
+				log.Debugf(ctx, "skipping notification %s because of an error checking notification mute: %s", n.ID, err)
+				continue
+			}
+
+			if muted {
+				continue
+			}
 		}
 
 		if n.Status != nil {
@@ -87,8 +105,11 @@ func (p *Processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, ma
 			}
 		}
 
-		item, err := p.converter.NotificationToAPINotification(ctx, n)
+		item, err := p.converter.NotificationToAPINotification(ctx, n, filters)
 		if err != nil {
+			if errors.Is(err, statusfilter.ErrHideStatus) {
+				continue
+			}
 			log.Debugf(ctx, "skipping notification %s because it couldn't be converted to its api representation: %s", n.ID, err)
 			continue
 		}
@@ -121,7 +142,7 @@ func (p *Processor) NotificationGet(ctx context.Context, account *gtsmodel.Accou
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
-	apiNotif, err := p.converter.NotificationToAPINotification(ctx, notif)
+	apiNotif, err := p.converter.NotificationToAPINotification(ctx, notif, nil)
 	if err != nil {
 		if errors.Is(err, db.ErrNoEntries) {
 			return nil, gtserror.NewErrorNotFound(err)
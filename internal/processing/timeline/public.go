@@ -24,7 +24,9 @@ import (
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -51,6 +53,12 @@ func (p *Processor) PublicTimelineGet(ctx context.Context, authed *oauth.Auth, m
 		prevMinIDValue = statuses[0].ID
 	)
 
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, authed.Account.ID)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve filters for account %s: %w", authed.Account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	for _, s := range statuses {
 		timelineable, err := p.filter.StatusPublicTimelineable(ctx, authed.Account, s)
 		if err != nil {
@@ -62,7 +70,10 @@ func (p *Processor) PublicTimelineGet(ctx context.Context, authed *oauth.Auth, m
 			continue
 		}
 
-		apiStatus, err := p.converter.StatusToAPIStatus(ctx, s, authed.Account)
+		apiStatus, err := p.converter.StatusToAPIStatus(ctx, s, authed.Account, gtsmodel.FilterContextPublic, filters)
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			continue
+		}
 		if err != nil {
 			log.Errorf(ctx, "error convert to api status: %v", err)
 			continue
@@ -0,0 +1,202 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// TagsGet returns currently trending hashtags
+// which have been approved by an admin.
+func (p *Processor) TagsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+	offset int,
+) ([]*apimodel.Tag, gtserror.WithCode) {
+	trends, errWithCode := p.getTrending(ctx, gtsmodel.TrendTypeTag)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiTags := make([]*apimodel.Tag, 0, limit)
+	for _, trend := range trends {
+		if !*trend.Tag.Useable || !*trend.Tag.Listable {
+			// Tag made unlistable
+			// since it was approved.
+			continue
+		}
+
+		if offset > 0 {
+			// Skip
+			// to offset.
+			offset--
+			continue
+		}
+
+		apiTag, err := p.converter.TrendToAPITag(ctx, trend)
+		if err != nil {
+			log.Errorf(ctx, "error converting trend %s: %v", trend.ID, err)
+			continue
+		}
+
+		apiTags = append(apiTags, apiTag)
+		if len(apiTags) == limit {
+			break
+		}
+	}
+
+	return apiTags, nil
+}
+
+// StatusesGet returns currently trending statuses which have
+// been approved by an admin, and are visible to requester.
+func (p *Processor) StatusesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+	offset int,
+) ([]*apimodel.Status, gtserror.WithCode) {
+	trends, errWithCode := p.getTrending(ctx, gtsmodel.TrendTypeStatus)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filters, err := p.state.DB.GetFiltersForAccountID(ctx, requester.ID)
+	if err != nil {
+		err = gtserror.Newf("couldn't retrieve filters for account %s: %w", requester.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiStatuses := make([]*apimodel.Status, 0, limit)
+	for _, trend := range trends {
+		// Ensure status is visible to requester.
+		visible, err := p.filter.StatusVisible(ctx, requester, trend.Status)
+		if err != nil {
+			log.Errorf(ctx, "error checking status visibility: %v", err)
+			continue
+		}
+
+		if !visible {
+			continue
+		}
+
+		// Ensure status not muted by requester.
+		muted, err := p.filter.StatusMuted(ctx, requester, trend.Status)
+		if err != nil {
+			log.Errorf(ctx, "error checking status mute: %v", err)
+			continue
+		}
+
+		if muted {
+			continue
+		}
+
+		if offset > 0 {
+			// Skip
+			// to offset.
+			offset--
+			continue
+		}
+
+		apiStatus, err := p.converter.StatusToAPIStatus(ctx,
+			trend.Status,
+			requester,
+			gtsmodel.FilterContextPublic,
+			filters,
+		)
+		if err != nil {
+			if !errors.Is(err, statusfilter.ErrHideStatus) {
+				log.Errorf(ctx, "error converting status %s: %v", trend.Status.ID, err)
+			}
+			continue
+		}
+
+		apiStatuses = append(apiStatuses, apiStatus)
+		if len(apiStatuses) == limit {
+			break
+		}
+	}
+
+	return apiStatuses, nil
+}
+
+// LinksGet returns up to limit currently trending
+// links, by their preview cards, skipping offset.
+func (p *Processor) LinksGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+	offset int,
+) ([]*apimodel.TrendsLink, gtserror.WithCode) {
+	trends, errWithCode := p.getTrending(ctx, gtsmodel.TrendTypeLink)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiLinks := make([]*apimodel.TrendsLink, 0, limit)
+	for _, trend := range trends {
+		if offset > 0 {
+			// Skip
+			// to offset.
+			offset--
+			continue
+		}
+
+		apiLink, err := p.converter.TrendToAPITrendsLink(ctx, trend)
+		if err != nil {
+			log.Errorf(ctx, "error converting trend %s: %v", trend.ID, err)
+			continue
+		}
+
+		apiLinks = append(apiLinks, apiLink)
+		if len(apiLinks) == limit {
+			break
+		}
+	}
+
+	return apiLinks, nil
+}
+
+func (p *Processor) getTrending(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+) ([]*gtsmodel.Trend, gtserror.WithCode) {
+	trends, err := p.state.DB.GetTrendsByType(ctx, trendType)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting %s trends: %w", trendType.String(), err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	trending := make([]*gtsmodel.Trend, 0, len(trends))
+	for _, trend := range trends {
+		if trend.Trending() {
+			trending = append(trending, trend)
+		}
+	}
+
+	return trending, nil
+}
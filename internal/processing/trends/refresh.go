@@ -0,0 +1,403 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// day is the length of one
+	// day of trend history.
+	day = 24 * time.Hour
+
+	// historyDays is the number of days of usage
+	// history used to calculate hashtag + link trends.
+	historyDays = 7
+
+	// tagMinScore is the minimum time-decayed
+	// score a hashtag needs in order to trend,
+	// roughly equivalent to distinct accounts
+	// using it today.
+	tagMinScore = 2.0
+
+	// linkMinScore is the minimum time-decayed
+	// score a link needs in order to trend,
+	// roughly equivalent to distinct accounts
+	// sharing it today.
+	linkMinScore = 2.0
+
+	// statusWindow is the period over which
+	// boosts + faves of statuses are counted.
+	statusWindow = 2 * day
+
+	// statusMaxAge is the maximum age of
+	// a status for it to be able to trend.
+	statusMaxAge = 7 * day
+
+	// statusHalfLife is the age at which
+	// a status' trend score is halved.
+	statusHalfLife = 12 * time.Hour
+
+	// statusMinInteractions is the minimum number
+	// of boosts + faves within statusWindow that
+	// a status needs in order to trend.
+	statusMinInteractions = 2
+
+	// maxTrends is the maximum number of
+	// trends of each type kept per refresh.
+	maxTrends = 50
+)
+
+// seenKey is a targetID+day+accountID
+// combo for counting tag / link users.
+type seenKey struct {
+	targetID  string
+	day       int
+	accountID string
+}
+
+// candidate is a
+// possible trend.
+type candidate struct {
+	targetID string
+	score    float64
+	history  []gtsmodel.TrendHistory
+}
+
+// ScheduleRefresh schedules recalculation of trends to run
+// periodically, according to the configured refresh period.
+func (p *Processor) ScheduleRefresh() error {
+	every := config.GetInstanceTrendsRefreshEvery()
+	if every <= 0 {
+		log.Info(nil, "trends refresh disabled")
+		return nil
+	}
+
+	// Give the instance a minute
+	// to settle before first run.
+	firstRunAt := time.Now().Add(time.Minute)
+
+	log.Infof(nil,
+		"scheduling trends to be refreshed every %s, starting at %s",
+		every, firstRunAt,
+	)
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@trendsrefresh",
+		firstRunAt,
+		every,
+		func(ctx context.Context, now time.Time) {
+			p.Refresh(ctx, now)
+		},
+	) {
+		return gtserror.New("failed to schedule @trendsrefresh")
+	}
+
+	return nil
+}
+
+// Refresh recalculates hashtag, status and link trends as of
+// the given time, storing the results in the database.
+func (p *Processor) Refresh(ctx context.Context, now time.Time) {
+	l := log.WithContext(ctx)
+	l.Info("start refreshing trends")
+	defer l.Info("finished refreshing trends")
+
+	if err := p.refreshTagTrends(ctx, now); err != nil {
+		l.Errorf("error refreshing tag trends: %v", err)
+	}
+
+	if err := p.refreshStatusTrends(ctx, now); err != nil {
+		l.Errorf("error refreshing status trends: %v", err)
+	}
+
+	if err := p.refreshLinkTrends(ctx, now); err != nil {
+		l.Errorf("error refreshing link trends: %v", err)
+	}
+}
+
+func (p *Processor) refreshTagTrends(ctx context.Context, now time.Time) error {
+	// Start of today, and of first day of history.
+	today := now.UTC().Truncate(day)
+	since := today.Add(-(historyDays - 1) * day)
+
+	uses, err := p.state.DB.GetTagUsesSince(ctx, since)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting tag uses: %w", err)
+	}
+
+	candidates := usageCandidates(today, uses, tagMinScore)
+
+	// Tags which aren't useable + listable
+	// on this instance should never trend.
+	candidates, err = p.filterTagCandidates(ctx, candidates)
+	if err != nil {
+		return err
+	}
+
+	return p.storeTrends(ctx, gtsmodel.TrendTypeTag, candidates)
+}
+
+func (p *Processor) refreshLinkTrends(ctx context.Context, now time.Time) error {
+	// Start of today, and of first day of history.
+	today := now.UTC().Truncate(day)
+	since := today.Add(-(historyDays - 1) * day)
+
+	uses, err := p.state.DB.GetLinkUsesSince(ctx, since)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting link uses: %w", err)
+	}
+
+	candidates := usageCandidates(today, uses, linkMinScore)
+
+	return p.storeTrends(ctx, gtsmodel.TrendTypeLink, candidates)
+}
+
+// usageCandidates builds per-day usage history of each
+// target of the given uses, going back historyDays from
+// today, returning those with a high enough score.
+func usageCandidates(today time.Time, uses []*gtsmodel.TrendUse, minScore float64) []candidate {
+	var (
+		// Usage history per target ID.
+		histories = make(map[string][]gtsmodel.TrendHistory)
+
+		// Seen targetID+day+accountID combos,
+		// to count distinct accounts per day.
+		seen = make(map[seenKey]struct{})
+	)
+
+	for _, use := range uses {
+		d := int(today.Sub(use.CreatedAt.UTC().Truncate(day)) / day)
+		if d < 0 || d >= historyDays {
+			// Outside
+			// history.
+			continue
+		}
+
+		history, ok := histories[use.TargetID]
+		if !ok {
+			history = make([]gtsmodel.TrendHistory, historyDays)
+			for i := range history {
+				history[i].Day = today.Add(-time.Duration(i) * day)
+			}
+			histories[use.TargetID] = history
+		}
+
+		history[d].Uses++
+
+		key := seenKey{use.TargetID, d, use.AccountID}
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			history[d].Accounts++
+		}
+	}
+
+	candidates := make([]candidate, 0, len(histories))
+	for targetID, history := range histories {
+		var score float64
+		for d, h := range history {
+			// Halve the weight of
+			// each day going back.
+			score += float64(h.Accounts) * math.Pow(0.5, float64(d))
+		}
+
+		if score < minScore {
+			continue
+		}
+
+		candidates = append(candidates, candidate{
+			targetID: targetID,
+			score:    score,
+			history:  history,
+		})
+	}
+
+	return candidates
+}
+
+func (p *Processor) filterTagCandidates(ctx context.Context, candidates []candidate) ([]candidate, error) {
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	tagIDs := make([]string, len(candidates))
+	for i, c := range candidates {
+		tagIDs[i] = c.targetID
+	}
+
+	tags, err := p.state.DB.GetTags(ctx, tagIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting tags: %w", err)
+	}
+
+	return slices.DeleteFunc(candidates, func(c candidate) bool {
+		i := slices.IndexFunc(tags, func(tag *gtsmodel.Tag) bool {
+			return tag.ID == c.targetID
+		})
+		return i < 0 || !*tags[i].Useable || !*tags[i].Listable
+	}), nil
+}
+
+func (p *Processor) refreshStatusTrends(ctx context.Context, now time.Time) error {
+	counts, err := p.state.DB.GetStatusInteractionCountsSince(ctx, now.Add(-statusWindow))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting status interaction counts: %w", err)
+	}
+
+	statusIDs := make([]string, 0, len(counts))
+	for statusID, count := range counts {
+		if count < statusMinInteractions {
+			// Not enough
+			// interactions.
+			continue
+		}
+		statusIDs = append(statusIDs, statusID)
+	}
+
+	statuses, err := p.state.DB.GetStatusesByIDs(gtscontext.SetBarebones(ctx), statusIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting statuses: %w", err)
+	}
+
+	candidates := make([]candidate, 0, len(statuses))
+	for _, status := range statuses {
+		if status.Visibility != gtsmodel.VisibilityPublic ||
+			status.BoostOfID != "" ||
+			status.ContentWarning != "" ||
+			*status.Sensitive {
+			// Only original public statuses
+			// without content warnings trend.
+			continue
+		}
+
+		age := now.Sub(status.CreatedAt)
+		if age > statusMaxAge {
+			continue
+		}
+
+		// Halve the score for each half life elapsed.
+		score := float64(counts[status.ID]) * math.Pow(0.5, float64(age)/float64(statusHalfLife))
+
+		candidates = append(candidates, candidate{
+			targetID: status.ID,
+			score:    score,
+		})
+	}
+
+	return p.storeTrends(ctx, gtsmodel.TrendTypeStatus, candidates)
+}
+
+// storeTrends stores the top-scoring of the given candidates
+// as trends of the given type, updating existing trends where
+// possible, so as to retain admin review state. Trends that are
+// no longer candidates have their score reset if they've been
+// reviewed by an admin, or are removed if they're still pending.
+func (p *Processor) storeTrends(ctx context.Context, trendType gtsmodel.TrendType, candidates []candidate) error {
+	// Keep only the highest scoring candidates.
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		switch {
+		case a.score > b.score:
+			return -1
+		case a.score < b.score:
+			return 1
+		default:
+			return 0
+		}
+	})
+	if len(candidates) > maxTrends {
+		candidates = candidates[:maxTrends]
+	}
+
+	existing, err := p.state.DB.GetTrendsByType(gtscontext.SetBarebones(ctx), trendType)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting existing %s trends: %w", trendType.String(), err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, c := range candidates {
+		i := slices.IndexFunc(existing, func(trend *gtsmodel.Trend) bool {
+			return trend.TargetID == c.targetID
+		})
+
+		if i < 0 {
+			// New trend, store pending review.
+			if err := p.state.DB.PutTrend(ctx, &gtsmodel.Trend{
+				ID:       id.NewULID(),
+				Type:     trendType,
+				TargetID: c.targetID,
+				Score:    c.score,
+				History:  c.history,
+				Review:   gtsmodel.TrendReviewPending,
+			}); err != nil {
+				errs.Appendf("db error putting trend: %w", err)
+			}
+			continue
+		}
+
+		// Existing trend, update score.
+		trend := existing[i]
+		trend.Score = c.score
+		trend.History = c.history
+		if err := p.state.DB.UpdateTrend(ctx, trend, "score", "history"); err != nil {
+			errs.Appendf("db error updating trend %s: %w", trend.ID, err)
+		}
+
+		// Mark as handled.
+		existing = slices.Delete(existing, i, i+1)
+	}
+
+	// Remaining existing trends are no longer trending.
+	for _, trend := range existing {
+		if trend.Review == gtsmodel.TrendReviewPending {
+			// Never reviewed, just drop it.
+			if err := p.state.DB.DeleteTrendByID(ctx, trend.ID); err != nil {
+				errs.Appendf("db error deleting trend %s: %w", trend.ID, err)
+			}
+			continue
+		}
+
+		if trend.Score == 0 {
+			// Already reset.
+			continue
+		}
+
+		// Keep reviewed trend so review state sticks
+		// if it trends again, but reset its score.
+		trend.Score = 0
+		trend.History = nil
+		if err := p.state.DB.UpdateTrend(ctx, trend, "score", "history"); err != nil {
+			errs.Appendf("db error updating trend %s: %w", trend.ID, err)
+		}
+	}
+
+	return errs.Combine()
+}
@@ -0,0 +1,228 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/trends"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TrendsTestSuite struct {
+	suite.Suite
+	state  state.State
+	trends trends.Processor
+
+	testAccounts map[string]*gtsmodel.Account
+	testTags     map[string]*gtsmodel.Tag
+}
+
+func (suite *TrendsTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+	testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+	converter := typeutils.NewConverter(&suite.state)
+	filter := visibility.NewFilter(&suite.state)
+	suite.trends = trends.New(&suite.state, converter, filter)
+
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testTags = testrig.NewTestTags()
+}
+
+func (suite *TrendsTestSuite) TearDownTest() {
+	testrig.StopWorkers(&suite.state)
+	testrig.StandardDBTeardown(suite.state.DB)
+}
+
+// putStatus stores a new public status
+// by the given account, using the given tags.
+func (suite *TrendsTestSuite) putStatus(ctx context.Context, account *gtsmodel.Account, tagIDs ...string) *gtsmodel.Status {
+	statusID := id.NewULID()
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 account.URI + "/statuses/" + statusID,
+		URL:                 account.URL + "/statuses/" + statusID,
+		Content:             "trendy!",
+		Text:                "trendy!",
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		TagIDs:              tagIDs,
+		Local:               util.Ptr(true),
+		Visibility:          gtsmodel.VisibilityPublic,
+		Sensitive:           util.Ptr(false),
+		Federated:           util.Ptr(true),
+		Boostable:           util.Ptr(true),
+		Replyable:           util.Ptr(true),
+		Likeable:            util.Ptr(true),
+		ActivityStreamsType: "Note",
+	}
+	if err := suite.state.DB.PutStatus(ctx, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return status
+}
+
+func (suite *TrendsTestSuite) TestRefresh() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+		tag       = suite.testTags["welcome"]
+	)
+
+	// Two accounts use the tag today, that's enough to trend.
+	status := suite.putStatus(ctx, suite.testAccounts["local_account_2"], tag.ID)
+	status2 := suite.putStatus(ctx, suite.testAccounts["admin_account"], tag.ID)
+
+	// Both statuses share the same link, that's enough to trend too.
+	card := &gtsmodel.Card{
+		ID:    id.NewULID(),
+		URL:   "https://example.org/some/article",
+		Title: "Some Article",
+		Type:  gtsmodel.CardTypeLink,
+	}
+	if err := suite.state.DB.PutCard(ctx, card); err != nil {
+		suite.FailNow(err.Error())
+	}
+	for _, s := range []*gtsmodel.Status{status, status2} {
+		s.CardID = card.ID
+		if err := suite.state.DB.UpdateStatus(ctx, s, "card_id"); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Two accounts fave the first status, that's enough to trend.
+	for _, faver := range []*gtsmodel.Account{
+		suite.testAccounts["local_account_1"],
+		suite.testAccounts["admin_account"],
+	} {
+		if err := suite.state.DB.PutStatusFave(ctx, &gtsmodel.StatusFave{
+			ID:              id.NewULID(),
+			AccountID:       faver.ID,
+			TargetAccountID: status.AccountID,
+			StatusID:        status.ID,
+			URI:             faver.URI + "/faves/" + id.NewULID(),
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	suite.trends.Refresh(ctx, time.Now())
+
+	tagTrends, err := suite.state.DB.GetTrendsByType(ctx, gtsmodel.TrendTypeTag)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(tagTrends, 1)
+	suite.Equal(tag.ID, tagTrends[0].TargetID)
+	suite.Equal(gtsmodel.TrendReviewPending, tagTrends[0].Review)
+	suite.Equal(2, tagTrends[0].History[0].Accounts)
+
+	statusTrends, err := suite.state.DB.GetTrendsByType(ctx, gtsmodel.TrendTypeStatus)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(statusTrends, 1)
+	suite.Equal(status.ID, statusTrends[0].TargetID)
+
+	linkTrends, err := suite.state.DB.GetTrendsByType(ctx, gtsmodel.TrendTypeLink)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(linkTrends, 1)
+	suite.Equal(card.ID, linkTrends[0].TargetID)
+	suite.Equal(2, linkTrends[0].History[0].Accounts)
+
+	// Pending trends aren't shown.
+	apiTags, errWithCode := suite.trends.TagsGet(ctx, requester, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Empty(apiTags)
+
+	apiStatuses, errWithCode := suite.trends.StatusesGet(ctx, requester, 20, 0)
+	suite.NoError(errWithCode)
+	suite.Empty(apiStatuses)
+
+	apiLinks, errWithCode := suite.trends.LinksGet(ctx, requester, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Empty(apiLinks)
+
+	// Approve all trends.
+	for _, trend := range []*gtsmodel.Trend{tagTrends[0], statusTrends[0], linkTrends[0]} {
+		trend.Review = gtsmodel.TrendReviewApproved
+		trend.ReviewedAt = time.Now()
+		if err := suite.state.DB.UpdateTrend(ctx, trend, "review", "reviewed_at"); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	apiTags, errWithCode = suite.trends.TagsGet(ctx, requester, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Len(apiTags, 1)
+	suite.Equal("welcome", apiTags[0].Name)
+	suite.NotNil(apiTags[0].History)
+
+	apiStatuses, errWithCode = suite.trends.StatusesGet(ctx, requester, 20, 0)
+	suite.NoError(errWithCode)
+	suite.Len(apiStatuses, 1)
+	suite.Equal(status.ID, apiStatuses[0].ID)
+
+	// Offset past the end.
+	apiStatuses, errWithCode = suite.trends.StatusesGet(ctx, requester, 20, 1)
+	suite.NoError(errWithCode)
+	suite.Empty(apiStatuses)
+
+	apiLinks, errWithCode = suite.trends.LinksGet(ctx, requester, 10, 0)
+	suite.NoError(errWithCode)
+	if suite.Len(apiLinks, 1) {
+		suite.Equal(card.URL, apiLinks[0].URL)
+		suite.Equal("Some Article", apiLinks[0].Title)
+		suite.Len(apiLinks[0].History, 7)
+	}
+
+	// Refreshing well into the future drops the
+	// trends, but keeps their approved review state.
+	suite.trends.Refresh(ctx, time.Now().Add(30*24*time.Hour))
+
+	tagTrends, err = suite.state.DB.GetTrendsByType(ctx, gtsmodel.TrendTypeTag)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(tagTrends, 1)
+	suite.Zero(tagTrends[0].Score)
+	suite.Equal(gtsmodel.TrendReviewApproved, tagTrends[0].Review)
+
+	apiTags, errWithCode = suite.trends.TagsGet(ctx, requester, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Empty(apiTags)
+}
+
+func TestTrendsTestSuite(t *testing.T) {
+	suite.Run(t, new(TrendsTestSuite))
+}
@@ -23,9 +23,16 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+	"golang.org/x/crypto/bcrypt"
 )
 
 var oneWeek = 168 * time.Hour
@@ -80,3 +87,140 @@ func (p *Processor) EmailConfirm(ctx context.Context, token string) (*gtsmodel.U
 
 	return user, nil
 }
+
+// EmailChange processes an email address change request for the given user.
+// The new address is stored as the user's unconfirmed email address, and
+// a confirmation email is sent to it; the change only takes effect once the
+// user has confirmed the new address via EmailConfirm.
+func (p *Processor) EmailChange(
+	ctx context.Context,
+	user *gtsmodel.User,
+	password string,
+	newEmail string,
+) (*apimodel.User, gtserror.WithCode) {
+	// Ensure provided password is the correct current password.
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		err := gtserror.Newf("%w", err)
+		return nil, gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	// Ensure new email address is valid.
+	if err := validate.Email(newEmail); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Ensure new email address is different from current one.
+	if newEmail == user.Email {
+		const help = "new email address cannot be the same as current email address"
+		err := gtserror.New(help)
+		return nil, gtserror.NewErrorBadRequest(err, help)
+	}
+
+	// Ensure the new email domain is allowed.
+	if errWithCode := p.c.CheckEmailDomain(ctx, newEmail); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if newEmail != user.UnconfirmedEmail {
+		// Ensure the new email address isn't
+		// already in use by (another) user.
+		emailAvailable, err := p.state.DB.IsEmailAvailable(ctx, newEmail)
+		if err != nil {
+			err := gtserror.Newf("db error checking email availability: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if !emailAvailable {
+			const help = "new email address is already in use"
+			err := gtserror.Newf("email address %s is not available", newEmail)
+			return nil, gtserror.NewErrorConflict(err, help)
+		}
+	}
+
+	// Set new email address as
+	// unconfirmed email address.
+	user.UnconfirmedEmail = newEmail
+	if err := p.state.DB.UpdateUser(ctx, user, "unconfirmed_email"); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if user.Account == nil {
+		var err error
+		user.Account, err = p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			err := gtserror.Newf("db error getting account for user: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Ask the user to confirm their new email address.
+	if err := p.EmailPleaseConfirm(ctx, user, user.Account.Username); err != nil {
+		err := gtserror.Newf("error sending confirmation email: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.User{
+		Email:            user.Email,
+		UnconfirmedEmail: user.UnconfirmedEmail,
+	}, nil
+}
+
+// EmailPleaseConfirm sends a 'please confirm your email'
+// style email to the unconfirmed address of the given
+// user, and stores the confirmation token on the user.
+// This is done both on sign-up, and on email change.
+func (p *Processor) EmailPleaseConfirm(ctx context.Context, user *gtsmodel.User, username string) error {
+	if user.UnconfirmedEmail == "" ||
+		user.UnconfirmedEmail == user.Email {
+		// User has already confirmed this
+		// email address; nothing to do.
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	// We need a token and a link for the
+	// user to click on. We'll use a uuid
+	// as our token since it's secure enough
+	// for this purpose.
+	var (
+		confirmToken = uuid.NewString()
+		confirmLink  = uris.GenerateURIForEmailConfirm(confirmToken)
+	)
+
+	// Assemble email contents and send the email.
+	if err := p.emailSender.SendConfirmEmail(
+		user.UnconfirmedEmail,
+		email.ConfirmData{
+			Username:     username,
+			InstanceURL:  instance.URI,
+			InstanceName: instance.Title,
+			ConfirmLink:  confirmLink,
+		},
+	); err != nil {
+		return err
+	}
+
+	// Email sent, update the user entry
+	// with the new confirmation token.
+	now := time.Now()
+	user.ConfirmationToken = confirmToken
+	user.ConfirmationSentAt = now
+	user.LastEmailedAt = now
+
+	if err := p.state.DB.UpdateUser(
+		ctx,
+		user,
+		"confirmation_token",
+		"confirmation_sent_at",
+		"last_emailed_at",
+	); err != nil {
+		return gtserror.Newf("error updating user entry after email sent: %w", err)
+	}
+
+	return nil
+}
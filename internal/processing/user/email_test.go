@@ -19,10 +19,13 @@ package user_test
 
 import (
 	"context"
+	"net"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type EmailConfirmTestSuite struct {
@@ -79,6 +82,80 @@ func (suite *EmailConfirmTestSuite) TestConfirmEmailOldToken() {
 	suite.EqualError(errWithCode, "ConfirmEmail: confirmation token expired")
 }
 
+func (suite *EmailConfirmTestSuite) TestEmailChange() {
+	ctx := context.Background()
+
+	user := suite.testUsers["local_account_1"]
+
+	apiUser, errWithCode := suite.user.EmailChange(ctx, user, "password", "new.email@example.org")
+	suite.NoError(errWithCode)
+
+	// Email should be unchanged until
+	// the new address is confirmed.
+	suite.Equal(user.Email, apiUser.Email)
+	suite.Equal("new.email@example.org", apiUser.UnconfirmedEmail)
+
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(user.Email, dbUser.Email)
+	suite.Equal("new.email@example.org", dbUser.UnconfirmedEmail)
+	suite.NotEmpty(dbUser.ConfirmationToken)
+	suite.WithinDuration(time.Now(), dbUser.ConfirmationSentAt, 1*time.Minute)
+
+	// A confirmation email should have
+	// been sent to the new address.
+	suite.Contains(suite.sentEmails, "new.email@example.org")
+
+	// Confirming should now
+	// switch over the address.
+	confirmed, errWithCode := suite.user.EmailConfirm(ctx, dbUser.ConfirmationToken)
+	suite.NoError(errWithCode)
+	suite.Equal("new.email@example.org", confirmed.Email)
+	suite.Empty(confirmed.UnconfirmedEmail)
+}
+
+func (suite *EmailConfirmTestSuite) TestEmailChangeErrors() {
+	ctx := context.Background()
+
+	user := suite.testUsers["local_account_1"]
+
+	if err := suite.db.PutEmailDomainBlock(ctx, &gtsmodel.EmailDomainBlock{
+		ID:                 "01HT0QFS0W7H5YB3A7G16F4CKX",
+		Domain:             "blocked.example",
+		CreatedByAccountID: suite.testUsers["admin_account"].AccountID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Don't look up real MX records.
+	suite.common.SetMXResolver(noMXResolver{})
+
+	for _, test := range []struct {
+		password string
+		newEmail string
+		code     int
+	}{
+		{"wrong password", "new.email@example.org", http.StatusUnauthorized},
+		{"password", "not an email address", http.StatusBadRequest},
+		{"password", user.Email, http.StatusBadRequest},
+		{"password", suite.testUsers["admin_account"].Email, http.StatusConflict},
+		{"password", "someone@mail.blocked.example", http.StatusUnprocessableEntity},
+	} {
+		_, errWithCode := suite.user.EmailChange(ctx, user, test.password, test.newEmail)
+		if suite.NotNil(errWithCode, test.newEmail) {
+			suite.Equal(test.code, errWithCode.Code(), test.newEmail)
+		}
+	}
+}
+
+type noMXResolver struct{}
+
+func (noMXResolver) LookupMX(context.Context, string) ([]*net.MX, error) {
+	return nil, nil
+}
+
 func TestEmailConfirmTestSuite(t *testing.T) {
 	suite.Run(t, &EmailConfirmTestSuite{})
 }
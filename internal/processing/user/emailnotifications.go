@@ -0,0 +1,154 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// EmailNotificationsGet returns the
+// email notification settings of the given user.
+func (p *Processor) EmailNotificationsGet(user *gtsmodel.User) *apimodel.EmailNotifications {
+	digest := string(user.EmailDigest)
+	if user.EmailDigest == gtsmodel.EmailDigestNever {
+		digest = "never"
+	}
+
+	return &apimodel.EmailNotifications{
+		Follow:        util.PtrValueOr(user.EmailNotifyFollow, false),
+		FollowRequest: util.PtrValueOr(user.EmailNotifyFollowRequest, false),
+		Mention:       util.PtrValueOr(user.EmailNotifyMention, false),
+		Direct:        util.PtrValueOr(user.EmailNotifyDirect, false),
+		Report:        util.PtrValueOr(user.EmailNotifyReport, true),
+		Digest:        digest,
+	}
+}
+
+// EmailNotificationsUpdate updates the email notification settings
+// of the given user, only changing the settings present in form.
+func (p *Processor) EmailNotificationsUpdate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	form *apimodel.EmailNotificationsUpdateRequest,
+) (*apimodel.EmailNotifications, gtserror.WithCode) {
+	var columns []string
+
+	for _, setting := range []struct {
+		value  *bool
+		field  **bool
+		column string
+	}{
+		{form.Follow, &user.EmailNotifyFollow, "email_notify_follow"},
+		{form.FollowRequest, &user.EmailNotifyFollowRequest, "email_notify_follow_request"},
+		{form.Mention, &user.EmailNotifyMention, "email_notify_mention"},
+		{form.Direct, &user.EmailNotifyDirect, "email_notify_direct"},
+		{form.Report, &user.EmailNotifyReport, "email_notify_report"},
+	} {
+		if setting.value != nil {
+			*setting.field = util.Ptr(*setting.value)
+			columns = append(columns, setting.column)
+		}
+	}
+
+	if form.Digest != nil {
+		var digest gtsmodel.EmailDigest
+		switch *form.Digest {
+		case "never", "":
+			digest = gtsmodel.EmailDigestNever
+		case "daily":
+			digest = gtsmodel.EmailDigestDaily
+		case "weekly":
+			digest = gtsmodel.EmailDigestWeekly
+		default:
+			const help = "digest must be one of: never, daily, weekly"
+			err := fmt.Errorf("invalid digest %q", *form.Digest)
+			return nil, gtserror.NewErrorBadRequest(err, help)
+		}
+
+		if digest != user.EmailDigest {
+			// Start the new period from now, so enabling
+			// a digest doesn't resend old notifications.
+			user.EmailDigest = digest
+			user.EmailDigestSentAt = time.Now()
+			columns = append(columns, "email_digest", "email_digest_sent_at")
+		}
+	}
+
+	if len(columns) == 0 {
+		// Nothing to do.
+		return p.EmailNotificationsGet(user), nil
+	}
+
+	if err := p.state.DB.UpdateUser(ctx, user, columns...); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.EmailNotificationsGet(user), nil
+}
+
+// EmailUnsubscribe processes a one-click unsubscribe request, usually
+// initiated as a result of clicking on the link in a notification email
+// (or an RFC 8058 List-Unsubscribe POST from a mail client). All email
+// notifications and digests are turned off for the user with the token.
+func (p *Processor) EmailUnsubscribe(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode) {
+	if token == "" {
+		return nil, gtserror.NewErrorNotFound(errors.New("no token provided"))
+	}
+
+	user, err := p.state.DB.GetUserByEmailUnsubscribeToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err := gtserror.Newf("db error getting user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	user.EmailNotifyFollow = util.Ptr(false)
+	user.EmailNotifyFollowRequest = util.Ptr(false)
+	user.EmailNotifyMention = util.Ptr(false)
+	user.EmailNotifyDirect = util.Ptr(false)
+	user.EmailNotifyReport = util.Ptr(false)
+	user.EmailDigest = gtsmodel.EmailDigestNever
+
+	if err := p.state.DB.UpdateUser(
+		ctx,
+		user,
+		"email_notify_follow",
+		"email_notify_follow_request",
+		"email_notify_mention",
+		"email_notify_direct",
+		"email_notify_report",
+		"email_digest",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,119 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type EmailNotificationsTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *EmailNotificationsTestSuite) TestEmailNotificationsGetDefaults() {
+	user := suite.testUsers["local_account_1"]
+
+	settings := suite.user.EmailNotificationsGet(user)
+	suite.Equal(&apimodel.EmailNotifications{
+		Report: true,
+		Digest: "never",
+	}, settings)
+}
+
+func (suite *EmailNotificationsTestSuite) TestEmailNotificationsUpdate() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	settings, errWithCode := suite.user.EmailNotificationsUpdate(ctx, user, &apimodel.EmailNotificationsUpdateRequest{
+		Mention: util.Ptr(true),
+		Direct:  util.Ptr(true),
+		Digest:  util.Ptr("weekly"),
+	})
+	suite.NoError(errWithCode)
+	suite.Equal(&apimodel.EmailNotifications{
+		Mention: true,
+		Direct:  true,
+		Report:  true,
+		Digest:  "weekly",
+	}, settings)
+
+	// Settings should be stored.
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.True(*dbUser.EmailNotifyMention)
+	suite.True(*dbUser.EmailNotifyDirect)
+	suite.Equal(gtsmodel.EmailDigestWeekly, dbUser.EmailDigest)
+	suite.False(dbUser.EmailDigestSentAt.IsZero())
+}
+
+func (suite *EmailNotificationsTestSuite) TestEmailNotificationsUpdateInvalidDigest() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	_, errWithCode := suite.user.EmailNotificationsUpdate(ctx, user, &apimodel.EmailNotificationsUpdateRequest{
+		Digest: util.Ptr("hourly"),
+	})
+	suite.EqualError(errWithCode, `invalid digest "hourly"`)
+	suite.Equal("Bad Request: digest must be one of: never, daily, weekly", errWithCode.Safe())
+}
+
+func (suite *EmailNotificationsTestSuite) TestEmailUnsubscribe() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	// Opt in to everything, as though
+	// notification emails have been sent.
+	user.EmailNotifyFollow = util.Ptr(true)
+	user.EmailNotifyMention = util.Ptr(true)
+	user.EmailDigest = gtsmodel.EmailDigestDaily
+	user.EmailUnsubscribeToken = "0d6f3e79-5b93-4e3c-8a0b-6b4b6a1c2f6e"
+	err := suite.db.UpdateUser(ctx, user,
+		"email_notify_follow",
+		"email_notify_mention",
+		"email_digest",
+		"email_unsubscribe_token",
+	)
+	suite.NoError(err)
+
+	unsubscribed, errWithCode := suite.user.EmailUnsubscribe(ctx, "0d6f3e79-5b93-4e3c-8a0b-6b4b6a1c2f6e")
+	suite.NoError(errWithCode)
+	suite.Equal(user.ID, unsubscribed.ID)
+
+	// All emails should be turned off.
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.Equal(&apimodel.EmailNotifications{
+		Digest: "never",
+	}, suite.user.EmailNotificationsGet(dbUser))
+}
+
+func (suite *EmailNotificationsTestSuite) TestEmailUnsubscribeUnknownToken() {
+	_, errWithCode := suite.user.EmailUnsubscribe(context.Background(), "0d6f3e79-5b93-4e3c-8a0b-6b4b6a1c2f6e")
+	suite.Error(errWithCode)
+	suite.Equal(404, errWithCode.Code())
+}
+
+func TestEmailNotificationsTestSuite(t *testing.T) {
+	suite.Run(t, new(EmailNotificationsTestSuite))
+}
@@ -0,0 +1,461 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// tokenUsageFlushEvery is how often recorded
+// token usage is written to the database.
+const tokenUsageFlushEvery = 5 * time.Minute
+
+// tokenUsage batches up the last used times of
+// access tokens in memory, so that the database
+// doesn't need to be written to on every request.
+type tokenUsage struct {
+	mu   sync.Mutex
+	used map[string]time.Time // access token -> last used
+}
+
+// TokenUsed records that the given access token was just
+// used to authorize a request. The time is only stored in
+// memory, and written to the database by FlushTokenUsage.
+func (p *Processor) TokenUsed(access string) {
+	p.tokenUsage.mu.Lock()
+	p.tokenUsage.used[access] = time.Now()
+	p.tokenUsage.mu.Unlock()
+}
+
+// lastUsed returns when the given token was last
+// used, taking account of not yet flushed usage.
+func (p *Processor) lastUsed(token *gtsmodel.Token) time.Time {
+	p.tokenUsage.mu.Lock()
+	lastUsed := p.tokenUsage.used[token.Access]
+	p.tokenUsage.mu.Unlock()
+
+	if lastUsed.After(token.LastUsed) {
+		return lastUsed
+	}
+	return token.LastUsed
+}
+
+// ScheduleTokenUsageFlush schedules recorded
+// token usage to be periodically written to
+// the database, see FlushTokenUsage.
+func (p *Processor) ScheduleTokenUsageFlush() error {
+	firstRunAt := time.Now().Add(tokenUsageFlushEvery)
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@tokenusageflush",
+		firstRunAt,
+		tokenUsageFlushEvery,
+		func(ctx context.Context, _ time.Time) {
+			p.FlushTokenUsage(ctx)
+		},
+	) {
+		return gtserror.New("failed to schedule @tokenusageflush")
+	}
+
+	return nil
+}
+
+// FlushTokenUsage writes the last used times of access
+// tokens recorded by TokenUsed to the database.
+func (p *Processor) FlushTokenUsage(ctx context.Context) {
+	p.tokenUsage.mu.Lock()
+	used := p.tokenUsage.used
+	p.tokenUsage.used = make(map[string]time.Time, len(used))
+	p.tokenUsage.mu.Unlock()
+
+	for access, lastUsed := range used {
+		if err := p.state.DB.UpdateTokenLastUsed(ctx, access, lastUsed); err != nil {
+			log.Errorf(ctx, "db error updating token last used: %v", err)
+		}
+	}
+}
+
+// TokensGet returns the access tokens issued
+// to applications on behalf of the given user.
+// currentAccess is the access token used to
+// make the request, which is marked as current.
+func (p *Processor) TokensGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+	currentAccess string,
+) ([]*apimodel.TokenInfo, gtserror.WithCode) {
+	tokens, err := p.state.DB.GetAccessTokensByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTokens := make([]*apimodel.TokenInfo, 0, len(tokens))
+	for _, token := range tokens {
+		apiToken, errWithCode := p.apiToken(ctx, token, currentAccess)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiTokens = append(apiTokens, apiToken)
+	}
+
+	return apiTokens, nil
+}
+
+// TokenGet returns the access token with the
+// given ID, if it belongs to the given user.
+func (p *Processor) TokenGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+	currentAccess string,
+	id string,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, errWithCode := p.getOwnToken(ctx, user, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiToken(ctx, token, currentAccess)
+}
+
+// TokenRevoke revokes the access token with the given
+// ID, if it belongs to the given user, and returns it.
+func (p *Processor) TokenRevoke(
+	ctx context.Context,
+	user *gtsmodel.User,
+	currentAccess string,
+	id string,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, errWithCode := p.getOwnToken(ctx, user, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before deleting,
+	// as we can't afterwards.
+	apiToken, errWithCode := p.apiToken(ctx, token, currentAccess)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.deleteToken(ctx, token); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiToken, nil
+}
+
+// AuthorizedAppsGet returns the applications that the given
+// user has authorized to access their account, ie., that have
+// been issued one or more access tokens on the user's behalf.
+// Applications are ordered by most recently authorized first.
+func (p *Processor) AuthorizedAppsGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+) ([]*apimodel.AuthorizedApplication, gtserror.WithCode) {
+	tokens, err := p.state.DB.GetAccessTokensByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Group tokens by their client ID,
+	// keeping order of first appearance
+	// (tokens are sorted newest first).
+	var clientIDs []string
+	byClientID := make(map[string][]*gtsmodel.Token)
+	for _, token := range tokens {
+		if _, ok := byClientID[token.ClientID]; !ok {
+			clientIDs = append(clientIDs, token.ClientID)
+		}
+		byClientID[token.ClientID] = append(byClientID[token.ClientID], token)
+	}
+
+	apiApps := make([]*apimodel.AuthorizedApplication, 0, len(clientIDs))
+	for _, clientID := range clientIDs {
+		app, err := p.state.DB.GetApplicationByClientID(ctx, clientID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				err := gtserror.Newf("db error getting application for client %s: %w", clientID, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			// Application has been deleted,
+			// not much we can show for it.
+			log.Debugf(ctx, "no application found for client %s", clientID)
+			continue
+		}
+
+		apiApps = append(apiApps, p.apiAuthorizedApp(app, byClientID[clientID]))
+	}
+
+	return apiApps, nil
+}
+
+// AuthorizedAppRevoke revokes all access tokens that have been
+// issued to the application with the given ID on behalf of the
+// given user, and returns the application as it was before.
+func (p *Processor) AuthorizedAppRevoke(
+	ctx context.Context,
+	user *gtsmodel.User,
+	appID string,
+) (*apimodel.AuthorizedApplication, gtserror.WithCode) {
+	app, err := p.state.DB.GetApplicationByID(ctx, appID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting application %s: %w", appID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if app == nil {
+		const text = "application not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	tokens, err := p.state.DB.GetAccessTokensByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Only revoke tokens of this app.
+	tokens = slices.DeleteFunc(tokens, func(token *gtsmodel.Token) bool {
+		return token.ClientID != app.ClientID
+	})
+
+	if len(tokens) == 0 {
+		// Don't reveal whether the app exists
+		// if the user never authorized it.
+		const text = "application not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	apiApp := p.apiAuthorizedApp(app, tokens)
+
+	for _, token := range tokens {
+		if err := p.deleteToken(ctx, token); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return apiApp, nil
+}
+
+// TokenRevokeByClient revokes the given access or refresh token on
+// behalf of the oauth client with the given ID and secret, following
+// RFC 7009. tokenTypeHint may be "access_token", "refresh_token", or
+// empty. Revoking a token that doesn't exist is not an error.
+func (p *Processor) TokenRevokeByClient(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+	token string,
+	tokenTypeHint string,
+) gtserror.WithCode {
+	client := &gtsmodel.Client{}
+	if err := p.state.DB.GetByID(ctx, clientID, client); err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting client %s: %w", clientID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		const text = "client authentication failed"
+		return gtserror.NewErrorUnauthorized(oauth.InvalidClient(), text)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(client.Secret), []byte(clientSecret)) != 1 {
+		const text = "client authentication failed"
+		return gtserror.NewErrorUnauthorized(oauth.InvalidClient(), text)
+	}
+
+	// Figure out which kind of
+	// token to look for first.
+	getters := []func(context.Context, string) (*gtsmodel.Token, error){
+		p.state.DB.GetTokenByAccess,
+		p.state.DB.GetTokenByRefresh,
+	}
+
+	switch tokenTypeHint {
+	case "", "access_token":
+		// Access first.
+	case "refresh_token":
+		slices.Reverse(getters)
+	default:
+		const text = "token_type_hint must be access_token or refresh_token"
+		return gtserror.NewErrorBadRequest(oauth.UnsupportedTokenType(), text)
+	}
+
+	var dbToken *gtsmodel.Token
+	for _, get := range getters {
+		var err error
+		dbToken, err = get(ctx, token)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting token: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if dbToken != nil {
+			break
+		}
+	}
+
+	if dbToken == nil {
+		// Invalid tokens don't need
+		// revoking, see RFC 7009 2.2.
+		return nil
+	}
+
+	if dbToken.ClientID != client.ID {
+		const text = "token was not issued to this client"
+		return gtserror.NewErrorBadRequest(oauth.UnauthorizedClient(), text)
+	}
+
+	if err := p.deleteToken(ctx, dbToken); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// getOwnToken gets the access token with
+// the given ID, if it belongs to user.
+func (p *Processor) getOwnToken(
+	ctx context.Context,
+	user *gtsmodel.User,
+	id string,
+) (*gtsmodel.Token, gtserror.WithCode) {
+	token, err := p.state.DB.GetTokenByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting token %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if token == nil || token.UserID != user.ID || token.Access == "" {
+		const text = "token not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return token, nil
+}
+
+// deleteToken deletes the given token along
+// with anything that depends on it.
+func (p *Processor) deleteToken(ctx context.Context, token *gtsmodel.Token) error {
+	// Delete any web push subscription
+	// created with this token first.
+	if err := p.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, token.ID); err != nil {
+		return gtserror.Newf("db error deleting web push subscription for token %s: %w", token.ID, err)
+	}
+
+	if err := p.state.DB.DeleteTokenByID(ctx, token.ID); err != nil {
+		return gtserror.Newf("db error deleting token %s: %w", token.ID, err)
+	}
+
+	// No need to flush
+	// usage any more.
+	p.tokenUsage.mu.Lock()
+	delete(p.tokenUsage.used, token.Access)
+	p.tokenUsage.mu.Unlock()
+
+	return nil
+}
+
+func (p *Processor) apiToken(
+	ctx context.Context,
+	token *gtsmodel.Token,
+	currentAccess string,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	// Take account of unflushed
+	// usage of the token.
+	token.LastUsed = p.lastUsed(token)
+
+	apiToken, err := p.converter.TokenToAPITokenInfo(ctx, token)
+	if err != nil {
+		err := gtserror.Newf("error converting token %s: %w", token.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiToken.Current = (currentAccess != "" && token.Access == currentAccess)
+	return apiToken, nil
+}
+
+// apiAuthorizedApp summarizes the given
+// tokens issued to app as an authorized app.
+func (p *Processor) apiAuthorizedApp(
+	app *gtsmodel.Application,
+	tokens []*gtsmodel.Token,
+) *apimodel.AuthorizedApplication {
+	var (
+		scopes    []string
+		createdAt time.Time
+		lastUsed  time.Time
+	)
+
+	for _, token := range tokens {
+		for _, scope := range strings.Fields(token.Scope) {
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+
+		tokenCreatedAt := token.AccessCreateAt
+		if tokenCreatedAt.IsZero() {
+			tokenCreatedAt = token.CreatedAt
+		}
+
+		if createdAt.IsZero() || tokenCreatedAt.Before(createdAt) {
+			createdAt = tokenCreatedAt
+		}
+
+		if tokenLastUsed := p.lastUsed(token); tokenLastUsed.After(lastUsed) {
+			lastUsed = tokenLastUsed
+		}
+	}
+
+	apiApp := &apimodel.AuthorizedApplication{
+		ID:        app.ID,
+		Name:      app.Name,
+		Website:   app.Website,
+		Scopes:    scopes,
+		CreatedAt: util.FormatISO8601(createdAt),
+		Tokens:    len(tokens),
+	}
+
+	if scopes == nil {
+		apiApp.Scopes = []string{}
+	}
+
+	if !lastUsed.IsZero() {
+		apiApp.LastUsed = util.Ptr(util.FormatISO8601(lastUsed))
+	}
+
+	return apiApp
+}
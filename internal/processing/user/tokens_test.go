@@ -0,0 +1,244 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TokensTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TokensTestSuite) TestTokensGet() {
+	var (
+		ctx    = context.Background()
+		user   = suite.testUsers["local_account_1"]
+		tokens = testrig.NewTestTokens()
+		token  = tokens["local_account_1"]
+	)
+
+	apiTokens, errWithCode := suite.user.TokensGet(ctx, user, token.Access)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Authorization codes and client
+	// tokens shouldn't be included.
+	if !suite.Len(apiTokens, 1) {
+		suite.FailNow("")
+	}
+
+	apiToken := apiTokens[0]
+	suite.Equal(token.ID, apiToken.ID)
+	suite.Equal("read write follow push", apiToken.Scope)
+	suite.Equal("2022-06-10T15:22:08.000Z", apiToken.CreatedAt)
+	suite.Nil(apiToken.LastUsed)
+	suite.True(apiToken.Current)
+	suite.Equal("really cool gts application", apiToken.Application.Name)
+	suite.Equal(testrig.NewTestApplications()["application_1"].ID, apiToken.Application.ID)
+
+	// Someone else's token should not be visible.
+	_, errWithCode = suite.user.TokenGet(ctx, user, token.Access, tokens["local_account_2"].ID)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+
+	// Nor should an authorization code.
+	_, errWithCode = suite.user.TokenGet(ctx, user, token.Access, tokens["local_account_1_user_authorization_token"].ID)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+}
+
+func (suite *TokensTestSuite) TestTokenUsage() {
+	var (
+		ctx   = context.Background()
+		user  = suite.testUsers["local_account_1"]
+		token = testrig.NewTestTokens()["local_account_1"]
+	)
+
+	suite.user.TokenUsed(token.Access)
+
+	// Usage should be shown
+	// before being flushed.
+	apiToken, errWithCode := suite.user.TokenGet(ctx, user, "", token.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.NotNil(apiToken.LastUsed)
+	suite.False(apiToken.Current)
+
+	// But not yet stored.
+	dbToken, err := suite.db.GetTokenByID(ctx, token.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(dbToken.LastUsed)
+
+	suite.user.FlushTokenUsage(ctx)
+
+	dbToken, err = suite.db.GetTokenByID(ctx, token.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.WithinDuration(time.Now(), dbToken.LastUsed, time.Minute)
+
+	apps, errWithCode := suite.user.AuthorizedAppsGet(ctx, user)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if suite.Len(apps, 1) {
+		suite.NotNil(apps[0].LastUsed)
+	}
+}
+
+func (suite *TokensTestSuite) TestTokenRevoke() {
+	var (
+		ctx   = context.Background()
+		user  = suite.testUsers["local_account_1"]
+		token = testrig.NewTestTokens()["local_account_1"]
+	)
+
+	apiToken, errWithCode := suite.user.TokenRevoke(ctx, user, "", token.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(token.ID, apiToken.ID)
+
+	_, err := suite.db.GetTokenByID(ctx, token.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	apiTokens, errWithCode := suite.user.TokensGet(ctx, user, "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiTokens)
+
+	// Revoking again should 404.
+	_, errWithCode = suite.user.TokenRevoke(ctx, user, "", token.ID)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+}
+
+func (suite *TokensTestSuite) TestAuthorizedApps() {
+	var (
+		ctx  = context.Background()
+		user = suite.testUsers["local_account_1"]
+		apps = testrig.NewTestApplications()
+	)
+
+	apiApps, errWithCode := suite.user.AuthorizedAppsGet(ctx, user)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if !suite.Len(apiApps, 1) {
+		suite.FailNow("")
+	}
+
+	apiApp := apiApps[0]
+	suite.Equal(apps["application_1"].ID, apiApp.ID)
+	suite.Equal("really cool gts application", apiApp.Name)
+	suite.Equal([]string{"read", "write", "follow", "push"}, apiApp.Scopes)
+	suite.Equal("2022-06-10T15:22:08.000Z", apiApp.CreatedAt)
+	suite.Nil(apiApp.LastUsed)
+	suite.Equal(1, apiApp.Tokens)
+
+	// An app the user hasn't authorized
+	// shouldn't be revokable by them.
+	_, errWithCode = suite.user.AuthorizedAppRevoke(ctx, user, apps["application_2"].ID)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+
+	revoked, errWithCode := suite.user.AuthorizedAppRevoke(ctx, user, apiApp.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(apiApp, revoked)
+
+	apiApps, errWithCode = suite.user.AuthorizedAppsGet(ctx, user)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiApps)
+
+	// The app's client token isn't
+	// owned by the user, so remains.
+	_, err := suite.db.GetTokenByID(ctx, testrig.NewTestTokens()["local_account_1_client_application_token"].ID)
+	suite.NoError(err)
+}
+
+func (suite *TokensTestSuite) TestTokenRevokeByClient() {
+	var (
+		ctx     = context.Background()
+		tokens  = testrig.NewTestTokens()
+		clients = testrig.NewTestClients()
+		client  = clients["local_account_1"]
+	)
+
+	// Bad client secret.
+	errWithCode := suite.user.TokenRevokeByClient(ctx, client.ID, "nope", tokens["local_account_1"].Access, "")
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+		suite.EqualError(errWithCode, "invalid_client")
+	}
+
+	// Token issued to another client.
+	errWithCode = suite.user.TokenRevokeByClient(ctx, client.ID, client.Secret, tokens["local_account_2"].Access, "")
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusBadRequest, errWithCode.Code())
+		suite.EqualError(errWithCode, "unauthorized_client")
+	}
+
+	// Bad token type hint.
+	errWithCode = suite.user.TokenRevokeByClient(ctx, client.ID, client.Secret, tokens["local_account_1"].Access, "id_token")
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusBadRequest, errWithCode.Code())
+		suite.EqualError(errWithCode, "unsupported_token_type")
+	}
+
+	// Token that doesn't exist is fine.
+	errWithCode = suite.user.TokenRevokeByClient(ctx, client.ID, client.Secret, "not a real token", "refresh_token")
+	suite.Nil(errWithCode)
+
+	// Our own token.
+	errWithCode = suite.user.TokenRevokeByClient(ctx, client.ID, client.Secret, tokens["local_account_1"].Access, "")
+	suite.Nil(errWithCode)
+
+	_, err := suite.db.GetTokenByAccess(ctx, tokens["local_account_1"].Access)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	// Other client's token should still be there.
+	_, err = suite.db.GetTokenByAccess(ctx, tokens["local_account_2"].Access)
+	suite.NoError(err)
+}
+
+func TestTokensTestSuite(t *testing.T) {
+	suite.Run(t, new(TokensTestSuite))
+}
@@ -0,0 +1,274 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// recoveryCodesCount is the number of
+// recovery codes generated for a user
+// when enabling two-factor authentication.
+const recoveryCodesCount = 10
+
+// TwoFactorGet returns the two-factor
+// authentication status of the given user.
+func (p *Processor) TwoFactorGet(user *gtsmodel.User) *apimodel.TwoFactor {
+	if !user.TwoFactorEnabled() {
+		return &apimodel.TwoFactor{}
+	}
+
+	return &apimodel.TwoFactor{
+		Enabled:                true,
+		EnabledAt:              util.FormatISO8601(user.TwoFactorEnabledAt),
+		RecoveryCodesRemaining: len(user.TwoFactorBackups),
+	}
+}
+
+// TwoFactorEnroll generates a new TOTP secret for the given user, after
+// checking their current password, to be added to their authenticator app.
+// Two-factor authentication is not enabled until confirmed with a code from
+// the app, via TwoFactorEnable. Enrolling again before then replaces the
+// previously generated secret.
+func (p *Processor) TwoFactorEnroll(ctx context.Context, user *gtsmodel.User, password string) (*apimodel.TwoFactorEnrollment, gtserror.WithCode) {
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		err := gtserror.Newf("%w", err)
+		return nil, gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	if user.TwoFactorEnabled() {
+		const help = "two-factor authentication is already enabled, disable it first to enroll again"
+		return nil, gtserror.NewErrorConflict(errors.New(help), help)
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		err := gtserror.Newf("error generating secret: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	user.TwoFactorSecret = secret
+	if err := p.state.DB.UpdateUser(ctx, user, "two_factor_secret"); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    totp.URI(secret, config.GetHost(), user.Email),
+	}, nil
+}
+
+// TwoFactorEnable enables two-factor authentication for the given user,
+// after checking their current password, and the given code against the
+// secret generated on enrollment. Newly generated recovery codes are
+// returned, which will not be shown again.
+func (p *Processor) TwoFactorEnable(ctx context.Context, user *gtsmodel.User, password string, code string) (*apimodel.TwoFactorRecoveryCodes, gtserror.WithCode) {
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		err := gtserror.Newf("%w", err)
+		return nil, gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	if user.TwoFactorEnabled() {
+		const help = "two-factor authentication is already enabled"
+		return nil, gtserror.NewErrorConflict(errors.New(help), help)
+	}
+
+	if user.TwoFactorSecret == "" {
+		const help = "no two-factor secret found, enroll first to generate one"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(help), help)
+	}
+
+	step, ok := totp.ValidateAfter(
+		user.TwoFactorSecret, code, time.Now(),
+		uint64(user.TwoFactorLastCounter), // #nosec G115 -- never negative.
+	)
+	if !ok {
+		const help = "code was incorrect, check your authenticator app's clock"
+		return nil, gtserror.NewErrorBadRequest(errors.New(help), help)
+	}
+
+	// Mark the code as used, so it can't be used
+	// again, even by a concurrent request with it.
+	if err := p.state.DB.UpdateUserTwoFactorCounter(
+		ctx, user,
+		int64(step), // #nosec G115 -- won't overflow.
+	); err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			const help = "code was incorrect, check your authenticator app's clock"
+			return nil, gtserror.NewErrorBadRequest(errors.New(help), help)
+		}
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		err := gtserror.Newf("error generating recovery codes: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	user.TwoFactorEnabledAt = time.Now()
+	user.TwoFactorBackups = hashes
+	if err := p.state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_enabled_at",
+		"two_factor_backups",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.TwoFactorRecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// TwoFactorDisable disables two-factor authentication for
+// the given user, after checking their current password.
+func (p *Processor) TwoFactorDisable(ctx context.Context, user *gtsmodel.User, password string) gtserror.WithCode {
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		err := gtserror.Newf("%w", err)
+		return gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	if !user.TwoFactorEnabled() && user.TwoFactorSecret == "" {
+		// Nothing to do.
+		return nil
+	}
+
+	user.TwoFactorSecret = ""
+	user.TwoFactorEnabledAt = time.Time{}
+	user.TwoFactorBackups = nil
+	user.TwoFactorLastCounter = 0
+	if err := p.state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_secret",
+		"two_factor_enabled_at",
+		"two_factor_backups",
+		"two_factor_last_counter",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// TwoFactorCheck checks the given code from a user signing in, which
+// may be either a code from their authenticator app, or one of their
+// recovery codes. Codes from the app are only accepted once, and
+// recovery codes are removed from the user once used.
+func (p *Processor) TwoFactorCheck(ctx context.Context, user *gtsmodel.User, code string) gtserror.WithCode {
+	if !user.TwoFactorEnabled() {
+		err := gtserror.Newf("user %s does not have two-factor authentication enabled", user.ID)
+		return gtserror.NewErrorBadRequest(err)
+	}
+
+	if step, ok := totp.ValidateAfter(
+		user.TwoFactorSecret, code, time.Now(),
+		uint64(user.TwoFactorLastCounter), // #nosec G115 -- never negative.
+	); ok {
+		// Valid code from the app, mark it as used so
+		// it can't be replayed. This only succeeds for
+		// one of any concurrent requests with the code.
+		if err := p.state.DB.UpdateUserTwoFactorCounter(
+			ctx, user,
+			int64(step), // #nosec G115 -- won't overflow.
+		); err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				err := gtserror.Newf("replayed two-factor code for user %s", user.ID)
+				return gtserror.NewErrorUnauthorized(err, "two-factor code was incorrect")
+			}
+			err := gtserror.Newf("db error updating user: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+		return nil
+	}
+
+	// Check if this is one of the user's recovery codes.
+	i := slices.Index(user.TwoFactorBackups, hashRecoveryCode(code))
+	if i == -1 {
+		err := gtserror.Newf("incorrect two-factor code for user %s", user.ID)
+		return gtserror.NewErrorUnauthorized(err, "two-factor code was incorrect")
+	}
+
+	// Recovery codes are single
+	// use, so remove it now.
+	user.TwoFactorBackups = slices.Delete(
+		slices.Clone(user.TwoFactorBackups),
+		i, i+1,
+	)
+	if err := p.state.DB.UpdateUser(ctx, user, "two_factor_backups"); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// newRecoveryCodes generates a new set of recovery
+// codes, returning them along with their hashes.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodesCount)
+	hashes := make([]string, recoveryCodesCount)
+
+	for i := range codes {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+
+		// Format like "0a1b2-c3d4e"
+		// for easier transcription.
+		h := hex.EncodeToString(b)
+		codes[i] = h[:5] + "-" + h[5:]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode returns the hash of the given recovery code,
+// ignoring case, whitespace and dashes. Recovery codes are random,
+// so a fast hash is fine here (unlike with passwords).
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' || r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, code)
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,166 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+)
+
+type TwoFactorTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorEnableDisable() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	// Not enabled to start with.
+	suite.False(suite.user.TwoFactorGet(user).Enabled)
+
+	// Can't enable without enrolling first.
+	_, errWithCode := suite.user.TwoFactorEnable(ctx, user, "password", "123456")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Wrong password doesn't enroll.
+	_, errWithCode = suite.user.TwoFactorEnroll(ctx, user, "ooooopsydoooopsy")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.Empty(user.TwoFactorSecret)
+
+	enrollment, errWithCode := suite.user.TwoFactorEnroll(ctx, user, "password")
+	suite.NoError(errWithCode)
+	suite.NotEmpty(enrollment.Secret)
+	suite.Contains(enrollment.URI, "otpauth://totp/localhost:8080:zork@example.org?")
+	suite.Contains(enrollment.URI, "secret="+enrollment.Secret)
+
+	// Wrong code doesn't enable.
+	_, errWithCode = suite.user.TwoFactorEnable(ctx, user, "password", "not a code")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.False(suite.user.TwoFactorGet(user).Enabled)
+
+	code, err := totp.Code(enrollment.Secret, time.Now())
+	suite.NoError(err)
+
+	// Nor does wrong password.
+	_, errWithCode = suite.user.TwoFactorEnable(ctx, user, "ooooopsydoooopsy", code)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.False(suite.user.TwoFactorGet(user).Enabled)
+
+	recovery, errWithCode := suite.user.TwoFactorEnable(ctx, user, "password", code)
+	suite.NoError(errWithCode)
+	suite.Len(recovery.RecoveryCodes, 10)
+
+	// Check it's enabled in the db.
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.True(dbUser.TwoFactorEnabled())
+	suite.Equal(enrollment.Secret, dbUser.TwoFactorSecret)
+	suite.Len(dbUser.TwoFactorBackups, 10)
+
+	status := suite.user.TwoFactorGet(dbUser)
+	suite.True(status.Enabled)
+	suite.Equal(10, status.RecoveryCodesRemaining)
+
+	// Can't enroll again while enabled.
+	_, errWithCode = suite.user.TwoFactorEnroll(ctx, dbUser, "password")
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Wrong password doesn't disable.
+	errWithCode = suite.user.TwoFactorDisable(ctx, dbUser, "ooooopsydoooopsy")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	errWithCode = suite.user.TwoFactorDisable(ctx, dbUser, "password")
+	suite.NoError(errWithCode)
+
+	dbUser, err = suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.False(dbUser.TwoFactorEnabled())
+	suite.Empty(dbUser.TwoFactorSecret)
+	suite.Empty(dbUser.TwoFactorBackups)
+	suite.Zero(dbUser.TwoFactorLastCounter)
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorCheck() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	enrollment, errWithCode := suite.user.TwoFactorEnroll(ctx, user, "password")
+	suite.NoError(errWithCode)
+
+	code, err := totp.Code(enrollment.Secret, time.Now())
+	suite.NoError(err)
+
+	recovery, errWithCode := suite.user.TwoFactorEnable(ctx, user, "password", code)
+	suite.NoError(errWithCode)
+
+	// Code used to enable can't be replayed.
+	errWithCode = suite.user.TwoFactorCheck(ctx, user, code)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	// Next code from the app is accepted.
+	code, err = totp.Code(enrollment.Secret, time.Now().Add(totp.Period))
+	suite.NoError(err)
+	staleUser := new(gtsmodel.User)
+	*staleUser = *user
+	suite.NoError(suite.user.TwoFactorCheck(ctx, user, code))
+
+	// But only once.
+	errWithCode = suite.user.TwoFactorCheck(ctx, user, code)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	// Even by a concurrent sign in
+	// that loaded the user before.
+	errWithCode = suite.user.TwoFactorCheck(ctx, staleUser, code)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.Equal(user.TwoFactorLastCounter, dbUser.TwoFactorLastCounter)
+	suite.NotZero(dbUser.TwoFactorLastCounter)
+
+	// Incorrect code is not.
+	errWithCode = suite.user.TwoFactorCheck(ctx, user, "000000-")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.Equal("Unauthorized: two-factor code was incorrect", errWithCode.Safe())
+
+	// Recovery code is accepted, ignoring case + dashes.
+	recoveryCode := recovery.RecoveryCodes[3]
+	suite.NoError(suite.user.TwoFactorCheck(ctx, user, " "+strings.ToUpper(strings.ReplaceAll(recoveryCode, "-", ""))))
+
+	// But only once.
+	errWithCode = suite.user.TwoFactorCheck(ctx, user, recoveryCode)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	dbUser, err = suite.db.GetUserByID(ctx, user.ID)
+	suite.NoError(err)
+	suite.Len(dbUser.TwoFactorBackups, 9)
+
+	// Other recovery codes still work.
+	suite.NoError(suite.user.TwoFactorCheck(ctx, dbUser, recovery.RecoveryCodes[0]))
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, &TwoFactorTestSuite{})
+}
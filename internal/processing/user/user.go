@@ -18,19 +18,48 @@
 package user
 
 import (
+	"time"
+
 	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
 
 type Processor struct {
+	// common processor logic
+	c *common.Processor
+
 	state       *state.State
+	converter   *typeutils.Converter
 	emailSender email.Sender
+
+	// last used times of access
+	// tokens not yet flushed to db
+	tokenUsage *tokenUsage
+
+	// challenges of WebAuthn
+	// registrations in progress
+	webAuthnChallenges *webAuthnChallenges
 }
 
 // New returns a new user processor
-func New(state *state.State, emailSender email.Sender) Processor {
+func New(
+	common *common.Processor,
+	state *state.State,
+	converter *typeutils.Converter,
+	emailSender email.Sender,
+) Processor {
 	return Processor{
+		c:           common,
 		state:       state,
+		converter:   converter,
 		emailSender: emailSender,
+		tokenUsage: &tokenUsage{
+			used: make(map[string]time.Time),
+		},
+		webAuthnChallenges: &webAuthnChallenges{
+			challenges: make(map[string]webAuthnChallenge),
+		},
 	}
 }
@@ -22,8 +22,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -33,11 +36,13 @@ type UserStandardTestSuite struct {
 	db          db.DB
 	state       state.State
 
-	testUsers map[string]*gtsmodel.User
+	testUsers    map[string]*gtsmodel.User
+	testAccounts map[string]*gtsmodel.Account
 
 	sentEmails map[string]string
 
-	user user.Processor
+	common common.Processor
+	user   user.Processor
 }
 
 func (suite *UserStandardTestSuite) SetupTest() {
@@ -52,8 +57,11 @@ func (suite *UserStandardTestSuite) SetupTest() {
 	suite.sentEmails = make(map[string]string)
 	suite.emailSender = testrig.NewEmailSender("../../../web/template/", suite.sentEmails)
 	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
 
-	suite.user = user.New(&suite.state, suite.emailSender)
+	converter := typeutils.NewConverter(&suite.state)
+	suite.common = common.New(&suite.state, converter, nil, visibility.NewFilter(&suite.state))
+	suite.user = user.New(&suite.common, &suite.state, converter, suite.emailSender)
 
 	testrig.StandardDBSetup(suite.db, nil)
 }
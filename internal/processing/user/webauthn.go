@@ -0,0 +1,462 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// webAuthnCredentialsMax is the maximum number
+	// of WebAuthn credentials a user can register.
+	webAuthnCredentialsMax = 20
+
+	// webAuthnNameMaxChars is the maximum
+	// length of a WebAuthn credential name.
+	webAuthnNameMaxChars = 64
+)
+
+// webAuthnChallenges holds challenges issued to users
+// registering WebAuthn credentials, keyed by user ID,
+// until the registration ceremony is completed.
+type webAuthnChallenges struct {
+	mu         sync.Mutex
+	challenges map[string]webAuthnChallenge
+}
+
+type webAuthnChallenge struct {
+	challenge []byte
+	expires   time.Time
+}
+
+// set stores given challenge for user.
+func (c *webAuthnChallenges) set(userID string, challenge []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Drop expired challenges while we're here, so
+	// abandoned registrations don't build up forever.
+	now := time.Now()
+	for userID, challenge := range c.challenges {
+		if now.After(challenge.expires) {
+			delete(c.challenges, userID)
+		}
+	}
+
+	c.challenges[userID] = webAuthnChallenge{
+		challenge: challenge,
+		expires:   now.Add(webauthn.Timeout),
+	}
+}
+
+// pop removes and returns the unexpired
+// challenge for user, if there is one.
+func (c *webAuthnChallenges) pop(userID string) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	challenge, ok := c.challenges[userID]
+	delete(c.challenges, userID)
+
+	if !ok || time.Now().After(challenge.expires) {
+		return nil
+	}
+
+	return challenge.challenge
+}
+
+// WebAuthnRegistered returns whether the
+// user has any WebAuthn credentials registered.
+func (p *Processor) WebAuthnRegistered(ctx context.Context, user *gtsmodel.User) (bool, error) {
+	credentials, err := p.state.DB.GetWebAuthnCredentialsByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting webauthn credentials: %w", err)
+	}
+	return len(credentials) != 0, nil
+}
+
+// WebAuthnCredentialsGet returns the
+// WebAuthn credentials of the given user.
+func (p *Processor) WebAuthnCredentialsGet(ctx context.Context, user *gtsmodel.User) ([]*apimodel.WebAuthnCredential, gtserror.WithCode) {
+	credentials, err := p.state.DB.GetWebAuthnCredentialsByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting webauthn credentials: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiCredentials := make([]*apimodel.WebAuthnCredential, 0, len(credentials))
+	for _, credential := range credentials {
+		apiCredentials = append(apiCredentials, webAuthnCredentialToAPI(credential))
+	}
+
+	return apiCredentials, nil
+}
+
+// WebAuthnRegisterOptions returns options for the browser to create a new
+// WebAuthn credential for the given account / user, with navigator.credentials.create(),
+// after checking their current password. The resulting credential should then be passed
+// to WebAuthnRegister within webauthn.Timeout, after which the options' challenge is no
+// longer valid. As the challenge is only stored once the password has been checked, a
+// credential can't be registered without knowing the password.
+func (p *Processor) WebAuthnRegisterOptions(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	user *gtsmodel.User,
+	password string,
+) (*webauthn.CreationOptions, gtserror.WithCode) {
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		err := gtserror.Newf("%w", err)
+		return nil, gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	credentials, err := p.state.DB.GetWebAuthnCredentialsByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting webauthn credentials: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(credentials) >= webAuthnCredentialsMax {
+		const help = "maximum number of security keys reached, remove one first"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(help), help)
+	}
+
+	// Exclude existing credentials, so
+	// browsers don't offer registering
+	// the same authenticator again.
+	exclude := make([][]byte, 0, len(credentials))
+	for _, credential := range credentials {
+		credentialID, err := webauthn.DecodeBase64URL(credential.CredentialID)
+		if err != nil {
+			log.Warnf(ctx, "invalid webauthn credential id %s: %v", credential.CredentialID, err)
+			continue
+		}
+		exclude = append(exclude, credentialID)
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		err := gtserror.Newf("error generating challenge: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.webAuthnChallenges.set(user.ID, challenge)
+
+	displayName := account.DisplayName
+	if displayName == "" {
+		displayName = account.Username
+	}
+
+	// The user handle is stored on authenticators,
+	// so use the opaque user ID, not the email.
+	return webauthn.NewRelyingParty().CreationOptions(
+		challenge,
+		[]byte(user.ID),
+		user.Email,
+		displayName,
+		exclude,
+	), nil
+}
+
+// WebAuthnRegister verifies the credential the browser created for the
+// options from WebAuthnRegisterOptions, and stores it for the given user.
+// The challenge stored by WebAuthnRegisterOptions is required, and can
+// only be used once.
+func (p *Processor) WebAuthnRegister(
+	ctx context.Context,
+	user *gtsmodel.User,
+	form *apimodel.WebAuthnCredentialCreateRequest,
+) (*apimodel.WebAuthnCredential, gtserror.WithCode) {
+	name, errWithCode := webAuthnCredentialName(form.Name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	challenge := p.webAuthnChallenges.pop(user.ID)
+	if challenge == nil {
+		const help = "no registration in progress, or it timed out; get new registration options and try again"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(help), help)
+	}
+
+	clientDataJSON, err := webauthn.DecodeBase64URL(form.ClientDataJSON)
+	if err != nil {
+		const help = "client_data_json was not valid base64url"
+		return nil, gtserror.NewErrorBadRequest(err, help)
+	}
+
+	attestationObject, err := webauthn.DecodeBase64URL(form.AttestationObject)
+	if err != nil {
+		const help = "attestation_object was not valid base64url"
+		return nil, gtserror.NewErrorBadRequest(err, help)
+	}
+
+	verified, err := webauthn.NewRelyingParty().VerifyRegistration(
+		challenge,
+		clientDataJSON,
+		attestationObject,
+	)
+	if err != nil {
+		err := gtserror.Newf("error verifying webauthn registration: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, "security key registration could not be verified")
+	}
+
+	credential := &gtsmodel.WebAuthnCredential{
+		ID:           id.NewULID(),
+		UserID:       user.ID,
+		Name:         name,
+		CredentialID: base64.RawURLEncoding.EncodeToString(verified.ID),
+		PublicKey:    verified.PublicKey,
+		SignCount:    int64(verified.SignCount),
+	}
+
+	if err := p.state.DB.PutWebAuthnCredential(ctx, credential); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			const help = "this security key is already registered"
+			return nil, gtserror.NewErrorConflict(err, help)
+		}
+		err := gtserror.Newf("db error putting webauthn credential: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return webAuthnCredentialToAPI(credential), nil
+}
+
+// WebAuthnCredentialUpdate renames the given user's WebAuthn credential with given id.
+func (p *Processor) WebAuthnCredentialUpdate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	credentialID string,
+	form *apimodel.WebAuthnCredentialUpdateRequest,
+) (*apimodel.WebAuthnCredential, gtserror.WithCode) {
+	name, errWithCode := webAuthnCredentialName(form.Name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	credential, errWithCode := p.getWebAuthnCredential(ctx, user, credentialID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	credential.Name = name
+	if err := p.state.DB.UpdateWebAuthnCredential(ctx, credential, "name"); err != nil {
+		err := gtserror.Newf("db error updating webauthn credential: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return webAuthnCredentialToAPI(credential), nil
+}
+
+// WebAuthnCredentialDelete removes the given user's WebAuthn credential
+// with given id, after checking their current password.
+func (p *Processor) WebAuthnCredentialDelete(
+	ctx context.Context,
+	user *gtsmodel.User,
+	credentialID string,
+	password string,
+) gtserror.WithCode {
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		err := gtserror.Newf("%w", err)
+		return gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	credential, errWithCode := p.getWebAuthnCredential(ctx, user, credentialID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteWebAuthnCredentialByID(ctx, credential.ID); err != nil {
+		err := gtserror.Newf("db error deleting webauthn credential: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// WebAuthnSignInOptions returns options for the browser to get an assertion
+// from a WebAuthn credential with navigator.credentials.get(), in order to
+// sign in. If user is set, they've already entered their password, so any of
+// their credentials can be used as a second factor. Else, this is passwordless
+// sign in, so any discoverable credential (passkey) can be used.
+//
+// The options' challenge should be stored in the
+// user's session, to be passed to WebAuthnSignIn.
+func (p *Processor) WebAuthnSignInOptions(ctx context.Context, user *gtsmodel.User) (*webauthn.RequestOptions, gtserror.WithCode) {
+	var allow [][]byte
+
+	if user != nil {
+		credentials, err := p.state.DB.GetWebAuthnCredentialsByUserID(ctx, user.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting webauthn credentials: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if len(credentials) == 0 {
+			const help = "no security keys registered"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(help), help)
+		}
+
+		allow = make([][]byte, 0, len(credentials))
+		for _, credential := range credentials {
+			credentialID, err := webauthn.DecodeBase64URL(credential.CredentialID)
+			if err != nil {
+				log.Warnf(ctx, "invalid webauthn credential id %s: %v", credential.CredentialID, err)
+				continue
+			}
+			allow = append(allow, credentialID)
+		}
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		err := gtserror.Newf("error generating challenge: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return webauthn.NewRelyingParty().RequestOptions(challenge, allow), nil
+}
+
+// WebAuthnSignIn verifies the assertion the browser got for the options
+// from WebAuthnSignInOptions with given challenge, returning the user
+// the credential belongs to. If user is set, the credential must be one
+// of theirs (second factor), else the authenticator must have verified
+// the user, eg., with a PIN or biometrics (passwordless).
+func (p *Processor) WebAuthnSignIn(
+	ctx context.Context,
+	user *gtsmodel.User,
+	challenge []byte,
+	rawCredentialID []byte,
+	clientDataJSON []byte,
+	authenticatorData []byte,
+	signature []byte,
+) (*gtsmodel.User, gtserror.WithCode) {
+	const help = "security key could not be verified"
+
+	credentialID := base64.RawURLEncoding.EncodeToString(rawCredentialID)
+	credential, err := p.state.DB.GetWebAuthnCredentialByCredentialID(ctx, credentialID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("webauthn credential %s not found", credentialID)
+			return nil, gtserror.NewErrorUnauthorized(err, help)
+		}
+		err := gtserror.Newf("db error getting webauthn credential: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if user != nil && credential.UserID != user.ID {
+		err := gtserror.Newf("webauthn credential %s does not belong to user %s", credential.ID, user.ID)
+		return nil, gtserror.NewErrorUnauthorized(err, help)
+	}
+
+	signCount, err := webauthn.NewRelyingParty().VerifyAssertion(
+		challenge,
+		clientDataJSON,
+		authenticatorData,
+		signature,
+		credential.PublicKey,
+		uint32(credential.SignCount), // #nosec G115 -- stored from a uint32.
+		user == nil,
+	)
+	if err != nil {
+		err := gtserror.Newf("error verifying webauthn assertion for credential %s: %w", credential.ID, err)
+		return nil, gtserror.NewErrorUnauthorized(err, help)
+	}
+
+	credential.SignCount = int64(signCount)
+	credential.LastUsedAt = time.Now()
+	if err := p.state.DB.UpdateWebAuthnCredential(
+		ctx, credential,
+		"sign_count",
+		"last_used_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating webauthn credential: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if user != nil {
+		return user, nil
+	}
+
+	user, err = p.state.DB.GetUserByID(ctx, credential.UserID)
+	if err != nil {
+		err := gtserror.Newf("db error getting user %s: %w", credential.UserID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return user, nil
+}
+
+func (p *Processor) getWebAuthnCredential(ctx context.Context, user *gtsmodel.User, id string) (*gtsmodel.WebAuthnCredential, gtserror.WithCode) {
+	credential, err := p.state.DB.GetWebAuthnCredentialByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting webauthn credential: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if credential == nil || credential.UserID != user.ID {
+		err := gtserror.Newf("webauthn credential %s not found for user %s", id, user.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return credential, nil
+}
+
+// webAuthnCredentialName validates the given
+// credential name, returning a default if empty.
+func webAuthnCredentialName(name string) (string, gtserror.WithCode) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "Security key", nil
+	}
+
+	if utf8.RuneCountInString(name) > webAuthnNameMaxChars {
+		const help = "name must be 64 characters or less"
+		return "", gtserror.NewErrorBadRequest(errors.New(help), help)
+	}
+
+	return name, nil
+}
+
+func webAuthnCredentialToAPI(credential *gtsmodel.WebAuthnCredential) *apimodel.WebAuthnCredential {
+	apiCredential := &apimodel.WebAuthnCredential{
+		ID:        credential.ID,
+		Name:      credential.Name,
+		CreatedAt: util.FormatISO8601(credential.CreatedAt),
+	}
+
+	if !credential.LastUsedAt.IsZero() {
+		apiCredential.LastUsedAt = util.Ptr(util.FormatISO8601(credential.LastUsedAt))
+	}
+
+	return apiCredential
+}
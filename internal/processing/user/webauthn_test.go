@@ -0,0 +1,253 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type WebAuthnTestSuite struct {
+	UserStandardTestSuite
+}
+
+// register registers a new credential for local_account_1
+// with the given software authenticator, returning its id.
+func (suite *WebAuthnTestSuite) register(authenticator *testrig.SoftwareAuthenticator, name string) string {
+	var (
+		ctx     = context.Background()
+		user    = suite.testUsers["local_account_1"]
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	options, errWithCode := suite.user.WebAuthnRegisterOptions(ctx, account, user, "password")
+	suite.NoError(errWithCode)
+	suite.Equal([]byte(user.ID), []byte(options.User.ID))
+	suite.Equal("zork@example.org", options.User.Name)
+
+	attestation := authenticator.Create(options)
+
+	credential, errWithCode := suite.user.WebAuthnRegister(ctx, user, &apimodel.WebAuthnCredentialCreateRequest{
+		Name:              name,
+		ClientDataJSON:    base64.RawURLEncoding.EncodeToString(attestation.ClientDataJSON),
+		AttestationObject: base64.RawURLEncoding.EncodeToString(attestation.AttestationObject),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	return credential.ID
+}
+
+func (suite *WebAuthnTestSuite) TestRegisterManage() {
+	var (
+		ctx           = context.Background()
+		user          = suite.testUsers["local_account_1"]
+		authenticator = testrig.NewSoftwareAuthenticator()
+	)
+
+	registered, err := suite.user.WebAuthnRegistered(ctx, user)
+	suite.NoError(err)
+	suite.False(registered)
+
+	id := suite.register(authenticator, "")
+
+	registered, err = suite.user.WebAuthnRegistered(ctx, user)
+	suite.NoError(err)
+	suite.True(registered)
+
+	credentials, errWithCode := suite.user.WebAuthnCredentialsGet(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Len(credentials, 1)
+	suite.Equal(id, credentials[0].ID)
+	suite.Equal("Security key", credentials[0].Name)
+	suite.Nil(credentials[0].LastUsedAt)
+
+	// Rename it.
+	credential, errWithCode := suite.user.WebAuthnCredentialUpdate(ctx, user, id, &apimodel.WebAuthnCredentialUpdateRequest{
+		Name: "yubikey",
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("yubikey", credential.Name)
+
+	// Other users can't see it.
+	_, errWithCode = suite.user.WebAuthnCredentialUpdate(ctx, suite.testUsers["admin_account"], id, &apimodel.WebAuthnCredentialUpdateRequest{
+		Name: "mine now",
+	})
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Removing it needs the right password.
+	errWithCode = suite.user.WebAuthnCredentialDelete(ctx, user, id, "ooooopsydoooopsy")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	errWithCode = suite.user.WebAuthnCredentialDelete(ctx, user, id, "password")
+	suite.NoError(errWithCode)
+
+	credentials, errWithCode = suite.user.WebAuthnCredentialsGet(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Empty(credentials)
+}
+
+func (suite *WebAuthnTestSuite) TestRegisterWithoutOptions() {
+	user := suite.testUsers["local_account_1"]
+
+	_, errWithCode := suite.user.WebAuthnRegister(context.Background(), user, &apimodel.WebAuthnCredentialCreateRequest{
+		ClientDataJSON:    "e30",
+		AttestationObject: "oA",
+	})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *WebAuthnTestSuite) TestRegisterWrongPassword() {
+	var (
+		ctx           = context.Background()
+		user          = suite.testUsers["local_account_1"]
+		account       = suite.testAccounts["local_account_1"]
+		authenticator = testrig.NewSoftwareAuthenticator()
+	)
+
+	options, errWithCode := suite.user.WebAuthnRegisterOptions(ctx, account, user, "ooooopsydoooopsy")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.Nil(options)
+
+	// No challenge was stored, so a credential created
+	// with some other challenge can't be registered.
+	attestation := authenticator.Create(&webauthn.CreationOptions{
+		Challenge: []byte("not a stored challenge"),
+		User: webauthn.UserEntity{
+			ID:   []byte(user.ID),
+			Name: user.Email,
+		},
+	})
+
+	_, errWithCode = suite.user.WebAuthnRegister(ctx, user, &apimodel.WebAuthnCredentialCreateRequest{
+		ClientDataJSON:    base64.RawURLEncoding.EncodeToString(attestation.ClientDataJSON),
+		AttestationObject: base64.RawURLEncoding.EncodeToString(attestation.AttestationObject),
+	})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	registered, err := suite.user.WebAuthnRegistered(ctx, user)
+	suite.NoError(err)
+	suite.False(registered)
+}
+
+func (suite *WebAuthnTestSuite) TestSignInSecondFactor() {
+	var (
+		ctx           = context.Background()
+		user          = suite.testUsers["local_account_1"]
+		authenticator = testrig.NewSoftwareAuthenticator()
+	)
+
+	suite.register(authenticator, "yubikey")
+
+	// Second factor doesn't
+	// need user verification.
+	authenticator.UserVerified = false
+
+	options, errWithCode := suite.user.WebAuthnSignInOptions(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Len(options.AllowCredentials, 1)
+
+	assertion := authenticator.Get(options)
+	signedIn, errWithCode := suite.user.WebAuthnSignIn(
+		ctx,
+		user,
+		options.Challenge,
+		assertion.ID,
+		assertion.ClientDataJSON,
+		assertion.AuthenticatorData,
+		assertion.Signature,
+	)
+	suite.NoError(errWithCode)
+	suite.Equal(user.ID, signedIn.ID)
+
+	credentials, errWithCode := suite.user.WebAuthnCredentialsGet(ctx, user)
+	suite.NoError(errWithCode)
+	suite.NotNil(credentials[0].LastUsedAt)
+
+	// Another user can't use
+	// this user's credential.
+	_, errWithCode = suite.user.WebAuthnSignIn(
+		ctx,
+		suite.testUsers["admin_account"],
+		options.Challenge,
+		assertion.ID,
+		assertion.ClientDataJSON,
+		assertion.AuthenticatorData,
+		assertion.Signature,
+	)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+}
+
+func (suite *WebAuthnTestSuite) TestSignInPasswordless() {
+	var (
+		ctx           = context.Background()
+		user          = suite.testUsers["local_account_1"]
+		authenticator = testrig.NewSoftwareAuthenticator()
+	)
+
+	suite.register(authenticator, "phone")
+
+	options, errWithCode := suite.user.WebAuthnSignInOptions(ctx, nil)
+	suite.NoError(errWithCode)
+	suite.Empty(options.AllowCredentials)
+	suite.Equal("required", options.UserVerification)
+
+	assertion := authenticator.Get(options)
+	signedIn, errWithCode := suite.user.WebAuthnSignIn(
+		ctx,
+		nil,
+		options.Challenge,
+		assertion.ID,
+		assertion.ClientDataJSON,
+		assertion.AuthenticatorData,
+		assertion.Signature,
+	)
+	suite.NoError(errWithCode)
+	suite.Equal(user.ID, signedIn.ID)
+
+	// Passwordless requires user verification.
+	authenticator.UserVerified = false
+
+	options, errWithCode = suite.user.WebAuthnSignInOptions(ctx, nil)
+	suite.NoError(errWithCode)
+
+	assertion = authenticator.Get(options)
+	_, errWithCode = suite.user.WebAuthnSignIn(
+		ctx,
+		nil,
+		options.Challenge,
+		assertion.ID,
+		assertion.ClientDataJSON,
+		assertion.AuthenticatorData,
+		assertion.Signature,
+	)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.Equal("Unauthorized: security key could not be verified", errWithCode.Safe())
+}
+
+func TestWebAuthnTestSuite(t *testing.T) {
+	suite.Run(t, new(WebAuthnTestSuite))
+}
@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// updateFeaturedTags encapsulates common logic used to refresh the
+// usage stats of the tags featured by the author of a newly created,
+// updated or deleted status. All of the author's featured tags are
+// refreshed, as an edit or delete may drop a tag from the status.
+type updateFeaturedTags func(context.Context, *gtsmodel.Status)
+
+// updateFeaturedTagsF returns an updateFeaturedTags util function.
+func updateFeaturedTagsF(state *state.State) updateFeaturedTags {
+	return func(ctx context.Context, status *gtsmodel.Status) {
+		if status.BoostOfID != "" {
+			// Boosts don't
+			// count as uses.
+			return
+		}
+
+		featuredTags, err := state.DB.GetFeaturedTagsByAccountID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting featured tags: %v", err)
+			return
+		}
+
+		for _, featuredTag := range featuredTags {
+			count, lastAt, err := state.DB.GetAccountTagUsage(ctx,
+				featuredTag.AccountID,
+				featuredTag.TagID,
+			)
+			if err != nil {
+				log.Errorf(ctx, "db error getting tag usage: %v", err)
+				continue
+			}
+
+			if count == featuredTag.StatusesCount &&
+				lastAt.Equal(featuredTag.LastStatusAt) {
+				// Nothing
+				// changed.
+				continue
+			}
+
+			featuredTag.StatusesCount = count
+			featuredTag.LastStatusAt = lastAt
+			if err := state.DB.UpdateFeaturedTag(ctx,
+				featuredTag,
+				"statuses_count",
+				"last_status_at",
+			); err != nil {
+				log.Errorf(ctx, "db error updating featured tag: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cards"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// fetchCard encapsulates common logic used to (re)generate the
+// preview card of a newly created or updated status. As linked
+// pages may be slow to fetch, this is queued on the media worker
+// pool rather than holding up processing of the status itself.
+// Cards are best-effort, so the job is dropped when shutting down.
+type fetchCard func(context.Context, *gtsmodel.Status)
+
+// fetchCardF returns a fetchCard util function.
+func fetchCardF(state *state.State, fetcher cards.Fetcher, surface *surface) fetchCard {
+	return func(ctx context.Context, status *gtsmodel.Status) {
+		statusID := status.ID
+
+		_ = state.Workers.Media.EnqueueCtx(ctx, func(ctx context.Context) {
+			// Reload the status, it may have been
+			// edited or deleted since being queued.
+			status, err := state.DB.GetStatusByID(
+				gtscontext.SetBarebones(ctx),
+				statusID,
+			)
+			if err != nil {
+				if !errors.Is(err, db.ErrNoEntries) {
+					log.Errorf(ctx, "db error getting status %s: %v", statusID, err)
+				}
+				return
+			}
+
+			changed, err := fetcher.FetchStatusCard(ctx, status)
+			if err != nil {
+				log.Errorf(ctx, "error fetching card for status %s: %v", statusID, err)
+			}
+
+			if changed {
+				// Status representation has changed, invalidate from timelines.
+				surface.invalidateStatusFromTimelines(ctx, statusID)
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
@@ -39,12 +40,15 @@ import (
 // specifically for messages originating
 // from the client/REST API.
 type clientAPI struct {
-	state      *state.State
-	converter  *typeutils.Converter
-	surface    *surface
-	federate   *federate
-	wipeStatus wipeStatus
-	account    *account.Processor
+	state              *state.State
+	converter          *typeutils.Converter
+	surface            *surface
+	federate           *federate
+	wipeStatus         wipeStatus
+	fetchCard          fetchCard
+	account            *account.Processor
+	user               *user.Processor
+	updateFeaturedTags updateFeaturedTags
 }
 
 func (p *Processor) EnqueueClientAPI(cctx context.Context, msgs ...messages.FromClientAPI) {
@@ -136,20 +140,28 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg messages.From
 
 	// ACCEPT SOMETHING
 	case ap.ActivityAccept:
-		switch cMsg.APObjectType { //nolint:gocritic
+		switch cMsg.APObjectType {
 
 		// ACCEPT FOLLOW (request)
 		case ap.ActivityFollow:
 			return p.clientAPI.AcceptFollow(ctx, cMsg)
+
+		// ACCEPT PROFILE/ACCOUNT (sign-up)
+		case ap.ObjectProfile:
+			return p.clientAPI.AcceptAccount(ctx, cMsg)
 		}
 
 	// REJECT SOMETHING
 	case ap.ActivityReject:
-		switch cMsg.APObjectType { //nolint:gocritic
+		switch cMsg.APObjectType {
 
 		// REJECT FOLLOW (request)
 		case ap.ActivityFollow:
 			return p.clientAPI.RejectFollowRequest(ctx, cMsg)
+
+		// REJECT PROFILE/ACCOUNT (sign-up)
+		case ap.ObjectProfile:
+			return p.clientAPI.RejectAccount(ctx, cMsg)
 		}
 
 	// UNDO SOMETHING
@@ -211,10 +223,18 @@ func (p *clientAPI) CreateAccount(ctx context.Context, cMsg messages.FromClientA
 		return gtserror.Newf("db error getting user for account id %s: %w", account.ID, err)
 	}
 
-	if err := p.surface.emailPleaseConfirm(ctx, user, account.Username); err != nil {
+	if err := p.user.EmailPleaseConfirm(ctx, user, account.Username); err != nil {
 		log.Errorf(ctx, "error emailing confirm: %v", err)
 	}
 
+	if !*user.Approved {
+		// Let moderators know a new
+		// sign-up is awaiting approval.
+		if err := p.surface.notifySignup(ctx, user); err != nil {
+			log.Errorf(ctx, "error notifying signup: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -238,6 +258,12 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg messages.FromClientAP
 		log.Errorf(ctx, "error federating status: %v", err)
 	}
 
+	// Generate preview card for any link.
+	p.fetchCard(ctx, status)
+
+	// Tag usage may have changed, update featured tags.
+	p.updateFeaturedTags(ctx, status)
+
 	return nil
 }
 
@@ -407,6 +433,12 @@ func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg messages.FromClientAP
 	// Status representation has changed, invalidate from timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
 
+	// Links may have changed, regenerate preview card.
+	p.fetchCard(ctx, status)
+
+	// Tag usage may have changed, update featured tags.
+	p.updateFeaturedTags(ctx, status)
+
 	if status.Poll != nil && status.Poll.Closing {
 
 		// If the latest status has a newly closed poll, at least compared
@@ -489,6 +521,32 @@ func (p *clientAPI) RejectFollowRequest(ctx context.Context, cMsg messages.FromC
 	return nil
 }
 
+func (p *clientAPI) AcceptAccount(ctx context.Context, cMsg messages.FromClientAPI) error {
+	user, ok := cMsg.GTSModel.(*gtsmodel.User)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.User", cMsg.GTSModel)
+	}
+
+	if err := p.surface.emailSignupApproved(ctx, user); err != nil {
+		log.Errorf(ctx, "error emailing signup approved: %v", err)
+	}
+
+	return nil
+}
+
+func (p *clientAPI) RejectAccount(ctx context.Context, cMsg messages.FromClientAPI) error {
+	user, ok := cMsg.GTSModel.(*gtsmodel.User)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.User", cMsg.GTSModel)
+	}
+
+	if err := p.surface.emailSignupRejected(ctx, user); err != nil {
+		log.Errorf(ctx, "error emailing signup rejected: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) UndoFollow(ctx context.Context, cMsg messages.FromClientAPI) error {
 	follow, ok := cMsg.GTSModel.(*gtsmodel.Follow)
 	if !ok {
@@ -590,6 +648,9 @@ func (p *clientAPI) DeleteStatus(ctx context.Context, cMsg messages.FromClientAP
 		log.Errorf(ctx, "error federating status delete: %v", err)
 	}
 
+	// Tag usage may have changed, update featured tags.
+	p.updateFeaturedTags(ctx, status)
+
 	return nil
 }
 
@@ -153,6 +153,8 @@ func (suite *FromClientAPITestSuite) statusJSON(
 		ctx,
 		status,
 		requestingAccount,
+		gtsmodel.FilterContextNone,
+		nil,
 	)
 	if err != nil {
 		suite.FailNow(err.Error())
@@ -247,7 +249,7 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusWithNotification() {
 		suite.FailNow("timed out waiting for new status notification")
 	}
 
-	apiNotif, err := suite.typeconverter.NotificationToAPINotification(ctx, notif)
+	apiNotif, err := suite.typeconverter.NotificationToAPINotification(ctx, notif, nil)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -753,6 +755,101 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusBoostNoReblogs() {
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusDirectConversation() {
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		streams          = suite.openStreams(ctx, receivingAccount, nil)
+		directStream     = streams[stream.TimelineDirect]
+
+		// Admin account sends a direct reply to turtle.
+		status = suite.newStatus(
+			ctx,
+			postingAccount,
+			gtsmodel.VisibilityDirect,
+			suite.testStatuses["local_account_2_status_6"],
+			nil,
+		)
+	)
+
+	// Process the new status.
+	if err := suite.processor.Workers().ProcessFromClientAPI(
+		ctx,
+		messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			OriginAccount:  postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Turtle should now have an unread conversation with admin.
+	conversation, err := suite.db.GetConversationByThreadAndAccountIDs(
+		ctx,
+		receivingAccount.ID,
+		status.ThreadID,
+		[]string{postingAccount.ID},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(status.ID, conversation.LastStatusID)
+	suite.False(*conversation.Read)
+
+	// Admin should have a read conversation with turtle.
+	ownConversation, err := suite.db.GetConversationByThreadAndAccountIDs(
+		ctx,
+		postingAccount.ID,
+		status.ThreadID,
+		[]string{receivingAccount.ID},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*ownConversation.Read)
+
+	apiConversation, err := suite.typeconverter.ConversationToAPIConversation(ctx, conversation, receivingAccount, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	conversationJSON, err := json.Marshal(apiConversation)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Check message in direct stream.
+	suite.checkStreamed(
+		directStream,
+		true,
+		string(conversationJSON),
+		stream.EventTypeConversation,
+	)
+
+	// Now delete the status.
+	if err := suite.processor.Workers().ProcessFromClientAPI(
+		ctx,
+		messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityDelete,
+			GTSModel:       status,
+			OriginAccount:  postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// It was the only status in both
+	// conversations, so they should be gone.
+	for _, id := range []string{conversation.ID, ownConversation.ID} {
+		_, err := suite.db.GetConversationByID(ctx, id)
+		suite.ErrorIs(err, db.ErrNoEntries)
+	}
+}
+
 func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	var (
 		ctx                  = context.Background()
@@ -810,6 +907,41 @@ func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	}
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateAccountPendingApproval() {
+	var (
+		ctx        = context.Background()
+		newAccount = suite.testAccounts["unconfirmed_account"]
+		modAccount = suite.testAccounts["admin_account"]
+	)
+
+	// Process the new sign-up.
+	if err := suite.processor.Workers().ProcessFromClientAPI(
+		ctx,
+		messages.FromClientAPI{
+			APObjectType:   ap.ObjectProfile,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       newAccount,
+			OriginAccount:  newAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Admin should have been notified
+	// of the pending sign-up.
+	notif, err := suite.db.GetNotification(
+		ctx,
+		gtsmodel.NotificationSignup,
+		modAccount.ID,
+		newAccount.ID,
+		"",
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotNil(notif)
+}
+
 func TestFromClientAPITestSuite(t *testing.T) {
 	suite.Run(t, &FromClientAPITestSuite{})
 }
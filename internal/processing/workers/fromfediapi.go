@@ -19,10 +19,15 @@ package workers
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"time"
 
 	"codeberg.org/gruf/go-kv"
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
@@ -39,11 +44,13 @@ import (
 // specifically for messages originating
 // from the federation/ActivityPub API.
 type fediAPI struct {
-	state      *state.State
-	surface    *surface
-	federate   *federate
-	wipeStatus wipeStatus
-	account    *account.Processor
+	state              *state.State
+	surface            *surface
+	federate           *federate
+	wipeStatus         wipeStatus
+	fetchCard          fetchCard
+	account            *account.Processor
+	updateFeaturedTags updateFeaturedTags
 }
 
 func (p *Processor) EnqueueFediAPI(cctx context.Context, msgs ...messages.FromFediAPI) {
@@ -145,6 +152,15 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg messages.FromFe
 		case ap.ObjectProfile:
 			return p.fediAPI.DeleteAccount(ctx, fMsg)
 		}
+
+	// MOVE SOMETHING
+	case ap.ActivityMove:
+		switch fMsg.APObjectType { //nolint:gocritic
+
+		// MOVE PROFILE/ACCOUNT
+		case ap.ObjectProfile:
+			return p.fediAPI.MoveAccount(ctx, fMsg)
+		}
 	}
 
 	return gtserror.Newf("unhandled: %s %s", fMsg.APActivityType, fMsg.APObjectType)
@@ -222,6 +238,12 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg messages.FromFediAPI) e
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	// Generate preview card for any link.
+	p.fetchCard(ctx, status)
+
+	// Tag usage may have changed, update featured tags.
+	p.updateFeaturedTags(ctx, status)
+
 	return nil
 }
 
@@ -526,6 +548,12 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg messages.FromFediAPI) e
 	// Status representation was refetched, uncache from timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
 
+	// Links may have changed, regenerate preview card.
+	p.fetchCard(ctx, status)
+
+	// Tag usage may have changed, update featured tags.
+	p.updateFeaturedTags(ctx, status)
+
 	if status.Poll != nil && status.Poll.Closing {
 
 		// If the latest status has a newly closed poll, at least compared
@@ -564,6 +592,9 @@ func (p *fediAPI) DeleteStatus(ctx context.Context, fMsg messages.FromFediAPI) e
 		p.surface.invalidateStatusFromTimelines(ctx, status.InReplyToID)
 	}
 
+	// Tag usage may have changed, update featured tags.
+	p.updateFeaturedTags(ctx, status)
+
 	return nil
 }
 
@@ -579,3 +610,180 @@ func (p *fediAPI) DeleteAccount(ctx context.Context, fMsg messages.FromFediAPI)
 
 	return nil
 }
+
+// moveFollowInterval is the time to wait between
+// sending each Follow when redirecting local
+// followers from a moved account to its target,
+// to avoid hammering the target's instance.
+const moveFollowInterval = 500 * time.Millisecond
+
+func (p *fediAPI) MoveAccount(ctx context.Context, fMsg messages.FromFediAPI) error {
+	// The account doing the Move.
+	origin, ok := fMsg.GTSModel.(*gtsmodel.Account)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Account", fMsg.GTSModel)
+	}
+
+	// The account being Moved to.
+	targetIRI := fMsg.APIri
+	if targetIRI == nil {
+		return gtserror.New("Move target IRI not set")
+	}
+	targetURIStr := targetIRI.String()
+
+	// The same Move gets delivered to each
+	// receiving inbox, so get latest version
+	// of origin to see if it's already done.
+	origin, err := p.state.DB.GetAccountByID(ctx, origin.ID)
+	if err != nil {
+		return gtserror.Newf("error getting origin account: %w", err)
+	}
+
+	switch origin.MovedToURI {
+	case "":
+		// Not yet moved.
+
+	case targetURIStr:
+		// We already processed this Move,
+		// followers are being redirected.
+		log.Debugf(ctx, "Move from %s to %s already processed", origin.URI, targetURIStr)
+		return nil
+
+	default:
+		// We already processed a Move from this account
+		// to a different target; accounts can't Move twice.
+		return gtserror.Newf(
+			"account %s already moved to %s, ignoring Move to %s",
+			origin.URI, origin.MovedToURI, targetURIStr,
+		)
+	}
+
+	// Fetch the Move target, making sure we have an up to
+	// date model in order to check its alsoKnownAs entries.
+	target, _, err := p.federate.GetAccountByURI(ctx,
+		fMsg.ReceivingAccount.Username,
+		targetIRI,
+	)
+	if err != nil {
+		return gtserror.Newf("error dereferencing Move target %s: %w", targetURIStr, err)
+	}
+
+	if target.IsRemote() {
+		target, _, err = p.federate.RefreshAccount(ctx,
+			fMsg.ReceivingAccount.Username,
+			target,
+			nil,
+			// Force refresh within 5min window.
+			dereferencing.Fresh,
+		)
+		if err != nil {
+			return gtserror.Newf("error refreshing Move target %s: %w", targetURIStr, err)
+		}
+	}
+
+	switch {
+	case target.ID == origin.ID:
+		return gtserror.Newf("account %s cannot Move to itself", origin.URI)
+
+	case !target.SuspendedAt.IsZero():
+		return gtserror.Newf("Move target %s is suspended", target.URI)
+
+	case target.MovedToURI != "":
+		return gtserror.Newf("Move target %s has itself moved to %s", target.URI, target.MovedToURI)
+
+	case !slices.Contains(target.AlsoKnownAsURIs, origin.URI):
+		// The target must confirm it's an alias of
+		// the origin, else anyone could take followers.
+		return gtserror.Newf("Move target %s does not list %s in alsoKnownAs", target.URI, origin.URI)
+	}
+
+	// Mark the origin account as moved.
+	origin.MovedToURI = target.URI
+	origin.MovedTo = target
+	if err := p.state.DB.UpdateAccount(ctx, origin, "moved_to_uri"); err != nil {
+		return gtserror.Newf("error updating moved account %s: %w", origin.URI, err)
+	}
+
+	// Redirect local followers of origin to target.
+	return p.redirectFollowers(ctx, origin, target)
+}
+
+// redirectFollowers schedules a task for each local follower
+// of origin, which follows target on their behalf then unfollows
+// origin. Tasks are spaced by moveFollowInterval, so as to rate
+// limit follow sends without holding up a worker in the meantime.
+func (p *fediAPI) redirectFollowers(
+	ctx context.Context,
+	origin *gtsmodel.Account,
+	target *gtsmodel.Account,
+) error {
+	follows, err := p.state.DB.GetAccountLocalFollowers(
+		// Only barebones items needed.
+		gtscontext.SetBarebones(ctx),
+		origin.ID,
+	)
+	if err != nil {
+		return gtserror.Newf("error getting local followers of %s: %w", origin.URI, err)
+	}
+
+	now := time.Now()
+	for i, follow := range follows {
+		// Key task by follow ID so that
+		// any duplicates get dropped.
+		taskID := "@moveredirect:" + follow.ID
+		startAt := now.Add(time.Duration(i) * moveFollowInterval)
+
+		if !p.state.Workers.Scheduler.AddOnce(
+			taskID,
+			startAt,
+			p.onRedirectFollow(taskID, follow.ID, target.ID),
+		) {
+			// Either the scheduler is stopping, or
+			// this follow is already being redirected.
+			log.Debugf(ctx, "could not schedule redirect of follow %s", follow.ID)
+		}
+	}
+
+	return nil
+}
+
+// onRedirectFollow returns a callback function to be used by the scheduler
+// to redirect the follow with given ID to the account with given target ID.
+func (p *fediAPI) onRedirectFollow(taskID, followID, targetID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, _ time.Time) {
+		// Free up task ID once done.
+		defer p.state.Workers.Scheduler.Cancel(taskID)
+
+		// Get the latest version of follow from database.
+		follow, err := p.state.DB.GetFollowByID(ctx, followID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "error getting follow %s: %v", followID, err)
+			}
+			return
+		}
+
+		// Follow target, carrying over follow preferences.
+		if _, errWithCode := p.account.FollowCreate(ctx,
+			follow.Account,
+			&apimodel.AccountFollowRequest{
+				ID:      targetID,
+				Reblogs: follow.ShowReblogs,
+				Notify:  follow.Notify,
+			},
+		); errWithCode != nil {
+			// Leave the existing follow in
+			// place so nothing is lost.
+			log.Errorf(ctx, "error following %s for %s: %v", targetID, follow.Account.URI, errWithCode)
+			return
+		}
+
+		// Unfollow origin now it's been redirected.
+		if _, errWithCode := p.account.FollowRemove(ctx,
+			follow.Account,
+			follow.TargetAccountID,
+		); errWithCode != nil {
+			log.Errorf(ctx, "error unfollowing %s for %s: %v", follow.TargetAccountID, follow.Account.URI, errWithCode)
+		}
+	}
+}
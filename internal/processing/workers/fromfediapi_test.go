@@ -543,6 +543,109 @@ func (suite *FromFediAPITestSuite) TestCreateStatusFromIRI() {
 	suite.Equal(statusCreator.URI, s.AccountURI)
 }
 
+// TestMoveAccount checks that an inbound Move from a
+// remote account redirects its local followers to the target.
+func (suite *FromFediAPITestSuite) TestMoveAccount() {
+	var (
+		ctx              = context.Background()
+		receivingAccount = suite.testAccounts["admin_account"]
+		follower         = suite.testAccounts["admin_account"]
+	)
+
+	// Load origin and target from the db, since
+	// they get modified during the test.
+	origin, err := suite.db.GetAccountByID(ctx, suite.testAccounts["remote_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	target, err := suite.db.GetAccountByID(ctx, suite.testAccounts["local_account_2"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Target confirms it's an alias of origin.
+	target.AlsoKnownAsURIs = []string{origin.URI}
+	if err := suite.db.UpdateAccount(ctx, target, "also_known_as_uris"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Local follower follows origin.
+	if err := suite.db.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              "01HQ6YZ3ZK6C6CMEGB6M8ZFG0S",
+		URI:             "http://localhost:8080/users/admin/follow/01HQ6YZ3ZK6C6CMEGB6M8ZFG0S",
+		AccountID:       follower.ID,
+		TargetAccountID: origin.ID,
+		ShowReblogs:     util.Ptr(true),
+		Notify:          util.Ptr(false),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err = suite.processor.Workers().ProcessFromFediAPI(ctx, messages.FromFediAPI{
+		APObjectType:     ap.ObjectProfile,
+		APActivityType:   ap.ActivityMove,
+		GTSModel:         origin,
+		APIri:            testrig.URLMustParse(target.URI),
+		ReceivingAccount: receivingAccount,
+	})
+	suite.NoError(err)
+
+	// Origin should now be marked as moved.
+	dbOrigin, err := suite.db.GetAccountByID(ctx, origin.ID)
+	suite.NoError(err)
+	suite.Equal(target.URI, dbOrigin.MovedToURI)
+
+	// Follower should now follow (or have requested) target,
+	// and no longer follow origin, once the redirect has run.
+	if !testrig.WaitFor(func() bool {
+		following, _ := suite.db.IsFollowing(ctx, follower.ID, target.ID)
+		requested, _ := suite.db.IsFollowRequested(ctx, follower.ID, target.ID)
+		stillFollowing, _ := suite.db.IsFollowing(ctx, follower.ID, origin.ID)
+		return (following || requested) && !stillFollowing
+	}) {
+		suite.FailNow("timed out waiting for follow redirect")
+	}
+
+	// Processing the same Move again
+	// (eg., from another inbox) is a no-op.
+	err = suite.processor.Workers().ProcessFromFediAPI(ctx, messages.FromFediAPI{
+		APObjectType:     ap.ObjectProfile,
+		APActivityType:   ap.ActivityMove,
+		GTSModel:         origin,
+		APIri:            testrig.URLMustParse(target.URI),
+		ReceivingAccount: receivingAccount,
+	})
+	suite.NoError(err)
+}
+
+// TestMoveAccountNotAlias checks that a Move to an account
+// which doesn't list the origin in alsoKnownAs is rejected.
+func (suite *FromFediAPITestSuite) TestMoveAccountNotAlias() {
+	var (
+		ctx    = context.Background()
+		target = suite.testAccounts["local_account_2"]
+	)
+
+	origin, err := suite.db.GetAccountByID(ctx, suite.testAccounts["remote_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err = suite.processor.Workers().ProcessFromFediAPI(ctx, messages.FromFediAPI{
+		APObjectType:     ap.ObjectProfile,
+		APActivityType:   ap.ActivityMove,
+		GTSModel:         origin,
+		APIri:            testrig.URLMustParse(target.URI),
+		ReceivingAccount: suite.testAccounts["admin_account"],
+	})
+	suite.ErrorContains(err, "does not list")
+
+	// Origin should not be marked as moved.
+	dbOrigin, err := suite.db.GetAccountByID(ctx, origin.ID)
+	suite.NoError(err)
+	suite.Empty(dbOrigin.MovedToURI)
+}
+
 func TestFromFederatorTestSuite(t *testing.T) {
 	suite.Run(t, &FromFediAPITestSuite{})
 }
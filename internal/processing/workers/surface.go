@@ -23,6 +23,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // surface wraps functions for 'surfacing' the result
@@ -31,10 +32,13 @@ import (
 //   - removing a status from timelines
 //   - sending a notification to a user
 //   - sending an email
+//   - pushing a notification to web push subscriptions
 type surface struct {
-	state       *state.State
-	converter   *typeutils.Converter
-	stream      *stream.Processor
-	filter      *visibility.Filter
-	emailSender email.Sender
+	state         *state.State
+	converter     *typeutils.Converter
+	stream        *stream.Processor
+	filter        *visibility.Filter
+	emailSender   email.Sender
+	emailBatches  *emailBatches
+	webPushSender webpush.Sender
 }
@@ -20,17 +20,57 @@ package workers
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+const (
+	// emailBatchDelay is how long new notifications are
+	// collected for before being emailed to a user, so
+	// that a burst of notifications (eg., a reply storm)
+	// only results in a single email.
+	emailBatchDelay = 10 * time.Minute
+
+	// emailDigestEvery is how often users
+	// are checked for a digest being due.
+	emailDigestEvery = time.Hour
+
+	// emailNotificationsListMax is the maximum
+	// number of notifications listed in an email.
+	emailNotificationsListMax = 20
+
+	// emailDigestFetchMax is the maximum number of
+	// unread notifications fetched for a digest.
+	emailDigestFetchMax = 200
+
+	// emailStatusTextMax is the maximum number of characters
+	// of status text quoted in a notification email.
+	emailStatusTextMax = 200
+)
+
+// emailBatches tracks notifications waiting to be
+// emailed to users, keyed by user ID. A user with
+// a pending batch has an email scheduled already.
+type emailBatches struct {
+	mu      sync.Mutex
+	pending map[string][]string // user ID -> notification IDs
+}
+
 func (s *surface) emailReportOpened(ctx context.Context, report *gtsmodel.Report) error {
 	instance, err := s.state.DB.GetInstance(ctx, config.GetHost())
 	if err != nil {
@@ -71,15 +111,10 @@ func (s *surface) emailReportClosed(ctx context.Context, report *gtsmodel.Report
 		return gtserror.Newf("db error getting user: %w", err)
 	}
 
-	if user.ConfirmedAt.IsZero() ||
-		!*user.Approved ||
-		*user.Disabled ||
-		user.Email == "" {
-		// Only email users who:
-		// - are confirmed
-		// - are approved
-		// - are not disabled
-		// - have an email address
+	if !emailable(user) ||
+		!util.PtrValueOr(user.EmailNotifyReport, true) {
+		// Only email users who can be
+		// emailed, and haven't opted out.
 		return nil
 	}
 
@@ -104,57 +139,444 @@ func (s *surface) emailReportClosed(ctx context.Context, report *gtsmodel.Report
 	return s.emailSender.SendReportClosedEmail(user.Email, reportClosedData)
 }
 
-func (s *surface) emailPleaseConfirm(ctx context.Context, user *gtsmodel.User, username string) error {
-	if user.UnconfirmedEmail == "" ||
-		user.UnconfirmedEmail == user.Email {
-		// User has already confirmed this
-		// email address; nothing to do.
+func (s *surface) emailSignupApproved(ctx context.Context, user *gtsmodel.User) error {
+	toAddress := signupEmailAddress(user)
+	if toAddress == "" {
+		// Nowhere to send.
+		return nil
+	}
+
+	instance, err := s.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	if user.Account == nil {
+		user.Account, err = s.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			return gtserror.Newf("db error getting account: %w", err)
+		}
+	}
+
+	signupApprovedData := email.SignupApprovedData{
+		Username:     user.Account.Username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+	}
+
+	return s.emailSender.SendSignupApprovedEmail(toAddress, signupApprovedData)
+}
+
+// emailSignupRejected emails the given user to let
+// them know their sign-up was rejected. The user and
+// account may have already been deleted from the db
+// by this point, so both must be populated by caller.
+func (s *surface) emailSignupRejected(ctx context.Context, user *gtsmodel.User) error {
+	toAddress := signupEmailAddress(user)
+	if toAddress == "" {
+		// Nowhere to send.
 		return nil
 	}
 
+	if user.Account == nil {
+		return gtserror.New("user account not populated")
+	}
+
 	instance, err := s.state.DB.GetInstance(ctx, config.GetHost())
 	if err != nil {
 		return gtserror.Newf("db error getting instance: %w", err)
 	}
 
-	// We need a token and a link for the
-	// user to click on. We'll use a uuid
-	// as our token since it's secure enough
-	// for this purpose.
-	var (
-		confirmToken = uuid.NewString()
-		confirmLink  = uris.GenerateURIForEmailConfirm(confirmToken)
+	signupRejectedData := email.SignupRejectedData{
+		Username:     user.Account.Username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+	}
+
+	return s.emailSender.SendSignupRejectedEmail(toAddress, signupRejectedData)
+}
+
+// signupEmailAddress returns the address that sign-up
+// decision emails should be sent to for the given user.
+// New sign-ups may not have confirmed their email yet,
+// so this falls back to the unconfirmed email address.
+func signupEmailAddress(user *gtsmodel.User) string {
+	if user.Email != "" {
+		return user.Email
+	}
+	return user.UnconfirmedEmail
+}
+
+// emailable returns whether the given user can be emailed
+// about notifications, ie., whether the user:
+//   - is confirmed
+//   - is approved
+//   - is not disabled
+//   - has an email address
+func emailable(user *gtsmodel.User) bool {
+	return !user.ConfirmedAt.IsZero() &&
+		util.PtrValueOr(user.Approved, false) &&
+		!util.PtrValueOr(user.Disabled, false) &&
+		user.Email != ""
+}
+
+// emailNotifies returns whether the given user has
+// opted in to emails about the given notification
+// type, as returned by emailNotificationType.
+func emailNotifies(user *gtsmodel.User, notifType string) bool {
+	var notify *bool
+
+	switch notifType {
+	case string(gtsmodel.NotificationFollow):
+		notify = user.EmailNotifyFollow
+	case string(gtsmodel.NotificationFollowRequest):
+		notify = user.EmailNotifyFollowRequest
+	case string(gtsmodel.NotificationMention):
+		notify = user.EmailNotifyMention
+	case "direct":
+		notify = user.EmailNotifyDirect
+	}
+
+	return util.PtrValueOr(notify, false)
+}
+
+// emailNotificationType returns the type of the given
+// notification for emails, which is the notification
+// type, except for mentions in direct messages which
+// are "direct". The notification must be populated.
+func emailNotificationType(notif *gtsmodel.Notification) string {
+	if notif.NotificationType == gtsmodel.NotificationMention &&
+		notif.Status != nil &&
+		notif.Status.Visibility == gtsmodel.VisibilityDirect {
+		return "direct"
+	}
+	return string(notif.NotificationType)
+}
+
+// emailNotification adds the given notification to the batch
+// of notifications to be emailed to its target user, if they've
+// opted in to emails about this notification type. The first
+// notification of a batch schedules the email to be sent after
+// emailBatchDelay, see emailNotificationBatch.
+func (s *surface) emailNotification(ctx context.Context, notif *gtsmodel.Notification) error {
+	user, err := s.state.DB.GetUserByAccountID(
+		gtscontext.SetBarebones(ctx),
+		notif.TargetAccountID,
 	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// No user, eg.,
+			// instance account.
+			return nil
+		}
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if !emailable(user) {
+		// Nowhere to send.
+		return nil
+	}
+
+	if notif.NotificationType == gtsmodel.NotificationMention &&
+		notif.Status == nil {
+		// Status is needed to tell
+		// mentions and DMs apart.
+		notif.Status, err = s.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			notif.StatusID,
+		)
+		if err != nil {
+			return gtserror.Newf("db error getting notification status: %w", err)
+		}
+	}
+
+	if !emailNotifies(user, emailNotificationType(notif)) {
+		// Not opted in.
+		return nil
+	}
 
-	// Assemble email contents and send the email.
-	if err := s.emailSender.SendConfirmEmail(
-		user.UnconfirmedEmail,
-		email.ConfirmData{
-			Username:     username,
-			InstanceURL:  instance.URI,
-			InstanceName: instance.Title,
-			ConfirmLink:  confirmLink,
+	s.emailBatches.mu.Lock()
+	notifIDs, pending := s.emailBatches.pending[user.ID]
+	s.emailBatches.pending[user.ID] = append(notifIDs, notif.ID)
+	s.emailBatches.mu.Unlock()
+
+	if pending {
+		// Email already
+		// scheduled.
+		return nil
+	}
+
+	userID := user.ID
+	if !s.state.Workers.Scheduler.AddOnce(
+		"@emailnotifications."+userID,
+		time.Now().Add(emailBatchDelay),
+		func(ctx context.Context, _ time.Time) {
+			if err := s.emailNotificationBatch(ctx, userID); err != nil {
+				log.Errorf(ctx, "error emailing notifications to user %s: %v", userID, err)
+			}
 		},
-	); err != nil {
+	) {
+		// Scheduler is stopping, drop the batch.
+		s.emailBatches.mu.Lock()
+		delete(s.emailBatches.pending, userID)
+		s.emailBatches.mu.Unlock()
+		return gtserror.Newf("failed to schedule notifications email for user %s", userID)
+	}
+
+	return nil
+}
+
+// emailNotificationBatch emails the batch of
+// notifications pending for the given user.
+func (s *surface) emailNotificationBatch(ctx context.Context, userID string) error {
+	s.emailBatches.mu.Lock()
+	notifIDs := s.emailBatches.pending[userID]
+	delete(s.emailBatches.pending, userID)
+	s.emailBatches.mu.Unlock()
+
+	// Get latest version of the user, as
+	// settings may have changed meanwhile.
+	user, err := s.state.DB.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// User was deleted.
+			return nil
+		}
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if !emailable(user) {
+		// Nowhere to send.
+		return nil
+	}
+
+	// Get the batched notifications, any deleted
+	// in the meantime (eg., undone follows) are
+	// not returned.
+	notifs, err := s.state.DB.GetNotificationsByIDs(ctx, notifIDs)
+	if err != nil {
+		return gtserror.Newf("db error getting notifications: %w", err)
+	}
+
+	// Drop notifications of types that
+	// were opted out of in the meantime.
+	notifs = slices.DeleteFunc(notifs, func(notif *gtsmodel.Notification) bool {
+		return !emailNotifies(user, emailNotificationType(notif))
+	})
+
+	if len(notifs) == 0 {
+		// Nothing to send.
+		return nil
+	}
+
+	instance, err := s.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	unsubscribeURL, err := s.emailUnsubscribeURL(ctx, user)
+	if err != nil {
 		return err
 	}
 
-	// Email sent, update the user entry
-	// with the new confirmation token.
-	now := time.Now()
-	user.ConfirmationToken = confirmToken
-	user.ConfirmationSentAt = now
-	user.LastEmailedAt = now
+	emailNotifs, more := emailNotificationsData(notifs)
+	notificationsData := email.NotificationsData{
+		Username:       user.Account.Username,
+		InstanceURL:    instance.URI,
+		InstanceName:   instance.Title,
+		Notifications:  emailNotifs,
+		More:           more,
+		SettingsURL:    instance.URI + "/settings/user/settings",
+		UnsubscribeURL: unsubscribeURL,
+	}
+
+	if err := s.emailSender.SendNotificationsEmail(user.Email, notificationsData); err != nil {
+		return gtserror.Newf("error sending notifications email: %w", err)
+	}
+
+	user.LastEmailedAt = time.Now()
+	if err := s.state.DB.UpdateUser(ctx, user, "last_emailed_at"); err != nil {
+		return gtserror.Newf("db error updating user: %w", err)
+	}
+
+	return nil
+}
+
+// emailDigests emails a digest of unread notifications
+// to each user who has opted in to digests, and whose
+// digest is due. Users are checked every emailDigestEvery.
+func (s *surface) emailDigests(ctx context.Context, now time.Time) {
+	users, err := s.state.DB.GetEmailDigestUsers(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting email digest users: %v", err)
+		return
+	}
+
+	for _, user := range users {
+		if err := s.emailDigest(ctx, user, now); err != nil {
+			log.Errorf(ctx, "error emailing digest to user %s: %v", user.ID, err)
+		}
+	}
+}
+
+// emailDigest emails a digest of unread notifications
+// to the given user, if their digest is due.
+func (s *surface) emailDigest(ctx context.Context, user *gtsmodel.User, now time.Time) error {
+	var period time.Duration
+	switch user.EmailDigest {
+	case gtsmodel.EmailDigestDaily:
+		period = 24 * time.Hour
+	case gtsmodel.EmailDigestWeekly:
+		period = 7 * 24 * time.Hour
+	default:
+		return nil
+	}
+
+	// Allow for some leeway, so that the
+	// digest doesn't creep later each time
+	// due to the frequency of checking.
+	due := user.EmailDigestSentAt.Add(period - emailDigestEvery/2)
+	if now.Before(due) || !emailable(user) {
+		// Not yet, or
+		// can't be sent.
+		return nil
+	}
+
+	// Only include notifications newer than the user's
+	// notifications marker (ie., unread), and newer than
+	// the last digest, so notifications aren't repeated.
+	var minID string
+	marker, err := s.state.DB.GetMarker(ctx, user.AccountID, gtsmodel.MarkerNameNotifications)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting notifications marker: %w", err)
+	} else if marker != nil {
+		minID = marker.LastReadID
+	}
 
+	if !user.EmailDigestSentAt.IsZero() {
+		sentID, err := id.NewULIDFromTime(user.EmailDigestSentAt)
+		if err != nil {
+			return gtserror.Newf("error generating id: %w", err)
+		}
+
+		if sentID > minID {
+			minID = sentID
+		}
+	}
+
+	notifs, err := s.state.DB.GetAccountNotifications(
+		ctx,
+		user.AccountID,
+		"",
+		"",
+		minID,
+		emailDigestFetchMax,
+		nil,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting notifications: %w", err)
+	}
+
+	if len(notifs) != 0 {
+		instance, err := s.state.DB.GetInstance(ctx, config.GetHost())
+		if err != nil {
+			return gtserror.Newf("db error getting instance: %w", err)
+		}
+
+		unsubscribeURL, err := s.emailUnsubscribeURL(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		emailNotifs, more := emailNotificationsData(notifs)
+		digestData := email.DigestData{
+			Username:       user.Account.Username,
+			InstanceURL:    instance.URI,
+			InstanceName:   instance.Title,
+			Frequency:      string(user.EmailDigest),
+			Notifications:  emailNotifs,
+			More:           more,
+			SettingsURL:    instance.URI + "/settings/user/settings",
+			UnsubscribeURL: unsubscribeURL,
+		}
+
+		if err := s.emailSender.SendDigestEmail(user.Email, digestData); err != nil {
+			return gtserror.Newf("error sending digest email: %w", err)
+		}
+
+		user.LastEmailedAt = now
+	}
+
+	// Mark digest as sent, even if there was nothing
+	// to send, so the next check is one period later.
+	user.EmailDigestSentAt = now
 	if err := s.state.DB.UpdateUser(
 		ctx,
 		user,
-		"confirmation_token",
-		"confirmation_sent_at",
+		"email_digest_sent_at",
 		"last_emailed_at",
 	); err != nil {
-		return gtserror.Newf("error updating user entry after email sent: %w", err)
+		return gtserror.Newf("db error updating user: %w", err)
 	}
 
 	return nil
 }
+
+// emailUnsubscribeURL returns the one-click unsubscribe link
+// for the given user, generating their unsubscribe token if
+// they don't have one yet.
+func (s *surface) emailUnsubscribeURL(ctx context.Context, user *gtsmodel.User) (string, error) {
+	if user.EmailUnsubscribeToken == "" {
+		user.EmailUnsubscribeToken = uuid.NewString()
+		if err := s.state.DB.UpdateUser(ctx, user, "email_unsubscribe_token"); err != nil {
+			return "", gtserror.Newf("db error updating user: %w", err)
+		}
+	}
+
+	return uris.GenerateURIForEmailUnsubscribe(user.EmailUnsubscribeToken), nil
+}
+
+// emailNotificationsData converts the given (populated) notifications
+// for listing in an email, returning at most emailNotificationsListMax,
+// along with the number of notifications left over.
+func emailNotificationsData(notifs []*gtsmodel.Notification) ([]email.Notification, int) {
+	var more int
+	if len(notifs) > emailNotificationsListMax {
+		more = len(notifs) - emailNotificationsListMax
+		notifs = notifs[:emailNotificationsListMax]
+	}
+
+	emailNotifs := make([]email.Notification, 0, len(notifs))
+	for _, notif := range notifs {
+		emailNotif := email.Notification{
+			Type:        emailNotificationType(notif),
+			AccountName: "@" + notif.OriginAccount.Username,
+			AccountURL:  notif.OriginAccount.URL,
+		}
+
+		if notif.OriginAccount.IsRemote() {
+			emailNotif.AccountName += "@" + notif.OriginAccount.Domain
+		}
+
+		if status := notif.Status; status != nil {
+			emailNotif.StatusURL = status.URL
+			if status.ContentWarning != "" {
+				// Don't reveal what's behind a
+				// content warning in the email.
+				emailNotif.StatusText = "CW: " + status.ContentWarning
+			} else {
+				emailNotif.StatusText = text.SanitizeToPlaintext(status.Content)
+			}
+
+			// Squash status text onto one line, and shorten it.
+			emailNotif.StatusText = strings.Join(strings.Fields(emailNotif.StatusText), " ")
+			if r := []rune(emailNotif.StatusText); len(r) > emailStatusTextMax {
+				emailNotif.StatusText = string(r[:emailStatusTextMax]) + "…"
+			}
+		}
+
+		emailNotifs = append(emailNotifs, emailNotif)
+	}
+
+	return emailNotifs, more
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -333,6 +334,55 @@ func (s *surface) notifyPollClose(ctx context.Context, status *gtsmodel.Status)
 	return errs.Combine()
 }
 
+// notifySignup notifies all moderators + admins of
+// this instance that a new account has signed up,
+// and is awaiting approval.
+func (s *surface) notifySignup(ctx context.Context, newUser *gtsmodel.User) error {
+	modUsers, err := s.state.DB.GetInstanceModerators(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// No registered
+			// mod accounts.
+			return nil
+		}
+
+		// Real error.
+		return gtserror.Newf("error getting instance moderator users: %w", err)
+	}
+
+	// Ensure user + account populated.
+	if err := s.state.DB.PopulateUser(ctx, newUser); err != nil {
+		return gtserror.Newf("error populating new user: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, modUser := range modUsers {
+		if modUser.Account == nil {
+			// Ensure mod user account populated.
+			modUser.Account, err = s.state.DB.GetAccountByID(ctx, modUser.AccountID)
+			if err != nil {
+				errs.Appendf("error getting mod account %s: %w", modUser.AccountID, err)
+				continue
+			}
+		}
+
+		// notify mod that
+		// someone signed up.
+		if err := s.notify(ctx,
+			gtsmodel.NotificationSignup,
+			modUser.Account,
+			newUser.Account,
+			"",
+		); err != nil {
+			errs.Appendf("error notifying mod %s: %w", modUser.AccountID, err)
+			continue
+		}
+	}
+
+	return errs.Combine()
+}
+
 // notify creates, inserts, and streams a new
 // notification to the target account if it
 // doesn't yet exist with the given parameters.
@@ -356,6 +406,21 @@ func (s *surface) notify(
 		return nil
 	}
 
+	// Check whether target account has muted
+	// notifications from the origin account.
+	muted, err := s.filter.AccountNotificationsMuted(ctx,
+		targetAccount,
+		originAccount,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking notification mute: %w", err)
+	}
+
+	if muted {
+		// nothing to do.
+		return nil
+	}
+
 	// Make sure a notification doesn't
 	// already exist with these params.
 	if _, err := s.state.DB.GetNotification(
@@ -389,9 +454,19 @@ func (s *surface) notify(
 		return gtserror.Newf("error putting notification in database: %w", err)
 	}
 
+	filters, err := s.state.DB.GetFiltersForAccountID(ctx, targetAccount.ID)
+	if err != nil {
+		return gtserror.Newf("couldn't retrieve filters for account %s: %w", targetAccount.ID, err)
+	}
+
 	// Stream notification to the user.
-	apiNotif, err := s.converter.NotificationToAPINotification(ctx, notif)
+	apiNotif, err := s.converter.NotificationToAPINotification(ctx, notif, filters)
 	if err != nil {
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			// Don't stream notifications
+			// for statuses that should be hidden.
+			return nil
+		}
 		return gtserror.Newf("error converting notification to api representation: %w", err)
 	}
 
@@ -399,5 +474,22 @@ func (s *surface) notify(
 		return gtserror.Newf("error streaming notification to account: %w", err)
 	}
 
-	return nil
+	// Push and email are independent of each
+	// other, so a failure of one shouldn't
+	// stop the user getting the other.
+	errs := gtserror.NewMultiError(2)
+
+	// Push notification to the user's web push
+	// subscriptions, for clients not streaming.
+	if err := s.webPushSender.Send(ctx, notif, apiNotif); err != nil {
+		errs.Appendf("error pushing notification to account: %w", err)
+	}
+
+	// Email notification to the user
+	// if they've opted in to this type.
+	if err := s.emailNotification(ctx, notif); err != nil {
+		errs.Appendf("error emailing notification to account: %w", err)
+	}
+
+	return errs.Combine()
 }
@@ -20,11 +20,14 @@ package workers
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/timeline"
@@ -66,11 +69,25 @@ func (s *surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 		return gtserror.Newf("error timelining status %s for followers: %w", status.ID, err)
 	}
 
+	// Timeline the status for each local account following
+	// one of its hashtags that didn't already receive it.
+	if err := s.timelineStatusForTagFollowers(ctx, status, follows); err != nil {
+		return gtserror.Newf("error timelining status %s for tag followers: %w", status.ID, err)
+	}
+
 	// Notify each local account that's mentioned by this status.
 	if err := s.notifyMentions(ctx, status); err != nil {
 		return gtserror.Newf("error notifying status mentions for status %s: %w", status.ID, err)
 	}
 
+	// Update the direct message conversations
+	// of each local participant, if applicable.
+	if status.Visibility == gtsmodel.VisibilityDirect {
+		if err := s.updateConversationsForStatus(ctx, status); err != nil {
+			return gtserror.Newf("error updating conversations for status %s: %w", status.ID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -173,6 +190,86 @@ func (s *surface) timelineAndNotifyStatusForFollowers(
 	return errs.Combine()
 }
 
+// timelineStatusForTagFollowers inserts the given status into the home
+// timeline of each local account following any of its hashtags, skipping
+// accounts contained in the given slice of follows, as these will already
+// have been handled by timelineAndNotifyStatusForFollowers.
+func (s *surface) timelineStatusForTagFollowers(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	follows []*gtsmodel.Follow,
+) error {
+	if status.Visibility != gtsmodel.VisibilityPublic ||
+		status.BoostOfID != "" || len(status.TagIDs) == 0 {
+		// Only original public statuses
+		// with tags can be tag-timelined.
+		return nil
+	}
+
+	// Get IDs of accounts following any of the status tags.
+	accountIDs, err := s.state.DB.GetAccountIDsFollowingTagIDs(ctx, status.TagIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting tag followers: %w", err)
+	}
+
+	// Drop accounts that already
+	// received status as a follower.
+	accountIDs = slices.DeleteFunc(accountIDs, func(id string) bool {
+		return slices.ContainsFunc(follows, func(follow *gtsmodel.Follow) bool {
+			return follow.AccountID == id
+		})
+	})
+
+	if len(accountIDs) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	accounts, err := s.state.DB.GetAccountsByIDs(ctx, accountIDs)
+	if err != nil {
+		return gtserror.Newf("error getting tag follower accounts: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, account := range accounts {
+		if account.IsRemote() {
+			// Only local accounts
+			// have home timelines.
+			continue
+		}
+
+		// Check the status is timelineable for this account,
+		// this also takes care of blocks, mutes and filters.
+		timelineable, err := s.filter.StatusHomeTimelineable(
+			ctx, account, status,
+		)
+		if err != nil {
+			errs.Appendf("error checking status %s hometimelineability: %w", status.ID, err)
+			continue
+		}
+
+		if !timelineable {
+			// Nothing to do.
+			continue
+		}
+
+		// Add status to home timeline of account.
+		if _, err := s.timelineStatus(
+			ctx,
+			s.state.Timelines.Home.IngestOne,
+			account.ID, // home timelines are keyed by account ID
+			account,
+			status,
+			stream.TimelineHome,
+		); err != nil {
+			errs.Appendf("error home timelining status: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
 // listTimelineStatusForFollow puts the given status
 // in any eligible lists owned by the given follower.
 func (s *surface) listTimelineStatusForFollow(
@@ -342,9 +439,20 @@ func (s *surface) timelineStatus(
 		return false, nil
 	}
 
+	filters, err := s.state.DB.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil {
+		return true, gtserror.Newf("couldn't retrieve filters for account %s: %w", account.ID, err)
+	}
+
 	// The status was inserted so stream it to the user.
-	apiStatus, err := s.converter.StatusToAPIStatus(ctx, status, account)
+	apiStatus, err := s.converter.StatusToAPIStatus(ctx, status, account, gtsmodel.FilterContextHome, filters)
 	if err != nil {
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			// Don't stream statuses
+			// that should be hidden.
+			return true, nil
+		}
+
 		err = gtserror.Newf("error converting status %s to frontend representation: %w", status.ID, err)
 		return true, err
 	}
@@ -371,6 +479,215 @@ func (s *surface) deleteStatusFromTimelines(ctx context.Context, statusID string
 	return s.stream.Delete(statusID)
 }
 
+// updateConversationsForStatus adds the given direct-visibility status
+// to the matching conversation of each local account participating in
+// it (the author + mentioned accounts), creating conversations where
+// necessary, and streams the updated conversation to each of them.
+func (s *surface) updateConversationsForStatus(ctx context.Context, status *gtsmodel.Status) error {
+	if status.ThreadID == "" {
+		// Unthreaded statuses don't mention or
+		// come from local accounts, nothing to do.
+		return nil
+	}
+
+	// Gather all participants of the
+	// status, without duplicates.
+	participants := []*gtsmodel.Account{status.Account}
+	for _, mention := range status.Mentions {
+		if mention.TargetAccount == nil ||
+			slices.ContainsFunc(participants, func(a *gtsmodel.Account) bool {
+				return a.ID == mention.TargetAccountID
+			}) {
+			continue
+		}
+		participants = append(participants, mention.TargetAccount)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, owner := range participants {
+		if !owner.IsLocal() {
+			// Only local accounts have conversations.
+			continue
+		}
+
+		visible, err := s.filter.StatusVisible(ctx, owner, status)
+		if err != nil {
+			errs.Appendf("error checking status %s visibility: %w", status.ID, err)
+			continue
+		}
+
+		if !visible {
+			// Nothing to do.
+			continue
+		}
+
+		// Conversations are keyed by
+		// everyone other than the owner.
+		otherAccountIDs := make([]string, 0, len(participants)-1)
+		for _, participant := range participants {
+			if participant.ID != owner.ID {
+				otherAccountIDs = append(otherAccountIDs, participant.ID)
+			}
+		}
+
+		conversation, err := s.updateConversationForOwner(ctx, owner, otherAccountIDs, status)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+
+		// Stream the updated conversation to the owner.
+		if err := s.streamConversation(ctx, owner, conversation); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// updateConversationForOwner gets or creates the conversation of
+// the given owner with the given other accounts in the thread of
+// the given status, and sets the status as its last status.
+func (s *surface) updateConversationForOwner(
+	ctx context.Context,
+	owner *gtsmodel.Account,
+	otherAccountIDs []string,
+	status *gtsmodel.Status,
+) (*gtsmodel.Conversation, error) {
+	// Statuses sent by the owner
+	// are already read by them.
+	read := status.AccountID == owner.ID
+
+	conversation, err := s.state.DB.GetConversationByThreadAndAccountIDs(
+		gtscontext.SetBarebones(ctx),
+		owner.ID,
+		status.ThreadID,
+		otherAccountIDs,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting conversation for account %s: %w", owner.ID, err)
+	}
+
+	if conversation == nil {
+		// First status in this conversation, create it.
+		conversation = &gtsmodel.Conversation{
+			ID:              id.NewULID(),
+			AccountID:       owner.ID,
+			OtherAccountIDs: otherAccountIDs,
+			ThreadID:        status.ThreadID,
+			LastStatusID:    status.ID,
+			Read:            &read,
+		}
+
+		if err := s.state.DB.PutConversation(ctx, conversation); err != nil {
+			return nil, gtserror.Newf("error inserting conversation for account %s: %w", owner.ID, err)
+		}
+	} else if status.ID > conversation.LastStatusID {
+		// Newer status in an existing conversation.
+		conversation.LastStatusID = status.ID
+		conversation.Read = &read
+
+		if err := s.state.DB.UpdateConversation(
+			ctx,
+			conversation,
+			"last_status_id",
+			"read",
+		); err != nil {
+			return nil, gtserror.Newf("error updating conversation %s: %w", conversation.ID, err)
+		}
+	}
+
+	if err := s.state.DB.AddStatusToConversation(ctx, conversation.ID, status.ID); err != nil {
+		return nil, gtserror.Newf("error adding status to conversation %s: %w", conversation.ID, err)
+	}
+
+	return conversation, nil
+}
+
+// streamConversation streams the given conversation to
+// any open direct timeline streams of the given owner.
+func (s *surface) streamConversation(
+	ctx context.Context,
+	owner *gtsmodel.Account,
+	conversation *gtsmodel.Conversation,
+) error {
+	filters, err := s.state.DB.GetFiltersForAccountID(ctx, owner.ID)
+	if err != nil {
+		return gtserror.Newf("couldn't retrieve filters for account %s: %w", owner.ID, err)
+	}
+
+	apiConversation, err := s.converter.ConversationToAPIConversation(ctx, conversation, owner, filters)
+	if err != nil {
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			// Don't stream conversations
+			// whose status should be hidden.
+			return nil
+		}
+
+		return gtserror.Newf("error converting conversation %s to frontend representation: %w", conversation.ID, err)
+	}
+
+	if err := s.stream.Conversation(apiConversation, owner); err != nil {
+		return gtserror.Newf("error streaming conversation %s: %w", conversation.ID, err)
+	}
+
+	return nil
+}
+
+// deleteStatusFromConversations removes the given status from any
+// conversations it belongs to. Conversations that had it as their
+// last status fall back to their previous status, or are deleted
+// if the given status was the only status they contained.
+func (s *surface) deleteStatusFromConversations(ctx context.Context, statusID string) error {
+	conversations, err := s.state.DB.GetConversationsByStatusID(
+		gtscontext.SetBarebones(ctx),
+		statusID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting conversations for status %s: %w", statusID, err)
+	}
+
+	if len(conversations) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	if err := s.state.DB.DeleteStatusFromConversations(ctx, statusID); err != nil {
+		return gtserror.Newf("error deleting status %s from conversations: %w", statusID, err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, conversation := range conversations {
+		if conversation.LastStatusID != statusID {
+			// Last status unchanged.
+			continue
+		}
+
+		lastStatusID, err := s.state.DB.GetConversationLatestStatusID(ctx, conversation.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error getting latest status of conversation %s: %w", conversation.ID, err)
+			continue
+		}
+
+		if lastStatusID == "" {
+			// Conversation is now empty, remove it.
+			if err := s.state.DB.DeleteConversationByID(ctx, conversation.ID); err != nil {
+				errs.Appendf("error deleting conversation %s: %w", conversation.ID, err)
+			}
+			continue
+		}
+
+		conversation.LastStatusID = lastStatusID
+		if err := s.state.DB.UpdateConversation(ctx, conversation, "last_status_id"); err != nil {
+			errs.Appendf("error updating conversation %s: %w", conversation.ID, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
 // invalidateStatusFromTimelines does cache invalidation on the given status by
 // unpreparing it from all timelines, forcing it to be prepared again (with updated
 // stats, boost counts, etc) next time it's fetched by the timeline owner. This goes
@@ -550,8 +867,18 @@ func (s *surface) timelineStreamStatusUpdate(
 	status *gtsmodel.Status,
 	streamType string,
 ) error {
-	apiStatus, err := s.converter.StatusToAPIStatus(ctx, status, account)
+	filters, err := s.state.DB.GetFiltersForAccountID(ctx, account.ID)
 	if err != nil {
+		return gtserror.Newf("couldn't retrieve filters for account %s: %w", account.ID, err)
+	}
+
+	apiStatus, err := s.converter.StatusToAPIStatus(ctx, status, account, gtsmodel.FilterContextHome, filters)
+	if err != nil {
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			// Don't stream statuses
+			// that should be hidden.
+			return nil
+		}
 		err = gtserror.Newf("error converting status %s to frontend representation: %w", status.ID, err)
 		return err
 	}
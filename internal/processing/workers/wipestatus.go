@@ -125,6 +125,11 @@ func wipeStatusF(state *state.State, media *media.Processor, surface *surface) w
 			errs.Appendf("error deleting status from timelines: %w", err)
 		}
 
+		// delete this status from any direct message conversations
+		if err := surface.deleteStatusFromConversations(ctx, statusToDelete.ID); err != nil {
+			errs.Appendf("error deleting status from conversations: %w", err)
+		}
+
 		// finally, delete the status itself
 		if err := state.DB.DeleteStatusByID(ctx, statusToDelete.ID); err != nil {
 			errs.Appendf("error deleting status: %w", err)
@@ -18,19 +18,26 @@
 package workers
 
 import (
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cards"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 	"github.com/superseriousbusiness/gotosocial/internal/workers"
 )
 
 type Processor struct {
 	workers   *workers.Workers
+	surface   *surface
 	clientAPI *clientAPI
 	fediAPI   *fediAPI
 }
@@ -41,18 +48,23 @@ func New(
 	converter *typeutils.Converter,
 	filter *visibility.Filter,
 	emailSender email.Sender,
+	webPushSender webpush.Sender,
+	cardFetcher cards.Fetcher,
 	account *account.Processor,
+	user *user.Processor,
 	media *media.Processor,
 	stream *stream.Processor,
 ) Processor {
 	// Init surface logic
 	// wrapper struct.
 	surface := &surface{
-		state:       state,
-		converter:   converter,
-		stream:      stream,
-		filter:      filter,
-		emailSender: emailSender,
+		state:         state,
+		converter:     converter,
+		stream:        stream,
+		filter:        filter,
+		emailSender:   emailSender,
+		emailBatches:  &emailBatches{pending: make(map[string][]string)},
+		webPushSender: webPushSender,
 	}
 
 	// Init federate logic
@@ -71,22 +83,58 @@ func New(
 		surface,
 	)
 
+	// Init shared logic fetch
+	// status card util func.
+	fetchCard := fetchCardF(
+		state,
+		cardFetcher,
+		surface,
+	)
+
+	// Init shared logic update
+	// featured tags util func.
+	updateFeaturedTags := updateFeaturedTagsF(state)
+
 	return Processor{
 		workers: &state.Workers,
+		surface: surface,
 		clientAPI: &clientAPI{
-			state:      state,
-			converter:  converter,
-			surface:    surface,
-			federate:   federate,
-			wipeStatus: wipeStatus,
-			account:    account,
+			state:              state,
+			converter:          converter,
+			surface:            surface,
+			federate:           federate,
+			wipeStatus:         wipeStatus,
+			fetchCard:          fetchCard,
+			account:            account,
+			user:               user,
+			updateFeaturedTags: updateFeaturedTags,
 		},
 		fediAPI: &fediAPI{
-			state:      state,
-			surface:    surface,
-			federate:   federate,
-			wipeStatus: wipeStatus,
-			account:    account,
+			state:              state,
+			surface:            surface,
+			federate:           federate,
+			wipeStatus:         wipeStatus,
+			fetchCard:          fetchCard,
+			account:            account,
+			updateFeaturedTags: updateFeaturedTags,
 		},
 	}
 }
+
+// ScheduleEmailDigests schedules a periodic check for
+// users who are due an email digest of their unread
+// notifications, sending the digests that are due.
+func (p *Processor) ScheduleEmailDigests() error {
+	firstRunAt := time.Now().Add(emailDigestEvery)
+
+	if !p.workers.Scheduler.AddRecurring(
+		"@emaildigests",
+		firstRunAt,
+		emailDigestEvery,
+		p.surface.emailDigests,
+	) {
+		return gtserror.New("failed to schedule @emaildigests")
+	}
+
+	return nil
+}
@@ -29,6 +29,10 @@ const (
 	// EventTypeStatusUpdate -- something in the user's timeline has been edited
 	// (yes this is a confusing name, blame Mastodon)
 	EventTypeStatusUpdate string = "status.update"
+	// EventTypeFiltersChanged -- the user's filters have changed
+	EventTypeFiltersChanged string = "filters_changed"
+	// EventTypeConversation -- a user's direct message conversation has been updated
+	EventTypeConversation string = "conversation"
 )
 
 const (
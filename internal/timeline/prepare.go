@@ -24,6 +24,7 @@ import (
 
 	"codeberg.org/gruf/go-kv"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -129,6 +130,12 @@ func (t *timeline) prepareXBetweenIDs(ctx context.Context, amount int, behindID
 				t.items.data.Remove(e)
 				continue
 			}
+			if errors.Is(err, statusfilter.ErrHideStatus) {
+				// This item has been filtered out by the requesting user's filters.
+				// Remove it and skip past it.
+				t.items.data.Remove(e)
+				continue
+			}
 			// We've got a proper db error.
 			return gtserror.Newf("db error while trying to prepare %s: %w", entry.itemID, err)
 		}
@@ -0,0 +1,186 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package totp implements time-based one-time passwords
+// (RFC 6238), as generated by authenticator apps, for use
+// as a second factor when signing in.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505 -- SHA1 is what authenticator apps expect.
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period is how long each code is valid for.
+	Period = 30 * time.Second
+
+	// Digits is the number of digits in each code.
+	Digits = 6
+
+	// Skew is the number of periods either side of
+	// the current one for which codes are accepted,
+	// to account for clock drift and slow typists.
+	Skew = 1
+
+	// secretSize is the length in bytes of
+	// generated secrets, as recommended by
+	// RFC 4226 for HMAC-SHA1.
+	secretSize = 20
+)
+
+// b32 is the encoding used for secrets, which
+// is how they're entered into authenticator apps.
+var b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewSecret returns a new randomly
+// generated, base32 encoded secret.
+func NewSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error reading random bytes: %w", err)
+	}
+	return b32.EncodeToString(b), nil
+}
+
+// Code returns the code for given
+// base32 encoded secret at time t.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return code(key, counter(t)), nil
+}
+
+// Validate returns whether the given code is valid for
+// base32 encoded secret at time t, allowing for Skew.
+func Validate(secret string, passcode string, t time.Time) bool {
+	_, ok := ValidateAfter(secret, passcode, t, 0)
+	return ok
+}
+
+// ValidateAfter is like Validate, but only accepts codes for time
+// steps after the given one, so that the caller can make sure no
+// code is accepted twice, as required by RFC 6238 section 5.2.
+// On success the time step of the code is returned, to be stored
+// and passed as "after" when validating subsequent codes.
+func ValidateAfter(secret string, passcode string, t time.Time, after uint64) (uint64, bool) {
+	passcode = strings.TrimSpace(passcode)
+	if len(passcode) != Digits {
+		return 0, false
+	}
+
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	now := counter(t)
+	for i := -Skew; i <= Skew; i++ {
+		step := now + uint64(int64(i)) // #nosec G115 -- wraps as intended.
+		if step <= after {
+			// Already used.
+			continue
+		}
+
+		c := code(key, step)
+		if subtle.ConstantTimeCompare([]byte(c), []byte(passcode)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// URI returns an otpauth:// provisioning URI for
+// given secret, issuer and account name. Encoded
+// as a QR code, this can be scanned by most
+// authenticator apps in order to add the secret.
+//
+// See: https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+func URI(secret string, issuer string, accountName string) string {
+	q := make(url.Values, 5)
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(Digits))
+	q.Set("period", fmt.Sprint(int(Period/time.Second)))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + accountName,
+		RawQuery: q.Encode(),
+	}
+
+	return u.String()
+}
+
+// decodeSecret decodes a base32 secret, being lenient
+// with case, whitespace and padding, as users might
+// copy + paste secrets in any of these forms.
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.Join(strings.Fields(secret), ""))
+	secret = strings.TrimRight(secret, "=")
+	if secret == "" {
+		return nil, errors.New("empty secret")
+	}
+
+	key, err := b32.DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding secret: %w", err)
+	}
+
+	return key, nil
+}
+
+// counter returns the moving factor for time t.
+func counter(t time.Time) uint64 {
+	return uint64(t.Unix()) / uint64(Period/time.Second) // #nosec G115 -- times are after the epoch.
+}
+
+// code implements the HOTP algorithm
+// from RFC 4226 for given key and counter.
+func code(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation.
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
@@ -0,0 +1,130 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package totp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+)
+
+// rfcSecret is the SHA1 secret used for the test vectors
+// in RFC 6238 appendix B, "12345678901234567890", base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+type TOTPTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TOTPTestSuite) TestCode() {
+	// RFC 6238 test vectors, truncated to 6 digits.
+	for _, test := range []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	} {
+		code, err := totp.Code(rfcSecret, time.Unix(test.unix, 0))
+		suite.NoError(err)
+		suite.Equal(test.code, code, "unix time %d", test.unix)
+	}
+}
+
+func (suite *TOTPTestSuite) TestValidate() {
+	now := time.Unix(1111111109, 0)
+
+	// Current code is valid.
+	suite.True(totp.Validate(rfcSecret, "081804", now))
+
+	// Codes one period either side are valid.
+	suite.True(totp.Validate(rfcSecret, "081804", now.Add(totp.Period)))
+	suite.True(totp.Validate(rfcSecret, "081804", now.Add(-totp.Period)))
+
+	// Codes further away are not.
+	suite.False(totp.Validate(rfcSecret, "081804", now.Add(3*totp.Period)))
+	suite.False(totp.Validate(rfcSecret, "081804", now.Add(-3*totp.Period)))
+
+	// Lenient with surrounding whitespace and secret format.
+	suite.True(totp.Validate(rfcSecret, " 081804\n", now))
+	suite.True(totp.Validate("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", "081804", now))
+
+	// Garbage is rejected.
+	suite.False(totp.Validate(rfcSecret, "", now))
+	suite.False(totp.Validate(rfcSecret, "81804", now))
+	suite.False(totp.Validate(rfcSecret, "0818040", now))
+	suite.False(totp.Validate("", "081804", now))
+	suite.False(totp.Validate("not base32!", "081804", now))
+}
+
+func (suite *TOTPTestSuite) TestValidateAfter() {
+	now := time.Unix(1111111109, 0)
+
+	// Returns the time step of the accepted code.
+	step, ok := totp.ValidateAfter(rfcSecret, "081804", now, 0)
+	suite.True(ok)
+	suite.EqualValues(1111111109/30, step)
+
+	// The same code can't be used again,
+	// even within the allowed skew.
+	_, ok = totp.ValidateAfter(rfcSecret, "081804", now, step)
+	suite.False(ok)
+	_, ok = totp.ValidateAfter(rfcSecret, "081804", now.Add(totp.Period), step)
+	suite.False(ok)
+
+	// Nor can an older code.
+	_, ok = totp.ValidateAfter(rfcSecret, "081804", now, step+1)
+	suite.False(ok)
+
+	// But the next one can.
+	next, err := totp.Code(rfcSecret, now.Add(totp.Period))
+	suite.NoError(err)
+	step2, ok := totp.ValidateAfter(rfcSecret, next, now.Add(totp.Period), step)
+	suite.True(ok)
+	suite.Equal(step+1, step2)
+}
+
+func (suite *TOTPTestSuite) TestNewSecret() {
+	secret1, err := totp.NewSecret()
+	suite.NoError(err)
+	suite.Len(secret1, 32)
+
+	secret2, err := totp.NewSecret()
+	suite.NoError(err)
+	suite.NotEqual(secret1, secret2)
+
+	// New secrets should be usable.
+	code, err := totp.Code(secret1, time.Now())
+	suite.NoError(err)
+	suite.True(totp.Validate(secret1, code, time.Now()))
+}
+
+func (suite *TOTPTestSuite) TestURI() {
+	uri := totp.URI(rfcSecret, "example.org", "zork@example.org")
+	suite.Equal("otpauth://totp/example.org:zork@example.org?algorithm=SHA1&digits=6&issuer=example.org&period=30&secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", uri)
+}
+
+func TestTOTPTestSuite(t *testing.T) {
+	suite.Run(t, new(TOTPTestSuite))
+}
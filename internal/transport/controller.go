@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"net/url"
 	"runtime"
+	"sync"
 
 	"codeberg.org/gruf/go-byteutil"
 	"codeberg.org/gruf/go-cache/v3"
@@ -43,6 +44,9 @@ type Controller interface {
 
 	// NewTransportForUsername searches for account with username, and returns result of .NewTransport().
 	NewTransportForUsername(ctx context.Context, username string) (Transport, error)
+
+	// ProcessDeliveryQueue retries queued outgoing deliveries that are due another attempt.
+	ProcessDeliveryQueue(ctx context.Context)
 }
 
 type controller struct {
@@ -52,7 +56,8 @@ type controller struct {
 	client    httpclient.SigningClient
 	trspCache cache.TTLCache[string, *transport]
 	userAgent string
-	senders   int // no. concurrent batch delivery routines.
+	senders   int        // no. concurrent batch delivery routines.
+	queueMu   sync.Mutex // held while draining delivery queue.
 }
 
 // NewController returns an implementation of the Controller interface for creating new transports
@@ -27,6 +27,8 @@ import (
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 )
 
 func (t *transport) BatchDeliver(ctx context.Context, b []byte, recipients []*url.URL) error {
@@ -39,15 +41,15 @@ func (t *transport) BatchDeliver(ctx context.Context, b []byte, recipients []*ur
 		// routines have returned.
 		wait sync.WaitGroup
 
-		// mutex protects 'recipients' and
+		// mutex protects 'deliveries' and
 		// 'errs' for concurrent access.
 		mutex sync.Mutex
-
-		// Get current instance host info.
-		domain = config.GetAccountDomain()
-		host   = config.GetHost()
 	)
 
+	// Prepare deliveries to each of the recipients;
+	// any that fail will be queued to retry later.
+	deliveries := t.newDeliveries(b, recipients)
+
 	// Block on expect no. senders.
 	wait.Add(t.controller.senders)
 
@@ -60,31 +62,30 @@ func (t *transport) BatchDeliver(ctx context.Context, b []byte, recipients []*ur
 				// Acquire lock.
 				mutex.Lock()
 
-				if len(recipients) == 0 {
+				if len(deliveries) == 0 {
 					// Reached end.
 					mutex.Unlock()
 					return
 				}
 
-				// Pop next recipient.
-				i := len(recipients) - 1
-				to := recipients[i]
-				recipients = recipients[:i]
+				// Pop next delivery.
+				i := len(deliveries) - 1
+				d := deliveries[i]
+				deliveries = deliveries[:i]
 
 				// Done with lock.
 				mutex.Unlock()
 
-				// Skip delivery to recipient if it is "us".
-				if to.Host == host || to.Host == domain {
-					continue
-				}
-
 				// Attempt to deliver data to recipient.
-				if err := t.deliver(ctx, b, to); err != nil {
+				err := t.deliver(ctx, d)
+				if err != nil {
 					mutex.Lock() // safely append err to accumulator.
-					errs.Appendf("error delivering to %s: %w", to, err)
+					errs.Appendf("error delivering to %s: %w", d.TargetURI, err)
 					mutex.Unlock()
 				}
+
+				// Update queue with result.
+				t.controller.handleDeliveryResult(ctx, d, err)
 			}
 		}()
 	}
@@ -97,32 +98,69 @@ func (t *transport) BatchDeliver(ctx context.Context, b []byte, recipients []*ur
 }
 
 func (t *transport) Deliver(ctx context.Context, b []byte, to *url.URL) error {
-	// if 'to' host is our own, skip as we don't need to deliver to ourselves...
-	if to.Host == config.GetHost() || to.Host == config.GetAccountDomain() {
+	// Prepare delivery to recipient (this skips "us").
+	deliveries := t.newDeliveries(b, []*url.URL{to})
+	if len(deliveries) == 0 {
 		return nil
 	}
 
 	// Deliver data to recipient.
-	return t.deliver(ctx, b, to)
+	err := t.deliver(ctx, deliveries[0])
+
+	// Update queue with result.
+	t.controller.handleDeliveryResult(ctx, deliveries[0], err)
+
+	return err
 }
 
-func (t *transport) deliver(ctx context.Context, b []byte, to *url.URL) error {
-	url := to.String()
+// newDeliveries prepares deliveries of b to each of the given
+// recipients, skipping any which are "us". These aren't stored
+// in the delivery queue until their first attempt fails (see
+// handleDeliveryResult), so that the payload is only written to
+// the database for deliveries which actually need retrying.
+func (t *transport) newDeliveries(b []byte, recipients []*url.URL) []*gtsmodel.Delivery {
+	var (
+		// Get current instance host info.
+		domain = config.GetAccountDomain()
+		host   = config.GetHost()
+
+		// Deliveries to attempt.
+		deliveries = make([]*gtsmodel.Delivery, 0, len(recipients))
+	)
+
+	for _, to := range recipients {
+		// Skip delivery to recipient if it is "us".
+		if to.Host == host || to.Host == domain {
+			continue
+		}
+
+		deliveries = append(deliveries, &gtsmodel.Delivery{
+			ID:           id.NewULID(),
+			PubKeyID:     t.pubKeyID,
+			TargetURI:    to.String(),
+			TargetDomain: to.Host,
+			Data:         b,
+		})
+	}
+
+	return deliveries
+}
 
+func (t *transport) deliver(ctx context.Context, d *gtsmodel.Delivery) error {
 	// Use rewindable bytes reader for body.
 	var body byteutil.ReadNopCloser
-	body.Reset(b)
+	body.Reset(d.Data)
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, &body)
+	req, err := http.NewRequestWithContext(ctx, "POST", d.TargetURI, &body)
 	if err != nil {
 		return err
 	}
 
 	req.Header.Add("Content-Type", string(apiutil.AppActivityLDJSON))
 	req.Header.Add("Accept-Charset", "utf-8")
-	req.Header.Set("Host", to.Host)
+	req.Header.Set("Host", d.TargetDomain)
 
-	rsp, err := t.POST(req, b)
+	rsp, err := t.POST(req, d.Data)
 	if err != nil {
 		return err
 	}
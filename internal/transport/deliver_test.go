@@ -0,0 +1,184 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DeliverTestSuite struct {
+	TransportTestSuite
+}
+
+// controllerWithStatus returns a transport controller whose
+// client responds to every request with the code returned by
+// status, counting the no. of requests made in the given counter.
+func (suite *DeliverTestSuite) controllerWithStatus(status func() int, count *atomic.Int32) transport.Controller {
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		count.Add(1)
+		code := status()
+		return &http.Response{
+			Status:     http.StatusText(code),
+			StatusCode: code,
+			Body:       io.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}, nil
+	}, "")
+	return testrig.NewTestTransportController(&suite.state, httpClient)
+}
+
+func (suite *DeliverTestSuite) TestDeliverRetry() {
+	var (
+		ctx   = context.Background()
+		count atomic.Int32
+		code  atomic.Int32
+	)
+
+	// Remote is down.
+	code.Store(http.StatusServiceUnavailable)
+	controller := suite.controllerWithStatus(func() int { return int(code.Load()) }, &count)
+
+	tsport, err := controller.NewTransportForUsername(ctx, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	to, _ := url.Parse("https://example.org/users/someone/inbox")
+	err = tsport.BatchDeliver(ctx, []byte(`{"hello":"world"}`), []*url.URL{to})
+	suite.Error(err)
+
+	// Delivery should be queued for retry.
+	deliveries, err := suite.db.GetDeliveriesForDomain(ctx, "example.org", 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if !suite.Len(deliveries, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal(to.String(), deliveries[0].TargetURI)
+	suite.Equal(1, deliveries[0].Attempts)
+	suite.NotEmpty(deliveries[0].LastError)
+	suite.WithinDuration(time.Now().Add(time.Minute), deliveries[0].NextAttemptAt, 10*time.Second)
+
+	// Processing the queue now shouldn't
+	// retry, since it isn't due yet.
+	before := count.Load()
+	controller.ProcessDeliveryQueue(ctx)
+	suite.Equal(before, count.Load())
+
+	// Remote comes back, and the delivery is flushed.
+	code.Store(http.StatusAccepted)
+	n, err := suite.db.SetDeliveriesDueForDomain(ctx, "example.org", time.Now())
+	suite.NoError(err)
+	suite.Equal(1, n)
+
+	controller.ProcessDeliveryQueue(ctx)
+	suite.Greater(count.Load(), before)
+
+	// Delivery should now be gone from the queue.
+	counts, err := suite.db.CountDeliveriesPerDomain(ctx)
+	suite.NoError(err)
+	suite.Empty(counts)
+}
+
+func (suite *DeliverTestSuite) TestDeliverSuccessNotQueued() {
+	var (
+		ctx   = context.Background()
+		count atomic.Int32
+	)
+
+	controller := suite.controllerWithStatus(func() int { return http.StatusAccepted }, &count)
+
+	tsport, err := controller.NewTransportForUsername(ctx, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	to1, _ := url.Parse("https://example.org/users/someone/inbox")
+	to2, _ := url.Parse("https://example.org/users/someone_else/inbox")
+	suite.NoError(tsport.BatchDeliver(ctx, []byte(`{"hello":"world"}`), []*url.URL{to1, to2}))
+	suite.EqualValues(2, count.Load())
+
+	// Successful first attempts
+	// never touch the queue.
+	counts, err := suite.db.CountDeliveriesPerDomain(ctx)
+	suite.NoError(err)
+	suite.Empty(counts)
+}
+
+func (suite *DeliverTestSuite) TestDeliverRejected() {
+	var (
+		ctx   = context.Background()
+		count atomic.Int32
+	)
+
+	// Remote rejects the delivery outright.
+	controller := suite.controllerWithStatus(func() int { return http.StatusForbidden }, &count)
+
+	tsport, err := controller.NewTransportForUsername(ctx, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	to, _ := url.Parse("https://example.org/users/someone/inbox")
+	err = tsport.Deliver(ctx, []byte(`{"hello":"world"}`), to)
+	suite.Error(err)
+
+	// Delivery shouldn't be queued for retry.
+	counts, err := suite.db.CountDeliveriesPerDomain(ctx)
+	suite.NoError(err)
+	suite.Empty(counts)
+}
+
+func (suite *DeliverTestSuite) TestDeliverToSelf() {
+	var (
+		ctx   = context.Background()
+		count atomic.Int32
+	)
+
+	controller := suite.controllerWithStatus(func() int { return http.StatusAccepted }, &count)
+
+	tsport, err := controller.NewTransportForUsername(ctx, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Deliveries to "us" should be skipped entirely.
+	to, _ := url.Parse("http://localhost:8080/users/the_mighty_zork/inbox")
+	suite.NoError(tsport.Deliver(ctx, []byte(`{"hello":"world"}`), to))
+	suite.Zero(count.Load())
+
+	counts, err := suite.db.CountDeliveriesPerDomain(ctx)
+	suite.NoError(err)
+	suite.Empty(counts)
+}
+
+func TestDeliverTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliverTestSuite))
+}
@@ -0,0 +1,250 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// deliveryBackoffMin and deliveryBackoffMax bound the
+	// exponential backoff between failed delivery attempts.
+	deliveryBackoffMin = time.Minute
+	deliveryBackoffMax = 24 * time.Hour
+
+	// deliveryMaxAttempts is the number of failed attempts
+	// after which a queued delivery is dropped. With the
+	// above backoff, this gives a remote just over 5 days
+	// to come back before we give up on delivering to it.
+	deliveryMaxAttempts = 16
+
+	// deliveryQueuePageSize is the no. of due
+	// deliveries to load from the queue at a time.
+	deliveryQueuePageSize = 100
+)
+
+// ProcessDeliveryQueue attempts each queued delivery that is
+// due for another attempt, using the delivery worker pool. Only
+// one call to this function will drain the queue at once, other
+// calls return immediately while the queue is being drained.
+func (c *controller) ProcessDeliveryQueue(ctx context.Context) {
+	if !c.queueMu.TryLock() {
+		// Already being drained.
+		return
+	}
+	defer c.queueMu.Unlock()
+
+	var (
+		maxID string
+		wait  sync.WaitGroup
+	)
+
+	for {
+		// Load next page of deliveries due for an attempt.
+		deliveries, err := c.state.DB.GetDueDeliveries(ctx,
+			time.Now(),
+			maxID,
+			deliveryQueuePageSize,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "error getting due deliveries: %v", err)
+			return
+		}
+
+		if len(deliveries) == 0 {
+			// Reached end.
+			return
+		}
+
+		// Set next page from last delivery.
+		maxID = deliveries[len(deliveries)-1].ID
+
+		for _, d := range deliveries {
+			d := d // rescope
+
+			wait.Add(1)
+			if !c.state.Workers.Delivery.EnqueueCtx(ctx, func(ctx context.Context) {
+				defer wait.Done()
+				c.retryDelivery(ctx, d)
+			}) {
+				// Context was cancelled
+				// before we could enqueue.
+				wait.Done()
+			}
+		}
+
+		// Wait for page to finish.
+		wait.Wait()
+
+		if err := ctx.Err(); err != nil {
+			// Stopped draining.
+			return
+		}
+	}
+}
+
+// retryDelivery makes another attempt at the given queued delivery.
+func (c *controller) retryDelivery(ctx context.Context, d *gtsmodel.Delivery) {
+	// Don't bother retrying deliveries
+	// to a domain that's since been blocked.
+	blocked, err := c.state.DB.IsDomainBlocked(ctx, d.TargetDomain)
+	if err != nil {
+		log.Errorf(ctx, "error checking domain block for %s: %v", d.TargetDomain, err)
+		return
+	}
+
+	if blocked {
+		log.Debugf(ctx, "dropping delivery to blocked domain %s", d.TargetDomain)
+		c.dropDelivery(ctx, d)
+		return
+	}
+
+	// Get the local account which queued this delivery, in order to sign it.
+	account, err := c.state.DB.GetAccountByPubkeyID(gtscontext.SetBarebones(ctx), d.PubKeyID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Account has since been deleted.
+			log.Debugf(ctx, "dropping delivery signed by missing key %s", d.PubKeyID)
+			c.dropDelivery(ctx, d)
+			return
+		}
+
+		log.Errorf(ctx, "error getting account for key %s: %v", d.PubKeyID, err)
+		return
+	}
+
+	tsport, err := c.NewTransport(account.PublicKeyURI, account.PrivateKey)
+	if err != nil {
+		log.Errorf(ctx, "error creating transport for key %s: %v", d.PubKeyID, err)
+		return
+	}
+
+	// Attempt to deliver data to recipient.
+	err = tsport.(*transport).deliver(ctx, d)
+	if err != nil {
+		log.Warnf(ctx, "error retrying delivery to %s: %v", d.TargetURI, err)
+	}
+
+	// Update queue with result.
+	c.handleDeliveryResult(ctx, d, err)
+}
+
+// handleDeliveryResult updates the delivery queue with the
+// result of an attempt at the given delivery. A delivery is
+// only added to the queue once its first attempt has failed
+// and it should be retried; after that, it's removed on success,
+// rescheduled with backoff on failure, or dropped entirely if
+// it should no longer be retried.
+func (c *controller) handleDeliveryResult(ctx context.Context, d *gtsmodel.Delivery, err error) {
+	// Update queue using detached context, as
+	// a failure may be due to ctx cancellation.
+	ctx = gtscontext.WithValues(context.Background(), ctx)
+
+	// Only deliveries which have
+	// already failed are queued.
+	queued := d.Attempts > 0
+
+	if err == nil {
+		// Delivered!
+		if queued {
+			c.dropDelivery(ctx, d)
+		}
+		return
+	}
+
+	now := time.Now()
+	d.Attempts++
+	d.LastAttemptAt = now
+	d.LastError = err.Error()
+
+	if d.Attempts >= deliveryMaxAttempts {
+		log.Warnf(ctx, "giving up on delivery to %s after %d attempts", d.TargetURI, d.Attempts)
+		if queued {
+			c.dropDelivery(ctx, d)
+		}
+		return
+	}
+
+	if deliveryRejected(err) {
+		log.Warnf(ctx, "delivery to %s rejected, not retrying: %v", d.TargetURI, err)
+		if queued {
+			c.dropDelivery(ctx, d)
+		}
+		return
+	}
+
+	d.NextAttemptAt = now.Add(deliveryBackoff(d.Attempts))
+
+	if !queued {
+		// First attempt failed,
+		// queue it to retry later.
+		if err := c.state.DB.PutDeliveries(ctx, d); err != nil {
+			log.Errorf(ctx, "error queueing delivery %s: %v", d.ID, err)
+		}
+		return
+	}
+
+	if err := c.state.DB.UpdateDelivery(ctx, d,
+		"attempts",
+		"last_attempt_at",
+		"last_error",
+		"next_attempt_at",
+	); err != nil {
+		log.Errorf(ctx, "error updating delivery %s: %v", d.ID, err)
+	}
+}
+
+// dropDelivery removes the given delivery from the queue.
+func (c *controller) dropDelivery(ctx context.Context, d *gtsmodel.Delivery) {
+	if err := c.state.DB.DeleteDeliveryByID(ctx, d.ID); err != nil {
+		log.Errorf(ctx, "error deleting delivery %s: %v", d.ID, err)
+	}
+}
+
+// deliveryBackoff returns the time to wait before the next
+// attempt at a delivery which has failed the given no. times.
+func deliveryBackoff(attempts int) time.Duration {
+	backoff := deliveryBackoffMin
+	for i := 1; i < attempts && backoff < deliveryBackoffMax; i++ {
+		backoff *= 2
+	}
+	return min(backoff, deliveryBackoffMax)
+}
+
+// deliveryRejected returns whether the given delivery error
+// indicates the remote outright rejected the delivery, such
+// that there's no point retrying it.
+func deliveryRejected(err error) bool {
+	switch code := gtserror.StatusCode(err); code {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests:
+		return false
+	default:
+		return code >= 400 && code < 500
+	}
+}
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (t *transport) DereferenceDomainPermissions(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+) (io.ReadCloser, string, error) {
+	// Prepare HTTP request to the list URI.
+	req, err := http.NewRequestWithContext(ctx, "GET", permSub.URI, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Add("Accept", string(permSub.ContentType)+",*/*")
+
+	// Set basic auth credentials if provided.
+	if permSub.FetchUsername != "" || permSub.FetchPassword != "" {
+		req.SetBasicAuth(permSub.FetchUsername, permSub.FetchPassword)
+	}
+
+	// Set caching headers from the last
+	// successful fetch, so the remote can
+	// tell us if nothing has changed.
+	if permSub.ETag != "" {
+		req.Header.Set("If-None-Match", permSub.ETag)
+	}
+	if !permSub.SuccessfullyFetchedAt.IsZero() {
+		req.Header.Set("If-Modified-Since", permSub.SuccessfullyFetchedAt.UTC().Format(http.TimeFormat))
+	}
+
+	// Perform the HTTP request
+	rsp, err := t.GET(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch rsp.StatusCode {
+	case http.StatusOK:
+		// Fetched list, return body.
+		return rsp.Body, rsp.Header.Get("ETag"), nil
+
+	case http.StatusNotModified:
+		// List unchanged since last fetch.
+		_ = rsp.Body.Close()
+		return nil, permSub.ETag, nil
+
+	default:
+		_ = rsp.Body.Close()
+		return nil, "", gtserror.NewFromResponse(rsp)
+	}
+}
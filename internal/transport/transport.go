@@ -73,6 +73,12 @@ type Transport interface {
 	// DereferenceInstance dereferences remote instance information, first by checking /api/v1/instance, and then by checking /.well-known/nodeinfo.
 	DereferenceInstance(ctx context.Context, iri *url.URL) (*gtsmodel.Instance, error)
 
+	// DereferenceDomainPermissions dereferences the list of domain permissions
+	// at the given subscription's URI, using its fetch credentials and caching
+	// headers. Returns the response body and the latest ETag, or a nil body if
+	// the list was not modified since it was last successfully fetched.
+	DereferenceDomainPermissions(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription) (io.ReadCloser, string, error)
+
 	// Finger performs a webfinger request with the given username and domain, and returns the bytes from the response body.
 	Finger(ctx context.Context, targetUsername string, targetDomain string) ([]byte, error)
 }
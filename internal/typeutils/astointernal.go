@@ -202,7 +202,11 @@ func (c *Converter) ASRepresentationToAccount(ctx context.Context, accountable a
 		acct.FeaturedCollectionURI = featuredURI.String()
 	}
 
-	// TODO: FeaturedTagsURI
+	// Extract a FeaturedTagsURI, but only trust if equal to / subdomain of account's domain.
+	if featuredTagsURI := ap.GetFeaturedTags(accountable); // nocollapse
+	featuredTagsURI != nil && dns.CompareDomainName(acct.Domain, featuredTagsURI.Host) >= 2 {
+		acct.FeaturedTagsURI = featuredTagsURI.String()
+	}
 
 	// Moved and AlsoKnownAsURIs,
 	// needed for account migrations.
@@ -345,6 +349,14 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		log.Warnf(ctx, "unusable published property on %s", uri)
 	}
 
+	// status.Updated
+	//
+	// Extract time of last edit for the status,
+	// only if it's actually after creation time.
+	if upd := ap.GetUpdated(statusable); upd.After(status.CreatedAt) {
+		status.EditedAt = upd
+	}
+
 	// status.AccountURI
 	// status.AccountID
 	// status.Account
@@ -419,6 +431,15 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 
 	// status.Sensitive
 	sensitive := ap.ExtractSensitive(statusable)
+
+	// Apply any admin content warning
+	// policy targeting the status author.
+	status.ContentWarning, sensitive = c.applyContentWarningPolicy(
+		ctx,
+		status.Account,
+		status.ContentWarning,
+		sensitive,
+	)
 	status.Sensitive = &sensitive
 
 	// ActivityStreamsType
@@ -147,6 +147,7 @@ func (suite *ASToInternalTestSuite) TestParseGargron() {
 	suite.NoError(err)
 	suite.Equal("https://mastodon.social/inbox", *acct.SharedInboxURI)
 	suite.Equal([]string{"https://tooting.ai/users/Gargron"}, acct.AlsoKnownAsURIs)
+	suite.Equal("https://mastodon.social/users/Gargron/collections/tags", acct.FeaturedTagsURI)
 	suite.Equal(int64(1458086400), acct.CreatedAt.Unix())
 }
 
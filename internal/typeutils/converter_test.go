@@ -473,16 +473,19 @@ const (
 
 type TypeUtilsTestSuite struct {
 	suite.Suite
-	db              db.DB
-	state           state.State
-	testAccounts    map[string]*gtsmodel.Account
-	testStatuses    map[string]*gtsmodel.Status
-	testAttachments map[string]*gtsmodel.MediaAttachment
-	testPeople      map[string]vocab.ActivityStreamsPerson
-	testEmojis      map[string]*gtsmodel.Emoji
-	testReports     map[string]*gtsmodel.Report
-	testMentions    map[string]*gtsmodel.Mention
-	testPollVotes   map[string]*gtsmodel.PollVote
+	db                 db.DB
+	state              state.State
+	testAccounts       map[string]*gtsmodel.Account
+	testStatuses       map[string]*gtsmodel.Status
+	testAttachments    map[string]*gtsmodel.MediaAttachment
+	testPeople         map[string]vocab.ActivityStreamsPerson
+	testEmojis         map[string]*gtsmodel.Emoji
+	testReports        map[string]*gtsmodel.Report
+	testMentions       map[string]*gtsmodel.Mention
+	testPollVotes      map[string]*gtsmodel.PollVote
+	testFilters        map[string]*gtsmodel.Filter
+	testFilterKeywords map[string]*gtsmodel.FilterKeyword
+	testFilterStatuses map[string]*gtsmodel.FilterStatus
 
 	typeconverter *typeutils.Converter
 }
@@ -506,6 +509,9 @@ func (suite *TypeUtilsTestSuite) SetupTest() {
 	suite.testReports = testrig.NewTestReports()
 	suite.testMentions = testrig.NewTestMentions()
 	suite.testPollVotes = testrig.NewTestPollVotes()
+	suite.testFilters = testrig.NewTestFilters()
+	suite.testFilterKeywords = testrig.NewTestFilterKeywords()
+	suite.testFilterStatuses = testrig.NewTestFilterStatuses()
 	suite.typeconverter = typeutils.NewConverter(&suite.state)
 
 	testrig.StandardDBSetup(suite.db, nil)
@@ -47,3 +47,38 @@ func APIMarkerNameToMarkerName(m apimodel.MarkerName) gtsmodel.MarkerName {
 	}
 	return ""
 }
+
+func APIFilterActionToFilterAction(m apimodel.FilterAction) gtsmodel.FilterAction {
+	switch m {
+	case apimodel.FilterActionWarn:
+		return gtsmodel.FilterActionWarn
+	case apimodel.FilterActionHide:
+		return gtsmodel.FilterActionHide
+	}
+	return gtsmodel.FilterActionNone
+}
+
+// APIFilterContextsToFilter sets the context fields of
+// the given filter according to the given API contexts.
+// Contexts not present in the slice are set to false.
+func APIFilterContextsToFilter(contexts []apimodel.FilterContext, filter *gtsmodel.Filter) {
+	filter.ContextHome = new(bool)
+	filter.ContextNotifications = new(bool)
+	filter.ContextPublic = new(bool)
+	filter.ContextThread = new(bool)
+	filter.ContextAccount = new(bool)
+	for _, context := range contexts {
+		switch context {
+		case apimodel.FilterContextHome:
+			*filter.ContextHome = true
+		case apimodel.FilterContextNotifications:
+			*filter.ContextNotifications = true
+		case apimodel.FilterContextPublic:
+			*filter.ContextPublic = true
+		case apimodel.FilterContextThread:
+			*filter.ContextThread = true
+		case apimodel.FilterContextAccount:
+			*filter.ContextAccount = true
+		}
+	}
+}
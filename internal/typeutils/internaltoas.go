@@ -118,7 +118,14 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 	person.SetTootFeatured(featuredProp)
 
 	// featuredTags
-	// NOT IMPLEMENTED
+	// Hashtags featured on the profile.
+	if a.FeaturedTagsURI != "" {
+		featuredTagsURI, err := url.Parse(a.FeaturedTagsURI)
+		if err != nil {
+			return nil, err
+		}
+		ap.SetFeaturedTags(person, featuredTagsURI)
+	}
 
 	// preferredUsername
 	// Used for Webfinger lookup. Must be unique on the domain, and must correspond to a Webfinger acct: URI.
@@ -488,6 +495,11 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	publishedProp.Set(s.CreatedAt)
 	status.SetActivityStreamsPublished(publishedProp)
 
+	// updated
+	if !s.EditedAt.IsZero() {
+		ap.SetUpdated(status, s.EditedAt)
+	}
+
 	// url
 	if s.URL != "" {
 		sURL, err := url.Parse(s.URL)
@@ -669,7 +681,7 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 
 	// sensitive
 	sensitiveProp := streams.NewActivityStreamsSensitiveProperty()
-	sensitiveProp.AppendXMLSchemaBoolean(*s.Sensitive)
+	sensitiveProp.AppendXMLSchemaBoolean(s.IsSensitive())
 	status.SetActivityStreamsSensitive(sensitiveProp)
 
 	return status, nil
@@ -1623,6 +1635,41 @@ func (c *Converter) StatusesToASFeaturedCollection(ctx context.Context, featured
 	return collection, nil
 }
 
+// FeaturedTagsToASCollection converts a slice of gts model featured tags (with tags
+// populated) into an activitystreams collection of hashtags, suitable for serving
+// at /users/:username/collections/tags
+func (c *Converter) FeaturedTagsToASCollection(ctx context.Context, featuredTagsID string, featuredTags []*gtsmodel.FeaturedTag) (vocab.ActivityStreamsCollection, error) {
+	collection := streams.NewActivityStreamsCollection()
+
+	collectionIDProp := streams.NewJSONLDIdProperty()
+	featuredTagsIDURI, err := url.Parse(featuredTagsID)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing url %s", featuredTagsID)
+	}
+	collectionIDProp.SetIRI(featuredTagsIDURI)
+	collection.SetJSONLDId(collectionIDProp)
+
+	itemsProp := streams.NewActivityStreamsItemsProperty()
+	for _, ft := range featuredTags {
+		if ft.Tag == nil {
+			return nil, gtserror.Newf("featured tag %s tag was nil", ft.ID)
+		}
+
+		hashtag, err := c.TagToAS(ctx, ft.Tag)
+		if err != nil {
+			return nil, err
+		}
+		itemsProp.AppendTootHashtag(hashtag)
+	}
+	collection.SetActivityStreamsItems(itemsProp)
+
+	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProp.Set(len(featuredTags))
+	collection.SetActivityStreamsTotalItems(totalItemsProp)
+
+	return collection, nil
+}
+
 // ReportToASFlag converts a gts model report into an activitystreams FLAG, suitable for federation.
 func (c *Converter) ReportToASFlag(ctx context.Context, r *gtsmodel.Report) (vocab.ActivityStreamsFlag, error) {
 	flag := streams.NewActivityStreamsFlag()
@@ -85,6 +85,26 @@ func (suite *InternalToASTestSuite) TestAccountToAS() {
 }`, trimmed)
 }
 
+func (suite *InternalToASTestSuite) TestAccountToASWithFeaturedTags() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"] // take zork for this test
+	testAccount.FeaturedTagsURI = "http://localhost:8080/users/the_mighty_zork/collections/tags"
+
+	asPerson, err := suite.typeconverter.AccountToAS(context.Background(), testAccount)
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(asPerson)
+	suite.NoError(err)
+
+	suite.Equal("http://localhost:8080/users/the_mighty_zork/collections/tags", ser["featuredTags"])
+
+	// featuredTags isn't in the vocab, so
+	// it needs its own term in the context.
+	bytes, err := json.Marshal(ser["@context"])
+	suite.NoError(err)
+	suite.Contains(string(bytes), `{"featuredTags":{"@id":"http://joinmastodon.org/ns#featuredTags","@type":"@id"}}`)
+}
+
 func (suite *InternalToASTestSuite) TestAccountToASWithFields() {
 	testAccount := &gtsmodel.Account{}
 	*testAccount = *suite.testAccounts["local_account_2"]
@@ -22,17 +22,23 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -44,7 +50,7 @@ const (
 	instanceMediaAttachmentsVideoFrameRateLimit = 60
 	instancePollsMinExpiration                  = 300     // seconds
 	instancePollsMaxExpiration                  = 2629746 // seconds
-	instanceAccountsMaxFeaturedTags             = 10
+	instanceAccountsMaxFeaturedTags             = gtsmodel.MaxFeaturedTags
 	instanceAccountsMaxProfileFields            = 6 // FIXME: https://github.com/superseriousbusiness/gotosocial/issues/1876
 	instanceSourceURL                           = "https://github.com/superseriousbusiness/gotosocial"
 	instanceMastodonVersion                     = "3.5.3"
@@ -368,6 +374,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 	var (
 		email                  string
 		ip                     *string
+		ips                    = []apimodel.AdminIP{}
 		domain                 *string
 		locale                 string
 		confirmed              bool
@@ -376,6 +383,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		disabled               bool
 		role                   = apimodel.AccountRole{Name: apimodel.AccountRoleUser} // assume user by default
 		createdByApplicationID string
+		invitedByAccountID     string
 	)
 
 	if a.IsRemote() {
@@ -405,6 +413,34 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 			ip = &i
 		}
 
+		// Collect all known IPs, most recently used
+		// first, skipping any duplicates, and the
+		// placeholder IPs left on stubbed users.
+		for _, u := range []struct {
+			ip     net.IP
+			usedAt time.Time
+		}{
+			{user.CurrentSignInIP, user.CurrentSignInAt},
+			{user.LastSignInIP, user.LastSignInAt},
+			{user.SignUpIP, user.CreatedAt},
+		} {
+			if u.ip == nil || u.ip.IsUnspecified() || slices.ContainsFunc(ips, func(i apimodel.AdminIP) bool {
+				return i.IP == u.ip.String()
+			}) {
+				continue
+			}
+
+			var usedAt string
+			if !u.usedAt.IsZero() {
+				usedAt = util.FormatISO8601(u.usedAt)
+			}
+
+			ips = append(ips, apimodel.AdminIP{
+				IP:     u.ip.String(),
+				UsedAt: usedAt,
+			})
+		}
+
 		locale = user.Locale
 		if user.Account.Reason != "" {
 			inviteRequest = &user.Account.Reason
@@ -420,6 +456,19 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		approved = *user.Approved
 		disabled = *user.Disabled
 		createdByApplicationID = user.CreatedByApplicationID
+
+		if user.InviteID != "" {
+			// User registered using an invite,
+			// show who created that invite.
+			invite, err := c.state.DB.GetInviteByID(gtscontext.SetBarebones(ctx), user.InviteID)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return nil, fmt.Errorf("AccountToAdminAPIAccount: error getting invite %s from database for account id %s: %w", user.InviteID, a.ID, err)
+			}
+
+			if invite != nil {
+				invitedByAccountID = invite.AccountID
+			}
+		}
 	}
 
 	apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
@@ -434,18 +483,19 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		CreatedAt:              util.FormatISO8601(a.CreatedAt),
 		Email:                  email,
 		IP:                     ip,
-		IPs:                    []interface{}{}, // not implemented,
+		IPs:                    ips,
 		Locale:                 locale,
 		InviteRequest:          inviteRequest,
 		Role:                   role,
 		Confirmed:              confirmed,
 		Approved:               approved,
 		Disabled:               disabled,
-		Silenced:               !a.SilencedAt.IsZero(),
+		Silenced:               a.IsSilenced(),
+		Sensitized:             a.IsSensitized(),
 		Suspended:              !a.SuspendedAt.IsZero(),
 		Account:                apiAccount,
 		CreatedByApplicationID: createdByApplicationID,
-		InvitedByAccountID:     "", // not implemented (yet)
+		InvitedByAccountID:     invitedByAccountID,
 	}, nil
 }
 
@@ -470,6 +520,84 @@ func (c *Converter) AppToAPIAppPublic(ctx context.Context, a *gtsmodel.Applicati
 	}, nil
 }
 
+// TokenToAPITokenInfo converts a gts model oauth token into an api model token info,
+// for serving at /api/v1/tokens. The token itself is not included in the result.
+func (c *Converter) TokenToAPITokenInfo(ctx context.Context, t *gtsmodel.Token) (*apimodel.TokenInfo, error) {
+	createdAt := t.AccessCreateAt
+	if createdAt.IsZero() {
+		createdAt = t.CreatedAt
+	}
+
+	var lastUsed *string
+	if !t.LastUsed.IsZero() {
+		lastUsed = util.Ptr(util.FormatISO8601(t.LastUsed))
+	}
+
+	var apiApp *apimodel.Application
+	app, err := c.state.DB.GetApplicationByClientID(ctx, t.ClientID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting application for token %s: %w", t.ID, err)
+	}
+
+	if app != nil {
+		apiApp, err = c.AppToAPIAppPublic(ctx, app)
+		if err != nil {
+			return nil, gtserror.Newf("error converting application: %w", err)
+		}
+		apiApp.ID = app.ID
+	}
+
+	return &apimodel.TokenInfo{
+		ID:          t.ID,
+		CreatedAt:   util.FormatISO8601(createdAt),
+		LastUsed:    lastUsed,
+		Scope:       t.Scope,
+		Application: apiApp,
+	}, nil
+}
+
+// CardToAPICard converts a gts model preview card into its api representation for serialization on the API.
+func (c *Converter) CardToAPICard(ctx context.Context, card *gtsmodel.Card) (*apimodel.Card, error) {
+	if card.ImageID != "" && card.Image == nil {
+		var err error
+		card.Image, err = c.state.DB.GetAttachmentByID(ctx, card.ImageID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting card image: %w", err)
+		}
+	}
+
+	apiCard := &apimodel.Card{
+		URL:          card.URL,
+		Title:        card.Title,
+		Description:  card.Description,
+		Type:         string(card.Type),
+		AuthorName:   card.AuthorName,
+		AuthorURL:    card.AuthorURL,
+		ProviderName: card.ProviderName,
+		ProviderURL:  card.ProviderURL,
+		HTML:         card.HTML,
+		Width:        card.Width,
+		Height:       card.Height,
+		EmbedURL:     card.EmbedURL,
+	}
+
+	if image := card.Image; image != nil {
+		// Serve the (smaller) thumbnail
+		// of the image as card preview.
+		apiCard.Image = image.Thumbnail.URL
+		apiCard.Blurhash = image.Blurhash
+
+		if apiCard.Width == 0 && apiCard.Height == 0 {
+			// Link cards take the
+			// size of their image.
+			apiCard.Width = image.FileMeta.Small.Width
+			apiCard.Height = image.FileMeta.Small.Height
+		}
+	}
+
+	return apiCard, nil
+}
+
 // AttachmentToAPIAttachment converts a gts model media attacahment into its api representation for serialization on the API.
 func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.MediaAttachment) (apimodel.Attachment, error) {
 	apiAttachment := apimodel.Attachment{
@@ -647,35 +775,166 @@ func (c *Converter) EmojiCategoryToAPIEmojiCategory(ctx context.Context, categor
 
 // TagToAPITag converts a gts model tag into its api (frontend) representation for serialization on the API.
 // If stubHistory is set to 'true', then the 'history' field of the tag will be populated with a pointer to an empty slice, for API compatibility reasons.
-func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistory bool) (apimodel.Tag, error) {
+// If following is non-nil, it will be used to set the 'following' field of the tag.
+func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistory bool, following *bool) (apimodel.Tag, error) {
 	return apimodel.Tag{
 		Name: strings.ToLower(t.Name),
 		URL:  uris.URIForTag(t.Name),
-		History: func() *[]any {
+		History: func() *[]apimodel.History {
 			if !stubHistory {
 				return nil
 			}
 
-			h := make([]any, 0)
+			h := make([]apimodel.History, 0)
 			return &h
 		}(),
+		Following: following,
 	}, nil
 }
 
+// FeaturedTagToAPIFeaturedTag converts a gts model featured tag
+// (with tag populated) into its api (frontend) representation.
+func (c *Converter) FeaturedTagToAPIFeaturedTag(ctx context.Context, ft *gtsmodel.FeaturedTag) (*apimodel.FeaturedTag, error) {
+	if ft.Tag == nil {
+		return nil, gtserror.Newf("featured tag %s tag was nil", ft.ID)
+	}
+
+	var lastStatusAt *string
+	if !ft.LastStatusAt.IsZero() {
+		lastStatusAt = util.Ptr(util.FormatISO8601(ft.LastStatusAt))
+	}
+
+	return &apimodel.FeaturedTag{
+		ID:            ft.ID,
+		Name:          strings.ToLower(ft.Tag.Name),
+		URL:           uris.URIForTag(ft.Tag.Name),
+		StatusesCount: ft.StatusesCount,
+		LastStatusAt:  lastStatusAt,
+	}, nil
+}
+
+// TrendToAPITag converts a gts model trend of type tag into its api
+// (frontend) tag representation, with history populated from the trend.
+func (c *Converter) TrendToAPITag(ctx context.Context, t *gtsmodel.Trend) (*apimodel.Tag, error) {
+	if t.Tag == nil {
+		return nil, gtserror.Newf("trend %s tag was nil", t.ID)
+	}
+
+	apiTag, err := c.TagToAPITag(ctx, t.Tag, false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	history := trendHistoryToAPIHistory(t.History)
+	apiTag.History = &history
+
+	return &apiTag, nil
+}
+
+// TrendToAPITrendsLink converts a gts model trend of type link into its api
+// (frontend) trending link representation, with history populated from the trend.
+func (c *Converter) TrendToAPITrendsLink(ctx context.Context, t *gtsmodel.Trend) (*apimodel.TrendsLink, error) {
+	if t.Card == nil {
+		return nil, gtserror.Newf("trend %s card was nil", t.ID)
+	}
+
+	apiCard, err := c.CardToAPICard(ctx, t.Card)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apimodel.TrendsLink{
+		Card:    *apiCard,
+		History: trendHistoryToAPIHistory(t.History),
+	}, nil
+}
+
+// trendHistoryToAPIHistory converts gts model trend
+// history into its api (frontend) representation.
+func trendHistoryToAPIHistory(in []gtsmodel.TrendHistory) []apimodel.History {
+	history := make([]apimodel.History, len(in))
+	for i, h := range in {
+		history[i] = apimodel.History{
+			Day:      strconv.FormatInt(h.Day.Unix(), 10),
+			Uses:     strconv.Itoa(h.Uses),
+			Accounts: strconv.Itoa(h.Accounts),
+		}
+	}
+	return history
+}
+
+// TrendToAdminAPITrend converts a gts model trend into its admin api
+// (frontend) representation, for serialization on the admin API.
+func (c *Converter) TrendToAdminAPITrend(ctx context.Context, t *gtsmodel.Trend, requestingAccount *gtsmodel.Account) (*apimodel.AdminTrend, error) {
+	apiTrend := &apimodel.AdminTrend{
+		ID:     t.ID,
+		Type:   t.Type.String(),
+		Score:  t.Score,
+		Review: t.Review.String(),
+	}
+
+	if !t.ReviewedAt.IsZero() {
+		reviewedAt := util.FormatISO8601(t.ReviewedAt)
+		apiTrend.ReviewedAt = &reviewedAt
+	}
+
+	var err error
+
+	switch t.Type {
+	case gtsmodel.TrendTypeTag:
+		apiTrend.Tag, err = c.TrendToAPITag(ctx, t)
+		if err != nil {
+			return nil, gtserror.Newf("error converting trend tag: %w", err)
+		}
+
+	case gtsmodel.TrendTypeStatus:
+		if t.Status == nil {
+			return nil, gtserror.Newf("trend %s status was nil", t.ID)
+		}
+
+		apiTrend.Status, err = c.StatusToAPIStatus(ctx, t.Status, requestingAccount, gtsmodel.FilterContextNone, nil)
+		if err != nil {
+			return nil, gtserror.Newf("error converting trend status: %w", err)
+		}
+
+	case gtsmodel.TrendTypeLink:
+		apiTrend.Link, err = c.TrendToAPITrendsLink(ctx, t)
+		if err != nil {
+			return nil, gtserror.Newf("error converting trend link: %w", err)
+		}
+	}
+
+	return apiTrend, nil
+}
+
 // StatusToAPIStatus converts a gts model status into its api
 // (frontend) representation for serialization on the API.
 //
 // Requesting account can be nil.
+//
+// Filter context can be the empty string if these statuses are not being filtered.
+//
+// If there is a matching "hide" filter, the returned status will be nil with a ErrHideStatus error;
+// callers need to handle that case by excluding it from results.
 func (c *Converter) StatusToAPIStatus(
 	ctx context.Context,
 	s *gtsmodel.Status,
 	requestingAccount *gtsmodel.Account,
+	filterContext gtsmodel.FilterContext,
+	filters []*gtsmodel.Filter,
 ) (*apimodel.Status, error) {
 	apiStatus, err := c.statusToFrontend(ctx, s, requestingAccount)
 	if err != nil {
 		return nil, err
 	}
 
+	// Apply any filters that match this status.
+	filtered, err := c.statusToAPIFilterResults(ctx, s, requestingAccount, filterContext, filters)
+	if err != nil {
+		return nil, err
+	}
+	apiStatus.Filtered = filtered
+
 	// Normalize status for the API by pruning
 	// out unknown attachment types and replacing
 	// them with a helpful message.
@@ -848,13 +1107,31 @@ func (c *Converter) statusToFrontend(
 		log.Errorf(ctx, "error converting status emojis: %v", err)
 	}
 
+	var apiCard *apimodel.Card
+	if s.Card != nil {
+		apiCard, err = c.CardToAPICard(ctx, s.Card)
+		if err != nil {
+			log.Errorf(ctx, "error converting status card: %v", err)
+		}
+	}
+
+	// Apply any admin content warning policy targeting
+	// the status author, covering statuses that were
+	// stored before the policy was created.
+	spoilerText, sensitive := c.applyContentWarningPolicy(
+		ctx,
+		s.Account,
+		s.ContentWarning,
+		s.IsSensitive(),
+	)
+
 	apiStatus := &apimodel.Status{
 		ID:                 s.ID,
 		CreatedAt:          util.FormatISO8601(s.CreatedAt),
 		InReplyToID:        nil, // Set below.
 		InReplyToAccountID: nil, // Set below.
-		Sensitive:          *s.Sensitive,
-		SpoilerText:        s.ContentWarning,
+		Sensitive:          sensitive,
+		SpoilerText:        spoilerText,
 		Visibility:         c.VisToAPIVis(ctx, s.Visibility),
 		Language:           nil, // Set below.
 		URI:                s.URI,
@@ -875,7 +1152,7 @@ func (c *Converter) statusToFrontend(
 		Mentions:           apiMentions,
 		Tags:               apiTags,
 		Emojis:             apiEmojis,
-		Card:               nil, // TODO: implement cards
+		Card:               apiCard,
 		Text:               s.Text,
 	}
 
@@ -892,8 +1169,14 @@ func (c *Converter) statusToFrontend(
 		apiStatus.Language = util.Ptr(s.Language)
 	}
 
+	if !s.EditedAt.IsZero() {
+		apiStatus.EditedAt = util.Ptr(util.FormatISO8601(s.EditedAt))
+	}
+
 	if s.BoostOf != nil {
-		reblog, err := c.StatusToAPIStatus(ctx, s.BoostOf, requestingAccount)
+		// Filters are applied to the boost itself,
+		// which checks the boosted status content.
+		reblog, err := c.StatusToAPIStatus(ctx, s.BoostOf, requestingAccount, gtsmodel.FilterContextNone, nil)
 		if err != nil {
 			return nil, gtserror.Newf("error converting boosted status: %w", err)
 		}
@@ -932,6 +1215,105 @@ func (c *Converter) statusToFrontend(
 	return apiStatus, nil
 }
 
+// statusToAPIFilterResults applies filters to a status and returns an API filter result object.
+// The result may be nil if no filters matched.
+// If the status should not be returned at all, it returns the ErrHideStatus error.
+func (c *Converter) statusToAPIFilterResults(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+	filterContext gtsmodel.FilterContext,
+	filters []*gtsmodel.Filter,
+) ([]apimodel.FilterResult, error) {
+	if filterContext == gtsmodel.FilterContextNone || len(filters) == 0 || requestingAccount == nil {
+		return nil, nil
+	}
+
+	// If this is a boost, the boosted
+	// status is the one to check.
+	target := s
+	if s.BoostOf != nil {
+		target = s.BoostOf
+	}
+
+	// Users never see their own
+	// statuses filtered out.
+	if target.AccountID == requestingAccount.ID {
+		return nil, nil
+	}
+
+	// Gather the status text fields that keywords will be matched against.
+	fields := make([]string, 0, 2+len(target.Attachments))
+	fields = append(fields, text.SanitizeToPlaintext(target.Content))
+	if target.ContentWarning != "" {
+		fields = append(fields, target.ContentWarning)
+	}
+	if target.Poll != nil {
+		fields = append(fields, target.Poll.Options...)
+	}
+	for _, attachment := range target.Attachments {
+		if attachment.Description != "" {
+			fields = append(fields, attachment.Description)
+		}
+	}
+
+	now := time.Now()
+	filterResults := make([]apimodel.FilterResult, 0)
+	for _, filter := range filters {
+		if !filter.AppliesInContext(filterContext) || filter.Expired(now) {
+			continue
+		}
+
+		var keywordMatches []string
+		for _, filterKeyword := range filter.Keywords {
+			if filterKeyword.Regexp == nil {
+				continue
+			}
+
+			for _, field := range fields {
+				if filterKeyword.Regexp.MatchString(field) {
+					keywordMatches = append(keywordMatches, filterKeyword.Keyword)
+					break
+				}
+			}
+		}
+
+		var statusMatches []string
+		for _, filterStatus := range filter.Statuses {
+			if filterStatus.StatusID == s.ID || filterStatus.StatusID == target.ID {
+				statusMatches = append(statusMatches, filterStatus.StatusID)
+			}
+		}
+
+		if len(keywordMatches) == 0 && len(statusMatches) == 0 {
+			continue
+		}
+
+		switch filter.Action {
+		case gtsmodel.FilterActionWarn:
+			apiFilter, err := c.FilterToAPIFilterV2(ctx, filter)
+			if err != nil {
+				return nil, gtserror.Newf("error converting filter %s: %w", filter.ID, err)
+			}
+
+			filterResults = append(filterResults, apimodel.FilterResult{
+				Filter:         *apiFilter,
+				KeywordMatches: keywordMatches,
+				StatusMatches:  statusMatches,
+			})
+
+		case gtsmodel.FilterActionHide:
+			return nil, statusfilter.ErrHideStatus
+		}
+	}
+
+	if len(filterResults) == 0 {
+		return nil, nil
+	}
+
+	return filterResults, nil
+}
+
 // VisToAPIVis converts a gts visibility into its api equivalent
 func (c *Converter) VisToAPIVis(ctx context.Context, m gtsmodel.Visibility) apimodel.Visibility {
 	switch m {
@@ -991,7 +1373,7 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 		Languages:            config.GetInstanceLanguages().TagStrs(),
 		Registrations:        config.GetAccountsRegistrationOpen(),
 		ApprovalRequired:     config.GetAccountsApprovalRequired(),
-		InvitesEnabled:       false, // todo: not supported yet
+		InvitesEnabled:       config.GetAccountsInvitesEnabled(),
 		MaxTootChars:         uint(config.GetStatusesMaxChars()),
 		Rules:                c.InstanceRulesToAPIRules(i.Rules),
 		Terms:                i.Terms,
@@ -1203,7 +1585,11 @@ func (c *Converter) RelationshipToAPIRelationship(ctx context.Context, r *gtsmod
 }
 
 // NotificationToAPINotification converts a gts notification into a api notification
-func (c *Converter) NotificationToAPINotification(ctx context.Context, n *gtsmodel.Notification) (*apimodel.Notification, error) {
+func (c *Converter) NotificationToAPINotification(
+	ctx context.Context,
+	n *gtsmodel.Notification,
+	filters []*gtsmodel.Filter,
+) (*apimodel.Notification, error) {
 	if n.TargetAccount == nil {
 		tAccount, err := c.state.DB.GetAccountByID(ctx, n.TargetAccountID)
 		if err != nil {
@@ -1244,8 +1630,11 @@ func (c *Converter) NotificationToAPINotification(ctx context.Context, n *gtsmod
 		}
 
 		var err error
-		apiStatus, err = c.StatusToAPIStatus(ctx, n.Status, n.TargetAccount)
+		apiStatus, err = c.StatusToAPIStatus(ctx, n.Status, n.TargetAccount, gtsmodel.FilterContextNotifications, filters)
 		if err != nil {
+			if errors.Is(err, statusfilter.ErrHideStatus) {
+				return nil, err
+			}
 			return nil, fmt.Errorf("NotificationToapi: error converting status to api: %s", err)
 		}
 	}
@@ -1284,6 +1673,14 @@ func (c *Converter) DomainPermToAPIDomainPerm(
 		},
 	}
 
+	// Domain blocks also carry their limits,
+	// which are needed to re-import exports.
+	if block, ok := d.(*gtsmodel.DomainBlock); ok {
+		domainPerm.Severity = string(block.Severity)
+		domainPerm.RejectMedia = util.PtrValueOr(block.RejectMedia, false)
+		domainPerm.RejectReports = util.PtrValueOr(block.RejectReports, false)
+	}
+
 	// If we're exporting, provide
 	// only bare minimum detail.
 	if export {
@@ -1300,6 +1697,212 @@ func (c *Converter) DomainPermToAPIDomainPerm(
 	return domainPerm, nil
 }
 
+// DomainPermSubToAPIDomainPermSub converts a domain permission
+// subscription into its API model representation.
+func (c *Converter) DomainPermSubToAPIDomainPermSub(
+	ctx context.Context,
+	d *gtsmodel.DomainPermissionSubscription,
+) (*apimodel.DomainPermissionSubscription, error) {
+	var (
+		fetchedAt             string
+		successfullyFetchedAt string
+	)
+
+	if !d.FetchedAt.IsZero() {
+		fetchedAt = util.FormatISO8601(d.FetchedAt)
+	}
+
+	if !d.SuccessfullyFetchedAt.IsZero() {
+		successfullyFetchedAt = util.FormatISO8601(d.SuccessfullyFetchedAt)
+	}
+
+	return &apimodel.DomainPermissionSubscription{
+		ID:                    d.ID,
+		Priority:              d.Priority,
+		Title:                 d.Title,
+		PermissionType:        d.PermissionType.String(),
+		AdoptOrphans:          util.PtrValueOr(d.AdoptOrphans, false),
+		CreatedBy:             d.CreatedByAccountID,
+		CreatedAt:             util.FormatISO8601(d.CreatedAt),
+		URI:                   d.URI,
+		ContentType:           string(d.ContentType),
+		FetchUsername:         d.FetchUsername,
+		FetchPassword:         d.FetchPassword,
+		FetchedAt:             fetchedAt,
+		SuccessfullyFetchedAt: successfullyFetchedAt,
+		Error:                 d.Error,
+	}, nil
+}
+
+// ContentWarningPolicyToAPIContentWarningPolicy converts a content warning
+// policy into its API model representation, for serving at
+// /api/v1/admin/content_warning_policies.
+func (c *Converter) ContentWarningPolicyToAPIContentWarningPolicy(
+	ctx context.Context,
+	p *gtsmodel.ContentWarningPolicy,
+) (*apimodel.ContentWarningPolicy, error) {
+	apiPolicy := &apimodel.ContentWarningPolicy{
+		ID:             p.ID,
+		Domain:         p.Domain,
+		AccountID:      p.AccountID,
+		ContentWarning: p.ContentWarning,
+		Sensitive:      util.PtrValueOr(p.Sensitive, false),
+		CreatedBy:      p.CreatedByAccountID,
+		CreatedAt:      util.FormatISO8601(p.CreatedAt),
+	}
+
+	if p.Account != nil {
+		apiAccount, err := c.AccountToAPIAccountPublic(ctx, p.Account)
+		if err != nil {
+			return nil, gtserror.Newf("error converting account: %w", err)
+		}
+		apiPolicy.Account = apiAccount
+	}
+
+	return apiPolicy, nil
+}
+
+// EmailDomainBlockToAdminAPIEmailDomainBlock converts an email domain block into
+// its admin API model representation, for serving at /api/v1/admin/email_domain_blocks.
+func (c *Converter) EmailDomainBlockToAdminAPIEmailDomainBlock(
+	ctx context.Context,
+	b *gtsmodel.EmailDomainBlock,
+) *apimodel.AdminEmailDomainBlock {
+	return &apimodel.AdminEmailDomainBlock{
+		ID:        b.ID,
+		Domain:    b.Domain,
+		CreatedAt: util.FormatISO8601(b.CreatedAt),
+		CreatedBy: b.CreatedByAccountID,
+	}
+}
+
+// DeliveryToAPIDelivery converts a gts model queued delivery into an api model delivery, for serving at /api/v1/admin/delivery_queue.
+func (c *Converter) DeliveryToAPIDelivery(ctx context.Context, d *gtsmodel.Delivery) *apimodel.Delivery {
+	var lastAttemptAt string
+	if !d.LastAttemptAt.IsZero() {
+		lastAttemptAt = util.FormatISO8601(d.LastAttemptAt)
+	}
+
+	return &apimodel.Delivery{
+		ID:            d.ID,
+		CreatedAt:     util.FormatISO8601(d.CreatedAt),
+		TargetURI:     d.TargetURI,
+		Attempts:      d.Attempts,
+		NextAttemptAt: util.FormatISO8601(d.NextAttemptAt),
+		LastAttemptAt: lastAttemptAt,
+		LastError:     d.LastError,
+	}
+}
+
+// ScheduledStatusToAPIScheduledStatus converts a gts model scheduled status into
+// an api model scheduled status, for serving at /api/v1/scheduled_statuses.
+func (c *Converter) ScheduledStatusToAPIScheduledStatus(ctx context.Context, s *gtsmodel.ScheduledStatus) (*apimodel.ScheduledStatus, error) {
+	if err := c.state.DB.PopulateScheduledStatus(ctx, s); err != nil {
+		log.Errorf(ctx, "error(s) populating scheduled status, will continue: %v", err)
+	}
+
+	apiAttachments := make([]apimodel.Attachment, 0, len(s.MediaAttachments))
+	for _, attachment := range s.MediaAttachments {
+		apiAttachment, err := c.AttachmentToAPIAttachment(ctx, attachment)
+		if err != nil {
+			return nil, gtserror.Newf("error converting attachment %s: %w", attachment.ID, err)
+		}
+		apiAttachments = append(apiAttachments, apiAttachment)
+	}
+
+	scheduledAt := util.FormatISO8601(s.ScheduledAt)
+
+	params := &apimodel.StatusParams{
+		Text:          s.Text,
+		InReplyToID:   s.InReplyToID,
+		MediaIDs:      s.MediaIDs,
+		Sensitive:     util.PtrValueOr(s.Sensitive, false),
+		SpoilerText:   s.SpoilerText,
+		Visibility:    string(c.VisToAPIVis(ctx, s.Visibility)),
+		ScheduledAt:   scheduledAt,
+		ApplicationID: s.ApplicationID,
+	}
+
+	if s.Language != "" {
+		params.Language = util.Ptr(s.Language)
+	}
+
+	if len(s.PollOptions) > 0 {
+		params.Poll = &apimodel.StatusParamsPoll{
+			Options:    s.PollOptions,
+			ExpiresIn:  s.PollExpiresIn,
+			Multiple:   util.PtrValueOr(s.PollMultiple, false),
+			HideTotals: util.PtrValueOr(s.PollHideTotals, false),
+		}
+	}
+
+	return &apimodel.ScheduledStatus{
+		ID:               s.ID,
+		ScheduledAt:      scheduledAt,
+		Params:           params,
+		MediaAttachments: apiAttachments,
+	}, nil
+}
+
+// InviteToAPIInvite converts a gts model invite into an api model invite, for serving at /api/v1/invites.
+func (c *Converter) InviteToAPIInvite(ctx context.Context, i *gtsmodel.Invite) *apimodel.Invite {
+	var expiresAt *string
+	if !i.ExpiresAt.IsZero() {
+		expiresAt = util.Ptr(util.FormatISO8601(i.ExpiresAt))
+	}
+
+	var maxUses *int
+	if i.MaxUses > 0 {
+		maxUses = util.Ptr(i.MaxUses)
+	}
+
+	return &apimodel.Invite{
+		ID:        i.ID,
+		Code:      i.Code,
+		CreatedAt: util.FormatISO8601(i.CreatedAt),
+		ExpiresAt: expiresAt,
+		MaxUses:   maxUses,
+		Uses:      i.Uses,
+		Usable:    i.Usable(time.Now()),
+	}
+}
+
+// InviteToAdminAPIInvite converts a gts model invite into an admin api model invite,
+// including the creator of the invite and accounts registered with it, for serving
+// at /api/v1/admin/invites.
+func (c *Converter) InviteToAdminAPIInvite(ctx context.Context, i *gtsmodel.Invite) (*apimodel.AdminInvite, error) {
+	if err := c.state.DB.PopulateInvite(ctx, i); err != nil {
+		return nil, gtserror.Newf("error populating invite: %w", err)
+	}
+
+	account, err := c.AccountToAdminAPIAccount(ctx, i.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting invite account to admin api account: %w", err)
+	}
+
+	users, err := c.state.DB.GetUsersByInviteID(ctx, i.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting users for invite: %w", err)
+	}
+
+	invitedAccounts := make([]*apimodel.AdminAccountInfo, 0, len(users))
+	for _, user := range users {
+		invitedAccount, err := c.AccountToAdminAPIAccount(ctx, user.Account)
+		if err != nil {
+			log.Errorf(ctx, "error converting invited account to admin api account: %v", err)
+			continue
+		}
+
+		invitedAccounts = append(invitedAccounts, invitedAccount)
+	}
+
+	return &apimodel.AdminInvite{
+		Invite:          *c.InviteToAPIInvite(ctx, i),
+		Account:         account,
+		InvitedAccounts: invitedAccounts,
+	}, nil
+}
+
 // ReportToAPIReport converts a gts model report into an api model report, for serving at /api/v1/reports
 func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*apimodel.Report, error) {
 	report := &apimodel.Report{
@@ -1397,7 +2000,7 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		}
 	}
 	for _, s := range r.Statuses {
-		status, err := c.StatusToAPIStatus(ctx, s, requestingAccount)
+		status, err := c.StatusToAPIStatus(ctx, s, requestingAccount, gtsmodel.FilterContextNone, nil)
 		if err != nil {
 			return nil, fmt.Errorf("ReportToAdminAPIReport: error converting status with id %s to api status: %w", s.ID, err)
 		}
@@ -1471,7 +2074,212 @@ func (c *Converter) MarkersToAPIMarker(ctx context.Context, markers []*gtsmodel.
 	return apiMarker, nil
 }
 
+// FilterToAPIFiltersV1 converts one GTS model filter into an API v1 filter list.
+// Each keyword in the v2 filter becomes its own v1 filter.
+func (c *Converter) FilterToAPIFiltersV1(ctx context.Context, filter *gtsmodel.Filter) ([]*apimodel.FilterV1, error) {
+	apiFilters := make([]*apimodel.FilterV1, 0, len(filter.Keywords))
+	for _, filterKeyword := range filter.Keywords {
+		apiFilter, err := c.FilterKeywordToAPIFilterV1(ctx, filterKeyword)
+		if err != nil {
+			return nil, err
+		}
+		apiFilters = append(apiFilters, apiFilter)
+	}
+	return apiFilters, nil
+}
+
+// FilterKeywordToAPIFilterV1 converts one GTS model filter and filter keyword into an API v1 filter.
+// Keywords are the only part of a v2 filter representable in v1, so the ID of the keyword is used as the v1 filter ID.
+func (c *Converter) FilterKeywordToAPIFilterV1(ctx context.Context, filterKeyword *gtsmodel.FilterKeyword) (*apimodel.FilterV1, error) {
+	if filterKeyword.Filter == nil {
+		return nil, gtserror.New("FilterKeyword model's Filter field isn't populated, but needs to be")
+	}
+	filter := filterKeyword.Filter
+
+	return &apimodel.FilterV1{
+		// v1 filters have a single keyword each, so we use the filter keyword ID as the v1 filter ID.
+		ID:           filterKeyword.ID,
+		Phrase:       filterKeyword.Keyword,
+		Context:      filterToAPIFilterContexts(filter),
+		WholeWord:    util.PtrValueOr(filterKeyword.WholeWord, false),
+		ExpiresAt:    filterExpiresAtToAPIFilterExpiresAt(filter.ExpiresAt),
+		Irreversible: filter.Action == gtsmodel.FilterActionHide,
+	}, nil
+}
+
+// FilterToAPIFilterV2 converts one GTS model filter into an API v2 filter.
+func (c *Converter) FilterToAPIFilterV2(ctx context.Context, filter *gtsmodel.Filter) (*apimodel.FilterV2, error) {
+	apiFilterKeywords := make([]apimodel.FilterKeyword, 0, len(filter.Keywords))
+	for _, filterKeyword := range filter.Keywords {
+		apiFilterKeywords = append(apiFilterKeywords, *c.FilterKeywordToAPIFilterKeyword(ctx, filterKeyword))
+	}
+
+	apiFilterStatuses := make([]apimodel.FilterStatus, 0, len(filter.Statuses))
+	for _, filterStatus := range filter.Statuses {
+		apiFilterStatuses = append(apiFilterStatuses, *c.FilterStatusToAPIFilterStatus(ctx, filterStatus))
+	}
+
+	return &apimodel.FilterV2{
+		ID:           filter.ID,
+		Title:        filter.Title,
+		Context:      filterToAPIFilterContexts(filter),
+		ExpiresAt:    filterExpiresAtToAPIFilterExpiresAt(filter.ExpiresAt),
+		FilterAction: apimodel.FilterAction(filter.Action),
+		Keywords:     apiFilterKeywords,
+		Statuses:     apiFilterStatuses,
+	}, nil
+}
+
+// FilterKeywordToAPIFilterKeyword converts a GTS model filter keyword into an API filter keyword.
+func (c *Converter) FilterKeywordToAPIFilterKeyword(ctx context.Context, filterKeyword *gtsmodel.FilterKeyword) *apimodel.FilterKeyword {
+	return &apimodel.FilterKeyword{
+		ID:        filterKeyword.ID,
+		Keyword:   filterKeyword.Keyword,
+		WholeWord: util.PtrValueOr(filterKeyword.WholeWord, false),
+	}
+}
+
+// FilterStatusToAPIFilterStatus converts a GTS model filter status into an API filter status.
+func (c *Converter) FilterStatusToAPIFilterStatus(ctx context.Context, filterStatus *gtsmodel.FilterStatus) *apimodel.FilterStatus {
+	return &apimodel.FilterStatus{
+		ID:       filterStatus.ID,
+		StatusID: filterStatus.StatusID,
+	}
+}
+
+func filterExpiresAtToAPIFilterExpiresAt(expiresAt time.Time) *string {
+	if expiresAt.IsZero() {
+		return nil
+	}
+	return util.Ptr(util.FormatISO8601(expiresAt))
+}
+
+func filterToAPIFilterContexts(filter *gtsmodel.Filter) []apimodel.FilterContext {
+	contexts := filter.Contexts()
+	apiContexts := make([]apimodel.FilterContext, 0, len(contexts))
+	for _, context := range contexts {
+		apiContexts = append(apiContexts, apimodel.FilterContext(context))
+	}
+	return apiContexts
+}
+
 // PollToAPIPoll converts a database (gtsmodel) Poll into an API model representation appropriate for the given requesting account.
+// StatusToAPIEdits converts the edit history of the given
+// status into its api (frontend) representation, ordered
+// oldest to newest, and ending with the current revision.
+func (c *Converter) StatusToAPIEdits(ctx context.Context, s *gtsmodel.Status) ([]*apimodel.StatusEdit, error) {
+	// Ensure status is populated.
+	if err := c.state.DB.PopulateStatus(ctx, s); err != nil {
+		if s.Account == nil {
+			err = gtserror.Newf("error(s) populating status, cannot continue (status.Account not set): %w", err)
+			return nil, err
+		}
+		log.Errorf(ctx, "error(s) populating status, will continue: %v", err)
+	}
+
+	if len(s.Edits) != len(s.EditIDs) {
+		// Edits are not loaded by PopulateStatus,
+		// as they are only needed here; fetch them.
+		edits, err := c.state.DB.GetStatusEditsByIDs(ctx, s.EditIDs)
+		if err != nil {
+			return nil, gtserror.Newf("error getting status edits: %w", err)
+		}
+		s.Edits = edits
+	}
+
+	apiAuthorAccount, err := c.AccountToAPIAccountPublic(ctx, s.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting status author: %w", err)
+	}
+
+	apiEmojis, err := c.convertEmojisToAPIEmojis(ctx, s.Emojis, s.EmojiIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting status emojis: %v", err)
+	}
+
+	apiEdits := make([]*apimodel.StatusEdit, 0, len(s.Edits)+1)
+
+	for _, edit := range s.Edits {
+		apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, edit.Attachments, edit.AttachmentIDs)
+		if err != nil {
+			log.Errorf(ctx, "error converting status edit attachments: %v", err)
+		}
+
+		// Attachments may have had their descriptions
+		// changed since this revision, so use the ones
+		// stored alongside the edit where available.
+		for i, apiAttachment := range apiAttachments {
+			if i < len(edit.AttachmentDescriptions) {
+				apiAttachment.Description = util.Ptr(edit.AttachmentDescriptions[i])
+			}
+		}
+
+		apiEdit := &apimodel.StatusEdit{
+			Content:          edit.Content,
+			SpoilerText:      edit.ContentWarning,
+			Sensitive:        util.PtrValueOr(edit.Sensitive, false),
+			CreatedAt:        util.FormatISO8601(edit.CreatedAt),
+			Account:          apiAuthorAccount,
+			MediaAttachments: apiAttachments,
+			Emojis:           apiEmojis,
+		}
+
+		if len(edit.PollOptions) > 0 {
+			apiEdit.Poll = &apimodel.StatusEditPoll{
+				Options: make([]apimodel.StatusEditPollOption, len(edit.PollOptions)),
+			}
+			for i, title := range edit.PollOptions {
+				apiEdit.Poll.Options[i].Title = title
+			}
+		}
+
+		apiEdits = append(apiEdits, apiEdit)
+	}
+
+	// Finally, append the current revision.
+	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, s.Attachments, s.AttachmentIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting status attachments: %v", err)
+	}
+
+	current := &apimodel.StatusEdit{
+		Content:          s.Content,
+		SpoilerText:      s.ContentWarning,
+		Sensitive:        util.PtrValueOr(s.Sensitive, false),
+		CreatedAt:        util.FormatISO8601(s.CreatedAt),
+		Account:          apiAuthorAccount,
+		MediaAttachments: apiAttachments,
+		Emojis:           apiEmojis,
+	}
+
+	if !s.EditedAt.IsZero() {
+		current.CreatedAt = util.FormatISO8601(s.EditedAt)
+	}
+
+	if s.Poll != nil {
+		current.Poll = &apimodel.StatusEditPoll{
+			Options: make([]apimodel.StatusEditPollOption, len(s.Poll.Options)),
+		}
+		for i, title := range s.Poll.Options {
+			current.Poll.Options[i].Title = title
+		}
+	}
+
+	apiEdits = append(apiEdits, current)
+
+	return apiEdits, nil
+}
+
+// StatusToAPIStatusSource converts a gts model status
+// into its plain-text source representation for the API.
+func (c *Converter) StatusToAPIStatusSource(ctx context.Context, s *gtsmodel.Status) *apimodel.StatusSource {
+	return &apimodel.StatusSource{
+		ID:          s.ID,
+		Text:        s.Text,
+		SpoilerText: s.ContentWarning,
+	}
+}
+
 func (c *Converter) PollToAPIPoll(ctx context.Context, requester *gtsmodel.Account, poll *gtsmodel.Poll) (*apimodel.Poll, error) {
 	// Ensure the poll model is fully populated for src status.
 	if err := c.state.DB.PopulatePoll(ctx, poll); err != nil {
@@ -1700,7 +2508,7 @@ func (c *Converter) convertTagsToAPITags(ctx context.Context, tags []*gtsmodel.T
 
 	// Convert GTS models to frontend models
 	for _, tag := range tags {
-		apiTag, err := c.TagToAPITag(ctx, tag, false)
+		apiTag, err := c.TagToAPITag(ctx, tag, false, nil)
 		if err != nil {
 			errs.Appendf("error converting tag %s to api tag: %w", tag.ID, err)
 			continue
@@ -1710,3 +2518,80 @@ func (c *Converter) convertTagsToAPITags(ctx context.Context, tags []*gtsmodel.T
 
 	return apiTags, errs.Combine()
 }
+
+// WebPushSubscriptionToAPIPushSubscription converts a gts model web push subscription into
+// an api model push subscription, for serving at /api/v1/push/subscription. The serverKey
+// should be the public key of the instance's VAPID key pair.
+func (c *Converter) WebPushSubscriptionToAPIPushSubscription(
+	ctx context.Context,
+	s *gtsmodel.WebPushSubscription,
+	serverKey string,
+) *apimodel.PushSubscription {
+	return &apimodel.PushSubscription{
+		ID:        s.ID,
+		Endpoint:  s.Endpoint,
+		ServerKey: serverKey,
+		Alerts: &apimodel.PushSubscriptionAlerts{
+			Follow:        util.PtrValueOr(s.AlertFollow, false),
+			FollowRequest: util.PtrValueOr(s.AlertFollowRequest, false),
+			Favourite:     util.PtrValueOr(s.AlertFavourite, false),
+			Mention:       util.PtrValueOr(s.AlertMention, false),
+			Reblog:        util.PtrValueOr(s.AlertReblog, false),
+			Poll:          util.PtrValueOr(s.AlertPoll, false),
+			Status:        util.PtrValueOr(s.AlertStatus, false),
+			AdminSignup:   util.PtrValueOr(s.AlertSignup, false),
+		},
+		Policy: string(s.Policy),
+	}
+}
+
+// ConversationToAPIConversation converts a gts model conversation into an api model conversation,
+// for serving at /api/v1/conversations. The conversation should be owned by the requester.
+//
+// If the last status of the conversation should be hidden according to the given
+// filters, statusfilter.ErrHideStatus will be returned, along with a nil conversation.
+func (c *Converter) ConversationToAPIConversation(
+	ctx context.Context,
+	conversation *gtsmodel.Conversation,
+	requester *gtsmodel.Account,
+	filters []*gtsmodel.Filter,
+) (*apimodel.Conversation, error) {
+	if err := c.state.DB.PopulateConversation(ctx, conversation); err != nil {
+		return nil, gtserror.Newf("error populating conversation %s: %w", conversation.ID, err)
+	}
+
+	lastStatus, err := c.StatusToAPIStatus(
+		ctx,
+		conversation.LastStatus,
+		requester,
+		gtsmodel.FilterContextThread,
+		filters,
+	)
+	if err != nil {
+		// Includes statusfilter.ErrHideStatus.
+		return nil, err
+	}
+
+	// Mastodon includes the owner as the only
+	// participant of a conversation with themself.
+	participants := conversation.OtherAccounts
+	if len(participants) == 0 {
+		participants = []*gtsmodel.Account{conversation.Account}
+	}
+
+	accounts := make([]apimodel.Account, 0, len(participants))
+	for _, participant := range participants {
+		account, err := c.AccountToAPIAccountPublic(ctx, participant)
+		if err != nil {
+			return nil, gtserror.Newf("error converting account %s: %w", participant.ID, err)
+		}
+		accounts = append(accounts, *account)
+	}
+
+	return &apimodel.Conversation{
+		ID:         conversation.ID,
+		Accounts:   accounts,
+		Unread:     !util.PtrValueOr(conversation.Read, false),
+		LastStatus: lastStatus,
+	}, nil
+}
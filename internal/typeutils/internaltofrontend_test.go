@@ -21,11 +21,14 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -423,10 +426,46 @@ func (suite *InternalToFrontendTestSuite) TestLocalInstanceAccountToFrontendBloc
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestCardToFrontend() {
+	image := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	card := &gtsmodel.Card{
+		ID:           "01HT2GN9Y4PQ6M9VJ8E0F5HX3Z",
+		URL:          "https://news.example.org/articles/1",
+		Title:        "A very good article",
+		Description:  "You won't believe what happens next.",
+		Type:         gtsmodel.CardTypeLink,
+		ProviderName: "Example News",
+		ImageID:      image.ID,
+	}
+
+	apiCard, err := suite.typeconverter.CardToAPICard(context.Background(), card)
+	suite.NoError(err)
+
+	b, err := json.MarshalIndent(apiCard, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "url": "https://news.example.org/articles/1",
+  "title": "A very good article",
+  "description": "You won't believe what happens next.",
+  "type": "link",
+  "author_name": "",
+  "author_url": "",
+  "provider_name": "Example News",
+  "provider_url": "",
+  "html": "",
+  "width": 472,
+  "height": 291,
+  "image": "http://localhost:8080/fileserver/01F8MH5ZK5VRH73AKHQM6Y9VNX/attachment/small/01FVW7RXPQ8YJHTEXYPE7Q8ZY0.jpg",
+  "embed_url": "",
+  "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+}`, string(b))
+}
+
 func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
 	testStatus := suite.testStatuses["admin_account_status_1"]
 	requestingAccount := suite.testAccounts["local_account_1"]
-	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount)
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount, gtsmodel.FilterContextNone, nil)
 	suite.NoError(err)
 
 	b, err := json.MarshalIndent(apiStatus, "", "  ")
@@ -536,11 +575,110 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
 }`, string(b))
 }
 
+// Test that a status which is filtered with a warn filter by the requesting user has `filtered` set correctly.
+func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	expectedMatchingFilter := suite.testFilters["local_account_1_filter_1"]
+	expectedMatchingFilterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	suite.NoError(expectedMatchingFilterKeyword.Compile())
+	expectedMatchingFilterKeyword.Filter = expectedMatchingFilter
+	expectedMatchingFilter.Keywords = []*gtsmodel.FilterKeyword{expectedMatchingFilterKeyword}
+	expectedMatchingFilter.Statuses = nil
+
+	// Mention the keyword in the status content.
+	testStatus.Content += " fnord"
+	testStatus.Text += " fnord"
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		gtsmodel.FilterContextHome,
+		[]*gtsmodel.Filter{expectedMatchingFilter},
+	)
+	suite.NoError(err)
+
+	if suite.Len(apiStatus.Filtered, 1) {
+		filterResult := apiStatus.Filtered[0]
+		suite.Equal(expectedMatchingFilter.ID, filterResult.Filter.ID)
+		suite.Equal(expectedMatchingFilter.Title, filterResult.Filter.Title)
+		suite.Equal([]string{expectedMatchingFilterKeyword.Keyword}, filterResult.KeywordMatches)
+		suite.Empty(filterResult.StatusMatches)
+	}
+}
+
+// Test that a status which is filtered with a hide filter by the requesting user results in the ErrHideStatus error.
+func (suite *InternalToFrontendTestSuite) TestHideFilteredStatusToFrontend() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	expectedMatchingFilter := suite.testFilters["local_account_1_filter_1"]
+	expectedMatchingFilter.Action = gtsmodel.FilterActionHide
+	expectedMatchingFilterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	suite.NoError(expectedMatchingFilterKeyword.Compile())
+	expectedMatchingFilterKeyword.Filter = expectedMatchingFilter
+	expectedMatchingFilter.Keywords = []*gtsmodel.FilterKeyword{expectedMatchingFilterKeyword}
+	expectedMatchingFilter.Statuses = nil
+
+	// Mention the keyword in the status content warning.
+	testStatus.ContentWarning = "fnord"
+
+	_, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		gtsmodel.FilterContextHome,
+		[]*gtsmodel.Filter{expectedMatchingFilter},
+	)
+	suite.ErrorIs(err, statusfilter.ErrHideStatus)
+}
+
+// Test that a filter is not applied outside of its contexts, or after it has expired.
+func (suite *InternalToFrontendTestSuite) TestFilteredStatusToFrontendInactiveFilter() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	filter := suite.testFilters["local_account_1_filter_1"]
+	filterKeyword := suite.testFilterKeywords["local_account_1_filter_1_keyword_1"]
+	suite.NoError(filterKeyword.Compile())
+	filterKeyword.Filter = filter
+	filter.Keywords = []*gtsmodel.FilterKeyword{filterKeyword}
+	filter.Statuses = nil
+
+	testStatus.Content += " fnord"
+	testStatus.Text += " fnord"
+
+	// The test filter doesn't apply to threads.
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		gtsmodel.FilterContextThread,
+		[]*gtsmodel.Filter{filter},
+	)
+	suite.NoError(err)
+	suite.Empty(apiStatus.Filtered)
+
+	// Expired filters should be ignored.
+	filter.ExpiresAt = time.Now().Add(-time.Hour)
+	apiStatus, err = suite.typeconverter.StatusToAPIStatus(
+		context.Background(),
+		testStatus,
+		requestingAccount,
+		gtsmodel.FilterContextHome,
+		[]*gtsmodel.Filter{filter},
+	)
+	suite.NoError(err)
+	suite.Empty(apiStatus.Filtered)
+}
+
 func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments() {
 	testStatus := suite.testStatuses["remote_account_2_status_1"]
 	requestingAccount := suite.testAccounts["admin_account"]
 
-	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount)
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount, gtsmodel.FilterContextNone, nil)
 	suite.NoError(err)
 
 	b, err := json.MarshalIndent(apiStatus, "", "  ")
@@ -773,7 +911,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
 	*testStatus = *suite.testStatuses["admin_account_status_1"]
 	testStatus.Language = ""
 	requestingAccount := suite.testAccounts["local_account_1"]
-	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount)
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount, gtsmodel.FilterContextNone, nil)
 	suite.NoError(err)
 
 	b, err := json.MarshalIndent(apiStatus, "", "  ")
@@ -883,6 +1021,37 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendContentWarningPolicy() {
+	var (
+		ctx               = context.Background()
+		testStatus        = suite.testStatuses["remote_account_1_status_1"]
+		requestingAccount = suite.testAccounts["local_account_1"]
+	)
+
+	// Force a content warning onto all
+	// statuses of the status author's domain.
+	if err := suite.db.PutContentWarningPolicy(ctx, &gtsmodel.ContentWarningPolicy{
+		ID:                 "01HSVX4W6FD0YM5DJ9Q7M1J8AB",
+		Domain:             suite.testAccounts["remote_account_1"].Domain,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		ContentWarning:     "fossbros",
+		Sensitive:          util.Ptr(false),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(ctx, testStatus, requestingAccount, gtsmodel.FilterContextNone, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("fossbros", apiStatus.SpoilerText)
+	suite.True(apiStatus.Sensitive)
+
+	// The stored status itself should be untouched.
+	suite.Empty(testStatus.ContentWarning)
+	suite.False(*testStatus.Sensitive)
+}
+
 func (suite *InternalToFrontendTestSuite) TestVideoAttachmentToFrontend() {
 	testAttachment := suite.testAttachments["local_account_1_status_4_attachment_2"]
 	apiAttachment, err := suite.typeconverter.AttachmentToAPIAttachment(context.Background(), testAttachment)
@@ -1354,6 +1523,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "approved": false,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -1385,7 +1555,20 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "created_at": "2022-06-04T13:12:00.000Z",
     "email": "tortle.dude@example.org",
     "ip": "118.44.18.196",
-    "ips": [],
+    "ips": [
+      {
+        "ip": "118.44.18.196",
+        "used_at": "2022-06-05T13:12:00.000Z"
+      },
+      {
+        "ip": "198.98.21.15",
+        "used_at": "2022-06-06T13:12:00.000Z"
+      },
+      {
+        "ip": "59.99.19.172",
+        "used_at": "2022-05-23T13:12:00.000Z"
+      }
+    ],
     "locale": "en",
     "invite_request": null,
     "role": {
@@ -1395,6 +1578,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -1441,7 +1625,16 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "created_at": "2022-05-17T13:10:59.000Z",
     "email": "admin@example.org",
     "ip": "89.122.255.1",
-    "ips": [],
+    "ips": [
+      {
+        "ip": "89.122.255.1",
+        "used_at": "2022-06-04T13:12:00.000Z"
+      },
+      {
+        "ip": "89.22.189.19",
+        "used_at": "2022-06-01T13:12:00.000Z"
+      }
+    ],
     "locale": "en",
     "invite_request": null,
     "role": {
@@ -1451,6 +1644,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -1487,7 +1681,16 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "created_at": "2022-05-17T13:10:59.000Z",
     "email": "admin@example.org",
     "ip": "89.122.255.1",
-    "ips": [],
+    "ips": [
+      {
+        "ip": "89.122.255.1",
+        "used_at": "2022-06-04T13:12:00.000Z"
+      },
+      {
+        "ip": "89.22.189.19",
+        "used_at": "2022-06-01T13:12:00.000Z"
+      }
+    ],
     "locale": "en",
     "invite_request": null,
     "role": {
@@ -1497,6 +1700,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -1556,7 +1760,20 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     "created_at": "2022-06-04T13:12:00.000Z",
     "email": "tortle.dude@example.org",
     "ip": "118.44.18.196",
-    "ips": [],
+    "ips": [
+      {
+        "ip": "118.44.18.196",
+        "used_at": "2022-06-05T13:12:00.000Z"
+      },
+      {
+        "ip": "198.98.21.15",
+        "used_at": "2022-06-06T13:12:00.000Z"
+      },
+      {
+        "ip": "59.99.19.172",
+        "used_at": "2022-05-23T13:12:00.000Z"
+      }
+    ],
     "locale": "en",
     "invite_request": null,
     "role": {
@@ -1566,6 +1783,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -1622,6 +1840,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     "approved": false,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -1800,6 +2019,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "approved": false,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -1841,6 +2061,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": true,
     "account": {
       "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -1876,7 +2097,16 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "created_at": "2022-05-17T13:10:59.000Z",
     "email": "admin@example.org",
     "ip": "89.122.255.1",
-    "ips": [],
+    "ips": [
+      {
+        "ip": "89.122.255.1",
+        "used_at": "2022-06-04T13:12:00.000Z"
+      },
+      {
+        "ip": "89.22.189.19",
+        "used_at": "2022-06-01T13:12:00.000Z"
+      }
+    ],
     "locale": "en",
     "invite_request": null,
     "role": {
@@ -1886,6 +2116,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -1922,7 +2153,16 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "created_at": "2022-05-17T13:10:59.000Z",
     "email": "admin@example.org",
     "ip": "89.122.255.1",
-    "ips": [],
+    "ips": [
+      {
+        "ip": "89.122.255.1",
+        "used_at": "2022-06-04T13:12:00.000Z"
+      },
+      {
+        "ip": "89.22.189.19",
+        "used_at": "2022-06-01T13:12:00.000Z"
+      }
+    ],
     "locale": "en",
     "invite_request": null,
     "role": {
@@ -1932,6 +2172,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -19,6 +19,7 @@ package typeutils
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"path"
@@ -28,6 +29,7 @@ import (
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -80,6 +82,26 @@ func (c *Converter) interactionsWithStatusForAccount(ctx context.Context, s *gts
 	return si, nil
 }
 
+// applyContentWarningPolicy returns the given content warning
+// and sensitive flag of a status authored by account, with any
+// admin content warning policy targeting the account applied.
+func (c *Converter) applyContentWarningPolicy(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	contentWarning string,
+	sensitive bool,
+) (string, bool) {
+	policy, err := c.state.DB.GetContentWarningPolicyForAccount(ctx, account)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "error getting content warning policy for account %s: %v", account.ID, err)
+		}
+		return contentWarning, sensitive
+	}
+
+	return policy.Apply(contentWarning, sensitive)
+}
+
 func misskeyReportInlineURLs(content string) []*url.URL {
 	m := regexes.MisskeyReportNotes.FindAllStringSubmatch(content, -1)
 	urls := make([]*url.URL, 0, len(m))
@@ -42,6 +42,7 @@ const (
 	BlocksPath       = "blocks"        // BlocksPath is used to generate the URI for a block
 	ReportsPath      = "reports"       // ReportsPath is used to generate the URI for a report/flag
 	ConfirmEmailPath = "confirm_email" // ConfirmEmailPath is used to generate the URI for an email confirmation link
+	UnsubscribePath  = "unsubscribe"   // UnsubscribePath is used to generate the URI for a notification email unsubscribe link
 	FileserverPath   = "fileserver"    // FileserverPath is a path component for serving attachments + media
 	EmojiPath        = "emoji"         // EmojiPath represents the activitypub emoji location
 	TagsPath         = "tags"          // TagsPath represents the activitypub tags location
@@ -72,6 +73,8 @@ type UserURIs struct {
 	LikedURI string
 	// The activitypub URI for this user's featured collections, eg., https://example.org/users/example_user/collections/featured
 	FeaturedCollectionURI string
+	// The activitypub URI for this user's featured tags, eg., https://example.org/users/example_user/collections/tags
+	FeaturedTagsURI string
 	// The URI for this user's public key, eg., https://example.org/users/example_user/publickey
 	PublicKeyURI string
 }
@@ -127,6 +130,14 @@ func GenerateURIForEmailConfirm(token string) string {
 	return fmt.Sprintf("%s://%s/%s?token=%s", protocol, host, ConfirmEmailPath, token)
 }
 
+// GenerateURIForEmailUnsubscribe returns a link for unsubscribing from notification emails -- something like:
+// https://example.org/unsubscribe?token=490e337c-0162-454f-ac48-4b22bb92a205
+func GenerateURIForEmailUnsubscribe(token string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s?token=%s", protocol, host, UnsubscribePath, token)
+}
+
 // GenerateURIsForAccount throws together a bunch of URIs for the given username, with the given protocol and host.
 func GenerateURIsForAccount(username string) *UserURIs {
 	protocol := config.GetProtocol()
@@ -146,6 +157,7 @@ func GenerateURIsForAccount(username string) *UserURIs {
 	followingURI := fmt.Sprintf("%s/%s", userURI, FollowingPath)
 	likedURI := fmt.Sprintf("%s/%s", userURI, LikedPath)
 	collectionURI := fmt.Sprintf("%s/%s/%s", userURI, CollectionsPath, FeaturedPath)
+	featuredTagsURI := fmt.Sprintf("%s/%s/%s", userURI, CollectionsPath, TagsPath)
 	publicKeyURI := fmt.Sprintf("%s/%s", userURI, PublicKeyPath)
 
 	return &UserURIs{
@@ -161,6 +173,7 @@ func GenerateURIsForAccount(username string) *UserURIs {
 		FollowingURI:          followingURI,
 		LikedURI:              likedURI,
 		FeaturedCollectionURI: collectionURI,
+		FeaturedTagsURI:       featuredTagsURI,
 		PublicKeyURI:          publicKeyURI,
 	}
 }
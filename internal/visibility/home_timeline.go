@@ -20,6 +20,7 @@ package visibility
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/cache"
@@ -88,6 +89,17 @@ func (f *Filter) isStatusHomeTimelineable(ctx context.Context, owner *gtsmodel.A
 		return false, nil
 	}
 
+	// Check whether status is muted by timeline owner.
+	muted, err := f.StatusMuted(ctx, owner, status)
+	if err != nil {
+		return false, err
+	}
+
+	if muted {
+		log.Trace(ctx, "status muted by timeline owner")
+		return false, nil
+	}
+
 	if status.AccountID == owner.ID {
 		// Author can always see their status.
 		return true, nil
@@ -196,6 +208,17 @@ func (f *Filter) isStatusHomeTimelineable(ctx context.Context, owner *gtsmodel.A
 	}
 
 	if follow == nil {
+		// Owner doesn't follow author, but they
+		// may follow one of the status hashtags.
+		followedTag, err := f.usesFollowedTag(ctx, owner, status)
+		if err != nil {
+			return false, err
+		}
+
+		if followedTag {
+			return true, nil
+		}
+
 		log.Trace(ctx, "ignoring status from unfollowed author")
 		return false, nil
 	}
@@ -209,6 +232,50 @@ func (f *Filter) isStatusHomeTimelineable(ctx context.Context, owner *gtsmodel.A
 	return true, nil
 }
 
+// usesFollowedTag returns whether the given status is a public,
+// non-boost status using a listable hashtag followed by owner.
+func (f *Filter) usesFollowedTag(ctx context.Context, owner *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	if status.Visibility != gtsmodel.VisibilityPublic ||
+		status.BoostOfID != "" || len(status.TagIDs) == 0 {
+		// Only original public
+		// statuses with tags.
+		return false, nil
+	}
+
+	// Get IDs of all tags followed by owner.
+	tagIDs, err := f.state.DB.GetFollowedTagIDs(ctx, owner.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("error getting followed tags for %s: %w", owner.ID, err)
+	}
+
+	if len(tagIDs) == 0 {
+		// Owner follows no tags.
+		return false, nil
+	}
+
+	if !status.TagsPopulated() {
+		// Ensure status tags are populated.
+		status.Tags, err = f.state.DB.GetTags(ctx, status.TagIDs)
+		if err != nil {
+			return false, gtserror.Newf("error populating status %s tags: %w", status.ID, err)
+		}
+	}
+
+	for _, tag := range status.Tags {
+		if !*tag.Listable {
+			// Tag may not be
+			// shown on timelines.
+			continue
+		}
+
+		if slices.Contains(tagIDs, tag.ID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (f *Filter) isVisibleConversation(
 	ctx context.Context,
 	owner *gtsmodel.Account,
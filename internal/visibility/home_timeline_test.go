@@ -87,6 +87,38 @@ func (suite *StatusStatusHomeTimelineableTestSuite) TestFollowingBoostedStatusHo
 	suite.False(timelineable)
 }
 
+func (suite *StatusStatusHomeTimelineableTestSuite) TestFollowingMutedStatusHomeTimelineable() {
+	testStatus := suite.testStatuses["local_account_2_status_1"]
+	testAccount := suite.testAccounts["local_account_1"]
+	ctx := context.Background()
+
+	// Mute the status author.
+	mute := &gtsmodel.UserMute{
+		ID:              "01HQX0MGFP1Y4Z4GCMT4D0YBCT",
+		AccountID:       testAccount.ID,
+		TargetAccountID: testStatus.AccountID,
+		Notifications:   util.Ptr(false),
+	}
+	if err := suite.db.PutMute(ctx, mute); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	timelineable, err := suite.filter.StatusHomeTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.False(timelineable)
+
+	// Expire the mute, status
+	// should now be timelineable.
+	mute.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := suite.db.UpdateMute(ctx, mute, "expires_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	timelineable, err = suite.filter.StatusHomeTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.True(timelineable)
+}
+
 func (suite *StatusStatusHomeTimelineableTestSuite) TestNotFollowingStatusHomeTimelineable() {
 	testStatus := suite.testStatuses["remote_account_1_status_1"]
 	testAccount := suite.testAccounts["local_account_1"]
@@ -98,6 +130,29 @@ func (suite *StatusStatusHomeTimelineableTestSuite) TestNotFollowingStatusHomeTi
 	suite.False(timelineable)
 }
 
+func (suite *StatusStatusHomeTimelineableTestSuite) TestNotFollowingFollowedTagStatusHomeTimelineable() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testAccount := suite.testAccounts["local_account_2"]
+	ctx := context.Background()
+
+	timelineable, err := suite.filter.StatusHomeTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.False(timelineable)
+
+	// Follow one of the status hashtags.
+	if err := suite.db.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+		ID:        "01HRF5BSN2BFVPGQ8CV9CBDAWA",
+		AccountID: testAccount.ID,
+		TagID:     suite.testTags["welcome"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	timelineable, err = suite.filter.StatusHomeTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.True(timelineable)
+}
+
 func (suite *StatusStatusHomeTimelineableTestSuite) TestStatusTooNewNotTimelineable() {
 	testStatus := &gtsmodel.Status{}
 	*testStatus = *suite.testStatuses["local_account_1_status_1"]
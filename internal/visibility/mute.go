@@ -0,0 +1,101 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// StatusMuted checks whether the given status was authored by, or
+// is a boost of a status authored by, an account muted by requester.
+func (f *Filter) StatusMuted(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	if requester == nil {
+		// Unauthed requests
+		// can't have mutes.
+		return false, nil
+	}
+
+	mute, err := f.getActiveMute(ctx, requester.ID, status.AccountID)
+	if err != nil {
+		return false, err
+	}
+
+	if mute != nil {
+		// Status author is muted.
+		return true, nil
+	}
+
+	if status.BoostOfAccountID != "" {
+		mute, err = f.getActiveMute(ctx, requester.ID, status.BoostOfAccountID)
+		if err != nil {
+			return false, err
+		}
+
+		if mute != nil {
+			// Boosted status author is muted.
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// AccountNotificationsMuted checks whether the given requester
+// has muted the given account with notifications muted too.
+func (f *Filter) AccountNotificationsMuted(ctx context.Context, requester *gtsmodel.Account, account *gtsmodel.Account) (bool, error) {
+	mute, err := f.getActiveMute(ctx, requester.ID, account.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return mute != nil && *mute.Notifications, nil
+}
+
+// getActiveMute returns the mute from source
+// account to target account, if it exists and
+// has not yet expired, else nil.
+func (f *Filter) getActiveMute(ctx context.Context, sourceAccountID string, targetAccountID string) (*gtsmodel.UserMute, error) {
+	if sourceAccountID == targetAccountID {
+		// Can't mute yourself.
+		return nil, nil
+	}
+
+	mute, err := f.state.DB.GetMute(
+		gtscontext.SetBarebones(ctx),
+		sourceAccountID,
+		targetAccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting mute: %w", err)
+	}
+
+	if mute == nil || mute.Expired(time.Now()) {
+		// No mute, or it's expired
+		// and just not cleaned up yet.
+		return nil, nil
+	}
+
+	return mute, nil
+}
@@ -91,6 +91,29 @@ func (f *Filter) isStatusPublicTimelineable(ctx context.Context, requester *gtsm
 		return false, nil
 	}
 
+	// Don't show statuses of silenced accounts on
+	// this timeline, even to followers of the account.
+	silenced, err := f.isAccountSilenced(ctx, status.Account)
+	if err != nil {
+		return false, gtserror.Newf("error checking if status author silenced: %w", err)
+	}
+
+	if silenced {
+		log.Trace(ctx, "status author is silenced")
+		return false, nil
+	}
+
+	// Check whether status is muted by requester.
+	muted, err := f.StatusMuted(ctx, requester, status)
+	if err != nil {
+		return false, err
+	}
+
+	if muted {
+		log.Trace(ctx, "status muted by timeline requester")
+		return false, nil
+	}
+
 	for parent := status; parent.InReplyToURI != ""; {
 		// Fetch next parent to lookup.
 		parentID := parent.InReplyToID
@@ -90,6 +90,23 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 		return false, nil
 	}
 
+	silenced, err := f.isAccountSilenced(ctx, status.Account)
+	if err != nil {
+		return false, gtserror.Newf("error checking if status author silenced: %w", err)
+	}
+
+	if silenced {
+		// Statuses by silenced accounts are only visible
+		// to followers of the account, or those mentioned.
+		visible, err := f.isSilencedStatusVisible(ctx, requester, status)
+		if err != nil {
+			return false, gtserror.Newf("error checking silenced status %s visibility: %w", status.ID, err)
+		} else if !visible {
+			log.Trace(ctx, "silenced account status not visible to requester")
+			return false, nil
+		}
+	}
+
 	if status.Visibility == gtsmodel.VisibilityPublic {
 		// This status will be visible to all.
 		return true, nil
@@ -176,6 +193,44 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 	}
 }
 
+// isAccountSilenced returns whether the given account is
+// silenced, either directly by a moderator, or because its
+// domain is limited by a "silence" severity domain block.
+func (f *Filter) isAccountSilenced(ctx context.Context, account *gtsmodel.Account) (bool, error) {
+	if account.IsSilenced() {
+		return true, nil
+	}
+
+	if account.IsLocal() {
+		// Local accounts can't
+		// be domain silenced.
+		return false, nil
+	}
+
+	return f.state.DB.IsDomainSilenced(ctx, account.Domain)
+}
+
+// isSilencedStatusVisible returns whether the given status,
+// authored by a silenced account, is visible to requester.
+// Only the author, their followers, and mentioned accounts
+// can see the statuses of a silenced account.
+func (f *Filter) isSilencedStatusVisible(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	if requester == nil {
+		// Unauthed requests can't follow.
+		return false, nil
+	}
+
+	if requester.ID == status.AccountID ||
+		status.MentionsAccount(requester.ID) {
+		return true, nil
+	}
+
+	return f.state.DB.IsFollowing(ctx,
+		requester.ID,
+		status.AccountID,
+	)
+}
+
 // areStatusAccountsVisible calls Filter{}.AccountVisible() on status author and the status boost-of (if set) author, returning visibility of status (and boost-of) to requester.
 func (f *Filter) areStatusAccountsVisible(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
 	// Check whether status author's account is visible to requester.
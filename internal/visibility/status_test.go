@@ -20,6 +20,7 @@ package visibility_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -156,6 +157,82 @@ func (suite *StatusVisibleTestSuite) TestStatusNotVisibleIfNotFollowingCached()
 	suite.False(visible)
 }
 
+func (suite *StatusVisibleTestSuite) TestSilencedAccountStatusVisibility() {
+	ctx := context.Background()
+
+	// Silence the author of the status.
+	author := new(gtsmodel.Account)
+	*author = *suite.testAccounts["local_account_1"]
+	author.SilencedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, author, "silenced_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	testStatusID := suite.testStatuses["local_account_1_status_1"].ID
+	testStatus, err := suite.db.GetStatusByID(ctx, testStatusID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.VisibilityPublic, testStatus.Visibility)
+
+	// Followers can still see the status.
+	visible, err := suite.filter.StatusVisible(ctx, suite.testAccounts["admin_account"], testStatus)
+	suite.NoError(err)
+	suite.True(visible)
+
+	// But it shouldn't show on the public timeline.
+	timelineable, err := suite.filter.StatusPublicTimelineable(ctx, suite.testAccounts["admin_account"], testStatus)
+	suite.NoError(err)
+	suite.False(timelineable)
+
+	// And unauthed requesters can't see it at all.
+	visible, err = suite.filter.StatusVisible(ctx, nil, testStatus)
+	suite.NoError(err)
+	suite.False(visible)
+}
+
+func (suite *StatusVisibleTestSuite) TestSilencedDomainStatusVisibility() {
+	ctx := context.Background()
+
+	testStatus, err := suite.db.GetStatusByID(ctx, suite.testStatuses["remote_account_1_status_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	requester := suite.testAccounts["admin_account"]
+
+	// Status is visible before the domain is silenced.
+	visible, err := suite.filter.StatusVisible(ctx, requester, testStatus)
+	suite.NoError(err)
+	suite.True(visible)
+
+	// Silence the author's domain.
+	if err := suite.db.CreateDomainBlock(ctx, &gtsmodel.DomainBlock{
+		ID:                 "01HRWFHCKQBSTR9QFDX34SDFWB",
+		Domain:             testStatus.Account.Domain,
+		CreatedByAccountID: requester.ID,
+		Severity:           gtsmodel.DomainBlockSeveritySilence,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Now it's hidden from non-followers.
+	visible, err = suite.filter.StatusVisible(ctx, requester, testStatus)
+	suite.NoError(err)
+	suite.False(visible)
+
+	// Follow the author, status should be visible again.
+	if err := suite.db.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              "01HRWFJ3Q0MYEX6Y3SK4CWD0AC",
+		URI:             "http://localhost:8080/users/admin/follow/01HRWFJ3Q0MYEX6Y3SK4CWD0AC",
+		AccountID:       requester.ID,
+		TargetAccountID: testStatus.AccountID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	visible, err = suite.filter.StatusVisible(ctx, requester, testStatus)
+	suite.NoError(err)
+	suite.True(visible)
+}
+
 func TestStatusVisibleTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusVisibleTestSuite))
 }
@@ -21,6 +21,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -55,6 +56,29 @@ func (f *Filter) StatusTagTimelineable(
 		return false, nil
 	}
 
+	// Don't show statuses of silenced accounts on
+	// this timeline, even to followers of the account.
+	silenced, err := f.isAccountSilenced(ctx, status.Account)
+	if err != nil {
+		return false, gtserror.Newf("error checking if status author silenced: %w", err)
+	}
+
+	if silenced {
+		log.Trace(ctx, "status author is silenced")
+		return false, nil
+	}
+
+	// Check whether status is muted by requester.
+	muted, err := f.StatusMuted(ctx, requester, status)
+	if err != nil {
+		return false, err
+	}
+
+	if muted {
+		log.Trace(ctx, "status muted by timeline requester")
+		return false, nil
+	}
+
 	// Looks good!
 	return true, nil
 }
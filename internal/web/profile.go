@@ -121,6 +121,7 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		maxStatusID    = apiutil.ParseMaxID(c.Query(apiutil.MaxIDKey), "")
 		paging         = maxStatusID != ""
 		pinnedStatuses []*apimodel.Status
+		featuredTags   []*apimodel.FeaturedTag
 	)
 
 	if !paging {
@@ -131,6 +132,13 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
 			return
 		}
+
+		// Load + display featured tags too.
+		featuredTags, errWithCode = m.processor.Tags().AccountFeatured(ctx, authed.Account, targetAccount.ID)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+			return
+		}
 	}
 
 	// Get statuses from maxStatusID onwards (or from top if empty string).
@@ -157,6 +165,7 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 			"statuses":         statusResp.Items,
 			"statuses_next":    statusResp.NextLink,
 			"pinned_statuses":  pinnedStatuses,
+			"featured_tags":    featuredTags,
 			"show_back_to_top": paging,
 		},
 	}
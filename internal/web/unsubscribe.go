@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// unsubscribeGETHandler serves a page asking the user to confirm
+// unsubscribing from notification emails. Unsubscribing is only
+// done on POST, so that link scanners don't unsubscribe users.
+func (m *Module) unsubscribeGETHandler(c *gin.Context) {
+	m.unsubscribe(c, false)
+}
+
+// unsubscribePOSTHandler unsubscribes the user from notification
+// emails. This is both the target of the form served on GET, and
+// of one-click unsubscribe requests from mail clients (RFC 8058).
+func (m *Module) unsubscribePOSTHandler(c *gin.Context) {
+	m.unsubscribe(c, true)
+}
+
+func (m *Module) unsubscribe(c *gin.Context, confirmed bool) {
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Return instance we already got from the db,
+	// don't try to fetch it again when erroring.
+	instanceGet := func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode) {
+		return instance, nil
+	}
+
+	// We only serve text/html at this endpoint.
+	if _, err := apiutil.NegotiateAccept(c, apiutil.TextHTML); err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), instanceGet)
+		return
+	}
+
+	// If there's no token in the query,
+	// just serve the 404 web handler.
+	token := c.Query("token")
+	if token == "" {
+		errWithCode := gtserror.NewErrorNotFound(errors.New(http.StatusText(http.StatusNotFound)))
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	extra := map[string]any{
+		"token":        token,
+		"unsubscribed": confirmed,
+	}
+
+	if confirmed {
+		user, errWithCode := m.processor.User().EmailUnsubscribe(c.Request.Context(), token)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+			return
+		}
+		extra["email"] = user.Email
+	}
+
+	page := apiutil.WebPage{
+		Template: "unsubscribe.tmpl",
+		Instance: instance,
+		Extra:    extra,
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
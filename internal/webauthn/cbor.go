@@ -0,0 +1,255 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// maxCBORDepth is the maximum nesting of arrays
+// and maps we'll decode, which is far more than
+// is needed for any of the WebAuthn structures.
+const maxCBORDepth = 8
+
+var errCBORShort = errors.New("cbor: unexpected end of data")
+
+// decodeCBOR decodes the first CBOR (RFC 8949) data item in b, returning
+// it along with any remaining bytes. Only what's needed for WebAuthn is
+// supported, ie., definite length items. Values decode to:
+//
+//   - unsigned + negative integers: int64
+//   - byte strings: []byte
+//   - text strings: string
+//   - arrays: []any
+//   - maps: map[any]any (keys being int64 or string)
+//   - simple values: bool, or nil for null / undefined
+//   - floats: float64
+func decodeCBOR(b []byte) (any, []byte, error) {
+	return decodeCBORItem(b, 0)
+}
+
+func decodeCBORItem(b []byte, depth int) (any, []byte, error) {
+	if depth > maxCBORDepth {
+		return nil, nil, errors.New("cbor: nested too deeply")
+	}
+
+	if len(b) == 0 {
+		return nil, nil, errCBORShort
+	}
+
+	major := b[0] >> 5
+	info := b[0] & 0x1f
+
+	if major == 7 {
+		return decodeCBORSimple(b)
+	}
+
+	arg, b, err := decodeCBORArg(b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+
+	// Unsigned integer.
+	case 0:
+		if arg > math.MaxInt64 {
+			return nil, nil, errors.New("cbor: integer overflow")
+		}
+		return int64(arg), b, nil
+
+	// Negative integer.
+	case 1:
+		if arg > math.MaxInt64 {
+			return nil, nil, errors.New("cbor: integer overflow")
+		}
+		return -1 - int64(arg), b, nil
+
+	// Byte string.
+	case 2:
+		if uint64(len(b)) < arg {
+			return nil, nil, errCBORShort
+		}
+		return b[:arg:arg], b[arg:], nil
+
+	// Text string.
+	case 3:
+		if uint64(len(b)) < arg {
+			return nil, nil, errCBORShort
+		}
+		return string(b[:arg]), b[arg:], nil
+
+	// Array.
+	case 4:
+		// Each item is at least one byte,
+		// so sanity check length first.
+		if uint64(len(b)) < arg {
+			return nil, nil, errCBORShort
+		}
+
+		array := make([]any, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			var item any
+			item, b, err = decodeCBORItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			array = append(array, item)
+		}
+		return array, b, nil
+
+	// Map.
+	case 5:
+		// Each pair is at least two bytes,
+		// so sanity check length first.
+		if uint64(len(b))/2 < arg {
+			return nil, nil, errCBORShort
+		}
+
+		m := make(map[any]any, arg)
+		for i := uint64(0); i < arg; i++ {
+			var key, value any
+			key, b, err = decodeCBORItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, nil, fmt.Errorf("cbor: unsupported map key type %T", key)
+			}
+
+			if _, ok := m[key]; ok {
+				return nil, nil, fmt.Errorf("cbor: duplicate map key %v", key)
+			}
+
+			value, b, err = decodeCBORItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			m[key] = value
+		}
+		return m, b, nil
+
+	// Tag, just decode
+	// the tagged item.
+	case 6:
+		return decodeCBORItem(b, depth+1)
+	}
+
+	return nil, nil, fmt.Errorf("cbor: unsupported major type %d (info %d)", major, info)
+}
+
+// decodeCBORArg decodes the argument of the data item
+// at the start of b, returning the remaining bytes.
+func decodeCBORArg(b []byte) (uint64, []byte, error) {
+	info := b[0] & 0x1f
+	b = b[1:]
+
+	switch {
+	case info < 24:
+		return uint64(info), b, nil
+	case info == 24:
+		if len(b) < 1 {
+			return 0, nil, errCBORShort
+		}
+		return uint64(b[0]), b[1:], nil
+	case info == 25:
+		if len(b) < 2 {
+			return 0, nil, errCBORShort
+		}
+		return uint64(binary.BigEndian.Uint16(b)), b[2:], nil
+	case info == 26:
+		if len(b) < 4 {
+			return 0, nil, errCBORShort
+		}
+		return uint64(binary.BigEndian.Uint32(b)), b[4:], nil
+	case info == 27:
+		if len(b) < 8 {
+			return 0, nil, errCBORShort
+		}
+		return binary.BigEndian.Uint64(b), b[8:], nil
+	default:
+		return 0, nil, fmt.Errorf("cbor: unsupported additional info %d", info)
+	}
+}
+
+// decodeCBORSimple decodes the major type 7 (simple
+// value / float) item at the start of b, returning
+// the remaining bytes.
+func decodeCBORSimple(b []byte) (any, []byte, error) {
+	info := b[0] & 0x1f
+	b = b[1:]
+
+	switch info {
+	case 20:
+		return false, b, nil
+	case 21:
+		return true, b, nil
+	case 22, 23:
+		return nil, b, nil
+	case 25:
+		if len(b) < 2 {
+			return nil, nil, errCBORShort
+		}
+		return halfToFloat64(binary.BigEndian.Uint16(b)), b[2:], nil
+	case 26:
+		if len(b) < 4 {
+			return nil, nil, errCBORShort
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), b[4:], nil
+	case 27:
+		if len(b) < 8 {
+			return nil, nil, errCBORShort
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), b[8:], nil
+	default:
+		return nil, nil, fmt.Errorf("cbor: unsupported simple value %d", info)
+	}
+}
+
+// halfToFloat64 converts IEEE 754 half precision float bits to float64.
+func halfToFloat64(h uint16) float64 {
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+
+	if h&0x8000 != 0 {
+		f = -f
+	}
+
+	return f
+}
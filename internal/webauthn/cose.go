@@ -0,0 +1,172 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifiers of the signature algorithms we
+// support, in order of preference. See the IANA registry:
+// https://www.iana.org/assignments/cose/cose.xhtml#algorithms
+const (
+	AlgES256 = -7   // ECDSA w/ SHA-256
+	AlgEdDSA = -8   // EdDSA (Ed25519 only)
+	AlgRS256 = -257 // RSASSA-PKCS1-v1_5 w/ SHA-256
+)
+
+// COSE key parameters + values.
+// See: https://www.rfc-editor.org/rfc/rfc9053
+const (
+	coseKeyKty = 1
+	coseKeyAlg = 3
+
+	coseKtyOKP = 1
+	coseKtyEC2 = 2
+	coseKtyRSA = 3
+
+	coseEC2Crv = -1
+	coseEC2X   = -2
+	coseEC2Y   = -3
+
+	coseOKPCrv = -1
+	coseOKPX   = -2
+
+	coseRSAN = -1
+	coseRSAE = -2
+
+	coseCrvP256    = 1
+	coseCrvEd25519 = 6
+)
+
+// publicKey is a decoded COSE_Key.
+type publicKey struct {
+	alg int64
+	key crypto.PublicKey
+}
+
+// parsePublicKey parses the CBOR encoded COSE_Key in b, returning
+// any remaining bytes (as a COSE_Key is embedded in authenticator
+// data, followed by any extensions).
+func parsePublicKey(b []byte) (*publicKey, []byte, error) {
+	v, rest, err := decodeCBOR(b)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decoding public key: %w", err)
+	}
+
+	m, ok := v.(map[any]any)
+	if !ok {
+		return nil, nil, errors.New("public key was not a map")
+	}
+
+	kty, _ := m[int64(coseKeyKty)].(int64)
+	alg, _ := m[int64(coseKeyAlg)].(int64)
+
+	switch {
+	case kty == coseKtyEC2 && alg == AlgES256:
+		crv, _ := m[int64(coseEC2Crv)].(int64)
+		x, _ := m[int64(coseEC2X)].([]byte)
+		y, _ := m[int64(coseEC2Y)].([]byte)
+		if crv != coseCrvP256 || len(x) != 32 || len(y) != 32 {
+			return nil, nil, errors.New("invalid ES256 public key")
+		}
+
+		key := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+
+		// Ensure point is on the curve, by round
+		// tripping through the (checked) ecdh form.
+		if _, err := key.ECDH(); err != nil {
+			return nil, nil, fmt.Errorf("invalid ES256 public key: %w", err)
+		}
+
+		return &publicKey{alg: alg, key: key}, rest, nil
+
+	case kty == coseKtyOKP && alg == AlgEdDSA:
+		crv, _ := m[int64(coseOKPCrv)].(int64)
+		x, _ := m[int64(coseOKPX)].([]byte)
+		if crv != coseCrvEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, nil, errors.New("invalid EdDSA public key")
+		}
+
+		key := ed25519.PublicKey(x)
+		return &publicKey{alg: alg, key: key}, rest, nil
+
+	case kty == coseKtyRSA && alg == AlgRS256:
+		n, _ := m[int64(coseRSAN)].([]byte)
+		e, _ := m[int64(coseRSAE)].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			// Require at least 2048 bit keys.
+			return nil, nil, errors.New("invalid RS256 public key")
+		}
+
+		var exp int
+		for _, b := range e {
+			exp = exp<<8 | int(b)
+		}
+
+		key := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: exp,
+		}
+
+		return &publicKey{alg: alg, key: key}, rest, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unsupported public key type %d / algorithm %d", kty, alg)
+	}
+}
+
+// verify checks sig is a valid signature of data by the key.
+func (k *publicKey) verify(data []byte, sig []byte) error {
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		sum := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(key, sum[:], sig) {
+			return errors.New("invalid ES256 signature")
+		}
+		return nil
+
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, sig) {
+			return errors.New("invalid EdDSA signature")
+		}
+		return nil
+
+	case *rsa.PublicKey:
+		sum := sha256.Sum256(data)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], sig); err != nil {
+			return fmt.Errorf("invalid RS256 signature: %w", err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
@@ -0,0 +1,446 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package webauthn implements the relying party side of the WebAuthn
+// (https://www.w3.org/TR/webauthn-2/) registration and authentication
+// ceremonies, allowing users to sign in with passkeys + security keys.
+//
+// Attestation is not requested, so attestation statements are not
+// verified: we only care that a credential's key signs assertions,
+// not who manufactured the authenticator holding it.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+const (
+	// Timeout is how long users are given to complete
+	// a ceremony, after which its challenge is invalid.
+	Timeout = 5 * time.Minute
+
+	// challengeSize is the length in
+	// bytes of generated challenges.
+	challengeSize = 32
+)
+
+// Authenticator data flags.
+const (
+	flagUserPresent      = 0x01
+	flagUserVerified     = 0x04
+	flagAttestedCredData = 0x40
+)
+
+// Base64URL is a byte slice which is JSON encoded as unpadded base64url,
+// as is used for binary values in the JSON forms of WebAuthn structures.
+type Base64URL []byte
+
+// MarshalJSON implements json.Marshaler.
+func (b Base64URL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Base64URL) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	decoded, err := DecodeBase64URL(s)
+	if err != nil {
+		return err
+	}
+
+	*b = decoded
+	return nil
+}
+
+// DecodeBase64URL decodes base64url, with or without padding.
+func DecodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// RelyingParty is the party (ie., this instance) which users
+// register credentials with, and then authenticate to.
+type RelyingParty struct {
+	// ID is the domain credentials are scoped to.
+	ID string
+
+	// Name is shown to the user
+	// when creating a credential.
+	Name string
+
+	// Origin is the only web origin
+	// from which ceremonies are accepted.
+	Origin string
+}
+
+// NewRelyingParty returns a RelyingParty
+// for this instance, based on config.
+func NewRelyingParty() *RelyingParty {
+	host := config.GetHost()
+
+	// Relying party ID is a
+	// domain, so drop any port.
+	id := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		id = h
+	}
+
+	return &RelyingParty{
+		ID:     id,
+		Name:   host,
+		Origin: config.GetProtocol() + "://" + host,
+	}
+}
+
+// NewChallenge returns a new randomly generated challenge.
+func NewChallenge() ([]byte, error) {
+	challenge := make([]byte, challengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, fmt.Errorf("error reading random bytes: %w", err)
+	}
+	return challenge, nil
+}
+
+// CreationOptions models PublicKeyCredentialCreationOptions,
+// to be passed (decoded) to navigator.credentials.create().
+type CreationOptions struct {
+	Challenge              Base64URL              `json:"challenge"`
+	RP                     RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameters `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions models PublicKeyCredentialRequestOptions,
+// to be passed (decoded) to navigator.credentials.get().
+type RequestOptions struct {
+	Challenge        Base64URL              `json:"challenge"`
+	RPID             string                 `json:"rpId"`
+	Timeout          int64                  `json:"timeout"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// RelyingPartyEntity models PublicKeyCredentialRpEntity.
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserEntity models PublicKeyCredentialUserEntity.
+type UserEntity struct {
+	ID          Base64URL `json:"id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"displayName"`
+}
+
+// CredentialParameters models PublicKeyCredentialParameters.
+type CredentialParameters struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+// CredentialDescriptor models PublicKeyCredentialDescriptor.
+type CredentialDescriptor struct {
+	Type string    `json:"type"`
+	ID   Base64URL `json:"id"`
+}
+
+// AuthenticatorSelection models AuthenticatorSelectionCriteria.
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions returns options for registering a new credential for the
+// user with given ID (an opaque handle), name + display name, excluding any
+// given existing credential IDs so the same authenticator isn't added twice.
+//
+// Credentials are requested to be discoverable (ie., passkeys), allowing
+// passwordless sign in, though authenticators that can't do this are fine
+// too, as these can still be used as a second factor.
+func (rp *RelyingParty) CreationOptions(challenge []byte, userID []byte, name string, displayName string, exclude [][]byte) *CreationOptions {
+	excludeCredentials := make([]CredentialDescriptor, 0, len(exclude))
+	for _, id := range exclude {
+		excludeCredentials = append(excludeCredentials, CredentialDescriptor{
+			Type: "public-key",
+			ID:   id,
+		})
+	}
+
+	return &CreationOptions{
+		Challenge: challenge,
+		RP: RelyingPartyEntity{
+			ID:   rp.ID,
+			Name: rp.Name,
+		},
+		User: UserEntity{
+			ID:          userID,
+			Name:        name,
+			DisplayName: displayName,
+		},
+		PubKeyCredParams: []CredentialParameters{
+			{Type: "public-key", Alg: AlgES256},
+			{Type: "public-key", Alg: AlgEdDSA},
+			{Type: "public-key", Alg: AlgRS256},
+		},
+		Timeout:            Timeout.Milliseconds(),
+		ExcludeCredentials: excludeCredentials,
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: "preferred",
+		},
+		Attestation: "none",
+	}
+}
+
+// RequestOptions returns options for authenticating with one of the given
+// credential IDs. If no credential IDs are given, any discoverable credential
+// for this relying party may be used, ie., for passwordless sign in, in which
+// case user verification (PIN, biometrics, etc) is required.
+func (rp *RelyingParty) RequestOptions(challenge []byte, allow [][]byte) *RequestOptions {
+	userVerification := "preferred"
+	if len(allow) == 0 {
+		userVerification = "required"
+	}
+
+	allowCredentials := make([]CredentialDescriptor, 0, len(allow))
+	for _, id := range allow {
+		allowCredentials = append(allowCredentials, CredentialDescriptor{
+			Type: "public-key",
+			ID:   id,
+		})
+	}
+
+	return &RequestOptions{
+		Challenge:        challenge,
+		RPID:             rp.ID,
+		Timeout:          Timeout.Milliseconds(),
+		AllowCredentials: allowCredentials,
+		UserVerification: userVerification,
+	}
+}
+
+// Credential is a newly registered credential.
+type Credential struct {
+	// ID is the credential ID chosen by the authenticator.
+	ID []byte
+
+	// PublicKey is the CBOR encoded COSE_Key
+	// used to verify the credential's assertions.
+	PublicKey []byte
+
+	// SignCount is the authenticator's
+	// signature counter on registration.
+	SignCount uint32
+}
+
+// VerifyRegistration verifies the response of navigator.credentials.create()
+// to options created with the given challenge, returning the new credential.
+func (rp *RelyingParty) VerifyRegistration(challenge []byte, clientDataJSON []byte, attestationObject []byte) (*Credential, error) {
+	if err := rp.verifyClientData(clientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+
+	v, rest, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding attestation object: %w", err)
+	}
+
+	if len(rest) != 0 {
+		return nil, errors.New("trailing bytes after attestation object")
+	}
+
+	attestation, ok := v.(map[any]any)
+	if !ok {
+		return nil, errors.New("attestation object was not a map")
+	}
+
+	authData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, errors.New("attestation object had no authData")
+	}
+
+	flags, signCount, attested, err := rp.parseAuthData(authData, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if flags&flagAttestedCredData == 0 {
+		return nil, errors.New("authenticator data had no attested credential data")
+	}
+
+	// Attested credential data is:
+	// aaguid (16) | credentialIdLength (2) | credentialId | credentialPublicKey
+	if len(attested) < 18 {
+		return nil, errors.New("attested credential data too short")
+	}
+
+	idLen := int(binary.BigEndian.Uint16(attested[16:18]))
+	attested = attested[18:]
+	if idLen == 0 || idLen > 1023 || len(attested) < idLen {
+		return nil, errors.New("invalid credential id length")
+	}
+
+	id := attested[:idLen]
+	keyBytes := attested[idLen:]
+
+	_, rest, err = parsePublicKey(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:        bytes.Clone(id),
+		PublicKey: bytes.Clone(keyBytes[:len(keyBytes)-len(rest)]),
+		SignCount: signCount,
+	}, nil
+}
+
+// VerifyAssertion verifies the response of navigator.credentials.get() to
+// options created with the given challenge, for the credential with given
+// stored public key and sign count, returning the updated sign count. If
+// requireUV is true, the user must have been verified by the authenticator.
+func (rp *RelyingParty) VerifyAssertion(
+	challenge []byte,
+	clientDataJSON []byte,
+	authenticatorData []byte,
+	signature []byte,
+	publicKeyBytes []byte,
+	signCount uint32,
+	requireUV bool,
+) (uint32, error) {
+	if err := rp.verifyClientData(clientDataJSON, "webauthn.get", challenge); err != nil {
+		return 0, err
+	}
+
+	_, newSignCount, _, err := rp.parseAuthData(authenticatorData, requireUV)
+	if err != nil {
+		return 0, err
+	}
+
+	key, _, err := parsePublicKey(publicKeyBytes)
+	if err != nil {
+		return 0, err
+	}
+
+	// Signature is over the authenticator
+	// data and the hash of the client data.
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := make([]byte, 0, len(authenticatorData)+len(clientDataHash))
+	signed = append(signed, authenticatorData...)
+	signed = append(signed, clientDataHash[:]...)
+
+	if err := key.verify(signed, signature); err != nil {
+		return 0, err
+	}
+
+	// If the authenticator keeps a signature counter, it
+	// must always increase, else the credential may have
+	// been cloned. Passkeys synced between devices always
+	// report zero, in which case there's nothing to check.
+	if (newSignCount != 0 || signCount != 0) && newSignCount <= signCount {
+		return 0, fmt.Errorf("sign count %d not greater than stored sign count %d", newSignCount, signCount)
+	}
+
+	return newSignCount, nil
+}
+
+// verifyClientData checks the collected client data is of expected
+// ceremony type, for the expected challenge, from the expected origin.
+func (rp *RelyingParty) verifyClientData(clientDataJSON []byte, typ string, challenge []byte) error {
+	var clientData struct {
+		Type        string `json:"type"`
+		Challenge   string `json:"challenge"`
+		Origin      string `json:"origin"`
+		CrossOrigin bool   `json:"crossOrigin"`
+	}
+
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return fmt.Errorf("error decoding client data: %w", err)
+	}
+
+	if clientData.Type != typ {
+		return fmt.Errorf("client data type %q was not %q", clientData.Type, typ)
+	}
+
+	got, err := DecodeBase64URL(clientData.Challenge)
+	if err != nil {
+		return fmt.Errorf("error decoding client data challenge: %w", err)
+	}
+
+	if len(challenge) == 0 || subtle.ConstantTimeCompare(got, challenge) != 1 {
+		return errors.New("client data challenge did not match")
+	}
+
+	if clientData.Origin != rp.Origin {
+		return fmt.Errorf("client data origin %q was not %q", clientData.Origin, rp.Origin)
+	}
+
+	if clientData.CrossOrigin {
+		return errors.New("client data was cross origin")
+	}
+
+	return nil
+}
+
+// parseAuthData checks the fixed fields of given authenticator data,
+// returning the flags, sign count, and any remaining variable length
+// data (attested credential data and / or extensions).
+func (rp *RelyingParty) parseAuthData(authData []byte, requireUV bool) (byte, uint32, []byte, error) {
+	// Authenticator data is:
+	// rpIdHash (32) | flags (1) | signCount (4) | ...
+	if len(authData) < 37 {
+		return 0, 0, nil, errors.New("authenticator data too short")
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if subtle.ConstantTimeCompare(authData[:32], rpIDHash[:]) != 1 {
+		return 0, 0, nil, errors.New("authenticator data relying party id hash did not match")
+	}
+
+	flags := authData[32]
+	if flags&flagUserPresent == 0 {
+		return 0, 0, nil, errors.New("user was not present")
+	}
+
+	if requireUV && flags&flagUserVerified == 0 {
+		return 0, 0, nil, errors.New("user was not verified")
+	}
+
+	signCount := binary.BigEndian.Uint32(authData[33:37])
+	return flags, signCount, authData[37:], nil
+}
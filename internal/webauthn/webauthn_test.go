@@ -0,0 +1,250 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webauthn_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type WebAuthnTestSuite struct {
+	suite.Suite
+	rp            *webauthn.RelyingParty
+	authenticator *testrig.SoftwareAuthenticator
+}
+
+func (suite *WebAuthnTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	suite.rp = webauthn.NewRelyingParty()
+	suite.authenticator = testrig.NewSoftwareAuthenticator()
+}
+
+func (suite *WebAuthnTestSuite) challenge() []byte {
+	challenge, err := webauthn.NewChallenge()
+	suite.NoError(err)
+	return challenge
+}
+
+// register registers a new credential
+// with the software authenticator.
+func (suite *WebAuthnTestSuite) register() *webauthn.Credential {
+	challenge := suite.challenge()
+	options := suite.rp.CreationOptions(challenge, []byte("some_user"), "zork@example.org", "zork", nil)
+	attestation := suite.authenticator.Create(options)
+
+	credential, err := suite.rp.VerifyRegistration(challenge, attestation.ClientDataJSON, attestation.AttestationObject)
+	suite.NoError(err)
+	suite.Equal(attestation.ID, credential.ID)
+	return credential
+}
+
+func (suite *WebAuthnTestSuite) TestRelyingParty() {
+	suite.Equal("localhost", suite.rp.ID)
+	suite.Equal("localhost:8080", suite.rp.Name)
+	suite.Equal("http://localhost:8080", suite.rp.Origin)
+}
+
+func (suite *WebAuthnTestSuite) TestCreationOptionsJSON() {
+	options := suite.rp.CreationOptions([]byte{1, 2, 3}, []byte("some_user"), "zork@example.org", "zork", [][]byte{{4, 5, 6}})
+
+	b, err := json.MarshalIndent(options, "", "  ")
+	suite.NoError(err)
+	suite.Equal(`{
+  "challenge": "AQID",
+  "rp": {
+    "id": "localhost",
+    "name": "localhost:8080"
+  },
+  "user": {
+    "id": "c29tZV91c2Vy",
+    "name": "zork@example.org",
+    "displayName": "zork"
+  },
+  "pubKeyCredParams": [
+    {
+      "type": "public-key",
+      "alg": -7
+    },
+    {
+      "type": "public-key",
+      "alg": -8
+    },
+    {
+      "type": "public-key",
+      "alg": -257
+    }
+  ],
+  "timeout": 300000,
+  "excludeCredentials": [
+    {
+      "type": "public-key",
+      "id": "BAUG"
+    }
+  ],
+  "authenticatorSelection": {
+    "residentKey": "preferred",
+    "userVerification": "preferred"
+  },
+  "attestation": "none"
+}`, string(b))
+}
+
+func (suite *WebAuthnTestSuite) TestRegisterAndAssert() {
+	credential := suite.register()
+
+	challenge := suite.challenge()
+	options := suite.rp.RequestOptions(challenge, [][]byte{credential.ID})
+	suite.Equal("preferred", options.UserVerification)
+
+	assertion := suite.authenticator.Get(options)
+	suite.NotNil(assertion)
+	suite.Equal([]byte("some_user"), assertion.UserHandle)
+
+	signCount, err := suite.rp.VerifyAssertion(
+		challenge,
+		assertion.ClientDataJSON,
+		assertion.AuthenticatorData,
+		assertion.Signature,
+		credential.PublicKey,
+		credential.SignCount,
+		false,
+	)
+	suite.NoError(err)
+	suite.EqualValues(1, signCount)
+
+	// Replaying the same assertion
+	// with the new sign count fails.
+	_, err = suite.rp.VerifyAssertion(
+		challenge,
+		assertion.ClientDataJSON,
+		assertion.AuthenticatorData,
+		assertion.Signature,
+		credential.PublicKey,
+		signCount,
+		false,
+	)
+	suite.EqualError(err, "sign count 1 not greater than stored sign count 1")
+}
+
+func (suite *WebAuthnTestSuite) TestAssertWrongChallenge() {
+	credential := suite.register()
+
+	options := suite.rp.RequestOptions(suite.challenge(), nil)
+	suite.Equal("required", options.UserVerification)
+
+	assertion := suite.authenticator.Get(options)
+	_, err := suite.rp.VerifyAssertion(
+		suite.challenge(),
+		assertion.ClientDataJSON,
+		assertion.AuthenticatorData,
+		assertion.Signature,
+		credential.PublicKey,
+		credential.SignCount,
+		true,
+	)
+	suite.EqualError(err, "client data challenge did not match")
+}
+
+func (suite *WebAuthnTestSuite) TestAssertWrongOrigin() {
+	credential := suite.register()
+	suite.authenticator.Origin = "https://evil.example.org"
+
+	challenge := suite.challenge()
+	assertion := suite.authenticator.Get(suite.rp.RequestOptions(challenge, nil))
+	_, err := suite.rp.VerifyAssertion(
+		challenge,
+		assertion.ClientDataJSON,
+		assertion.AuthenticatorData,
+		assertion.Signature,
+		credential.PublicKey,
+		credential.SignCount,
+		false,
+	)
+	suite.EqualError(err, `client data origin "https://evil.example.org" was not "http://localhost:8080"`)
+}
+
+func (suite *WebAuthnTestSuite) TestAssertUserNotVerified() {
+	credential := suite.register()
+	suite.authenticator.UserVerified = false
+
+	challenge := suite.challenge()
+	assertion := suite.authenticator.Get(suite.rp.RequestOptions(challenge, nil))
+
+	// Fine as a second factor...
+	_, err := suite.rp.VerifyAssertion(
+		challenge,
+		assertion.ClientDataJSON,
+		assertion.AuthenticatorData,
+		assertion.Signature,
+		credential.PublicKey,
+		credential.SignCount,
+		false,
+	)
+	suite.NoError(err)
+
+	// ...but not passwordless.
+	_, err = suite.rp.VerifyAssertion(
+		challenge,
+		assertion.ClientDataJSON,
+		assertion.AuthenticatorData,
+		assertion.Signature,
+		credential.PublicKey,
+		credential.SignCount,
+		true,
+	)
+	suite.EqualError(err, "user was not verified")
+}
+
+func (suite *WebAuthnTestSuite) TestAssertBadSignature() {
+	credential := suite.register()
+
+	challenge := suite.challenge()
+	assertion := suite.authenticator.Get(suite.rp.RequestOptions(challenge, nil))
+	assertion.AuthenticatorData[36]++ // tamper with sign count
+
+	_, err := suite.rp.VerifyAssertion(
+		challenge,
+		assertion.ClientDataJSON,
+		assertion.AuthenticatorData,
+		assertion.Signature,
+		credential.PublicKey,
+		credential.SignCount,
+		false,
+	)
+	suite.EqualError(err, "invalid ES256 signature")
+}
+
+func (suite *WebAuthnTestSuite) TestRegisterWrongType() {
+	challenge := suite.challenge()
+	options := suite.rp.CreationOptions(challenge, []byte("some_user"), "zork@example.org", "zork", nil)
+	suite.authenticator.Create(options)
+
+	// Use an assertion's client
+	// data for registration.
+	assertion := suite.authenticator.Get(suite.rp.RequestOptions(challenge, nil))
+	_, err := suite.rp.VerifyRegistration(challenge, assertion.ClientDataJSON, assertion.AuthenticatorData)
+	suite.EqualError(err, `client data type "webauthn.get" was not "webauthn.create"`)
+}
+
+func TestWebAuthnTestSuite(t *testing.T) {
+	suite.Run(t, new(WebAuthnTestSuite))
+}
@@ -0,0 +1,185 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// recordSize is the record size used for aes128gcm content
+	// encoding. Push services only accept single record messages
+	// of around 4KiB, so payloads are always sent in one record.
+	recordSize = 4096
+
+	// maxPayloadSize is the largest plaintext payload that fits in
+	// one record, minus the AEAD tag and the padding delimiter.
+	maxPayloadSize = recordSize - 16 - 1
+
+	// authSecretSize is the size of user agent authentication secrets.
+	authSecretSize = 16
+)
+
+// CheckKeys checks whether the given base64 encoded user
+// agent public key (p256dh) and authentication secret (auth)
+// of a subscription can be used to encrypt messages.
+func CheckKeys(p256dh string, auth string) error {
+	_, _, err := decodeKeys(p256dh, auth)
+	return err
+}
+
+// decodeKeys decodes and parses the given base64 encoded user agent
+// public key (p256dh) and authentication secret (auth) of a subscription.
+func decodeKeys(p256dh string, auth string) (*ecdh.PublicKey, []byte, error) {
+	p256dhBytes, err := decodeBase64(p256dh)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decoding p256dh: %w", err)
+	}
+
+	uaPublic, err := ecdh.P256().NewPublicKey(p256dhBytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing p256dh: %w", err)
+	}
+
+	authSecret, err := decodeBase64(auth)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decoding auth: %w", err)
+	}
+
+	if len(authSecret) != authSecretSize {
+		return nil, nil, fmt.Errorf("auth must be %d bytes, was %d", authSecretSize, len(authSecret))
+	}
+
+	return uaPublic, authSecret, nil
+}
+
+// encrypt encrypts the given plaintext for the user agent with the
+// given public key (p256dh) and authentication secret (auth), using
+// "aes128gcm" content encoding as described in RFC 8291 and RFC 8188.
+func encrypt(p256dh string, auth string, plaintext []byte) ([]byte, error) {
+	if len(plaintext) > maxPayloadSize {
+		return nil, fmt.Errorf("payload of %d bytes exceeds maximum of %d", len(plaintext), maxPayloadSize)
+	}
+
+	uaPublic, authSecret, err := decodeKeys(p256dh, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate a fresh application server key pair and
+	// salt, these must never be reused between messages.
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("error generating key pair: %w", err)
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("error generating salt: %w", err)
+	}
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("error computing shared secret: %w", err)
+	}
+
+	// key_info = "WebPush: info" || 0x00 || ua_public || as_public
+	keyInfo := make([]byte, 0, 14+2*len(asPublic))
+	keyInfo = append(keyInfo, "WebPush: info\x00"...)
+	keyInfo = append(keyInfo, uaPublic.Bytes()...)
+	keyInfo = append(keyInfo, asPublic...)
+
+	// IKM = HKDF(auth_secret, ecdh_secret, key_info, 32)
+	ikm, err := readHKDF(ecdhSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	// CEK = HKDF(salt, IKM, "Content-Encoding: aes128gcm" || 0x00, 16)
+	cek, err := readHKDF(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+
+	// NONCE = HKDF(salt, IKM, "Content-Encoding: nonce" || 0x00, 12)
+	nonce, err := readHKDF(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error creating gcm: %w", err)
+	}
+
+	// Header = salt || rs || idlen || keyid, where
+	// the key id is the application server public key.
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	// The only (and so last) record is
+	// delimited by 0x02, without padding.
+	record := make([]byte, 0, len(plaintext)+1)
+	record = append(record, plaintext...)
+	record = append(record, 0x02)
+
+	return gcm.Seal(header, nonce, record, nil), nil
+}
+
+// readHKDF reads length bytes from
+// HKDF-SHA-256 with the given parameters.
+func readHKDF(secret []byte, salt []byte, info []byte, length int) ([]byte, error) {
+	b := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), b); err != nil {
+		return nil, fmt.Errorf("error reading hkdf: %w", err)
+	}
+	return b, nil
+}
+
+// decodeBase64 decodes the given base64 string, which may be
+// either URL or standard encoded, with or without padding, as
+// user agents are not entirely consistent about this.
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	if s == "" {
+		return nil, errors.New("empty string")
+	}
+	return base64.RawURLEncoding.DecodeString(s)
+}
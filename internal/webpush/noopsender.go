@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// NewNoopSender returns a no-op Web Push sender that will just execute
+// the given sendCallback every time it would otherwise push a notification.
+//
+// Passing a nil function is also acceptable, in which case Send will just return nil.
+func NewNoopSender(sendCallback func(notification *gtsmodel.Notification)) Sender {
+	return &noopSender{
+		sendCallback: sendCallback,
+	}
+}
+
+type noopSender struct {
+	sendCallback func(notification *gtsmodel.Notification)
+}
+
+func (s *noopSender) Send(
+	ctx context.Context,
+	notification *gtsmodel.Notification,
+	apiNotification *apimodel.Notification,
+) error {
+	if s.sendCallback != nil {
+		s.sendCallback(notification)
+	}
+	return nil
+}
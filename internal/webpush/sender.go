@@ -0,0 +1,315 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+const (
+	// ttl is how long push services should keep trying
+	// to deliver a message to an offline user agent.
+	ttl = 48 * time.Hour
+
+	// maxBodyLength is the maximum number of
+	// characters of notification body to push.
+	maxBodyLength = 140
+)
+
+// Sender contains functions for pushing
+// notifications to Web Push subscriptions.
+type Sender interface {
+	// Send pushes the given notification to each Web Push subscription
+	// of the notification's target account which wants this type of
+	// notification, from this origin account. Subscriptions which have
+	// been removed on the push service side are deleted.
+	Send(ctx context.Context, notification *gtsmodel.Notification, apiNotification *apimodel.Notification) error
+}
+
+// HTTPClient is the subset of http client
+// functionality used to deliver push messages.
+type HTTPClient interface {
+	Do(r *http.Request) (*http.Response, error)
+}
+
+// NewSender returns a new Web Push Sender, which
+// delivers push messages using the given client.
+func NewSender(client HTTPClient, state *state.State) Sender {
+	return &sender{
+		client: client,
+		state:  state,
+	}
+}
+
+type sender struct {
+	client HTTPClient
+	state  *state.State
+}
+
+// message is the JSON payload pushed to
+// user agents, in the same shape as Mastodon.
+type message struct {
+	AccessToken      string `json:"access_token"`
+	PreferredLocale  string `json:"preferred_locale"`
+	NotificationID   string `json:"notification_id"`
+	NotificationType string `json:"notification_type"`
+	Icon             string `json:"icon"`
+	Title            string `json:"title"`
+	Body             string `json:"body"`
+}
+
+func (s *sender) Send(
+	ctx context.Context,
+	notification *gtsmodel.Notification,
+	apiNotification *apimodel.Notification,
+) error {
+	subscriptions, err := s.state.DB.GetWebPushSubscriptionsByAccountID(ctx, notification.TargetAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting web push subscriptions: %w", err)
+	}
+
+	if len(subscriptions) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	keyPair, err := GetVAPIDKeyPair(ctx, s.state)
+	if err != nil {
+		return err
+	}
+
+	var errs gtserror.MultiError
+
+	for _, subscription := range subscriptions {
+		if !subscription.AlertEnabled(notification.NotificationType) {
+			// Subscriber doesn't
+			// want this type.
+			continue
+		}
+
+		allowed, err := s.policyAllows(ctx, subscription, notification)
+		if err != nil {
+			errs.Appendf("error checking policy of web push subscription %s: %w", subscription.ID, err)
+			continue
+		}
+
+		if !allowed {
+			// Subscriber doesn't want
+			// it from this account.
+			continue
+		}
+
+		if err := s.push(ctx, keyPair, subscription, notification, apiNotification); err != nil {
+			errs.Appendf("error pushing to web push subscription %s: %w", subscription.ID, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// policyAllows returns whether the policy of the given
+// subscription allows pushing the given notification,
+// depending on the relationship with its origin account.
+func (s *sender) policyAllows(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+	notification *gtsmodel.Notification,
+) (bool, error) {
+	switch subscription.Policy {
+	case gtsmodel.WebPushSubscriptionPolicyNone:
+		return false, nil
+
+	case gtsmodel.WebPushSubscriptionPolicyFollowed:
+		return s.state.DB.IsFollowing(ctx, subscription.AccountID, notification.OriginAccountID)
+
+	case gtsmodel.WebPushSubscriptionPolicyFollower:
+		return s.state.DB.IsFollowing(ctx, notification.OriginAccountID, subscription.AccountID)
+
+	default:
+		return true, nil
+	}
+}
+
+// push encrypts and delivers the given
+// notification to the given subscription.
+func (s *sender) push(
+	ctx context.Context,
+	keyPair *gtsmodel.VAPIDKeyPair,
+	subscription *gtsmodel.WebPushSubscription,
+	notification *gtsmodel.Notification,
+	apiNotification *apimodel.Notification,
+) error {
+	// Fetch the token the subscription was created with,
+	// the client needs its access token to fetch the
+	// notification in full after being woken up.
+	token := &gtsmodel.Token{}
+	if err := s.state.DB.GetByID(ctx, subscription.TokenID, token); err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting token: %w", err)
+		}
+
+		// Token has been revoked since,
+		// so subscription is stale.
+		return s.delete(ctx, subscription)
+	}
+
+	msg := newMessage(notification, apiNotification)
+	msg.AccessToken = token.Access
+
+	plaintext, err := json.Marshal(msg)
+	if err != nil {
+		return gtserror.Newf("error marshaling message: %w", err)
+	}
+
+	ciphertext, err := encrypt(subscription.P256dh, subscription.Auth, plaintext)
+	if err != nil {
+		return gtserror.Newf("error encrypting message: %w", err)
+	}
+
+	authorization, err := vapidAuthorization(keyPair, subscription.Endpoint, time.Now())
+	if err != nil {
+		return gtserror.Newf("error creating vapid authorization: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		subscription.Endpoint,
+		bytes.NewReader(ciphertext),
+	)
+	if err != nil {
+		return gtserror.Newf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(ttl.Seconds())))
+	req.Header.Set("Urgency", "normal")
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return gtserror.Newf("error doing request: %w", err)
+	}
+
+	// Drain body so connection can be reused.
+	_, _ = io.Copy(io.Discard, rsp.Body)
+	_ = rsp.Body.Close()
+
+	switch {
+	case rsp.StatusCode >= 200 && rsp.StatusCode < 300:
+		return nil
+
+	case rsp.StatusCode == http.StatusNotFound ||
+		rsp.StatusCode == http.StatusGone:
+		// Push service says the subscription has
+		// expired or been unsubscribed, remove it.
+		return s.delete(ctx, subscription)
+
+	default:
+		return gtserror.Newf("push service returned %s", rsp.Status)
+	}
+}
+
+// delete deletes the given stale subscription.
+func (s *sender) delete(ctx context.Context, subscription *gtsmodel.WebPushSubscription) error {
+	log.Debugf(ctx, "deleting stale web push subscription %s", subscription.ID)
+	if err := s.state.DB.DeleteWebPushSubscriptionByID(ctx, subscription.ID); err != nil {
+		return gtserror.Newf("db error deleting web push subscription: %w", err)
+	}
+	return nil
+}
+
+// newMessage creates a new push message for the given
+// notification, without the access token filled in.
+func newMessage(notification *gtsmodel.Notification, apiNotification *apimodel.Notification) *message {
+	msg := &message{
+		NotificationID:   apiNotification.ID,
+		NotificationType: apiNotification.Type,
+	}
+
+	if notification.TargetAccount != nil {
+		msg.PreferredLocale = notification.TargetAccount.Language
+	}
+
+	name := "someone"
+	if account := apiNotification.Account; account != nil {
+		name = account.DisplayName
+		if name == "" {
+			name = "@" + account.Acct
+		}
+		msg.Icon = account.Avatar
+	}
+
+	switch notification.NotificationType {
+	case gtsmodel.NotificationFollow:
+		msg.Title = fmt.Sprintf("%s followed you", name)
+	case gtsmodel.NotificationFollowRequest:
+		msg.Title = fmt.Sprintf("%s requested to follow you", name)
+	case gtsmodel.NotificationFave:
+		msg.Title = fmt.Sprintf("%s favourited your post", name)
+	case gtsmodel.NotificationMention:
+		msg.Title = fmt.Sprintf("%s mentioned you", name)
+	case gtsmodel.NotificationReblog:
+		msg.Title = fmt.Sprintf("%s boosted your post", name)
+	case gtsmodel.NotificationPoll:
+		if notification.OriginAccountID == notification.TargetAccountID {
+			msg.Title = "Your poll has ended"
+		} else {
+			msg.Title = "A poll you voted in has ended"
+		}
+	case gtsmodel.NotificationStatus:
+		msg.Title = fmt.Sprintf("%s just posted", name)
+	case gtsmodel.NotificationSignup:
+		msg.Title = fmt.Sprintf("%s signed up", name)
+	default:
+		msg.Title = fmt.Sprintf("New notification from %s", name)
+	}
+
+	// Use status content warning or content
+	// as body, falling back to account bio.
+	switch {
+	case apiNotification.Status != nil && apiNotification.Status.SpoilerText != "":
+		msg.Body = apiNotification.Status.SpoilerText
+	case apiNotification.Status != nil:
+		msg.Body = text.SanitizeToPlaintext(apiNotification.Status.Content)
+	case apiNotification.Account != nil:
+		msg.Body = text.SanitizeToPlaintext(apiNotification.Account.Note)
+	}
+
+	if body := []rune(msg.Body); len(body) > maxBodyLength {
+		msg.Body = string(body[:maxBodyLength-1]) + "…"
+	}
+
+	return msg
+}
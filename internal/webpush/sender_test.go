@@ -0,0 +1,286 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+	"golang.org/x/crypto/hkdf"
+)
+
+type SenderTestSuite struct {
+	suite.Suite
+	state state.State
+
+	testAccounts map[string]*gtsmodel.Account
+	testTokens   map[string]*gtsmodel.Token
+
+	// user agent keys
+	uaPrivate  *ecdh.PrivateKey
+	authSecret []byte
+
+	// local stand-in for a push service,
+	// responding with the given status
+	pushService *httptest.Server
+	pushStatus  int
+	pushed      []*http.Request
+	pushedBody  [][]byte
+}
+
+func (suite *SenderTestSuite) SetupSuite() {
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testTokens = testrig.NewTestTokens()
+}
+
+func (suite *SenderTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.state.Caches.Init()
+	suite.state.DB = testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, suite.testAccounts)
+
+	var err error
+	suite.uaPrivate, err = ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.authSecret = make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, suite.authSecret); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.pushStatus = http.StatusCreated
+	suite.pushed = nil
+	suite.pushedBody = nil
+	suite.pushService = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		suite.pushed = append(suite.pushed, r)
+		suite.pushedBody = append(suite.pushedBody, b)
+		w.WriteHeader(suite.pushStatus)
+	}))
+}
+
+func (suite *SenderTestSuite) TearDownTest() {
+	suite.pushService.Close()
+	testrig.StandardDBTeardown(suite.state.DB)
+}
+
+// putSubscription puts a subscription for local_account_1,
+// pushing to the stand-in push service, with mentions enabled.
+func (suite *SenderTestSuite) putSubscription() *gtsmodel.WebPushSubscription {
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:           "01HQ5Y6VBZ2K6J8FSXK3Q4A0E3",
+		AccountID:    suite.testAccounts["local_account_1"].ID,
+		TokenID:      suite.testTokens["local_account_1"].ID,
+		Endpoint:     suite.pushService.URL + "/push/abcdef",
+		Auth:         base64.RawURLEncoding.EncodeToString(suite.authSecret),
+		P256dh:       base64.RawURLEncoding.EncodeToString(suite.uaPrivate.PublicKey().Bytes()),
+		AlertMention: util.Ptr(true),
+		Policy:       gtsmodel.WebPushSubscriptionPolicyAll,
+	}
+	if err := suite.state.DB.PutWebPushSubscription(context.Background(), subscription); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return subscription
+}
+
+func (suite *SenderTestSuite) mention() (*gtsmodel.Notification, *apimodel.Notification) {
+	notification := &gtsmodel.Notification{
+		ID:               "01HQ5Y6VBZ2K6J8FSXK3Q4A0E4",
+		NotificationType: gtsmodel.NotificationMention,
+		TargetAccountID:  suite.testAccounts["local_account_1"].ID,
+		TargetAccount:    suite.testAccounts["local_account_1"],
+		OriginAccountID:  suite.testAccounts["admin_account"].ID,
+	}
+
+	apiNotification := &apimodel.Notification{
+		ID:   notification.ID,
+		Type: string(notification.NotificationType),
+		Account: &apimodel.Account{
+			Acct:   "admin",
+			Avatar: "http://localhost:8080/avatar.png",
+		},
+		Status: &apimodel.Status{
+			Content: "<p>hello <span>@the_mighty_zork</span> &amp; friends</p>",
+		},
+	}
+
+	return notification, apiNotification
+}
+
+// decrypt decrypts the given aes128gcm encoded body using
+// the user agent's keys, as described in RFC 8291.
+func (suite *SenderTestSuite) decrypt(body []byte) []byte {
+	suite.Greater(len(body), 21)
+	salt := body[:16]
+	suite.Equal(uint32(4096), binary.BigEndian.Uint32(body[16:20]))
+	idlen := int(body[20])
+	asPublicBytes := body[21 : 21+idlen]
+	ciphertext := body[21+idlen:]
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ecdhSecret, err := suite.uaPrivate.ECDH(asPublic)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	read := func(secret, salt, info []byte, length int) []byte {
+		b := make([]byte, length)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), b); err != nil {
+			suite.FailNow(err.Error())
+		}
+		return b
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), suite.uaPrivate.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm := read(ecdhSecret, suite.authSecret, keyInfo, 32)
+	cek := read(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := read(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	record, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Strip last record delimiter.
+	suite.Equal(byte(0x02), record[len(record)-1])
+	return record[:len(record)-1]
+}
+
+func (suite *SenderTestSuite) TestSend() {
+	var (
+		ctx    = context.Background()
+		sender = webpush.NewSender(http.DefaultClient, &suite.state)
+	)
+
+	suite.putSubscription()
+	notification, apiNotification := suite.mention()
+
+	if err := sender.Send(ctx, notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(suite.pushed, 1)
+	req := suite.pushed[0]
+	suite.Equal("/push/abcdef", req.URL.Path)
+	suite.Equal("aes128gcm", req.Header.Get("Content-Encoding"))
+	suite.NotEmpty(req.Header.Get("TTL"))
+
+	keyPair, err := webpush.GetVAPIDKeyPair(ctx, &suite.state)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	authorization := req.Header.Get("Authorization")
+	suite.True(strings.HasPrefix(authorization, "vapid t="))
+	suite.True(strings.HasSuffix(authorization, ", k="+keyPair.Public))
+
+	msg := make(map[string]string)
+	if err := json.Unmarshal(suite.decrypt(suite.pushedBody[0]), &msg); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(map[string]string{
+		"access_token":      suite.testTokens["local_account_1"].Access,
+		"preferred_locale":  "en",
+		"notification_id":   notification.ID,
+		"notification_type": "mention",
+		"icon":              "http://localhost:8080/avatar.png",
+		"title":             "@admin mentioned you",
+		"body":              "hello @the_mighty_zork & friends",
+	}, msg)
+}
+
+func (suite *SenderTestSuite) TestSendAlertDisabled() {
+	sender := webpush.NewSender(http.DefaultClient, &suite.state)
+
+	suite.putSubscription()
+	notification, apiNotification := suite.mention()
+	notification.NotificationType = gtsmodel.NotificationFave
+	apiNotification.Type = string(gtsmodel.NotificationFave)
+
+	if err := sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Nothing should have been pushed.
+	suite.Empty(suite.pushed)
+}
+
+func (suite *SenderTestSuite) TestSendSubscriptionGone() {
+	var (
+		ctx    = context.Background()
+		sender = webpush.NewSender(http.DefaultClient, &suite.state)
+	)
+
+	subscription := suite.putSubscription()
+	notification, apiNotification := suite.mention()
+
+	// Push service says the
+	// subscription is gone.
+	suite.pushStatus = http.StatusGone
+
+	if err := sender.Send(ctx, notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.pushed, 1)
+
+	// Subscription should have been removed.
+	_, err := suite.state.DB.GetWebPushSubscriptionByTokenID(ctx, subscription.TokenID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestSenderTestSuite(t *testing.T) {
+	suite.Run(t, new(SenderTestSuite))
+}
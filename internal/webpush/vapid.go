@@ -0,0 +1,156 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// vapidExpiry is how long signed VAPID
+// tokens are valid for. RFC 8292 allows
+// at most 24 hours, so stay well below.
+const vapidExpiry = 12 * time.Hour
+
+// GetVAPIDKeyPair returns the instance's VAPID key
+// pair from the database, generating and storing a
+// new one first if it doesn't have one yet.
+func GetVAPIDKeyPair(ctx context.Context, state *state.State) (*gtsmodel.VAPIDKeyPair, error) {
+	keyPair, err := state.DB.GetVAPIDKeyPair(ctx)
+	if err == nil {
+		return keyPair, nil
+	} else if !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting vapid key pair: %w", err)
+	}
+
+	// No key pair yet, create one.
+	keyPair, err = newVAPIDKeyPair()
+	if err != nil {
+		return nil, gtserror.Newf("error generating vapid key pair: %w", err)
+	}
+
+	if err := state.DB.PutVAPIDKeyPair(ctx, keyPair); err != nil {
+		return nil, gtserror.Newf("db error putting vapid key pair: %w", err)
+	}
+
+	// Fetch the key pair again rather than returning ours
+	// straight away, in case another caller raced us to it.
+	keyPair, err = state.DB.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting vapid key pair: %w", err)
+	}
+
+	return keyPair, nil
+}
+
+// newVAPIDKeyPair generates a new P-256 VAPID key pair.
+func newVAPIDKeyPair() (*gtsmodel.VAPIDKeyPair, error) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	privateBytes, err := x509.MarshalECPrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+
+	public, err := private.PublicKey.ECDH()
+	if err != nil {
+		return nil, err
+	}
+
+	return &gtsmodel.VAPIDKeyPair{
+		ID:      id.NewULID(),
+		Public:  base64.RawURLEncoding.EncodeToString(public.Bytes()),
+		Private: base64.RawURLEncoding.EncodeToString(privateBytes),
+	}, nil
+}
+
+// vapidAuthorization returns an Authorization header value for
+// a request to the given push service endpoint, as described
+// in RFC 8292, signed with the given VAPID key pair.
+func vapidAuthorization(keyPair *gtsmodel.VAPIDKeyPair, endpoint string, now time.Time) (string, error) {
+	privateBytes, err := base64.RawURLEncoding.DecodeString(keyPair.Private)
+	if err != nil {
+		return "", fmt.Errorf("error decoding vapid private key: %w", err)
+	}
+
+	private, err := x509.ParseECPrivateKey(privateBytes)
+	if err != nil {
+		return "", fmt.Errorf("error parsing vapid private key: %w", err)
+	}
+
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("error parsing endpoint: %w", err)
+	}
+
+	header, err := json.Marshal(map[string]string{
+		"typ": "JWT",
+		"alg": "ES256",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		// Audience is the origin of the push service.
+		"aud": endpointURL.Scheme + "://" + endpointURL.Host,
+		"exp": now.Add(vapidExpiry).Unix(),
+		"sub": config.GetProtocol() + "://" + config.GetHost(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(claims)
+
+	// ES256 signatures are the concatenation
+	// of the 32 byte big endian r and s values.
+	hash := sha256.Sum256([]byte(unsigned))
+	r, s, err := ecdsa.Sign(rand.Reader, private, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("error signing vapid token: %w", err)
+	}
+
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	token := unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+	return "vapid t=" + token + ", k=" + keyPair.Public, nil
+}
@@ -39,6 +39,10 @@ type Workers struct {
 	// incoming federated actions, and our own side-effects.
 	Federator runners.WorkerPool
 
+	// Delivery provides a worker pool that handles
+	// retrying queued outgoing federation deliveries.
+	Delivery runners.WorkerPool
+
 	// Enqueue functions for clientAPI / federator worker pools,
 	// these are pointers to Processor{}.Enqueue___() msg functions.
 	// This prevents dependency cycling as Processor depends on Workers.
@@ -80,6 +84,10 @@ func (w *Workers) Start() {
 		return w.Federator.Start(4*maxprocs, 400*maxprocs)
 	})
 
+	tryUntil("starting delivery workerpool", 5, func() bool {
+		return w.Delivery.Start(4*maxprocs, 400*maxprocs)
+	})
+
 	tryUntil("starting media workerpool", 5, func() bool {
 		return w.Media.Start(8*maxprocs, 80*maxprocs)
 	})
@@ -90,6 +98,7 @@ func (w *Workers) Stop() {
 	tryUntil("stopping scheduler", 5, w.Scheduler.Stop)
 	tryUntil("stopping client API workerpool", 5, w.ClientAPI.Stop)
 	tryUntil("stopping federator workerpool", 5, w.Federator.Stop)
+	tryUntil("stopping delivery workerpool", 5, w.Delivery.Stop)
 	tryUntil("stopping media workerpool", 5, w.Media.Stop)
 }
 
@@ -8,6 +8,8 @@ EXPECT=$(cat << "EOF"
     "accounts-allow-custom-css": true,
     "accounts-approval-required": false,
     "accounts-custom-css-length": 5000,
+    "accounts-invites-enabled": false,
+    "accounts-invites-moderators-only": true,
     "accounts-reason-required": false,
     "accounts-registration-open": true,
     "advanced-cookies-samesite": "strict",
@@ -29,8 +31,13 @@ EXPECT=$(cat << "EOF"
         "application-mem-ratio": 0.1,
         "block-mem-ratio": 3,
         "boost-of-ids-mem-ratio": 3,
+        "card-mem-ratio": 1,
+        "content-warning-policy-mem-ratio": 0.1,
         "emoji-category-mem-ratio": 0.1,
         "emoji-mem-ratio": 3,
+        "filter-keyword-mem-ratio": 0.5,
+        "filter-mem-ratio": 0.5,
+        "filter-status-mem-ratio": 0.5,
         "follow-ids-mem-ratio": 4,
         "follow-mem-ratio": 2,
         "follow-request-ids-mem-ratio": 2,
@@ -55,9 +62,15 @@ EXPECT=$(cat << "EOF"
         "thread-mute-mem-ratio": 0.2,
         "tombstone-mem-ratio": 0.5,
         "user-mem-ratio": 0.25,
+        "user-mute-mem-ratio": 2,
         "visibility-mem-ratio": 2,
         "webfinger-mem-ratio": 0.1
     },
+    "cards-deny-domains": [
+        "example.org",
+        "example.net"
+    ],
+    "cards-enabled": false,
     "config-path": "internal/config/testdata/test.yaml",
     "db-address": ":memory:",
     "db-database": "gotosocial_prod",
@@ -92,6 +105,8 @@ EXPECT=$(cat << "EOF"
         "nl",
         "en-GB"
     ],
+    "instance-subscriptions-process-every": 86400000000000,
+    "instance-trends-refresh-every": 900000000000,
     "landing-page-user": "admin",
     "letsencrypt-cert-dir": "/gotosocial/storage/certs",
     "letsencrypt-email-address": "",
@@ -212,6 +227,7 @@ GTS_INSTANCE_FEDERATION_MODE='allowlist' \
 GTS_INSTANCE_DELIVER_TO_SHARED_INBOXES=false \
 GTS_INSTANCE_INJECT_MASTODON_VERSION=true \
 GTS_INSTANCE_LANGUAGES="nl,en-gb" \
+GTS_INSTANCE_SUBSCRIPTIONS_PROCESS_EVERY="24h" \
 GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
 GTS_ACCOUNTS_REGISTRATION_OPEN=true \
@@ -239,6 +255,8 @@ GTS_STATUSES_CW_MAX_CHARS=420 \
 GTS_STATUSES_POLL_MAX_OPTIONS=1 \
 GTS_STATUSES_POLL_OPTIONS_MAX_CHARS=69 \
 GTS_STATUSES_MEDIA_MAX_FILES=1 \
+GTS_CARDS_ENABLED=false \
+GTS_CARDS_DENY_DOMAINS='example.org,example.net' \
 GTS_LETS_ENCRYPT_ENABLED=false \
 GTS_LETS_ENCRYPT_PORT=8080 \
 GTS_LETS_ENCRYPT_CERT_DIR='/root/certs' \
@@ -0,0 +1,39 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package testrig
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/cards"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// NewCardFetcher returns a noop preview card fetcher that won't make any remote calls.
+//
+// If fetchedStatuses is not nil, the noop callback function will place statuses
+// it was asked to fetch a card for in the map, with the ID of the status as the key.
+func NewCardFetcher(fetchedStatuses map[string]*gtsmodel.Status) cards.Fetcher {
+	var fetchCallback func(status *gtsmodel.Status)
+
+	if fetchedStatuses != nil {
+		fetchCallback = func(status *gtsmodel.Status) {
+			fetchedStatuses[status.ID] = status
+		}
+	}
+
+	return cards.NewNoopFetcher(fetchCallback)
+}
@@ -77,12 +77,15 @@ var testDefaults = config.Configuration{
 			TagStr: "en-gb",
 		},
 	},
+	InstanceSubscriptionsProcessEvery: 24 * time.Hour,
 
-	AccountsRegistrationOpen: true,
-	AccountsApprovalRequired: true,
-	AccountsReasonRequired:   true,
-	AccountsAllowCustomCSS:   true,
-	AccountsCustomCSSLength:  10000,
+	AccountsRegistrationOpen:      true,
+	AccountsApprovalRequired:      true,
+	AccountsReasonRequired:        true,
+	AccountsInvitesEnabled:        false,
+	AccountsInvitesModeratorsOnly: true,
+	AccountsAllowCustomCSS:        true,
+	AccountsCustomCSSLength:       10000,
 
 	MediaImageMaxSize:        10485760, // 10mb
 	MediaVideoMaxSize:        41943040, // 40mb
@@ -106,6 +109,9 @@ var testDefaults = config.Configuration{
 	StatusesPollOptionMaxChars: 50,
 	StatusesMediaMaxFiles:      6,
 
+	CardsEnabled:     true,
+	CardsDenyDomains: []string{},
+
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         0,
 	LetsEncryptCertDir:      "",
@@ -35,7 +35,12 @@ var testModels = []interface{}{
 	&gtsmodel.AccountToEmoji{},
 	&gtsmodel.Application{},
 	&gtsmodel.Block{},
+	&gtsmodel.Card{},
+	&gtsmodel.Conversation{},
+	&gtsmodel.ConversationToStatus{},
 	&gtsmodel.DomainBlock{},
+	&gtsmodel.DomainPermissionSubscription{},
+	&gtsmodel.Delivery{},
 	&gtsmodel.EmailDomainBlock{},
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
@@ -51,13 +56,23 @@ var testModels = []interface{}{
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusFave{},
 	&gtsmodel.StatusBookmark{},
+	&gtsmodel.StatusEdit{},
 	&gtsmodel.Tag{},
 	&gtsmodel.Thread{},
 	&gtsmodel.ThreadMute{},
 	&gtsmodel.ThreadToStatus{},
 	&gtsmodel.User{},
+	&gtsmodel.UserMute{},
+	&gtsmodel.FollowedTag{},
+	&gtsmodel.FeaturedTag{},
+	&gtsmodel.Trend{},
+	&gtsmodel.PinnedSuggestion{},
+	&gtsmodel.DismissedSuggestion{},
+	&gtsmodel.ContentWarningPolicy{},
 	&gtsmodel.Emoji{},
 	&gtsmodel.Instance{},
+	&gtsmodel.Invite{},
+	&gtsmodel.ScheduledStatus{},
 	&gtsmodel.Notification{},
 	&gtsmodel.RouterSession{},
 	&gtsmodel.Token{},
@@ -67,6 +82,12 @@ var testModels = []interface{}{
 	&gtsmodel.Report{},
 	&gtsmodel.Rule{},
 	&gtsmodel.AccountNote{},
+	&gtsmodel.Filter{},
+	&gtsmodel.FilterKeyword{},
+	&gtsmodel.FilterStatus{},
+	&gtsmodel.VAPIDKeyPair{},
+	&gtsmodel.WebPushSubscription{},
+	&gtsmodel.WebAuthnCredential{},
 }
 
 // NewTestDB returns a new initialized, empty database for testing.
@@ -177,6 +198,18 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestDomainPermissionSubscriptions() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestInvites() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
 	for _, v := range NewTestInstances() {
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(nil, err)
@@ -329,6 +362,24 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestFilters() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestFilterKeywords() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	for _, v := range NewTestFilterStatuses() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
 	if err := db.CreateInstanceAccount(ctx); err != nil {
 		log.Panic(nil, err)
 	}
@@ -1358,6 +1358,60 @@ func NewTestDomainBlocks() map[string]*gtsmodel.DomainBlock {
 			PrivateComment:     "i blocked this domain because they keep replying with pushy + unwarranted linux advice",
 			PublicComment:      "reply-guying to tech posts",
 			Obfuscate:          util.Ptr(false),
+			Severity:           gtsmodel.DomainBlockSeveritySuspend,
+			RejectMedia:        util.Ptr(false),
+			RejectReports:      util.Ptr(false),
+		},
+	}
+}
+
+func NewTestDomainPermissionSubscriptions() map[string]*gtsmodel.DomainPermissionSubscription {
+	return map[string]*gtsmodel.DomainPermissionSubscription{
+		"admin_account_block_1": {
+			ID:                 "01JGE681TQSBPAV59GZXPKE62H",
+			CreatedAt:          TimeMustParse("2024-01-30T11:27:08+01:00"),
+			UpdatedAt:          TimeMustParse("2024-01-30T11:27:08+01:00"),
+			Priority:           255,
+			Title:              "really cool list of baddies",
+			PermissionType:     gtsmodel.DomainPermissionBlock,
+			AdoptOrphans:       util.Ptr(false),
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			URI:                "https://lists.example.org/baddies.csv",
+			ContentType:        gtsmodel.DomainPermSubContentTypeCSV,
+		},
+	}
+}
+
+// NewTestInvites returns a map of invites keyed according to which account created them.
+func NewTestInvites() map[string]*gtsmodel.Invite {
+	return map[string]*gtsmodel.Invite{
+		"admin_account_invite_1": {
+			ID:        "01HPG7CBFXZ5G5TQFV3GJ0ANVW",
+			CreatedAt: TimeMustParse("2024-02-12T10:00:00+01:00"),
+			UpdatedAt: TimeMustParse("2024-02-12T10:00:00+01:00"),
+			Code:      "9D7S4AB2YKQPXH3M",
+			AccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			MaxUses:   5,
+			Uses:      0,
+		},
+		"admin_account_invite_expired": {
+			ID:        "01HPG7D6RJ9Z2V5Y8H1Q2W3E4R",
+			CreatedAt: TimeMustParse("2024-02-01T10:00:00+01:00"),
+			UpdatedAt: TimeMustParse("2024-02-01T10:00:00+01:00"),
+			Code:      "EXP1R3DC0D3XXXXX",
+			AccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			MaxUses:   0,
+			Uses:      0,
+			ExpiresAt: TimeMustParse("2024-02-02T10:00:00+01:00"),
+		},
+		"local_account_1_invite_1": {
+			ID:        "01HPG7E2M4N6P8R0T2V4X6Z8B0",
+			CreatedAt: TimeMustParse("2024-02-12T11:00:00+01:00"),
+			UpdatedAt: TimeMustParse("2024-02-12T11:00:00+01:00"),
+			Code:      "ZK3Q9W7E5R1T8Y2U",
+			AccountID: "01F8MH1H7YV1Z7D2C8K2730QBF",
+			MaxUses:   1,
+			Uses:      0,
 		},
 	}
 }
@@ -2537,6 +2591,51 @@ func NewTestMarkers() map[string]*gtsmodel.Marker {
 	}
 }
 
+func NewTestFilters() map[string]*gtsmodel.Filter {
+	return map[string]*gtsmodel.Filter{
+		"local_account_1_filter_1": {
+			ID:                   "01HN26VM6KZTW1ANNRVSBMA461",
+			CreatedAt:            TimeMustParse("2024-01-25T12:20:03+02:00"),
+			UpdatedAt:            TimeMustParse("2024-01-25T12:20:03+02:00"),
+			AccountID:            "01F8MH1H7YV1Z7D2C8K2730QBF",
+			Title:                "fnord",
+			Action:               gtsmodel.FilterActionWarn,
+			ContextHome:          util.Ptr(true),
+			ContextNotifications: util.Ptr(false),
+			ContextPublic:        util.Ptr(true),
+			ContextThread:        util.Ptr(false),
+			ContextAccount:       util.Ptr(false),
+		},
+	}
+}
+
+func NewTestFilterKeywords() map[string]*gtsmodel.FilterKeyword {
+	return map[string]*gtsmodel.FilterKeyword{
+		"local_account_1_filter_1_keyword_1": {
+			ID:        "01HN272TAVWAXX72ZX4M8JZ0PS",
+			CreatedAt: TimeMustParse("2024-01-25T12:20:03+02:00"),
+			UpdatedAt: TimeMustParse("2024-01-25T12:20:03+02:00"),
+			AccountID: "01F8MH1H7YV1Z7D2C8K2730QBF",
+			FilterID:  "01HN26VM6KZTW1ANNRVSBMA461",
+			Keyword:   "fnord",
+			WholeWord: util.Ptr(true),
+		},
+	}
+}
+
+func NewTestFilterStatuses() map[string]*gtsmodel.FilterStatus {
+	return map[string]*gtsmodel.FilterStatus{
+		"local_account_1_filter_1_status_1": {
+			ID:        "01HN277FSPQAWXZXK92QPPYF79",
+			CreatedAt: TimeMustParse("2024-01-25T12:20:03+02:00"),
+			UpdatedAt: TimeMustParse("2024-01-25T12:20:03+02:00"),
+			AccountID: "01F8MH1H7YV1Z7D2C8K2730QBF",
+			FilterID:  "01HN26VM6KZTW1ANNRVSBMA461",
+			StatusID:  "01HEN2QRFA8H3C6QPN7RD4KSR6",
+		},
+	}
+}
+
 func NewTestBlocks() map[string]*gtsmodel.Block {
 	return map[string]*gtsmodel.Block{
 		"local_account_2_block_remote_account_1": {
@@ -128,6 +128,8 @@ func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relat
 			responseCode, responseBytes, responseContentType, responseContentLength = WebfingerResponse(req)
 		} else if strings.Contains(reqURLString, ".well-known/host-meta") {
 			responseCode, responseBytes, responseContentType, responseContentLength = HostMetaResponse(req)
+		} else if strings.HasPrefix(reqURLString, "https://lists.example.org/") {
+			responseCode, responseBytes, responseContentType, responseContentLength = DomainPermissionListResponse(req)
 		} else if note, ok := mockHTTPClient.TestRemoteStatuses[reqURLString]; ok {
 			// the request is for a note that we have stored
 			noteI, err := streams.Serialize(note)
@@ -298,6 +300,56 @@ func HostMetaResponse(req *http.Request) (responseCode int, responseBytes []byte
 	return
 }
 
+// DomainPermissionListResponse serves domain permission
+// lists in each of the supported formats, for testing
+// domain permission subscriptions.
+func DomainPermissionListResponse(req *http.Request) (responseCode int, responseBytes []byte, responseContentType string, responseContentLength int) {
+	switch req.URL.String() {
+	case "https://lists.example.org/baddies.csv":
+		responseCode = http.StatusOK
+		responseContentType = "text/csv"
+		responseBytes = []byte(`#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate
+bumfaces.net,suspend,false,false,big jerks,false
+peepee.poopoo,suspend,false,false,harassment,false
+nothanks.com,suspend,false,false,,false
+silenced.example.org,silence,false,false,not that bad,false`)
+
+	case "https://lists.example.org/baddies.json":
+		responseCode = http.StatusOK
+		responseContentType = applicationJSON
+		responseBytes = []byte(`[
+  {
+    "domain": "bumfaces.net",
+    "public_comment": "big jerks"
+  },
+  {
+    "domain": "peepee.poopoo",
+    "public_comment": "harassment"
+  },
+  {
+    "domain": "nothanks.com"
+  }
+]`)
+
+	case "https://lists.example.org/baddies.txt":
+		responseCode = http.StatusOK
+		responseContentType = "text/plain"
+		responseBytes = []byte(`# some baddies
+bumfaces.net
+peepee.poopoo
+
+nothanks.com`)
+
+	default:
+		responseCode = http.StatusNotFound
+		responseContentType = applicationJSON
+		responseBytes = []byte(`{"error":"404 not found"}`)
+	}
+
+	responseContentLength = len(responseBytes)
+	return
+}
+
 func WebfingerResponse(req *http.Request) (responseCode int, responseBytes []byte, responseContentType string, responseContentLength int) {
 	var wfr *apimodel.WellKnownResponse
 
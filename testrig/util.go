@@ -47,6 +47,7 @@ func StartNoopWorkers(state *state.State) {
 	_ = state.Workers.Scheduler.Start()
 	_ = state.Workers.ClientAPI.Start(1, 10)
 	_ = state.Workers.Federator.Start(1, 10)
+	_ = state.Workers.Delivery.Start(1, 10)
 	_ = state.Workers.Media.Start(1, 10)
 }
 
@@ -61,6 +62,7 @@ func StartWorkers(state *state.State, wProcessor *wprocessor.Processor) {
 	_ = state.Workers.Scheduler.Start()
 	_ = state.Workers.ClientAPI.Start(1, 10)
 	_ = state.Workers.Federator.Start(1, 10)
+	_ = state.Workers.Delivery.Start(1, 10)
 	_ = state.Workers.Media.Start(1, 10)
 }
 
@@ -68,6 +70,7 @@ func StopWorkers(state *state.State) {
 	_ = state.Workers.Scheduler.Stop()
 	_ = state.Workers.ClientAPI.Stop()
 	_ = state.Workers.Federator.Stop()
+	_ = state.Workers.Delivery.Stop()
 	_ = state.Workers.Media.Stop()
 }
 
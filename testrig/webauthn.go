@@ -0,0 +1,251 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package testrig
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/superseriousbusiness/gotosocial/internal/webauthn"
+)
+
+// SoftwareAuthenticator is a WebAuthn authenticator implemented in
+// software, holding ES256 credentials in memory. It responds to
+// creation + request options as a browser would, for testing.
+type SoftwareAuthenticator struct {
+	// Origin reported in client data.
+	Origin string
+
+	// UserVerified sets whether the authenticator
+	// reports that the user was verified (PIN etc).
+	UserVerified bool
+
+	credentials []*softwareCredential
+}
+
+type softwareCredential struct {
+	id         []byte
+	rpID       string
+	userHandle []byte
+	key        *ecdsa.PrivateKey
+	signCount  uint32
+}
+
+// SoftwareAttestation is the response
+// of SoftwareAuthenticator.Create.
+type SoftwareAttestation struct {
+	ID                []byte
+	ClientDataJSON    []byte
+	AttestationObject []byte
+}
+
+// SoftwareAssertion is the response
+// of SoftwareAuthenticator.Get.
+type SoftwareAssertion struct {
+	ID                []byte
+	UserHandle        []byte
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+}
+
+// NewSoftwareAuthenticator returns a new software authenticator
+// with no credentials, reporting the configured instance origin.
+func NewSoftwareAuthenticator() *SoftwareAuthenticator {
+	return &SoftwareAuthenticator{
+		Origin:       webauthn.NewRelyingParty().Origin,
+		UserVerified: true,
+	}
+}
+
+// Create creates a new credential for given options,
+// as navigator.credentials.create() would.
+func (a *SoftwareAuthenticator) Create(options *webauthn.CreationOptions) *SoftwareAttestation {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+
+	credential := &softwareCredential{
+		id:         id,
+		rpID:       options.RP.ID,
+		userHandle: options.User.ID,
+		key:        key,
+	}
+	a.credentials = append(a.credentials, credential)
+
+	// COSE_Key of the public key.
+	var x, y [32]byte
+	key.X.FillBytes(x[:])
+	key.Y.FillBytes(y[:])
+	coseKey := cborMap(
+		cborInt(1), cborInt(2), // kty: EC2
+		cborInt(3), cborInt(webauthn.AlgES256), // alg
+		cborInt(-1), cborInt(1), // crv: P-256
+		cborInt(-2), cborBytes(x[:]),
+		cborInt(-3), cborBytes(y[:]),
+	)
+
+	// Attested credential data, with a zero aaguid.
+	attested := make([]byte, 16, 18+len(id)+len(coseKey))
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(id))) // #nosec G115 -- always 16.
+	attested = append(attested, id...)
+	attested = append(attested, coseKey...)
+
+	authData := a.authData(credential, 0x40)
+	authData = append(authData, attested...)
+
+	attestationObject := cborMap(
+		cborText("fmt"), cborText("none"),
+		cborText("attStmt"), cborMap(),
+		cborText("authData"), cborBytes(authData),
+	)
+
+	return &SoftwareAttestation{
+		ID:                id,
+		ClientDataJSON:    a.clientData("webauthn.create", options.Challenge),
+		AttestationObject: attestationObject,
+	}
+}
+
+// Get returns an assertion for given options, as navigator.credentials.get()
+// would, using the first allowed credential held. If options don't specify
+// allowed credentials, the first credential for the relying party is used.
+// Returns nil if the authenticator holds no suitable credential.
+func (a *SoftwareAuthenticator) Get(options *webauthn.RequestOptions) *SoftwareAssertion {
+	var credential *softwareCredential
+	for _, c := range a.credentials {
+		if c.rpID != options.RPID {
+			continue
+		}
+
+		if len(options.AllowCredentials) == 0 {
+			credential = c
+			break
+		}
+
+		for _, allowed := range options.AllowCredentials {
+			if bytes.Equal(allowed.ID, c.id) {
+				credential = c
+				break
+			}
+		}
+
+		if credential != nil {
+			break
+		}
+	}
+
+	if credential == nil {
+		return nil
+	}
+
+	credential.signCount++
+	authData := a.authData(credential, 0)
+	clientDataJSON := a.clientData("webauthn.get", options.Challenge)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(bytes.Clone(authData), clientDataHash[:]...)
+	digest := sha256.Sum256(signed)
+
+	signature, err := ecdsa.SignASN1(rand.Reader, credential.key, digest[:])
+	if err != nil {
+		panic(err)
+	}
+
+	return &SoftwareAssertion{
+		ID:                credential.id,
+		UserHandle:        credential.userHandle,
+		ClientDataJSON:    clientDataJSON,
+		AuthenticatorData: authData,
+		Signature:         signature,
+	}
+}
+
+func (a *SoftwareAuthenticator) authData(credential *softwareCredential, flags byte) []byte {
+	flags |= 0x01 // user present
+	if a.UserVerified {
+		flags |= 0x04
+	}
+
+	rpIDHash := sha256.Sum256([]byte(credential.rpID))
+	authData := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(authData, credential.signCount)
+}
+
+func (a *SoftwareAuthenticator) clientData(typ string, challenge []byte) []byte {
+	b, err := json.Marshal(map[string]any{
+		"type":      typ,
+		"challenge": base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":    a.Origin,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Minimal CBOR encoding, enough for the
+// attestation object and COSE key above.
+
+func cborHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(n)) // #nosec G115 -- test data is small.
+	}
+}
+
+func cborInt(i int) []byte {
+	if i < 0 {
+		return cborHead(1, uint64(-1-i)) // #nosec G115 -- checked above.
+	}
+	return cborHead(0, uint64(i))
+}
+
+func cborBytes(b []byte) []byte {
+	return append(cborHead(2, uint64(len(b))), b...)
+}
+
+func cborText(s string) []byte {
+	return append(cborHead(3, uint64(len(s))), s...)
+}
+
+func cborMap(kvs ...[]byte) []byte {
+	b := cborHead(5, uint64(len(kvs)/2))
+	for _, kv := range kvs {
+		b = append(b, kv...)
+	}
+	return b
+}
@@ -0,0 +1,39 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package testrig
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+)
+
+// NewWebPushSender returns a noop Web Push sender that won't make any remote calls.
+//
+// If pushedNotifications is not nil, the noop callback function will place pushed
+// notifications in the map, with the ID of the notification as the key.
+func NewWebPushSender(pushedNotifications map[string]*gtsmodel.Notification) webpush.Sender {
+	var sendCallback func(notification *gtsmodel.Notification)
+
+	if pushedNotifications != nil {
+		sendCallback = func(notification *gtsmodel.Notification) {
+			pushedNotifications[notification.ID] = notification
+		}
+	}
+
+	return webpush.NewNoopSender(sendCallback)
+}
@@ -64,6 +64,15 @@ skulk({
 				}]
 			],
 		},
+		webauthn: {
+			entryFile: "webauthn",
+			outputFile: "webauthn.js",
+			preset: ["js"],
+			prodCfg: prodCfg,
+			transform: [
+				["babelify", { global: true }]
+			],
+		},
 		settings: {
 			entryFile: "settings",
 			outputFile: "settings.js",
@@ -0,0 +1,73 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Sign in with WebAuthn credentials (passkeys + security keys),
+// for forms with class "webauthn-sign-in" on the sign in pages.
+//
+// Options are fetched from the server and passed to the browser,
+// then the browser's response is submitted with the (hidden) form.
+
+function decode(base64url) {
+	const base64 = base64url.replace(/-/g, "+").replace(/_/g, "/");
+	const binary = atob(base64.padEnd(Math.ceil(base64.length / 4) * 4, "="));
+	return Uint8Array.from(binary, (c) => c.charCodeAt(0));
+}
+
+function encode(buffer) {
+	const binary = String.fromCharCode(...new Uint8Array(buffer));
+	return btoa(binary).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+}
+
+async function signIn(form) {
+	const res = await fetch("/auth/webauthn/options", {
+		credentials: "same-origin",
+		headers: { "Accept": "application/json" },
+	});
+	if (!res.ok) {
+		throw new Error(`error getting options: ${res.status}`);
+	}
+
+	const options = await res.json();
+	options.challenge = decode(options.challenge);
+	options.allowCredentials = options.allowCredentials.map((credential) => {
+		return Object.assign({}, credential, { id: decode(credential.id) });
+	});
+
+	const credential = await navigator.credentials.get({ publicKey: options });
+
+	form.elements["credential_id"].value = encode(credential.rawId);
+	form.elements["client_data_json"].value = encode(credential.response.clientDataJSON);
+	form.elements["authenticator_data"].value = encode(credential.response.authenticatorData);
+	form.elements["signature"].value = encode(credential.response.signature);
+	form.submit();
+}
+
+if (window.PublicKeyCredential) {
+	Array.from(document.getElementsByClassName("webauthn-sign-in")).forEach((form) => {
+		const button = form.querySelector("button");
+		button.addEventListener("click", () => {
+			button.disabled = true;
+			signIn(form).catch((e) => {
+				console.error(e);
+				button.disabled = false;
+			});
+		});
+		form.hidden = false;
+	});
+}